@@ -1,16 +1,24 @@
 package api
 
 //go:generate moq -out ../mocks/mocks.go -pkg mocks . DownloadsGenerator
+//go:generate moq -out ../mocks/dataset_event_emitter_mocks.go -pkg mocks . DatasetEventEmitter
 
 import (
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/ONSdigital/dp-api-clients-go/headers"
 	"github.com/ONSdigital/dp-authorisation/auth"
+	"github.com/ONSdigital/dp-dataset-api/collection"
+	"github.com/ONSdigital/dp-dataset-api/codelist"
 	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/dimension"
 	"github.com/ONSdigital/dp-dataset-api/instance"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
 	"github.com/ONSdigital/dp-dataset-api/pagination"
 	"github.com/ONSdigital/dp-dataset-api/store"
 	"github.com/ONSdigital/dp-dataset-api/url"
@@ -45,6 +53,21 @@ type DownloadsGenerator interface {
 	Generate(ctx context.Context, datasetID, instanceID, edition, version string) error
 }
 
+// DatasetEventEmitter tells other services about changes to datasets
+type DatasetEventEmitter interface {
+	Emit(ctx context.Context, eventType, datasetID, edition, version, instanceID string) error
+}
+
+// CollectionPermissions checks whether a user can access the dataset resources associated with a collection
+type CollectionPermissions interface {
+	CanAccess(ctx context.Context, userAccessToken, datasetID, collectionID string) (bool, error)
+}
+
+// Collections provides the details of the collections that dataset versions are associated with
+type Collections interface {
+	GetCollection(ctx context.Context, collectionID string) (*collection.Collection, error)
+}
+
 // AuthHandler provides authorisation checks on requests
 type AuthHandler interface {
 	Require(required auth.Permissions, handler http.HandlerFunc) http.HandlerFunc
@@ -59,16 +82,29 @@ type DatasetAPI struct {
 	downloadServiceToken     string
 	EnablePrePublishView     bool
 	downloadGenerator        DownloadsGenerator
+	importRetrier            instance.ImportRetrier
+	eventEmitter             DatasetEventEmitter
+	collectionPermissions    CollectionPermissions
+	collections              Collections
 	enablePrivateEndpoints   bool
 	enableDetachDataset      bool
+	enableForwardedHostLinks bool
+	forwardedHosts           []string
 	datasetPermissions       AuthHandler
 	permissions              AuthHandler
 	instancePublishedChecker *instance.PublishCheck
 	versionPublishedChecker  *PublishCheck
+	cacheControl             config.CacheControlConfig
+	deleteJobThreshold       int64
+	deletedDatasetRetention  time.Duration
+	graphQLMaxDepth          int
+	graphQLMaxCost           int
+	cancelPurger             context.CancelFunc
+	purgerDone               chan struct{}
 }
 
 // Setup creates a new Dataset API instance and register the API routes based on the application configuration.
-func Setup(ctx context.Context, cfg *config.Configuration, router *mux.Router, dataStore store.DataStore, urlBuilder *url.Builder, downloadGenerator DownloadsGenerator, datasetPermissions AuthHandler, permissions AuthHandler) *DatasetAPI {
+func Setup(ctx context.Context, cfg *config.Configuration, router *mux.Router, dataStore store.DataStore, urlBuilder *url.Builder, downloadGenerator DownloadsGenerator, importRetrier instance.ImportRetrier, eventEmitter DatasetEventEmitter, collectionPermissions CollectionPermissions, collections Collections, codeListValidator *codelist.Validator, datasetPermissions AuthHandler, permissions AuthHandler) *DatasetAPI {
 
 	api := &DatasetAPI{
 		dataStore:                dataStore,
@@ -78,15 +114,30 @@ func Setup(ctx context.Context, cfg *config.Configuration, router *mux.Router, d
 		Router:                   router,
 		urlBuilder:               urlBuilder,
 		downloadGenerator:        downloadGenerator,
+		importRetrier:            importRetrier,
+		eventEmitter:             eventEmitter,
+		collectionPermissions:    collectionPermissions,
+		collections:              collections,
 		enablePrivateEndpoints:   cfg.EnablePrivateEndpoints,
 		enableDetachDataset:      cfg.EnableDetachDataset,
+		enableForwardedHostLinks: cfg.EnableForwardedHostLinks,
+		forwardedHosts:           cfg.ForwardedHosts,
 		datasetPermissions:       datasetPermissions,
 		permissions:              permissions,
 		versionPublishedChecker:  nil,
 		instancePublishedChecker: nil,
+		cacheControl:             cfg.CacheControlConfig,
+		deleteJobThreshold:       cfg.DeleteJobThreshold,
+		deletedDatasetRetention:  cfg.DeletionConfig.Retention,
+		graphQLMaxDepth:          cfg.GraphQLMaxDepth,
+		graphQLMaxCost:           cfg.GraphQLMaxCost,
 	}
 
 	paginator := pagination.NewPaginator(cfg.DefaultLimit, cfg.DefaultOffset, cfg.DefaultMaxLimit)
+	paginator.RenderLinks = api.renderLinks
+
+	api.get("/swagger.yaml", api.getSpec)
+	api.enableLatestAliases()
 
 	if api.enablePrivateEndpoints {
 		log.Event(ctx, "enabling private endpoints for dataset api", log.INFO)
@@ -103,15 +154,20 @@ func Setup(ctx context.Context, cfg *config.Configuration, router *mux.Router, d
 			Host:                api.host,
 			Storer:              api.dataStore.Backend,
 			EnableDetachDataset: api.enableDetachDataset,
+			ImportRetrier:       api.importRetrier,
+			CodeListValidator:   codeListValidator,
+			BaseURL:             api.baseURL,
 		}
 
 		dimensionAPI := &dimension.Store{
-			Storer: api.dataStore.Backend,
+			Storer:            api.dataStore.Backend,
+			CodeListValidator: codeListValidator,
 		}
 
 		api.enablePrivateDatasetEndpoints(ctx, paginator)
 		api.enablePrivateInstancesEndpoints(instanceAPI, paginator)
 		api.enablePrivateDimensionsEndpoints(dimensionAPI, paginator)
+		api.enablePrivateTopicsEndpoints(paginator)
 	} else {
 		log.Event(ctx, "enabling only public endpoints for dataset api", log.INFO)
 		api.enablePublicEndpoints(ctx, paginator)
@@ -122,14 +178,20 @@ func Setup(ctx context.Context, cfg *config.Configuration, router *mux.Router, d
 // enablePublicEndpoints register only the public GET endpoints.
 func (api *DatasetAPI) enablePublicEndpoints(ctx context.Context, paginator *pagination.Paginator) {
 	api.get("/datasets", paginator.Paginate(api.getDatasets))
-	api.get("/datasets/{dataset_id}", api.getDataset)
+	api.get("/datasets/{dataset_id}", api.cacheable(api.cacheControl.DatasetMaxAge, api.getDataset))
 	api.get("/datasets/{dataset_id}/editions", paginator.Paginate(api.getEditions))
-	api.get("/datasets/{dataset_id}/editions/{edition}", api.getEdition)
+	api.get("/datasets/{dataset_id}/editions/{edition}", api.cacheable(api.cacheControl.EditionMaxAge, api.getEdition))
 	api.get("/datasets/{dataset_id}/editions/{edition}/versions", paginator.Paginate(api.getVersions))
-	api.get("/datasets/{dataset_id}/editions/{edition}/versions/{version}", api.getVersion)
-	api.get("/datasets/{dataset_id}/editions/{edition}/versions/{version}/metadata", api.getMetadata)
-	api.get("/datasets/{dataset_id}/editions/{edition}/versions/{version}/dimensions", paginator.Paginate(api.getDimensions))
-	api.get("/datasets/{dataset_id}/editions/{edition}/versions/{version}/dimensions/{dimension}/options", paginator.Paginate(api.getDimensionOptions))
+	api.get("/datasets/{dataset_id}/editions/{edition}/versions/{version}", api.cacheable(api.cacheControl.VersionMaxAge, api.getVersion))
+	api.get("/datasets/{dataset_id}/editions/{edition}/versions/{version}/metadata", api.cacheable(api.cacheControl.MetadataMaxAge, api.getMetadata))
+	api.get("/datasets/{dataset_id}/editions/{edition}/versions/{version}/dimensions", api.cacheable(api.cacheControl.DimensionsMaxAge, paginator.Paginate(api.getDimensions)))
+	api.get("/datasets/{dataset_id}/editions/{edition}/versions/{version}/dimensions/{dimension}/options", api.cacheable(api.cacheControl.DimensionOptionsMaxAge, paginator.Paginate(api.getDimensionOptions)))
+	api.get("/datasets/{dataset_id}/related", api.getRelatedDatasets)
+	api.get("/topics", api.cacheable(api.cacheControl.TopicsMaxAge, paginator.Paginate(api.getTopics)))
+	api.get("/topics/{topic_id}", api.cacheable(api.cacheControl.TopicsMaxAge, api.getTopic))
+	api.get("/topics/{topic_id}/datasets", api.cacheable(api.cacheControl.TopicsMaxAge, paginator.Paginate(api.getTopicDatasets)))
+	api.post("/graphql", api.graphQL(paginator))
+	api.post("/versions/batch", api.isValidRequest(api.getVersionBatch))
 
 }
 
@@ -144,7 +206,12 @@ func (api *DatasetAPI) enablePrivateDatasetEndpoints(ctx context.Context, pagina
 	api.get(
 		"/datasets/{dataset_id}",
 		api.isAuthorisedForDatasets(readPermission,
-			api.getDataset),
+			api.cacheable(api.cacheControl.DatasetMaxAge, api.getDataset)),
+	)
+
+	api.get(
+		"/datasets/{dataset_id}/related",
+		api.isAuthorisedForDatasets(readPermission, api.getRelatedDatasets),
 	)
 
 	api.get(
@@ -155,7 +222,7 @@ func (api *DatasetAPI) enablePrivateDatasetEndpoints(ctx context.Context, pagina
 	api.get(
 		"/datasets/{dataset_id}/editions/{edition}",
 		api.isAuthorisedForDatasets(readPermission,
-			api.getEdition),
+			api.cacheable(api.cacheControl.EditionMaxAge, api.getEdition)),
 	)
 
 	api.get(
@@ -167,39 +234,49 @@ func (api *DatasetAPI) enablePrivateDatasetEndpoints(ctx context.Context, pagina
 	api.get(
 		"/datasets/{dataset_id}/editions/{edition}/versions/{version}",
 		api.isAuthorisedForDatasets(readPermission,
-			api.getVersion),
+			api.cacheable(api.cacheControl.VersionMaxAge, api.getVersion)),
 	)
 
 	api.get(
 		"/datasets/{dataset_id}/editions/{edition}/versions/{version}/metadata",
 		api.isAuthorisedForDatasets(readPermission,
-			api.getMetadata),
+			api.cacheable(api.cacheControl.MetadataMaxAge, api.getMetadata)),
 	)
 
 	api.get(
 		"/datasets/{dataset_id}/editions/{edition}/versions/{version}/dimensions",
 		api.isAuthorisedForDatasets(readPermission,
-			paginator.Paginate(api.getDimensions)),
+			api.cacheable(api.cacheControl.DimensionsMaxAge, paginator.Paginate(api.getDimensions))),
 	)
 
 	api.get(
 		"/datasets/{dataset_id}/editions/{edition}/versions/{version}/dimensions/{dimension}/options",
 		api.isAuthorisedForDatasets(readPermission,
-			paginator.Paginate(api.getDimensionOptions)),
+			api.cacheable(api.cacheControl.DimensionOptionsMaxAge, paginator.Paginate(api.getDimensionOptions))),
+	)
+
+	api.post(
+		"/graphql",
+		api.isAuthorised(readPermission, api.graphQL(paginator)),
+	)
+
+	api.post(
+		"/versions/batch",
+		api.isAuthorised(readPermission, api.isValidRequest(api.getVersionBatch)),
 	)
 
 	api.post(
 		"/datasets/{dataset_id}",
 		api.isAuthenticated(
 			api.isAuthorisedForDatasets(createPermission,
-				api.addDataset)),
+				api.isValidRequest(api.addDataset))),
 	)
 
 	api.put(
 		"/datasets/{dataset_id}",
 		api.isAuthenticated(
 			api.isAuthorisedForDatasets(updatePermission,
-				api.putDataset)),
+				api.isValidRequest(api.putDataset))),
 	)
 
 	api.delete(
@@ -209,12 +286,83 @@ func (api *DatasetAPI) enablePrivateDatasetEndpoints(ctx context.Context, pagina
 				api.deleteDataset)),
 	)
 
+	api.post(
+		"/datasets/{dataset_id}/restore",
+		api.isAuthenticated(
+			api.isAuthorisedForDatasets(updatePermission,
+				api.restoreDataset)),
+	)
+
+	api.post(
+		"/datasets/{dataset_id}/editions/{edition}",
+		api.isAuthenticated(
+			api.isAuthorisedForDatasets(createPermission,
+				api.addEdition)),
+	)
+
+	api.put(
+		"/datasets/{dataset_id}/editions/{edition}",
+		api.isAuthenticated(
+			api.isAuthorisedForDatasets(updatePermission,
+				api.putEdition)),
+	)
+
+	api.delete(
+		"/datasets/{dataset_id}/editions/{edition}",
+		api.isAuthenticated(
+			api.isAuthorisedForDatasets(deletePermission,
+				api.deleteEdition)),
+	)
+
+	api.get(
+		"/delete-jobs/{job_id}",
+		api.isAuthenticated(
+			api.isAuthorised(readPermission,
+				api.getDeleteJob)),
+	)
+
+	api.get(
+		"/orphaned-versions",
+		api.isAuthenticated(
+			api.isAuthorised(readPermission,
+				paginator.Paginate(api.getOrphanedVersions))),
+	)
+
+	api.get(
+		"/link-reports",
+		api.isAuthenticated(
+			api.isAuthorised(readPermission,
+				paginator.Paginate(api.getLinkReports))),
+	)
+
+	api.get(
+		"/link-reports/{dataset_id}",
+		api.isAuthenticated(
+			api.isAuthorised(readPermission,
+				api.getLinkReport)),
+	)
+
+	api.post(
+		"/cache/flush",
+		api.isAuthenticated(
+			api.isAuthorised(updatePermission,
+				api.flushCache)),
+	)
+
 	api.put(
 		"/datasets/{dataset_id}/editions/{edition}/versions/{version}",
 		api.isAuthenticated(
 			api.isAuthorisedForDatasets(updatePermission,
-				api.isVersionPublished(updateVersionAction,
-					api.putVersion))),
+				api.isValidRequest(
+					api.isVersionPublished(updateVersionAction,
+						api.putVersion)))),
+	)
+
+	api.post(
+		"/datasets/{dataset_id}/editions/{edition}/versions/{version}/withdraw",
+		api.isAuthenticated(
+			api.isAuthorisedForDatasets(updatePermission,
+				api.withdrawVersion)),
 	)
 
 	if api.enableDetachDataset {
@@ -241,7 +389,7 @@ func (api *DatasetAPI) enablePrivateInstancesEndpoints(instanceAPI *instance.Sto
 		"/instances",
 		api.isAuthenticated(
 			api.isAuthorised(createPermission,
-				instanceAPI.Add)),
+				api.isValidRequest(instanceAPI.Add))),
 	)
 
 	api.get(
@@ -255,35 +403,87 @@ func (api *DatasetAPI) enablePrivateInstancesEndpoints(instanceAPI *instance.Sto
 		"/instances/{instance_id}",
 		api.isAuthenticated(
 			api.isAuthorised(updatePermission,
-				api.isInstancePublished(instanceAPI.Update))),
+				api.isValidRequest(
+					api.isInstancePublished(instanceAPI.Update)))),
 	)
 
 	api.put(
 		"/instances/{instance_id}/dimensions/{dimension}",
 		api.isAuthenticated(
 			api.isAuthorised(updatePermission,
-				api.isInstancePublished(instanceAPI.UpdateDimension))),
+				api.isValidRequest(
+					api.isInstancePublished(instanceAPI.UpdateDimension)))),
 	)
 
 	api.post(
 		"/instances/{instance_id}/events",
 		api.isAuthenticated(
 			api.isAuthorised(createPermission,
-				instanceAPI.AddEvent)),
+				api.isValidRequest(instanceAPI.AddEvent))),
 	)
 
 	api.put(
 		"/instances/{instance_id}/inserted_observations/{inserted_observations}",
 		api.isAuthenticated(
 			api.isAuthorised(updatePermission,
-				api.isInstancePublished(instanceAPI.UpdateObservations))),
+				api.isValidRequest(
+					api.isInstancePublished(instanceAPI.UpdateObservations)))),
 	)
 
 	api.put(
 		"/instances/{instance_id}/import_tasks",
 		api.isAuthenticated(
 			api.isAuthorised(updatePermission,
-				api.isInstancePublished(instanceAPI.UpdateImportTask))),
+				api.isValidRequest(
+					api.isInstancePublished(instanceAPI.UpdateImportTask)))),
+	)
+
+	api.post(
+		"/instances/{instance_id}/import_tasks/retry",
+		api.isAuthenticated(
+			api.isAuthorised(updatePermission,
+				api.isValidRequest(
+					api.isInstancePublished(instanceAPI.RetryImportTasks)))),
+	)
+}
+
+// enablePrivateTopicsEndpoints register the topics endpoints with the appropriate authentication and authorisation
+// checks required when running the dataset API in publishing (private) mode.
+func (api *DatasetAPI) enablePrivateTopicsEndpoints(paginator *pagination.Paginator) {
+	api.get(
+		"/topics",
+		api.isAuthorised(readPermission, paginator.Paginate(api.getTopics)),
+	)
+
+	api.get(
+		"/topics/{topic_id}",
+		api.isAuthorised(readPermission, api.getTopic),
+	)
+
+	api.get(
+		"/topics/{topic_id}/datasets",
+		api.isAuthorised(readPermission, paginator.Paginate(api.getTopicDatasets)),
+	)
+
+	api.post(
+		"/topics/{topic_id}",
+		api.isAuthenticated(
+			api.isAuthorised(createPermission,
+				api.addTopic)),
+	)
+
+	api.put(
+		"/topics/{topic_id}",
+		api.isAuthenticated(
+			api.isAuthorised(updatePermission,
+				api.putTopic)),
+	)
+
+	api.delete(
+		"/topics/{topic_id}",
+		api.isAuthenticated(
+			api.isAuthorised(deletePermission,
+				api.deleteTopic)),
 	)
 }
 
@@ -301,9 +501,20 @@ func (api *DatasetAPI) enablePrivateDimensionsEndpoints(dimensionAPI *dimension.
 		"/instances/{instance_id}/dimensions",
 		api.isAuthenticated(
 			api.isAuthorised(createPermission,
-				api.isInstancePublished(dimensionAPI.AddHandler))),
+				api.isValidRequest(
+					api.isInstancePublished(dimensionAPI.AddHandler)))),
 	)
 
+	// the code lists of dimensions can only be reported on when there is a code list API to check them against
+	if dimensionAPI.CodeListValidator != nil {
+		api.get(
+			"/instances/{instance_id}/dimensions/validation",
+			api.isAuthenticated(
+				api.isAuthorised(readPermission,
+					dimensionAPI.GetValidationReportHandler)),
+		)
+	}
+
 	api.get(
 		"/instances/{instance_id}/dimensions/{dimension}/options",
 		api.isAuthenticated(
@@ -315,7 +526,8 @@ func (api *DatasetAPI) enablePrivateDimensionsEndpoints(dimensionAPI *dimension.
 		"/instances/{instance_id}/dimensions/{dimension}/options/{option}",
 		api.isAuthenticated(
 			api.isAuthorised(updatePermission,
-				api.isInstancePublished(dimensionAPI.PatchOptionHandler))),
+				api.isValidRequest(
+					api.isInstancePublished(dimensionAPI.PatchOptionHandler)))),
 	)
 
 	// Deprecated
@@ -323,7 +535,8 @@ func (api *DatasetAPI) enablePrivateDimensionsEndpoints(dimensionAPI *dimension.
 		"/instances/{instance_id}/dimensions/{dimension}/options/{option}/node_id/{node_id}",
 		api.isAuthenticated(
 			api.isAuthorised(updatePermission,
-				api.isInstancePublished(dimensionAPI.AddNodeIDHandler))),
+				api.isValidRequest(
+					api.isInstancePublished(dimensionAPI.AddNodeIDHandler)))),
 	)
 }
 
@@ -363,27 +576,27 @@ func (api *DatasetAPI) isVersionPublished(action string, handler http.HandlerFun
 
 // get registers a GET http.HandlerFunc.
 func (api *DatasetAPI) get(path string, handler http.HandlerFunc) {
-	api.Router.HandleFunc(path, handler).Methods(http.MethodGet)
+	api.Router.HandleFunc(path, api.varyByForwardedHost(handler)).Methods(http.MethodGet)
 }
 
 // put registers a PUT http.HandlerFunc.
 func (api *DatasetAPI) put(path string, handler http.HandlerFunc) {
-	api.Router.HandleFunc(path, handler).Methods(http.MethodPut)
+	api.Router.HandleFunc(path, api.varyByForwardedHost(handler)).Methods(http.MethodPut)
 }
 
 // patch registers a PATCH http.HandlerFunc
 func (api *DatasetAPI) patch(path string, handler http.HandlerFunc) {
-	api.Router.HandleFunc(path, handler).Methods(http.MethodPatch)
+	api.Router.HandleFunc(path, api.varyByForwardedHost(handler)).Methods(http.MethodPatch)
 }
 
 // post registers a POST http.HandlerFunc.
 func (api *DatasetAPI) post(path string, handler http.HandlerFunc) {
-	api.Router.HandleFunc(path, handler).Methods(http.MethodPost)
+	api.Router.HandleFunc(path, api.varyByForwardedHost(handler)).Methods(http.MethodPost)
 }
 
 // delete registers a DELETE http.HandlerFunc.
 func (api *DatasetAPI) delete(path string, handler http.HandlerFunc) {
-	api.Router.HandleFunc(path, handler).Methods(http.MethodDelete)
+	api.Router.HandleFunc(path, api.varyByForwardedHost(handler)).Methods(http.MethodDelete)
 }
 
 func (api *DatasetAPI) authenticate(r *http.Request, logData log.Data) bool {
@@ -406,6 +619,7 @@ func (api *DatasetAPI) authenticate(r *http.Request, logData log.Data) bool {
 
 		if hasCallerIdentity || hasUserIdentity {
 			authorised = true
+			recordAuthentication(r)
 		}
 		logData["authenticated"] = authorised
 
@@ -414,6 +628,122 @@ func (api *DatasetAPI) authenticate(r *http.Request, logData log.Data) bool {
 	return authorised
 }
 
+// canAccessCollection returns true if the authenticated caller of a request can view the unpublished resources of a
+// dataset associated with the provided collection. Services can view every unpublished resource, whereas users can
+// only view those associated with collections they have access to. Unpublished resources that are not yet associated
+// with a collection are only visible to users with permissions on the dataset itself.
+func (api *DatasetAPI) canAccessCollection(r *http.Request, datasetID, collectionID string, logData log.Data) bool {
+	if !api.authenticate(r, logData) {
+		return false
+	}
+
+	if isServiceRequest(r) {
+		return true
+	}
+
+	ctx := r.Context()
+	userAccessToken, err := dphandlers.GetFlorenceToken(ctx, r)
+	if err != nil {
+		log.Event(ctx, "failed to read user access token from request", log.ERROR, log.Error(err), logData)
+		return false
+	}
+	if userAccessToken == "" {
+		log.Event(ctx, "request is neither from a service nor a user", log.WARN, logData)
+		return false
+	}
+
+	// Zebedee grants the permissions on a dataset that are not granted through a collection when none is provided
+	canAccess, err := api.collectionPermissions.CanAccess(ctx, userAccessToken, datasetID, collectionID)
+	if err != nil {
+		logData["collection_id"] = collectionID
+		log.Event(ctx, "failed to check user access to collection", log.ERROR, log.Error(err), logData)
+		return false
+	}
+	logData["collection_access"] = canAccess
+
+	return canAccess
+}
+
+// authenticateForDataset returns true if the caller of a request is authenticated and can view the unpublished
+// resources of the dataset, which include its unpublished editions
+func (api *DatasetAPI) authenticateForDataset(r *http.Request, datasetID string, logData log.Data) (bool, error) {
+	if !api.authenticate(r, logData) {
+		return false, nil
+	}
+
+	if isServiceRequest(r) {
+		return true, nil
+	}
+
+	dataset, err := api.dataStore.Backend.GetDataset(r.Context(), datasetID)
+	if err != nil {
+		return false, err
+	}
+
+	return api.canAccessCollection(r, datasetID, nextCollectionID(dataset), logData), nil
+}
+
+// isServiceRequest returns true for requests from other services, which are identified by a service auth token and
+// made without a user access token
+func isServiceRequest(r *http.Request) bool {
+	if dprequest.Caller(r.Context()) == "" {
+		return false
+	}
+
+	if userAccessToken, err := dphandlers.GetFlorenceToken(r.Context(), r); err != nil || userAccessToken != "" {
+		return false
+	}
+
+	serviceAuthToken, err := headers.GetServiceAuthToken(r)
+	return err == nil && serviceAuthToken != ""
+}
+
+// nextCollectionID returns the collection the unpublished changes to a dataset are associated with
+func nextCollectionID(dataset *models.DatasetUpdate) string {
+	if dataset == nil || dataset.Next == nil || dataset.Next.State == models.PublishedState {
+		return ""
+	}
+	return dataset.Next.CollectionID
+}
+
 func setJSONContentType(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json")
 }
+
+// isDownloadService returns true if the request was sent by the download service, which is given the storage locations
+// of the downloads of versions. The request is recorded as authenticated, so that the response is never cached.
+func (api *DatasetAPI) isDownloadService(r *http.Request) bool {
+	if r.Header.Get(downloadServiceToken) != api.downloadServiceToken {
+		return false
+	}
+	recordAuthentication(r)
+	return true
+}
+
+// getIfMatch returns the stored eTag the If-Match header of the request matches, without the quotes and weak
+// indicator of the header, or AnyETag where there is no header
+func getIfMatch(r *http.Request) string {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		return mongo.AnyETag
+	}
+	return unquoteETag(ifMatch)
+}
+
+// setETag sets the ETag header to the quoted stored eTag of the document, as RFC 7232 requires
+func setETag(w http.ResponseWriter, eTag string) {
+	if eTag == "" {
+		return
+	}
+	w.Header().Set("ETag", `"`+eTag+`"`)
+}
+
+// unquoteETag returns the value of an entity tag, without its weak indicator or quotes. Unquoted values, as sent by
+// callers before ETags were quoted, are returned as they are.
+func unquoteETag(eTag string) string {
+	eTag = strings.TrimPrefix(strings.TrimSpace(eTag), "W/")
+	if len(eTag) >= 2 && strings.HasPrefix(eTag, `"`) && strings.HasSuffix(eTag, `"`) {
+		return eTag[1 : len(eTag)-1]
+	}
+	return eTag
+}
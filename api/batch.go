@@ -0,0 +1,118 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/utils"
+	dphttp "github.com/ONSdigital/dp-net/http"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// getDatasetsByIDs returns the datasets with the ids, in the order they were requested in, as the caller of the request
+// can view them. Each id the caller cannot view a dataset for is marked as not found, and each dataset that could not be
+// retrieved is returned with the error, rather than failing the request.
+func (api *DatasetAPI) getDatasetsByIDs(w http.ResponseWriter, r *http.Request, ids []string, limit, offset int) (interface{}, int, error) {
+	ctx := r.Context()
+	logData := log.Data{"ids": ids}
+
+	pageIDs := slice(ids, offset, limit)
+	datasets, err := api.dataStore.Backend.GetDatasetsByIDs(ctx, pageIDs)
+	if err != nil {
+		log.Event(ctx, "getDatasetsByIDs: datastore.GetDatasetsByIDs returned an error", log.ERROR, log.Error(err), logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return nil, 0, err
+	}
+
+	datasetsByID := make(map[string]*models.DatasetUpdate, len(datasets))
+	for _, dataset := range datasets {
+		datasetsByID[dataset.ID] = dataset
+	}
+
+	items := make([]models.DatasetBatchItem, 0, len(pageIDs))
+	for _, id := range pageIDs {
+		dataset, ok := datasetsByID[id]
+		if !ok {
+			items = append(items, models.DatasetBatchItem{ID: id, NotFound: true})
+			continue
+		}
+
+		itemLogData := log.Data{"dataset_id": id}
+		resource, _, err := api.viewableDatasetResponse(r, id, dataset, itemLogData)
+		switch {
+		case err == nil:
+			items = append(items, models.DatasetBatchItem{ID: id, Resource: resource})
+		case notFound[err]:
+			items = append(items, models.DatasetBatchItem{ID: id, NotFound: true})
+		default:
+			log.Event(ctx, "getDatasetsByIDs: failed to retrieve dataset", log.ERROR, log.Error(err), itemLogData)
+			items = append(items, models.DatasetBatchItem{ID: id, Error: utils.NewProblem(ctx, errs.ErrInternalServer, http.StatusInternalServerError)})
+		}
+	}
+
+	return items, len(ids), nil
+}
+
+// getVersionBatch returns the requested versions, in the order they were requested in, as the caller of the request
+// can view them. Each version the caller cannot view is marked as not found, and each version that could not be
+// retrieved is returned with the error, rather than failing the request.
+func (api *DatasetAPI) getVersionBatch(w http.ResponseWriter, r *http.Request) {
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	logData := log.Data{}
+
+	var batch models.VersionBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		log.Event(ctx, "getVersionBatch endpoint: failed to unmarshal request body", log.ERROR, log.Error(err), logData)
+		handleVersionAPIErr(ctx, errs.ErrUnableToParseJSON, w, logData)
+		return
+	}
+
+	logData["count"] = len(batch.Items)
+	if len(batch.Items) > MaxIDs() {
+		log.Event(ctx, "getVersionBatch endpoint: too many versions requested", log.ERROR, logData)
+		handleVersionAPIErr(ctx, errs.ErrTooManyBatchItems, w, logData)
+		return
+	}
+
+	authorised := api.authenticate(r, logData)
+
+	response := models.VersionBatchResponse{Items: make([]models.VersionBatchItem, 0, len(batch.Items))}
+	for _, key := range batch.Items {
+		itemLogData := log.Data{"dataset_id": key.Dataset, "edition": key.Edition, "version": key.Version}
+		if key.Version < 1 {
+			log.Event(ctx, "getVersionBatch endpoint: invalid version", log.ERROR, log.Error(errs.ErrInvalidVersion), itemLogData)
+			response.Items = append(response.Items, models.VersionBatchItem{VersionKey: key, Error: utils.NewProblem(ctx, errs.ErrInvalidVersion, http.StatusBadRequest)})
+			continue
+		}
+
+		version, err := api.viewableVersion(r, authorised, key.Dataset, key.Edition, key.Version, itemLogData)
+		switch {
+		case err == nil:
+			response.Items = append(response.Items, models.VersionBatchItem{VersionKey: key, Resource: version})
+		case notFound[err]:
+			response.Items = append(response.Items, models.VersionBatchItem{VersionKey: key, NotFound: true})
+		default:
+			log.Event(ctx, "getVersionBatch endpoint: failed to retrieve version", log.ERROR, log.Error(err), itemLogData)
+			response.Items = append(response.Items, models.VersionBatchItem{VersionKey: key, Error: utils.NewProblem(ctx, errs.ErrInternalServer, http.StatusInternalServerError)})
+		}
+	}
+	response.Count = len(response.Items)
+
+	api.renderLinks(r, response)
+	b, err := json.Marshal(response)
+	if err != nil {
+		log.Event(ctx, "getVersionBatch endpoint: failed to marshal versions into bytes", log.ERROR, log.Error(err), logData)
+		handleVersionAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	setJSONContentType(w)
+	if _, err = w.Write(b); err != nil {
+		log.Event(ctx, "getVersionBatch endpoint: error writing bytes to response", log.ERROR, log.Error(err), logData)
+	}
+	log.Event(ctx, "getVersionBatch endpoint: request successful", log.INFO, logData)
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetDatasetsByIDs(t *testing.T) {
+	t.Parallel()
+
+	published := &models.DatasetUpdate{
+		ID:      "cpih01",
+		Current: &models.Dataset{Title: "CPIH", State: models.PublishedState},
+		Next:    &models.Dataset{Title: "CPIH", State: models.PublishedState},
+	}
+	unpublished := &models.DatasetUpdate{
+		ID:   "ashe-table-7",
+		Next: &models.Dataset{Title: "ASHE", State: models.CreatedState},
+	}
+
+	Convey("Given datasets that have and have not been published", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetsByIDsFunc: func(ctx context.Context, ids []string) ([]*models.DatasetUpdate, error) {
+				return []*models.DatasetUpdate{unpublished, published}, nil
+			},
+		}
+
+		Convey("When the public api is requested for the datasets and an unknown id", func() {
+			api := GetWebAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets?id=unknown,cpih01&id=ashe-table-7", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the published dataset is returned in the order requested, and the others are marked as not found", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var page struct {
+					Items      []models.DatasetBatchItem `json:"items"`
+					TotalCount int                       `json:"total_count"`
+				}
+				So(json.Unmarshal(w.Body.Bytes(), &page), ShouldBeNil)
+				So(page.TotalCount, ShouldEqual, 3)
+				So(page.Items, ShouldHaveLength, 3)
+				So(page.Items[0], ShouldResemble, models.DatasetBatchItem{ID: "unknown", NotFound: true})
+				So(page.Items[1].ID, ShouldEqual, "cpih01")
+				So(page.Items[1].NotFound, ShouldBeFalse)
+				So(page.Items[1].Resource.(map[string]interface{})["title"], ShouldEqual, "CPIH")
+				So(page.Items[2], ShouldResemble, models.DatasetBatchItem{ID: "ashe-table-7", NotFound: true})
+
+				So(mockedDataStore.GetDatasetsByIDsCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.GetDatasetsByIDsCalls()[0].Ids, ShouldResemble, []string{"unknown", "cpih01", "ashe-table-7"})
+				So(mockedDataStore.GetDatasetsCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the private api is requested for the datasets by an authorised caller", func() {
+			api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets?id=ashe-table-7", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the unpublished dataset is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, `{"id":"ashe-table-7","resource":{"id":"ashe-table-7","next":{`)
+				So(w.Body.String(), ShouldNotContainSubstring, "not_found")
+			})
+		})
+
+		Convey("When more ids are requested than are allowed", func() {
+			api := GetWebAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets?id="+manyIDs(MaxIDs()+1), nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a bad request is returned without the datasets being requested", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrTooManyQueryParameters.Error())
+				So(mockedDataStore.GetDatasetsByIDsCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetVersionBatch(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a published version", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
+				if datasetID == "unknown" {
+					return errs.ErrDatasetNotFound
+				}
+				return nil
+			},
+			CheckEditionExistsFunc: func(ctx context.Context, datasetID, edition, state string) error {
+				return nil
+			},
+			GetVersionFunc: func(ctx context.Context, datasetID, edition string, version int, state string) (*models.Version, error) {
+				if version != 1 {
+					return nil, errs.ErrVersionNotFound
+				}
+				return &models.Version{
+					Version: 1,
+					State:   models.PublishedState,
+					Links:   &models.VersionLinks{Self: &models.LinkObject{}, Version: &models.LinkObject{HRef: "/datasets/cpih01/editions/time-series/versions/1"}},
+					Downloads: &models.DownloadList{
+						CSV: &models.DownloadObject{HRef: "/downloads/cpih01.csv", Private: "s3://private/cpih01.csv"},
+					},
+				}, nil
+			},
+		}
+		api := GetWebAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When the version is requested in a batch with versions that do not exist", func() {
+			b := `{"items":[{"dataset":"cpih01","edition":"time-series","version":2},{"dataset":"cpih01","edition":"time-series","version":1},{"dataset":"unknown","edition":"time-series","version":1}]}`
+			r := httptest.NewRequest("POST", "http://localhost:22000/versions/batch", bytes.NewBufferString(b))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the version is returned as it is by itself, and the others are marked as not found", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var response models.VersionBatchResponse
+				So(json.Unmarshal(w.Body.Bytes(), &response), ShouldBeNil)
+				So(response.Count, ShouldEqual, 3)
+				So(response.Items[0], ShouldResemble, models.VersionBatchItem{VersionKey: models.VersionKey{Dataset: "cpih01", Edition: "time-series", Version: 2}, NotFound: true})
+				So(response.Items[1].VersionKey, ShouldResemble, models.VersionKey{Dataset: "cpih01", Edition: "time-series", Version: 1})
+				So(response.Items[1].NotFound, ShouldBeFalse)
+				So(response.Items[1].Resource.Links.Self.HRef, ShouldEqual, "http://localhost:22000/datasets/cpih01/editions/time-series/versions/1")
+				So(response.Items[1].Resource.Downloads.CSV.Private, ShouldBeEmpty)
+				So(response.Items[2], ShouldResemble, models.VersionBatchItem{VersionKey: models.VersionKey{Dataset: "unknown", Edition: "time-series", Version: 1}, NotFound: true})
+
+				So(mockedDataStore.GetVersionCalls(), ShouldHaveLength, 2)
+				So(mockedDataStore.GetVersionCalls()[0].State, ShouldEqual, models.PublishedState)
+			})
+		})
+
+		Convey("When a version is requested without an edition", func() {
+			b := `{"items":[{"dataset":"cpih01","version":1}]}`
+			r := httptest.NewRequest("POST", "http://localhost:22000/versions/batch", bytes.NewBufferString(b))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a bad request is returned without any version being requested", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, `"field":"items.0.edition"`)
+				So(mockedDataStore.GetVersionCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a version that fails to be retrieved is requested in a batch with the version", func() {
+			mockedDataStore.CheckEditionExistsFunc = func(ctx context.Context, datasetID, edition, state string) error {
+				if edition == "broken" {
+					return errs.ErrInternalServer
+				}
+				return nil
+			}
+			b := `{"items":[{"dataset":"cpih01","edition":"broken","version":1},{"dataset":"cpih01","edition":"time-series","version":1}]}`
+			r := httptest.NewRequest("POST", "http://localhost:22000/versions/batch", bytes.NewBufferString(b))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the version that failed is returned with an internal error without failing the batch", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var response models.VersionBatchResponse
+				So(json.Unmarshal(w.Body.Bytes(), &response), ShouldBeNil)
+				So(response.Count, ShouldEqual, 2)
+				So(response.Items[0].Resource, ShouldBeNil)
+				So(response.Items[0].NotFound, ShouldBeFalse)
+				So(response.Items[0].Error.Status, ShouldEqual, http.StatusInternalServerError)
+				So(response.Items[0].Error.Code, ShouldEqual, "internal_error")
+				So(response.Items[1].Error, ShouldBeNil)
+				So(response.Items[1].Resource.Version, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func manyIDs(n int) string {
+	ids := make([]byte, 0, 2*n)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			ids = append(ids, ',')
+		}
+		ids = append(ids, 'a')
+	}
+	return string(ids)
+}
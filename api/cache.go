@@ -0,0 +1,163 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/log.go/log"
+)
+
+const (
+	cacheControlHeader    = "Cache-Control"
+	lastModifiedHeader    = "Last-Modified"
+	ifNoneMatchHeader     = "If-None-Match"
+	ifModifiedSinceHeader = "If-Modified-Since"
+
+	privateCacheControl = "private, no-store"
+)
+
+// bufferedResponseWriter holds the response of a handler so that its validators can be
+// computed, and the request preconditions evaluated, before anything is sent to the client
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+func (b *bufferedResponseWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+func (b *bufferedResponseWriter) WriteHeader(status int) {
+	b.status = status
+}
+
+// authentication records whether the handler of a request authenticated it, so that cacheable can tell the responses
+// that may contain unpublished data apart without authenticating the request again
+type authentication struct {
+	authenticated bool
+}
+
+type authenticationKey struct{}
+
+// recordAuthentication records that the request was authenticated, if it is being served by a cacheable handler
+func recordAuthentication(r *http.Request) {
+	if a, ok := r.Context().Value(authenticationKey{}).(*authentication); ok {
+		a.authenticated = true
+	}
+}
+
+// cacheable wraps a GET handler so that public responses are sent with a Cache-Control header with the provided
+// max-age and are answered with a 304 when the request preconditions (If-None-Match / If-Modified-Since) show the
+// client already holds the current representation. The ETag of the document set by the handler is kept, so that it
+// can still be used in If-Match headers, and responses without one are sent with a strong ETag computed from the body.
+// Responses to requests the handler authenticated may contain unpublished data, so they are never cached.
+func (api *DatasetAPI) cacheable(maxAge time.Duration, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		auth := &authentication{}
+		r = r.WithContext(context.WithValue(r.Context(), authenticationKey{}, auth))
+
+		buf := newBufferedResponseWriter()
+		handler(buf, r)
+
+		for k, v := range buf.header {
+			w.Header()[k] = v
+		}
+
+		if auth.authenticated {
+			w.Header().Set(cacheControlHeader, privateCacheControl)
+			w.WriteHeader(buf.status)
+			writeBufferedBody(w, r, buf)
+			return
+		}
+
+		if buf.status != http.StatusOK {
+			w.WriteHeader(buf.status)
+			writeBufferedBody(w, r, buf)
+			return
+		}
+
+		eTag := w.Header().Get("ETag")
+		if eTag == "" {
+			eTag = strongETag(buf.body.Bytes())
+			w.Header().Set("ETag", eTag)
+		}
+		w.Header().Set(cacheControlHeader, fmt.Sprintf("public, max-age=%d", int64(maxAge/time.Second)))
+
+		if notModified(r, eTag, w.Header().Get(lastModifiedHeader)) {
+			w.Header().Del("Content-Type")
+			w.Header().Del("Content-Length")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		writeBufferedBody(w, r, buf)
+	}
+}
+
+func writeBufferedBody(w http.ResponseWriter, r *http.Request, buf *bufferedResponseWriter) {
+	if _, err := w.Write(buf.body.Bytes()); err != nil {
+		log.Event(r.Context(), "failed to write buffered response body", log.ERROR, log.Error(err))
+	}
+}
+
+// strongETag returns a quoted SHA-1 hash of the provided response body
+func strongETag(body []byte) string {
+	return fmt.Sprintf(`"%x"`, sha1.Sum(body))
+}
+
+// notModified evaluates the request preconditions against the validators of the response. If-None-Match takes
+// precedence over If-Modified-Since, as defined in RFC 7232.
+func notModified(r *http.Request, eTag, lastModified string) bool {
+	if ifNoneMatch := r.Header.Get(ifNoneMatchHeader); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = unquoteETag(candidate)
+			if candidate == "*" || candidate == unquoteETag(eTag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	ifModifiedSince := r.Header.Get(ifModifiedSinceHeader)
+	if ifModifiedSince == "" || lastModified == "" {
+		return false
+	}
+
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+
+	return !modified.After(since)
+}
+
+// setLastModified sets the Last-Modified header, ignoring documents without a last updated time
+func setLastModified(w http.ResponseWriter, lastUpdated time.Time) {
+	if lastUpdated.IsZero() {
+		return
+	}
+	w.Header().Set(lastModifiedHeader, lastUpdated.UTC().Format(http.TimeFormat))
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/dp-dataset-api/events"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// flushCache tells the web instances to flush their response caches, by sending a cache flushed event. The caches are
+// flushed as the event is consumed, so the request is accepted rather than completed.
+func (api *DatasetAPI) flushCache(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logData := log.Data{"func": "flushCache"}
+
+	if err := api.eventEmitter.Emit(ctx, events.CacheFlushed, "", "", "", ""); err != nil {
+		log.Event(ctx, "failed to send cache flushed event", log.ERROR, log.Error(err), logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	log.Event(ctx, "sent cache flushed event", log.INFO, logData)
+}
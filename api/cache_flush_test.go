@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/events"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFlushCache(t *testing.T) {
+	t.Parallel()
+	Convey("Given a publishing instance of the dataset api", t, func() {
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), permissions)
+		emitter := api.eventEmitter.(*mocks.DatasetEventEmitterMock)
+
+		Convey("When the caches are flushed", func() {
+			r := createRequestWithAuth("POST", "http://localhost:22000/cache/flush", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a cache flushed event is sent, and the request is accepted", func() {
+				So(w.Code, ShouldEqual, http.StatusAccepted)
+				So(permissions.Required.Calls, ShouldEqual, 1)
+				So(emitter.EmitCalls(), ShouldHaveLength, 1)
+				So(emitter.EmitCalls()[0].EventType, ShouldEqual, events.CacheFlushed)
+				So(emitter.EmitCalls()[0].DatasetID, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the cache flushed event cannot be sent", func() {
+			emitter.EmitFunc = func(ctx context.Context, eventType, datasetID, edition, version, instanceID string) error {
+				return errs.ErrInternalServer
+			}
+			r := createRequestWithAuth("POST", "http://localhost:22000/cache/flush", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then an internal server error is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+
+		Convey("When the caches are flushed without authentication", func() {
+			r := httptest.NewRequest("POST", "http://localhost:22000/cache/flush", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the request is unauthorised, and no event is sent", func() {
+				So(w.Code, ShouldEqual, http.StatusUnauthorized)
+				So(emitter.EmitCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,299 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	"github.com/ONSdigital/log.go/log"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testLastUpdated = time.Date(2020, time.March, 10, 9, 30, 0, 0, time.UTC)
+
+func cacheTestHandler(api *DatasetAPI, status int, eTag string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		api.authenticate(r, log.Data{})
+		if eTag != "" {
+			setETag(w, eTag)
+		}
+		setLastModified(w, testLastUpdated)
+		setJSONContentType(w)
+		w.WriteHeader(status)
+		w.Write([]byte(`{"id":"123"}`))
+	}
+}
+
+func TestCacheable(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a cacheable handler in an API with pre-publish view enabled", t, func() {
+		api := &DatasetAPI{EnablePrePublishView: true}
+		handler := api.cacheable(time.Minute, cacheTestHandler(api, http.StatusOK, ""))
+		expectedETag := strongETag([]byte(`{"id":"123"}`))
+
+		Convey("When an unauthenticated request is made", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			Convey("Then the response contains the body, validators and a public Cache-Control header", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, `{"id":"123"}`)
+				So(w.Header().Get("ETag"), ShouldEqual, expectedETag)
+				So(w.Header().Get("Last-Modified"), ShouldEqual, "Tue, 10 Mar 2020 09:30:00 GMT")
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=60")
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/json")
+			})
+		})
+
+		Convey("When an unauthenticated request is made with a matching If-None-Match header", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+			r.Header.Set("If-None-Match", `"other", `+expectedETag)
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			Convey("Then a 304 is returned without a body", func() {
+				So(w.Code, ShouldEqual, http.StatusNotModified)
+				So(w.Body.Len(), ShouldEqual, 0)
+				So(w.Header().Get("ETag"), ShouldEqual, expectedETag)
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=60")
+			})
+		})
+
+		Convey("When an unauthenticated request is made with a different If-None-Match header", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+			r.Header.Set("If-None-Match", `"other"`)
+			r.Header.Set("If-Modified-Since", "Wed, 11 Mar 2020 09:30:00 GMT")
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			Convey("Then the If-Modified-Since header is ignored and the full response is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, `{"id":"123"}`)
+			})
+		})
+
+		Convey("When an unauthenticated request is made with an If-Modified-Since header at or after the last update", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+			r.Header.Set("If-Modified-Since", "Tue, 10 Mar 2020 09:30:00 GMT")
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			Convey("Then a 304 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotModified)
+				So(w.Body.Len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When an unauthenticated request is made with an If-Modified-Since header before the last update", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+			r.Header.Set("If-Modified-Since", "Mon, 09 Mar 2020 09:30:00 GMT")
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			Convey("Then the full response is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, `{"id":"123"}`)
+			})
+		})
+
+		Convey("When an authenticated request is made with a matching If-None-Match header", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/123", nil)
+			r.Header.Set("If-None-Match", expectedETag)
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			Convey("Then the full response is returned and is not cacheable", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, `{"id":"123"}`)
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "private, no-store")
+				So(w.Header().Get("ETag"), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a cacheable handler that sets the ETag of the document it returns", t, func() {
+		api := &DatasetAPI{EnablePrePublishView: true}
+		handler := api.cacheable(time.Minute, cacheTestHandler(api, http.StatusOK, testETag))
+
+		Convey("When an unauthenticated request is made", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			Convey("Then the ETag of the document is kept, and the response is cacheable", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("ETag"), ShouldEqual, `"`+testETag+`"`)
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=60")
+			})
+		})
+
+		Convey("When an unauthenticated request is made with the ETag of the document in an If-None-Match header", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+			r.Header.Set("If-None-Match", testETag)
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			Convey("Then a 304 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotModified)
+				So(w.Body.Len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When an unauthenticated request is made with the quoted ETag of the document in an If-None-Match header", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+			r.Header.Set("If-None-Match", `"other", W/"`+testETag+`"`)
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			Convey("Then a 304 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotModified)
+				So(w.Header().Get("ETag"), ShouldEqual, `"`+testETag+`"`)
+			})
+		})
+
+		Convey("When an authenticated request is made", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/123", nil)
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			Convey("Then the ETag of the document is kept, and the response is not cacheable", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("ETag"), ShouldEqual, `"`+testETag+`"`)
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "private, no-store")
+			})
+		})
+	})
+
+	Convey("Given a cacheable handler that returns an error", t, func() {
+		api := &DatasetAPI{}
+		handler := api.cacheable(time.Minute, cacheTestHandler(api, http.StatusNotFound, ""))
+
+		Convey("When a request is made", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+			r.Header.Set("If-None-Match", "*")
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			Convey("Then the response is passed through without validators or a Cache-Control header", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Body.String(), ShouldEqual, `{"id":"123"}`)
+				So(w.Header().Get("ETag"), ShouldBeEmpty)
+				So(w.Header().Get("Cache-Control"), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetIfMatch(t *testing.T) {
+	Convey("When the If-Match header holds a quoted or weak ETag, then the stored eTag is returned without the quotes", t, func() {
+		for _, ifMatch := range []string{`"` + testETag + `"`, `W/"` + testETag + `"`, testETag} {
+			r := httptest.NewRequest("PUT", "http://localhost:22000/datasets/123", nil)
+			r.Header.Set("If-Match", ifMatch)
+			So(getIfMatch(r), ShouldEqual, testETag)
+		}
+	})
+
+	Convey("When there is no If-Match header, then any eTag is matched", t, func() {
+		r := httptest.NewRequest("PUT", "http://localhost:22000/datasets/123", nil)
+		So(getIfMatch(r), ShouldEqual, mongo.AnyETag)
+	})
+}
+
+func TestWebSubnetVersionEndpointDownloadService(t *testing.T) {
+	Convey("Given a web API serving a published version whose downloads are stored privately", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			CheckDatasetExistsFunc: func(ctx context.Context, ID, state string) error {
+				return nil
+			},
+			CheckEditionExistsFunc: func(ctx context.Context, ID, editionID, state string) error {
+				return nil
+			},
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
+				return &models.Version{
+					ID:      "789",
+					State:   models.PublishedState,
+					Version: 1,
+					Links: &models.VersionLinks{
+						Self:    &models.LinkObject{},
+						Version: &models.LinkObject{ID: "1", HRef: "http://localhost:22000/datasets/123/editions/2017/versions/1"},
+					},
+					Downloads: &models.DownloadList{
+						CSV: &models.DownloadObject{Private: "s3://csv-exported/myfile.csv", HRef: "http://localhost:23600/datasets/123/editions/2017/versions/1.csv"},
+					},
+					ETag: testETag,
+				}, nil
+			},
+		}
+		api := GetWebAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, nil, nil)
+
+		Convey("When the download service requests the version", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1", nil)
+			r.Header.Set(downloadServiceToken, api.downloadServiceToken)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the private location of the downloads is returned in a response which is never cached", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, "s3://csv-exported/myfile.csv")
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "private, no-store")
+			})
+		})
+
+		Convey("When the version is requested without the download service token", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the private location of the downloads is removed from a cacheable response", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldNotContainSubstring, "s3://csv-exported/myfile.csv")
+				So(w.Header().Get("Cache-Control"), ShouldStartWith, "public, max-age=")
+			})
+		})
+	})
+}
+
+func TestWebSubnetDatasetEndpointConditionalGet(t *testing.T) {
+	Convey("When the API is started with private endpoints disabled", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{
+					ID:      "123",
+					Current: &models.Dataset{ID: "123", Title: "current", LastUpdated: testLastUpdated},
+					Next:    &models.Dataset{ID: "123", Title: "next"},
+					ETag:    testETag,
+				}, nil
+			},
+		}
+		api := GetWebAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, nil, nil)
+
+		Convey("Calling the dataset endpoint returns the validators of the published dataset", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get("ETag"), ShouldEqual, `"`+testETag+`"`)
+			So(w.Header().Get("Last-Modified"), ShouldEqual, "Tue, 10 Mar 2020 09:30:00 GMT")
+			So(w.Header().Get("Cache-Control"), ShouldStartWith, "public, max-age=")
+
+			Convey("And calling it again with the returned ETag returns a 304", func() {
+				r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+				r.Header.Set("If-None-Match", w.Header().Get("ETag"))
+				w2 := httptest.NewRecorder()
+				api.Router.ServeHTTP(w2, r)
+
+				So(w2.Code, ShouldEqual, http.StatusNotModified)
+				So(w2.Body.Len(), ShouldEqual, 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,510 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/collection"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	dprequest "github.com/ONSdigital/dp-net/request"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testUserAccessToken = "user-access-token"
+	testCollectionID    = "collection-123"
+)
+
+// createRequestWithUserAuth creates a request made on behalf of a user, as identified by the identity middleware
+func createRequestWithUserAuth(method, URL string, body io.Reader) *http.Request {
+	r := httptest.NewRequest(method, URL, body)
+	r.Header.Set(dprequest.FlorenceHeaderKey, testUserAccessToken)
+	ctx := r.Context()
+	ctx = dprequest.SetCaller(ctx, "someone@ons.gov.uk")
+	ctx = dprequest.SetUser(ctx, "someone@ons.gov.uk")
+	return r.WithContext(ctx)
+}
+
+func getAPIWithCollectionPermissions(mockedDataStore *storetest.StorerMock, collectionPermissions CollectionPermissions) *DatasetAPI {
+	api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+	api.collectionPermissions = collectionPermissions
+	return api
+}
+
+func datasetInCollection() *models.DatasetUpdate {
+	return &models.DatasetUpdate{
+		ID:      "123",
+		Current: &models.Dataset{ID: "123", Title: "published", State: models.PublishedState},
+		Next:    &models.Dataset{ID: "123", Title: "embargoed", State: models.AssociatedState, CollectionID: testCollectionID},
+	}
+}
+
+func versionInCollection() *models.Version {
+	return &models.Version{
+		State:        models.AssociatedState,
+		CollectionID: testCollectionID,
+		Links: &models.VersionLinks{
+			Self:    &models.LinkObject{},
+			Version: &models.LinkObject{HRef: "href"},
+		},
+	}
+}
+
+func TestGetDatasetCollectionVisibility(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a dataset with unpublished changes associated with a collection", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				return datasetInCollection(), nil
+			},
+		}
+		checker := collection.NewLocalChecker()
+		api := getAPIWithCollectionPermissions(mockedDataStore, checker)
+
+		Convey("When a user without access to the collection requests the dataset", func() {
+			r := createRequestWithUserAuth("GET", "http://localhost:22000/datasets/123", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then only the published dataset is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var dataset models.Dataset
+				So(json.Unmarshal(w.Body.Bytes(), &dataset), ShouldBeNil)
+				So(dataset.Title, ShouldEqual, "published")
+			})
+		})
+
+		Convey("When a user with access to the collection requests the dataset", func() {
+			checker.Grant(testUserAccessToken, testCollectionID)
+			r := createRequestWithUserAuth("GET", "http://localhost:22000/datasets/123", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the unpublished changes are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var dataset models.DatasetUpdate
+				So(json.Unmarshal(w.Body.Bytes(), &dataset), ShouldBeNil)
+				So(dataset.Next.Title, ShouldEqual, "embargoed")
+			})
+		})
+
+		Convey("When another service requests the dataset", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/123", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the unpublished changes are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var dataset models.DatasetUpdate
+				So(json.Unmarshal(w.Body.Bytes(), &dataset), ShouldBeNil)
+				So(dataset.Next.Title, ShouldEqual, "embargoed")
+			})
+		})
+	})
+
+	Convey("Given a dataset that has never been published and is associated with a collection", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				dataset := datasetInCollection()
+				dataset.Current = nil
+				return dataset, nil
+			},
+		}
+		api := getAPIWithCollectionPermissions(mockedDataStore, collection.NewLocalChecker())
+
+		Convey("When a user without access to the collection requests the dataset", func() {
+			r := createRequestWithUserAuth("GET", "http://localhost:22000/datasets/123", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestGetDatasetWithoutCollectionVisibility(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a dataset with unpublished changes that are not yet associated with a collection", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				dataset := datasetInCollection()
+				dataset.Next.CollectionID = ""
+				return dataset, nil
+			},
+		}
+		checker := collection.NewLocalChecker()
+		api := getAPIWithCollectionPermissions(mockedDataStore, checker)
+
+		Convey("When a user without permissions on the dataset requests it", func() {
+			r := createRequestWithUserAuth("GET", "http://localhost:22000/datasets/123", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then only the published dataset is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var dataset models.Dataset
+				So(json.Unmarshal(w.Body.Bytes(), &dataset), ShouldBeNil)
+				So(dataset.Title, ShouldEqual, "published")
+			})
+		})
+
+		Convey("When a user with permissions on the dataset requests it", func() {
+			checker.Grant(testUserAccessToken, "")
+			r := createRequestWithUserAuth("GET", "http://localhost:22000/datasets/123", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the unpublished changes are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var dataset models.DatasetUpdate
+				So(json.Unmarshal(w.Body.Bytes(), &dataset), ShouldBeNil)
+				So(dataset.Next.Title, ShouldEqual, "embargoed")
+			})
+		})
+
+		Convey("When an identified caller without a service auth token or user access token requests it", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+			r = r.WithContext(dprequest.SetCaller(r.Context(), "someone@ons.gov.uk"))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the caller is not treated as a service, and only the published dataset is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var dataset models.Dataset
+				So(json.Unmarshal(w.Body.Bytes(), &dataset), ShouldBeNil)
+				So(dataset.Title, ShouldEqual, "published")
+			})
+		})
+	})
+}
+
+func TestGetDatasetsCollectionVisibility(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a user without access to the collection of the unpublished datasets", t, func() {
+		unpublished := datasetInCollection()
+		unpublished.ID = "456"
+		unpublished.Current = nil
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetsFunc: func(ctx context.Context, offset, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
+				return []*models.DatasetUpdate{datasetInCollection(), unpublished}, 2, nil
+			},
+		}
+		api := getAPIWithCollectionPermissions(mockedDataStore, collection.NewLocalChecker())
+
+		Convey("When the datasets are requested", func() {
+			r := createRequestWithUserAuth("GET", "http://localhost:22000/datasets", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the unpublished changes are replaced by the published datasets", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var page struct {
+					Items      []models.DatasetUpdate `json:"items"`
+					TotalCount int                    `json:"total_count"`
+				}
+				So(json.Unmarshal(w.Body.Bytes(), &page), ShouldBeNil)
+				So(page.Items, ShouldHaveLength, 1)
+				So(page.Items[0].Next.Title, ShouldEqual, "published")
+				So(page.TotalCount, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestGetEditionCollectionVisibility(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a dataset with unpublished changes associated with a collection", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				return datasetInCollection(), nil
+			},
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
+				return nil
+			},
+			GetEditionFunc: func(ctx context.Context, id string, editionID string, state string) (*models.EditionUpdate, error) {
+				return &models.EditionUpdate{}, nil
+			},
+		}
+		checker := collection.NewLocalChecker()
+		api := getAPIWithCollectionPermissions(mockedDataStore, checker)
+
+		Convey("When a user without access to the collection requests an edition", func() {
+			r := createRequestWithUserAuth("GET", "http://localhost:22000/datasets/123/editions/2021", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then only published editions are looked up", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedDataStore.GetEditionCalls()[0].State, ShouldEqual, models.PublishedState)
+			})
+		})
+
+		Convey("When a user with access to the collection requests an edition", func() {
+			checker.Grant(testUserAccessToken, testCollectionID)
+			r := createRequestWithUserAuth("GET", "http://localhost:22000/datasets/123/editions/2021", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then unpublished editions are looked up", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedDataStore.GetEditionCalls()[0].State, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When another service requests an edition", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/123/editions/2021", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then unpublished editions are looked up without checking the dataset collection", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedDataStore.GetEditionCalls()[0].State, ShouldBeEmpty)
+				So(mockedDataStore.GetDatasetCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetVersionCollectionVisibility(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given an unpublished version associated with a collection", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
+				return nil
+			},
+			CheckEditionExistsFunc: func(ctx context.Context, datasetID, editionID, state string) error {
+				return nil
+			},
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
+				return versionInCollection(), nil
+			},
+		}
+		checker := collection.NewLocalChecker()
+		api := getAPIWithCollectionPermissions(mockedDataStore, checker)
+
+		Convey("When a user without access to the collection requests the version", func() {
+			r := createRequestWithUserAuth("GET", "http://localhost:22000/datasets/123/editions/2021/versions/1", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When a user with access to the collection requests the version", func() {
+			checker.Grant(testUserAccessToken, testCollectionID)
+			r := createRequestWithUserAuth("GET", "http://localhost:22000/datasets/123/editions/2021/versions/1", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the version is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When another service requests the version", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/123/editions/2021/versions/1", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the version is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+	})
+}
+
+func TestGetVersionsCollectionVisibility(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a user without access to the collection of an unpublished version", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
+				return nil
+			},
+			CheckEditionExistsFunc: func(ctx context.Context, datasetID, editionID, state string) error {
+				return nil
+			},
+			GetVersionsFunc: func(ctx context.Context, datasetID, editionID, state string, offset, limit int) ([]models.Version, int, error) {
+				return []models.Version{{Version: 1, State: models.PublishedState}, {Version: 2, State: models.AssociatedState, CollectionID: testCollectionID}}, 2, nil
+			},
+		}
+		api := getAPIWithCollectionPermissions(mockedDataStore, collection.NewLocalChecker())
+
+		Convey("When the versions are requested", func() {
+			r := createRequestWithUserAuth("GET", "http://localhost:22000/datasets/123/editions/2021/versions", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then only the published version is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var page struct {
+					Items      []models.Version `json:"items"`
+					TotalCount int              `json:"total_count"`
+				}
+				So(json.Unmarshal(w.Body.Bytes(), &page), ShouldBeNil)
+				So(page.Items, ShouldHaveLength, 1)
+				So(page.Items[0].Version, ShouldEqual, 1)
+				So(page.TotalCount, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestPutVersionValidatesCollection(t *testing.T) {
+	t.Parallel()
+
+	newMockedDataStore := func() *storetest.StorerMock {
+		return &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{}, nil
+			},
+			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
+				return nil
+			},
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				numberOfOptions := 1
+				return &models.Version{
+					ID:         "789",
+					State:      models.EditionConfirmedState,
+					Dimensions: []models.Dimension{{Name: "age", NumberOfOptions: &numberOfOptions}},
+					Links: &models.VersionLinks{
+						Dataset: &models.LinkObject{HRef: "http://localhost:22000/datasets/123", ID: "123"},
+						Version: &models.LinkObject{HRef: "http://localhost:22000/datasets/123/editions/2017/versions/1", ID: "1"},
+					},
+				}, nil
+			},
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return testETag, nil
+			},
+			UpdateDatasetWithAssociationFunc: func(context.Context, *models.DatasetUpdate, string, *models.Version) error {
+				return nil
+			},
+		}
+	}
+	generatorMock := &mocks.DownloadsGeneratorMock{
+		GenerateFunc: func(context.Context, string, string, string, string) error {
+			return nil
+		},
+	}
+
+	Convey("Given a version is associated with an open collection", t, func() {
+		mockedDataStore := newMockedDataStore()
+		api := GetAPIWithMocks(mockedDataStore, generatorMock, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123/editions/2017/versions/1", bytes.NewBufferString(versionAssociatedPayload))
+		w := httptest.NewRecorder()
+		api.Router.ServeHTTP(w, r)
+
+		Convey("Then the name of the collection is stored with the version and dataset", func() {
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(mockedDataStore.UpdateVersionCalls(), ShouldHaveLength, 1)
+			So(mockedDataStore.UpdateVersionCalls()[0].Version.CollectionName, ShouldEqual, testCollection.Name)
+			So(mockedDataStore.UpdateDatasetWithAssociationCalls(), ShouldHaveLength, 1)
+			So(mockedDataStore.UpdateDatasetWithAssociationCalls()[0].Version.CollectionName, ShouldEqual, testCollection.Name)
+		})
+	})
+
+	Convey("Given a version is associated with a collection that does not exist", t, func() {
+		mockedDataStore := newMockedDataStore()
+		api := GetAPIWithMocks(mockedDataStore, generatorMock, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+		api.collections = collection.NewLocalCollections()
+
+		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123/editions/2017/versions/1", bytes.NewBufferString(versionAssociatedPayload))
+		w := httptest.NewRecorder()
+		api.Router.ServeHTTP(w, r)
+
+		Convey("Then a 400 is returned and the version is not updated", func() {
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.String(), ShouldContainSubstring, errs.ErrCollectionNotFound.Error())
+			So(mockedDataStore.UpdateVersionCalls(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a version is associated with a collection that has been approved", t, func() {
+		mockedDataStore := newMockedDataStore()
+		api := GetAPIWithMocks(mockedDataStore, generatorMock, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+		api.collections = collection.NewLocalCollections(collection.Collection{ID: testCollection.ID, ApprovalStatus: "COMPLETE"})
+
+		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123/editions/2017/versions/1", bytes.NewBufferString(versionAssociatedPayload))
+		w := httptest.NewRecorder()
+		api.Router.ServeHTTP(w, r)
+
+		Convey("Then a 409 is returned and the version is not updated", func() {
+			So(w.Code, ShouldEqual, http.StatusConflict)
+			So(w.Body.String(), ShouldContainSubstring, errs.ErrCollectionNotEditable.Error())
+			So(mockedDataStore.UpdateVersionCalls(), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestGetOrphanedVersions(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given unpublished versions associated with existing and removed collections", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetVersionsInCollectionsFunc: func(ctx context.Context) ([]models.Version, error) {
+				return []models.Version{
+					{ID: "1", CollectionID: testCollection.ID, State: models.AssociatedState},
+					{ID: "2", CollectionID: "typo", State: models.AssociatedState},
+					{ID: "3", CollectionID: "typo", State: models.AssociatedState},
+				}, nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When the orphaned versions are requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/orphaned-versions?limit=10", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then only the versions of removed collections are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var page struct {
+					Items      []models.Version `json:"items"`
+					TotalCount int              `json:"total_count"`
+				}
+				So(json.Unmarshal(w.Body.Bytes(), &page), ShouldBeNil)
+				So(page.TotalCount, ShouldEqual, 2)
+				So(page.Items, ShouldHaveLength, 2)
+				So(page.Items[0].ID, ShouldEqual, "2")
+				So(page.Items[1].ID, ShouldEqual, "3")
+			})
+		})
+	})
+
+	Convey("Given the versions associated with collections cannot be retrieved", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetVersionsInCollectionsFunc: func(ctx context.Context) ([]models.Version, error) {
+				return nil, errs.ErrInternalServer
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When the orphaned versions are requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/orphaned-versions", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a 500 is returned", func() {
+				assertInternalServerErr(w)
+			})
+		})
+	})
+}
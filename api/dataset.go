@@ -5,11 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	"github.com/ONSdigital/dp-dataset-api/utils"
 	dphttp "github.com/ONSdigital/dp-net/http"
 	"github.com/ONSdigital/log.go/log"
 	"github.com/gorilla/mux"
@@ -20,6 +23,11 @@ var (
 	datasetsForbidden = map[error]bool{
 		errs.ErrDeletePublishedDatasetForbidden: true,
 		errs.ErrAddDatasetAlreadyExists:         true,
+		errs.ErrRestoreWindowExpired:            true,
+		errs.ErrAddEditionAlreadyExists:         true,
+		errs.ErrDeletePublishedEditionForbidden: true,
+		errs.ErrAddTopicAlreadyExists:           true,
+		errs.ErrDeleteTopicForbidden:            true,
 	}
 
 	// errors that should return a 204 status
@@ -33,12 +41,28 @@ var (
 		errs.ErrTypeMismatch:               true,
 		errs.ErrDatasetTypeInvalid:         true,
 		errs.ErrInvalidQueryParameter:      true,
+		errs.ErrTooManyQueryParameters:     true,
+		errs.ErrAddUpdateEditionBadRequest: true,
+		errs.ErrTopicParentInvalid:         true,
+		errs.ErrDatasetThemeInvalid:        true,
+		errs.ErrUnableToParseJSON:          true,
+		errs.ErrRelatedDatasetInvalid:      true,
+	}
+
+	// errors that should return a 409 status
+	datasetsConflict = map[error]bool{
+		errs.ErrDatasetConflict: true,
+		errs.ErrEditionConflict: true,
 	}
 
 	// errors that should return a 404 status
 	resourcesNotFound = map[error]bool{
-		errs.ErrDatasetNotFound:  true,
-		errs.ErrEditionsNotFound: true,
+		errs.ErrDatasetNotFound:    true,
+		errs.ErrEditionNotFound:    true,
+		errs.ErrEditionsNotFound:   true,
+		errs.ErrDeleteJobNotFound:  true,
+		errs.ErrTopicNotFound:      true,
+		errs.ErrLinkReportNotFound: true,
 	}
 )
 
@@ -46,6 +70,18 @@ var (
 func (api *DatasetAPI) getDatasets(w http.ResponseWriter, r *http.Request, limit int, offset int) (interface{}, int, error) {
 	ctx := r.Context()
 	logData := log.Data{}
+
+	ids, err := utils.GetQueryParamListValues(r.URL.Query(), "id", MaxIDs())
+	if err != nil {
+		logData["query_params"] = r.URL.RawQuery
+		log.Event(ctx, "api endpoint getDatasets failed to obtain list of IDs from request query parameters", log.ERROR, log.Error(err), logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return nil, 0, err
+	}
+	if len(ids) > 0 {
+		return api.getDatasetsByIDs(w, r, ids, limit, offset)
+	}
+
 	authorised := api.authenticate(r, logData)
 	datasets, totalCount, err := api.dataStore.Backend.GetDatasets(ctx, offset, limit, authorised)
 	if err != nil {
@@ -54,51 +90,55 @@ func (api *DatasetAPI) getDatasets(w http.ResponseWriter, r *http.Request, limit
 		return nil, 0, err
 	}
 	if authorised {
+		datasets, totalCount = api.withoutInaccessibleChanges(r, datasets, totalCount, logData)
 		return datasets, totalCount, nil
 	}
 	return mapResults(datasets), totalCount, nil
 }
 
+// withoutInaccessibleChanges replaces the unpublished changes to the datasets, which are associated with a collection
+// the caller cannot access, with the published dataset. Datasets that have never been published are removed.
+func (api *DatasetAPI) withoutInaccessibleChanges(r *http.Request, datasets []*models.DatasetUpdate, totalCount int, logData log.Data) ([]*models.DatasetUpdate, int) {
+	items := []*models.DatasetUpdate{}
+	for _, item := range datasets {
+		if api.canAccessCollection(r, item.ID, nextCollectionID(item), logData) {
+			items = append(items, item)
+			continue
+		}
+		if item.Current == nil {
+			totalCount--
+			continue
+		}
+		item.Next = item.Current
+		items = append(items, item)
+	}
+	return items, totalCount
+}
+
 func (api *DatasetAPI) getDataset(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
 	datasetID := vars["dataset_id"]
 	logData := log.Data{"dataset_id": datasetID}
 
+	var eTag string
+	var lastUpdated time.Time
 	b, err := func() ([]byte, error) {
-		dataset, err := api.dataStore.Backend.GetDataset(datasetID)
+		dataset, err := api.dataStore.Backend.GetDataset(ctx, datasetID)
 		if err != nil {
 			log.Event(ctx, "getDataset endpoint: dataStore.Backend.GetDataset returned an error", log.ERROR, log.Error(err), logData)
 			return nil, err
 		}
 
-		authorised := api.authenticate(r, logData)
-
-		var b []byte
 		var datasetResponse interface{}
-
-		if !authorised {
-			// User is not authenticated and hence has only access to current sub document
-			if dataset.Current == nil {
-				log.Event(ctx, "getDataste endpoint: published dataset not found", log.INFO, logData)
-				return nil, errs.ErrDatasetNotFound
-			}
-
-			log.Event(ctx, "getDataset endpoint: caller authorised returning dataset current sub document", log.INFO, logData)
-
-			dataset.Current.ID = dataset.ID
-			datasetResponse = dataset.Current
-		} else {
-			// User has valid authentication to get raw dataset document
-			if dataset == nil {
-				log.Event(ctx, "getDataset endpoint: published or unpublished dataset not found", log.INFO, logData)
-				return nil, errs.ErrDatasetNotFound
-			}
-			log.Event(ctx, "getDataset endpoint: caller not authorised returning dataset", log.INFO, logData)
-			datasetResponse = dataset
+		datasetResponse, lastUpdated, err = api.viewableDatasetResponse(r, datasetID, dataset, logData)
+		if err != nil {
+			return nil, err
 		}
+		eTag = dataset.ETag
 
-		b, err = json.Marshal(datasetResponse)
+		api.renderLinks(r, datasetResponse)
+		b, err := json.Marshal(datasetResponse)
 		if err != nil {
 			log.Event(ctx, "getDataset endpoint: failed to marshal dataset resource into bytes", log.ERROR, log.Error(err), logData)
 			return nil, err
@@ -113,6 +153,8 @@ func (api *DatasetAPI) getDataset(w http.ResponseWriter, r *http.Request) {
 	}
 
 	setJSONContentType(w)
+	setETag(w, eTag)
+	setLastModified(w, lastUpdated)
 	if _, err = w.Write(b); err != nil {
 		log.Event(ctx, "getDataset endpoint: error writing bytes to response", log.ERROR, log.Error(err), logData)
 		handleDatasetAPIErr(ctx, err, w, logData)
@@ -120,6 +162,33 @@ func (api *DatasetAPI) getDataset(w http.ResponseWriter, r *http.Request) {
 	log.Event(ctx, "getDataset endpoint: request successful", log.INFO, logData)
 }
 
+// viewableDatasetResponse returns the dataset as the caller of the request can view it, and when it was last updated.
+// Callers that can access the collection of the unpublished changes to the dataset view the whole document, whereas
+// other callers only view the published dataset.
+func (api *DatasetAPI) viewableDatasetResponse(r *http.Request, datasetID string, dataset *models.DatasetUpdate, logData log.Data) (interface{}, time.Time, error) {
+	ctx := r.Context()
+
+	if !api.canAccessCollection(r, datasetID, nextCollectionID(dataset), logData) {
+		// User is not authenticated and hence has only access to current sub document
+		if dataset.Current == nil {
+			log.Event(ctx, "viewableDatasetResponse: published dataset not found", log.INFO, logData)
+			return nil, time.Time{}, errs.ErrDatasetNotFound
+		}
+
+		log.Event(ctx, "viewableDatasetResponse: caller not authorised returning dataset current sub document", log.INFO, logData)
+		dataset.Current.ID = dataset.ID
+		return dataset.Current, dataset.Current.LastUpdated, nil
+	}
+
+	// User has valid authentication to get raw dataset document
+	log.Event(ctx, "viewableDatasetResponse: caller authorised returning dataset", log.INFO, logData)
+	var lastUpdated time.Time
+	if dataset.Next != nil {
+		lastUpdated = dataset.Next.LastUpdated
+	}
+	return dataset, lastUpdated, nil
+}
+
 func (api *DatasetAPI) addDataset(w http.ResponseWriter, r *http.Request) {
 
 	defer dphttp.DrainBody(r)
@@ -130,9 +199,10 @@ func (api *DatasetAPI) addDataset(w http.ResponseWriter, r *http.Request) {
 
 	logData := log.Data{"dataset_id": datasetID}
 
+	var eTag string
 	// TODO Could just do an insert, if dataset already existed we would get a duplicate key error instead of reading then writing doc
 	b, err := func() ([]byte, error) {
-		_, err := api.dataStore.Backend.GetDataset(datasetID)
+		_, err := api.dataStore.Backend.GetDataset(ctx, datasetID)
 		if err != nil {
 			if err != errs.ErrDatasetNotFound {
 				log.Event(ctx, "addDataset endpoint: error checking if dataset exists", log.ERROR, log.Error(err), logData)
@@ -143,6 +213,18 @@ func (api *DatasetAPI) addDataset(w http.ResponseWriter, r *http.Request) {
 			return nil, errs.ErrAddDatasetAlreadyExists
 		}
 
+		// a deleted dataset keeps its ID until it is purged, so that it can be restored
+		_, err = api.dataStore.Backend.GetDeletedDataset(ctx, datasetID)
+		if err != nil {
+			if err != errs.ErrDatasetNotFound {
+				log.Event(ctx, "addDataset endpoint: error checking if dataset has been deleted", log.ERROR, log.Error(err), logData)
+				return nil, err
+			}
+		} else {
+			log.Event(ctx, "addDataset endpoint: unable to create a dataset that has been deleted", log.ERROR, log.Error(errs.ErrAddDatasetAlreadyExists), logData)
+			return nil, errs.ErrAddDatasetAlreadyExists
+		}
+
 		dataset, err := models.CreateDataset(r.Body)
 		if err != nil {
 			log.Event(ctx, "addDataset endpoint: failed to model dataset resource based on request", log.ERROR, log.Error(err), logData)
@@ -172,16 +254,24 @@ func (api *DatasetAPI) addDataset(w http.ResponseWriter, r *http.Request) {
 		dataset.State = models.CreatedState
 		dataset.ID = datasetID
 
+		if err = api.setTaxonomy(ctx, dataset, logData); err != nil {
+			return nil, err
+		}
+
+		if err = api.resolveRelatedDatasets(r, datasetID, dataset, logData); err != nil {
+			return nil, err
+		}
+
 		if dataset.Links == nil {
 			dataset.Links = &models.DatasetLinks{}
 		}
 
 		dataset.Links.Editions = &models.LinkObject{
-			HRef: fmt.Sprintf("%s/datasets/%s/editions", api.host, datasetID),
+			HRef: fmt.Sprintf("/datasets/%s/editions", datasetID),
 		}
 
 		dataset.Links.Self = &models.LinkObject{
-			HRef: fmt.Sprintf("%s/datasets/%s", api.host, datasetID),
+			HRef: fmt.Sprintf("/datasets/%s", datasetID),
 		}
 
 		// Remove latest version from new dataset resource, this cannot be added at this point
@@ -194,12 +284,14 @@ func (api *DatasetAPI) addDataset(w http.ResponseWriter, r *http.Request) {
 			Next: dataset,
 		}
 
-		if err = api.dataStore.Backend.UpsertDataset(datasetID, datasetDoc); err != nil {
+		if err = api.dataStore.Backend.UpsertDataset(ctx, datasetID, datasetDoc, mongo.AnyETag); err != nil {
 			logData["new_dataset"] = datasetID
 			log.Event(ctx, "addDataset endpoint: failed to insert dataset resource to datastore", log.ERROR, log.Error(err), logData)
 			return nil, err
 		}
+		eTag = datasetDoc.ETag
 
+		api.renderLinks(r, datasetDoc)
 		b, err := json.Marshal(datasetDoc)
 		if err != nil {
 			log.Event(ctx, "addDataset endpoint: failed to marshal dataset resource into bytes", log.ERROR, log.Error(err), logData)
@@ -214,10 +306,11 @@ func (api *DatasetAPI) addDataset(w http.ResponseWriter, r *http.Request) {
 	}
 
 	setJSONContentType(w)
+	setETag(w, eTag)
 	w.WriteHeader(http.StatusCreated)
 	if _, err = w.Write(b); err != nil {
 		log.Event(ctx, "addDataset endpoint: error writing bytes to response", log.ERROR, log.Error(err), logData)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
 	}
 	log.Event(ctx, "addDataset endpoint: request completed successfully", log.INFO, logData)
 }
@@ -229,20 +322,26 @@ func (api *DatasetAPI) putDataset(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
 	datasetID := vars["dataset_id"]
+	eTag := getIfMatch(r)
 	data := log.Data{"dataset_id": datasetID}
 
-	err := func() error {
+	newETag, err := func() (string, error) {
 
 		dataset, err := models.CreateDataset(r.Body)
 		if err != nil {
 			log.Event(ctx, "putDataset endpoint: failed to model dataset resource based on request", log.ERROR, log.Error(err), data)
-			return errs.ErrAddUpdateDatasetBadRequest
+			return "", errs.ErrAddUpdateDatasetBadRequest
 		}
 
-		currentDataset, err := api.dataStore.Backend.GetDataset(datasetID)
+		currentDataset, err := api.dataStore.Backend.GetDataset(ctx, datasetID)
 		if err != nil {
 			log.Event(ctx, "putDataset endpoint: datastore.getDataset returned an error", log.ERROR, log.Error(err), data)
-			return err
+			return "", err
+		}
+
+		if eTag != mongo.AnyETag && eTag != currentDataset.ETag {
+			log.Event(ctx, "putDataset endpoint: dataset does not match the expected eTag", log.ERROR, log.Error(errs.ErrDatasetConflict), data)
+			return "", errs.ErrDatasetConflict
 		}
 
 		dataset.Type = currentDataset.Next.Type
@@ -250,28 +349,39 @@ func (api *DatasetAPI) putDataset(w http.ResponseWriter, r *http.Request) {
 		_, err = models.ValidateNomisURL(ctx, dataset.Type, dataset.NomisReferenceURL)
 		if err != nil {
 			log.Event(ctx, "putDataset endpoint: error dataset.Type mismatch", log.ERROR, log.Error(err), data)
-			return err
+			return "", err
 		}
 
 		models.CleanDataset(dataset)
 
 		if err = models.ValidateDataset(dataset); err != nil {
 			log.Event(ctx, "putDataset endpoint: failed validation check to update dataset", log.ERROR, log.Error(err), data)
-			return err
+			return "", err
+		}
+
+		if err = api.setTaxonomy(ctx, dataset, data); err != nil {
+			return "", err
+		}
+
+		if err = api.resolveRelatedDatasets(r, datasetID, dataset, data); err != nil {
+			return "", err
 		}
 
 		if dataset.State == models.PublishedState {
-			if err := api.publishDataset(ctx, currentDataset, nil); err != nil {
+			newETag, err := api.publishDataset(ctx, currentDataset, nil, eTag)
+			if err != nil {
 				log.Event(ctx, "putDataset endpoint: failed to update dataset document to published", log.ERROR, log.Error(err), data)
-				return err
-			}
-		} else {
-			if err := api.dataStore.Backend.UpdateDataset(ctx, datasetID, dataset, currentDataset.Next.State); err != nil {
-				log.Event(ctx, "putDataset endpoint: failed to update dataset resource", log.ERROR, log.Error(err), data)
-				return err
+				return "", err
 			}
+			return newETag, nil
 		}
-		return nil
+
+		newETag, err := api.dataStore.Backend.UpdateDataset(ctx, currentDataset, dataset, eTag)
+		if err != nil {
+			log.Event(ctx, "putDataset endpoint: failed to update dataset resource", log.ERROR, log.Error(err), data)
+			return "", err
+		}
+		return newETag, nil
 	}()
 
 	if err != nil {
@@ -280,11 +390,14 @@ func (api *DatasetAPI) putDataset(w http.ResponseWriter, r *http.Request) {
 	}
 
 	setJSONContentType(w)
+	setETag(w, newETag)
 	w.WriteHeader(http.StatusOK)
 	log.Event(ctx, "putDataset endpoint: request successful", log.INFO, data)
 }
 
-func (api *DatasetAPI) publishDataset(ctx context.Context, currentDataset *models.DatasetUpdate, version *models.Version) error {
+// publishDataset sets the next sub document of the dataset as its current sub document and returns the new eTag. The
+// dataset is only updated if it matches the eTag selector, otherwise ErrDatasetConflict is returned.
+func (api *DatasetAPI) publishDataset(ctx context.Context, currentDataset *models.DatasetUpdate, version *models.Version, eTagSelector string) (string, error) {
 	if version != nil {
 		currentDataset.Next.CollectionID = ""
 
@@ -307,23 +420,34 @@ func (api *DatasetAPI) publishDataset(ctx context.Context, currentDataset *model
 		Next:    currentDataset.Next,
 	}
 
-	if err := api.dataStore.Backend.UpsertDataset(currentDataset.ID, newDataset); err != nil {
+	if err := api.dataStore.Backend.UpsertDataset(ctx, currentDataset.ID, newDataset, eTagSelector); err != nil {
 		log.Event(ctx, "unable to update dataset", log.ERROR, log.Error(err), log.Data{"dataset_id": currentDataset.ID})
-		return err
+		return "", err
 	}
 
-	return nil
+	return newDataset.ETag, nil
 }
 
 func (api *DatasetAPI) deleteDataset(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
 	datasetID := vars["dataset_id"]
+	eTag := getIfMatch(r)
 	logData := log.Data{"dataset_id": datasetID, "func": "deleteDataset"}
 
+	// the response body, which is only written for dry runs
+	var body []byte
+
 	// attempt to delete the dataset.
 	err := func() error {
-		currentDataset, err := api.dataStore.Backend.GetDataset(datasetID)
+		dryRun, err := getDryRun(r)
+		if err != nil {
+			log.Event(ctx, "invalid dry_run query parameter", log.ERROR, log.Error(err), logData)
+			return err
+		}
+		logData["dry_run"] = dryRun
+
+		currentDataset, err := api.dataStore.Backend.GetDataset(ctx, datasetID)
 
 		if err == errs.ErrDatasetNotFound {
 			log.Event(ctx, "cannot delete dataset, it does not exist", log.INFO, logData)
@@ -335,30 +459,51 @@ func (api *DatasetAPI) deleteDataset(w http.ResponseWriter, r *http.Request) {
 			return err
 		}
 
+		if eTag != mongo.AnyETag && eTag != currentDataset.ETag {
+			log.Event(ctx, "dataset does not match the expected eTag", log.ERROR, log.Error(errs.ErrDatasetConflict), logData)
+			return errs.ErrDatasetConflict
+		}
+
 		if currentDataset.Current != nil && currentDataset.Current.State == models.PublishedState {
 			log.Event(ctx, "unable to delete a published dataset", log.ERROR, log.Error(errs.ErrDeletePublishedDatasetForbidden), logData)
 			return errs.ErrDeletePublishedDatasetForbidden
 		}
 
-		// Find any editions associated with this dataset
-		editionDocs, _, err := api.dataStore.Backend.GetEditions(ctx, currentDataset.ID, "", 0, 0, true)
+		danglingReferences, err := api.getDanglingReferences(r, datasetID, logData)
 		if err != nil {
-			log.Event(ctx, "unable to find the dataset editions", log.ERROR, log.Error(errs.ErrEditionsNotFound), logData)
-			return errs.ErrEditionsNotFound
+			return err
 		}
 
-		// Then delete them
-		for i := range editionDocs {
-			if err := api.dataStore.Backend.DeleteEdition(editionDocs[i].ID); err != nil {
-				log.Event(ctx, "failed to delete edition", log.ERROR, log.Error(err), logData)
+		// A dry run reports everything that will be removed when the deleted dataset is purged
+		if dryRun {
+			deletion, err := api.planDatasetDeletion(ctx, datasetID, logData)
+			if err != nil {
 				return err
 			}
+			logData["counts"] = deletion.counts
+
+			body, err = json.Marshal(&models.DeleteDryRun{DatasetID: datasetID, Counts: deletion.counts, DanglingReferences: danglingReferences})
+			if err != nil {
+				log.Event(ctx, "failed to marshal delete dry run into bytes", log.ERROR, log.Error(err), logData)
+				return err
+			}
+			log.Event(ctx, "dataset deletion dry run completed", log.INFO, logData)
+			return nil
+		}
+
+		if _, err := api.getDeletableInstances(ctx, datasetID, logData); err != nil {
+			return err
 		}
 
-		if err := api.dataStore.Backend.DeleteDataset(datasetID); err != nil {
+		if err := api.dataStore.Backend.SoftDeleteDataset(ctx, datasetID, eTag, deletedBy(ctx), time.Now()); err != nil {
 			log.Event(ctx, "failed to delete dataset", log.ERROR, log.Error(err), logData)
 			return err
 		}
+
+		if len(danglingReferences) > 0 {
+			logData["dangling_references"] = danglingReferences
+			log.Event(ctx, "deleted dataset is still a related dataset of other datasets", log.WARN, logData)
+		}
 		log.Event(ctx, "dataset deleted successfully", log.INFO, logData)
 		return nil
 	}()
@@ -368,10 +513,71 @@ func (api *DatasetAPI) deleteDataset(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	if body == nil {
+		w.WriteHeader(http.StatusNoContent)
+		log.Event(ctx, "delete dataset", log.INFO, logData)
+		return
+	}
+
+	setJSONContentType(w)
+	if _, err = w.Write(body); err != nil {
+		log.Event(ctx, "error writing bytes to response", log.ERROR, log.Error(err), logData)
+		utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
+	}
 	log.Event(ctx, "delete dataset", log.INFO, logData)
 }
 
+func (api *DatasetAPI) restoreDataset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	datasetID := vars["dataset_id"]
+	logData := log.Data{"dataset_id": datasetID, "func": "restoreDataset"}
+
+	err := func() error {
+		deletedDataset, err := api.dataStore.Backend.GetDeletedDataset(ctx, datasetID)
+		if err != nil {
+			log.Event(ctx, "failed to find deleted dataset", log.ERROR, log.Error(err), logData)
+			return err
+		}
+
+		if deletedDataset.Next != nil && deletedDataset.Next.Deletion != nil {
+			logData["deleted_at"] = deletedDataset.Next.Deletion.DeletedAt
+			if time.Since(deletedDataset.Next.Deletion.DeletedAt) > api.deletedDatasetRetention {
+				log.Event(ctx, "unable to restore a dataset deleted outside the retention window", log.ERROR, log.Error(errs.ErrRestoreWindowExpired), logData)
+				return errs.ErrRestoreWindowExpired
+			}
+		}
+
+		if err := api.dataStore.Backend.RestoreDataset(ctx, datasetID); err != nil {
+			log.Event(ctx, "failed to restore dataset", log.ERROR, log.Error(err), logData)
+			return err
+		}
+		return nil
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Event(ctx, "restore dataset", log.INFO, logData)
+}
+
+// getDryRun returns the value of the optional dry_run query parameter
+func getDryRun(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("dry_run")
+	if value == "" {
+		return false, nil
+	}
+
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errs.ErrInvalidQueryParameter
+	}
+	return dryRun, nil
+}
+
 // utility function to cut a slice according to the provided offset and limit.
 // limit=0 means no limit, and values higher than the slice length are ignored
 func slice(full []string, offset, limit int) (sliced []string) {
@@ -409,10 +615,12 @@ func handleDatasetAPIErr(ctx context.Context, err error, w http.ResponseWriter,
 		status = http.StatusForbidden
 	case datasetsNoContent[err]:
 		status = http.StatusNoContent
-	case datasetsBadRequest[err], strings.HasPrefix(err.Error(), "invalid fields:"):
+	case datasetsBadRequest[err], strings.HasPrefix(err.Error(), "invalid fields:"), strings.HasPrefix(err.Error(), "missing mandatory fields:"):
 		status = http.StatusBadRequest
 	case resourcesNotFound[err]:
 		status = http.StatusNotFound
+	case datasetsConflict[err]:
+		status = http.StatusConflict
 	default:
 		err = errs.ErrInternalServer
 		status = http.StatusInternalServerError
@@ -420,5 +628,9 @@ func handleDatasetAPIErr(ctx context.Context, err error, w http.ResponseWriter,
 
 	data["responseStatus"] = status
 	log.Event(ctx, "request unsuccessful", log.ERROR, log.Error(err), data)
-	http.Error(w, err.Error(), status)
+	if status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+	utils.WriteError(ctx, w, err, status)
 }
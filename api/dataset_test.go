@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -14,12 +15,14 @@ import (
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/mocks"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
 	"github.com/ONSdigital/dp-dataset-api/store"
 	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
 	"github.com/ONSdigital/dp-dataset-api/url"
 	dprequest "github.com/ONSdigital/dp-net/request"
 	"github.com/gorilla/mux"
 
+	"github.com/ONSdigital/dp-dataset-api/collection"
 	"github.com/ONSdigital/dp-dataset-api/config"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -33,9 +36,12 @@ const (
 )
 
 var (
-	datasetPayload = `{"contacts":[{"email":"testing@hotmail.com","name":"John Cox","telephone":"01623 456789"}],"description":"census","links":{"access_rights":{"href":"http://ons.gov.uk/accessrights"}},"title":"CensusEthnicity","theme":"population","state":"completed","next_release":"2016-04-04","publisher":{"name":"The office of national statistics","type":"government department","url":"https://www.ons.gov.uk/"},"type":"nomis","nomis_reference_url":"https://www.nomis.co.uk"}`
-	urlBuilder     = url.NewBuilder("localhost:20000")
-	mu             sync.Mutex
+	datasetPayload       = `{"contacts":[{"email":"testing@hotmail.com","name":"John Cox","telephone":"01623 456789"}],"description":"census","links":{"access_rights":{"href":"http://ons.gov.uk/accessrights"}},"title":"CensusEthnicity","theme":"population","state":"completed","next_release":"2016-04-04","publisher":{"name":"The office of national statistics","type":"government department","href":"https://www.ons.gov.uk/"},"type":"nomis","nomis_reference_url":"https://www.nomis.co.uk"}`
+	urlBuilder           = url.NewBuilder("localhost:20000")
+	testETag             = "testETag"
+	testServiceAuthToken = "testServiceAuthToken"
+	testCollection       = collection.Collection{ID: "12345", Name: "test collection", ApprovalStatus: "IN_PROGRESS"}
+	mu                   sync.Mutex
 )
 
 func getAuthorisationHandlerMock() *mocks.AuthHandlerMock {
@@ -45,6 +51,15 @@ func getAuthorisationHandlerMock() *mocks.AuthHandlerMock {
 }
 
 // GetAPIWithMocks also used in other tests, so exported
+// emitterMock returns an event emitter which accepts every event
+func emitterMock() *mocks.DatasetEventEmitterMock {
+	return &mocks.DatasetEventEmitterMock{
+		EmitFunc: func(ctx context.Context, eventType, datasetID, edition, version, instanceID string) error {
+			return nil
+		},
+	}
+}
+
 func GetAPIWithMocks(mockedDataStore store.Storer, mockedGeneratedDownloads DownloadsGenerator, datasetPermissions AuthHandler, permissions AuthHandler) *DatasetAPI {
 	mu.Lock()
 	defer mu.Unlock()
@@ -56,7 +71,7 @@ func GetAPIWithMocks(mockedDataStore store.Storer, mockedGeneratedDownloads Down
 	cfg.DefaultLimit = 0
 	cfg.DefaultOffset = 0
 
-	return Setup(testContext, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, mockedGeneratedDownloads, datasetPermissions, permissions)
+	return Setup(testContext, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, mockedGeneratedDownloads, &mocks.ImportRetrierMock{}, emitterMock(), collection.NewLocalChecker(), collection.NewLocalCollections(testCollection), nil, datasetPermissions, permissions)
 }
 
 func createRequestWithAuth(method, URL string, body io.Reader) *http.Request {
@@ -64,6 +79,7 @@ func createRequestWithAuth(method, URL string, body io.Reader) *http.Request {
 	ctx := r.Context()
 	ctx = dprequest.SetCaller(ctx, "someone@ons.gov.uk")
 	r = r.WithContext(ctx)
+	dprequest.AddServiceTokenHeader(r, testServiceAuthToken)
 	return r
 }
 
@@ -71,7 +87,7 @@ func TestGetDatasetsReturnsOK(t *testing.T) {
 	t.Parallel()
 
 	Convey("A successful request to get dataset returns 200 OK response, and limit and offset are delegated to the datastore", t, func() {
-		r := &http.Request{}
+		r := httptest.NewRequest("GET", "http://localhost:22000/datasets", nil)
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
@@ -97,7 +113,7 @@ func TestGetDatasetsReturnsOK(t *testing.T) {
 func TestGetDatasetsReturnsError(t *testing.T) {
 	t.Parallel()
 	Convey("When the api cannot connect to datastore return an internal server error", t, func() {
-		r := &http.Request{}
+		r := httptest.NewRequest("GET", "http://localhost:22000/datasets", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
 			GetDatasetsFunc: func(ctx context.Context, offset, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
@@ -118,7 +134,7 @@ func TestGetDatasetsReturnsError(t *testing.T) {
 		So(actualTotalCount, ShouldEqual, 0)
 		So(err, ShouldEqual, errs.ErrInternalServer)
 		So(w.Code, ShouldEqual, http.StatusInternalServerError)
-		So(string(w.Body.Bytes()), ShouldEqual, "internal error\n")
+		So(string(w.Body.Bytes()), ShouldEqual, `{"title":"Internal Server Error","status":500,"code":"internal_error","detail":"internal error"}`)
 	})
 }
 
@@ -128,7 +144,7 @@ func TestGetDatasetReturnsOK(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123-456", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(id string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{ID: "123", Current: &models.Dataset{ID: "123"}}, nil
 			},
 		}
@@ -149,7 +165,7 @@ func TestGetDatasetReturnsOK(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(id string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{ID: "123"}}, nil
 			},
 		}
@@ -164,6 +180,25 @@ func TestGetDatasetReturnsOK(t *testing.T) {
 		So(datasetPermissions.Required.Calls, ShouldEqual, 1)
 		So(permissions.Required.Calls, ShouldEqual, 0)
 	})
+
+	Convey("When a dataset is found the ETag header is set to the dataset eTag", t, func() {
+		r := createRequestWithAuth("GET", "http://localhost:22000/datasets/123-456", nil)
+
+		w := httptest.NewRecorder()
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{ID: "123"}, ETag: testETag}, nil
+			},
+		}
+
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusOK)
+		So(w.Header().Get("ETag"), ShouldEqual, `"`+testETag+`"`)
+	})
 }
 
 func TestGetDatasetReturnsError(t *testing.T) {
@@ -172,7 +207,7 @@ func TestGetDatasetReturnsError(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123-456", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(id string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrInternalServer
 			},
 		}
@@ -192,7 +227,7 @@ func TestGetDatasetReturnsError(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123-456", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(id string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{ID: "123"}}, nil
 			},
 		}
@@ -214,7 +249,7 @@ func TestGetDatasetReturnsError(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(id string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
 		}
@@ -240,17 +275,23 @@ func TestPostDatasetsReturnsCreated(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpsertDatasetFunc: func(id string, datasetDoc *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(ctx context.Context, id string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
 				return nil
 			},
 		}
 
 		datasetPermissions := getAuthorisationHandlerMock()
 		permissions := getAuthorisationHandlerMock()
-		mockedDataStore.UpsertDataset("123", &models.DatasetUpdate{Next: &models.Dataset{}})
+		mockedDataStore.UpsertDataset(context.Background(), "123", &models.DatasetUpdate{Next: &models.Dataset{}}, mongo.AnyETag)
 		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
 		api.Router.ServeHTTP(w, r)
 
@@ -259,6 +300,8 @@ func TestPostDatasetsReturnsCreated(t *testing.T) {
 		So(permissions.Required.Calls, ShouldEqual, 0)
 		So(len(mockedDataStore.GetDatasetCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 2)
+		So(len(mockedDataStore.GetTopicCalls()), ShouldEqual, 1)
+		So(mockedDataStore.GetTopicCalls()[0].ID, ShouldEqual, "population")
 
 		Convey("then the request body has been drained", func() {
 			_, err := r.Body.Read(make([]byte, 1))
@@ -268,23 +311,29 @@ func TestPostDatasetsReturnsCreated(t *testing.T) {
 
 	Convey("When creating the dataset with an empty QMI url returns 201 success", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "", "title": "test"}}`
 
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpsertDatasetFunc: func(id string, datasetDoc *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(ctx context.Context, id string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
 				return nil
 			},
 		}
 
 		datasetPermissions := getAuthorisationHandlerMock()
 		permissions := getAuthorisationHandlerMock()
-		mockedDataStore.UpsertDataset("123", &models.DatasetUpdate{Next: &models.Dataset{}})
+		mockedDataStore.UpsertDataset(context.Background(), "123", &models.DatasetUpdate{Next: &models.Dataset{}}, mongo.AnyETag)
 		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
 		api.Router.ServeHTTP(w, r)
 
@@ -302,23 +351,29 @@ func TestPostDatasetsReturnsCreated(t *testing.T) {
 
 	Convey("When creating the dataset with a valid QMI url (path in appropriate url format) returns 201 success", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http://domain.com/path", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http://domain.com/path", "title": "test"}}`
 
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpsertDatasetFunc: func(id string, datasetDoc *models.DatasetUpdate) error {
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			UpsertDatasetFunc: func(ctx context.Context, id string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
 				return nil
 			},
 		}
 
 		datasetPermissions := getAuthorisationHandlerMock()
 		permissions := getAuthorisationHandlerMock()
-		mockedDataStore.UpsertDataset("123", &models.DatasetUpdate{Next: &models.Dataset{}})
+		mockedDataStore.UpsertDataset(context.Background(), "123", &models.DatasetUpdate{Next: &models.Dataset{}}, mongo.AnyETag)
 		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
 		api.Router.ServeHTTP(w, r)
 
@@ -336,23 +391,29 @@ func TestPostDatasetsReturnsCreated(t *testing.T) {
 
 	Convey("When creating the dataset with a valid QMI url (relative path) returns 201 success", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "/path", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "/path", "title": "test"}}`
 
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpsertDatasetFunc: func(id string, datasetDoc *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(ctx context.Context, id string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
 				return nil
 			},
 		}
 
 		datasetPermissions := getAuthorisationHandlerMock()
 		permissions := getAuthorisationHandlerMock()
-		mockedDataStore.UpsertDataset("123", &models.DatasetUpdate{Next: &models.Dataset{}})
+		mockedDataStore.UpsertDataset(context.Background(), "123", &models.DatasetUpdate{Next: &models.Dataset{}}, mongo.AnyETag)
 		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
 		api.Router.ServeHTTP(w, r)
 
@@ -370,23 +431,29 @@ func TestPostDatasetsReturnsCreated(t *testing.T) {
 
 	Convey("When creating the dataset with a valid QMI url (valid host but an empty path) returns 201 success", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http://domain.com/", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http://domain.com/", "title": "test"}}`
 
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpsertDatasetFunc: func(id string, datasetDoc *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(ctx context.Context, id string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
 				return nil
 			},
 		}
 
 		datasetPermissions := getAuthorisationHandlerMock()
 		permissions := getAuthorisationHandlerMock()
-		mockedDataStore.UpsertDataset("123", &models.DatasetUpdate{Next: &models.Dataset{}})
+		mockedDataStore.UpsertDataset(context.Background(), "123", &models.DatasetUpdate{Next: &models.Dataset{}}, mongo.AnyETag)
 		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
 		api.Router.ServeHTTP(w, r)
 
@@ -404,23 +471,29 @@ func TestPostDatasetsReturnsCreated(t *testing.T) {
 
 	Convey("When creating the dataset with a valid QMI url (only a valid domain) returns 201 success", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "domain.com", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "domain.com", "title": "test"}}`
 
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpsertDatasetFunc: func(id string, datasetDoc *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(ctx context.Context, id string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
 				return nil
 			},
 		}
 
 		datasetPermissions := getAuthorisationHandlerMock()
 		permissions := getAuthorisationHandlerMock()
-		mockedDataStore.UpsertDataset("123", &models.DatasetUpdate{Next: &models.Dataset{}})
+		mockedDataStore.UpsertDataset(context.Background(), "123", &models.DatasetUpdate{Next: &models.Dataset{}}, mongo.AnyETag)
 		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
 		api.Router.ServeHTTP(w, r)
 
@@ -435,6 +508,32 @@ func TestPostDatasetsReturnsCreated(t *testing.T) {
 			So(err, ShouldEqual, io.EOF)
 		})
 	})
+
+	Convey("When creating the dataset with a taxonomy link but without a theme returns 201 success without the link", t, func() {
+		b := `{"title": "CensusEthnicity", "state": "completed", "links": {"taxonomy": {"href": "http://localhost:22000/topics/population"}}}`
+		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
+
+		w := httptest.NewRecorder()
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			UpsertDatasetFunc: func(ctx context.Context, id string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
+				return nil
+			},
+		}
+
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusCreated)
+		So(len(mockedDataStore.GetTopicCalls()), ShouldEqual, 0)
+		So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 1)
+		So(mockedDataStore.UpsertDatasetCalls()[0].DatasetDoc.Next.Links.Taxonomy, ShouldBeNil)
+	})
 }
 
 func TestPostDatasetReturnsError(t *testing.T) {
@@ -446,10 +545,16 @@ func TestPostDatasetReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpsertDatasetFunc: func(string, *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate, string) error {
 				return errs.ErrAddUpdateDatasetBadRequest
 			},
 		}
@@ -463,7 +568,7 @@ func TestPostDatasetReturnsError(t *testing.T) {
 		So(datasetPermissions.Required.Calls, ShouldEqual, 1)
 		So(permissions.Required.Calls, ShouldEqual, 0)
 		So(w.Body.String(), ShouldContainSubstring, errs.ErrUnableToParseJSON.Error())
-		So(len(mockedDataStore.GetDatasetCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.GetDatasetCalls()), ShouldEqual, 0)
 		So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 0)
 
 		Convey("then the request body has been drained", func() {
@@ -479,10 +584,13 @@ func TestPostDatasetReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrInternalServer
 			},
-			UpsertDatasetFunc: func(string, *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate, string) error {
 				return nil
 			},
 		}
@@ -504,16 +612,48 @@ func TestPostDatasetReturnsError(t *testing.T) {
 		})
 	})
 
+	Convey("When the dataset has been deleted but not yet purged return status forbidden", t, func() {
+		var b string
+		b = datasetPayload
+		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
+
+		w := httptest.NewRecorder()
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{State: models.DeletedState}}, nil
+			},
+		}
+
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusForbidden)
+		So(w.Body.String(), ShouldContainSubstring, errs.ErrAddDatasetAlreadyExists.Error())
+		So(len(mockedDataStore.GetDeletedDatasetCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 0)
+	})
+
 	Convey("When the request does not contain a valid internal token returns 401", t, func() {
 		var b string
 		b = datasetPayload
 		r := httptest.NewRequest("POST", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpsertDatasetFunc: func(string, *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate, string) error {
 				return nil
 			},
 		}
@@ -542,14 +682,17 @@ func TestPostDatasetReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{
 					ID:      "123",
 					Next:    &models.Dataset{},
 					Current: &models.Dataset{},
 				}, nil
 			},
-			UpsertDatasetFunc: func(string, *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate, string) error {
 				return nil
 			},
 		}
@@ -562,7 +705,7 @@ func TestPostDatasetReturnsError(t *testing.T) {
 		So(w.Code, ShouldEqual, http.StatusForbidden)
 		So(datasetPermissions.Required.Calls, ShouldEqual, 1)
 		So(permissions.Required.Calls, ShouldEqual, 0)
-		So(w.Body.String(), ShouldResemble, "forbidden - dataset already exists\n")
+		So(w.Body.String(), ShouldResemble, `{"title":"Forbidden","status":403,"code":"dataset_already_exists","detail":"forbidden - dataset already exists"}`)
 		So(len(mockedDataStore.GetDatasetCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 0)
 
@@ -574,16 +717,22 @@ func TestPostDatasetReturnsError(t *testing.T) {
 
 	Convey("When the request has an filterable datatype and nomis url it should return type mismatch error", t, func() {
 		var b string
-		b = `{"contacts":[{"email":"testing@hotmail.com","name":"John Cox","telephone":"01623 456789"}],"description":"census","links":{"access_rights":{"href":"http://ons.gov.uk/accessrights"}},"title":"CensusEthnicity","theme":"population","state":"completed","next_release":"2016-04-04","publisher":{"name":"The office of national statistics","type":"government department","url":"https://www.ons.gov.uk/"},"type":"filterable","nomis_reference_url":"https://www.nomis.co.uk"}`
+		b = `{"contacts":[{"email":"testing@hotmail.com","name":"John Cox","telephone":"01623 456789"}],"description":"census","links":{"access_rights":{"href":"http://ons.gov.uk/accessrights"}},"title":"CensusEthnicity","theme":"population","state":"completed","next_release":"2016-04-04","publisher":{"name":"The office of national statistics","type":"government department","href":"https://www.ons.gov.uk/"},"type":"filterable","nomis_reference_url":"https://www.nomis.co.uk"}`
 
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpsertDatasetFunc: func(string, *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate, string) error {
 				return nil
 			},
 		}
@@ -593,7 +742,7 @@ func TestPostDatasetReturnsError(t *testing.T) {
 		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
 		api.Router.ServeHTTP(w, r)
 
-		So(w.Body.String(), ShouldResemble, "type mismatch\n")
+		So(w.Body.String(), ShouldResemble, `{"title":"Bad Request","status":400,"code":"dataset_type_mismatch","detail":"type mismatch"}`)
 		So(mockedDataStore.GetDatasetCalls(), ShouldHaveLength, 1)
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
 		So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 0)
@@ -606,16 +755,22 @@ func TestPostDatasetReturnsError(t *testing.T) {
 
 	Convey("When creating the dataset with invalid QMI url (invalid character) returns bad request", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": ":not a link", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": ":not a link", "title": "test"}}`
 
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpsertDatasetFunc: func(string, *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate, string) error {
 				return nil
 			},
 		}
@@ -625,7 +780,7 @@ func TestPostDatasetReturnsError(t *testing.T) {
 		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
 		api.Router.ServeHTTP(w, r)
 
-		So(w.Body.String(), ShouldResemble, "invalid fields: [QMI]\n")
+		So(w.Body.String(), ShouldResemble, `{"title":"Bad Request","status":400,"code":"invalid_fields","detail":"invalid fields: [QMI]","errors":[{"field":"QMI","message":"invalid fields"}]}`)
 		So(mockedDataStore.GetDatasetCalls(), ShouldHaveLength, 1)
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
 		So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 0)
@@ -638,16 +793,22 @@ func TestPostDatasetReturnsError(t *testing.T) {
 
 	Convey("When creating the dataset with invalid QMI url (scheme only) returns bad request", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http://", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http://", "title": "test"}}`
 
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpsertDatasetFunc: func(string, *models.DatasetUpdate) error {
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate, string) error {
 				return nil
 			},
 		}
@@ -657,7 +818,7 @@ func TestPostDatasetReturnsError(t *testing.T) {
 		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
 		api.Router.ServeHTTP(w, r)
 
-		So(w.Body.String(), ShouldResemble, "invalid fields: [QMI]\n")
+		So(w.Body.String(), ShouldResemble, `{"title":"Bad Request","status":400,"code":"invalid_fields","detail":"invalid fields: [QMI]","errors":[{"field":"QMI","message":"invalid fields"}]}`)
 		So(mockedDataStore.GetDatasetCalls(), ShouldHaveLength, 1)
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
 		So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 0)
@@ -670,16 +831,22 @@ func TestPostDatasetReturnsError(t *testing.T) {
 
 	Convey("When creating the dataset with invalid QMI url (scheme and path only) returns bad request", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http:///path", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http:///path", "title": "test"}}`
 
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpsertDatasetFunc: func(string, *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate, string) error {
 				return nil
 			},
 		}
@@ -689,7 +856,7 @@ func TestPostDatasetReturnsError(t *testing.T) {
 		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
 		api.Router.ServeHTTP(w, r)
 
-		So(w.Body.String(), ShouldResemble, "invalid fields: [QMI]\n")
+		So(w.Body.String(), ShouldResemble, `{"title":"Bad Request","status":400,"code":"invalid_fields","detail":"invalid fields: [QMI]","errors":[{"field":"QMI","message":"invalid fields"}]}`)
 		So(mockedDataStore.GetDatasetCalls(), ShouldHaveLength, 1)
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
 		So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 0)
@@ -702,16 +869,22 @@ func TestPostDatasetReturnsError(t *testing.T) {
 
 	Convey("When the request has an invalid datatype it should return invalid type errorq", t, func() {
 		var b string
-		b = `{"contacts":[{"email":"testing@hotmail.com","name":"John Cox","telephone":"01623 456789"}],"description":"census","links":{"access_rights":{"href":"http://ons.gov.uk/accessrights"}},"title":"CensusEthnicity","theme":"population","state":"completed","next_release":"2016-04-04","publisher":{"name":"The office of national statistics","type":"government department","url":"https://www.ons.gov.uk/"},"type":"nomis_filterable"}`
+		b = `{"contacts":[{"email":"testing@hotmail.com","name":"John Cox","telephone":"01623 456789"}],"description":"census","links":{"access_rights":{"href":"http://ons.gov.uk/accessrights"}},"title":"CensusEthnicity","theme":"population","state":"completed","next_release":"2016-04-04","publisher":{"name":"The office of national statistics","type":"government department","href":"https://www.ons.gov.uk/"},"type":"nomis_filterable"}`
 
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpsertDatasetFunc: func(string, *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate, string) error {
 				return nil
 			},
 		}
@@ -722,8 +895,8 @@ func TestPostDatasetReturnsError(t *testing.T) {
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
-		So(w.Body.String(), ShouldResemble, "invalid dataset type\n")
-		So(mockedDataStore.GetDatasetCalls(), ShouldHaveLength, 1)
+		So(w.Body.String(), ShouldResemble, `{"title":"Bad Request","status":400,"code":"invalid_body","detail":"invalid request body","errors":[{"field":"type","message":"value is not one of the allowed values"}]}`)
+		So(mockedDataStore.GetDatasetCalls(), ShouldHaveLength, 0)
 		So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 0)
 
 		Convey("then the request body has been drained", func() {
@@ -734,23 +907,29 @@ func TestPostDatasetReturnsError(t *testing.T) {
 
 	Convey("When the request body has an empty type field it should create a dataset with type defaulted to filterable", t, func() {
 		var b string
-		b = `{"contacts":[{"email":"testing@hotmail.com","name":"John Cox","telephone":"01623 456789"}],"description":"census","links":{"access_rights":{"href":"http://ons.gov.uk/accessrights"}},"title":"CensusEthnicity","theme":"population","state":"completed","next_release":"2016-04-04","publisher":{"name":"The office of national statistics","type":"government department","url":"https://www.ons.gov.uk/"},"type":""}`
-		res := `{"id":"123123","next":{"contacts":[{"email":"testing@hotmail.com","name":"John Cox","telephone":"01623 456789"}],"description":"census","id":"123123","links":{"access_rights":{"href":"http://ons.gov.uk/accessrights"},"editions":{"href":"http://localhost:22000/datasets/123123/editions"},"self":{"href":"http://localhost:22000/datasets/123123"}},"next_release":"2016-04-04","publisher":{"name":"The office of national statistics","type":"government department"},"state":"created","theme":"population","title":"CensusEthnicity","type":"filterable"}}`
+		b = `{"contacts":[{"email":"testing@hotmail.com","name":"John Cox","telephone":"01623 456789"}],"description":"census","links":{"access_rights":{"href":"http://ons.gov.uk/accessrights"}},"title":"CensusEthnicity","theme":"population","state":"completed","next_release":"2016-04-04","publisher":{"name":"The office of national statistics","type":"government department","href":"https://www.ons.gov.uk/"},"type":""}`
+		res := `{"id":"123123","next":{"contacts":[{"email":"testing@hotmail.com","name":"John Cox","telephone":"01623 456789"}],"description":"census","id":"123123","links":{"access_rights":{"href":"http://ons.gov.uk/accessrights"},"editions":{"href":"http://localhost:22000/datasets/123123/editions"},"self":{"href":"http://localhost:22000/datasets/123123"},"taxonomy":{"href":"http://localhost:22000/topics/population","id":"population"}},"next_release":"2016-04-04","publisher":{"href":"https://www.ons.gov.uk/","name":"The office of national statistics","type":"government department"},"state":"created","theme":"population","title":"CensusEthnicity","type":"filterable"}}`
 		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpsertDatasetFunc: func(string, *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate, string) error {
 				return nil
 			},
 		}
 
 		datasetPermissions := getAuthorisationHandlerMock()
 		permissions := getAuthorisationHandlerMock()
-		mockedDataStore.UpsertDataset("123123", &models.DatasetUpdate{Next: &models.Dataset{}})
+		mockedDataStore.UpsertDataset(context.Background(), "123123", &models.DatasetUpdate{Next: &models.Dataset{}}, mongo.AnyETag)
 		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
 		api.Router.ServeHTTP(w, r)
 
@@ -765,6 +944,32 @@ func TestPostDatasetReturnsError(t *testing.T) {
 		})
 	})
 
+	Convey("When the dataset theme is not an existing topic return status bad request", t, func() {
+		r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123", bytes.NewBufferString(datasetPayload))
+
+		w := httptest.NewRecorder()
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return nil, errs.ErrTopicNotFound
+			},
+		}
+
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusBadRequest)
+		So(w.Body.String(), ShouldContainSubstring, errs.ErrDatasetThemeInvalid.Error())
+		So(mockedDataStore.GetTopicCalls(), ShouldHaveLength, 1)
+		So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 0)
+	})
 }
 
 func TestPutDatasetReturnsSuccessfully(t *testing.T) {
@@ -776,11 +981,14 @@ func TestPutDatasetReturnsSuccessfully(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{Type: "nomis"}}, nil
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return nil
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -790,7 +998,7 @@ func TestPutDatasetReturnsSuccessfully(t *testing.T) {
 		dataset := &models.Dataset{
 			Title: "CPI",
 		}
-		mockedDataStore.UpdateDataset(testContext, "123", dataset, models.CreatedState)
+		mockedDataStore.UpdateDataset(testContext, &models.DatasetUpdate{ID: "123", Next: &models.Dataset{State: models.CreatedState}}, dataset, mongo.AnyETag)
 
 		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
 		api.Router.ServeHTTP(w, r)
@@ -811,18 +1019,21 @@ func TestPutDatasetReturnsSuccessfully(t *testing.T) {
 		// Dataset type field cannot be updated and hence is ignored in any updates to the dataset
 
 		var b string
-		b = `{"contacts":[{"email":"testing@hotmail.com","name":"John Cox","telephone":"01623 456789"}],"description":"census","links":{"access_rights":{"href":"http://ons.gov.uk/accessrights"}},"title":"CensusEthnicity","theme":"population","state":"completed","next_release":"2016-04-04","publisher":{"name":"The office of national statistics","type":"government department","url":"https://www.ons.gov.uk/"},"type":"filterable","nomis_reference_url":"https://www.nomis.co.uk"}`
+		b = `{"contacts":[{"email":"testing@hotmail.com","name":"John Cox","telephone":"01623 456789"}],"description":"census","links":{"access_rights":{"href":"http://ons.gov.uk/accessrights"}},"title":"CensusEthnicity","theme":"population","state":"completed","next_release":"2016-04-04","publisher":{"name":"The office of national statistics","type":"government department","href":"https://www.ons.gov.uk/"},"type":"filterable","nomis_reference_url":"https://www.nomis.co.uk"}`
 
 		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{Type: "nomis"}}, nil
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return nil
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -843,18 +1054,21 @@ func TestPutDatasetReturnsSuccessfully(t *testing.T) {
 
 	Convey("When updating the dataset with an empty QMI url returns 200 success", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "", "title": "test"}}`
 
 		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{Type: "nomis"}}, nil
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return nil
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -876,18 +1090,21 @@ func TestPutDatasetReturnsSuccessfully(t *testing.T) {
 
 	Convey("When updating the dataset with a valid QMI url (path in appropriate url format) returns 200 success", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http://domain.com/path", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http://domain.com/path", "title": "test"}}`
 
 		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{Type: "nomis"}}, nil
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return nil
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -909,18 +1126,21 @@ func TestPutDatasetReturnsSuccessfully(t *testing.T) {
 
 	Convey("When updating the dataset with a valid QMI url (relative path) returns 200 success", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "/path", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "/path", "title": "test"}}`
 
 		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{Type: "nomis"}}, nil
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return nil
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -942,18 +1162,21 @@ func TestPutDatasetReturnsSuccessfully(t *testing.T) {
 
 	Convey("When updating the dataset with a valid QMI url (valid host but an empty path) returns 200 success", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http://domain.com/", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http://domain.com/", "title": "test"}}`
 
 		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{Type: "nomis"}}, nil
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return nil
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -975,18 +1198,21 @@ func TestPutDatasetReturnsSuccessfully(t *testing.T) {
 
 	Convey("When updating the dataset with a valid QMI url (only a valid domain) returns 200 success", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "domain.com", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "domain.com", "title": "test"}}`
 
 		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{Type: "nomis"}}, nil
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return nil
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -1005,6 +1231,34 @@ func TestPutDatasetReturnsSuccessfully(t *testing.T) {
 			So(err, ShouldEqual, io.EOF)
 		})
 	})
+
+	Convey("When the If-Match header matches the dataset eTag the dataset is updated and the new eTag is returned", t, func() {
+		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(datasetPayload))
+		r.Header.Set("If-Match", testETag)
+
+		w := httptest.NewRecorder()
+		mockedDataStore := &storetest.StorerMock{
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{Type: "nomis"}, ETag: testETag}, nil
+			},
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "newETag", nil
+			},
+		}
+
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusOK)
+		So(w.Header().Get("ETag"), ShouldEqual, `"newETag"`)
+		So(mockedDataStore.UpdateDatasetCalls(), ShouldHaveLength, 1)
+		So(mockedDataStore.UpdateDatasetCalls()[0].ETagSelector, ShouldEqual, testETag)
+	})
 }
 
 func TestPutDatasetReturnsError(t *testing.T) {
@@ -1021,11 +1275,14 @@ func TestPutDatasetReturnsError(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{}}, nil
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return errs.ErrAddUpdateDatasetBadRequest
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", errs.ErrAddUpdateDatasetBadRequest
 			},
 		}
 
@@ -1047,17 +1304,20 @@ func TestPutDatasetReturnsError(t *testing.T) {
 
 	Convey("When the api cannot connect to datastore return an internal server error", t, func() {
 		var b string
-		b = versionPayload
+		b = `{"title":"CPI"}`
 		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{State: models.CreatedState}}, nil
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return errs.ErrInternalServer
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", errs.ErrInternalServer
 			},
 		}
 
@@ -1067,7 +1327,7 @@ func TestPutDatasetReturnsError(t *testing.T) {
 
 		datasetPermissions := getAuthorisationHandlerMock()
 		permissions := getAuthorisationHandlerMock()
-		mockedDataStore.UpdateDataset(testContext, "123", dataset, models.CreatedState)
+		mockedDataStore.UpdateDataset(testContext, &models.DatasetUpdate{ID: "123", Next: &models.Dataset{State: models.CreatedState}}, dataset, mongo.AnyETag)
 		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
 
 		api.Router.ServeHTTP(w, r)
@@ -1092,11 +1352,14 @@ func TestPutDatasetReturnsError(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return errs.ErrDatasetNotFound
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", errs.ErrDatasetNotFound
 			},
 		}
 
@@ -1121,18 +1384,21 @@ func TestPutDatasetReturnsError(t *testing.T) {
 
 	Convey("When updating the dataset nomis_reference_url and the stored dataset type is not nomis return bad request", t, func() {
 		var b string
-		b = `{"contacts":[{"email":"testing@hotmail.com","name":"John Cox","telephone":"01623 456789"}],"description":"census","links":{"access_rights":{"href":"http://ons.gov.uk/accessrights"}},"title":"CensusEthnicity","theme":"population","state":"completed","next_release":"2016-04-04","publisher":{"name":"The office of national statistics","type":"government department","url":"https://www.ons.gov.uk/"},"nomis_reference_url":"https://www.nomis.co.uk"}`
+		b = `{"contacts":[{"email":"testing@hotmail.com","name":"John Cox","telephone":"01623 456789"}],"description":"census","links":{"access_rights":{"href":"http://ons.gov.uk/accessrights"}},"title":"CensusEthnicity","theme":"population","state":"completed","next_release":"2016-04-04","publisher":{"name":"The office of national statistics","type":"government department","href":"https://www.ons.gov.uk/"},"nomis_reference_url":"https://www.nomis.co.uk"}`
 
 		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{Type: "filterable"}}, nil
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return nil
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -1143,7 +1409,7 @@ func TestPutDatasetReturnsError(t *testing.T) {
 
 		api.Router.ServeHTTP(w, r)
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
-		So(w.Body.String(), ShouldResemble, errs.ErrTypeMismatch.Error()+"\n")
+		So(w.Body.String(), ShouldResemble, `{"title":"Bad Request","status":400,"code":"dataset_type_mismatch","detail":"type mismatch"}`)
 		So(mockedDataStore.GetDatasetCalls(), ShouldHaveLength, 1)
 		So(mockedDataStore.UpdateDatasetCalls(), ShouldHaveLength, 0)
 
@@ -1155,18 +1421,21 @@ func TestPutDatasetReturnsError(t *testing.T) {
 
 	Convey("When updating the dataset with invalid QMI url (invalid character) returns bad request", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": ":not a link", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": ":not a link", "title": "test"}}`
 
 		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{Type: "nomis"}}, nil
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return nil
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -1177,7 +1446,7 @@ func TestPutDatasetReturnsError(t *testing.T) {
 
 		api.Router.ServeHTTP(w, r)
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
-		So(w.Body.String(), ShouldResemble, "invalid fields: [QMI]\n")
+		So(w.Body.String(), ShouldResemble, `{"title":"Bad Request","status":400,"code":"invalid_fields","detail":"invalid fields: [QMI]","errors":[{"field":"QMI","message":"invalid fields"}]}`)
 		So(mockedDataStore.GetDatasetCalls(), ShouldHaveLength, 1)
 		So(mockedDataStore.UpdateDatasetCalls(), ShouldHaveLength, 0)
 
@@ -1189,18 +1458,21 @@ func TestPutDatasetReturnsError(t *testing.T) {
 
 	Convey("When updating the dataset with invalid QMI url (scheme only) returns bad request", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http://", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http://", "title": "test"}}`
 
 		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{Type: "nomis"}}, nil
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return nil
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -1211,7 +1483,7 @@ func TestPutDatasetReturnsError(t *testing.T) {
 
 		api.Router.ServeHTTP(w, r)
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
-		So(w.Body.String(), ShouldResemble, "invalid fields: [QMI]\n")
+		So(w.Body.String(), ShouldResemble, `{"title":"Bad Request","status":400,"code":"invalid_fields","detail":"invalid fields: [QMI]","errors":[{"field":"QMI","message":"invalid fields"}]}`)
 		So(mockedDataStore.GetDatasetCalls(), ShouldHaveLength, 1)
 		So(mockedDataStore.UpdateDatasetCalls(), ShouldHaveLength, 0)
 
@@ -1223,18 +1495,21 @@ func TestPutDatasetReturnsError(t *testing.T) {
 
 	Convey("When updating the dataset with invalid QMI url (scheme and path only) returns bad request", t, func() {
 		var b string
-		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "url": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http:///path", "title": "test"}}`
+		b = `{"contacts": [{"email": "testing@hotmail.com", "name": "John Cox", "telephone": "01623 456789"}], "description": "census", "links": {"access_rights": {"href": "http://ons.gov.uk/accessrights"}}, "title": "CensusEthnicity", "theme": "population", "state": "completed", "next_release": "2016-04-04", "publisher": {"name": "The office of national statistics", "type": "government department", "href": "https://www.ons.gov.uk/"}, "type": "nomis", "nomis_reference_url": "https://www.nomis.co.uk", "qmi": {"href": "http:///path", "title": "test"}}`
 
 		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{Type: "nomis"}}, nil
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return nil
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -1245,7 +1520,7 @@ func TestPutDatasetReturnsError(t *testing.T) {
 
 		api.Router.ServeHTTP(w, r)
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
-		So(w.Body.String(), ShouldResemble, "invalid fields: [QMI]\n")
+		So(w.Body.String(), ShouldResemble, `{"title":"Bad Request","status":400,"code":"invalid_fields","detail":"invalid fields: [QMI]","errors":[{"field":"QMI","message":"invalid fields"}]}`)
 		So(mockedDataStore.GetDatasetCalls(), ShouldHaveLength, 1)
 		So(mockedDataStore.UpdateDatasetCalls(), ShouldHaveLength, 0)
 
@@ -1263,11 +1538,14 @@ func TestPutDatasetReturnsError(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{}}, nil
 			},
-			UpdateDatasetFunc: func(context.Context, string, *models.Dataset, string) error {
-				return nil
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -1289,24 +1567,127 @@ func TestPutDatasetReturnsError(t *testing.T) {
 			So(err, ShouldEqual, io.EOF)
 		})
 	})
+
+	Convey("When the If-Match header does not match the dataset eTag return status conflict", t, func() {
+		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(datasetPayload))
+		r.Header.Set("If-Match", "wrongETag")
+
+		w := httptest.NewRecorder()
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{Type: "nomis"}, ETag: testETag}, nil
+			},
+		}
+
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusConflict)
+		So(w.Body.String(), ShouldContainSubstring, errs.ErrDatasetConflict.Error())
+		So(len(mockedDataStore.GetDatasetCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.UpdateDatasetCalls()), ShouldEqual, 0)
+	})
+
+	Convey("When the dataset is modified concurrently return status conflict", t, func() {
+		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(datasetPayload))
+
+		w := httptest.NewRecorder()
+		mockedDataStore := &storetest.StorerMock{
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{Type: "nomis"}, ETag: testETag}, nil
+			},
+			UpdateDatasetFunc: func(context.Context, *models.DatasetUpdate, *models.Dataset, string) (string, error) {
+				return "", errs.ErrDatasetConflict
+			},
+		}
+
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusConflict)
+		So(len(mockedDataStore.UpdateDatasetCalls()), ShouldEqual, 1)
+		So(mockedDataStore.UpdateDatasetCalls()[0].ETagSelector, ShouldEqual, mongo.AnyETag)
+	})
+
+	Convey("When the dataset is modified concurrently while it is published return status conflict", t, func() {
+		b := strings.Replace(datasetPayload, `"state":"completed"`, `"state":"published"`, 1)
+		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
+		r.Header.Set("If-Match", testETag)
+
+		w := httptest.NewRecorder()
+		mockedDataStore := &storetest.StorerMock{
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Population"}, nil
+			},
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{Type: "nomis"}, ETag: testETag}, nil
+			},
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate, string) error {
+				return errs.ErrDatasetConflict
+			},
+		}
+
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusConflict)
+		So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 1)
+		So(mockedDataStore.UpsertDatasetCalls()[0].ETagSelector, ShouldEqual, testETag)
+	})
+
+	Convey("When the dataset theme is not an existing topic return status bad request", t, func() {
+		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123", bytes.NewBufferString(datasetPayload))
+
+		w := httptest.NewRecorder()
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{Type: "nomis"}}, nil
+			},
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return nil, errs.ErrTopicNotFound
+			},
+		}
+
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusBadRequest)
+		So(w.Body.String(), ShouldContainSubstring, errs.ErrDatasetThemeInvalid.Error())
+		So(mockedDataStore.GetTopicCalls(), ShouldHaveLength, 1)
+		So(mockedDataStore.UpdateDatasetCalls(), ShouldHaveLength, 0)
+	})
 }
 
 func TestDeleteDatasetReturnsSuccessfully(t *testing.T) {
 	t.Parallel()
-	Convey("A successful request to delete dataset returns 200 OK response", t, func() {
+	Convey("A successful request to delete dataset returns 204 No Content response", t, func() {
 		r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123", nil)
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{State: models.CreatedState}}, nil
 			},
-			GetEditionsFunc: func(ctx context.Context, ID string, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
-				return []*models.EditionUpdate{}, 0, nil
+			GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+				return []*models.Instance{}, nil
 			},
-			DeleteDatasetFunc: func(string) error {
+			SoftDeleteDatasetFunc: func(ctx context.Context, datasetID, eTagSelector, deletedBy string, deletedAt time.Time) error {
 				return nil
 			},
+			GetDatasetsRelatedToFunc: func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+				return []*models.DatasetUpdate{}, nil
+			},
 		}
 
 		datasetPermissions := getAuthorisationHandlerMock()
@@ -1318,28 +1699,26 @@ func TestDeleteDatasetReturnsSuccessfully(t *testing.T) {
 		So(datasetPermissions.Required.Calls, ShouldEqual, 1)
 		So(permissions.Required.Calls, ShouldEqual, 0)
 		So(len(mockedDataStore.GetDatasetCalls()), ShouldEqual, 1)
-		So(len(mockedDataStore.GetEditionsCalls()), ShouldEqual, 1)
-		So(len(mockedDataStore.DeleteDatasetCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.SoftDeleteDatasetCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.DeleteDatasetCalls()), ShouldEqual, 0)
 	})
 
-	Convey("A successful request to delete dataset with editions returns 200 OK response", t, func() {
+	Convey("A successful request to delete dataset with unpublished versions returns 204 No Content response", t, func() {
 		r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123", nil)
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{State: models.CreatedState}}, nil
 			},
-			GetEditionsFunc: func(ctx context.Context, ID string, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
-				var items []*models.EditionUpdate
-				items = append(items, &models.EditionUpdate{})
-				return items, 0, nil
+			GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+				return []*models.Instance{{InstanceID: "456", State: models.EditionConfirmedState}}, nil
 			},
-			DeleteEditionFunc: func(ID string) error {
+			SoftDeleteDatasetFunc: func(ctx context.Context, datasetID, eTagSelector, deletedBy string, deletedAt time.Time) error {
 				return nil
 			},
-			DeleteDatasetFunc: func(string) error {
-				return nil
+			GetDatasetsRelatedToFunc: func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+				return []*models.DatasetUpdate{}, nil
 			},
 		}
 
@@ -1352,9 +1731,8 @@ func TestDeleteDatasetReturnsSuccessfully(t *testing.T) {
 		So(datasetPermissions.Required.Calls, ShouldEqual, 1)
 		So(permissions.Required.Calls, ShouldEqual, 0)
 		So(len(mockedDataStore.GetDatasetCalls()), ShouldEqual, 1)
-		So(len(mockedDataStore.GetEditionsCalls()), ShouldEqual, 1)
-		So(len(mockedDataStore.DeleteEditionCalls()), ShouldEqual, 1)
-		So(len(mockedDataStore.DeleteDatasetCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.GetDatasetInstancesCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.SoftDeleteDatasetCalls()), ShouldEqual, 1)
 	})
 }
 
@@ -1365,15 +1743,21 @@ func TestDeleteDatasetReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Current: &models.Dataset{State: models.PublishedState}}, nil
 			},
 			GetEditionsFunc: func(ctx context.Context, ID string, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
 				return []*models.EditionUpdate{}, 0, nil
 			},
-			DeleteDatasetFunc: func(string) error {
+			GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+				return []*models.Instance{}, nil
+			},
+			DeleteDatasetFunc: func(context.Context, string) error {
 				return nil
 			},
+			GetDatasetsRelatedToFunc: func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+				return []*models.DatasetUpdate{}, nil
+			},
 		}
 
 		datasetPermissions := getAuthorisationHandlerMock()
@@ -1396,15 +1780,21 @@ func TestDeleteDatasetReturnsError(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Next: &models.Dataset{State: models.CreatedState}}, nil
 			},
 			GetEditionsFunc: func(ctx context.Context, ID string, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
 				return []*models.EditionUpdate{}, 0, nil
 			},
-			DeleteDatasetFunc: func(string) error {
+			GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+				return []*models.Instance{}, nil
+			},
+			SoftDeleteDatasetFunc: func(ctx context.Context, datasetID, eTagSelector, deletedBy string, deletedAt time.Time) error {
 				return errs.ErrInternalServer
 			},
+			GetDatasetsRelatedToFunc: func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+				return []*models.DatasetUpdate{}, nil
+			},
 		}
 
 		datasetPermissions := getAuthorisationHandlerMock()
@@ -1415,9 +1805,8 @@ func TestDeleteDatasetReturnsError(t *testing.T) {
 		assertInternalServerErr(w)
 		So(datasetPermissions.Required.Calls, ShouldEqual, 1)
 		So(len(mockedDataStore.GetDatasetCalls()), ShouldEqual, 1)
-		So(len(mockedDataStore.GetEditionsCalls()), ShouldEqual, 1)
-		So(len(mockedDataStore.DeleteEditionCalls()), ShouldEqual, 0)
-		So(len(mockedDataStore.DeleteDatasetCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.GetDatasetInstancesCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.SoftDeleteDatasetCalls()), ShouldEqual, 1)
 	})
 
 	Convey("When the dataset document cannot be found return status not found ", t, func() {
@@ -1426,15 +1815,21 @@ func TestDeleteDatasetReturnsError(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
 			GetEditionsFunc: func(ctx context.Context, ID string, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
 				return []*models.EditionUpdate{}, 0, nil
 			},
-			DeleteDatasetFunc: func(string) error {
+			GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+				return []*models.Instance{}, nil
+			},
+			DeleteDatasetFunc: func(context.Context, string) error {
 				return nil
 			},
+			GetDatasetsRelatedToFunc: func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+				return []*models.DatasetUpdate{}, nil
+			},
 		}
 
 		datasetPermissions := getAuthorisationHandlerMock()
@@ -1457,15 +1852,21 @@ func TestDeleteDatasetReturnsError(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return nil, errors.New("database is broken")
 			},
 			GetEditionsFunc: func(ctx context.Context, ID string, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
 				return []*models.EditionUpdate{}, 0, nil
 			},
-			DeleteDatasetFunc: func(string) error {
+			GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+				return []*models.Instance{}, nil
+			},
+			DeleteDatasetFunc: func(context.Context, string) error {
 				return nil
 			},
+			GetDatasetsRelatedToFunc: func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+				return []*models.DatasetUpdate{}, nil
+			},
 		}
 
 		datasetPermissions := getAuthorisationHandlerMock()
@@ -1503,4 +1904,26 @@ func TestDeleteDatasetReturnsError(t *testing.T) {
 		So(len(mockedDataStore.DeleteEditionCalls()), ShouldEqual, 0)
 		So(len(mockedDataStore.DeleteDatasetCalls()), ShouldEqual, 0)
 	})
+
+	Convey("When the If-Match header does not match the dataset eTag return status conflict", t, func() {
+		r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123", nil)
+		r.Header.Set("If-Match", "wrongETag")
+
+		w := httptest.NewRecorder()
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{Next: &models.Dataset{State: models.CreatedState}, ETag: testETag}, nil
+			},
+		}
+
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusConflict)
+		So(len(mockedDataStore.GetDatasetCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.GetEditionsCalls()), ShouldEqual, 0)
+		So(len(mockedDataStore.DeleteDatasetCalls()), ShouldEqual, 0)
+	})
 }
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/utils"
+	dprequest "github.com/ONSdigital/dp-net/request"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+)
+
+// datasetDeletion holds the IDs of everything reachable from a dataset being deleted, and how much of it there is
+type datasetDeletion struct {
+	datasetID   string
+	editionIDs  []string
+	instanceIDs []string
+	counts      models.DeleteCounts
+}
+
+// planDatasetDeletion finds the editions and instances (including versions) of the dataset, and counts the dimension
+// options and graph nodes of each instance, without removing anything
+func (api *DatasetAPI) planDatasetDeletion(ctx context.Context, datasetID string, logData log.Data) (*datasetDeletion, error) {
+	deletion := &datasetDeletion{
+		datasetID: datasetID,
+		counts:    models.DeleteCounts{Datasets: 1},
+	}
+
+	editionDocs, err := api.dataStore.Backend.GetDatasetEditions(ctx, datasetID)
+	if err != nil {
+		log.Event(ctx, "unable to find the dataset editions", log.ERROR, log.Error(err), logData)
+		return nil, err
+	}
+	for _, edition := range editionDocs {
+		deletion.editionIDs = append(deletion.editionIDs, edition.ID)
+	}
+	deletion.counts.Editions = len(deletion.editionIDs)
+
+	instances, err := api.getDeletableInstances(ctx, datasetID, logData)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, instance := range instances {
+		options, err := api.dataStore.Backend.CountDimensionOptions(ctx, instance.InstanceID)
+		if err != nil {
+			logData["instance_id"] = instance.InstanceID
+			log.Event(ctx, "unable to count the instance dimension options", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+
+		nodes, err := api.dataStore.Backend.CountInstanceNodes(ctx, instance.InstanceID)
+		if err != nil {
+			logData["instance_id"] = instance.InstanceID
+			log.Event(ctx, "unable to count the instance graph nodes", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+
+		deletion.instanceIDs = append(deletion.instanceIDs, instance.InstanceID)
+		deletion.counts.DimensionOptions += options
+		deletion.counts.GraphNodes += nodes
+	}
+	deletion.counts.Instances = len(deletion.instanceIDs)
+
+	return deletion, nil
+}
+
+// getDeletableInstances returns every instance (including versions) of the dataset, including those already deleted,
+// unless one of them has been published, in which case the dataset cannot be deleted
+func (api *DatasetAPI) getDeletableInstances(ctx context.Context, datasetID string, logData log.Data) ([]*models.Instance, error) {
+	instances, err := api.dataStore.Backend.GetDatasetInstances(ctx, datasetID)
+	if err != nil {
+		log.Event(ctx, "unable to find the dataset instances", log.ERROR, log.Error(err), logData)
+		return nil, err
+	}
+
+	for _, instance := range instances {
+		if models.IsPublished(instance.State) {
+			logData["instance_id"] = instance.InstanceID
+			log.Event(ctx, "unable to delete a dataset with a published version", log.ERROR, log.Error(errs.ErrDeletePublishedDatasetForbidden), logData)
+			return nil, errs.ErrDeletePublishedDatasetForbidden
+		}
+	}
+
+	return instances, nil
+}
+
+// executeDatasetDeletion removes the graph nodes, dimension options and documents of every instance of the dataset,
+// then its editions and finally the dataset itself. As the dataset is removed last, a deletion that fails part way
+// through can be completed by deleting the dataset again. What has been removed is recorded in deleted, and
+// onProgress, if provided, is called after each instance has been removed.
+func (api *DatasetAPI) executeDatasetDeletion(ctx context.Context, deletion *datasetDeletion, deleted *models.DeleteCounts, onProgress func()) error {
+	logData := log.Data{"dataset_id": deletion.datasetID}
+
+	for _, instanceID := range deletion.instanceIDs {
+		if err := api.deleteInstance(ctx, instanceID, deleted, logData); err != nil {
+			return err
+		}
+
+		if onProgress != nil {
+			onProgress()
+		}
+	}
+	delete(logData, "instance_id")
+
+	for _, editionID := range deletion.editionIDs {
+		if err := api.dataStore.Backend.DeleteEdition(ctx, editionID); err != nil {
+			logData["edition_id"] = editionID
+			log.Event(ctx, "failed to delete edition", log.ERROR, log.Error(err), logData)
+			return err
+		}
+		deleted.Editions++
+	}
+
+	if err := api.dataStore.Backend.DeleteDataset(ctx, deletion.datasetID); err != nil {
+		log.Event(ctx, "failed to delete dataset", log.ERROR, log.Error(err), logData)
+		return err
+	}
+	deleted.Datasets++
+
+	return nil
+}
+
+// deletedBy returns the user, or otherwise the service, making the request to delete a resource
+func deletedBy(ctx context.Context) string {
+	if user := dprequest.User(ctx); user != "" {
+		return user
+	}
+	return dprequest.Caller(ctx)
+}
+
+// deleteInstance removes the graph nodes, dimension options and document of the instance, recording what has been
+// removed in deleted
+func (api *DatasetAPI) deleteInstance(ctx context.Context, instanceID string, deleted *models.DeleteCounts, logData log.Data) error {
+	logData["instance_id"] = instanceID
+
+	nodes, err := api.dataStore.Backend.DeleteInstanceNodes(ctx, instanceID)
+	deleted.GraphNodes += nodes
+	if err != nil {
+		log.Event(ctx, "failed to delete instance graph nodes", log.ERROR, log.Error(err), logData)
+		return err
+	}
+
+	options, err := api.dataStore.Backend.DeleteDimensionOptions(ctx, instanceID)
+	deleted.DimensionOptions += options
+	if err != nil {
+		log.Event(ctx, "failed to delete instance dimension options", log.ERROR, log.Error(err), logData)
+		return err
+	}
+
+	if err = api.dataStore.Backend.DeleteInstance(ctx, instanceID); err != nil {
+		log.Event(ctx, "failed to delete instance", log.ERROR, log.Error(err), logData)
+		return err
+	}
+	deleted.Instances++
+
+	return nil
+}
+
+// runDeleteJob executes the deletion, recording its progress against the job. onProgress, if provided, is called after
+// each instance has been removed. A job interrupted by the service stopping is left submitted, and the deletion is
+// completed by the next purge.
+func (api *DatasetAPI) runDeleteJob(ctx context.Context, job *models.DeleteJob, deletion *datasetDeletion, onProgress func()) {
+	logData := log.Data{"job_id": job.ID, "dataset_id": job.DatasetID}
+	log.Event(ctx, "starting delete job", log.INFO, logData)
+
+	updateJob := func() {
+		if err := api.dataStore.Backend.UpdateDeleteJob(ctx, job); err != nil {
+			log.Event(ctx, "failed to record the progress of delete job", log.ERROR, log.Error(err), logData)
+		}
+	}
+
+	progress := func() {
+		updateJob()
+		if onProgress != nil {
+			onProgress()
+		}
+	}
+
+	if err := api.executeDatasetDeletion(ctx, deletion, &job.Deleted, progress); err != nil {
+		job.State = models.FailedState
+		job.Error = err.Error()
+		updateJob()
+		log.Event(ctx, "delete job failed", log.ERROR, log.Error(err), logData)
+		return
+	}
+
+	job.State = models.CompletedState
+	updateJob()
+	log.Event(ctx, "delete job completed", log.INFO, logData)
+}
+
+func (api *DatasetAPI) getDeleteJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	jobID := vars["job_id"]
+	logData := log.Data{"job_id": jobID, "func": "getDeleteJob"}
+
+	b, err := func() ([]byte, error) {
+		job, err := api.dataStore.Backend.GetDeleteJob(ctx, jobID)
+		if err != nil {
+			log.Event(ctx, "failed to get delete job", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+
+		api.renderLinks(r, job)
+		b, err := json.Marshal(job)
+		if err != nil {
+			log.Event(ctx, "failed to marshal delete job into bytes", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+		return b, nil
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	setJSONContentType(w)
+	if _, err = w.Write(b); err != nil {
+		log.Event(ctx, "error writing bytes to response", log.ERROR, log.Error(err), logData)
+		utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
+	}
+	log.Event(ctx, "get delete job", log.INFO, logData)
+}
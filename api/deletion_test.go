@@ -0,0 +1,542 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDeleteDatasetSoftDeletes(t *testing.T) {
+	t.Parallel()
+	Convey("Given an unpublished dataset with editions and instances", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{State: models.CreatedState}}, nil
+			},
+			GetDatasetEditionsFunc: func(ctx context.Context, datasetID string) ([]*models.EditionUpdate, error) {
+				return []*models.EditionUpdate{{ID: "edition-1"}}, nil
+			},
+			GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+				return []*models.Instance{
+					{InstanceID: "instance-1", State: models.AssociatedState},
+					{InstanceID: "instance-2", State: models.CompletedState},
+				}, nil
+			},
+			CountDimensionOptionsFunc: func(ctx context.Context, instanceID string) (int, error) {
+				return 10, nil
+			},
+			CountInstanceNodesFunc: func(ctx context.Context, instanceID string) (int64, error) {
+				return 100, nil
+			},
+			GetDatasetsRelatedToFunc: func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+				return []*models.DatasetUpdate{}, nil
+			},
+			SoftDeleteDatasetFunc: func(ctx context.Context, datasetID, eTagSelector, deletedBy string, deletedAt time.Time) error {
+				return nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When the dataset is deleted", func() {
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the dataset is moved into the deleted state, recording who deleted it", func() {
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+				So(mockedDataStore.SoftDeleteDatasetCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.SoftDeleteDatasetCalls()[0].DatasetID, ShouldEqual, "123")
+				So(mockedDataStore.SoftDeleteDatasetCalls()[0].ETagSelector, ShouldEqual, "*")
+				So(mockedDataStore.SoftDeleteDatasetCalls()[0].DeletedBy, ShouldEqual, "someone@ons.gov.uk")
+				So(mockedDataStore.SoftDeleteDatasetCalls()[0].DeletedAt, ShouldHappenWithin, time.Minute, time.Now())
+			})
+
+			Convey("And nothing is removed", func() {
+				So(mockedDataStore.DeleteInstanceNodesCalls(), ShouldHaveLength, 0)
+				So(mockedDataStore.DeleteDimensionOptionsCalls(), ShouldHaveLength, 0)
+				So(mockedDataStore.DeleteInstanceCalls(), ShouldHaveLength, 0)
+				So(mockedDataStore.DeleteEditionCalls(), ShouldHaveLength, 0)
+				So(mockedDataStore.DeleteDatasetCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the dataset is deleted as a dry run", func() {
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123?dry_run=true", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the counts of what would be removed are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var dryRun models.DeleteDryRun
+				So(json.Unmarshal(w.Body.Bytes(), &dryRun), ShouldBeNil)
+				So(dryRun.DatasetID, ShouldEqual, "123")
+				So(dryRun.Counts, ShouldResemble, models.DeleteCounts{
+					Datasets:         1,
+					Editions:         1,
+					Instances:        2,
+					DimensionOptions: 20,
+					GraphNodes:       200,
+				})
+			})
+
+			Convey("And the dataset is not deleted", func() {
+				So(mockedDataStore.SoftDeleteDatasetCalls(), ShouldHaveLength, 0)
+				So(mockedDataStore.DeleteDatasetCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the dry_run query parameter is not a boolean", func() {
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123?dry_run=maybe", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a bad request is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(mockedDataStore.GetDatasetCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the dataset is changed by another request while it is deleted", func() {
+			mockedDataStore.GetDatasetFunc = func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", ETag: "etag-1", Next: &models.Dataset{State: models.CreatedState}}, nil
+			}
+			mockedDataStore.SoftDeleteDatasetFunc = func(ctx context.Context, datasetID, eTagSelector, deletedBy string, deletedAt time.Time) error {
+				return errs.ErrDatasetConflict
+			}
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123", nil)
+			r.Header.Set("If-Match", "etag-1")
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the eTag is checked by the delete, and a conflict is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(mockedDataStore.SoftDeleteDatasetCalls()[0].ETagSelector, ShouldEqual, "etag-1")
+			})
+		})
+
+		Convey("When moving the dataset into the deleted state fails", func() {
+			mockedDataStore.SoftDeleteDatasetFunc = func(ctx context.Context, datasetID, eTagSelector, deletedBy string, deletedAt time.Time) error {
+				return errors.New("mongo is unavailable")
+			}
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then an internal server error is returned", func() {
+				assertInternalServerErr(w)
+			})
+		})
+	})
+
+	Convey("Given an unpublished dataset with a published version", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{State: models.CreatedState}}, nil
+			},
+			GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+				return []*models.Instance{{InstanceID: "instance-1", State: models.PublishedState}}, nil
+			},
+			GetDatasetsRelatedToFunc: func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+				return []*models.DatasetUpdate{}, nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When the dataset is deleted", func() {
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the request is forbidden and the dataset is not deleted", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(mockedDataStore.SoftDeleteDatasetCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestRestoreDataset(t *testing.T) {
+	t.Parallel()
+	Convey("Given a deleted dataset", t, func() {
+		deletedAt := time.Now().Add(-time.Hour)
+		mockedDataStore := &storetest.StorerMock{
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+				if id != "123" {
+					return nil, errs.ErrDatasetNotFound
+				}
+				return &models.DatasetUpdate{
+					ID:   "123",
+					Next: &models.Dataset{State: models.DeletedState, Deletion: &models.Deletion{DeletedAt: deletedAt}},
+				}, nil
+			},
+			RestoreDatasetFunc: func(ctx context.Context, datasetID string) error {
+				return nil
+			},
+		}
+		datasetPermissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, getAuthorisationHandlerMock())
+
+		Convey("When the dataset is restored within the retention window", func() {
+			r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/restore", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the dataset is restored", func() {
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+				So(datasetPermissions.Required.Calls, ShouldEqual, 1)
+				So(mockedDataStore.RestoreDatasetCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.RestoreDatasetCalls()[0].DatasetID, ShouldEqual, "123")
+			})
+		})
+
+		Convey("When the dataset is restored after the retention window", func() {
+			api.deletedDatasetRetention = time.Minute
+			r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/restore", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the request is forbidden and the dataset is not restored", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrRestoreWindowExpired.Error())
+				So(mockedDataStore.RestoreDatasetCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a dataset that has not been deleted is restored", func() {
+			r := createRequestWithAuth("POST", "http://localhost:22000/datasets/456/restore", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then not found is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(mockedDataStore.RestoreDatasetCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestPurgeDeletedDatasets(t *testing.T) {
+	t.Parallel()
+	Convey("Given a dataset deleted before the retention window", t, func() {
+		var jobs []models.DeleteJob
+		mockedDataStore := &storetest.StorerMock{
+			LockPurgeFunc: func(ctx context.Context, ttl time.Duration) (string, error) {
+				return "lock-1", nil
+			},
+			RenewPurgeLockFunc: func(ctx context.Context, lockID string, ttl time.Duration) error {
+				return nil
+			},
+			UnlockPurgeFunc: func(ctx context.Context, lockID string) error {
+				return nil
+			},
+			GetDatasetsDeletedBeforeFunc: func(ctx context.Context, before time.Time) ([]string, error) {
+				return []string{"123"}, nil
+			},
+			GetDatasetEditionsFunc: func(ctx context.Context, datasetID string) ([]*models.EditionUpdate, error) {
+				return []*models.EditionUpdate{{ID: "edition-1"}}, nil
+			},
+			GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+				return []*models.Instance{
+					{InstanceID: "instance-1", State: models.AssociatedState},
+					{InstanceID: "instance-2", State: models.CompletedState},
+				}, nil
+			},
+			CountDimensionOptionsFunc: func(ctx context.Context, instanceID string) (int, error) {
+				return 10, nil
+			},
+			CountInstanceNodesFunc: func(ctx context.Context, instanceID string) (int64, error) {
+				return 100, nil
+			},
+			DeleteInstanceNodesFunc: func(ctx context.Context, instanceID string) (int64, error) {
+				return 100, nil
+			},
+			DeleteDimensionOptionsFunc: func(ctx context.Context, instanceID string) (int, error) {
+				return 10, nil
+			},
+			DeleteInstanceFunc: func(ctx context.Context, instanceID string) error {
+				return nil
+			},
+			DeleteEditionFunc: func(ctx context.Context, ID string) error {
+				return nil
+			},
+			DeleteDatasetFunc: func(context.Context, string) error {
+				return nil
+			},
+			AddDeleteJobFunc: func(ctx context.Context, job *models.DeleteJob) error {
+				return nil
+			},
+			UpdateDeleteJobFunc: func(ctx context.Context, job *models.DeleteJob) error {
+				jobs = append(jobs, *job)
+				return nil
+			},
+			GetEditionsDeletedBeforeFunc: func(ctx context.Context, before time.Time) ([]*models.EditionUpdate, error) {
+				return []*models.EditionUpdate{}, nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When deleted datasets are purged", func() {
+			api.purgeDeletedDatasets(context.Background())
+
+			Convey("Then the purge is locked, and unlocked once it is done", func() {
+				So(mockedDataStore.LockPurgeCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.UnlockPurgeCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.UnlockPurgeCalls()[0].LockID, ShouldEqual, "lock-1")
+			})
+
+			Convey("And datasets deleted before the retention window are found", func() {
+				So(mockedDataStore.GetDatasetsDeletedBeforeCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.GetDatasetsDeletedBeforeCalls()[0].Before, ShouldHappenWithin, time.Minute, time.Now().Add(-api.deletedDatasetRetention))
+			})
+
+			Convey("And everything reachable from the dataset is removed", func() {
+				So(mockedDataStore.DeleteInstanceNodesCalls(), ShouldHaveLength, 2)
+				So(mockedDataStore.DeleteDimensionOptionsCalls(), ShouldHaveLength, 2)
+				So(mockedDataStore.DeleteInstanceCalls(), ShouldHaveLength, 2)
+				So(mockedDataStore.DeleteInstanceCalls()[0].InstanceID, ShouldEqual, "instance-1")
+				So(mockedDataStore.DeleteInstanceCalls()[1].InstanceID, ShouldEqual, "instance-2")
+				So(mockedDataStore.DeleteEditionCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.DeleteEditionCalls()[0].ID, ShouldEqual, "edition-1")
+				So(mockedDataStore.DeleteDatasetCalls(), ShouldHaveLength, 1)
+			})
+
+			Convey("And the purge lock is renewed as each instance is removed", func() {
+				So(mockedDataStore.RenewPurgeLockCalls(), ShouldHaveLength, 2)
+			})
+
+			Convey("And no delete job is added, as the dataset is below the delete job threshold", func() {
+				So(mockedDataStore.AddDeleteJobCalls(), ShouldHaveLength, 0)
+				So(mockedDataStore.UpdateDeleteJobCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When deleted datasets above the delete job threshold are purged", func() {
+			api.deleteJobThreshold = 100
+			api.purgeDeletedDatasets(context.Background())
+
+			Convey("Then everything reachable from the dataset is removed", func() {
+				So(mockedDataStore.DeleteInstanceCalls(), ShouldHaveLength, 2)
+				So(mockedDataStore.DeleteEditionCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.DeleteDatasetCalls(), ShouldHaveLength, 1)
+			})
+
+			Convey("And the removal is recorded by a completed delete job", func() {
+				So(mockedDataStore.AddDeleteJobCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.AddDeleteJobCalls()[0].Job.DatasetID, ShouldEqual, "123")
+
+				job := jobs[len(jobs)-1]
+				So(job.State, ShouldEqual, models.CompletedState)
+				So(job.Counts.Total(), ShouldEqual, 224)
+				So(job.Deleted, ShouldResemble, job.Counts)
+			})
+		})
+
+		Convey("When deleted datasets above the delete job threshold are purged and removing the graph nodes of an instance fails", func() {
+			api.deleteJobThreshold = 100
+			mockedDataStore.DeleteInstanceNodesFunc = func(ctx context.Context, instanceID string) (int64, error) {
+				return 0, errors.New("graph is unavailable")
+			}
+			api.purgeDeletedDatasets(context.Background())
+
+			Convey("Then the dataset is kept, so it is purged again later", func() {
+				So(mockedDataStore.DeleteInstanceCalls(), ShouldHaveLength, 0)
+				So(mockedDataStore.DeleteDatasetCalls(), ShouldHaveLength, 0)
+			})
+
+			Convey("And the delete job fails, recording the error", func() {
+				job := jobs[len(jobs)-1]
+				So(job.State, ShouldEqual, models.FailedState)
+				So(job.Error, ShouldEqual, "graph is unavailable")
+			})
+
+			Convey("And the purge is unlocked", func() {
+				So(mockedDataStore.UnlockPurgeCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When deleted datasets are purged while another instance holds the purge lock", func() {
+			mockedDataStore.LockPurgeFunc = func(ctx context.Context, ttl time.Duration) (string, error) {
+				return "", errs.ErrPurgeLocked
+			}
+			api.purgeDeletedDatasets(context.Background())
+
+			Convey("Then no datasets are purged", func() {
+				So(mockedDataStore.GetDatasetsDeletedBeforeCalls(), ShouldHaveLength, 0)
+				So(mockedDataStore.DeleteDatasetCalls(), ShouldHaveLength, 0)
+				So(mockedDataStore.UnlockPurgeCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestPurgeDeletedEditions(t *testing.T) {
+	t.Parallel()
+	Convey("Given an edition deleted before the retention window, from a dataset that has not been deleted", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			LockPurgeFunc: func(ctx context.Context, ttl time.Duration) (string, error) {
+				return "lock-1", nil
+			},
+			RenewPurgeLockFunc: func(ctx context.Context, lockID string, ttl time.Duration) error {
+				return nil
+			},
+			UnlockPurgeFunc: func(ctx context.Context, lockID string) error {
+				return nil
+			},
+			GetDatasetsDeletedBeforeFunc: func(ctx context.Context, before time.Time) ([]string, error) {
+				return []string{}, nil
+			},
+			GetEditionsDeletedBeforeFunc: func(ctx context.Context, before time.Time) ([]*models.EditionUpdate, error) {
+				return []*models.EditionUpdate{{
+					ID:   "edition-1",
+					Next: &models.Edition{Edition: "2017", Links: &models.EditionUpdateLinks{Dataset: &models.LinkObject{ID: "123"}}},
+				}}, nil
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+				return []*models.Instance{
+					{InstanceID: "instance-1", Edition: "2017", State: models.DeletedState},
+					{InstanceID: "instance-2", Edition: "2018", State: models.DeletedState},
+					{InstanceID: "instance-3", Edition: "2017", State: models.PublishedState},
+				}, nil
+			},
+			DeleteInstanceNodesFunc: func(ctx context.Context, instanceID string) (int64, error) {
+				return 100, nil
+			},
+			DeleteDimensionOptionsFunc: func(ctx context.Context, instanceID string) (int, error) {
+				return 10, nil
+			},
+			DeleteInstanceFunc: func(ctx context.Context, instanceID string) error {
+				return nil
+			},
+			DeleteEditionFunc: func(ctx context.Context, ID string) error {
+				return nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When deleted datasets are purged", func() {
+			api.purgeDeletedDatasets(context.Background())
+
+			Convey("Then the edition, and only the instances deleted with it, are removed", func() {
+				So(mockedDataStore.DeleteInstanceCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.DeleteInstanceCalls()[0].InstanceID, ShouldEqual, "instance-1")
+				So(mockedDataStore.DeleteEditionCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.DeleteEditionCalls()[0].ID, ShouldEqual, "edition-1")
+				So(mockedDataStore.DeleteDatasetCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When deleted datasets are purged and the dataset of the edition has also been deleted", func() {
+			mockedDataStore.GetDeletedDatasetFunc = func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123"}, nil
+			}
+			api.purgeDeletedDatasets(context.Background())
+
+			Convey("Then the edition is left to be purged with its dataset", func() {
+				So(mockedDataStore.DeleteInstanceCalls(), ShouldHaveLength, 0)
+				So(mockedDataStore.DeleteEditionCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestPurger(t *testing.T) {
+	t.Parallel()
+	Convey("Given a purger that has not been started", t, func() {
+		api := GetAPIWithMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("Then stopping it does nothing", func() {
+			So(api.StopPurger, ShouldNotPanic)
+		})
+	})
+
+	Convey("Given a started purger", t, func() {
+		purged := make(chan struct{}, 10)
+		mockedDataStore := &storetest.StorerMock{
+			LockPurgeFunc: func(ctx context.Context, ttl time.Duration) (string, error) {
+				return "lock-1", nil
+			},
+			UnlockPurgeFunc: func(ctx context.Context, lockID string) error {
+				return nil
+			},
+			GetDatasetsDeletedBeforeFunc: func(ctx context.Context, before time.Time) ([]string, error) {
+				purged <- struct{}{}
+				return nil, nil
+			},
+			GetEditionsDeletedBeforeFunc: func(ctx context.Context, before time.Time) ([]*models.EditionUpdate, error) {
+				return nil, nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+		api.StartPurger(context.Background(), 10*time.Millisecond)
+
+		Convey("Then deleted datasets are purged at each interval, until it is stopped", func() {
+			select {
+			case <-purged:
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for deleted datasets to be purged")
+			}
+
+			api.StopPurger()
+			calls := len(mockedDataStore.GetDatasetsDeletedBeforeCalls())
+			time.Sleep(50 * time.Millisecond)
+			So(mockedDataStore.GetDatasetsDeletedBeforeCalls(), ShouldHaveLength, calls)
+		})
+	})
+}
+
+func TestGetDeleteJob(t *testing.T) {
+	t.Parallel()
+	Convey("Given a stored delete job", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDeleteJobFunc: func(ctx context.Context, jobID string) (*models.DeleteJob, error) {
+				if jobID != "456" {
+					return nil, errs.ErrDeleteJobNotFound
+				}
+				return &models.DeleteJob{ID: "456", DatasetID: "123", State: models.CompletedState}, nil
+			},
+		}
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), permissions)
+
+		Convey("When the job is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/delete-jobs/456", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the job is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(permissions.Required.Calls, ShouldEqual, 1)
+
+				var job models.DeleteJob
+				So(json.Unmarshal(w.Body.Bytes(), &job), ShouldBeNil)
+				So(job.ID, ShouldEqual, "456")
+				So(job.State, ShouldEqual, models.CompletedState)
+			})
+		})
+
+		Convey("When an unknown job is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/delete-jobs/789", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then not found is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrDeleteJobNotFound.Error())
+			})
+		})
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"time"
 
 	"github.com/ONSdigital/dp-dataset-api/apierrors"
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
@@ -38,6 +39,7 @@ func (api *DatasetAPI) getDimensions(w http.ResponseWriter, r *http.Request, lim
 		return nil, 0, err
 	}
 
+	var lastUpdated time.Time
 	list, totalCount, err := func() ([]models.Dimension, int, error) {
 		authorised := api.authenticate(r, logData)
 
@@ -46,49 +48,88 @@ func (api *DatasetAPI) getDimensions(w http.ResponseWriter, r *http.Request, lim
 			state = models.PublishedState
 		}
 
-		versionDoc, err := api.dataStore.Backend.GetVersion(datasetID, edition, versionNumber, state)
+		versionDoc, err := api.dataStore.Backend.GetVersion(ctx, datasetID, edition, versionNumber, state)
 		if err != nil {
 			log.Event(ctx, "datastore.getversion returned an error", log.ERROR, log.Error(err), logData)
 			return nil, 0, err
 		}
 
+		if !api.canViewVersion(r, authorised, datasetID, versionDoc, logData) {
+			log.Event(ctx, "caller cannot access the collection of the unpublished version", log.INFO, logData)
+			return nil, 0, errs.ErrVersionNotFound
+		}
+
 		if err = models.CheckState("version", versionDoc.State); err != nil {
 			logData["state"] = versionDoc.State
 			log.Event(ctx, "unpublished version has an invalid state", log.ERROR, log.Error(err), logData)
 			return nil, 0, err
 		}
 
-		dimensions, err := api.dataStore.Backend.GetDimensions(datasetID, versionDoc.ID)
+		dimensions, err := api.listDimensions(ctx, datasetID, versionDoc)
 		if err != nil {
 			log.Event(ctx, "failed to get version dimensions", log.ERROR, log.Error(err), logData)
 			return nil, 0, err
 		}
 
 		slicedResults := []models.Dimension{}
-
 		if limit > 0 {
-			results, err := api.createListOfDimensions(versionDoc, dimensions)
-			if err != nil {
-				log.Event(ctx, "failed to convert bson to dimension", log.ERROR, log.Error(err), logData)
-				return nil, 0, err
-			}
-
-			sort.Slice(results, func(i, j int) bool {
-				return results[i].Name < results[j].Name
-			})
-
-			slicedResults = utils.Slice(results, offset, limit)
+			slicedResults = utils.Slice(dimensions, offset, limit)
 		}
 
+		lastUpdated = versionDoc.LastUpdated
 		return slicedResults, len(dimensions), nil
 	}()
 	if err != nil {
 		handleDimensionsErr(ctx, w, "", err, logData)
 		return nil, 0, err
 	}
+	setLastModified(w, lastUpdated)
 	return list, totalCount, nil
 }
 
+// listDimensions returns the dimensions of a version that have options, sorted by name. The summaries of the options
+// stored on the version are used when the version has them, otherwise the options of the version are grouped by
+// dimension, as they are for versions summarised before the summaries were stored. Either way, each dimension is
+// returned with the same fields, as the summaries are only used to find the dimensions that have options.
+func (api *DatasetAPI) listDimensions(ctx context.Context, datasetID string, versionDoc *models.Version) ([]models.Dimension, error) {
+	var results []models.Dimension
+	if models.DimensionsSummarised(versionDoc.Dimensions) {
+		for _, summary := range versionDoc.Dimensions {
+			if *summary.NumberOfOptions == 0 {
+				continue
+			}
+			dimension := models.Dimension{Name: summary.Name, Description: summary.Description, Label: summary.Label}
+			dimension.Links.CodeList = summary.Links.CodeList
+			dimension.Links.Options, dimension.Links.Version = dimensionLinks(versionDoc, summary.Name)
+			results = append(results, dimension)
+		}
+
+		if len(results) == 0 {
+			return nil, errs.ErrDimensionsNotFound
+		}
+	} else {
+		dimensions, err := api.dataStore.Backend.GetDimensions(ctx, datasetID, versionDoc.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if results, err = api.createListOfDimensions(versionDoc, dimensions); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+	return results, nil
+}
+
+// dimensionLinks returns the links to the options of a dimension and to the version the dimension belongs to
+func dimensionLinks(versionDoc *models.Version, name string) (options models.LinkObject, version models.LinkObject) {
+	versionHRef := fmt.Sprintf("/datasets/%s/editions/%s/versions/%s", versionDoc.Links.Dataset.ID, versionDoc.Edition, versionDoc.Links.Version.ID)
+	return models.LinkObject{ID: name, HRef: versionHRef + "/dimensions/" + name + "/options"}, models.LinkObject{HRef: versionHRef}
+}
+
 func (api *DatasetAPI) createListOfDimensions(versionDoc *models.Version, dimensions []bson.M) ([]models.Dimension, error) {
 
 	// Get dimension description from the version document and add to hash map
@@ -108,10 +149,7 @@ func (api *DatasetAPI) createListOfDimensions(versionDoc *models.Version, dimens
 
 		dimension := models.Dimension{Name: opt.Name}
 		dimension.Links.CodeList = opt.Links.CodeList
-		dimension.Links.Options = models.LinkObject{ID: opt.Name, HRef: fmt.Sprintf("%s/datasets/%s/editions/%s/versions/%s/dimensions/%s/options",
-			api.host, versionDoc.Links.Dataset.ID, versionDoc.Edition, versionDoc.Links.Version.ID, opt.Name)}
-		dimension.Links.Version = models.LinkObject{HRef: fmt.Sprintf("%s/datasets/%s/editions/%s/versions/%s",
-			api.host, versionDoc.Links.Dataset.ID, versionDoc.Edition, versionDoc.Links.Version.ID)}
+		dimension.Links.Options, dimension.Links.Version = dimensionLinks(versionDoc, opt.Name)
 
 		// Add description to dimension from hash map
 		dimension.Description = dimensionDescriptions[dimension.Name]
@@ -169,12 +207,17 @@ func (api *DatasetAPI) getDimensionOptions(w http.ResponseWriter, r *http.Reques
 	}
 
 	// ger version for provided dataset, edition and versionID
-	version, err := api.dataStore.Backend.GetVersion(datasetID, edition, versionName, state)
+	version, err := api.dataStore.Backend.GetVersion(ctx, datasetID, edition, versionName, state)
 	if err != nil {
 		handleDimensionsErr(ctx, w, "failed to get version", err, logData)
 		return nil, 0, err
 	}
 
+	if !api.canViewVersion(r, authorised, datasetID, version, logData) {
+		handleDimensionsErr(ctx, w, "caller cannot access the collection of the unpublished version", errs.ErrVersionNotFound, logData)
+		return nil, 0, errs.ErrVersionNotFound
+	}
+
 	// vaidate state
 	if err = models.CheckState("version", version.State); err != nil {
 		logData["version_state"] = version.State
@@ -193,7 +236,7 @@ func (api *DatasetAPI) getDimensionOptions(w http.ResponseWriter, r *http.Reques
 		}
 	} else {
 		// get dimension options from the provided list of IDs, sorted by option
-		results, totalCount, err = api.dataStore.Backend.GetDimensionOptionsFromIDs(version, dimension, ids)
+		results, totalCount, err = api.dataStore.Backend.GetDimensionOptionsFromIDs(ctx, version, dimension, ids)
 		if err != nil {
 			handleDimensionsErr(ctx, w, "failed to get a list of dimension options", err, logData)
 			return nil, 0, err
@@ -201,12 +244,13 @@ func (api *DatasetAPI) getDimensionOptions(w http.ResponseWriter, r *http.Reques
 	}
 
 	// populate links
-	versionHref := fmt.Sprintf("%s/datasets/%s/editions/%s/versions/%s", api.host, datasetID, edition, versionID)
+	versionHref := fmt.Sprintf("/datasets/%s/editions/%s/versions/%s", datasetID, edition, versionID)
 	for i := range results {
 		results[i].Links.Version.HRef = versionHref
 		results[i].Links.Version.ID = versionID
 	}
 
+	setLastModified(w, version.LastUpdated)
 	return results, totalCount, nil
 }
 
@@ -223,7 +267,7 @@ func handleDimensionsErr(ctx context.Context, w http.ResponseWriter, msg string,
 		data["response_status"] = http.StatusBadRequest
 		data["user_error"] = err.Error()
 		log.Event(ctx, fmt.Sprintf("request unsuccessful: %s", msg), log.ERROR, data)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		utils.WriteError(ctx, w, err, http.StatusBadRequest)
 	default:
 		// Switch by error message
 		switch {
@@ -231,17 +275,17 @@ func handleDimensionsErr(ctx context.Context, w http.ResponseWriter, msg string,
 			data["response_status"] = http.StatusBadRequest
 			data["user_error"] = err.Error()
 			log.Event(ctx, fmt.Sprintf("request unsuccessful: %s", msg), log.ERROR, data)
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			utils.WriteError(ctx, w, err, http.StatusBadRequest)
 		case errs.NotFoundMap[err]:
 			data["response_status"] = http.StatusNotFound
 			data["user_error"] = err.Error()
 			log.Event(ctx, fmt.Sprintf("request unsuccessful: %s", msg), log.ERROR, data)
-			http.Error(w, err.Error(), http.StatusNotFound)
+			utils.WriteError(ctx, w, err, http.StatusNotFound)
 		default:
 			// a stack trace is added for Non User errors
 			data["response_status"] = http.StatusInternalServerError
 			log.Event(ctx, fmt.Sprintf("request unsuccessful: %s", msg), log.ERROR, log.Error(err), data)
-			http.Error(w, errs.ErrInternalServer.Error(), http.StatusInternalServerError)
+			utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
 		}
 	}
 }
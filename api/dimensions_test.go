@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"sort"
@@ -29,10 +30,10 @@ func TestGetDimensionsReturnsOk(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1/dimensions", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(datasetID, edition string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, edition string, version int, state string) (*models.Version, error) {
 				return &models.Version{State: models.AssociatedState}, nil
 			},
-			GetDimensionsFunc: func(datasetID, versionID string) ([]bson.M, error) {
+			GetDimensionsFunc: func(ctx context.Context, datasetID, versionID string) ([]bson.M, error) {
 				return []bson.M{}, nil
 			},
 		}
@@ -48,6 +49,55 @@ func TestGetDimensionsReturnsOk(t *testing.T) {
 		So(len(mockedDataStore.GetVersionCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.GetDimensionsCalls()), ShouldEqual, 1)
 	})
+
+	Convey("When the version holds summaries of its dimensions they are returned without aggregating the options", t, func() {
+		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1/dimensions?limit=20", nil)
+		w := httptest.NewRecorder()
+		ageOptions, sexOptions, geographyOptions := 2, 0, 1
+		mockedDataStore := &storetest.StorerMock{
+			GetVersionFunc: func(ctx context.Context, datasetID, edition string, version int, state string) (*models.Version, error) {
+				return &models.Version{
+					ID:      "789",
+					Edition: "2017",
+					State:   models.AssociatedState,
+					Links: &models.VersionLinks{
+						Dataset: &models.LinkObject{ID: "123"},
+						Version: &models.LinkObject{ID: "1"},
+					},
+					Dimensions: []models.Dimension{
+						{Name: "sex", NumberOfOptions: &sexOptions},
+						{Name: "geography", NumberOfOptions: &geographyOptions, FirstOption: "K02000001"},
+						{Name: "age", Description: "Age in years", NumberOfOptions: &ageOptions, FirstOption: "24", IsHierarchy: true,
+							Links: models.DimensionLink{CodeList: models.LinkObject{ID: "age-list"}}},
+					},
+				}, nil
+			},
+		}
+
+		api := initAPIWithMockedStore(mockedDataStore)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusOK)
+		So(mockedDataStore.GetDimensionsCalls(), ShouldBeEmpty)
+
+		var dimensions struct {
+			Items      []models.Dimension `json:"items"`
+			TotalCount int                `json:"total_count"`
+		}
+		So(json.Unmarshal(w.Body.Bytes(), &dimensions), ShouldBeNil)
+		So(dimensions.TotalCount, ShouldEqual, 2)
+		So(dimensions.Items, ShouldHaveLength, 2)
+		So(dimensions.Items[0].Name, ShouldEqual, "age")
+		So(dimensions.Items[0].Description, ShouldEqual, "Age in years")
+		So(dimensions.Items[0].Links.CodeList.ID, ShouldEqual, "age-list")
+		So(dimensions.Items[0].Links.Options.HRef, ShouldEqual, "http://localhost:22000/datasets/123/editions/2017/versions/1/dimensions/age/options")
+		So(dimensions.Items[1].Name, ShouldEqual, "geography")
+
+		// the summaries are not returned, so that dimensions are returned the same way whether or not they are summarised
+		So(w.Body.String(), ShouldNotContainSubstring, "number_of_options")
+		So(w.Body.String(), ShouldNotContainSubstring, "first_option")
+		So(w.Body.String(), ShouldNotContainSubstring, "is_hierarchy")
+	})
 }
 
 func TestGetDimensionsReturnsErrors(t *testing.T) {
@@ -57,7 +107,7 @@ func TestGetDimensionsReturnsErrors(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1/dimensions", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(datasetID, edition string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, edition string, version int, state string) (*models.Version, error) {
 				return nil, errs.ErrInternalServer
 			},
 		}
@@ -75,7 +125,7 @@ func TestGetDimensionsReturnsErrors(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1/dimensions", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(datasetID, edition string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, edition string, version int, state string) (*models.Version, error) {
 				return nil, errs.ErrVersionNotFound
 			},
 		}
@@ -93,7 +143,7 @@ func TestGetDimensionsReturnsErrors(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/abcd/dimensions", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(datasetID, edition string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, edition string, version int, state string) (*models.Version, error) {
 				return nil, errs.ErrVersionNotFound
 			},
 		}
@@ -109,10 +159,10 @@ func TestGetDimensionsReturnsErrors(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1/dimensions", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(datasetID, edition string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, edition string, version int, state string) (*models.Version, error) {
 				return &models.Version{State: models.AssociatedState}, nil
 			},
-			GetDimensionsFunc: func(datasetID, versionID string) ([]bson.M, error) {
+			GetDimensionsFunc: func(ctx context.Context, datasetID, versionID string) ([]bson.M, error) {
 				return nil, errs.ErrDimensionsNotFound
 			},
 		}
@@ -130,7 +180,7 @@ func TestGetDimensionsReturnsErrors(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1/dimensions", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(datasetID, edition string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, edition string, version int, state string) (*models.Version, error) {
 				return &models.Version{State: "gobbly-gook"}, nil
 			},
 		}
@@ -151,7 +201,7 @@ func TestGetDimensionOptionsReturnsOk(t *testing.T) {
 
 		// testing DataStore with 5 dimension options
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(datasetID, edition string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, edition string, version int, state string) (*models.Version, error) {
 				return &models.Version{State: models.AssociatedState, ID: "v1"}, nil
 			},
 			GetDimensionOptionsFunc: func(ctx context.Context, version *models.Version, dimension string, offset int, limit int) ([]*models.PublicDimensionOption, int, error) {
@@ -163,7 +213,7 @@ func TestGetDimensionOptionsReturnsOk(t *testing.T) {
 					{Option: "op5"}}
 				return allItems, 5, nil
 			},
-			GetDimensionOptionsFromIDsFunc: func(version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
+			GetDimensionOptionsFromIDsFunc: func(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
 				ret := []*models.PublicDimensionOption{}
 				sort.Strings(ids)
 				for _, id := range ids {
@@ -226,7 +276,7 @@ func TestGetDimensionOptionsReturnsOk(t *testing.T) {
 
 		// expected Links structure for the requested dataset version
 		expectedLinks := models.DimensionOptionLinks{
-			Version: models.LinkObject{HRef: "http://localhost:22000/datasets/123/editions/2017/versions/1", ID: "1"},
+			Version: models.LinkObject{HRef: "/datasets/123/editions/2017/versions/1", ID: "1"},
 		}
 
 		Convey("When a valid dimension is provided without any query parameters", func() {
@@ -310,7 +360,7 @@ func TestGetDimensionOptionsReturnsErrors(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1/dimensions/age/options", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(datasetID, edition string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, edition string, version int, state string) (*models.Version, error) {
 				return nil, errs.ErrVersionNotFound
 			},
 		}
@@ -327,7 +377,7 @@ func TestGetDimensionOptionsReturnsErrors(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1/dimensions/age/options", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(datasetID, edition string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, edition string, version int, state string) (*models.Version, error) {
 				return &models.Version{State: models.AssociatedState}, nil
 			},
 			GetDimensionOptionsFunc: func(ctx context.Context, version *models.Version, dimensions string, offset, limit int) ([]*models.PublicDimensionOption, int, error) {
@@ -348,10 +398,10 @@ func TestGetDimensionOptionsReturnsErrors(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1/dimensions/age/options?id=id1", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(datasetID, edition string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, edition string, version int, state string) (*models.Version, error) {
 				return &models.Version{State: models.AssociatedState}, nil
 			},
-			GetDimensionOptionsFromIDsFunc: func(version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
+			GetDimensionOptionsFromIDsFunc: func(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
 				return nil, 0, errs.ErrInternalServer
 			},
 		}
@@ -369,7 +419,7 @@ func TestGetDimensionOptionsReturnsErrors(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123/editions/2017/versions/1/dimensions/age/options", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(datasetID, edition string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, edition string, version int, state string) (*models.Version, error) {
 				return &models.Version{State: "gobbly-gook"}, nil
 			},
 		}
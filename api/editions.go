@@ -1,11 +1,16 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	"github.com/ONSdigital/dp-dataset-api/utils"
+	dphttp "github.com/ONSdigital/dp-net/http"
 	"github.com/ONSdigital/log.go/log"
 	"github.com/gorilla/mux"
 )
@@ -17,7 +22,16 @@ func (api *DatasetAPI) getEditions(w http.ResponseWriter, r *http.Request, limit
 	datasetID := vars["dataset_id"]
 	logData := log.Data{"dataset_id": datasetID}
 
-	authorised := api.authenticate(r, logData)
+	authorised, err := api.authenticateForDataset(r, datasetID, logData)
+	if err != nil {
+		log.Event(ctx, "getEditions endpoint: unable to find dataset", log.ERROR, log.Error(err), logData)
+		if err == errs.ErrDatasetNotFound {
+			utils.WriteError(ctx, w, err, http.StatusNotFound)
+		} else {
+			utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
+		}
+		return nil, 0, err
+	}
 
 	var state string
 	if !authorised {
@@ -26,12 +40,12 @@ func (api *DatasetAPI) getEditions(w http.ResponseWriter, r *http.Request, limit
 
 	logData["state"] = state
 
-	if err := api.dataStore.Backend.CheckDatasetExists(datasetID, state); err != nil {
+	if err := api.dataStore.Backend.CheckDatasetExists(ctx, datasetID, state); err != nil {
 		log.Event(ctx, "getEditions endpoint: unable to find dataset", log.ERROR, log.Error(err), logData)
 		if err == errs.ErrDatasetNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			utils.WriteError(ctx, w, err, http.StatusNotFound)
 		} else {
-			http.Error(w, errs.ErrInternalServer.Error(), http.StatusInternalServerError)
+			utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
 		}
 		return nil, 0, err
 	}
@@ -40,9 +54,9 @@ func (api *DatasetAPI) getEditions(w http.ResponseWriter, r *http.Request, limit
 	if err != nil {
 		log.Event(ctx, "getEditions endpoint: unable to find editions for dataset", log.ERROR, log.Error(err), logData)
 		if err == errs.ErrEditionNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			utils.WriteError(ctx, w, err, http.StatusNotFound)
 		} else {
-			http.Error(w, errs.ErrInternalServer.Error(), http.StatusInternalServerError)
+			utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
 		}
 		return nil, 0, err
 	}
@@ -67,24 +81,32 @@ func (api *DatasetAPI) getEdition(w http.ResponseWriter, r *http.Request) {
 	edition := vars["edition"]
 	logData := log.Data{"dataset_id": datasetID, "edition": edition}
 
+	var eTag string
+	var lastUpdated time.Time
 	b, err := func() ([]byte, error) {
-		authorised := api.authenticate(r, logData)
+		authorised, err := api.authenticateForDataset(r, datasetID, logData)
+		if err != nil {
+			log.Event(ctx, "getEdition endpoint: unable to find dataset", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
 
 		var state string
 		if !authorised {
 			state = models.PublishedState
 		}
 
-		if err := api.dataStore.Backend.CheckDatasetExists(datasetID, state); err != nil {
+		if err := api.dataStore.Backend.CheckDatasetExists(ctx, datasetID, state); err != nil {
 			log.Event(ctx, "getEdition endpoint: unable to find dataset", log.ERROR, log.Error(err), logData)
 			return nil, err
 		}
 
-		edition, err := api.dataStore.Backend.GetEdition(datasetID, edition, state)
+		edition, err := api.dataStore.Backend.GetEdition(ctx, datasetID, edition, state)
 		if err != nil {
 			log.Event(ctx, "getEdition endpoint: unable to find edition", log.ERROR, log.Error(err), logData)
 			return nil, err
 		}
+		eTag = edition.ETag
+		api.renderLinks(r, edition)
 
 		var b []byte
 
@@ -95,6 +117,9 @@ func (api *DatasetAPI) getEdition(w http.ResponseWriter, r *http.Request) {
 				log.Event(ctx, "getEdition endpoint: failed to marshal edition resource into bytes", log.ERROR, log.Error(err), logData)
 				return nil, err
 			}
+			if edition.Next != nil {
+				lastUpdated = edition.Next.LastUpdated
+			}
 			log.Event(ctx, "getEdition endpoint: get edition with auth", log.INFO, logData)
 		} else {
 
@@ -104,6 +129,9 @@ func (api *DatasetAPI) getEdition(w http.ResponseWriter, r *http.Request) {
 				log.Event(ctx, "getEdition endpoint: failed to marshal edition resource into bytes", log.ERROR, log.Error(err), logData)
 				return nil, err
 			}
+			if edition.Current != nil {
+				lastUpdated = edition.Current.LastUpdated
+			}
 			log.Event(ctx, "getEdition endpoint: get edition without auth", log.INFO, logData)
 		}
 		return b, nil
@@ -111,19 +139,219 @@ func (api *DatasetAPI) getEdition(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		if err == errs.ErrDatasetNotFound || err == errs.ErrEditionNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			utils.WriteError(ctx, w, err, http.StatusNotFound)
 		} else {
-			http.Error(w, errs.ErrInternalServer.Error(), http.StatusInternalServerError)
+			utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
 		}
 		return
 	}
 
 	setJSONContentType(w)
+	setETag(w, eTag)
+	setLastModified(w, lastUpdated)
 	_, err = w.Write(b)
 	if err != nil {
 		log.Event(ctx, "getEdition endpoint: failed to write byte to response", log.ERROR, log.Error(err), logData)
-		http.Error(w, errs.ErrInternalServer.Error(), http.StatusInternalServerError)
+		utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
 		return
 	}
 	log.Event(ctx, "getEdition endpoint: request successful", log.INFO, logData)
 }
+
+func (api *DatasetAPI) addEdition(w http.ResponseWriter, r *http.Request) {
+
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	datasetID := vars["dataset_id"]
+	edition := vars["edition"]
+	logData := log.Data{"dataset_id": datasetID, "edition": edition}
+
+	var eTag string
+	b, err := func() ([]byte, error) {
+		metadata, err := models.CreateEditionMetadata(r.Body)
+		if err != nil {
+			log.Event(ctx, "addEdition endpoint: failed to model edition resource based on request", log.ERROR, log.Error(err), logData)
+			return nil, errs.ErrAddUpdateEditionBadRequest
+		}
+
+		if _, err = api.dataStore.Backend.GetDataset(ctx, datasetID); err != nil {
+			log.Event(ctx, "addEdition endpoint: unable to find dataset", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+
+		_, err = api.dataStore.Backend.GetEdition(ctx, datasetID, edition, "")
+		if err != nil {
+			if err != errs.ErrEditionNotFound {
+				log.Event(ctx, "addEdition endpoint: error checking if edition exists", log.ERROR, log.Error(err), logData)
+				return nil, err
+			}
+		} else {
+			log.Event(ctx, "addEdition endpoint: unable to create an edition that already exists", log.ERROR, log.Error(errs.ErrAddEditionAlreadyExists), logData)
+			return nil, errs.ErrAddEditionAlreadyExists
+		}
+
+		editionDoc := models.CreateEmptyEdition(datasetID, edition)
+		metadata.Apply(editionDoc.Next)
+
+		if err = api.upsertEdition(ctx, datasetID, edition, editionDoc, mongo.AnyETag, logData); err != nil {
+			return nil, err
+		}
+		eTag = editionDoc.ETag
+
+		api.renderLinks(r, editionDoc)
+		b, err := json.Marshal(editionDoc)
+		if err != nil {
+			log.Event(ctx, "addEdition endpoint: failed to marshal edition resource into bytes", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+		return b, nil
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	setJSONContentType(w)
+	setETag(w, eTag)
+	w.WriteHeader(http.StatusCreated)
+	if _, err = w.Write(b); err != nil {
+		log.Event(ctx, "addEdition endpoint: error writing bytes to response", log.ERROR, log.Error(err), logData)
+		utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
+	}
+	log.Event(ctx, "addEdition endpoint: request completed successfully", log.INFO, logData)
+}
+
+func (api *DatasetAPI) putEdition(w http.ResponseWriter, r *http.Request) {
+
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	datasetID := vars["dataset_id"]
+	edition := vars["edition"]
+	eTag := getIfMatch(r)
+	logData := log.Data{"dataset_id": datasetID, "edition": edition}
+
+	newETag, err := func() (string, error) {
+		metadata, err := models.CreateEditionMetadata(r.Body)
+		if err != nil {
+			log.Event(ctx, "putEdition endpoint: failed to model edition resource based on request", log.ERROR, log.Error(err), logData)
+			return "", errs.ErrAddUpdateEditionBadRequest
+		}
+
+		editionDoc, err := api.getEditionForUpdate(ctx, datasetID, edition, eTag, logData)
+		if err != nil {
+			return "", err
+		}
+
+		metadata.Apply(editionDoc.Next)
+
+		if err = api.upsertEdition(ctx, datasetID, edition, editionDoc, eTag, logData); err != nil {
+			return "", err
+		}
+		return editionDoc.ETag, nil
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	setJSONContentType(w)
+	setETag(w, newETag)
+	w.WriteHeader(http.StatusOK)
+	log.Event(ctx, "putEdition endpoint: request successful", log.INFO, logData)
+}
+
+func (api *DatasetAPI) deleteEdition(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	datasetID := vars["dataset_id"]
+	edition := vars["edition"]
+	eTag := getIfMatch(r)
+	logData := log.Data{"dataset_id": datasetID, "edition": edition, "func": "deleteEdition"}
+
+	err := func() error {
+		editionDoc, err := api.getEditionForUpdate(ctx, datasetID, edition, eTag, logData)
+		if err != nil {
+			return err
+		}
+
+		if editionDoc.Current != nil {
+			log.Event(ctx, "unable to delete a published edition", log.ERROR, log.Error(errs.ErrDeletePublishedEditionForbidden), logData)
+			return errs.ErrDeletePublishedEditionForbidden
+		}
+
+		instances, err := api.dataStore.Backend.GetDatasetInstances(ctx, datasetID)
+		if err != nil {
+			log.Event(ctx, "unable to find the dataset instances", log.ERROR, log.Error(err), logData)
+			return err
+		}
+
+		for _, instance := range instances {
+			if instance.Edition == edition && models.IsPublished(instance.State) {
+				logData["instance_id"] = instance.InstanceID
+				log.Event(ctx, "unable to delete an edition with a published version", log.ERROR, log.Error(errs.ErrDeletePublishedEditionForbidden), logData)
+				return errs.ErrDeletePublishedEditionForbidden
+			}
+		}
+
+		// the unpublished versions of the edition are deleted along with it, and purged once the retention window
+		// has passed
+		if err := api.dataStore.Backend.SoftDeleteEdition(ctx, datasetID, edition, eTag, deletedBy(ctx), time.Now()); err != nil {
+			log.Event(ctx, "failed to delete edition", log.ERROR, log.Error(err), logData)
+			return err
+		}
+		return nil
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Event(ctx, "delete edition", log.INFO, logData)
+}
+
+// getEditionForUpdate returns the edition of an existing dataset, if it matches the expected eTag
+func (api *DatasetAPI) getEditionForUpdate(ctx context.Context, datasetID, edition, eTag string, logData log.Data) (*models.EditionUpdate, error) {
+	if _, err := api.dataStore.Backend.GetDataset(ctx, datasetID); err != nil {
+		log.Event(ctx, "unable to find dataset", log.ERROR, log.Error(err), logData)
+		return nil, err
+	}
+
+	editionDoc, err := api.dataStore.Backend.GetEdition(ctx, datasetID, edition, "")
+	if err != nil {
+		log.Event(ctx, "unable to find edition", log.ERROR, log.Error(err), logData)
+		return nil, err
+	}
+
+	if eTag != mongo.AnyETag && eTag != editionDoc.ETag {
+		log.Event(ctx, "edition does not match the expected eTag", log.ERROR, log.Error(errs.ErrEditionConflict), logData)
+		return nil, errs.ErrEditionConflict
+	}
+
+	return editionDoc, nil
+}
+
+// upsertEdition stores the edition, if it matches the eTag selector, then unmarks any other edition of the dataset as
+// the latest edition if this edition has been marked as the latest. The edition is stored first, so an edition changed
+// by another request leaves the other editions unchanged.
+func (api *DatasetAPI) upsertEdition(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate, eTagSelector string, logData log.Data) error {
+	if err := api.dataStore.Backend.UpsertEdition(ctx, datasetID, edition, editionDoc, eTagSelector); err != nil {
+		log.Event(ctx, "failed to store edition", log.ERROR, log.Error(err), logData)
+		return err
+	}
+
+	if editionDoc.Next.Latest {
+		if err := api.dataStore.Backend.ClearLatestEditions(ctx, datasetID, edition); err != nil {
+			log.Event(ctx, "failed to unmark the previous latest edition", log.ERROR, log.Error(err), logData)
+			return err
+		}
+	}
+	return nil
+}
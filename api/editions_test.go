@@ -1,10 +1,13 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/mocks"
@@ -26,7 +29,7 @@ func TestGetEditionsReturnsOK(t *testing.T) {
 		publicResult := &models.Edition{ID: "20"}
 		results := []*models.EditionUpdate{{Current: publicResult}}
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
 			GetEditionsFunc: func(ctx context.Context, id string, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
@@ -53,7 +56,7 @@ func TestGetEditionsReturnsError(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123-456/editions", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return errs.ErrInternalServer
 			},
 		}
@@ -76,7 +79,7 @@ func TestGetEditionsReturnsError(t *testing.T) {
 		r.Header.Add("internal-token", "coffee")
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return errs.ErrDatasetNotFound
 			},
 		}
@@ -89,6 +92,8 @@ func TestGetEditionsReturnsError(t *testing.T) {
 		So(w.Code, ShouldEqual, http.StatusNotFound)
 		So(datasetPermissions.Required.Calls, ShouldEqual, 1)
 		So(permissions.Required.Calls, ShouldEqual, 0)
+		So(w.Header().Get("Content-Type"), ShouldEqual, "application/problem+json")
+		So(w.Body.String(), ShouldContainSubstring, `"code":"dataset_not_found"`)
 		So(w.Body.String(), ShouldContainSubstring, errs.ErrDatasetNotFound.Error())
 		So(len(mockedDataStore.CheckDatasetExistsCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.GetEditionsCalls()), ShouldEqual, 0)
@@ -99,7 +104,7 @@ func TestGetEditionsReturnsError(t *testing.T) {
 		r.Header.Add("internal-token", "coffee")
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
 			GetEditionsFunc: func(ctx context.Context, id string, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
@@ -124,7 +129,7 @@ func TestGetEditionsReturnsError(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123-456/editions", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
 			GetEditionsFunc: func(ctx context.Context, id string, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
@@ -152,10 +157,10 @@ func TestGetEditionReturnsOK(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123-456/editions/678", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
-			GetEditionFunc: func(id string, editionID string, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, id string, editionID string, state string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{}, nil
 			},
 		}
@@ -179,7 +184,7 @@ func TestGetEditionReturnsError(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123-456/editions/678", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return errs.ErrInternalServer
 			},
 		}
@@ -202,7 +207,7 @@ func TestGetEditionReturnsError(t *testing.T) {
 		r.Header.Add("internal-token", "coffee")
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return errs.ErrDatasetNotFound
 			},
 		}
@@ -225,10 +230,10 @@ func TestGetEditionReturnsError(t *testing.T) {
 		r.Header.Add("internal-token", "coffee")
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
-			GetEditionFunc: func(id string, editionID string, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, id string, editionID string, state string) (*models.EditionUpdate, error) {
 				return nil, errs.ErrEditionNotFound
 			},
 		}
@@ -250,10 +255,10 @@ func TestGetEditionReturnsError(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123-456/editions/678", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
-			GetEditionFunc: func(id string, editionID string, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, id string, editionID string, state string) (*models.EditionUpdate, error) {
 				return nil, errs.ErrEditionNotFound
 			},
 		}
@@ -271,3 +276,337 @@ func TestGetEditionReturnsError(t *testing.T) {
 		So(len(mockedDataStore.GetEditionCalls()), ShouldEqual, 1)
 	})
 }
+
+func TestPostEdition(t *testing.T) {
+	t.Parallel()
+	Convey("Given a dataset without the edition", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{State: models.CreatedState}}, nil
+			},
+			GetEditionFunc: func(ctx context.Context, id string, editionID string, state string) (*models.EditionUpdate, error) {
+				return nil, errs.ErrEditionNotFound
+			},
+			ClearLatestEditionsFunc: func(ctx context.Context, datasetID, edition string) error {
+				return nil
+			},
+			UpsertEditionFunc: func(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error {
+				editionDoc.ETag = testETag
+				return nil
+			},
+		}
+		datasetPermissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, getAuthorisationHandlerMock())
+
+		Convey("When the edition is created with a title and description", func() {
+			b := `{"title":"Time series","description":"Monthly figures"}`
+			r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/time-series", bytes.NewBufferString(b))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then an empty edition is created and returned", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(datasetPermissions.Required.Calls, ShouldEqual, 1)
+				So(w.Header().Get("ETag"), ShouldEqual, `"`+testETag+`"`)
+				So(mockedDataStore.UpsertEditionCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.ClearLatestEditionsCalls(), ShouldHaveLength, 0)
+
+				var edition models.EditionUpdate
+				So(json.Unmarshal(w.Body.Bytes(), &edition), ShouldBeNil)
+				So(edition.Next.Edition, ShouldEqual, "time-series")
+				So(edition.Next.State, ShouldEqual, models.CreatedState)
+				So(edition.Next.Title, ShouldEqual, "Time series")
+				So(edition.Next.Description, ShouldEqual, "Monthly figures")
+				So(edition.Next.Links.Self.HRef, ShouldEqual, "http://localhost:22000/datasets/123/editions/time-series")
+				So(edition.Next.Links.LatestVersion, ShouldBeNil)
+			})
+		})
+
+		Convey("When the edition is created as the latest edition", func() {
+			r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/time-series", bytes.NewBufferString(`{"latest":true}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then any other edition is unmarked as the latest edition", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(mockedDataStore.ClearLatestEditionsCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.ClearLatestEditionsCalls()[0].DatasetID, ShouldEqual, "123")
+				So(mockedDataStore.ClearLatestEditionsCalls()[0].Edition, ShouldEqual, "time-series")
+				So(mockedDataStore.UpsertEditionCalls()[0].EditionDoc.Next.Latest, ShouldBeTrue)
+			})
+		})
+
+		Convey("When the request body is not valid json", func() {
+			r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/time-series", bytes.NewBufferString("{"))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a bad request is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(mockedDataStore.UpsertEditionCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+
+	Convey("Given a dataset with the edition", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123"}, nil
+			},
+			GetEditionFunc: func(ctx context.Context, id string, editionID string, state string) (*models.EditionUpdate, error) {
+				return &models.EditionUpdate{ID: "456"}, nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When the edition is created", func() {
+			r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/time-series", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the request is forbidden", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrAddEditionAlreadyExists.Error())
+				So(mockedDataStore.UpsertEditionCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+
+	Convey("Given no dataset", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When an edition is created", func() {
+			r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/time-series", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then not found is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(mockedDataStore.GetEditionCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestPutEdition(t *testing.T) {
+	t.Parallel()
+	Convey("Given an edition", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123"}, nil
+			},
+			GetEditionFunc: func(ctx context.Context, id string, editionID string, state string) (*models.EditionUpdate, error) {
+				if editionID != "time-series" {
+					return nil, errs.ErrEditionNotFound
+				}
+				return &models.EditionUpdate{
+					ID:   "456",
+					Next: &models.Edition{Edition: "time-series", Title: "Time series", State: models.EditionConfirmedState},
+					ETag: testETag,
+				}, nil
+			},
+			ClearLatestEditionsFunc: func(ctx context.Context, datasetID, edition string) error {
+				return nil
+			},
+			UpsertEditionFunc: func(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error {
+				editionDoc.ETag = "newETag"
+				return nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When the edition is updated", func() {
+			b := `{"description":"Monthly figures","latest":true}`
+			r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123/editions/time-series", bytes.NewBufferString(b))
+			r.Header.Set("If-Match", testETag)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then only the provided fields are updated", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("ETag"), ShouldEqual, `"newETag"`)
+				So(mockedDataStore.UpsertEditionCalls(), ShouldHaveLength, 1)
+
+				next := mockedDataStore.UpsertEditionCalls()[0].EditionDoc.Next
+				So(next.Title, ShouldEqual, "Time series")
+				So(next.Description, ShouldEqual, "Monthly figures")
+				So(next.Latest, ShouldBeTrue)
+				So(next.State, ShouldEqual, models.EditionConfirmedState)
+				So(mockedDataStore.ClearLatestEditionsCalls(), ShouldHaveLength, 1)
+			})
+
+			Convey("And the edition is only updated if it still matches the eTag", func() {
+				So(mockedDataStore.UpsertEditionCalls()[0].ETagSelector, ShouldEqual, testETag)
+			})
+		})
+
+		Convey("When the edition is updated with an empty title", func() {
+			r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123/editions/time-series", bytes.NewBufferString(`{"title":""}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the title is cleared", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedDataStore.UpsertEditionCalls()[0].EditionDoc.Next.Title, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the edition is changed by another request while it is updated", func() {
+			mockedDataStore.UpsertEditionFunc = func(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error {
+				return errs.ErrEditionConflict
+			}
+			r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123/editions/time-series", bytes.NewBufferString(`{"latest":true}`))
+			r.Header.Set("If-Match", testETag)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a conflict is returned, and no other edition is changed", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(mockedDataStore.ClearLatestEditionsCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the edition is updated with an out of date eTag", func() {
+			r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123/editions/time-series", bytes.NewBufferString(`{"title":"Monthly"}`))
+			r.Header.Set("If-Match", "wrongETag")
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a conflict is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(mockedDataStore.UpsertEditionCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When an unknown edition is updated", func() {
+			r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123/editions/2017", bytes.NewBufferString(`{"title":"Monthly"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then not found is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(mockedDataStore.UpsertEditionCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestDeleteEdition(t *testing.T) {
+	t.Parallel()
+	Convey("Given an unpublished edition with an unpublished version", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{State: models.CreatedState}}, nil
+			},
+			GetEditionFunc: func(ctx context.Context, id string, editionID string, state string) (*models.EditionUpdate, error) {
+				return &models.EditionUpdate{ID: "edition-1", Next: &models.Edition{Edition: "2017"}}, nil
+			},
+			GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+				return []*models.Instance{
+					{InstanceID: "instance-1", Edition: "2017", State: models.EditionConfirmedState},
+					{InstanceID: "instance-2", Edition: "2018", State: models.PublishedState},
+				}, nil
+			},
+			SoftDeleteEditionFunc: func(ctx context.Context, datasetID, edition, eTagSelector, deletedBy string, deletedAt time.Time) error {
+				return nil
+			},
+		}
+		datasetPermissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, getAuthorisationHandlerMock())
+
+		Convey("When the edition is deleted", func() {
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123/editions/2017", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the edition and its versions are moved into the deleted state, recording who deleted them", func() {
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+				So(datasetPermissions.Required.Calls, ShouldEqual, 1)
+				So(mockedDataStore.SoftDeleteEditionCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.SoftDeleteEditionCalls()[0].DatasetID, ShouldEqual, "123")
+				So(mockedDataStore.SoftDeleteEditionCalls()[0].Edition, ShouldEqual, "2017")
+				So(mockedDataStore.SoftDeleteEditionCalls()[0].ETagSelector, ShouldEqual, "*")
+				So(mockedDataStore.SoftDeleteEditionCalls()[0].DeletedBy, ShouldEqual, "someone@ons.gov.uk")
+				So(mockedDataStore.SoftDeleteEditionCalls()[0].DeletedAt, ShouldHappenWithin, time.Minute, time.Now())
+			})
+
+			Convey("And nothing is removed", func() {
+				So(mockedDataStore.DeleteInstanceNodesCalls(), ShouldHaveLength, 0)
+				So(mockedDataStore.DeleteInstanceCalls(), ShouldHaveLength, 0)
+				So(mockedDataStore.DeleteEditionCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the edition is changed by another request while it is deleted", func() {
+			mockedDataStore.SoftDeleteEditionFunc = func(ctx context.Context, datasetID, edition, eTagSelector, deletedBy string, deletedAt time.Time) error {
+				return errs.ErrEditionConflict
+			}
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123/editions/2017", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a conflict is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+			})
+		})
+	})
+
+	Convey("Given an edition with a published version", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{State: models.CreatedState}}, nil
+			},
+			GetEditionFunc: func(ctx context.Context, id string, editionID string, state string) (*models.EditionUpdate, error) {
+				return &models.EditionUpdate{ID: "edition-1", Next: &models.Edition{Edition: "2017"}}, nil
+			},
+			GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+				return []*models.Instance{
+					{InstanceID: "instance-1", Edition: "2017", State: models.EditionConfirmedState},
+					{InstanceID: "instance-2", Edition: "2017", State: models.PublishedState},
+				}, nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When the edition is deleted", func() {
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123/editions/2017", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the request is forbidden and nothing is removed", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrDeletePublishedEditionForbidden.Error())
+				So(mockedDataStore.SoftDeleteEditionCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+
+	Convey("Given a published edition", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{State: models.CreatedState}}, nil
+			},
+			GetEditionFunc: func(ctx context.Context, id string, editionID string, state string) (*models.EditionUpdate, error) {
+				return &models.EditionUpdate{ID: "edition-1", Current: &models.Edition{State: models.PublishedState}}, nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When the edition is deleted", func() {
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123/editions/2017", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the request is forbidden", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(mockedDataStore.GetDatasetInstancesCalls(), ShouldHaveLength, 0)
+				So(mockedDataStore.SoftDeleteEditionCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,248 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
+	"github.com/ONSdigital/dp-dataset-api/utils"
+	"github.com/ONSdigital/log.go/log"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// graphQLSchema exposes the datasets, editions, versions, dimensions and dimension options of the api, so that a page
+// needing all of them can be rendered from a single request. Lists are paginated as they are by the REST endpoints.
+const graphQLSchema = `
+schema {
+	query: Query
+}
+
+type Query {
+	datasets(offset: Int, limit: Int): DatasetPage!
+	dataset(id: ID!): Dataset
+}
+
+type DatasetPage {
+	items: [Dataset!]!
+	count: Int!
+	offset: Int!
+	limit: Int!
+	totalCount: Int!
+}
+
+type Dataset {
+	id: ID!
+	href: String
+	title: String
+	description: String
+	keywords: [String!]
+	license: String
+	nationalStatistic: Boolean
+	nextRelease: String
+	releaseFrequency: String
+	state: String
+	theme: String
+	type: String
+	unitOfMeasure: String
+	uri: String
+	editions(offset: Int, limit: Int): EditionPage!
+	edition(id: String!): Edition
+}
+
+type EditionPage {
+	items: [Edition!]!
+	count: Int!
+	offset: Int!
+	limit: Int!
+	totalCount: Int!
+}
+
+type Edition {
+	id: ID!
+	edition: String!
+	href: String
+	title: String
+	description: String
+	state: String
+	latestVersion: Version
+	versions(offset: Int, limit: Int): VersionPage!
+	version(id: Int!): Version
+}
+
+type VersionPage {
+	items: [Version!]!
+	count: Int!
+	offset: Int!
+	limit: Int!
+	totalCount: Int!
+}
+
+type Version {
+	id: ID!
+	version: Int!
+	edition: String!
+	href: String
+	releaseDate: String
+	state: String
+	downloads: Downloads
+	dimensions(offset: Int, limit: Int): DimensionPage!
+}
+
+type Downloads {
+	csv: Download
+	csvw: Download
+	xls: Download
+}
+
+type Download {
+	href: String
+	size: String
+}
+
+type DimensionPage {
+	items: [Dimension!]!
+	count: Int!
+	offset: Int!
+	limit: Int!
+	totalCount: Int!
+}
+
+type Dimension {
+	name: String!
+	label: String
+	description: String
+	options(offset: Int, limit: Int): DimensionOptionPage!
+}
+
+type DimensionOptionPage {
+	items: [DimensionOption!]!
+	count: Int!
+	offset: Int!
+	limit: Int!
+	totalCount: Int!
+}
+
+type DimensionOption {
+	option: String!
+	label: String
+}
+`
+
+type graphQLQueryKey struct{}
+
+// errGraphQLCostExceeded is returned by the resolvers once a query has fetched more than the maximum cost allows
+var errGraphQLCostExceeded = errors.New("query exceeds the maximum cost")
+
+// graphQLParams represents the body of a GraphQL request
+type graphQLParams struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// graphQLQuery holds the state of a GraphQL query shared by its resolvers, which may run concurrently
+type graphQLQuery struct {
+	api           *DatasetAPI
+	paginator     *pagination.Paginator
+	r             *http.Request
+	authenticated bool
+
+	mu        sync.Mutex
+	remaining int
+}
+
+// graphQL returns a handler executing GraphQL queries against the datasets the caller can view. Queries nested deeper
+// than the maximum depth are rejected before they are executed, and each query may only fetch up to the maximum cost
+// of documents, with lists costing their limit, so that a single request cannot fan out over the whole store.
+func (api *DatasetAPI) graphQL(paginator *pagination.Paginator) http.HandlerFunc {
+	schema := graphql.MustParseSchema(graphQLSchema, &graphQLResolver{}, graphql.MaxDepth(api.graphQLMaxDepth))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logData := log.Data{}
+
+		var params graphQLParams
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			log.Event(ctx, "graphQL endpoint: failed to parse json body", log.ERROR, log.Error(err), logData)
+			utils.WriteError(ctx, w, errs.ErrUnableToParseJSON, http.StatusBadRequest)
+			return
+		}
+		logData["operation_name"] = params.OperationName
+
+		q := &graphQLQuery{
+			api:           api,
+			paginator:     paginator,
+			r:             r,
+			authenticated: api.authenticate(r, logData),
+			remaining:     api.graphQLMaxCost,
+		}
+
+		response := schema.Exec(context.WithValue(ctx, graphQLQueryKey{}, q), params.Query, params.OperationName, params.Variables)
+		if len(response.Errors) > 0 {
+			logData["errors"] = response.Errors
+			log.Event(ctx, "graphQL endpoint: query returned errors", log.WARN, logData)
+		}
+
+		b, err := json.Marshal(response)
+		if err != nil {
+			log.Event(ctx, "graphQL endpoint: failed to marshal query response into bytes", log.ERROR, log.Error(err), logData)
+			utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
+			return
+		}
+
+		setJSONContentType(w)
+		if _, err = w.Write(b); err != nil {
+			log.Event(ctx, "graphQL endpoint: error writing bytes to response", log.ERROR, log.Error(err), logData)
+			return
+		}
+		log.Event(ctx, "graphQL endpoint: request successful", log.INFO, logData)
+	}
+}
+
+// charge deducts the cost of fetching documents from what remains of the maximum cost of the query
+func (q *graphQLQuery) charge(cost int) error {
+	if cost < 1 {
+		cost = 1
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if cost > q.remaining {
+		q.remaining = 0
+		return errGraphQLCostExceeded
+	}
+	q.remaining -= cost
+	return nil
+}
+
+// page returns the offset and limit of a list, defaulted and bounded by the paginator as the REST endpoints are
+func (q *graphQLQuery) page(args pageArgs) (offset, limit int, err error) {
+	offset, limit = q.paginator.DefaultOffset, q.paginator.DefaultLimit
+	if args.Offset != nil {
+		offset = int(*args.Offset)
+	}
+	if args.Limit != nil {
+		limit = int(*args.Limit)
+	}
+
+	if offset < 0 || limit < 0 || limit > q.paginator.DefaultMaxLimit {
+		return 0, 0, errs.ErrInvalidQueryParameter
+	}
+	return offset, limit, q.charge(limit)
+}
+
+// link returns the link to the path of the api, rendered against the url the query was made to
+func (q *graphQLQuery) link(path string) *string {
+	return optionalString(q.api.baseURL(q.r) + path)
+}
+
+// internalError logs the error and hides its details from the caller
+func (q *graphQLQuery) internalError(msg string, err error, logData log.Data) error {
+	log.Event(q.r.Context(), msg, log.ERROR, log.Error(err), logData)
+	return errs.ErrInternalServer
+}
@@ -0,0 +1,466 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/utils"
+	"github.com/ONSdigital/log.go/log"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// pageArgs are the pagination arguments of the lists in the GraphQL schema
+type pageArgs struct {
+	Offset *int32
+	Limit  *int32
+}
+
+// graphQLPage resolves the pagination fields shared by the lists in the GraphQL schema
+type graphQLPage struct {
+	count, offset, limit, totalCount int
+}
+
+func newGraphQLPage(count, offset, limit, totalCount int) graphQLPage {
+	return graphQLPage{count: count, offset: offset, limit: limit, totalCount: totalCount}
+}
+
+func (p graphQLPage) Count() int32      { return int32(p.count) }
+func (p graphQLPage) Offset() int32     { return int32(p.offset) }
+func (p graphQLPage) Limit() int32      { return int32(p.limit) }
+func (p graphQLPage) TotalCount() int32 { return int32(p.totalCount) }
+
+// graphQLResolver resolves the root query of the GraphQL schema
+type graphQLResolver struct{}
+
+func (g *graphQLResolver) query(ctx context.Context) *graphQLQuery {
+	return ctx.Value(graphQLQueryKey{}).(*graphQLQuery)
+}
+
+// Datasets resolves the datasets the caller can view, as GET /datasets does
+func (g *graphQLResolver) Datasets(ctx context.Context, args pageArgs) (*datasetPageResolver, error) {
+	q := g.query(ctx)
+	logData := log.Data{}
+
+	offset, limit, err := q.page(args)
+	if err != nil {
+		return nil, err
+	}
+
+	datasets, totalCount, err := q.api.dataStore.Backend.GetDatasets(ctx, offset, limit, q.authenticated)
+	if err != nil {
+		return nil, q.internalError("graphQL: failed to get datasets", err, logData)
+	}
+
+	items := []*datasetResolver{}
+	for _, item := range datasets {
+		if q.authenticated && q.api.canAccessCollection(q.r, item.ID, nextCollectionID(item), logData) {
+			items = append(items, newDatasetResolver(q, item.ID, item.Next, true))
+			continue
+		}
+		if item.Current == nil {
+			totalCount--
+			continue
+		}
+		items = append(items, newDatasetResolver(q, item.ID, item.Current, false))
+	}
+
+	return &datasetPageResolver{graphQLPage: newGraphQLPage(len(items), offset, limit, totalCount), items: items}, nil
+}
+
+// Dataset resolves a dataset, as GET /datasets/{dataset_id} does
+func (g *graphQLResolver) Dataset(ctx context.Context, args struct{ ID graphql.ID }) (*datasetResolver, error) {
+	q := g.query(ctx)
+	datasetID := string(args.ID)
+	logData := log.Data{"dataset_id": datasetID}
+
+	if err := q.charge(1); err != nil {
+		return nil, err
+	}
+
+	dataset, err := q.api.dataStore.Backend.GetDataset(ctx, datasetID)
+	if err == errs.ErrDatasetNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, q.internalError("graphQL: failed to get dataset", err, logData)
+	}
+
+	authorised := q.api.canAccessCollection(q.r, datasetID, nextCollectionID(dataset), logData)
+	viewable := viewableDataset(dataset, authorised)
+	if viewable == nil {
+		return nil, nil
+	}
+	return newDatasetResolver(q, datasetID, viewable, authorised), nil
+}
+
+type datasetPageResolver struct {
+	graphQLPage
+	items []*datasetResolver
+}
+
+func (p *datasetPageResolver) Items() []*datasetResolver { return p.items }
+
+// datasetResolver resolves a dataset. authorised is whether the caller can view the unpublished changes to it.
+type datasetResolver struct {
+	q          *graphQLQuery
+	dataset    *models.Dataset
+	authorised bool
+}
+
+func newDatasetResolver(q *graphQLQuery, datasetID string, dataset *models.Dataset, authorised bool) *datasetResolver {
+	dataset.ID = datasetID
+	return &datasetResolver{q: q, dataset: dataset, authorised: authorised}
+}
+
+func (d *datasetResolver) ID() graphql.ID           { return graphql.ID(d.dataset.ID) }
+func (d *datasetResolver) Title() *string           { return optionalString(d.dataset.Title) }
+func (d *datasetResolver) Description() *string     { return optionalString(d.dataset.Description) }
+func (d *datasetResolver) License() *string         { return optionalString(d.dataset.License) }
+func (d *datasetResolver) NationalStatistic() *bool { return d.dataset.NationalStatistic }
+func (d *datasetResolver) NextRelease() *string     { return optionalString(d.dataset.NextRelease) }
+func (d *datasetResolver) ReleaseFrequency() *string {
+	return optionalString(d.dataset.ReleaseFrequency)
+}
+func (d *datasetResolver) State() *string         { return optionalString(d.dataset.State) }
+func (d *datasetResolver) Theme() *string         { return optionalString(d.dataset.Theme) }
+func (d *datasetResolver) Type() *string          { return optionalString(d.dataset.Type) }
+func (d *datasetResolver) UnitOfMeasure() *string { return optionalString(d.dataset.UnitOfMeasure) }
+func (d *datasetResolver) URI() *string           { return optionalString(d.dataset.URI) }
+func (d *datasetResolver) Keywords() *[]string    { return optionalStrings(d.dataset.Keywords) }
+func (d *datasetResolver) Href() *string {
+	return d.q.link(fmt.Sprintf("/datasets/%s", d.dataset.ID))
+}
+
+// Editions resolves the editions of the dataset the caller can view, as GET /datasets/{dataset_id}/editions does
+func (d *datasetResolver) Editions(ctx context.Context, args pageArgs) (*editionPageResolver, error) {
+	q := d.q
+	logData := log.Data{"dataset_id": d.dataset.ID}
+
+	offset, limit, err := q.page(args)
+	if err != nil {
+		return nil, err
+	}
+
+	results, totalCount, err := q.api.dataStore.Backend.GetEditions(ctx, d.dataset.ID, d.state(), offset, limit, d.authorised)
+	if err == errs.ErrEditionNotFound {
+		results, totalCount = nil, 0
+	} else if err != nil {
+		return nil, q.internalError("graphQL: failed to get editions", err, logData)
+	}
+
+	items := []*editionResolver{}
+	for _, item := range results {
+		if edition := d.viewableEdition(item); edition != nil {
+			items = append(items, &editionResolver{q: q, datasetID: d.dataset.ID, edition: edition})
+		}
+	}
+
+	return &editionPageResolver{graphQLPage: newGraphQLPage(len(items), offset, limit, totalCount), items: items}, nil
+}
+
+// Edition resolves an edition of the dataset, as GET /datasets/{dataset_id}/editions/{edition} does
+func (d *datasetResolver) Edition(ctx context.Context, args struct{ ID string }) (*editionResolver, error) {
+	q := d.q
+	logData := log.Data{"dataset_id": d.dataset.ID, "edition": args.ID}
+
+	if err := q.charge(1); err != nil {
+		return nil, err
+	}
+
+	result, err := q.api.dataStore.Backend.GetEdition(ctx, d.dataset.ID, args.ID, d.state())
+	if err == errs.ErrEditionNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, q.internalError("graphQL: failed to get edition", err, logData)
+	}
+
+	edition := d.viewableEdition(result)
+	if edition == nil {
+		return nil, nil
+	}
+	return &editionResolver{q: q, datasetID: d.dataset.ID, edition: edition}, nil
+}
+
+// state returns the state the editions of the dataset must be in to be viewed by the caller
+func (d *datasetResolver) state() string {
+	if d.authorised {
+		return ""
+	}
+	return models.PublishedState
+}
+
+func (d *datasetResolver) viewableEdition(edition *models.EditionUpdate) *models.Edition {
+	if d.authorised {
+		return edition.Next
+	}
+	return edition.Current
+}
+
+type editionPageResolver struct {
+	graphQLPage
+	items []*editionResolver
+}
+
+func (p *editionPageResolver) Items() []*editionResolver { return p.items }
+
+// editionResolver resolves an edition of a dataset
+type editionResolver struct {
+	q         *graphQLQuery
+	datasetID string
+	edition   *models.Edition
+}
+
+func (e *editionResolver) ID() graphql.ID       { return graphql.ID(e.edition.ID) }
+func (e *editionResolver) Edition() string      { return e.edition.Edition }
+func (e *editionResolver) Title() *string       { return optionalString(e.edition.Title) }
+func (e *editionResolver) Description() *string { return optionalString(e.edition.Description) }
+func (e *editionResolver) State() *string       { return optionalString(e.edition.State) }
+func (e *editionResolver) Href() *string {
+	return e.q.link(fmt.Sprintf("/datasets/%s/editions/%s", e.datasetID, e.edition.Edition))
+}
+
+// LatestVersion resolves the latest version of the edition the caller can view
+func (e *editionResolver) LatestVersion(ctx context.Context) (*versionResolver, error) {
+	if e.edition.Links == nil || e.edition.Links.LatestVersion == nil {
+		return nil, nil
+	}
+
+	number, err := strconv.Atoi(e.edition.Links.LatestVersion.ID)
+	if err != nil {
+		return nil, nil
+	}
+	return e.version(ctx, number)
+}
+
+// Version resolves a version of the edition, as GET /datasets/{dataset_id}/editions/{edition}/versions/{version} does
+func (e *editionResolver) Version(ctx context.Context, args struct{ ID int32 }) (*versionResolver, error) {
+	return e.version(ctx, int(args.ID))
+}
+
+func (e *editionResolver) version(ctx context.Context, number int) (*versionResolver, error) {
+	q := e.q
+	logData := log.Data{"dataset_id": e.datasetID, "edition": e.edition.Edition, "version": number}
+
+	if err := q.charge(1); err != nil {
+		return nil, err
+	}
+
+	version, err := q.api.dataStore.Backend.GetVersion(ctx, e.datasetID, e.edition.Edition, number, q.state())
+	if err == errs.ErrVersionNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, q.internalError("graphQL: failed to get version", err, logData)
+	}
+
+	if !q.api.canViewVersion(q.r, q.authenticated, e.datasetID, version, logData) {
+		return nil, nil
+	}
+
+	if err = models.CheckState("version", version.State); err != nil {
+		logData["state"] = version.State
+		log.Event(q.r.Context(), "graphQL: unpublished version has an invalid state", log.ERROR, log.Error(err), logData)
+		return nil, errs.ErrResourceState
+	}
+	return &versionResolver{q: q, datasetID: e.datasetID, version: version}, nil
+}
+
+// Versions resolves the versions of the edition the caller can view, as
+// GET /datasets/{dataset_id}/editions/{edition}/versions does
+func (e *editionResolver) Versions(ctx context.Context, args pageArgs) (*versionPageResolver, error) {
+	q := e.q
+	logData := log.Data{"dataset_id": e.datasetID, "edition": e.edition.Edition}
+
+	offset, limit, err := q.page(args)
+	if err != nil {
+		return nil, err
+	}
+
+	results, totalCount, err := q.api.dataStore.Backend.GetVersions(ctx, e.datasetID, e.edition.Edition, q.state(), offset, limit)
+	if err == errs.ErrVersionNotFound {
+		results, totalCount = nil, 0
+	} else if err != nil {
+		return nil, q.internalError("graphQL: failed to get versions", err, logData)
+	}
+
+	results, totalCount = q.api.withoutInaccessibleVersions(q.r, q.authenticated, e.datasetID, results, totalCount, logData)
+
+	items := []*versionResolver{}
+	for i := range results {
+		if err = models.CheckState("version", results[i].State); err != nil {
+			logData["state"] = results[i].State
+			log.Event(ctx, "graphQL: unpublished version has an invalid state", log.ERROR, log.Error(err), logData)
+			return nil, errs.ErrResourceState
+		}
+		items = append(items, &versionResolver{q: q, datasetID: e.datasetID, version: &results[i]})
+	}
+
+	return &versionPageResolver{graphQLPage: newGraphQLPage(len(items), offset, limit, totalCount), items: items}, nil
+}
+
+// state returns the state versions must be in to be viewed by the caller
+func (q *graphQLQuery) state() string {
+	if q.authenticated {
+		return ""
+	}
+	return models.PublishedState
+}
+
+type versionPageResolver struct {
+	graphQLPage
+	items []*versionResolver
+}
+
+func (p *versionPageResolver) Items() []*versionResolver { return p.items }
+
+// versionResolver resolves a version of an edition
+type versionResolver struct {
+	q         *graphQLQuery
+	datasetID string
+	version   *models.Version
+}
+
+func (v *versionResolver) ID() graphql.ID       { return graphql.ID(v.version.ID) }
+func (v *versionResolver) Version() int32       { return int32(v.version.Version) }
+func (v *versionResolver) Edition() string      { return v.version.Edition }
+func (v *versionResolver) ReleaseDate() *string { return optionalString(v.version.ReleaseDate) }
+func (v *versionResolver) State() *string       { return optionalString(v.version.State) }
+func (v *versionResolver) Href() *string {
+	return v.q.link(fmt.Sprintf("/datasets/%s/editions/%s/versions/%d", v.datasetID, v.version.Edition, v.version.Version))
+}
+
+// Downloads resolves the downloads of the version. Only the download service can see where the files are stored,
+// so the private and public locations of the files are not part of the schema.
+func (v *versionResolver) Downloads() *downloadsResolver {
+	if v.version.Downloads == nil {
+		return nil
+	}
+	return &downloadsResolver{downloads: v.version.Downloads}
+}
+
+// Dimensions resolves the dimensions of the version, as
+// GET /datasets/{dataset_id}/editions/{edition}/versions/{version}/dimensions does
+func (v *versionResolver) Dimensions(ctx context.Context, args pageArgs) (*dimensionPageResolver, error) {
+	q := v.q
+	logData := log.Data{"dataset_id": v.datasetID, "edition": v.version.Edition, "version": v.version.Version}
+
+	offset, limit, err := q.page(args)
+	if err != nil {
+		return nil, err
+	}
+
+	dimensions, err := q.api.listDimensions(ctx, v.datasetID, v.version)
+	if err == errs.ErrDimensionsNotFound {
+		dimensions = nil
+	} else if err != nil {
+		return nil, q.internalError("graphQL: failed to get version dimensions", err, logData)
+	}
+
+	items := []*dimensionResolver{}
+	if limit > 0 {
+		for _, dimension := range utils.Slice(dimensions, offset, limit) {
+			items = append(items, &dimensionResolver{q: q, version: v.version, dimension: dimension})
+		}
+	}
+
+	return &dimensionPageResolver{graphQLPage: newGraphQLPage(len(items), offset, limit, len(dimensions)), items: items}, nil
+}
+
+// downloadsResolver resolves the downloads of a version
+type downloadsResolver struct {
+	downloads *models.DownloadList
+}
+
+func (d *downloadsResolver) CSV() *downloadResolver  { return newDownloadResolver(d.downloads.CSV) }
+func (d *downloadsResolver) CSVW() *downloadResolver { return newDownloadResolver(d.downloads.CSVW) }
+func (d *downloadsResolver) XLS() *downloadResolver  { return newDownloadResolver(d.downloads.XLS) }
+
+// downloadResolver resolves a download of a version
+type downloadResolver struct {
+	download *models.DownloadObject
+}
+
+func newDownloadResolver(download *models.DownloadObject) *downloadResolver {
+	if download == nil {
+		return nil
+	}
+	return &downloadResolver{download: download}
+}
+
+func (d *downloadResolver) Href() *string { return optionalString(d.download.HRef) }
+func (d *downloadResolver) Size() *string { return optionalString(d.download.Size) }
+
+type dimensionPageResolver struct {
+	graphQLPage
+	items []*dimensionResolver
+}
+
+func (p *dimensionPageResolver) Items() []*dimensionResolver { return p.items }
+
+// dimensionResolver resolves a dimension of a version
+type dimensionResolver struct {
+	q         *graphQLQuery
+	version   *models.Version
+	dimension models.Dimension
+}
+
+func (d *dimensionResolver) Name() string         { return d.dimension.Name }
+func (d *dimensionResolver) Label() *string       { return optionalString(d.dimension.Label) }
+func (d *dimensionResolver) Description() *string { return optionalString(d.dimension.Description) }
+
+// Options resolves the options of the dimension, as
+// GET /datasets/{dataset_id}/editions/{edition}/versions/{version}/dimensions/{dimension}/options does
+func (d *dimensionResolver) Options(ctx context.Context, args pageArgs) (*dimensionOptionPageResolver, error) {
+	q := d.q
+	logData := log.Data{"edition": d.version.Edition, "version": d.version.Version, "dimension": d.dimension.Name}
+
+	offset, limit, err := q.page(args)
+	if err != nil {
+		return nil, err
+	}
+
+	results, totalCount, err := q.api.dataStore.Backend.GetDimensionOptions(ctx, d.version, d.dimension.Name, offset, limit)
+	if err != nil {
+		return nil, q.internalError("graphQL: failed to get dimension options", err, logData)
+	}
+
+	items := []*dimensionOptionResolver{}
+	for _, option := range results {
+		items = append(items, &dimensionOptionResolver{option: option})
+	}
+
+	return &dimensionOptionPageResolver{graphQLPage: newGraphQLPage(len(items), offset, limit, totalCount), items: items}, nil
+}
+
+type dimensionOptionPageResolver struct {
+	graphQLPage
+	items []*dimensionOptionResolver
+}
+
+func (p *dimensionOptionPageResolver) Items() []*dimensionOptionResolver { return p.items }
+
+// dimensionOptionResolver resolves an option of a dimension
+type dimensionOptionResolver struct {
+	option *models.PublicDimensionOption
+}
+
+func (o *dimensionOptionResolver) Option() string { return o.option.Option }
+func (o *dimensionOptionResolver) Label() *string { return optionalString(o.option.Label) }
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func optionalStrings(s []string) *[]string {
+	if s == nil {
+		return nil
+	}
+	return &s
+}
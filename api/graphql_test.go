@@ -0,0 +1,240 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/pagination"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	"github.com/globalsign/mgo/bson"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const graphQLPageQuery = `{"query": "{ dataset(id: \"123\") { id title href editions(limit: 10) { totalCount items { edition latestVersion { version releaseDate downloads { csv { href size } } dimensions(limit: 10) { totalCount items { name label options(limit: 10) { totalCount items { option label } } } } } } } } }"}`
+
+type graphQLTestResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func decodeGraphQLResponse(w *httptest.ResponseRecorder) graphQLTestResponse {
+	var response graphQLTestResponse
+	So(json.Unmarshal(w.Body.Bytes(), &response), ShouldBeNil)
+	return response
+}
+
+func TestGraphQL(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a published dataset with unpublished changes", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{
+					ID:      "123",
+					Current: &models.Dataset{Title: "published title", State: models.PublishedState},
+					Next:    &models.Dataset{Title: "unpublished title", State: models.AssociatedState},
+				}, nil
+			},
+			GetEditionsFunc: func(ctx context.Context, ID, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
+				return []*models.EditionUpdate{{
+					Current: &models.Edition{
+						Edition: "2021",
+						State:   models.PublishedState,
+						Links:   &models.EditionUpdateLinks{LatestVersion: &models.LinkObject{ID: "2"}},
+					},
+				}}, 1, nil
+			},
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
+				return &models.Version{
+					ID:          "789",
+					Edition:     editionID,
+					Version:     version,
+					ReleaseDate: "2021-06-01",
+					State:       models.PublishedState,
+					Downloads: &models.DownloadList{
+						CSV: &models.DownloadObject{HRef: "http://localhost:23600/downloads/789.csv", Private: "s3://private/789.csv", Size: "10"},
+					},
+					Links: &models.VersionLinks{
+						Dataset: &models.LinkObject{ID: datasetID},
+						Version: &models.LinkObject{ID: "2"},
+					},
+				}, nil
+			},
+			GetDimensionsFunc: func(ctx context.Context, datasetID, versionID string) ([]bson.M, error) {
+				return []bson.M{{"doc": bson.M{"name": "geography"}}}, nil
+			},
+			GetDimensionOptionsFunc: func(ctx context.Context, version *models.Version, dimension string, offset, limit int) ([]*models.PublicDimensionOption, int, error) {
+				return []*models.PublicDimensionOption{{Option: "K02000001", Label: "United Kingdom"}}, 1, nil
+			},
+		}
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+
+		Convey("When a page of the dataset is queried without authentication", func() {
+			r := httptest.NewRequest("POST", "http://localhost:22000/graphql", strings.NewReader(graphQLPageQuery))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the published dataset, edition, version, dimensions and options are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(permissions.Required.Calls, ShouldEqual, 1)
+
+				response := decodeGraphQLResponse(w)
+				So(response.Errors, ShouldBeEmpty)
+				So(string(response.Data), ShouldEqual, `{"dataset":{"id":"123","title":"published title","href":"http://localhost:22000/datasets/123",`+
+					`"editions":{"totalCount":1,"items":[{"edition":"2021","latestVersion":{"version":2,"releaseDate":"2021-06-01",`+
+					`"downloads":{"csv":{"href":"http://localhost:23600/downloads/789.csv","size":"10"}},`+
+					`"dimensions":{"totalCount":1,"items":[{"name":"geography","label":null,`+
+					`"options":{"totalCount":1,"items":[{"option":"K02000001","label":"United Kingdom"}]}}]}}}]}}}`)
+			})
+
+			Convey("Then only published documents are requested from the store", func() {
+				So(mockedDataStore.GetEditionsCalls()[0].State, ShouldEqual, models.PublishedState)
+				So(mockedDataStore.GetEditionsCalls()[0].Limit, ShouldEqual, 10)
+				So(mockedDataStore.GetVersionCalls()[0].State, ShouldEqual, models.PublishedState)
+				So(mockedDataStore.GetVersionCalls()[0].Version, ShouldEqual, 2)
+				So(mockedDataStore.GetDimensionOptionsCalls()[0].Dimension, ShouldEqual, "geography")
+			})
+		})
+
+		Convey("When the dataset is queried with authentication", func() {
+			r := createRequestWithAuth("POST", "http://localhost:22000/graphql", strings.NewReader(`{"query": "{ dataset(id: \"123\") { title state } }"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the unpublished changes are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(string(decodeGraphQLResponse(w).Data), ShouldEqual, `{"dataset":{"title":"unpublished title","state":"associated"}}`)
+			})
+		})
+	})
+
+	Convey("Given a dataset that has never been published", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{State: models.CreatedState}}, nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When it is queried without authentication", func() {
+			r := httptest.NewRequest("POST", "http://localhost:22000/graphql", strings.NewReader(`{"query": "{ dataset(id: \"123\") { title } }"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then no dataset is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(string(decodeGraphQLResponse(w).Data), ShouldEqual, `{"dataset":null}`)
+			})
+		})
+	})
+
+	Convey("Given the api with datasets", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{
+					ID:      "123",
+					Current: &models.Dataset{Title: "published title", State: models.PublishedState},
+					Next:    &models.Dataset{Title: "unpublished title", State: models.AssociatedState},
+				}, nil
+			},
+			GetEditionsFunc: func(ctx context.Context, ID, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
+				return []*models.EditionUpdate{{
+					Current: &models.Edition{
+						Edition: "2021",
+						State:   models.PublishedState,
+						Links:   &models.EditionUpdateLinks{LatestVersion: &models.LinkObject{ID: "2"}},
+					},
+				}}, 1, nil
+			},
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
+				return &models.Version{
+					ID:          "789",
+					Edition:     editionID,
+					Version:     version,
+					ReleaseDate: "2021-06-01",
+					State:       models.PublishedState,
+					Downloads: &models.DownloadList{
+						CSV: &models.DownloadObject{HRef: "http://localhost:23600/downloads/789.csv", Private: "s3://private/789.csv", Size: "10"},
+					},
+					Links: &models.VersionLinks{
+						Dataset: &models.LinkObject{ID: datasetID},
+						Version: &models.LinkObject{ID: "2"},
+					},
+				}, nil
+			},
+			GetDimensionsFunc: func(ctx context.Context, datasetID, versionID string) ([]bson.M, error) {
+				return []bson.M{{"doc": bson.M{"name": "geography"}}}, nil
+			},
+			GetDimensionOptionsFunc: func(ctx context.Context, version *models.Version, dimension string, offset, limit int) ([]*models.PublicDimensionOption, int, error) {
+				return []*models.PublicDimensionOption{{Option: "K02000001", Label: "United Kingdom"}}, 1, nil
+			},
+			GetDatasetsFunc: func(ctx context.Context, offset, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
+				return []*models.DatasetUpdate{{ID: "123", Current: &models.Dataset{Title: "published title"}}}, 1, nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When a list is queried with a limit greater than the maximum", func() {
+			r := httptest.NewRequest("POST", "http://localhost:22000/graphql", strings.NewReader(`{"query": "{ datasets(limit: 1001) { totalCount } }"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the query fails without requesting the datasets", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				response := decodeGraphQLResponse(w)
+				So(response.Errors, ShouldHaveLength, 1)
+				So(response.Errors[0].Message, ShouldEqual, errs.ErrInvalidQueryParameter.Error())
+				So(mockedDataStore.GetDatasetsCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a query is nested deeper than the maximum depth", func() {
+			api.graphQLMaxDepth = 3
+			r := httptest.NewRequest("POST", "http://localhost:22000/graphql", strings.NewReader(graphQLPageQuery))
+			w := httptest.NewRecorder()
+			api.graphQL(pagination.NewPaginator(0, 0, 1000))(w, r)
+
+			Convey("Then the query is rejected before it is executed", func() {
+				response := decodeGraphQLResponse(w)
+				So(response.Errors, ShouldNotBeEmpty)
+				So(response.Errors[0].Message, ShouldContainSubstring, "exceeds max depth 3")
+				So(mockedDataStore.GetDatasetCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a query costs more than the maximum", func() {
+			api.graphQLMaxCost = 15
+			r := httptest.NewRequest("POST", "http://localhost:22000/graphql", strings.NewReader(graphQLPageQuery))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the query stops fetching documents once the maximum is reached", func() {
+				response := decodeGraphQLResponse(w)
+				So(response.Errors, ShouldHaveLength, 1)
+				So(response.Errors[0].Message, ShouldEqual, errGraphQLCostExceeded.Error())
+				So(mockedDataStore.GetDimensionsCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the body of the request is not json", func() {
+			r := httptest.NewRequest("POST", "http://localhost:22000/graphql", strings.NewReader("{ datasets { totalCount } }"))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a bad request is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
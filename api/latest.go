@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// latestSubPath matches the rest of a path after a latest alias, so that the alias can be used in place of an
+	// edition or version on every route beneath it
+	latestSubPath = "{path:(?:/.*)?}"
+
+	contentLocationHeader = "Content-Location"
+
+	// maxLatestAliases is the most aliases a path can be resolved through, which is an edition alias followed by a
+	// version alias
+	maxLatestAliases = 2
+)
+
+type latestAliasesKey struct{}
+
+// latestResolver returns the path of the resource a latest alias currently refers to for the caller of the request
+type latestResolver func(r *http.Request, vars map[string]string, logData log.Data) (string, error)
+
+// enableLatestAliases registers the latest aliases of editions and versions. They are registered ahead of every other
+// route, as the routes of editions and versions would otherwise match "latest" as an edition or version.
+func (api *DatasetAPI) enableLatestAliases() {
+	alias := func(path string, maxAge time.Duration, resolve latestResolver) {
+		handler := api.resolveLatest(maxAge, resolve)
+		if api.enablePrivateEndpoints {
+			handler = api.isAuthorisedForDatasets(readPermission, handler)
+		}
+		api.Router.HandleFunc(path+latestSubPath, handler).Methods(http.MethodGet)
+	}
+
+	alias("/datasets/{dataset_id}/latest", api.cacheControl.DatasetMaxAge, api.latestDatasetVersion)
+	alias("/datasets/{dataset_id}/editions/latest", api.cacheControl.DatasetMaxAge, api.latestEdition)
+	alias("/datasets/{dataset_id}/editions/{edition}/versions/latest", api.cacheControl.EditionMaxAge, api.latestEditionVersion)
+}
+
+// resolveLatest returns a http.HandlerFunc that resolves a latest alias to the path of the resource it refers to, then
+// serves the request as if that path had been requested. The resolved path is returned in the Content-Location header.
+// The resource an alias refers to changes whenever a new version is published, so public responses are not cached
+// for longer than the resource the alias is resolved from.
+func (api *DatasetAPI) resolveLatest(maxAge time.Duration, resolve latestResolver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		vars := mux.Vars(r)
+		logData := log.Data{"path": r.URL.Path}
+
+		// editions and versions are not expected to be named latest, but if they are the path of their alias resolves
+		// to itself
+		aliases, _ := ctx.Value(latestAliasesKey{}).(int)
+		if aliases >= maxLatestAliases {
+			log.Event(ctx, "resolveLatest: too many latest aliases in path", log.ERROR, logData)
+			handleVersionAPIErr(ctx, errs.ErrVersionNotFound, w, logData)
+			return
+		}
+
+		resolved, err := resolve(r, vars, logData)
+		if err != nil {
+			log.Event(ctx, "resolveLatest: failed to resolve latest alias", log.ERROR, log.Error(err), logData)
+			handleVersionAPIErr(ctx, err, w, logData)
+			return
+		}
+		resolved += vars["path"]
+		logData["resolved_path"] = resolved
+		log.Event(ctx, "resolveLatest: resolved latest alias", log.INFO, logData)
+
+		r = r.WithContext(context.WithValue(ctx, latestAliasesKey{}, aliases+1))
+		u := *r.URL
+		u.Path = resolved
+		u.RawPath = ""
+		r.URL = &u
+		w.Header().Set(contentLocationHeader, resolved)
+
+		api.Router.ServeHTTP(&aliasResponseWriter{ResponseWriter: w, maxAge: maxAge}, r)
+	}
+}
+
+// latestDatasetVersion resolves /datasets/{dataset_id}/latest to the latest version of the dataset
+func (api *DatasetAPI) latestDatasetVersion(r *http.Request, vars map[string]string, logData log.Data) (string, error) {
+	datasetID, edition, version, err := api.latestVersionOfDataset(r, vars["dataset_id"], logData)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("/datasets/%s/editions/%s/versions/%s", datasetID, edition, version), nil
+}
+
+// latestEdition resolves /datasets/{dataset_id}/editions/latest to the edition of the latest version of the dataset
+func (api *DatasetAPI) latestEdition(r *http.Request, vars map[string]string, logData log.Data) (string, error) {
+	datasetID, edition, _, err := api.latestVersionOfDataset(r, vars["dataset_id"], logData)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("/datasets/%s/editions/%s", datasetID, edition), nil
+}
+
+// latestEditionVersion resolves /datasets/{dataset_id}/editions/{edition}/versions/latest to the latest version of
+// the edition. Authenticated callers that can view the unpublished editions of the dataset are resolved to its latest
+// version, whereas other callers are resolved to its latest published version.
+func (api *DatasetAPI) latestEditionVersion(r *http.Request, vars map[string]string, logData log.Data) (string, error) {
+	datasetID := vars["dataset_id"]
+	edition := vars["edition"]
+	logData["dataset_id"] = datasetID
+	logData["edition"] = edition
+
+	authorised, err := api.authenticateForDataset(r, datasetID, logData)
+	if err != nil {
+		return "", err
+	}
+
+	var state string
+	if !authorised {
+		state = models.PublishedState
+	}
+
+	if err = api.dataStore.Backend.CheckDatasetExists(r.Context(), datasetID, state); err != nil {
+		return "", err
+	}
+
+	editionDoc, err := api.dataStore.Backend.GetEdition(r.Context(), datasetID, edition, state)
+	if err != nil {
+		return "", err
+	}
+
+	latest := editionDoc.Current
+	if authorised && editionDoc.Next != nil {
+		latest = editionDoc.Next
+	}
+	if latest == nil || latest.Links == nil || latest.Links.LatestVersion == nil || latest.Links.LatestVersion.ID == "" {
+		return "", errs.ErrVersionNotFound
+	}
+
+	return fmt.Sprintf("/datasets/%s/editions/%s/versions/%s", datasetID, edition, latest.Links.LatestVersion.ID), nil
+}
+
+// latestVersionOfDataset returns the dataset, edition and version the latest version link of the dataset refers to.
+// Callers that can access the collection of the unpublished changes to the dataset are resolved to its latest version,
+// whereas other callers are resolved to its latest published version.
+func (api *DatasetAPI) latestVersionOfDataset(r *http.Request, datasetID string, logData log.Data) (string, string, string, error) {
+	logData["dataset_id"] = datasetID
+
+	dataset, err := api.dataStore.Backend.GetDataset(r.Context(), datasetID)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	latest := dataset.Current
+	if api.canAccessCollection(r, datasetID, nextCollectionID(dataset), logData) && dataset.Next != nil {
+		latest = dataset.Next
+	}
+	if latest == nil {
+		return "", "", "", errs.ErrDatasetNotFound
+	}
+	if latest.Links == nil || latest.Links.LatestVersion == nil {
+		return "", "", "", errs.ErrVersionNotFound
+	}
+
+	return parseVersionPath(latest.Links.LatestVersion.HRef)
+}
+
+// parseVersionPath returns the dataset, edition and version of the link to a version, which is stored as a path but
+// may still be an absolute url on documents written before links were stored as paths
+func parseVersionPath(href string) (string, string, string, error) {
+	u, err := neturl.Parse(href)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) != 6 || segments[0] != "datasets" || segments[2] != "editions" || segments[4] != "versions" {
+		return "", "", "", fmt.Errorf("invalid version link: %s", href)
+	}
+
+	return segments[1], segments[3], segments[5], nil
+}
+
+// aliasResponseWriter caps the max-age of a public response to a latest alias at the max-age of the resource the alias
+// is resolved from
+type aliasResponseWriter struct {
+	http.ResponseWriter
+	maxAge      time.Duration
+	wroteHeader bool
+}
+
+func (a *aliasResponseWriter) WriteHeader(status int) {
+	if !a.wroteHeader {
+		a.wroteHeader = true
+
+		var maxAge int64
+		if _, err := fmt.Sscanf(a.Header().Get(cacheControlHeader), "public, max-age=%d", &maxAge); err == nil && maxAge > int64(a.maxAge/time.Second) {
+			a.Header().Set(cacheControlHeader, fmt.Sprintf("public, max-age=%d", int64(a.maxAge/time.Second)))
+		}
+	}
+	a.ResponseWriter.WriteHeader(status)
+}
+
+func (a *aliasResponseWriter) Write(p []byte) (int, error) {
+	if !a.wroteHeader {
+		a.WriteHeader(http.StatusOK)
+	}
+	return a.ResponseWriter.Write(p)
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	"github.com/globalsign/mgo/bson"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetLatest(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a dataset with a published version and an unpublished version in another edition", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{
+					ID: "cpih01",
+					Current: &models.Dataset{
+						State: models.PublishedState,
+						Links: &models.DatasetLinks{LatestVersion: &models.LinkObject{ID: "3", HRef: "/datasets/cpih01/editions/2017/versions/3"}},
+					},
+					Next: &models.Dataset{
+						State: models.AssociatedState,
+						Links: &models.DatasetLinks{LatestVersion: &models.LinkObject{ID: "1", HRef: "http://localhost:22000/datasets/cpih01/editions/2018/versions/1"}},
+					},
+				}, nil
+			},
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
+				return nil
+			},
+			CheckEditionExistsFunc: func(ctx context.Context, datasetID, edition, state string) error {
+				return nil
+			},
+			GetEditionFunc: func(ctx context.Context, ID, editionID, state string) (*models.EditionUpdate, error) {
+				return &models.EditionUpdate{
+					ID: editionID,
+					Current: &models.Edition{
+						State: models.PublishedState,
+						Links: &models.EditionUpdateLinks{LatestVersion: &models.LinkObject{ID: "3"}},
+					},
+					Next: &models.Edition{
+						State: models.EditionConfirmedState,
+						Links: &models.EditionUpdateLinks{LatestVersion: &models.LinkObject{ID: "4"}},
+					},
+				}, nil
+			},
+			GetVersionFunc: func(ctx context.Context, datasetID, edition string, version int, state string) (*models.Version, error) {
+				return &models.Version{
+					ID:      "789",
+					Edition: edition,
+					Version: version,
+					State:   models.PublishedState,
+					Links:   &models.VersionLinks{Self: &models.LinkObject{}, Version: &models.LinkObject{}},
+				}, nil
+			},
+			GetDimensionsFunc: func(ctx context.Context, datasetID, versionID string) ([]bson.M, error) {
+				return []bson.M{}, nil
+			},
+		}
+
+		Convey("When the latest version of the dataset is requested by a public caller", func() {
+			api := GetWebAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/cpih01/latest", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the latest published version is returned and reported in the Content-Location header", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Location"), ShouldEqual, "/datasets/cpih01/editions/2017/versions/3")
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=60")
+				So(w.Body.String(), ShouldContainSubstring, `"version":3`)
+
+				So(mockedDataStore.GetVersionCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.GetVersionCalls()[0].EditionID, ShouldEqual, "2017")
+				So(mockedDataStore.GetVersionCalls()[0].Version, ShouldEqual, 3)
+				So(mockedDataStore.GetVersionCalls()[0].State, ShouldEqual, models.PublishedState)
+			})
+		})
+
+		Convey("When the dimensions of the latest version of an edition are requested by a public caller", func() {
+			api := GetWebAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/cpih01/editions/2017/versions/latest/dimensions", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the dimensions of the latest published version of the edition are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Location"), ShouldEqual, "/datasets/cpih01/editions/2017/versions/3/dimensions")
+				So(mockedDataStore.GetEditionCalls()[0].State, ShouldEqual, models.PublishedState)
+				So(mockedDataStore.GetVersionCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.GetVersionCalls()[0].Version, ShouldEqual, 3)
+				So(mockedDataStore.GetDimensionsCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the latest version of the latest edition is requested by an authenticated caller", func() {
+			api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/cpih01/editions/latest/versions/latest", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the latest unpublished version of the latest unpublished edition is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Location"), ShouldEqual, "/datasets/cpih01/editions/2018/versions/4")
+				So(w.Header().Get("Cache-Control"), ShouldEqual, privateCacheControl)
+
+				So(mockedDataStore.GetEditionCalls()[0].EditionID, ShouldEqual, "2018")
+				So(mockedDataStore.GetEditionCalls()[0].State, ShouldBeEmpty)
+				So(mockedDataStore.GetVersionCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.GetVersionCalls()[0].EditionID, ShouldEqual, "2018")
+				So(mockedDataStore.GetVersionCalls()[0].Version, ShouldEqual, 4)
+			})
+		})
+	})
+
+	Convey("Given a dataset that has not been published", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{
+					ID:   "cpih01",
+					Next: &models.Dataset{State: models.CreatedState, Links: &models.DatasetLinks{}},
+				}, nil
+			},
+		}
+		api := GetWebAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When its latest version is requested by a public caller", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/cpih01/latest/metadata", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the dataset is not found", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrDatasetNotFound.Error())
+				So(w.Header().Get("Content-Location"), ShouldBeEmpty)
+				So(mockedDataStore.GetVersionCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestParseVersionPath(t *testing.T) {
+	Convey("The dataset, edition and version of a version link are returned whether it is a path or a url", t, func() {
+		for _, href := range []string{"/datasets/cpih01/editions/time-series/versions/2", "http://localhost:22000/datasets/cpih01/editions/time-series/versions/2"} {
+			datasetID, edition, version, err := parseVersionPath(href)
+			So(err, ShouldBeNil)
+			So(datasetID, ShouldEqual, "cpih01")
+			So(edition, ShouldEqual, "time-series")
+			So(version, ShouldEqual, "2")
+		}
+
+		_, _, _, err := parseVersionPath("/datasets/cpih01/editions/time-series")
+		So(err, ShouldNotBeNil)
+	})
+}
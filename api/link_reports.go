@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/utils"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+)
+
+// getLinkReports returns a page of the link reports of the datasets with broken links, only listing the broken links
+// of each, along with the total count of those datasets and an error
+func (api *DatasetAPI) getLinkReports(w http.ResponseWriter, r *http.Request, limit, offset int) (interface{}, int, error) {
+	ctx := r.Context()
+	logData := log.Data{"func": "getLinkReports"}
+
+	reports, totalCount, err := api.dataStore.Backend.GetLinkReports(ctx, offset, limit)
+	if err != nil {
+		log.Event(ctx, "failed to get link reports", log.ERROR, log.Error(err), logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return nil, 0, err
+	}
+
+	items := []*models.LinkReport{}
+	for _, report := range reports {
+		items = append(items, report.WithBrokenLinksOnly())
+	}
+
+	log.Event(ctx, "get link reports", log.INFO, logData)
+	return items, totalCount, nil
+}
+
+// getLinkReport returns the status of every external link in the metadata of a published dataset
+func (api *DatasetAPI) getLinkReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	datasetID := vars["dataset_id"]
+	logData := log.Data{"dataset_id": datasetID, "func": "getLinkReport"}
+
+	b, err := func() ([]byte, error) {
+		report, err := api.dataStore.Backend.GetLinkReport(ctx, datasetID)
+		if err != nil {
+			log.Event(ctx, "failed to get link report", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+
+		b, err := json.Marshal(report)
+		if err != nil {
+			log.Event(ctx, "failed to marshal link report into bytes", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+		return b, nil
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	setJSONContentType(w)
+	if _, err = w.Write(b); err != nil {
+		log.Event(ctx, "error writing bytes to response", log.ERROR, log.Error(err), logData)
+		utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
+	}
+	log.Event(ctx, "get link report", log.INFO, logData)
+}
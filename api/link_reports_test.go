@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetLinkReports(t *testing.T) {
+	t.Parallel()
+	Convey("Given the link report of a dataset with a broken link", t, func() {
+		report := models.NewLinkReport("cpih01", []*models.LinkStatus{
+			{Field: "qmi", HRef: "https://www.ons.gov.uk/qmi", StatusCode: http.StatusOK},
+			{Field: "methodologies[0]", HRef: "https://www.ons.gov.uk/gone", StatusCode: http.StatusNotFound, Broken: true},
+		}, time.Now())
+
+		mockedDataStore := &storetest.StorerMock{
+			GetLinkReportsFunc: func(ctx context.Context, offset, limit int) ([]*models.LinkReport, int, error) {
+				return []*models.LinkReport{report}, 1, nil
+			},
+			GetLinkReportFunc: func(ctx context.Context, datasetID string) (*models.LinkReport, error) {
+				if datasetID == "cpih01" {
+					return report, nil
+				}
+				return nil, errs.ErrLinkReportNotFound
+			},
+		}
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), permissions)
+
+		Convey("When the link reports are requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/link-reports?limit=10&offset=5", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then only the broken links are returned, grouped by dataset", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(permissions.Required.Calls, ShouldEqual, 1)
+				So(mockedDataStore.GetLinkReportsCalls()[0].Offset, ShouldEqual, 5)
+				So(mockedDataStore.GetLinkReportsCalls()[0].Limit, ShouldEqual, 10)
+
+				var page struct {
+					Items      []*models.LinkReport `json:"items"`
+					TotalCount int                  `json:"total_count"`
+				}
+				So(json.Unmarshal(w.Body.Bytes(), &page), ShouldBeNil)
+				So(page.TotalCount, ShouldEqual, 1)
+				So(page.Items, ShouldHaveLength, 1)
+				So(page.Items[0].DatasetID, ShouldEqual, "cpih01")
+				So(page.Items[0].BrokenLinks, ShouldEqual, 1)
+				So(page.Items[0].Links, ShouldHaveLength, 1)
+				So(page.Items[0].Links[0].Field, ShouldEqual, "methodologies[0]")
+			})
+		})
+
+		Convey("When the link report of the dataset is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/link-reports/cpih01", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the status of every link is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var full models.LinkReport
+				So(json.Unmarshal(w.Body.Bytes(), &full), ShouldBeNil)
+				So(full.Links, ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When the link report of an unchecked dataset is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/link-reports/unknown", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then not found is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrLinkReportNotFound.Error())
+			})
+		})
+	})
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/url"
+)
+
+// baseURL returns the url that links to the api are rendered against in the response to the request
+func (api *DatasetAPI) baseURL(r *http.Request) string {
+	if !api.enableForwardedHostLinks {
+		return url.BaseURL(r, api.host, nil)
+	}
+	return url.BaseURL(r, api.host, api.forwardedHosts)
+}
+
+// relativeLink returns the path of the href if it links to the api, either at its configured url or at the url the
+// request was made to, so that links are stored independently of the url the api is served from
+func (api *DatasetAPI) relativeLink(r *http.Request, href string) string {
+	return url.RelativeLink(href, api.host, api.baseURL(r))
+}
+
+// renderLinks renders the links to the api held by the model, which are stored as paths, against the url the request
+// was made to
+func (api *DatasetAPI) renderLinks(r *http.Request, model interface{}) {
+	models.RenderLinks(model, api.baseURL(r))
+}
+
+// varyByForwardedHost wraps a handler so that its responses are marked as varying by the forwarded host, which the
+// links in them are rendered against, when forwarded host links are enabled
+func (api *DatasetAPI) varyByForwardedHost(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if api.enableForwardedHostLinks {
+			w.Header().Add("Vary", "X-Forwarded-Host, X-Forwarded-Proto")
+		}
+		handler(w, r)
+	}
+}
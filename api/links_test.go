@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRenderLinks(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a published dataset with links to the api stored as paths", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{
+					ID: "123",
+					Current: &models.Dataset{
+						ID:    "123",
+						QMI:   &models.GeneralDetails{HRef: "/qmi"},
+						Links: &models.DatasetLinks{Self: &models.LinkObject{HRef: "/datasets/123"}},
+					},
+				}, nil
+			},
+		}
+		api := GetWebAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+		api.enableForwardedHostLinks = true
+		api.forwardedHosts = []string{"api.example.com"}
+
+		getDataset := func(r *http.Request) (*httptest.ResponseRecorder, *models.Dataset) {
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+			So(w.Code, ShouldEqual, http.StatusOK)
+
+			var dataset models.Dataset
+			So(json.Unmarshal(w.Body.Bytes(), &dataset), ShouldBeNil)
+			return w, &dataset
+		}
+
+		Convey("When the api is requested directly", func() {
+			w, dataset := getDataset(httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil))
+
+			Convey("Then the links are rendered against the configured url", func() {
+				So(dataset.Links.Self.HRef, ShouldEqual, "http://localhost:22000/datasets/123")
+				So(w.Header().Get("Vary"), ShouldEqual, "X-Forwarded-Host, X-Forwarded-Proto")
+			})
+
+			Convey("Then the hrefs outside the links of the dataset are left unchanged", func() {
+				So(dataset.QMI.HRef, ShouldEqual, "/qmi")
+			})
+		})
+
+		Convey("When the api is requested through a proxy for a forwarded host", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+			r.Header.Set("X-Forwarded-Host", "api.example.com")
+			r.Header.Set("X-Forwarded-Proto", "https")
+			_, dataset := getDataset(r)
+
+			Convey("Then the links are rendered against the url the request was made to", func() {
+				So(dataset.Links.Self.HRef, ShouldEqual, "https://api.example.com/datasets/123")
+			})
+		})
+
+		Convey("When the api is requested through a proxy for a host that is not a forwarded host", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+			r.Header.Set("X-Forwarded-Host", "attacker.example.com")
+			_, dataset := getDataset(r)
+
+			Convey("Then the links are rendered against the configured url", func() {
+				So(dataset.Links.Self.HRef, ShouldEqual, "http://localhost:22000/datasets/123")
+			})
+		})
+
+		Convey("When the api is requested through a proxy with forwarded host links disabled", func() {
+			api.enableForwardedHostLinks = false
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123", nil)
+			r.Header.Set("X-Forwarded-Host", "api.example.com")
+			w, dataset := getDataset(r)
+
+			Convey("Then the links are rendered against the configured url", func() {
+				So(dataset.Links.Self.HRef, ShouldEqual, "http://localhost:22000/datasets/123")
+				So(w.Header().Get("Vary"), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -1,11 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/utils"
 	"github.com/ONSdigital/log.go/log"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
@@ -19,6 +22,7 @@ func (api *DatasetAPI) getMetadata(w http.ResponseWriter, r *http.Request) {
 	version := vars["version"]
 	logData := log.Data{"dataset_id": datasetID, "edition": edition, "version": version}
 
+	var lastUpdated time.Time
 	b, err := func() ([]byte, error) {
 
 		versionId, err := models.ValidateVersionNumber(ctx, version)
@@ -27,7 +31,7 @@ func (api *DatasetAPI) getMetadata(w http.ResponseWriter, r *http.Request) {
 			return nil, err
 		}
 
-		versionDoc, err := api.dataStore.Backend.GetVersion(datasetID, edition, versionId, "")
+		versionDoc, err := api.dataStore.Backend.GetVersion(ctx, datasetID, edition, versionId, "")
 		if err != nil {
 			if err == errs.ErrVersionNotFound {
 				log.Event(ctx, "getMetadata endpoint: failed to find version for dataset edition", log.ERROR, log.Error(err), logData)
@@ -37,17 +41,20 @@ func (api *DatasetAPI) getMetadata(w http.ResponseWriter, r *http.Request) {
 			return nil, err
 		}
 
-		datasetDoc, err := api.dataStore.Backend.GetDataset(datasetID)
+		datasetDoc, err := api.dataStore.Backend.GetDataset(ctx, datasetID)
 		if err != nil {
 			log.Event(ctx, "getMetadata endpoint: get datastore.getDataset returned an error", log.ERROR, log.Error(err), logData)
 			return nil, err
 		}
 
 		authorised := api.authenticate(r, logData)
+		if authorised && !models.IsPublished(versionDoc.State) {
+			authorised = api.canAccessCollection(r, datasetID, versionDoc.CollectionID, logData)
+		}
 		state := versionDoc.State
 
 		// if the requested version is not yet published and the user is unauthorised, return a 404
-		if !authorised && versionDoc.State != models.PublishedState {
+		if !authorised && !models.IsPublished(versionDoc.State) {
 			log.Event(ctx, "getMetadata endpoint: unauthorised user requested unpublished version, returning 404", log.ERROR, log.Error(errs.ErrUnauthorised), logData)
 			return nil, errs.ErrUnauthorised
 		}
@@ -64,7 +71,7 @@ func (api *DatasetAPI) getMetadata(w http.ResponseWriter, r *http.Request) {
 			state = datasetDoc.Current.State
 		}
 
-		if err = api.dataStore.Backend.CheckEditionExists(datasetID, edition, ""); err != nil {
+		if err = api.dataStore.Backend.CheckEditionExists(ctx, datasetID, edition, ""); err != nil {
 			log.Event(ctx, "getMetadata endpoint: failed to find edition for dataset", log.ERROR, log.Error(err), logData)
 			return nil, err
 		}
@@ -76,13 +83,22 @@ func (api *DatasetAPI) getMetadata(w http.ResponseWriter, r *http.Request) {
 		}
 
 		var metaDataDoc *models.Metadata
+		var dataset *models.Dataset
 		// combine version and dataset metadata
-		if state != models.PublishedState {
-			metaDataDoc = models.CreateMetaDataDoc(datasetDoc.Next, versionDoc, api.urlBuilder)
+		if !models.IsPublished(state) {
+			dataset = datasetDoc.Next
 		} else {
-			metaDataDoc = models.CreateMetaDataDoc(datasetDoc.Current, versionDoc, api.urlBuilder)
+			dataset = datasetDoc.Current
+		}
+		metaDataDoc = models.CreateMetaDataDoc(dataset, versionDoc, api.urlBuilder)
+
+		// the metadata is modified whenever either of the documents it is built from is modified
+		lastUpdated = versionDoc.LastUpdated
+		if dataset != nil && dataset.LastUpdated.After(lastUpdated) {
+			lastUpdated = dataset.LastUpdated
 		}
 
+		api.renderLinks(r, metaDataDoc)
 		b, err := json.Marshal(metaDataDoc)
 		if err != nil {
 			log.Event(ctx, "getMetadata endpoint: failed to marshal metadata resource into bytes", log.ERROR, log.Error(err), logData)
@@ -93,19 +109,20 @@ func (api *DatasetAPI) getMetadata(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		log.Event(ctx, "received error", log.ERROR, log.Error(err), logData)
-		handleMetadataErr(w, err)
+		handleMetadataErr(ctx, w, err)
 		return
 	}
 
 	setJSONContentType(w)
+	setLastModified(w, lastUpdated)
 	if _, err = w.Write(b); err != nil {
 		log.Event(ctx, "getMetadata endpoint: failed to write bytes to response", log.ERROR, log.Error(err), logData)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
 	}
 	log.Event(ctx, "getMetadata endpoint: get metadata request successful", log.INFO, logData)
 }
 
-func handleMetadataErr(w http.ResponseWriter, err error) {
+func handleMetadataErr(ctx context.Context, w http.ResponseWriter, err error) {
 	var responseStatus int
 
 	switch {
@@ -124,5 +141,5 @@ func handleMetadataErr(w http.ResponseWriter, err error) {
 		responseStatus = http.StatusInternalServerError
 	}
 
-	http.Error(w, err.Error(), responseStatus)
+	utils.WriteError(ctx, w, err, responseStatus)
 }
@@ -9,10 +9,10 @@ import (
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-dataset-api/store"
+	"github.com/ONSdigital/dp-dataset-api/utils"
 	dphttp "github.com/ONSdigital/dp-net/http"
 	"github.com/ONSdigital/log.go/log"
 	"github.com/gorilla/mux"
-	"github.com/pkg/errors"
 )
 
 // PublishCheck Checks if an version has been published
@@ -34,16 +34,16 @@ func (d *PublishCheck) Check(handle func(http.ResponseWriter, *http.Request), ac
 		if err != nil {
 			log.Event(ctx, "failed due to invalid version request", log.ERROR, log.Error(err), data)
 			dphttp.DrainBody(r)
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			utils.WriteError(ctx, w, err, http.StatusBadRequest)
 			return
 		}
 
-		currentVersion, err := d.Datastore.GetVersion(datasetID, edition, versionId, "")
+		currentVersion, err := d.Datastore.GetVersion(ctx, datasetID, edition, versionId, "")
 		if err != nil {
 			if err != errs.ErrVersionNotFound {
 				log.Event(ctx, "errored whilst retrieving version resource", log.ERROR, log.Error(err), data)
 				dphttp.DrainBody(r)
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
 				return
 			}
 			// If document cannot be found do not handle error
@@ -52,6 +52,14 @@ func (d *PublishCheck) Check(handle func(http.ResponseWriter, *http.Request), ac
 		}
 
 		if currentVersion != nil {
+			if currentVersion.State == models.WithdrawnState {
+				err = errs.ErrUpdateWithdrawnVersionForbidden
+				log.Event(ctx, "failed to update version", log.ERROR, log.Error(err), data)
+				dphttp.DrainBody(r)
+				utils.WriteError(ctx, w, err, http.StatusForbidden)
+				return
+			}
+
 			if currentVersion.State == models.PublishedState {
 
 				// We can allow public download links to be modified by the exporter
@@ -66,7 +74,7 @@ func (d *PublishCheck) Check(handle func(http.ResponseWriter, *http.Request), ac
 					if err != nil {
 						log.Event(ctx, "failed to model version resource based on request", log.ERROR, log.Error(err), data)
 						dphttp.DrainBody(r)
-						http.Error(w, err.Error(), http.StatusBadRequest)
+						utils.WriteError(ctx, w, err, http.StatusBadRequest)
 						return
 					}
 
@@ -102,7 +110,7 @@ func (d *PublishCheck) Check(handle func(http.ResponseWriter, *http.Request), ac
 							if err != nil {
 								log.Event(ctx, "failed to marshal new version resource based on request", log.ERROR, log.Error(err), data)
 								dphttp.DrainBody(r)
-								http.Error(w, err.Error(), http.StatusForbidden)
+								utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
 								return
 							}
 
@@ -120,11 +128,11 @@ func (d *PublishCheck) Check(handle func(http.ResponseWriter, *http.Request), ac
 					}
 				}
 
-				err = errors.New("unable to update version as it has been published")
+				err = errs.ErrUpdatePublishedVersionForbidden
 				data["version"] = currentVersion
 				log.Event(ctx, "failed to update version", log.ERROR, log.Error(err), data)
 				dphttp.DrainBody(r)
-				http.Error(w, err.Error(), http.StatusForbidden)
+				utils.WriteError(ctx, w, err, http.StatusForbidden)
 				return
 			}
 		}
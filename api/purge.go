@@ -0,0 +1,181 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// purgeLockTTL is how long the purge lock is held without being renewed, after which it is released, so that deleted
+// datasets are still purged once an instance stops while purging them
+const purgeLockTTL = 5 * time.Minute
+
+// StartPurger periodically purges the datasets that were deleted longer ago than the retention window, until
+// StopPurger is called
+func (api *DatasetAPI) StartPurger(ctx context.Context, interval time.Duration) {
+	ctx, api.cancelPurger = context.WithCancel(ctx)
+	api.purgerDone = make(chan struct{})
+
+	go func() {
+		defer close(api.purgerDone)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				api.purgeDeletedDatasets(ctx)
+			}
+		}
+	}()
+}
+
+// StopPurger stops the purger, waiting for any dataset being purged to be removed
+func (api *DatasetAPI) StopPurger() {
+	if api.cancelPurger == nil {
+		return
+	}
+
+	api.cancelPurger()
+	<-api.purgerDone
+}
+
+// purgeDeletedDatasets permanently removes every dataset deleted before the retention window, along with its editions,
+// instances, dimension options and graph nodes, and then every edition deleted on its own before the retention window.
+// Datasets are only purged by the instance holding the purge lock, which is renewed as each instance is removed. The
+// removal of a dataset with more documents and graph nodes than the delete job threshold is tracked by a delete job.
+func (api *DatasetAPI) purgeDeletedDatasets(ctx context.Context) {
+	before := time.Now().Add(-api.deletedDatasetRetention)
+	logData := log.Data{"deleted_before": before}
+
+	lockID, err := api.dataStore.Backend.LockPurge(ctx, purgeLockTTL)
+	if err != nil {
+		if err == errs.ErrPurgeLocked {
+			log.Event(ctx, "deleted datasets are being purged by another instance", log.INFO, logData)
+			return
+		}
+		log.Event(ctx, "failed to lock the purge of deleted datasets", log.ERROR, log.Error(err), logData)
+		return
+	}
+	defer func() {
+		if err := api.dataStore.Backend.UnlockPurge(ctx, lockID); err != nil {
+			log.Event(ctx, "failed to unlock the purge of deleted datasets", log.ERROR, log.Error(err), logData)
+		}
+	}()
+
+	renewLock := func() {
+		if err := api.dataStore.Backend.RenewPurgeLock(ctx, lockID, purgeLockTTL); err != nil {
+			log.Event(ctx, "failed to renew the purge lock", log.WARN, log.Error(err), logData)
+		}
+	}
+
+	datasetIDs, err := api.dataStore.Backend.GetDatasetsDeletedBefore(ctx, before)
+	if err != nil {
+		log.Event(ctx, "failed to find deleted datasets to purge", log.ERROR, log.Error(err), logData)
+		return
+	}
+
+	for _, datasetID := range datasetIDs {
+		if ctx.Err() != nil {
+			return
+		}
+		logData["dataset_id"] = datasetID
+
+		deletion, err := api.planDatasetDeletion(ctx, datasetID, logData)
+		if err != nil {
+			log.Event(ctx, "failed to plan the purge of deleted dataset", log.ERROR, log.Error(err), logData)
+			continue
+		}
+
+		if deletion.counts.Total() <= api.deleteJobThreshold {
+			if err := api.executeDatasetDeletion(ctx, deletion, &models.DeleteCounts{}, renewLock); err != nil {
+				log.Event(ctx, "failed to purge deleted dataset", log.ERROR, log.Error(err), logData)
+				continue
+			}
+			log.Event(ctx, "deleted dataset purged", log.INFO, logData)
+			continue
+		}
+
+		job := models.NewDeleteJob(datasetID, deletion.counts)
+		if err := api.dataStore.Backend.AddDeleteJob(ctx, job); err != nil {
+			log.Event(ctx, "failed to add delete job", log.ERROR, log.Error(err), logData)
+			continue
+		}
+
+		api.runDeleteJob(ctx, job, deletion, renewLock)
+	}
+
+	api.purgeDeletedEditions(ctx, before, renewLock)
+}
+
+// purgeDeletedEditions permanently removes every edition deleted on its own before the retention window, along with
+// the instances, dimension options and graph nodes deleted with it. Editions deleted with their dataset are removed
+// when the dataset is purged.
+func (api *DatasetAPI) purgeDeletedEditions(ctx context.Context, before time.Time, renewLock func()) {
+	logData := log.Data{"deleted_before": before}
+
+	editions, err := api.dataStore.Backend.GetEditionsDeletedBefore(ctx, before)
+	if err != nil {
+		log.Event(ctx, "failed to find deleted editions to purge", log.ERROR, log.Error(err), logData)
+		return
+	}
+
+	for _, editionDoc := range editions {
+		if ctx.Err() != nil {
+			return
+		}
+		if editionDoc.Next == nil || editionDoc.Next.Links == nil || editionDoc.Next.Links.Dataset == nil {
+			continue
+		}
+		datasetID := editionDoc.Next.Links.Dataset.ID
+		logData["dataset_id"] = datasetID
+		logData["edition"] = editionDoc.Next.Edition
+
+		if _, err := api.dataStore.Backend.GetDeletedDataset(ctx, datasetID); err == nil {
+			continue
+		} else if err != errs.ErrDatasetNotFound {
+			log.Event(ctx, "failed to check whether the dataset of deleted edition was deleted", log.ERROR, log.Error(err), logData)
+			continue
+		}
+
+		if err := api.purgeDeletedEdition(ctx, datasetID, editionDoc, renewLock, logData); err != nil {
+			log.Event(ctx, "failed to purge deleted edition", log.ERROR, log.Error(err), logData)
+			continue
+		}
+		log.Event(ctx, "deleted edition purged", log.INFO, logData)
+	}
+}
+
+// purgeDeletedEdition removes the deleted instances of the edition, and then the edition itself, so a purge that fails
+// part way through is completed by the next purge
+func (api *DatasetAPI) purgeDeletedEdition(ctx context.Context, datasetID string, editionDoc *models.EditionUpdate, renewLock func(), logData log.Data) error {
+	instances, err := api.dataStore.Backend.GetDatasetInstances(ctx, datasetID)
+	if err != nil {
+		log.Event(ctx, "unable to find the dataset instances", log.ERROR, log.Error(err), logData)
+		return err
+	}
+
+	deleted := &models.DeleteCounts{}
+	for _, instance := range instances {
+		if instance.Edition != editionDoc.Next.Edition || instance.State != models.DeletedState {
+			continue
+		}
+		if err := api.deleteInstance(ctx, instance.InstanceID, deleted, logData); err != nil {
+			return err
+		}
+		renewLock()
+	}
+	delete(logData, "instance_id")
+
+	if err := api.dataStore.Backend.DeleteEdition(ctx, editionDoc.ID); err != nil {
+		log.Event(ctx, "failed to delete edition", log.ERROR, log.Error(err), logData)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/utils"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+)
+
+// getRelatedDatasets returns the datasets the dataset is related to, with their current titles, and the datasets
+// related to it. Access is checked against the collection of each dataset, so callers without access to the
+// unpublished changes of a dataset only see its published relations.
+func (api *DatasetAPI) getRelatedDatasets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	datasetID := vars["dataset_id"]
+	logData := log.Data{"dataset_id": datasetID, "func": "getRelatedDatasets"}
+
+	b, err := func() ([]byte, error) {
+		dataset, err := api.dataStore.Backend.GetDataset(ctx, datasetID)
+		if err != nil {
+			log.Event(ctx, "failed to get dataset", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+
+		authorised := api.canAccessCollection(r, datasetID, nextCollectionID(dataset), logData)
+
+		doc := viewableDataset(dataset, authorised)
+		if doc == nil {
+			log.Event(ctx, "published dataset not found", log.INFO, logData)
+			return nil, errs.ErrDatasetNotFound
+		}
+
+		relations := &models.DatasetRelations{
+			Related:   []models.GeneralDetails{},
+			RelatedBy: []models.GeneralDetails{},
+		}
+
+		for _, related := range doc.RelatedDatasets {
+			if related.ID == "" {
+				relations.Related = append(relations.Related, related)
+				continue
+			}
+
+			target, err := api.dataStore.Backend.GetDataset(ctx, related.ID)
+			if err == errs.ErrDatasetNotFound {
+				relations.Dangling = append(relations.Dangling, related)
+				continue
+			}
+			if err != nil {
+				logData["related_dataset_id"] = related.ID
+				log.Event(ctx, "failed to get related dataset", log.ERROR, log.Error(err), logData)
+				return nil, err
+			}
+
+			targetDoc := viewableDataset(target, api.canAccessCollection(r, target.ID, nextCollectionID(target), logData))
+			if targetDoc == nil {
+				continue
+			}
+
+			// the title is read from the related dataset, as it may have been renamed since the link was added
+			if targetDoc.Title != "" {
+				related.Title = targetDoc.Title
+			}
+			relations.Related = append(relations.Related, related)
+		}
+
+		relating, err := api.dataStore.Backend.GetDatasetsRelatedTo(ctx, datasetID)
+		if err != nil {
+			log.Event(ctx, "failed to get the datasets related to the dataset", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+
+		for _, other := range relating {
+			otherDoc := viewableDataset(other, api.canAccessCollection(r, other.ID, nextCollectionID(other), logData))
+			if otherDoc == nil || !otherDoc.IsRelatedTo(datasetID) {
+				continue
+			}
+
+			relations.RelatedBy = append(relations.RelatedBy, models.GeneralDetails{
+				Description: otherDoc.Description,
+				HRef:        fmt.Sprintf("/datasets/%s", other.ID),
+				ID:          other.ID,
+				Title:       otherDoc.Title,
+			})
+		}
+
+		logData["related"] = len(relations.Related)
+		logData["related_by"] = len(relations.RelatedBy)
+		logData["dangling"] = len(relations.Dangling)
+
+		api.renderLinks(r, relations)
+		b, err := json.Marshal(relations)
+		if err != nil {
+			log.Event(ctx, "failed to marshal related datasets into bytes", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+		return b, nil
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	setJSONContentType(w)
+	if _, err = w.Write(b); err != nil {
+		log.Event(ctx, "error writing bytes to response", log.ERROR, log.Error(err), logData)
+		utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
+	}
+	log.Event(ctx, "get related datasets", log.INFO, logData)
+}
+
+// viewableDataset returns the unpublished dataset to authorised callers and the published dataset to everyone else,
+// which is nil if the dataset has never been published
+func viewableDataset(dataset *models.DatasetUpdate, authorised bool) *models.Dataset {
+	if authorised {
+		return dataset.Next
+	}
+	return dataset.Current
+}
+
+// resolveRelatedDatasets types the related datasets held by this API with the ID of the dataset they point at,
+// checking that each is an existing dataset other than the dataset itself. Related datasets outside this API are
+// left unchanged.
+func (api *DatasetAPI) resolveRelatedDatasets(r *http.Request, datasetID string, dataset *models.Dataset, logData log.Data) error {
+	ctx := r.Context()
+	for i, related := range dataset.RelatedDatasets {
+		related.HRef = api.relativeLink(r, related.HRef)
+		relatedID := models.RelatedDatasetID(api.host, related)
+		if relatedID == "" {
+			continue
+		}
+
+		logData["related_dataset_id"] = relatedID
+		if relatedID == datasetID {
+			log.Event(ctx, "a dataset cannot be related to itself", log.ERROR, log.Error(errs.ErrRelatedDatasetInvalid), logData)
+			return errs.ErrRelatedDatasetInvalid
+		}
+
+		target, err := api.dataStore.Backend.GetDataset(ctx, relatedID)
+		if err != nil {
+			if err == errs.ErrDatasetNotFound {
+				log.Event(ctx, "related dataset not found", log.ERROR, log.Error(errs.ErrRelatedDatasetInvalid), logData)
+				return errs.ErrRelatedDatasetInvalid
+			}
+			log.Event(ctx, "failed to get related dataset", log.ERROR, log.Error(err), logData)
+			return err
+		}
+
+		dataset.RelatedDatasets[i].ID = relatedID
+		dataset.RelatedDatasets[i].HRef = fmt.Sprintf("/datasets/%s", relatedID)
+		if related.Title == "" && target.Next != nil {
+			dataset.RelatedDatasets[i].Title = target.Next.Title
+		}
+	}
+	delete(logData, "related_dataset_id")
+
+	return nil
+}
+
+// getDanglingReferences returns links to the datasets that would be left with a dangling related dataset reference
+// if the dataset were removed
+func (api *DatasetAPI) getDanglingReferences(r *http.Request, datasetID string, logData log.Data) ([]*models.LinkObject, error) {
+	ctx := r.Context()
+	relating, err := api.dataStore.Backend.GetDatasetsRelatedTo(ctx, datasetID)
+	if err != nil {
+		log.Event(ctx, "failed to get the datasets related to the dataset", log.ERROR, log.Error(err), logData)
+		return nil, err
+	}
+
+	var references []*models.LinkObject
+	for _, other := range relating {
+		references = append(references, &models.LinkObject{
+			HRef: fmt.Sprintf("%s/datasets/%s", api.baseURL(r), other.ID),
+			ID:   other.ID,
+		})
+	}
+	return references, nil
+}
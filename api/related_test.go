@@ -0,0 +1,343 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/collection"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func relatedIDs(related []models.GeneralDetails) []string {
+	ids := []string{}
+	for _, item := range related {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func TestGetRelatedDatasets(t *testing.T) {
+	t.Parallel()
+	Convey("Given a dataset related to other datasets", t, func() {
+		// cpih01 is related to mm23, which has been renamed, to the unpublished rpi, to the deleted old01 and to an
+		// external link. The published mm22 is related to cpih01, and the unpublished cpi only in its unpublished changes.
+		datasets := map[string]*models.DatasetUpdate{
+			"cpih01": {
+				ID: "cpih01",
+				Current: &models.Dataset{
+					Title: "CPIH",
+					RelatedDatasets: []models.GeneralDetails{
+						{HRef: "http://localhost:22000/datasets/mm23", ID: "mm23", Title: "Consumer price inflation"},
+						{HRef: "http://localhost:22000/datasets/rpi", ID: "rpi", Title: "RPI"},
+						{HRef: "http://localhost:22000/datasets/old01", ID: "old01", Title: "Old"},
+						{HRef: "https://www.ons.gov.uk/economy", Title: "Economy"},
+					},
+				},
+				Next: &models.Dataset{
+					Title: "CPIH",
+					State: models.PublishedState,
+					RelatedDatasets: []models.GeneralDetails{
+						{HRef: "http://localhost:22000/datasets/mm23", ID: "mm23", Title: "Consumer price inflation"},
+						{HRef: "http://localhost:22000/datasets/rpi", ID: "rpi", Title: "RPI"},
+						{HRef: "http://localhost:22000/datasets/old01", ID: "old01", Title: "Old"},
+						{HRef: "https://www.ons.gov.uk/economy", Title: "Economy"},
+					},
+				},
+			},
+			"mm23": {
+				ID:      "mm23",
+				Current: &models.Dataset{Title: "Consumer price inflation detailed reference tables"},
+				Next:    &models.Dataset{Title: "Consumer price inflation detailed reference tables", State: models.PublishedState},
+			},
+			"rpi": {
+				ID:   "rpi",
+				Next: &models.Dataset{Title: "Retail prices index", State: models.CreatedState},
+			},
+		}
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+				if dataset, ok := datasets[id]; ok {
+					return dataset, nil
+				}
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDatasetsRelatedToFunc: func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+				return []*models.DatasetUpdate{
+					{
+						ID: "cpi",
+						Next: &models.Dataset{
+							Title:           "CPI",
+							State:           models.CreatedState,
+							RelatedDatasets: []models.GeneralDetails{{ID: "cpih01"}},
+						},
+					},
+					{
+						ID: "mm22",
+						Current: &models.Dataset{
+							Title:           "Producer price inflation",
+							RelatedDatasets: []models.GeneralDetails{{ID: "cpih01"}},
+						},
+						Next: &models.Dataset{
+							Title:           "Producer price inflation",
+							State:           models.PublishedState,
+							RelatedDatasets: []models.GeneralDetails{{ID: "cpih01"}},
+						},
+					},
+				}, nil
+			},
+		}
+		datasetPermissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, getAuthorisationHandlerMock())
+
+		Convey("When an authorised caller requests its related datasets", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/datasets/cpih01/related", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(datasetPermissions.Required.Calls, ShouldEqual, 1)
+
+			var relations models.DatasetRelations
+			So(json.Unmarshal(w.Body.Bytes(), &relations), ShouldBeNil)
+
+			Convey("Then the datasets it is related to are returned with their current titles", func() {
+				So(relatedIDs(relations.Related), ShouldResemble, []string{"mm23", "rpi", ""})
+				So(relations.Related[0].Title, ShouldEqual, "Consumer price inflation detailed reference tables")
+				So(relations.Related[2].HRef, ShouldEqual, "https://www.ons.gov.uk/economy")
+			})
+
+			Convey("Then the datasets related to it are returned", func() {
+				So(relatedIDs(relations.RelatedBy), ShouldResemble, []string{"cpi", "mm22"})
+				So(relations.RelatedBy[1].HRef, ShouldEqual, "http://localhost:22000/datasets/mm22")
+				So(relations.RelatedBy[1].Title, ShouldEqual, "Producer price inflation")
+			})
+
+			Convey("Then the related datasets that no longer exist are reported as dangling", func() {
+				So(relatedIDs(relations.Dangling), ShouldResemble, []string{"old01"})
+			})
+		})
+
+		Convey("When an unauthorised caller requests its related datasets", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/cpih01/related", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+
+			var relations models.DatasetRelations
+			So(json.Unmarshal(w.Body.Bytes(), &relations), ShouldBeNil)
+
+			Convey("Then only the published relations are returned", func() {
+				So(relatedIDs(relations.Related), ShouldResemble, []string{"mm23", ""})
+				So(relatedIDs(relations.RelatedBy), ShouldResemble, []string{"mm22"})
+			})
+		})
+
+		Convey("When an unauthorised caller requests the related datasets of an unpublished dataset", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/datasets/rpi/related", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then not found is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(mockedDataStore.GetDatasetsRelatedToCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestGetRelatedDatasetsCollectionVisibility(t *testing.T) {
+	t.Parallel()
+	Convey("Given related datasets with unpublished changes in different collections", t, func() {
+		datasets := map[string]*models.DatasetUpdate{
+			"cpih01": {
+				ID: "cpih01",
+				Next: &models.Dataset{
+					Title:           "CPIH",
+					State:           models.AssociatedState,
+					CollectionID:    testCollectionID,
+					RelatedDatasets: []models.GeneralDetails{{ID: "rpi"}},
+				},
+			},
+			"rpi": {
+				ID:   "rpi",
+				Next: &models.Dataset{Title: "Retail prices index", State: models.AssociatedState, CollectionID: "other-collection"},
+			},
+		}
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+				return datasets[id], nil
+			},
+			GetDatasetsRelatedToFunc: func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+				return []*models.DatasetUpdate{
+					{
+						ID: "cpi",
+						Next: &models.Dataset{
+							Title:           "CPI",
+							State:           models.AssociatedState,
+							CollectionID:    "other-collection",
+							RelatedDatasets: []models.GeneralDetails{{ID: "cpih01"}},
+						},
+					},
+				}, nil
+			},
+		}
+		checker := collection.NewLocalChecker()
+		checker.Grant(testUserAccessToken, testCollectionID)
+		api := getAPIWithCollectionPermissions(mockedDataStore, checker)
+
+		Convey("When a user with access to the collection of the dataset only requests its related datasets", func() {
+			r := createRequestWithUserAuth("GET", "http://localhost:22000/datasets/cpih01/related", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the unpublished datasets of the other collection are not returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var relations models.DatasetRelations
+				So(json.Unmarshal(w.Body.Bytes(), &relations), ShouldBeNil)
+				So(relations.Related, ShouldBeEmpty)
+				So(relations.RelatedBy, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a user with access to both collections requests its related datasets", func() {
+			checker.Grant(testUserAccessToken, "other-collection")
+			r := createRequestWithUserAuth("GET", "http://localhost:22000/datasets/cpih01/related", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the unpublished datasets of both collections are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var relations models.DatasetRelations
+				So(json.Unmarshal(w.Body.Bytes(), &relations), ShouldBeNil)
+				So(relatedIDs(relations.Related), ShouldResemble, []string{"rpi"})
+				So(relatedIDs(relations.RelatedBy), ShouldResemble, []string{"cpi"})
+			})
+		})
+	})
+}
+
+func TestAddDatasetWithRelatedDatasets(t *testing.T) {
+	t.Parallel()
+	Convey("Given existing datasets", t, func() {
+		datasets := map[string]*models.DatasetUpdate{
+			"mm23": {
+				ID:      "mm23",
+				Current: &models.Dataset{Title: "Consumer price inflation detailed reference tables"},
+				Next:    &models.Dataset{Title: "Consumer price inflation detailed reference tables", State: models.PublishedState},
+			},
+			"rpi": {
+				ID:   "rpi",
+				Next: &models.Dataset{Title: "Retail prices index", State: models.CreatedState},
+			},
+		}
+		var storedRelated []models.GeneralDetails
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+				if dataset, ok := datasets[id]; ok {
+					return dataset, nil
+				}
+				return nil, errs.ErrDatasetNotFound
+			},
+			GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+				return nil, errs.ErrDatasetNotFound
+			},
+			UpsertDatasetFunc: func(ctx context.Context, id string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
+				// the related datasets are recorded as they are stored, before they are rendered in the response
+				storedRelated = append(storedRelated, datasetDoc.Next.RelatedDatasets...)
+				return nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When a dataset is created with related datasets of this api and external related datasets", func() {
+			b := `{"title":"CPI","related_datasets":[{"href":"http://localhost:22000/datasets/mm23"},{"id":"rpi","title":"Retail prices"},{"href":"https://www.ons.gov.uk/economy","title":"Economy"}]}`
+			r := createRequestWithAuth("POST", "http://localhost:22000/datasets/cpi", bytes.NewBufferString(b))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the related datasets of this api are stored as typed references", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 1)
+
+				So(storedRelated, ShouldResemble, []models.GeneralDetails{
+					{HRef: "/datasets/mm23", ID: "mm23", Title: "Consumer price inflation detailed reference tables"},
+					{HRef: "/datasets/rpi", ID: "rpi", Title: "Retail prices"},
+					{HRef: "https://www.ons.gov.uk/economy", Title: "Economy"},
+				})
+			})
+		})
+
+		Convey("When a dataset is created with a related dataset that does not exist", func() {
+			b := `{"title":"CPI","related_datasets":[{"href":"/datasets/unknown"}]}`
+			r := createRequestWithAuth("POST", "http://localhost:22000/datasets/cpi", bytes.NewBufferString(b))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then bad request is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrRelatedDatasetInvalid.Error())
+				So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a dataset is created related to itself", func() {
+			b := `{"title":"CPI","related_datasets":[{"href":"http://localhost:22000/datasets/cpi"}]}`
+			r := createRequestWithAuth("POST", "http://localhost:22000/datasets/cpi", bytes.NewBufferString(b))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then bad request is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(mockedDataStore.UpsertDatasetCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestDeleteRelatedDatasetDryRun(t *testing.T) {
+	t.Parallel()
+	Convey("Given a dataset that other datasets are related to", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: "123", Next: &models.Dataset{State: models.CreatedState}}, nil
+			},
+			GetDatasetEditionsFunc: func(ctx context.Context, datasetID string) ([]*models.EditionUpdate, error) {
+				return []*models.EditionUpdate{}, nil
+			},
+			GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+				return []*models.Instance{}, nil
+			},
+			GetDatasetsRelatedToFunc: func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+				return []*models.DatasetUpdate{{ID: "mm22"}}, nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When the dataset is deleted as a dry run", func() {
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123?dry_run=true", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the datasets that would be left with a dangling reference are reported", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var dryRun models.DeleteDryRun
+				So(json.Unmarshal(w.Body.Bytes(), &dryRun), ShouldBeNil)
+				So(dryRun.DanglingReferences, ShouldResemble, []*models.LinkObject{
+					{HRef: "http://localhost:22000/datasets/mm22", ID: "mm22"},
+				})
+			})
+		})
+	})
+}
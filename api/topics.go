@@ -0,0 +1,348 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/utils"
+	dphttp "github.com/ONSdigital/dp-net/http"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+)
+
+// getTopics returns a page of the topics of the taxonomy, the total count of topics and an error
+func (api *DatasetAPI) getTopics(w http.ResponseWriter, r *http.Request, limit, offset int) (interface{}, int, error) {
+	ctx := r.Context()
+	logData := log.Data{"func": "getTopics"}
+
+	topics, totalCount, err := api.dataStore.Backend.GetTopicsPage(ctx, offset, limit)
+	if err != nil {
+		log.Event(ctx, "failed to get topics", log.ERROR, log.Error(err), logData)
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return nil, 0, err
+	}
+
+	log.Event(ctx, "get topics", log.INFO, logData)
+	return topics, totalCount, nil
+}
+
+func (api *DatasetAPI) getTopic(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	topicID := vars["topic_id"]
+	logData := log.Data{"topic_id": topicID, "func": "getTopic"}
+
+	b, err := func() ([]byte, error) {
+		topic, err := api.dataStore.Backend.GetTopic(ctx, topicID)
+		if err != nil {
+			log.Event(ctx, "failed to get topic", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+
+		api.renderLinks(r, topic)
+		b, err := json.Marshal(topic)
+		if err != nil {
+			log.Event(ctx, "failed to marshal topic into bytes", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+		return b, nil
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	setJSONContentType(w)
+	if _, err = w.Write(b); err != nil {
+		log.Event(ctx, "error writing bytes to response", log.ERROR, log.Error(err), logData)
+		utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
+	}
+	log.Event(ctx, "get topic", log.INFO, logData)
+}
+
+// getTopicDatasets returns a page of the published datasets of a topic, the total count of those datasets and an
+// error. The datasets of the topics below it in the taxonomy are included when include_descendants is true.
+func (api *DatasetAPI) getTopicDatasets(w http.ResponseWriter, r *http.Request, limit, offset int) (interface{}, int, error) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	topicID := vars["topic_id"]
+	logData := log.Data{"topic_id": topicID, "func": "getTopicDatasets"}
+
+	datasets, totalCount, err := func() ([]*models.DatasetUpdate, int, error) {
+		includeDescendants, err := getIncludeDescendants(r)
+		if err != nil {
+			log.Event(ctx, "invalid include_descendants query parameter", log.ERROR, log.Error(err), logData)
+			return nil, 0, err
+		}
+		logData["include_descendants"] = includeDescendants
+
+		if _, err := api.dataStore.Backend.GetTopic(ctx, topicID); err != nil {
+			log.Event(ctx, "failed to get topic", log.ERROR, log.Error(err), logData)
+			return nil, 0, err
+		}
+
+		topicIDs := []string{topicID}
+		if includeDescendants {
+			topics, err := api.dataStore.Backend.GetTopics(ctx)
+			if err != nil {
+				log.Event(ctx, "failed to get topics", log.ERROR, log.Error(err), logData)
+				return nil, 0, err
+			}
+			topicIDs = models.TopicAndDescendants(topics, topicID)
+			logData["topic_ids"] = topicIDs
+		}
+
+		datasets, totalCount, err := api.dataStore.Backend.GetTopicDatasets(ctx, topicIDs, offset, limit)
+		if err != nil {
+			log.Event(ctx, "failed to get topic datasets", log.ERROR, log.Error(err), logData)
+			return nil, 0, err
+		}
+		return datasets, totalCount, nil
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return nil, 0, err
+	}
+
+	log.Event(ctx, "get topic datasets", log.INFO, logData)
+	return mapResults(datasets), totalCount, nil
+}
+
+func (api *DatasetAPI) addTopic(w http.ResponseWriter, r *http.Request) {
+
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	topicID := vars["topic_id"]
+	logData := log.Data{"topic_id": topicID, "func": "addTopic"}
+
+	b, err := func() ([]byte, error) {
+		_, err := api.dataStore.Backend.GetTopic(ctx, topicID)
+		if err != nil {
+			if err != errs.ErrTopicNotFound {
+				log.Event(ctx, "error checking if topic exists", log.ERROR, log.Error(err), logData)
+				return nil, err
+			}
+		} else {
+			log.Event(ctx, "unable to create a topic that already exists", log.ERROR, log.Error(errs.ErrAddTopicAlreadyExists), logData)
+			return nil, errs.ErrAddTopicAlreadyExists
+		}
+
+		topic, err := api.createTopic(ctx, r, topicID, logData)
+		if err != nil {
+			return nil, err
+		}
+
+		api.renderLinks(r, topic)
+		b, err := json.Marshal(topic)
+		if err != nil {
+			log.Event(ctx, "failed to marshal topic into bytes", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+		return b, nil
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	setJSONContentType(w)
+	w.WriteHeader(http.StatusCreated)
+	if _, err = w.Write(b); err != nil {
+		log.Event(ctx, "error writing bytes to response", log.ERROR, log.Error(err), logData)
+		utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
+	}
+	log.Event(ctx, "add topic", log.INFO, logData)
+}
+
+func (api *DatasetAPI) putTopic(w http.ResponseWriter, r *http.Request) {
+
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	topicID := vars["topic_id"]
+	logData := log.Data{"topic_id": topicID, "func": "putTopic"}
+
+	err := func() error {
+		if _, err := api.dataStore.Backend.GetTopic(ctx, topicID); err != nil {
+			log.Event(ctx, "failed to get topic", log.ERROR, log.Error(err), logData)
+			return err
+		}
+
+		_, err := api.createTopic(ctx, r, topicID, logData)
+		return err
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	setJSONContentType(w)
+	w.WriteHeader(http.StatusOK)
+	log.Event(ctx, "put topic", log.INFO, logData)
+}
+
+func (api *DatasetAPI) deleteTopic(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	topicID := vars["topic_id"]
+	logData := log.Data{"topic_id": topicID, "func": "deleteTopic"}
+
+	err := func() error {
+		if _, err := api.dataStore.Backend.GetTopic(ctx, topicID); err != nil {
+			log.Event(ctx, "failed to get topic", log.ERROR, log.Error(err), logData)
+			return err
+		}
+
+		topics, err := api.dataStore.Backend.GetTopics(ctx)
+		if err != nil {
+			log.Event(ctx, "failed to get topics", log.ERROR, log.Error(err), logData)
+			return err
+		}
+
+		for _, topic := range topics {
+			if topic.ParentID == topicID {
+				logData["sub_topic_id"] = topic.ID
+				log.Event(ctx, "unable to delete a topic with sub topics", log.ERROR, log.Error(errs.ErrDeleteTopicForbidden), logData)
+				return errs.ErrDeleteTopicForbidden
+			}
+		}
+
+		count, err := api.dataStore.Backend.CountTopicDatasets(ctx, topicID)
+		if err != nil {
+			log.Event(ctx, "failed to count topic datasets", log.ERROR, log.Error(err), logData)
+			return err
+		}
+
+		if count > 0 {
+			logData["datasets"] = count
+			log.Event(ctx, "unable to delete a topic with datasets", log.ERROR, log.Error(errs.ErrDeleteTopicForbidden), logData)
+			return errs.ErrDeleteTopicForbidden
+		}
+
+		if err := api.dataStore.Backend.DeleteTopic(ctx, topicID); err != nil {
+			log.Event(ctx, "failed to delete topic", log.ERROR, log.Error(err), logData)
+			return err
+		}
+		return nil
+	}()
+
+	if err != nil {
+		handleDatasetAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Event(ctx, "delete topic", log.INFO, logData)
+}
+
+// createTopic models the topic in the request body, checks where it is placed in the taxonomy and stores it,
+// replacing any existing topic with the same ID
+func (api *DatasetAPI) createTopic(ctx context.Context, r *http.Request, topicID string, logData log.Data) (*models.Topic, error) {
+	topic, err := models.CreateTopic(r.Body)
+	if err != nil {
+		log.Event(ctx, "failed to model topic resource based on request", log.ERROR, log.Error(err), logData)
+		return nil, err
+	}
+	topic.ID = topicID
+
+	if err = models.ValidateTopic(topic); err != nil {
+		log.Event(ctx, "topic failed validation checks", log.ERROR, log.Error(err), logData)
+		return nil, err
+	}
+
+	if topic.ParentID != "" {
+		logData["parent_id"] = topic.ParentID
+		topics, err := api.dataStore.Backend.GetTopics(ctx)
+		if err != nil {
+			log.Event(ctx, "failed to get topics", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+
+		if !validParent(topics, topicID, topic.ParentID) {
+			log.Event(ctx, "invalid parent topic", log.ERROR, log.Error(errs.ErrTopicParentInvalid), logData)
+			return nil, errs.ErrTopicParentInvalid
+		}
+	}
+
+	topic.SetLinks()
+
+	if err = api.dataStore.Backend.UpsertTopic(ctx, topic); err != nil {
+		log.Event(ctx, "failed to store topic", log.ERROR, log.Error(err), logData)
+		return nil, err
+	}
+	return topic, nil
+}
+
+// validParent checks the parent is an existing topic that is not the topic itself or below it in the taxonomy,
+// so that the taxonomy remains a tree
+func validParent(topics []*models.Topic, topicID, parentID string) bool {
+	for _, id := range models.TopicAndDescendants(topics, topicID) {
+		if id == parentID {
+			return false
+		}
+	}
+
+	for _, topic := range topics {
+		if topic.ID == parentID {
+			return true
+		}
+	}
+	return false
+}
+
+// setTaxonomy checks the theme of the dataset is an existing topic and links the dataset to it. The taxonomy link is
+// only ever set from the theme, so a link provided without a theme is discarded.
+func (api *DatasetAPI) setTaxonomy(ctx context.Context, dataset *models.Dataset, logData log.Data) error {
+	if dataset.Theme == "" {
+		if dataset.Links != nil {
+			dataset.Links.Taxonomy = nil
+		}
+		return nil
+	}
+
+	if _, err := api.dataStore.Backend.GetTopic(ctx, dataset.Theme); err != nil {
+		logData["theme"] = dataset.Theme
+		if err == errs.ErrTopicNotFound {
+			log.Event(ctx, "the dataset theme is not an existing topic", log.ERROR, log.Error(errs.ErrDatasetThemeInvalid), logData)
+			return errs.ErrDatasetThemeInvalid
+		}
+		log.Event(ctx, "failed to get the dataset theme", log.ERROR, log.Error(err), logData)
+		return err
+	}
+
+	if dataset.Links == nil {
+		dataset.Links = &models.DatasetLinks{}
+	}
+
+	dataset.Links.Taxonomy = &models.LinkObject{
+		HRef: fmt.Sprintf("/topics/%s", dataset.Theme),
+		ID:   dataset.Theme,
+	}
+	return nil
+}
+
+// getIncludeDescendants returns the value of the optional include_descendants query parameter
+func getIncludeDescendants(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("include_descendants")
+	if value == "" {
+		return false, nil
+	}
+
+	includeDescendants, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errs.ErrInvalidQueryParameter
+	}
+	return includeDescendants, nil
+}
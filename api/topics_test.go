@@ -0,0 +1,454 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetTopics(t *testing.T) {
+	t.Parallel()
+	Convey("Given a taxonomy of topics", t, func() {
+		topics := []*models.Topic{
+			{ID: "economy", Label: "Economy"},
+			{ID: "inflation", Label: "Inflation", ParentID: "economy"},
+			{ID: "population", Label: "Population"},
+			{ID: "prices", Label: "Prices", ParentID: "inflation"},
+		}
+		mockedDataStore := &storetest.StorerMock{
+			GetTopicsPageFunc: func(ctx context.Context, offset, limit int) ([]*models.Topic, int, error) {
+				return topics[offset : offset+limit], len(topics), nil
+			},
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				for _, topic := range topics {
+					if topic.ID == id {
+						return topic, nil
+					}
+				}
+				return nil, errs.ErrTopicNotFound
+			},
+		}
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), permissions)
+
+		Convey("When a page of topics is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/topics?offset=1&limit=2", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the topics of the page are returned along with the total count", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(permissions.Required.Calls, ShouldEqual, 1)
+
+				var page struct {
+					Items      []*models.Topic `json:"items"`
+					TotalCount int             `json:"total_count"`
+				}
+				So(json.Unmarshal(w.Body.Bytes(), &page), ShouldBeNil)
+				So(page.TotalCount, ShouldEqual, 4)
+				So(page.Items, ShouldHaveLength, 2)
+				So(page.Items[0].ID, ShouldEqual, "inflation")
+				So(page.Items[1].ID, ShouldEqual, "population")
+				So(mockedDataStore.GetTopicsPageCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.GetTopicsPageCalls()[0].Offset, ShouldEqual, 1)
+				So(mockedDataStore.GetTopicsPageCalls()[0].Limit, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When a topic is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/topics/inflation", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the topic is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var topic models.Topic
+				So(json.Unmarshal(w.Body.Bytes(), &topic), ShouldBeNil)
+				So(topic.Label, ShouldEqual, "Inflation")
+				So(topic.ParentID, ShouldEqual, "economy")
+			})
+		})
+
+		Convey("When an unknown topic is requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/topics/unknown", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then not found is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrTopicNotFound.Error())
+			})
+		})
+	})
+}
+
+func TestGetTopicsPublic(t *testing.T) {
+	t.Parallel()
+	Convey("Given a web API holding a topic", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				return &models.Topic{ID: id, Label: "Economy"}, nil
+			},
+		}
+		api := GetWebAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When the topic is requested", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/topics/economy", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the topic is returned with a public Cache-Control header", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=60")
+				So(w.Header().Get("ETag"), ShouldNotBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetTopicDatasets(t *testing.T) {
+	t.Parallel()
+	Convey("Given a taxonomy of topics", t, func() {
+		topics := []*models.Topic{
+			{ID: "economy", Label: "Economy"},
+			{ID: "inflation", Label: "Inflation", ParentID: "economy"},
+			{ID: "population", Label: "Population"},
+			{ID: "prices", Label: "Prices", ParentID: "inflation"},
+		}
+		mockedDataStore := &storetest.StorerMock{
+			GetTopicsFunc: func(ctx context.Context) ([]*models.Topic, error) {
+				return topics, nil
+			},
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				for _, topic := range topics {
+					if topic.ID == id {
+						return topic, nil
+					}
+				}
+				return nil, errs.ErrTopicNotFound
+			},
+			GetTopicDatasetsFunc: func(ctx context.Context, topicIDs []string, offset, limit int) ([]*models.DatasetUpdate, int, error) {
+				return []*models.DatasetUpdate{{ID: "cpih01", Current: &models.Dataset{Theme: "inflation"}}}, 1, nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When the datasets of a topic are requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/topics/economy/datasets?limit=20", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then only the published datasets of the topic are returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, `"id":"cpih01"`)
+				So(mockedDataStore.GetTopicDatasetsCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.GetTopicDatasetsCalls()[0].TopicIDs, ShouldResemble, []string{"economy"})
+				So(mockedDataStore.GetTopicDatasetsCalls()[0].Limit, ShouldEqual, 20)
+				So(mockedDataStore.GetTopicsCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the datasets of a topic and its descendants are requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/topics/economy/datasets?include_descendants=true", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the datasets of every topic below it are included", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedDataStore.GetTopicDatasetsCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.GetTopicDatasetsCalls()[0].TopicIDs, ShouldResemble, []string{"economy", "inflation", "prices"})
+			})
+		})
+
+		Convey("When include_descendants is not a boolean", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/topics/economy/datasets?include_descendants=maybe", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then bad request is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrInvalidQueryParameter.Error())
+				So(mockedDataStore.GetTopicDatasetsCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the datasets of an unknown topic are requested", func() {
+			r := createRequestWithAuth("GET", "http://localhost:22000/topics/unknown/datasets", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then not found is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(mockedDataStore.GetTopicDatasetsCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestAddTopic(t *testing.T) {
+	t.Parallel()
+	Convey("Given a taxonomy of topics", t, func() {
+		topics := []*models.Topic{
+			{ID: "economy", Label: "Economy"},
+			{ID: "inflation", Label: "Inflation", ParentID: "economy"},
+			{ID: "population", Label: "Population"},
+			{ID: "prices", Label: "Prices", ParentID: "inflation"},
+		}
+		var storedLinks []string
+		mockedDataStore := &storetest.StorerMock{
+			GetTopicsFunc: func(ctx context.Context) ([]*models.Topic, error) {
+				return topics, nil
+			},
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				for _, topic := range topics {
+					if topic.ID == id {
+						return topic, nil
+					}
+				}
+				return nil, errs.ErrTopicNotFound
+			},
+			UpsertTopicFunc: func(ctx context.Context, topic *models.Topic) error {
+				// the links are recorded as they are stored, before they are rendered in the response
+				storedLinks = []string{topic.Links.Self.HRef, topic.Links.Parent.HRef, topic.Links.Datasets.HRef}
+				return nil
+			},
+		}
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), permissions)
+
+		Convey("When a topic is added below an existing topic", func() {
+			r := createRequestWithAuth("POST", "http://localhost:22000/topics/employment", bytes.NewBufferString(`{"label":"Employment","parent_id":"economy"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the topic is stored with its links", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(permissions.Required.Calls, ShouldEqual, 1)
+				So(mockedDataStore.UpsertTopicCalls(), ShouldHaveLength, 1)
+
+				So(mockedDataStore.UpsertTopicCalls()[0].Topic.ID, ShouldEqual, "employment")
+				So(storedLinks, ShouldResemble, []string{"/topics/employment", "/topics/economy", "/topics/employment/datasets"})
+			})
+
+			Convey("Then the links are rendered in the response", func() {
+				So(w.Body.String(), ShouldContainSubstring, `"href":"http://localhost:22000/topics/employment"`)
+			})
+
+			Convey("Then the request body has been drained", func() {
+				_, err := r.Body.Read(make([]byte, 1))
+				So(err, ShouldEqual, io.EOF)
+			})
+		})
+
+		Convey("When a topic that already exists is added", func() {
+			r := createRequestWithAuth("POST", "http://localhost:22000/topics/economy", bytes.NewBufferString(`{"label":"Economy"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then forbidden is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrAddTopicAlreadyExists.Error())
+				So(mockedDataStore.UpsertTopicCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a topic without a label is added", func() {
+			r := createRequestWithAuth("POST", "http://localhost:22000/topics/employment", bytes.NewBufferString(`{"parent_id":"economy"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then bad request is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, "missing mandatory fields: [label]")
+				So(mockedDataStore.UpsertTopicCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a topic is added below an unknown topic", func() {
+			r := createRequestWithAuth("POST", "http://localhost:22000/topics/employment", bytes.NewBufferString(`{"label":"Employment","parent_id":"unknown"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then bad request is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrTopicParentInvalid.Error())
+				So(mockedDataStore.UpsertTopicCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestPutTopic(t *testing.T) {
+	t.Parallel()
+	Convey("Given a taxonomy of topics", t, func() {
+		topics := []*models.Topic{
+			{ID: "economy", Label: "Economy"},
+			{ID: "inflation", Label: "Inflation", ParentID: "economy"},
+			{ID: "population", Label: "Population"},
+			{ID: "prices", Label: "Prices", ParentID: "inflation"},
+		}
+		mockedDataStore := &storetest.StorerMock{
+			GetTopicsFunc: func(ctx context.Context) ([]*models.Topic, error) {
+				return topics, nil
+			},
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				for _, topic := range topics {
+					if topic.ID == id {
+						return topic, nil
+					}
+				}
+				return nil, errs.ErrTopicNotFound
+			},
+			UpsertTopicFunc: func(ctx context.Context, topic *models.Topic) error {
+				return nil
+			},
+		}
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), permissions)
+
+		Convey("When a topic is moved to the top of the taxonomy", func() {
+			r := createRequestWithAuth("PUT", "http://localhost:22000/topics/inflation", bytes.NewBufferString(`{"label":"Inflation and prices"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the topic is replaced", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(permissions.Required.Calls, ShouldEqual, 1)
+				So(mockedDataStore.UpsertTopicCalls(), ShouldHaveLength, 1)
+
+				topic := mockedDataStore.UpsertTopicCalls()[0].Topic
+				So(topic.Label, ShouldEqual, "Inflation and prices")
+				So(topic.ParentID, ShouldBeEmpty)
+				So(topic.Links.Parent, ShouldBeNil)
+			})
+		})
+
+		Convey("When a topic is moved below one of its descendants", func() {
+			r := createRequestWithAuth("PUT", "http://localhost:22000/topics/economy", bytes.NewBufferString(`{"label":"Economy","parent_id":"prices"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then bad request is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrTopicParentInvalid.Error())
+				So(mockedDataStore.UpsertTopicCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a topic is moved below itself", func() {
+			r := createRequestWithAuth("PUT", "http://localhost:22000/topics/economy", bytes.NewBufferString(`{"label":"Economy","parent_id":"economy"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then bad request is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(mockedDataStore.UpsertTopicCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When an unknown topic is updated", func() {
+			r := createRequestWithAuth("PUT", "http://localhost:22000/topics/unknown", bytes.NewBufferString(`{"label":"Unknown"}`))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then not found is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(mockedDataStore.UpsertTopicCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestDeleteTopic(t *testing.T) {
+	t.Parallel()
+	Convey("Given a taxonomy of topics", t, func() {
+		topics := []*models.Topic{
+			{ID: "economy", Label: "Economy"},
+			{ID: "inflation", Label: "Inflation", ParentID: "economy"},
+			{ID: "population", Label: "Population"},
+			{ID: "prices", Label: "Prices", ParentID: "inflation"},
+		}
+		mockedDataStore := &storetest.StorerMock{
+			GetTopicsFunc: func(ctx context.Context) ([]*models.Topic, error) {
+				return topics, nil
+			},
+			GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+				for _, topic := range topics {
+					if topic.ID == id {
+						return topic, nil
+					}
+				}
+				return nil, errs.ErrTopicNotFound
+			},
+			CountTopicDatasetsFunc: func(ctx context.Context, id string) (int, error) {
+				if id == "population" {
+					return 1, nil
+				}
+				return 0, nil
+			},
+			DeleteTopicFunc: func(ctx context.Context, id string) error {
+				return nil
+			},
+		}
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), permissions)
+
+		Convey("When a topic without sub topics or datasets is deleted", func() {
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/topics/prices", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the topic is removed", func() {
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+				So(permissions.Required.Calls, ShouldEqual, 1)
+				So(mockedDataStore.DeleteTopicCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.DeleteTopicCalls()[0].ID, ShouldEqual, "prices")
+			})
+		})
+
+		Convey("When a topic with sub topics is deleted", func() {
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/topics/inflation", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then forbidden is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrDeleteTopicForbidden.Error())
+				So(mockedDataStore.DeleteTopicCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a topic with datasets is deleted", func() {
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/topics/population", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then forbidden is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(mockedDataStore.DeleteTopicCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When an unknown topic is deleted", func() {
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/topics/unknown", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then not found is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(mockedDataStore.DeleteTopicCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
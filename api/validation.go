@@ -0,0 +1,225 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/swagger"
+	"github.com/ONSdigital/dp-dataset-api/utils"
+	dphttp "github.com/ONSdigital/dp-net/http"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/ghodss/yaml"
+)
+
+var (
+	specRouter     routers.Router
+	specRouterErr  error
+	loadSpecRouter sync.Once
+)
+
+// getSpecRouter returns the routes of the embedded specification of the api, which is only loaded once as it can
+// not change while the api is running
+func getSpecRouter() (routers.Router, error) {
+	loadSpecRouter.Do(func() {
+		specRouter, specRouterErr = newSpecRouter(swagger.Spec)
+	})
+	return specRouter, specRouterErr
+}
+
+// newSpecRouter converts the swagger specification to OpenAPI 3, in which requests can be validated, and closes the
+// schemas of its request bodies so that unknown fields are rejected rather than silently ignored
+func newSpecRouter(spec []byte) (routers.Router, error) {
+	b, err := yaml.YAMLToJSON(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc2 openapi2.T
+	if err = json.Unmarshal(b, &doc2); err != nil {
+		return nil, err
+	}
+
+	doc3, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return nil, err
+	}
+	if err = openapi3.NewLoader().ResolveRefsIn(doc3, nil); err != nil {
+		return nil, err
+	}
+
+	visited := make(map[*openapi3.Schema]bool)
+	for _, pathItem := range doc3.Paths {
+		for _, operation := range pathItem.Operations() {
+			if operation.RequestBody == nil || operation.RequestBody.Value == nil {
+				continue
+			}
+			for _, mediaType := range operation.RequestBody.Value.Content {
+				closeSchema(mediaType.Schema, visited)
+			}
+		}
+	}
+
+	// routes are matched on their path alone, whichever host and base path the api is served from
+	doc3.Servers = nil
+	return gorillamux.NewRouter(doc3)
+}
+
+// closeSchema rejects the properties of an object that are not in its schema, merging the schemas it is composed of
+// first. Values are decoded by the handlers into structs, which treat null as the zero value of a field, so null is
+// accepted for every field as it is by the handlers.
+func closeSchema(ref *openapi3.SchemaRef, visited map[*openapi3.Schema]bool) {
+	if ref == nil || ref.Value == nil || visited[ref.Value] {
+		return
+	}
+	schema := ref.Value
+	visited[schema] = true
+
+	for _, part := range schema.AllOf {
+		closeSchema(part, visited)
+		if part.Value == nil {
+			continue
+		}
+		if schema.Type == "" {
+			schema.Type = part.Value.Type
+		}
+		for name, property := range part.Value.Properties {
+			if schema.Properties == nil {
+				schema.Properties = make(openapi3.Schemas)
+			}
+			if _, ok := schema.Properties[name]; !ok {
+				schema.Properties[name] = property
+			}
+		}
+		schema.Required = append(schema.Required, part.Value.Required...)
+	}
+	schema.AllOf = nil
+
+	schema.Nullable = true
+	if len(schema.Properties) > 0 && schema.AdditionalProperties == nil && schema.AdditionalPropertiesAllowed == nil {
+		closed := false
+		schema.AdditionalPropertiesAllowed = &closed
+	}
+
+	for _, property := range schema.Properties {
+		closeSchema(property, visited)
+	}
+	closeSchema(schema.Items, visited)
+}
+
+// isValidRequest wraps a http.HandlerFunc in another http.HandlerFunc that validates the parameters and body of the
+// request against the specification of the api. The wrapped handler is only called if the request is valid, otherwise
+// every field that does not match the specification is returned in the errors of the problem.
+func (api *DatasetAPI) isValidRequest(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logData := log.Data{"path": r.URL.Path, "method": r.Method}
+
+		router, err := getSpecRouter()
+		if err != nil {
+			log.Event(ctx, "isValidRequest: failed to load the specification of the api", log.ERROR, log.Error(err), logData)
+			utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
+			return
+		}
+
+		// the request has already been routed to the handler, so a route the specification does not describe yet is
+		// handled without being validated rather than failing the request
+		route, pathParams, err := router.FindRoute(r)
+		if err != nil {
+			log.Event(ctx, "isValidRequest: request is not described by the specification of the api, so is not validated", log.WARN, log.Error(err), logData)
+			handler(w, r)
+			return
+		}
+
+		// bodies are json unless stated otherwise, as the handlers have always assumed
+		if r.Header.Get("Content-Type") == "" {
+			r.Header.Set("Content-Type", "application/json")
+		}
+
+		err = openapi3filter.ValidateRequest(ctx, &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				MultiError:         true,
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			},
+		})
+		if err == nil {
+			handler(w, r)
+			return
+		}
+		dphttp.DrainBody(r)
+
+		fields := fieldErrors(err)
+		logData["errors"] = fields
+		log.Event(ctx, "isValidRequest: request does not match the specification of the api", log.WARN, logData)
+
+		utils.WriteError(ctx, w, errs.ErrInvalidBody, http.StatusBadRequest, fields...)
+	}
+}
+
+// fieldErrors flattens the errors of a request validation into the field each of them is about
+func fieldErrors(err error) []models.FieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var fields []models.FieldError
+		for _, err := range e {
+			fields = append(fields, fieldErrors(err)...)
+		}
+		return fields
+
+	case *openapi3filter.RequestError:
+		var parseError *openapi3filter.ParseError
+		switch {
+		case e.Parameter != nil:
+			fields := fieldErrors(e.Err)
+			if e.Err == nil {
+				fields = []models.FieldError{{Message: e.Reason}}
+			}
+			for i := range fields {
+				fields[i].Field = e.Parameter.Name
+			}
+			return fields
+		case e.Err == nil:
+			return []models.FieldError{{Message: e.Reason}}
+		case errors.As(e.Err, &parseError):
+			return []models.FieldError{{Message: errs.ErrUnableToParseJSON.Error()}}
+		default:
+			return fieldErrors(e.Err)
+		}
+
+	case *openapi3.SchemaError:
+		path := e.JSONPointer()
+
+		// an unsupported property is reported by the object it is in, while a missing one is reported by its own path
+		var property string
+		if _, scanErr := fmt.Sscanf(e.Reason, "property %q", &property); scanErr == nil {
+			if len(path) == 0 || path[len(path)-1] != property {
+				path = append(path, property)
+			}
+		}
+		return []models.FieldError{{Field: strings.Join(path, "."), Message: e.Reason}}
+	}
+
+	return []models.FieldError{{Message: err.Error()}}
+}
+
+// getSpec serves the specification the requests to the api are validated against
+func (api *DatasetAPI) getSpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	if _, err := w.Write(swagger.Spec); err != nil {
+		log.Event(r.Context(), "getSpec endpoint: error writing bytes to response", log.ERROR, log.Error(err))
+	}
+}
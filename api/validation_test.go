@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	"github.com/ONSdigital/dp-dataset-api/swagger"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestValidRequest(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the api", t, func() {
+		mockedDataStore := &storetest.StorerMock{}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When a version is updated with misspelt and mistyped fields", func() {
+			b := `{"relase_date":"2017-04-04","state":"associated","temporal":[{"frequency":"monthly","start_date":1}]}`
+			r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123/editions/2017/versions/1", bytes.NewBufferString(b))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then every invalid field is returned without the version being updated", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/problem+json")
+
+				var response models.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &response), ShouldBeNil)
+				So(response.Code, ShouldEqual, "invalid_body")
+				So(response.Errors, ShouldHaveLength, 2)
+				So(response.Errors, ShouldContain, models.FieldError{Field: "relase_date", Message: `property "relase_date" is unsupported`})
+				So(response.Errors, ShouldContain, models.FieldError{Field: "temporal.0.start_date", Message: `Field must be set to string or not be present`})
+				So(mockedDataStore.GetVersionCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a dataset is created with an unknown field nested in it", func() {
+			b := `{"title":"CPI","contacts":[{"name":"John Cox","phone":"01623 456789"}]}`
+			r := createRequestWithAuth("POST", "http://localhost:22000/datasets/123", bytes.NewBufferString(b))
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the path of the field is returned without the dataset being created", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldEqual, `{"title":"Bad Request","status":400,"code":"invalid_body","detail":"invalid request body","errors":[{"field":"contacts.0.phone","message":"property \"phone\" is unsupported"}]}`)
+				So(mockedDataStore.GetDatasetCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a request is made to a route the specification does not describe", func() {
+			handled := false
+			r := createRequestWithAuth("POST", "http://localhost:22000/undescribed", bytes.NewBufferString(`{"unknown":true}`))
+			w := httptest.NewRecorder()
+			api.isValidRequest(func(w http.ResponseWriter, r *http.Request) { handled = true })(w, r)
+
+			Convey("Then the request is handled without being validated", func() {
+				So(handled, ShouldBeTrue)
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+	})
+}
+
+func TestGetSpec(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the api", t, func() {
+		api := GetAPIWithMocks(&storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When the specification is requested", func() {
+			r := httptest.NewRequest("GET", "http://localhost:22000/swagger.yaml", nil)
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then the specification requests are validated against is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/yaml")
+				So(w.Body.Bytes(), ShouldResemble, swagger.Spec)
+			})
+		})
+	})
+}
@@ -5,10 +5,16 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/events"
+	"github.com/ONSdigital/dp-dataset-api/metrics"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
+	"github.com/ONSdigital/dp-dataset-api/utils"
 	dphttp "github.com/ONSdigital/dp-net/http"
 	dprequest "github.com/ONSdigital/dp-net/request"
 	"github.com/ONSdigital/log.go/log"
@@ -35,6 +41,22 @@ var (
 		models.ErrPublishedVersionCollectionIDInvalid:  true,
 		models.ErrAssociatedVersionCollectionIDInvalid: true,
 		models.ErrVersionStateInvalid:                  true,
+		errs.ErrCollectionNotFound:                     true,
+		errs.ErrWithdrawalAlertInvalid:                 true,
+		errs.ErrTooManyBatchItems:                      true,
+	}
+
+	// errors that map to a HTTP 403 response
+	forbidden = map[error]bool{
+		errs.ErrWithdrawVersionForbidden: true,
+	}
+
+	// errors that map to a HTTP 409 response
+	conflict = map[error]bool{
+		errs.ErrVersionConflict:       true,
+		errs.ErrDatasetConflict:       true,
+		errs.ErrEditionConflict:       true,
+		errs.ErrCollectionNotEditable: true,
 	}
 
 	// HTTP 500 responses with a specific message
@@ -71,12 +93,12 @@ func (api *DatasetAPI) getVersions(w http.ResponseWriter, r *http.Request, limit
 			state = models.PublishedState
 		}
 
-		if err := api.dataStore.Backend.CheckDatasetExists(datasetID, state); err != nil {
+		if err := api.dataStore.Backend.CheckDatasetExists(ctx, datasetID, state); err != nil {
 			log.Event(ctx, "failed to find dataset for list of versions", log.ERROR, log.Error(err), logData)
 			return nil, 0, err
 		}
 
-		if err := api.dataStore.Backend.CheckEditionExists(datasetID, edition, state); err != nil {
+		if err := api.dataStore.Backend.CheckEditionExists(ctx, datasetID, edition, state); err != nil {
 			log.Event(ctx, "failed to find edition for list of versions", log.ERROR, log.Error(err), logData)
 			return nil, 0, err
 		}
@@ -87,6 +109,8 @@ func (api *DatasetAPI) getVersions(w http.ResponseWriter, r *http.Request, limit
 			return nil, 0, err
 		}
 
+		results, totalCount = api.withoutInaccessibleVersions(r, authorised, datasetID, results, totalCount, logData)
+
 		var hasInvalidState bool
 		for _, item := range results {
 			if err = models.CheckState("version", item.State); err != nil {
@@ -96,7 +120,7 @@ func (api *DatasetAPI) getVersions(w http.ResponseWriter, r *http.Request, limit
 
 			// Only the download service should have access to the
 			// public/private download fields
-			if r.Header.Get(downloadServiceToken) != api.downloadServiceToken {
+			if !api.isDownloadService(r) {
 				if item.Downloads != nil {
 					if item.Downloads.CSV != nil {
 						item.Downloads.CSV.Private = ""
@@ -137,6 +161,8 @@ func (api *DatasetAPI) getVersion(w http.ResponseWriter, r *http.Request) {
 	version := vars["version"]
 	logData := log.Data{"dataset_id": datasetID, "edition": edition, "version": version}
 
+	var eTag string
+	var lastUpdated time.Time
 	b, getVersionErr := func() ([]byte, error) {
 		authorised := api.authenticate(r, logData)
 
@@ -146,53 +172,14 @@ func (api *DatasetAPI) getVersion(w http.ResponseWriter, r *http.Request) {
 			return nil, err
 		}
 
-		var state string
-		if !authorised {
-			state = models.PublishedState
-		}
-
-		if err := api.dataStore.Backend.CheckDatasetExists(datasetID, state); err != nil {
-			log.Event(ctx, "failed to find dataset", log.ERROR, log.Error(err), logData)
-			return nil, err
-		}
-
-		if err := api.dataStore.Backend.CheckEditionExists(datasetID, edition, state); err != nil {
-			log.Event(ctx, "failed to find edition for dataset", log.ERROR, log.Error(err), logData)
-			return nil, err
-		}
-
-		results, err := api.dataStore.Backend.GetVersion(datasetID, edition, versionId, state)
+		results, err := api.viewableVersion(r, authorised, datasetID, edition, versionId, logData)
 		if err != nil {
-			log.Event(ctx, "failed to find version for dataset edition", log.ERROR, log.Error(err), logData)
 			return nil, err
 		}
+		eTag = results.ETag
+		lastUpdated = results.LastUpdated
 
-		results.Links.Self.HRef = results.Links.Version.HRef
-
-		if err = models.CheckState("version", results.State); err != nil {
-			log.Event(ctx, "unpublished version has an invalid state", log.ERROR, log.Error(err), log.Data{"state": results.State})
-			return nil, errs.ErrResourceState
-		}
-
-		// Only the download service should not have access to the public/private download
-		// fields
-		if r.Header.Get(downloadServiceToken) != api.downloadServiceToken {
-			if results.Downloads != nil {
-				if results.Downloads.CSV != nil {
-					results.Downloads.CSV.Private = ""
-					results.Downloads.CSV.Public = ""
-				}
-				if results.Downloads.XLS != nil {
-					results.Downloads.XLS.Private = ""
-					results.Downloads.XLS.Public = ""
-				}
-				if results.Downloads.CSVW != nil {
-					results.Downloads.CSVW.Private = ""
-					results.Downloads.CSVW.Public = ""
-				}
-			}
-		}
-
+		api.renderLinks(r, results)
 		b, err := json.Marshal(results)
 		if err != nil {
 			log.Event(ctx, "failed to marshal version resource into bytes", log.ERROR, log.Error(err), logData)
@@ -207,6 +194,8 @@ func (api *DatasetAPI) getVersion(w http.ResponseWriter, r *http.Request) {
 	}
 
 	setJSONContentType(w)
+	setETag(w, eTag)
+	setLastModified(w, lastUpdated)
 	_, err := w.Write(b)
 	if err != nil {
 		log.Event(ctx, "failed writing bytes to response", log.ERROR, log.Error(err), logData)
@@ -215,6 +204,135 @@ func (api *DatasetAPI) getVersion(w http.ResponseWriter, r *http.Request) {
 	log.Event(ctx, "getVersion endpoint: request successful", log.INFO, logData)
 }
 
+// viewableVersion returns the version as the caller of the request can view it. Unauthorised callers can only view
+// published versions of published editions and datasets, and only the download service can view where the downloads
+// of the version are stored.
+func (api *DatasetAPI) viewableVersion(r *http.Request, authorised bool, datasetID, edition string, version int, logData log.Data) (*models.Version, error) {
+	ctx := r.Context()
+
+	var state string
+	if !authorised {
+		state = models.PublishedState
+	}
+
+	if err := api.dataStore.Backend.CheckDatasetExists(ctx, datasetID, state); err != nil {
+		log.Event(ctx, "failed to find dataset", log.ERROR, log.Error(err), logData)
+		return nil, err
+	}
+
+	if err := api.dataStore.Backend.CheckEditionExists(ctx, datasetID, edition, state); err != nil {
+		log.Event(ctx, "failed to find edition for dataset", log.ERROR, log.Error(err), logData)
+		return nil, err
+	}
+
+	results, err := api.dataStore.Backend.GetVersion(ctx, datasetID, edition, version, state)
+	if err != nil {
+		log.Event(ctx, "failed to find version for dataset edition", log.ERROR, log.Error(err), logData)
+		return nil, err
+	}
+
+	if !api.canViewVersion(r, authorised, datasetID, results, logData) {
+		log.Event(ctx, "caller cannot access the collection of the unpublished version", log.INFO, logData)
+		return nil, errs.ErrVersionNotFound
+	}
+
+	results.Links.Self.HRef = results.Links.Version.HRef
+
+	if err = models.CheckState("version", results.State); err != nil {
+		log.Event(ctx, "unpublished version has an invalid state", log.ERROR, log.Error(err), log.Data{"state": results.State})
+		return nil, errs.ErrResourceState
+	}
+
+	// Only the download service should not have access to the public/private download
+	// fields
+	if !api.isDownloadService(r) {
+		if results.Downloads != nil {
+			if results.Downloads.CSV != nil {
+				results.Downloads.CSV.Private = ""
+				results.Downloads.CSV.Public = ""
+			}
+			if results.Downloads.XLS != nil {
+				results.Downloads.XLS.Private = ""
+				results.Downloads.XLS.Public = ""
+			}
+			if results.Downloads.CSVW != nil {
+				results.Downloads.CSVW.Private = ""
+				results.Downloads.CSVW.Public = ""
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// canViewVersion returns true if the version is published (including withdrawn versions) or the authorised caller of
+// the request can access the collection the unpublished version is associated with. Unauthorised callers are only given
+// published versions.
+func (api *DatasetAPI) canViewVersion(r *http.Request, authorised bool, datasetID string, version *models.Version, logData log.Data) bool {
+	if !authorised || models.IsPublished(version.State) {
+		return true
+	}
+	return api.canAccessCollection(r, datasetID, version.CollectionID, logData)
+}
+
+// withoutInaccessibleVersions removes the unpublished versions the caller of the request cannot view
+func (api *DatasetAPI) withoutInaccessibleVersions(r *http.Request, authorised bool, datasetID string, versions []models.Version, totalCount int, logData log.Data) ([]models.Version, int) {
+	items := []models.Version{}
+	for i := range versions {
+		if !api.canViewVersion(r, authorised, datasetID, &versions[i], logData) {
+			totalCount--
+			continue
+		}
+		items = append(items, versions[i])
+	}
+	return items, totalCount
+}
+
+//getOrphanedVersions returns a list of the unpublished versions associated with a collection that no longer exists,
+//the total count of orphaned versions and an error
+func (api *DatasetAPI) getOrphanedVersions(w http.ResponseWriter, r *http.Request, limit, offset int) (interface{}, int, error) {
+	ctx := r.Context()
+	logData := log.Data{}
+
+	orphaned, err := func() ([]models.Version, error) {
+		versions, err := api.dataStore.Backend.GetVersionsInCollections(ctx)
+		if err != nil {
+			log.Event(ctx, "failed to find versions associated with collections", log.ERROR, log.Error(err), logData)
+			return nil, err
+		}
+
+		collectionExists := make(map[string]bool)
+		orphaned := []models.Version{}
+		for _, version := range versions {
+			exists, checked := collectionExists[version.CollectionID]
+			if !checked {
+				_, err := api.collections.GetCollection(ctx, version.CollectionID)
+				if err != nil && err != errs.ErrCollectionNotFound {
+					logData["collection_id"] = version.CollectionID
+					log.Event(ctx, "failed to get collection of version", log.ERROR, log.Error(err), logData)
+					return nil, err
+				}
+				exists = err == nil
+				collectionExists[version.CollectionID] = exists
+			}
+
+			if !exists {
+				orphaned = append(orphaned, version)
+			}
+		}
+		return orphaned, nil
+	}()
+
+	if err != nil {
+		handleVersionAPIErr(ctx, err, w, logData)
+		return nil, 0, err
+	}
+
+	logData["orphaned_versions"] = len(orphaned)
+	log.Event(ctx, "getOrphanedVersions endpoint: request successful", log.INFO, logData)
+	return utils.SliceVersions(orphaned, offset, limit), len(orphaned), nil
+}
+
 func (api *DatasetAPI) putVersion(w http.ResponseWriter, r *http.Request) {
 
 	defer dphttp.DrainBody(r)
@@ -232,7 +350,7 @@ func (api *DatasetAPI) putVersion(w http.ResponseWriter, r *http.Request) {
 		"version":   vars["version"],
 	}
 
-	currentDataset, currentVersion, versionDoc, err := api.updateVersion(ctx, r.Body, versionDetails)
+	currentDataset, currentVersion, versionDoc, err := api.updateVersion(ctx, r.Body, versionDetails, getIfMatch(r))
 	if err != nil {
 		handleVersionAPIErr(ctx, err, w, data)
 		return
@@ -248,7 +366,7 @@ func (api *DatasetAPI) putVersion(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if versionDoc.State == models.AssociatedState && currentVersion.State != models.AssociatedState {
-			if err := api.associateVersion(ctx, currentVersion, versionDoc, versionDetails); err != nil {
+			if err := api.associateVersion(ctx, currentDataset, currentVersion, versionDoc, versionDetails); err != nil {
 				handleVersionAPIErr(ctx, err, w, data)
 				return
 			}
@@ -256,6 +374,7 @@ func (api *DatasetAPI) putVersion(w http.ResponseWriter, r *http.Request) {
 	}
 
 	setJSONContentType(w)
+	setETag(w, versionDoc.ETag)
 	w.WriteHeader(http.StatusOK)
 	log.Event(ctx, "putVersion endpoint: request successful", log.INFO, data)
 }
@@ -272,9 +391,11 @@ func (api *DatasetAPI) detachVersion(w http.ResponseWriter, r *http.Request) {
 	datasetID := vars["dataset_id"]
 	edition := vars["edition"]
 	version := vars["version"]
+	eTag := getIfMatch(r)
 
 	logData := log.Data{"dataset_id": datasetID, "edition": edition, "version": version}
 
+	var newETag string
 	err := func() error {
 
 		authorised := api.authenticate(r, logData)
@@ -289,12 +410,18 @@ func (api *DatasetAPI) detachVersion(w http.ResponseWriter, r *http.Request) {
 			return err
 		}
 
-		editionDoc, err := api.dataStore.Backend.GetEdition(datasetID, edition, "")
+		editionDoc, err := api.dataStore.Backend.GetEdition(ctx, datasetID, edition, "")
 		if err != nil {
 			log.Event(ctx, "detachVersion endpoint: Cannot find the specified edition", log.ERROR, log.Error(errs.ErrEditionNotFound), logData)
 			return err
 		}
 
+		// An edition without a latest version has no version to detach
+		if editionDoc.Next == nil || editionDoc.Next.Links == nil || editionDoc.Next.Links.LatestVersion == nil {
+			log.Event(ctx, "detachVersion endpoint: the edition has no versions to detach", log.ERROR, log.Error(errs.ErrVersionNotFound), logData)
+			return errs.ErrVersionNotFound
+		}
+
 		// Only permit detachment of the latest version.
 		if editionDoc.Next.Links.LatestVersion.ID != version {
 			log.Event(ctx, "detachVersion endpoint: Detach called againt a version other than latest, aborting", log.ERROR, log.Error(errs.ErrVersionAlreadyExists), logData)
@@ -308,42 +435,54 @@ func (api *DatasetAPI) detachVersion(w http.ResponseWriter, r *http.Request) {
 			return errs.ErrIncorrectStateToDetach
 		}
 
-		versionDoc, err := api.dataStore.Backend.GetVersion(datasetID, edition, versionId, editionDoc.Next.State)
+		versionDoc, err := api.dataStore.Backend.GetVersion(ctx, datasetID, edition, versionId, editionDoc.Next.State)
 		if err != nil {
 			log.Event(ctx, "detachVersion endpoint: Cannot find the specified version", log.ERROR, log.Error(errs.ErrVersionNotFound), logData)
 			return errs.ErrVersionNotFound
 		}
 
-		datasetDoc, err := api.dataStore.Backend.GetDataset(datasetID)
+		if eTag != mongo.AnyETag && eTag != versionDoc.ETag {
+			log.Event(ctx, "detachVersion endpoint: version does not match the expected eTag", log.ERROR, log.Error(errs.ErrVersionConflict), logData)
+			return errs.ErrVersionConflict
+		}
+
+		datasetDoc, err := api.dataStore.Backend.GetDataset(ctx, datasetID)
 		if err != nil {
 			log.Event(ctx, "detachVersion endpoint: datastore.GetDatasets returned an error", log.ERROR, log.Error(err), logData)
 			return err
 		}
 
 		// Detach the version
-		versionDoc.State = models.DetachedState
-		if err = api.dataStore.Backend.UpdateVersion(versionDoc.ID, versionDoc); err != nil {
+		detachedVersion := *versionDoc
+		detachedVersion.State = models.DetachedState
+		if newETag, err = api.dataStore.Backend.UpdateVersion(ctx, versionDoc, &detachedVersion, eTag); err != nil {
 			log.Event(ctx, "detachVersion endpoint: failed to update version document", log.ERROR, log.Error(err), logData)
 			return err
 		}
+		metrics.StateChanged(versionDoc.State, detachedVersion.State)
 
 		// Only rollback dataset & edition if there's a "Current" sub-document to roll back to (i.e if a version has been published).
 		if datasetDoc.Current != nil {
 			// Rollback the edition
 			editionDoc.Next = editionDoc.Current
-			if err = api.dataStore.Backend.UpsertEdition(datasetID, edition, editionDoc); err != nil {
+			if err = api.dataStore.Backend.UpsertEdition(ctx, datasetID, edition, editionDoc, mongo.AnyETag); err != nil {
 				log.Event(ctx, "detachVersion endpoint: failed to update edition document", log.ERROR, log.Error(err), logData)
 				return err
 			}
 
 			// Rollback the dataset
 			datasetDoc.Next = datasetDoc.Current
-			if err = api.dataStore.Backend.UpsertDataset(datasetID, datasetDoc); err != nil {
+			if err = api.dataStore.Backend.UpsertDataset(ctx, datasetID, datasetDoc, mongo.AnyETag); err != nil {
 				log.Event(ctx, "detachVersion endpoint: failed to update dataset document", log.ERROR, log.Error(err), logData)
 				return err
 			}
 		}
 
+		// the version is detached by now, so web instances which miss the event pick it up as their cache expires
+		if err = api.eventEmitter.Emit(ctx, events.VersionDetached, datasetID, edition, version, versionDoc.ID); err != nil {
+			log.Event(ctx, "detachVersion endpoint: failed to send version detached event", log.ERROR, log.Error(err), logData)
+		}
+
 		return nil
 	}()
 
@@ -352,11 +491,160 @@ func (api *DatasetAPI) detachVersion(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	metrics.VersionsDetached.Inc()
+	setETag(w, newETag)
 	w.WriteHeader(http.StatusOK)
 	log.Event(ctx, "detachVersion endpoint: request successful", log.INFO, logData)
 }
 
-func (api *DatasetAPI) updateVersion(ctx context.Context, body io.ReadCloser, versionDetails VersionDetails) (*models.DatasetUpdate, *models.Version, *models.Version, error) {
+// withdrawVersion moves a published version into the withdrawn state, recording the correction alert explaining why.
+// Where the version was the latest version of its edition or dataset, the latest version is re-pointed to the previous
+// published version of the edition, if there is one.
+func (api *DatasetAPI) withdrawVersion(w http.ResponseWriter, r *http.Request) {
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	datasetID := vars["dataset_id"]
+	edition := vars["edition"]
+	version := vars["version"]
+	eTag := getIfMatch(r)
+	logData := log.Data{"dataset_id": datasetID, "edition": edition, "version": version}
+
+	var newETag string
+	err := func() error {
+		versionID, err := models.ValidateVersionNumber(ctx, version)
+		if err != nil {
+			log.Event(ctx, "withdrawVersion endpoint: invalid version request", log.ERROR, log.Error(err), logData)
+			return err
+		}
+
+		withdrawal, err := models.CreateVersionWithdrawal(r.Body)
+		if err != nil {
+			log.Event(ctx, "withdrawVersion endpoint: failed to model version withdrawal based on request", log.ERROR, log.Error(err), logData)
+			return errs.ErrUnableToParseJSON
+		}
+
+		if err = withdrawal.Validate(); err != nil {
+			log.Event(ctx, "withdrawVersion endpoint: the withdrawal does not have a correction alert", log.ERROR, log.Error(err), logData)
+			return err
+		}
+
+		datasetDoc, err := api.dataStore.Backend.GetDataset(ctx, datasetID)
+		if err != nil {
+			log.Event(ctx, "withdrawVersion endpoint: datastore.GetDataset returned an error", log.ERROR, log.Error(err), logData)
+			return err
+		}
+
+		editionDoc, err := api.dataStore.Backend.GetEdition(ctx, datasetID, edition, "")
+		if err != nil {
+			log.Event(ctx, "withdrawVersion endpoint: failed to find edition of dataset", log.ERROR, log.Error(err), logData)
+			return err
+		}
+
+		versionDoc, err := api.dataStore.Backend.GetVersion(ctx, datasetID, edition, versionID, "")
+		if err != nil {
+			log.Event(ctx, "withdrawVersion endpoint: datastore.GetVersion returned an error", log.ERROR, log.Error(err), logData)
+			return err
+		}
+
+		if eTag != mongo.AnyETag && eTag != versionDoc.ETag {
+			log.Event(ctx, "withdrawVersion endpoint: version does not match the expected eTag", log.ERROR, log.Error(errs.ErrVersionConflict), logData)
+			return errs.ErrVersionConflict
+		}
+
+		if versionDoc.State != models.PublishedState {
+			logData["state"] = versionDoc.State
+			log.Event(ctx, "withdrawVersion endpoint: only a published version can be withdrawn", log.ERROR, log.Error(errs.ErrWithdrawVersionForbidden), logData)
+			return errs.ErrWithdrawVersionForbidden
+		}
+
+		previousVersion, err := api.dataStore.Backend.GetPreviousPublishedVersion(ctx, datasetID, edition, versionID)
+		if err != nil && err != errs.ErrVersionNotFound {
+			log.Event(ctx, "withdrawVersion endpoint: failed to find the previous published version", log.ERROR, log.Error(err), logData)
+			return err
+		}
+
+		var alerts []models.Alert
+		if versionDoc.Alerts != nil {
+			alerts = append(alerts, *versionDoc.Alerts...)
+		}
+		alerts = append(alerts, *withdrawal.Alert)
+
+		withdrawnVersion := &models.Version{
+			State:  models.WithdrawnState,
+			Alerts: &alerts,
+		}
+
+		// every document is only updated if it has not been changed since it was read
+		if newETag, err = api.dataStore.Backend.UpdateVersion(ctx, versionDoc, withdrawnVersion, versionDoc.ETag); err != nil {
+			log.Event(ctx, "withdrawVersion endpoint: failed to update version document", log.ERROR, log.Error(err), logData)
+			return err
+		}
+		metrics.StateChanged(versionDoc.State, withdrawnVersion.State)
+
+		// with no previous published version, the withdrawn version remains the latest version
+		var latestChanged bool
+		if previousVersion != nil {
+			logData["previous_version"] = previousVersion.Version
+			if latestChanged, err = api.replaceLatestVersion(ctx, datasetDoc, editionDoc, versionDoc, previousVersion, logData); err != nil {
+				return err
+			}
+		}
+
+		// the version has been withdrawn, so failing to send the events does not fail the request
+		if err = api.eventEmitter.Emit(ctx, events.VersionWithdrawn, datasetID, edition, version, versionDoc.ID); err != nil {
+			log.Event(ctx, "withdrawVersion endpoint: failed to send version withdrawn event", log.ERROR, log.Error(err), logData)
+		}
+
+		if latestChanged {
+			previous := strconv.Itoa(previousVersion.Version)
+			if err = api.eventEmitter.Emit(ctx, events.LatestVersionChanged, datasetID, edition, previous, previousVersion.ID); err != nil {
+				log.Event(ctx, "withdrawVersion endpoint: failed to send latest version changed event", log.ERROR, log.Error(err), logData)
+			}
+		}
+
+		return nil
+	}()
+
+	if err != nil {
+		handleVersionAPIErr(ctx, err, w, logData)
+		return
+	}
+
+	setETag(w, newETag)
+	w.WriteHeader(http.StatusOK)
+	log.Event(ctx, "withdrawVersion endpoint: request successful", log.INFO, logData)
+}
+
+// replaceLatestVersion re-points the latest version of the edition and dataset from the withdrawn version to the
+// previous published version, returning true if either was changed
+func (api *DatasetAPI) replaceLatestVersion(ctx context.Context, datasetDoc *models.DatasetUpdate, editionDoc *models.EditionUpdate, withdrawnVersion, previousVersion *models.Version, logData log.Data) (bool, error) {
+	previousLink := &models.LinkObject{
+		ID:   previousVersion.Links.Version.ID,
+		HRef: previousVersion.Links.Version.HRef,
+	}
+
+	editionChanged := editionDoc.ReplaceLatestVersion(strconv.Itoa(withdrawnVersion.Version), previousLink)
+	if editionChanged {
+		if err := api.dataStore.Backend.UpsertEdition(ctx, datasetDoc.ID, withdrawnVersion.Edition, editionDoc, editionDoc.ETag); err != nil {
+			log.Event(ctx, "withdrawVersion endpoint: failed to update edition document", log.ERROR, log.Error(err), logData)
+			return false, err
+		}
+	}
+
+	datasetChanged := datasetDoc.ReplaceLatestVersion(withdrawnVersion.Links.Version, previousLink)
+	if datasetChanged {
+		if err := api.dataStore.Backend.UpsertDataset(ctx, datasetDoc.ID, datasetDoc, datasetDoc.ETag); err != nil {
+			log.Event(ctx, "withdrawVersion endpoint: failed to update dataset document", log.ERROR, log.Error(err), logData)
+			return false, err
+		}
+	}
+
+	return editionChanged || datasetChanged, nil
+}
+
+func (api *DatasetAPI) updateVersion(ctx context.Context, body io.ReadCloser, versionDetails VersionDetails, eTag string) (*models.DatasetUpdate, *models.Version, *models.Version, error) {
 	data := versionDetails.baseLogData()
 
 	// attempt to update the version
@@ -373,24 +661,33 @@ func (api *DatasetAPI) updateVersion(ctx context.Context, body io.ReadCloser, ve
 			log.Event(ctx, "putVersion endpoint: failed to model version resource based on request", log.ERROR, log.Error(err), data)
 			return nil, nil, nil, errs.ErrUnableToParseJSON
 		}
+		// the collection name is always taken from the collection the version is associated with
+		versionUpdate.CollectionName = ""
+		// the dimensions are only changed through the instance, or by summarising their options
+		versionUpdate.Dimensions = nil
 
-		currentDataset, err := api.dataStore.Backend.GetDataset(versionDetails.datasetID)
+		currentDataset, err := api.dataStore.Backend.GetDataset(ctx, versionDetails.datasetID)
 		if err != nil {
 			log.Event(ctx, "putVersion endpoint: datastore.getDataset returned an error", log.ERROR, log.Error(err), data)
 			return nil, nil, nil, err
 		}
 
-		if err = api.dataStore.Backend.CheckEditionExists(versionDetails.datasetID, versionDetails.edition, ""); err != nil {
+		if err = api.dataStore.Backend.CheckEditionExists(ctx, versionDetails.datasetID, versionDetails.edition, ""); err != nil {
 			log.Event(ctx, "putVersion endpoint: failed to find edition of dataset", log.ERROR, log.Error(err), data)
 			return nil, nil, nil, err
 		}
 
-		currentVersion, err := api.dataStore.Backend.GetVersion(versionDetails.datasetID, versionDetails.edition, version, "")
+		currentVersion, err := api.dataStore.Backend.GetVersion(ctx, versionDetails.datasetID, versionDetails.edition, version, "")
 		if err != nil {
 			log.Event(ctx, "putVersion endpoint: datastore.GetVersion returned an error", log.ERROR, log.Error(err), data)
 			return nil, nil, nil, err
 		}
 
+		if eTag != mongo.AnyETag && eTag != currentVersion.ETag {
+			log.Event(ctx, "putVersion endpoint: version does not match the expected eTag", log.ERROR, log.Error(errs.ErrVersionConflict), data)
+			return nil, nil, nil, errs.ErrVersionConflict
+		}
+
 		// Combine update version document to existing version document
 		populateNewVersionDoc(currentVersion, versionUpdate)
 		data["updated_version"] = versionUpdate
@@ -401,10 +698,21 @@ func (api *DatasetAPI) updateVersion(ctx context.Context, body io.ReadCloser, ve
 			return nil, nil, nil, err
 		}
 
-		if err := api.dataStore.Backend.UpdateVersion(versionUpdate.ID, versionUpdate); err != nil {
+		if err = api.validateCollection(ctx, currentVersion, versionUpdate, data); err != nil {
+			return nil, nil, nil, err
+		}
+
+		if err = api.summariseDimensions(ctx, currentVersion, versionUpdate, data); err != nil {
+			return nil, nil, nil, err
+		}
+
+		newETag, err := api.dataStore.Backend.UpdateVersion(ctx, currentVersion, versionUpdate, eTag)
+		if err != nil {
 			log.Event(ctx, "putVersion endpoint: failed to update version document", log.ERROR, log.Error(err), data)
 			return nil, nil, nil, err
 		}
+		versionUpdate.ETag = newETag
+		metrics.StateChanged(currentVersion.State, versionUpdate.State)
 		return currentDataset, currentVersion, versionUpdate, nil
 	}()
 
@@ -417,11 +725,38 @@ func (api *DatasetAPI) updateVersion(ctx context.Context, body io.ReadCloser, ve
 	return currentDataset, currentVersion, versionUpdate, nil
 }
 
+// validateCollection checks that the collection a version is being associated with exists and is still open for
+// changes, before storing the name of the collection in the version update
+func (api *DatasetAPI) validateCollection(ctx context.Context, currentVersion *models.Version, versionUpdate *models.Version, data log.Data) error {
+	if versionUpdate.State != models.AssociatedState {
+		return nil
+	}
+	if currentVersion.State == models.AssociatedState && currentVersion.CollectionID == versionUpdate.CollectionID {
+		return nil
+	}
+
+	data["collection_id"] = versionUpdate.CollectionID
+	collection, err := api.collections.GetCollection(ctx, versionUpdate.CollectionID)
+	if err != nil {
+		log.Event(ctx, "putVersion endpoint: failed to get the collection the version is being associated with", log.ERROR, log.Error(err), data)
+		return err
+	}
+
+	if !collection.IsEditable() {
+		data["approval_status"] = collection.ApprovalStatus
+		log.Event(ctx, "putVersion endpoint: the collection the version is being associated with is not editable", log.ERROR, log.Error(errs.ErrCollectionNotEditable), data)
+		return errs.ErrCollectionNotEditable
+	}
+
+	versionUpdate.CollectionName = collection.Name
+	return nil
+}
+
 func (api *DatasetAPI) publishVersion(ctx context.Context, currentDataset *models.DatasetUpdate, currentVersion *models.Version, versionDoc *models.Version, versionDetails VersionDetails) error {
 	data := versionDetails.baseLogData()
 	log.Event(ctx, "attempting to publish version", log.INFO, data)
 	err := func() error {
-		editionDoc, err := api.dataStore.Backend.GetEdition(versionDetails.datasetID, versionDetails.edition, "")
+		editionDoc, err := api.dataStore.Backend.GetEdition(ctx, versionDetails.datasetID, versionDetails.edition, "")
 		if err != nil {
 			log.Event(ctx, "putVersion endpoint: failed to find the edition we're trying to update", log.ERROR, log.Error(err), data)
 			return err
@@ -435,7 +770,7 @@ func (api *DatasetAPI) publishVersion(ctx context.Context, currentDataset *model
 
 		editionDoc.Current = editionDoc.Next
 
-		if err := api.dataStore.Backend.UpsertEdition(versionDetails.datasetID, versionDetails.edition, editionDoc); err != nil {
+		if err := api.dataStore.Backend.UpsertEdition(ctx, versionDetails.datasetID, versionDetails.edition, editionDoc, mongo.AnyETag); err != nil {
 			log.Event(ctx, "putVersion endpoint: failed to update edition during publishing", log.ERROR, log.Error(err), data)
 			return err
 		}
@@ -453,7 +788,7 @@ func (api *DatasetAPI) publishVersion(ctx context.Context, currentDataset *model
 		}
 
 		// Pass in newVersion variable to include relevant data needed for update on dataset API (e.g. links)
-		if err := api.publishDataset(ctx, currentDataset, versionDoc); err != nil {
+		if _, err := api.publishDataset(ctx, currentDataset, versionDoc, mongo.AnyETag); err != nil {
 			log.Event(ctx, "putVersion endpoint: failed to update dataset document once version state changes to publish", log.ERROR, log.Error(err), data)
 			return err
 		}
@@ -469,6 +804,11 @@ func (api *DatasetAPI) publishVersion(ctx context.Context, currentDataset *model
 			}
 		}
 
+		// the version is published by now, so web instances which miss the event pick it up as their cache expires
+		if err := api.eventEmitter.Emit(ctx, events.VersionPublished, versionDetails.datasetID, versionDetails.edition, versionDetails.version, versionDoc.ID); err != nil {
+			log.Event(ctx, "putVersion endpoint: failed to send version published event", log.ERROR, log.Error(err), data)
+		}
+
 		return nil
 	}()
 
@@ -476,15 +816,16 @@ func (api *DatasetAPI) publishVersion(ctx context.Context, currentDataset *model
 		return err
 	}
 
+	metrics.VersionsPublished.Inc()
 	log.Event(ctx, "publish version completed successfully", log.INFO, data)
 	return nil
 }
 
-func (api *DatasetAPI) associateVersion(ctx context.Context, currentVersion *models.Version, versionDoc *models.Version, versionDetails VersionDetails) error {
+func (api *DatasetAPI) associateVersion(ctx context.Context, currentDataset *models.DatasetUpdate, currentVersion *models.Version, versionDoc *models.Version, versionDetails VersionDetails) error {
 	data := versionDetails.baseLogData()
 
 	associateVersionErr := func() error {
-		if err := api.dataStore.Backend.UpdateDatasetWithAssociation(versionDetails.datasetID, versionDoc.State, versionDoc); err != nil {
+		if err := api.dataStore.Backend.UpdateDatasetWithAssociation(ctx, currentDataset, versionDoc.State, versionDoc); err != nil {
 			log.Event(ctx, "putVersion endpoint: failed to update dataset document after a version of a dataset has been associated with a collection", log.ERROR, log.Error(err), data)
 			return err
 		}
@@ -504,10 +845,35 @@ func (api *DatasetAPI) associateVersion(ctx context.Context, currentVersion *mod
 		return associateVersionErr
 	}
 
+	metrics.VersionsAssociated.Inc()
 	log.Event(ctx, "associate version completed successfully", log.INFO, data)
 	return associateVersionErr
 }
 
+// summariseDimensions stores the summaries of the dimension options on a version being associated with a collection,
+// for versions whose options were not summarised when their edition was confirmed
+func (api *DatasetAPI) summariseDimensions(ctx context.Context, currentVersion *models.Version, versionUpdate *models.Version, data log.Data) error {
+	if versionUpdate.State != models.AssociatedState || currentVersion.State == models.AssociatedState || models.DimensionsSummarised(currentVersion.Dimensions) {
+		return nil
+	}
+
+	// the import tasks of the version are only held on the instance
+	instance, err := api.dataStore.Backend.GetInstance(ctx, currentVersion.ID, mongo.AnyETag)
+	if err != nil {
+		log.Event(ctx, "putVersion endpoint: failed to get the instance of the version", log.ERROR, log.Error(err), data)
+		return err
+	}
+
+	summaries, err := api.dataStore.Backend.GetDimensionSummaries(ctx, currentVersion.ID)
+	if err != nil {
+		log.Event(ctx, "putVersion endpoint: failed to summarise the dimensions of the version", log.ERROR, log.Error(err), data)
+		return err
+	}
+
+	versionUpdate.Dimensions = models.SummariseDimensions(currentVersion.Dimensions, summaries, instance.ImportTasks)
+	return nil
+}
+
 func populateNewVersionDoc(currentVersion *models.Version, version *models.Version) *models.Version {
 
 	var alerts []models.Alert
@@ -635,6 +1001,10 @@ func handleVersionAPIErr(ctx context.Context, err error, w http.ResponseWriter,
 		status = http.StatusNotFound
 	case badRequest[err]:
 		status = http.StatusBadRequest
+	case forbidden[err]:
+		status = http.StatusForbidden
+	case conflict[err]:
+		status = http.StatusConflict
 	case internalServerErrWithMessage[err]:
 		status = http.StatusInternalServerError
 	case strings.HasPrefix(err.Error(), "missing mandatory fields:"):
@@ -653,5 +1023,5 @@ func handleVersionAPIErr(ctx context.Context, err error, w http.ResponseWriter,
 	}
 
 	log.Event(ctx, "request unsuccessful", log.ERROR, log.Error(err), data)
-	http.Error(w, err.Error(), status)
+	utils.WriteError(ctx, w, err, status)
 }
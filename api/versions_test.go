@@ -9,24 +9,26 @@ import (
 	"net/http/httptest"
 	"os"
 	"strconv"
-	"strings"
 	"testing"
 	"time"
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/events"
 	"github.com/ONSdigital/dp-dataset-api/mocks"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
 	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
 	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
 const (
-	versionPayload           = `{"instance_id":"a1b2c3","edition":"2017","license":"ONS","release_date":"2017-04-04"}`
-	versionAssociatedPayload = `{"instance_id":"a1b2c3","edition":"2017","license":"ONS","release_date":"2017-04-04","state":"associated","collection_id":"12345"}`
-	versionPublishedPayload  = `{"instance_id":"a1b2c3","edition":"2017","license":"ONS","release_date":"2017-04-04","state":"published","collection_id":"12345"}`
+	versionPayload           = `{"edition":"2017","release_date":"2017-04-04"}`
+	versionAssociatedPayload = `{"edition":"2017","release_date":"2017-04-04","state":"associated","collection_id":"12345"}`
+	versionPublishedPayload  = `{"edition":"2017","release_date":"2017-04-04","state":"published","collection_id":"12345"}`
 )
 
 func TestGetVersionsReturnsOK(t *testing.T) {
@@ -36,10 +38,10 @@ func TestGetVersionsReturnsOK(t *testing.T) {
 		w := httptest.NewRecorder()
 		results := []models.Version{}
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
-			CheckEditionExistsFunc: func(datasetID, editionID, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return nil
 			},
 			GetVersionsFunc: func(ctx context.Context, datasetID, editionID, state string, offset, limit int) ([]models.Version, int, error) {
@@ -71,7 +73,7 @@ func TestGetVersionsReturnsError(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123-456/editions/678/versions", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return errs.ErrInternalServer
 			},
 		}
@@ -91,7 +93,7 @@ func TestGetVersionsReturnsError(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123-456/editions/678/versions", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return errs.ErrDatasetNotFound
 			},
 		}
@@ -112,10 +114,10 @@ func TestGetVersionsReturnsError(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123-456/editions/678/versions", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
-			CheckEditionExistsFunc: func(datasetID, editionID, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return errs.ErrEditionNotFound
 			},
 		}
@@ -137,10 +139,10 @@ func TestGetVersionsReturnsError(t *testing.T) {
 		r.Header.Add("internal_token", "coffee")
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
-			CheckEditionExistsFunc: func(datasetID, editionID, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return nil
 			},
 			GetVersionsFunc: func(ctx context.Context, datasetID, editionID, state string, offset, limit int) ([]models.Version, int, error) {
@@ -164,10 +166,10 @@ func TestGetVersionsReturnsError(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123-456/editions/678/versions", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
-			CheckEditionExistsFunc: func(datasetID, editionID, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return nil
 			},
 			GetVersionsFunc: func(ctx context.Context, datasetID, editionID, state string, offset, limit int) ([]models.Version, int, error) {
@@ -194,10 +196,10 @@ func TestGetVersionsReturnsError(t *testing.T) {
 		version := models.Version{State: "gobbly-gook"}
 		items := []models.Version{version}
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
-			CheckEditionExistsFunc: func(datasetID, editionID, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return nil
 			},
 			GetVersionsFunc: func(ctx context.Context, datasetID, editionID, state string, offset, limit int) ([]models.Version, int, error) {
@@ -228,13 +230,13 @@ func TestGetVersionReturnsOK(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
-			CheckEditionExistsFunc: func(datasetID, editionID, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return nil
 			},
-			GetVersionFunc: func(datasetID, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
 				return &models.Version{
 					State: models.EditionConfirmedState,
 					Links: &models.VersionLinks{
@@ -268,7 +270,7 @@ func TestGetVersionReturnsError(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123-456/editions/678/versions/1", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return errs.ErrInternalServer
 			},
 		}
@@ -289,7 +291,7 @@ func TestGetVersionReturnsError(t *testing.T) {
 		r.Header.Add("internal_token", "coffee")
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return errs.ErrDatasetNotFound
 			},
 		}
@@ -314,10 +316,10 @@ func TestGetVersionReturnsError(t *testing.T) {
 		r.Header.Add("internal_token", "coffee")
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
-			CheckEditionExistsFunc: func(datasetID, editionID, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return errs.ErrEditionNotFound
 			},
 		}
@@ -342,13 +344,13 @@ func TestGetVersionReturnsError(t *testing.T) {
 		r.Header.Add("internal_token", "coffee")
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
-			CheckEditionExistsFunc: func(datasetID, editionID, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return nil
 			},
-			GetVersionFunc: func(datasetID, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
 				return nil, errs.ErrVersionNotFound
 			},
 		}
@@ -372,13 +374,13 @@ func TestGetVersionReturnsError(t *testing.T) {
 		r := httptest.NewRequest("GET", "http://localhost:22000/datasets/123-456/editions/678/versions/1", nil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
-			CheckEditionExistsFunc: func(datasetID, editionID, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return nil
 			},
-			GetVersionFunc: func(datasetID, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
 				return nil, errs.ErrVersionNotFound
 			},
 		}
@@ -463,13 +465,13 @@ func TestGetVersionReturnsError(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(datasetID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, datasetID, state string) error {
 				return nil
 			},
-			CheckEditionExistsFunc: func(datasetID, editionID, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, datasetID, editionID, state string) error {
 				return nil
 			},
-			GetVersionFunc: func(datasetID, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
 				return &models.Version{
 					State: "gobbly-gook",
 					Links: &models.VersionLinks{
@@ -514,13 +516,13 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(datasetID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
-			CheckEditionExistsFunc: func(string, string, string) error {
+			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 				return nil
 			},
-			GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{
 					ID: "789",
 					Links: &models.VersionLinks{
@@ -543,8 +545,8 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 					State:       models.EditionConfirmedState,
 				}, nil
 			},
-			UpdateVersionFunc: func(string, *models.Version) error {
-				return nil
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -586,21 +588,21 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(datasetID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
-			CheckEditionExistsFunc: func(string, string, string) error {
+			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 				return nil
 			},
-			GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{
 					State: models.AssociatedState,
 				}, nil
 			},
-			UpdateVersionFunc: func(string, *models.Version) error {
-				return nil
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
 			},
-			UpdateDatasetWithAssociationFunc: func(string, string, *models.Version) error {
+			UpdateDatasetWithAssociationFunc: func(context.Context, *models.DatasetUpdate, string, *models.Version) error {
 				return nil
 			},
 		}
@@ -646,21 +648,29 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(datasetID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
-			CheckEditionExistsFunc: func(string, string, string) error {
+			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 				return nil
 			},
-			GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{
-					State: models.EditionConfirmedState,
+					ID:         "789",
+					State:      models.EditionConfirmedState,
+					Dimensions: []models.Dimension{{Name: "age", Label: "Age"}},
 				}, nil
 			},
-			UpdateVersionFunc: func(string, *models.Version) error {
-				return nil
+			GetInstanceFunc: func(context.Context, string, string) (*models.Instance, error) {
+				return &models.Instance{InstanceID: "789"}, nil
 			},
-			UpdateDatasetWithAssociationFunc: func(string, string, *models.Version) error {
+			GetDimensionSummariesFunc: func(context.Context, string) ([]*models.DimensionSummary, error) {
+				return []*models.DimensionSummary{{Name: "age", NumberOfOptions: 3, FirstOption: "24"}}, nil
+			},
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
+			},
+			UpdateDatasetWithAssociationFunc: func(context.Context, *models.DatasetUpdate, string, *models.Version) error {
 				return nil
 			},
 		}
@@ -693,6 +703,15 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 0)
 		So(len(generatorMock.GenerateCalls()), ShouldEqual, 1)
 
+		Convey("then the dimensions of the version are summarised", func() {
+			So(mockedDataStore.GetDimensionSummariesCalls(), ShouldHaveLength, 1)
+			So(mockedDataStore.GetDimensionSummariesCalls()[0].InstanceID, ShouldEqual, "789")
+			numberOfOptions := 3
+			So(mockedDataStore.UpdateVersionCalls()[0].Version.Dimensions, ShouldResemble, []models.Dimension{
+				{Name: "age", Label: "Age", NumberOfOptions: &numberOfOptions, FirstOption: "24"},
+			})
+		})
+
 		Convey("then the request body has been drained", func() {
 			_, err := r.Body.Read(make([]byte, 1))
 			So(err, ShouldEqual, io.EOF)
@@ -713,10 +732,10 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			CheckEditionExistsFunc: func(string, string, string) error {
+			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 				return nil
 			},
-			GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{
 					ID: "789",
 					Links: &models.VersionLinks{
@@ -750,20 +769,20 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 					State: models.EditionConfirmedState,
 				}, nil
 			},
-			UpdateVersionFunc: func(string, *models.Version) error {
-				return nil
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
 			},
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{
 					ID:      "123",
 					Next:    &models.Dataset{Links: &models.DatasetLinks{}},
 					Current: &models.Dataset{Links: &models.DatasetLinks{}},
 				}, nil
 			},
-			UpsertDatasetFunc: func(string, *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate, string) error {
 				return nil
 			},
-			GetEditionFunc: func(string, string, string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(context.Context, string, string, string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					ID: "123",
 					Next: &models.Edition{
@@ -781,7 +800,7 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 					Current: &models.Edition{},
 				}, nil
 			},
-			UpsertEditionFunc: func(string, string, *models.EditionUpdate) error {
+			UpsertEditionFunc: func(context.Context, string, string, *models.EditionUpdate, string) error {
 				return nil
 			},
 			SetInstanceIsPublishedFunc: func(ctx context.Context, instanceID string) error {
@@ -807,10 +826,28 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 		So(len(mockedDataStore.UpdateDatasetWithAssociationCalls()), ShouldEqual, 0)
 		So(len(generatorMock.GenerateCalls()), ShouldEqual, 1)
 
+		emitter := api.eventEmitter.(*mocks.DatasetEventEmitterMock)
+		So(emitter.EmitCalls(), ShouldHaveLength, 1)
+		So(emitter.EmitCalls()[0].EventType, ShouldEqual, events.VersionPublished)
+		So(emitter.EmitCalls()[0].InstanceID, ShouldEqual, "789")
+
 		Convey("then the request body has been drained", func() {
 			_, err := r.Body.Read(make([]byte, 1))
 			So(err, ShouldEqual, io.EOF)
 		})
+
+		Convey("then a version published event which cannot be sent does not fail the request, as the version is published", func() {
+			api.eventEmitter = &mocks.DatasetEventEmitterMock{
+				EmitFunc: func(ctx context.Context, eventType, datasetID, edition, version, instanceID string) error {
+					return errors.New("kafka unavailable")
+				},
+			}
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, createRequestWithAuth("PUT", "http://localhost:22000/datasets/123/editions/2017/versions/1", bytes.NewBufferString(versionPublishedPayload)))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(len(mockedDataStore.SetInstanceIsPublishedCalls()), ShouldEqual, 2)
+		})
 	})
 
 	Convey("When version is already published and update includes downloads object only", t, func() {
@@ -840,6 +877,41 @@ func TestPutVersionReturnsSuccessfully(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("When the If-Match header matches the version eTag the version is updated and the new eTag is returned", t, func() {
+		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123/editions/2017/versions/1", bytes.NewBufferString(versionPayload))
+		r.Header.Set("If-Match", testETag)
+
+		w := httptest.NewRecorder()
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{}, nil
+			},
+			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
+				return nil
+			},
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return &models.Version{
+					ID:    "789",
+					State: models.EditionConfirmedState,
+					ETag:  testETag,
+				}, nil
+			},
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "newETag", nil
+			},
+		}
+
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusOK)
+		So(w.Header().Get("ETag"), ShouldEqual, `"newETag"`)
+		So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 1)
+		So(mockedDataStore.UpdateVersionCalls()[0].ETagSelector, ShouldEqual, testETag)
+	})
 }
 
 func updateVersionDownloadTest(r *http.Request) {
@@ -852,17 +924,17 @@ func updateVersionDownloadTest(r *http.Request) {
 	}
 
 	mockedDataStore := &storetest.StorerMock{
-		GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+		GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 			return &models.DatasetUpdate{
 				ID:      "123",
 				Next:    &models.Dataset{Links: &models.DatasetLinks{}},
 				Current: &models.Dataset{Links: &models.DatasetLinks{}},
 			}, nil
 		},
-		CheckEditionExistsFunc: func(string, string, string) error {
+		CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 			return nil
 		},
-		GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+		GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 			return &models.Version{
 				ID: "789",
 				Links: &models.VersionLinks{
@@ -895,10 +967,10 @@ func updateVersionDownloadTest(r *http.Request) {
 				State: models.PublishedState,
 			}, nil
 		},
-		UpdateVersionFunc: func(string, *models.Version) error {
-			return nil
+		UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+			return "", nil
 		},
-		GetEditionFunc: func(string, string, string) (*models.EditionUpdate, error) {
+		GetEditionFunc: func(context.Context, string, string, string) (*models.EditionUpdate, error) {
 			return &models.EditionUpdate{
 				ID: "123",
 				Next: &models.Edition{
@@ -936,19 +1008,25 @@ func TestPutVersionGenerateDownloadsError(t *testing.T) {
 		v.State = models.EditionConfirmedState
 
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(datasetID string, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error) {
 				return &v, nil
 			},
-			GetDatasetFunc: func(datasetID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
-			CheckEditionExistsFunc: func(ID string, editionID string, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, ID string, editionID string, state string) error {
 				return nil
 			},
-			UpdateVersionFunc: func(ID string, version *models.Version) error {
-				return nil
+			GetInstanceFunc: func(context.Context, string, string) (*models.Instance, error) {
+				return &models.Instance{}, nil
+			},
+			GetDimensionSummariesFunc: func(context.Context, string) ([]*models.DimensionSummary, error) {
+				return []*models.DimensionSummary{}, nil
+			},
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
 			},
-			UpdateDatasetWithAssociationFunc: func(ID string, state string, version *models.Version) error {
+			UpdateDatasetWithAssociationFunc: func(context.Context, *models.DatasetUpdate, string, *models.Version) error {
 				return nil
 			},
 		}
@@ -1018,17 +1096,17 @@ func TestPutEmptyVersion(t *testing.T) {
 
 	Convey("given an existing version with empty downloads", t, func() {
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(datasetID string, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error) {
 				return &v, nil
 			},
-			GetDatasetFunc: func(datasetID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
-			CheckEditionExistsFunc: func(ID string, editionID string, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, ID string, editionID string, state string) error {
 				return nil
 			},
-			UpdateVersionFunc: func(ID string, version *models.Version) error {
-				return nil
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -1058,18 +1136,18 @@ func TestPutEmptyVersion(t *testing.T) {
 
 	Convey("given an existing version with a xls download already exists", t, func() {
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(datasetID string, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error) {
 				v.Downloads = xlsDownload
 				return &v, nil
 			},
-			GetDatasetFunc: func(datasetID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
-			CheckEditionExistsFunc: func(ID string, editionID string, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, ID string, editionID string, state string) error {
 				return nil
 			},
-			UpdateVersionFunc: func(ID string, version *models.Version) error {
-				return nil
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -1133,10 +1211,10 @@ func TestPutVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{State: models.AssociatedState}, nil
 			},
-			GetDatasetFunc: func(datasetID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
 		}
@@ -1151,7 +1229,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 
 		So(datasetPermissions.Required.Calls, ShouldEqual, 1)
 		So(permissions.Required.Calls, ShouldEqual, 0)
-		So(len(mockedDataStore.GetVersionCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.GetVersionCalls()), ShouldEqual, 0)
 		So(len(mockedDataStore.GetDatasetCalls()), ShouldEqual, 0)
 		So(len(generatorMock.GenerateCalls()), ShouldEqual, 0)
 
@@ -1174,10 +1252,10 @@ func TestPutVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return nil, errs.ErrInternalServer
 			},
-			GetDatasetFunc: func(datasetID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
 		}
@@ -1215,13 +1293,13 @@ func TestPutVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{}, errs.ErrInvalidVersion
 			},
-			GetDatasetFunc: func(datasetID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			CheckEditionExistsFunc: func(string, string, string) error {
+			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 				return nil
 			},
 		}
@@ -1330,13 +1408,13 @@ func TestPutVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{}, errs.ErrVersionNotFound
 			},
-			GetDatasetFunc: func(datasetID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
 				return nil, errs.ErrDatasetNotFound
 			},
-			CheckEditionExistsFunc: func(string, string, string) error {
+			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 				return nil
 			},
 		}
@@ -1375,13 +1453,13 @@ func TestPutVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{}, errs.ErrVersionNotFound
 			},
-			GetDatasetFunc: func(datasetID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
-			CheckEditionExistsFunc: func(string, string, string) error {
+			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 				return errs.ErrEditionNotFound
 			},
 		}
@@ -1420,17 +1498,17 @@ func TestPutVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{}, errs.ErrVersionNotFound
 			},
-			GetDatasetFunc: func(datasetID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
-			CheckEditionExistsFunc: func(string, string, string) error {
+			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 				return nil
 			},
-			UpdateVersionFunc: func(string, *models.Version) error {
-				return nil
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -1469,7 +1547,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 		So(err, ShouldBeNil)
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{
 					State: "associated",
 				}, nil
@@ -1508,12 +1586,12 @@ func TestPutVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{
 					State: models.PublishedState,
 				}, nil
 			},
-			GetDatasetFunc: func(datasetID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
 		}
@@ -1524,7 +1602,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 
 		api.Router.ServeHTTP(w, r)
 		So(w.Code, ShouldEqual, http.StatusForbidden)
-		So(w.Body.String(), ShouldEqual, "unable to update version as it has been published\n")
+		So(w.Body.String(), ShouldContainSubstring, errs.ErrUpdatePublishedVersionForbidden.Error())
 
 		So(len(mockedDataStore.GetVersionCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.GetDatasetCalls()), ShouldEqual, 0)
@@ -1545,22 +1623,22 @@ func TestPutVersionReturnsError(t *testing.T) {
 		}
 
 		var b string
-		b = `{"instance_id":"a1b2c3","edition":"2017","license":"ONS","release_date":"2017-04-04","state":"associated"}`
+		b = `{"edition":"2017","release_date":"2017-04-04","state":"associated"}`
 		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123/editions/2017/versions/1", bytes.NewBufferString(b))
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{State: "associated"}, nil
 			},
-			GetDatasetFunc: func(datasetID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, datasetID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
-			CheckEditionExistsFunc: func(string, string, string) error {
+			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 				return nil
 			},
-			UpdateVersionFunc: func(string, *models.Version) error {
-				return nil
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
 			},
 		}
 
@@ -1570,7 +1648,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 		api.Router.ServeHTTP(w, r)
 
 		So(w.Code, ShouldEqual, http.StatusBadRequest)
-		So(w.Body.String(), ShouldEqual, "missing collection_id for association between version and a collection\n")
+		So(w.Body.String(), ShouldEqual, `{"title":"Bad Request","status":400,"code":"bad_request","detail":"missing collection_id for association between version and a collection"}`)
 
 		So(datasetPermissions.Required.Calls, ShouldEqual, 1)
 		So(permissions.Required.Calls, ShouldEqual, 0)
@@ -1600,10 +1678,10 @@ func TestPutVersionReturnsError(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			CheckEditionExistsFunc: func(string, string, string) error {
+			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
 				return nil
 			},
-			GetVersionFunc: func(string, string, int, string) (*models.Version, error) {
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
 				return &models.Version{
 					ID: "789",
 					Links: &models.VersionLinks{
@@ -1637,20 +1715,20 @@ func TestPutVersionReturnsError(t *testing.T) {
 					State: models.EditionConfirmedState,
 				}, nil
 			},
-			UpdateVersionFunc: func(string, *models.Version) error {
-				return nil
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
 			},
-			GetDatasetFunc: func(string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{
 					ID:      "123",
 					Next:    &models.Dataset{Links: &models.DatasetLinks{}},
 					Current: &models.Dataset{Links: &models.DatasetLinks{}},
 				}, nil
 			},
-			UpsertDatasetFunc: func(string, *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(context.Context, string, *models.DatasetUpdate, string) error {
 				return nil
 			},
-			GetEditionFunc: func(string, string, string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(context.Context, string, string, string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					ID: "123",
 					Next: &models.Edition{
@@ -1669,7 +1747,7 @@ func TestPutVersionReturnsError(t *testing.T) {
 					Current: &models.Edition{},
 				}, nil
 			},
-			UpsertEditionFunc: func(string, string, *models.EditionUpdate) error {
+			UpsertEditionFunc: func(context.Context, string, string, *models.EditionUpdate, string) error {
 				return nil
 			},
 			SetInstanceIsPublishedFunc: func(ctx context.Context, instanceID string) error {
@@ -1677,11 +1755,11 @@ func TestPutVersionReturnsError(t *testing.T) {
 			},
 		}
 
-		mockedDataStore.GetVersion("789", "2017", 1, "")
-		mockedDataStore.GetEdition("123", "2017", "")
-		mockedDataStore.UpdateVersion("a1b2c3", &models.Version{})
-		mockedDataStore.GetDataset("123")
-		mockedDataStore.UpsertDataset("123", &models.DatasetUpdate{Next: &models.Dataset{}})
+		mockedDataStore.GetVersion(context.Background(), "789", "2017", 1, "")
+		mockedDataStore.GetEdition(context.Background(), "123", "2017", "")
+		mockedDataStore.UpdateVersion(context.Background(), &models.Version{ID: "a1b2c3"}, &models.Version{}, mongo.AnyETag)
+		mockedDataStore.GetDataset(context.Background(), "123")
+		mockedDataStore.UpsertDataset(context.Background(), "123", &models.DatasetUpdate{Next: &models.Dataset{}}, mongo.AnyETag)
 
 		datasetPermissions := getAuthorisationHandlerMock()
 		permissions := getAuthorisationHandlerMock()
@@ -1707,6 +1785,38 @@ func TestPutVersionReturnsError(t *testing.T) {
 			So(err, ShouldEqual, io.EOF)
 		})
 	})
+
+	Convey("When the If-Match header does not match the version eTag return status conflict", t, func() {
+		r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123/editions/2017/versions/1", bytes.NewBufferString(versionPayload))
+		r.Header.Set("If-Match", "wrongETag")
+
+		w := httptest.NewRecorder()
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(context.Context, string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{}, nil
+			},
+			CheckEditionExistsFunc: func(context.Context, string, string, string) error {
+				return nil
+			},
+			GetVersionFunc: func(context.Context, string, string, int, string) (*models.Version, error) {
+				return &models.Version{
+					ID:    "789",
+					State: models.EditionConfirmedState,
+					ETag:  testETag,
+				}, nil
+			},
+		}
+
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusConflict)
+		So(w.Body.String(), ShouldContainSubstring, errs.ErrVersionConflict.Error())
+		So(len(mockedDataStore.GetVersionCalls()), ShouldEqual, 2)
+		So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 0)
+	})
 }
 
 func TestCreateNewVersionDoc(t *testing.T) {
@@ -1847,7 +1957,7 @@ func TestDetachVersionReturnOK(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(datasetID, editionID, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, datasetID, editionID, state string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					ID:      "test",
 					Current: &models.Edition{},
@@ -1858,19 +1968,19 @@ func TestDetachVersionReturnOK(t *testing.T) {
 							LatestVersion: &models.LinkObject{
 								ID: "1"}}}}, nil
 			},
-			GetVersionFunc: func(datasetID string, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error) {
 				return &models.Version{}, nil
 			},
-			GetDatasetFunc: func(ID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Current: &models.Dataset{}}, nil
 			},
-			UpdateVersionFunc: func(ID string, version *models.Version) error {
-				return nil
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "newETag", nil
 			},
-			UpsertEditionFunc: func(datasetID string, edition string, editionDoc *models.EditionUpdate) error {
+			UpsertEditionFunc: func(ctx context.Context, datasetID string, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error {
 				return nil
 			},
-			UpsertDatasetFunc: func(ID string, datasetDoc *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
 				return nil
 			},
 		}
@@ -1892,6 +2002,25 @@ func TestDetachVersionReturnOK(t *testing.T) {
 		So(len(mockedDataStore.UpsertEditionCalls()), ShouldEqual, 1)
 		So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 1)
 		So(len(generatorMock.GenerateCalls()), ShouldEqual, 0)
+
+		emitter := api.eventEmitter.(*mocks.DatasetEventEmitterMock)
+		So(emitter.EmitCalls(), ShouldHaveLength, 1)
+		So(emitter.EmitCalls()[0].EventType, ShouldEqual, events.VersionDetached)
+		So(emitter.EmitCalls()[0].DatasetID, ShouldEqual, "123")
+
+		Convey("then a version detached event which cannot be sent does not fail the request, as the version is detached", func() {
+			api.eventEmitter = &mocks.DatasetEventEmitterMock{
+				EmitFunc: func(ctx context.Context, eventType, datasetID, edition, version, instanceID string) error {
+					return errors.New("kafka unavailable")
+				},
+			}
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123/editions/2017/versions/1", nil))
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get("ETag"), ShouldEqual, `"newETag"`)
+			So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 2)
+		})
 	})
 
 	Convey("A successful detach request against a version of a unpublished dataset returns 200 OK response.", t, func() {
@@ -1905,7 +2034,7 @@ func TestDetachVersionReturnOK(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(datasetID, editionID, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, datasetID, editionID, state string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					ID:      "test",
 					Current: &models.Edition{},
@@ -1916,19 +2045,19 @@ func TestDetachVersionReturnOK(t *testing.T) {
 							LatestVersion: &models.LinkObject{
 								ID: "1"}}}}, nil
 			},
-			GetVersionFunc: func(datasetID string, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error) {
 				return &models.Version{}, nil
 			},
-			GetDatasetFunc: func(ID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
-			UpdateVersionFunc: func(ID string, version *models.Version) error {
-				return nil
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
 			},
-			UpsertEditionFunc: func(datasetID string, edition string, editionDoc *models.EditionUpdate) error {
+			UpsertEditionFunc: func(ctx context.Context, datasetID string, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error {
 				return nil
 			},
-			UpsertDatasetFunc: func(ID string, datasetDoc *models.DatasetUpdate) error {
+			UpsertDatasetFunc: func(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
 				return nil
 			},
 		}
@@ -1953,6 +2082,32 @@ func TestDetachVersionReturnOK(t *testing.T) {
 	})
 }
 
+func TestDetachVersionFromEditionWithoutVersions(t *testing.T) {
+	t.Parallel()
+	Convey("Given an edition without a latest version", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetEditionFunc: func(ctx context.Context, datasetID, editionID, state string) (*models.EditionUpdate, error) {
+				return &models.EditionUpdate{ID: "edition-1", Next: &models.Edition{State: models.EditionConfirmedState}}, nil
+			},
+		}
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, getAuthorisationHandlerMock(), getAuthorisationHandlerMock())
+
+		Convey("When a version is detached from the edition", func() {
+			r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123/editions/2017/versions/1", nil)
+			r = mux.SetURLVars(r, map[string]string{"dataset_id": "123", "edition": "2017", "version": "1"})
+			w := httptest.NewRecorder()
+			api.detachVersion(w, r)
+
+			Convey("Then the version is not found, and nothing is detached", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrVersionNotFound.Error())
+				So(mockedDataStore.GetVersionCalls(), ShouldHaveLength, 0)
+				So(mockedDataStore.UpdateVersionCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
 func TestDetachVersionReturnsError(t *testing.T) {
 
 	// TODO conditional test for feature flagged functionality. Will need tidying up eventually.
@@ -1975,7 +2130,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(datasetID, editionID, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, datasetID, editionID, state string) (*models.EditionUpdate, error) {
 				return nil, errs.ErrInternalServer
 			},
 		}
@@ -2007,7 +2162,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(datasetID, editionID, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, datasetID, editionID, state string) (*models.EditionUpdate, error) {
 				return nil, errs.ErrEditionNotFound
 			},
 		}
@@ -2039,7 +2194,7 @@ func TestDetachVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(datasetID, editionID, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, datasetID, editionID, state string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					Next: &models.Edition{
 						State: models.EditionConfirmedState,
@@ -2074,13 +2229,13 @@ func TestDetachVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(datasetID, editionID, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, datasetID, editionID, state string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					Next: &models.Edition{
 						State: models.PublishedState,
 						Links: &models.EditionUpdateLinks{LatestVersion: &models.LinkObject{ID: "1"}}}}, nil
 			},
-			GetVersionFunc: func(datasetID, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
 				return &models.Version{}, nil
 			},
 		}
@@ -2112,13 +2267,13 @@ func TestDetachVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(datasetID, editionID, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, datasetID, editionID, state string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					Next: &models.Edition{
 						State: models.EditionConfirmedState,
 						Links: &models.EditionUpdateLinks{LatestVersion: &models.LinkObject{ID: "1"}}}}, nil
 			},
-			GetVersionFunc: func(datasetID, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
 				return nil, errs.ErrVersionNotFound
 			},
 		}
@@ -2150,22 +2305,22 @@ func TestDetachVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(datasetID, editionID, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, datasetID, editionID, state string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					Next: &models.Edition{
 						State: models.EditionConfirmedState,
 						Links: &models.EditionUpdateLinks{LatestVersion: &models.LinkObject{ID: "1"}}}}, nil
 			},
 
-			GetDatasetFunc: func(ID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{}, nil
 			},
 
-			GetVersionFunc: func(datasetID, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
 				return &models.Version{}, nil
 			},
-			UpdateVersionFunc: func(ID string, version *models.Version) error {
-				return errs.ErrInternalServer
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", errs.ErrInternalServer
 			},
 		}
 
@@ -2197,24 +2352,24 @@ func TestDetachVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(datasetID, editionID, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, datasetID, editionID, state string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					Next: &models.Edition{
 						State: models.EditionConfirmedState,
 						Links: &models.EditionUpdateLinks{LatestVersion: &models.LinkObject{ID: "1"}}}}, nil
 			},
-			GetVersionFunc: func(datasetID, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
 				return &models.Version{}, nil
 			},
 
-			GetDatasetFunc: func(ID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{Current: &models.Dataset{}}, nil
 			},
 
-			UpdateVersionFunc: func(ID string, version *models.Version) error {
-				return nil
+			UpdateVersionFunc: func(context.Context, *models.Version, *models.Version, string) (string, error) {
+				return "", nil
 			},
-			UpsertEditionFunc: func(datasetID string, edition string, editionDoc *models.EditionUpdate) error {
+			UpsertEditionFunc: func(ctx context.Context, datasetID string, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error {
 				return errs.ErrInternalServer
 			},
 		}
@@ -2248,13 +2403,13 @@ func TestDetachVersionReturnsError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(datasetID, editionID, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, datasetID, editionID, state string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					Next: &models.Edition{
 						State: models.EditionConfirmedState,
 						Links: &models.EditionUpdateLinks{LatestVersion: &models.LinkObject{ID: "1"}}}}, nil
 			},
-			GetVersionFunc: func(datasetID, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
 				return nil, errs.ErrInvalidVersion
 			},
 		}
@@ -2331,9 +2486,333 @@ func TestDetachVersionReturnsError(t *testing.T) {
 		So(len(generatorMock.GenerateCalls()), ShouldEqual, 0)
 	})
 
+	Convey("When the If-Match header does not match the version eTag return status conflict", t, func() {
+		r := createRequestWithAuth("DELETE", "http://localhost:22000/datasets/123/editions/2017/versions/1", nil)
+		r.Header.Set("If-Match", "wrongETag")
+
+		w := httptest.NewRecorder()
+		mockedDataStore := &storetest.StorerMock{
+			GetEditionFunc: func(ctx context.Context, datasetID, editionID, state string) (*models.EditionUpdate, error) {
+				return &models.EditionUpdate{
+					ID: "test",
+					Next: &models.Edition{
+						State: models.EditionConfirmedState,
+						Links: &models.EditionUpdateLinks{
+							LatestVersion: &models.LinkObject{ID: "1"},
+						},
+					},
+				}, nil
+			},
+			GetVersionFunc: func(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error) {
+				return &models.Version{ETag: testETag}, nil
+			},
+		}
+
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+		api.Router.ServeHTTP(w, r)
+
+		So(w.Code, ShouldEqual, http.StatusConflict)
+		So(len(mockedDataStore.GetVersionCalls()), ShouldEqual, 1)
+		So(len(mockedDataStore.GetDatasetCalls()), ShouldEqual, 0)
+		So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 0)
+	})
+}
+
+const withdrawalPayload = `{"alert":{"date":"2017-10-10","description":"A correction to an observation","type":"correction"}}`
+
+func withdrawRequest(body string) *http.Request {
+	return createRequestWithAuth("POST", "http://localhost:22000/datasets/123/editions/2017/versions/2/withdraw", bytes.NewBufferString(body))
+}
+
+func TestWithdrawVersion(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a published version that is the latest version of its edition and dataset", t, func() {
+		latestVersion := func() *models.LinkObject {
+			return &models.LinkObject{ID: "2", HRef: "http://localhost:22000/datasets/123/editions/2017/versions/2"}
+		}
+		mockedDataStore := &storetest.StorerMock{
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{
+					ID:      "123",
+					ETag:    "datasetETag",
+					Current: &models.Dataset{State: models.PublishedState, Links: &models.DatasetLinks{LatestVersion: latestVersion()}},
+					Next:    &models.Dataset{State: models.PublishedState, Links: &models.DatasetLinks{LatestVersion: latestVersion()}},
+				}, nil
+			},
+			GetEditionFunc: func(ctx context.Context, ID, editionID, state string) (*models.EditionUpdate, error) {
+				return &models.EditionUpdate{
+					ID:      "456",
+					ETag:    "editionETag",
+					Current: &models.Edition{State: models.PublishedState, Links: &models.EditionUpdateLinks{LatestVersion: latestVersion()}},
+					Next:    &models.Edition{State: models.PublishedState, Links: &models.EditionUpdateLinks{LatestVersion: latestVersion()}},
+				}, nil
+			},
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
+				return &models.Version{
+					ID:      "789",
+					Edition: "2017",
+					Version: 2,
+					State:   models.PublishedState,
+					ETag:    testETag,
+					Links:   &models.VersionLinks{Version: latestVersion()},
+				}, nil
+			},
+			GetPreviousPublishedVersionFunc: func(ctx context.Context, datasetID, editionID string, version int) (*models.Version, error) {
+				return &models.Version{
+					ID:      "788",
+					Edition: "2017",
+					Version: 1,
+					State:   models.PublishedState,
+					Links: &models.VersionLinks{
+						Version: &models.LinkObject{ID: "1", HRef: "http://localhost:22000/datasets/123/editions/2017/versions/1"},
+					},
+				}, nil
+			},
+			UpdateVersionFunc: func(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (string, error) {
+				return "newETag", nil
+			},
+			UpsertEditionFunc: func(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error {
+				return nil
+			},
+			UpsertDatasetFunc: func(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
+				return nil
+			},
+		}
+		emitterMock := &mocks.DatasetEventEmitterMock{
+			EmitFunc: func(ctx context.Context, eventType, datasetID, edition, version, instanceID string) error {
+				return nil
+			},
+		}
+
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+		api.eventEmitter = emitterMock
+
+		Convey("When the version is withdrawn with a correction alert", func() {
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, withdrawRequest(withdrawalPayload))
+
+			Convey("Then the version is moved to the withdrawn state with the alert recorded against it", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("ETag"), ShouldEqual, `"newETag"`)
+				So(datasetPermissions.Required.Calls, ShouldEqual, 1)
+
+				So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 1)
+				update := mockedDataStore.UpdateVersionCalls()[0].Version
+				So(update.State, ShouldEqual, models.WithdrawnState)
+				So(*update.Alerts, ShouldResemble, []models.Alert{
+					{Date: "2017-10-10", Description: "A correction to an observation", Type: models.CorrectionAlertType},
+				})
+			})
+
+			Convey("Then the latest version of the edition and dataset is re-pointed to the previous published version", func() {
+				So(len(mockedDataStore.UpsertEditionCalls()), ShouldEqual, 1)
+				editionDoc := mockedDataStore.UpsertEditionCalls()[0].EditionDoc
+				So(editionDoc.Current.Links.LatestVersion.ID, ShouldEqual, "1")
+				So(editionDoc.Next.Links.LatestVersion.ID, ShouldEqual, "1")
+
+				So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 1)
+				datasetDoc := mockedDataStore.UpsertDatasetCalls()[0].DatasetDoc
+				So(datasetDoc.Current.Links.LatestVersion.HRef, ShouldEqual, "http://localhost:22000/datasets/123/editions/2017/versions/1")
+				So(datasetDoc.Next.Links.LatestVersion.HRef, ShouldEqual, "http://localhost:22000/datasets/123/editions/2017/versions/1")
+			})
+
+			Convey("Then the version, edition and dataset are only updated if they have not been changed since they were read", func() {
+				So(mockedDataStore.UpdateVersionCalls()[0].ETagSelector, ShouldEqual, testETag)
+				So(mockedDataStore.UpsertEditionCalls()[0].ETagSelector, ShouldEqual, "editionETag")
+				So(mockedDataStore.UpsertDatasetCalls()[0].ETagSelector, ShouldEqual, "datasetETag")
+			})
+
+			Convey("Then the version withdrawn and latest version changed events are sent", func() {
+				So(len(emitterMock.EmitCalls()), ShouldEqual, 2)
+				So(emitterMock.EmitCalls()[0].EventType, ShouldEqual, events.VersionWithdrawn)
+				So(emitterMock.EmitCalls()[0].Version, ShouldEqual, "2")
+				So(emitterMock.EmitCalls()[0].InstanceID, ShouldEqual, "789")
+				So(emitterMock.EmitCalls()[1].EventType, ShouldEqual, events.LatestVersionChanged)
+				So(emitterMock.EmitCalls()[1].Version, ShouldEqual, "1")
+				So(emitterMock.EmitCalls()[1].InstanceID, ShouldEqual, "788")
+			})
+		})
+
+		Convey("When there is no previous published version", func() {
+			mockedDataStore.GetPreviousPublishedVersionFunc = func(ctx context.Context, datasetID, editionID string, version int) (*models.Version, error) {
+				return nil, errs.ErrVersionNotFound
+			}
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, withdrawRequest(withdrawalPayload))
+
+			Convey("Then the version is withdrawn and remains the latest version", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 1)
+				So(len(mockedDataStore.UpsertEditionCalls()), ShouldEqual, 0)
+				So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 0)
+				So(len(emitterMock.EmitCalls()), ShouldEqual, 1)
+				So(emitterMock.EmitCalls()[0].EventType, ShouldEqual, events.VersionWithdrawn)
+			})
+		})
+
+		Convey("When the version is not the latest version of the dataset", func() {
+			mockedDataStore.GetDatasetFunc = func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				latest := &models.LinkObject{ID: "1", HRef: "http://localhost:22000/datasets/123/editions/2018/versions/1"}
+				return &models.DatasetUpdate{
+					ID:      "123",
+					Current: &models.Dataset{State: models.PublishedState, Links: &models.DatasetLinks{LatestVersion: latest}},
+				}, nil
+			}
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, withdrawRequest(withdrawalPayload))
+
+			Convey("Then only the latest version of the edition is re-pointed", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(len(mockedDataStore.UpsertEditionCalls()), ShouldEqual, 1)
+				So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 0)
+				So(len(emitterMock.EmitCalls()), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When the request does not contain a correction alert", func() {
+			for _, body := range []string{
+				`{}`,
+				`{"alert":{"description":"A correction to an observation","type":"alert"}}`,
+				`{"alert":{"type":"correction"}}`,
+			} {
+				w := httptest.NewRecorder()
+				api.Router.ServeHTTP(w, withdrawRequest(body))
+
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrWithdrawalAlertInvalid.Error())
+			}
+
+			Convey("Then the version is not withdrawn", func() {
+				So(len(mockedDataStore.GetVersionCalls()), ShouldEqual, 0)
+				So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 0)
+				So(len(emitterMock.EmitCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the request body is not valid json", func() {
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, withdrawRequest(`{`))
+
+			Convey("Then a bad request status is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrUnableToParseJSON.Error())
+				So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the version has not been published", func() {
+			mockedDataStore.GetVersionFunc = func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
+				return &models.Version{State: models.AssociatedState, ETag: testETag}, nil
+			}
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, withdrawRequest(withdrawalPayload))
+
+			Convey("Then a forbidden status is returned and the version is not withdrawn", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrWithdrawVersionForbidden.Error())
+				So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 0)
+				So(len(emitterMock.EmitCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the version has already been withdrawn", func() {
+			mockedDataStore.GetVersionFunc = func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
+				return &models.Version{State: models.WithdrawnState, ETag: testETag}, nil
+			}
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, withdrawRequest(withdrawalPayload))
+
+			Convey("Then a forbidden status is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the If-Match header does not match the version eTag", func() {
+			r := withdrawRequest(withdrawalPayload)
+			r.Header.Set("If-Match", "wrongETag")
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a conflict status is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the version does not exist", func() {
+			mockedDataStore.GetVersionFunc = func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
+				return nil, errs.ErrVersionNotFound
+			}
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, withdrawRequest(withdrawalPayload))
+
+			Convey("Then a not found status is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the edition is changed by another request while the version is withdrawn", func() {
+			mockedDataStore.UpsertEditionFunc = func(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error {
+				return errs.ErrEditionConflict
+			}
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, withdrawRequest(withdrawalPayload))
+
+			Convey("Then a conflict status is returned, and no events are sent", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(len(mockedDataStore.UpsertDatasetCalls()), ShouldEqual, 0)
+				So(len(emitterMock.EmitCalls()), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When the event cannot be sent", func() {
+			emitterMock.EmitFunc = func(ctx context.Context, eventType, datasetID, edition, version, instanceID string) error {
+				return errors.New("kafka is broken")
+			}
+			w := httptest.NewRecorder()
+			api.Router.ServeHTTP(w, withdrawRequest(withdrawalPayload))
+
+			Convey("Then the version is still withdrawn", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 1)
+				So(len(emitterMock.EmitCalls()), ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given a version that has been withdrawn", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
+				return &models.Version{State: models.WithdrawnState}, nil
+			},
+		}
+
+		datasetPermissions := getAuthorisationHandlerMock()
+		permissions := getAuthorisationHandlerMock()
+		api := GetAPIWithMocks(mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+
+		Convey("When the version is updated", func() {
+			w := httptest.NewRecorder()
+			r := createRequestWithAuth("PUT", "http://localhost:22000/datasets/123/editions/2017/versions/2", bytes.NewBufferString(versionPayload))
+			api.Router.ServeHTTP(w, r)
+
+			Convey("Then a forbidden status is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.String(), ShouldContainSubstring, "unable to update version as it has been withdrawn")
+				So(len(mockedDataStore.UpdateVersionCalls()), ShouldEqual, 0)
+			})
+		})
+	})
 }
 
 func assertInternalServerErr(w *httptest.ResponseRecorder) {
 	So(w.Code, ShouldEqual, http.StatusInternalServerError)
-	So(strings.TrimSpace(w.Body.String()), ShouldEqual, errs.ErrInternalServer.Error())
+	So(w.Body.String(), ShouldEqual, `{"title":"Internal Server Error","status":500,"code":"internal_error","detail":"internal error"}`)
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/globalsign/mgo/bson"
 
+	"github.com/ONSdigital/dp-dataset-api/collection"
 	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/mocks"
 	"github.com/ONSdigital/dp-dataset-api/models"
@@ -75,7 +76,7 @@ func TestWebSubnetDatasetEndpoint(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetDatasetFunc: func(ID string) (*models.DatasetUpdate, error) {
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
 				return &models.DatasetUpdate{
 					Current: current,
 					Next:    next,
@@ -106,7 +107,7 @@ func TestWebSubnetEditionsEndpoint(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(ID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, ID, state string) error {
 				datasetSearchState = state
 				return nil
 			},
@@ -136,11 +137,11 @@ func TestWebSubnetEditionEndpoint(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(ID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, ID, state string) error {
 				datasetSearchState = state
 				return nil
 			},
-			GetEditionFunc: func(ID, editionID, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, ID, editionID, state string) (*models.EditionUpdate, error) {
 				editionSearchState = state
 				return edition, nil
 			},
@@ -164,11 +165,11 @@ func TestWebSubnetVersionsEndpoint(t *testing.T) {
 		var versionSearchState, editionSearchState, datasetSearchState string
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(ID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, ID, state string) error {
 				datasetSearchState = state
 				return nil
 			},
-			CheckEditionExistsFunc: func(ID, editionID, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, ID, editionID, state string) error {
 				editionSearchState = state
 				return nil
 			},
@@ -197,15 +198,15 @@ func TestWebSubnetVersionEndpoint(t *testing.T) {
 		var versionSearchState, editionSearchState, datasetSearchState string
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			CheckDatasetExistsFunc: func(ID, state string) error {
+			CheckDatasetExistsFunc: func(ctx context.Context, ID, state string) error {
 				datasetSearchState = state
 				return nil
 			},
-			CheckEditionExistsFunc: func(ID, editionID, state string) error {
+			CheckEditionExistsFunc: func(ctx context.Context, ID, editionID, state string) error {
 				editionSearchState = state
 				return nil
 			},
-			GetVersionFunc: func(id string, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, id string, editionID string, version int, state string) (*models.Version, error) {
 				versionSearchState = state
 				return &models.Version{ID: "124", State: models.PublishedState,
 					Links: &models.VersionLinks{
@@ -233,14 +234,14 @@ func TestWebSubnetDimensionsEndpoint(t *testing.T) {
 		var versionSearchState string
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(id string, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, id string, editionID string, version int, state string) (*models.Version, error) {
 				versionSearchState = state
 				return &models.Version{ID: "124", State: models.PublishedState,
 					Links: &models.VersionLinks{
 						Version: &models.LinkObject{},
 						Self:    &models.LinkObject{}}}, nil
 			},
-			GetDimensionsFunc: func(datasetID string, versionID string) ([]bson.M, error) {
+			GetDimensionsFunc: func(ctx context.Context, datasetID string, versionID string) ([]bson.M, error) {
 				return []bson.M{}, nil
 			},
 		}
@@ -262,7 +263,7 @@ func TestWebSubnetDimensionOptionsEndpoint(t *testing.T) {
 		var versionSearchState string
 		w := httptest.NewRecorder()
 		mockedDataStore := &storetest.StorerMock{
-			GetVersionFunc: func(id string, editionID string, version int, state string) (*models.Version, error) {
+			GetVersionFunc: func(ctx context.Context, id string, editionID string, version int, state string) (*models.Version, error) {
 				versionSearchState = state
 				return &models.Version{ID: "124", State: models.PublishedState,
 					Links: &models.VersionLinks{
@@ -341,5 +342,5 @@ func GetWebAPIWithMocks(ctx context.Context, mockedDataStore store.Storer, mocke
 	cfg.DatasetAPIURL = host
 	cfg.EnablePrivateEndpoints = false
 
-	return Setup(ctx, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, mockedGeneratedDownloads, datasetPermissions, permissions)
+	return Setup(ctx, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, mockedGeneratedDownloads, &mocks.ImportRetrierMock{}, emitterMock(), collection.NewLocalChecker(), collection.NewLocalCollections(), nil, datasetPermissions, permissions)
 }
@@ -0,0 +1,98 @@
+package apierrors
+
+import "errors"
+
+// CodeMap maps each error of the API to the code returned to callers alongside its message. Codes must not be changed
+// once released, as callers match on them rather than on messages.
+var CodeMap = map[error]string{
+	ErrAddDatasetAlreadyExists:           "dataset_already_exists",
+	ErrAddEditionAlreadyExists:           "edition_already_exists",
+	ErrAddUpdateEditionBadRequest:        "invalid_json",
+	ErrDatasetTypeInvalid:                "invalid_dataset_type",
+	ErrTypeMismatch:                      "dataset_type_mismatch",
+	ErrAddUpdateDatasetBadRequest:        "invalid_json",
+	ErrConflictUpdatingInstance:          "instance_conflict",
+	ErrDatasetNotFound:                   "dataset_not_found",
+	ErrDeleteDatasetNotFound:             "dataset_not_found",
+	ErrDeletePublishedDatasetForbidden:   "dataset_published",
+	ErrDeletePublishedEditionForbidden:   "edition_published",
+	ErrDimensionNodeNotFound:             "dimension_node_not_found",
+	ErrDimensionNotFound:                 "dimension_not_found",
+	ErrDimensionOptionNotFound:           "dimension_option_not_found",
+	ErrDimensionsNotFound:                "dimensions_not_found",
+	ErrEditionNotFound:                   "edition_not_found",
+	ErrEditionsNotFound:                  "editions_not_found",
+	ErrIncorrectStateToDetach:            "version_not_detachable",
+	ErrIndexOutOfRange:                   "index_out_of_range",
+	ErrInstanceNotFound:                  "instance_not_found",
+	ErrInstanceConflict:                  "instance_conflict",
+	ErrDatasetConflict:                   "dataset_conflict",
+	ErrEditionConflict:                   "edition_conflict",
+	ErrVersionConflict:                   "version_conflict",
+	ErrInternalServer:                    "internal_error",
+	ErrInsertedObservationsInvalidSyntax: "invalid_inserted_observations",
+	ErrInvalidQueryParameter:             "invalid_query_parameter",
+	ErrInvalidBody:                       "invalid_body",
+	ErrTooManyQueryParameters:            "too_many_query_parameters",
+	ErrTooManyBatchItems:                 "too_many_batch_items",
+	ErrMetadataVersionNotFound:           "version_not_found",
+	ErrMissingJobProperties:              "missing_job_properties",
+	ErrMissingParameters:                 "missing_parameters",
+	ErrMissingVersionHeadersOrDimensions: "missing_version_headers_or_dimensions",
+	ErrNoAuthHeader:                      "missing_auth_header",
+	ErrObservationsNotFound:              "observations_not_found",
+	ErrResourcePublished:                 "resource_published",
+	ErrResourceState:                     "invalid_resource_state",
+	ErrTooManyWildcards:                  "too_many_wildcards",
+	ErrUnableToParseJSON:                 "invalid_json",
+	ErrUnableToReadMessage:               "unreadable_body",
+	ErrUnauthorised:                      "unauthorised",
+	ErrVersionMissingState:               "missing_version_state",
+	ErrVersionNotFound:                   "version_not_found",
+	ErrInvalidVersion:                    "invalid_version",
+	ErrVersionAlreadyExists:              "version_already_exists",
+	ErrNotFound:                          "not_found",
+	ErrCollectionNotFound:                "collection_not_found",
+	ErrCollectionNotEditable:             "collection_not_editable",
+	ErrDeleteJobNotFound:                 "delete_job_not_found",
+	ErrRestoreWindowExpired:              "restore_window_expired",
+	ErrWithdrawalAlertInvalid:            "invalid_withdrawal_alert",
+	ErrWithdrawVersionForbidden:          "version_not_withdrawable",
+	ErrUpdatePublishedVersionForbidden:   "version_published",
+	ErrUpdateWithdrawnVersionForbidden:   "version_withdrawn",
+	ErrTopicNotFound:                     "topic_not_found",
+	ErrAddTopicAlreadyExists:             "topic_already_exists",
+	ErrTopicParentInvalid:                "invalid_topic_parent",
+	ErrDeleteTopicForbidden:              "topic_not_deletable",
+	ErrDatasetThemeInvalid:               "invalid_dataset_theme",
+	ErrRelatedDatasetInvalid:             "invalid_related_dataset",
+	ErrLinkReportNotFound:                "link_report_not_found",
+	ErrCodeListNotFound:                  "code_list_not_found",
+	ErrCodeNotFound:                      "code_not_found",
+
+	ErrExpectedResourceStateOfCreated:          "expected_state_created",
+	ErrExpectedResourceStateOfSubmitted:        "expected_state_submitted",
+	ErrExpectedResourceStateOfCompleted:        "expected_state_completed",
+	ErrExpectedResourceStateOfEditionConfirmed: "expected_state_edition_confirmed",
+	ErrExpectedResourceStateOfAssociated:       "expected_state_associated",
+	ErrImportTasksNotRetryable:                 "import_tasks_not_retryable",
+	ErrNoFailedImportTasks:                     "no_failed_import_tasks",
+}
+
+// Code returns the code of the error, or of the first error it wraps that has one. An empty string is returned if none
+// of them have a code.
+func Code(err error) string {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if code, ok := CodeMap[err]; ok {
+			return code
+		}
+
+		switch e := err.(type) {
+		case ErrInvalidPatch:
+			return "invalid_patch"
+		case interface{ Code() string }:
+			return e.Code()
+		}
+	}
+	return ""
+}
@@ -16,6 +16,8 @@ func (e ErrInvalidPatch) Error() string {
 // A list of error messages for Dataset API
 var (
 	ErrAddDatasetAlreadyExists           = errors.New("forbidden - dataset already exists")
+	ErrAddEditionAlreadyExists           = errors.New("forbidden - edition already exists")
+	ErrAddUpdateEditionBadRequest        = errors.New("failed to parse json body")
 	ErrDatasetTypeInvalid                = errors.New("invalid dataset type")
 	ErrTypeMismatch                      = errors.New("type mismatch")
 	ErrAddUpdateDatasetBadRequest        = errors.New("failed to parse json body")
@@ -23,6 +25,7 @@ var (
 	ErrDatasetNotFound                   = errors.New("dataset not found")
 	ErrDeleteDatasetNotFound             = errors.New("dataset not found")
 	ErrDeletePublishedDatasetForbidden   = errors.New("a published dataset cannot be deleted")
+	ErrDeletePublishedEditionForbidden   = errors.New("an edition with published versions cannot be deleted")
 	ErrDimensionNodeNotFound             = errors.New("dimension node not found")
 	ErrDimensionNotFound                 = errors.New("dimension not found")
 	ErrDimensionOptionNotFound           = errors.New("dimension option not found")
@@ -33,11 +36,15 @@ var (
 	ErrIndexOutOfRange                   = errors.New("index out of range")
 	ErrInstanceNotFound                  = errors.New("instance not found")
 	ErrInstanceConflict                  = errors.New("instance does not match the expected eTag")
+	ErrDatasetConflict                   = errors.New("dataset does not match the expected eTag")
+	ErrEditionConflict                   = errors.New("edition does not match the expected eTag")
+	ErrVersionConflict                   = errors.New("version does not match the expected eTag")
 	ErrInternalServer                    = errors.New("internal error")
 	ErrInsertedObservationsInvalidSyntax = errors.New("inserted observation request parameter not an integer")
 	ErrInvalidQueryParameter             = errors.New("invalid query parameter")
 	ErrInvalidBody                       = errors.New("invalid request body")
 	ErrTooManyQueryParameters            = errors.New("too many query parameters have been provided")
+	ErrTooManyBatchItems                 = errors.New("too many items have been requested in the batch")
 	ErrMetadataVersionNotFound           = errors.New("version not found")
 	ErrMissingJobProperties              = errors.New("missing job properties")
 	ErrMissingParameters                 = errors.New("missing properties in JSON")
@@ -55,12 +62,33 @@ var (
 	ErrInvalidVersion                    = errors.New("invalid version requested")
 	ErrVersionAlreadyExists              = errors.New("an unpublished version of this dataset already exists")
 	ErrNotFound                          = errors.New("not found")
+	ErrCollectionNotFound                = errors.New("collection not found")
+	ErrCollectionNotEditable             = errors.New("collection has already been approved or published")
+	ErrDeleteJobNotFound                 = errors.New("delete job not found")
+	ErrRestoreWindowExpired              = errors.New("the dataset was deleted too long ago to be restored")
+	ErrPurgeLocked                       = errors.New("deleted datasets are being purged by another instance")
+	ErrLinkCheckLocked                   = errors.New("the links of published datasets are being checked by another instance")
+	ErrWithdrawalAlertInvalid            = errors.New("a version can only be withdrawn with a correction alert describing why")
+	ErrWithdrawVersionForbidden          = errors.New("only a published version can be withdrawn")
+	ErrUpdatePublishedVersionForbidden   = errors.New("unable to update version as it has been published")
+	ErrUpdateWithdrawnVersionForbidden   = errors.New("unable to update version as it has been withdrawn")
+	ErrTopicNotFound                     = errors.New("topic not found")
+	ErrAddTopicAlreadyExists             = errors.New("forbidden - topic already exists")
+	ErrTopicParentInvalid                = errors.New("the parent topic does not exist or is below the topic in the taxonomy")
+	ErrDeleteTopicForbidden              = errors.New("a topic with sub topics or datasets cannot be deleted")
+	ErrDatasetThemeInvalid               = errors.New("the theme of the dataset is not an existing topic")
+	ErrRelatedDatasetInvalid             = errors.New("a related dataset of this api is not an existing dataset other than the dataset itself")
+	ErrLinkReportNotFound                = errors.New("link report not found")
+	ErrCodeListNotFound                  = errors.New("code list not found")
+	ErrCodeNotFound                      = errors.New("code not found in code list")
 
 	ErrExpectedResourceStateOfCreated          = errors.New("unable to update resource, expected resource to have a state of created")
 	ErrExpectedResourceStateOfSubmitted        = errors.New("unable to update resource, expected resource to have a state of submitted")
 	ErrExpectedResourceStateOfCompleted        = errors.New("unable to update resource, expected resource to have a state of completed")
 	ErrExpectedResourceStateOfEditionConfirmed = errors.New("unable to update resource, expected resource to have a state of edition-confirmed")
 	ErrExpectedResourceStateOfAssociated       = errors.New("unable to update resource, expected resource to have a state of associated")
+	ErrImportTasksNotRetryable                 = errors.New("unable to retry import tasks, expected instance to have a state of submitted, completed or failed")
+	ErrNoFailedImportTasks                     = errors.New("unable to retry import tasks, neither the instance nor the requested import tasks have failed")
 
 	NotFoundMap = map[error]bool{
 		ErrDatasetNotFound:         true,
@@ -71,6 +99,7 @@ var (
 		ErrEditionNotFound:         true,
 		ErrInstanceNotFound:        true,
 		ErrVersionNotFound:         true,
+		ErrDeleteJobNotFound:       true,
 	}
 
 	BadRequestMap = map[error]bool{
@@ -78,6 +107,7 @@ var (
 		ErrInvalidBody:                       true,
 		ErrInvalidQueryParameter:             true,
 		ErrTooManyQueryParameters:            true,
+		ErrTooManyBatchItems:                 true,
 		ErrMissingJobProperties:              true,
 		ErrMissingParameters:                 true,
 		ErrUnableToParseJSON:                 true,
@@ -85,11 +115,17 @@ var (
 		ErrTypeMismatch:                      true,
 		ErrDatasetTypeInvalid:                true,
 		ErrInvalidVersion:                    true,
+		ErrCollectionNotFound:                true,
+		ErrCodeListNotFound:                  true,
+		ErrCodeNotFound:                      true,
 	}
 
 	ConflictRequestMap = map[error]bool{
 		ErrConflictUpdatingInstance: true,
 		ErrInstanceConflict:         true,
+		ErrDatasetConflict:          true,
+		ErrVersionConflict:          true,
+		ErrCollectionNotEditable:    true,
 	}
 
 	ForbiddenMap = map[error]bool{
@@ -98,6 +134,8 @@ var (
 		ErrExpectedResourceStateOfCompleted:        true,
 		ErrExpectedResourceStateOfEditionConfirmed: true,
 		ErrExpectedResourceStateOfAssociated:       true,
+		ErrImportTasksNotRetryable:                 true,
+		ErrNoFailedImportTasks:                     true,
 
 		ErrResourcePublished: true,
 	}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"container/list"
+	"strings"
+	"time"
+)
+
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// lru holds encoded values up to a total size in bytes, evicting the least recently used values to make room for new
+// ones. It is not safe for concurrent use.
+type lru struct {
+	maxBytes int64
+	bytes    int64
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+func newLRU(maxBytes int64) *lru {
+	return &lru{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// get returns the value of the key, unless it has expired. Values with a zero expiry never expire.
+func (l *lru) get(key string, now time.Time) ([]byte, bool) {
+	element, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := element.Value.(*entry)
+	if !e.expiresAt.IsZero() && !now.Before(e.expiresAt) {
+		l.remove(element)
+		return nil, false
+	}
+
+	l.order.MoveToFront(element)
+	return e.value, true
+}
+
+// add sets the value of the key, evicting the least recently used values until the values fit in the size limit.
+// Values larger than the limit are not added.
+func (l *lru) add(key string, value []byte, expiresAt time.Time) {
+	if element, ok := l.entries[key]; ok {
+		l.remove(element)
+	}
+	if int64(len(value)) > l.maxBytes {
+		return
+	}
+
+	l.entries[key] = l.order.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+	l.bytes += int64(len(value))
+
+	for l.bytes > l.maxBytes {
+		l.remove(l.order.Back())
+	}
+}
+
+// removePrefix removes the values of every key starting with the prefix
+func (l *lru) removePrefix(prefix string) {
+	for key, element := range l.entries {
+		if strings.HasPrefix(key, prefix) {
+			l.remove(element)
+		}
+	}
+}
+
+func (l *lru) clear() {
+	l.order.Init()
+	l.entries = make(map[string]*list.Element)
+	l.bytes = 0
+}
+
+func (l *lru) remove(element *list.Element) {
+	e := l.order.Remove(element).(*entry)
+	delete(l.entries, e.key)
+	l.bytes -= int64(len(e.value))
+}
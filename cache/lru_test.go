@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLRU(t *testing.T) {
+	now := time.Now()
+
+	Convey("Given an lru holding 10 bytes", t, func() {
+		l := newLRU(10)
+		l.add("a", []byte("1234"), time.Time{})
+		l.add("b", []byte("1234"), time.Time{})
+
+		Convey("When a value is added beyond the limit, then the least recently used value is evicted", func() {
+			_, ok := l.get("a", now)
+			So(ok, ShouldBeTrue)
+
+			l.add("c", []byte("1234"), time.Time{})
+
+			_, ok = l.get("b", now)
+			So(ok, ShouldBeFalse)
+			value, ok := l.get("a", now)
+			So(ok, ShouldBeTrue)
+			So(string(value), ShouldEqual, "1234")
+			So(l.bytes, ShouldEqual, 8)
+		})
+
+		Convey("When a value larger than the limit is added, then it is not held", func() {
+			l.add("c", []byte("12345678901"), time.Time{})
+			_, ok := l.get("c", now)
+			So(ok, ShouldBeFalse)
+			So(l.bytes, ShouldEqual, 8)
+		})
+
+		Convey("When a value expires, then it is no longer returned", func() {
+			l.add("c", []byte("12"), now.Add(time.Minute))
+			_, ok := l.get("c", now)
+			So(ok, ShouldBeTrue)
+			_, ok = l.get("c", now.Add(time.Minute))
+			So(ok, ShouldBeFalse)
+			So(l.bytes, ShouldEqual, 8)
+		})
+
+		Convey("When the values with a prefix are removed, then the other values are kept", func() {
+			l.add("ab", []byte("12"), time.Time{})
+			l.removePrefix("a")
+			_, ok := l.get("a", now)
+			So(ok, ShouldBeFalse)
+			_, ok = l.get("b", now)
+			So(ok, ShouldBeTrue)
+			So(l.bytes, ShouldEqual, 4)
+		})
+
+		Convey("When the lru is cleared, then no values are held", func() {
+			l.clear()
+			_, ok := l.get("b", now)
+			So(ok, ShouldBeFalse)
+			So(l.bytes, ShouldEqual, 0)
+		})
+	})
+}
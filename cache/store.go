@@ -0,0 +1,283 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/events"
+	"github.com/ONSdigital/dp-dataset-api/metrics"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/store"
+	"github.com/ONSdigital/dp-dataset-api/tracing"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/globalsign/mgo/bson"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// The prefixes of the keys of each kind of document cached. Every key ends with a separator, so that the prefix of
+// one dataset never matches the keys of another dataset whose ID it starts.
+const (
+	datasetsPrefix = "datasets/"
+	editionsPrefix = "editions/"
+	versionsPrefix = "versions/"
+	optionsPrefix  = "options/"
+)
+
+// MongoDB caches the public reads of the embedded mongo store, so that the datasets, versions and dimension options
+// requested most often are served without a round trip to mongo. Documents are invalidated by the dataset events of
+// their dataset, and expire after their TTLs in case an event is missed, or a document invalidated is cached again
+// from a secondary which has not caught up with the change. Published versions and their dimension options only change
+// when the version is withdrawn, so are given a longer TTL than datasets and editions. Values are cached in their
+// encoded form, so that each caller decodes its own copy which it is free to change.
+//
+// The cache is only used by web instances, whose reads are all public.
+type MongoDB struct {
+	store.MongoDB
+	cfg config.ResponseCacheConfig
+	now func() time.Time
+
+	mutex      sync.Mutex
+	entries    *lru
+	generation uint64
+}
+
+// New creates a cache of the public reads of the mongo store, within the limits of the config
+func New(db store.MongoDB, cfg config.ResponseCacheConfig) *MongoDB {
+	return &MongoDB{
+		MongoDB: db,
+		cfg:     cfg,
+		now:     time.Now,
+		entries: newLRU(cfg.MaxBytes),
+	}
+}
+
+// GetDataset returns the cached dataset, reading it from the store when it is not cached or has expired
+func (c *MongoDB) GetDataset(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+	key := datasetsPrefix + ID + "/"
+	var dataset models.DatasetUpdate
+	found, generation := c.lookup(ctx, "GetDataset", key, &dataset)
+	if found {
+		return &dataset, nil
+	}
+
+	result, err := c.MongoDB.GetDataset(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+	c.add(ctx, key, generation, result, c.cfg.DatasetTTL)
+	return result, nil
+}
+
+// GetEdition returns the cached edition, reading it from the store when it is not cached or has expired
+func (c *MongoDB) GetEdition(ctx context.Context, ID, editionID, state string) (*models.EditionUpdate, error) {
+	key := editionsPrefix + ID + "/" + editionID + "/" + state + "/"
+	var edition models.EditionUpdate
+	found, generation := c.lookup(ctx, "GetEdition", key, &edition)
+	if found {
+		return &edition, nil
+	}
+
+	result, err := c.MongoDB.GetEdition(ctx, ID, editionID, state)
+	if err != nil {
+		return nil, err
+	}
+	c.add(ctx, key, generation, result, c.cfg.EditionTTL)
+	return result, nil
+}
+
+// GetVersion returns the cached version, reading it from the store when it is not cached or has expired. Only
+// published versions are cached.
+func (c *MongoDB) GetVersion(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
+	key := versionKey(datasetID, editionID, strconv.Itoa(version)) + state + "/"
+	var versionDoc models.Version
+	found, generation := c.lookup(ctx, "GetVersion", key, &versionDoc)
+	if found {
+		return &versionDoc, nil
+	}
+
+	result, err := c.MongoDB.GetVersion(ctx, datasetID, editionID, version, state)
+	if err != nil {
+		return nil, err
+	}
+	if result.State == models.PublishedState {
+		c.add(ctx, key, generation, result, c.cfg.VersionTTL)
+	}
+	return result, nil
+}
+
+// optionsPage is the form a page of dimension options is cached in
+type optionsPage struct {
+	Options    []*models.PublicDimensionOption `bson:"options"`
+	TotalCount int                             `bson:"total_count"`
+}
+
+// GetDimensionOptions returns the cached page of the options of a dimension, reading it from the store when it is not
+// cached or has expired. Only the options of published versions are cached.
+func (c *MongoDB) GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset, limit int) ([]*models.PublicDimensionOption, int, error) {
+	key := optionsKey(version.ID, dimension) + strconv.Itoa(offset) + "/" + strconv.Itoa(limit) + "/"
+	var page optionsPage
+	found, generation := c.lookup(ctx, "GetDimensionOptions", key, &page)
+	if found {
+		return page.Options, page.TotalCount, nil
+	}
+
+	options, totalCount, err := c.MongoDB.GetDimensionOptions(ctx, version, dimension, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	if version.State == models.PublishedState {
+		c.add(ctx, key, generation, optionsPage{Options: options, TotalCount: totalCount}, c.cfg.VersionTTL)
+	}
+	return options, totalCount, nil
+}
+
+// GetDimensionOptionsFromIDs returns the cached options of a dimension with the IDs, reading them from the store when
+// they are not cached or have expired. Only the options of published versions are cached.
+func (c *MongoDB) GetDimensionOptionsFromIDs(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
+	key := optionsKey(version.ID, dimension) + "ids=" + strings.Join(ids, ",") + "/"
+	var page optionsPage
+	found, generation := c.lookup(ctx, "GetDimensionOptionsFromIDs", key, &page)
+	if found {
+		return page.Options, page.TotalCount, nil
+	}
+
+	options, totalCount, err := c.MongoDB.GetDimensionOptionsFromIDs(ctx, version, dimension, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	if version.State == models.PublishedState {
+		c.add(ctx, key, generation, optionsPage{Options: options, TotalCount: totalCount}, c.cfg.VersionTTL)
+	}
+	return options, totalCount, nil
+}
+
+// Invalidate removes the cached documents changed by the dataset event. Every event removes the dataset and its
+// editions, while events about a version also remove the version and its dimension options.
+func (c *MongoDB) Invalidate(event events.Event) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	defer c.changed()
+
+	if event.Type == events.CacheFlushed {
+		c.entries.clear()
+		return
+	}
+
+	c.entries.removePrefix(datasetsPrefix + event.DatasetID + "/")
+	c.entries.removePrefix(editionsPrefix + event.DatasetID + "/")
+	if event.Edition != "" && event.Version != "" {
+		c.entries.removePrefix(versionKey(event.DatasetID, event.Edition, event.Version))
+	}
+	if event.InstanceID != "" {
+		c.entries.removePrefix(optionsPrefix + event.InstanceID + "/")
+	}
+}
+
+// Flush removes every cached document
+func (c *MongoDB) Flush() {
+	c.Invalidate(events.Event{Type: events.CacheFlushed})
+}
+
+// Listen invalidates the cached documents changed by each dataset event received, until the events channel is closed
+// or the context is done. Events are received from kafka, or from a local channel in tests.
+func (c *MongoDB) Listen(ctx context.Context, datasetEvents <-chan events.Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-datasetEvents:
+			if !ok {
+				return
+			}
+			c.invalidate(ctx, event)
+		}
+	}
+}
+
+// invalidate handles a dataset event received from kafka, in a span continuing the trace of the request which sent it
+func (c *MongoDB) invalidate(ctx context.Context, event events.Event) {
+	ctx, span := tracing.StartConsumerSpan(ctx, "cache.Invalidate", event.TraceParent,
+		attribute.String("event_type", event.Type),
+		attribute.String("dataset_id", event.DatasetID),
+	)
+	defer span.End()
+
+	log.Event(ctx, "invalidating the response cache", log.INFO, log.Data{
+		"type":        event.Type,
+		"dataset_id":  event.DatasetID,
+		"edition":     event.Edition,
+		"version":     event.Version,
+		"instance_id": event.InstanceID,
+	})
+	c.Invalidate(event)
+}
+
+// lookup decodes the cached value of the key into the target, returning whether it was found. The generation of the
+// cache is returned, so that a value read from the store once it is not found is only added if no documents were
+// invalidated while it was being read.
+func (c *MongoDB) lookup(ctx context.Context, operation, key string, target interface{}) (bool, uint64) {
+	c.mutex.Lock()
+	value, found := c.entries.get(key, c.now())
+	generation := c.generation
+	c.mutex.Unlock()
+
+	if found {
+		if err := bson.Unmarshal(value, target); err != nil {
+			log.Event(ctx, "failed to decode cached document", log.ERROR, log.Error(err), log.Data{"key": key})
+			found = false
+		}
+	}
+
+	result := "miss"
+	if found {
+		result = "hit"
+	}
+	metrics.ResponseCacheLookups.WithLabelValues(operation, result).Inc()
+	return found, generation
+}
+
+// add caches the encoded value of the key for the ttl, or until it is evicted or invalidated where the ttl is zero.
+// Values larger than the maximum size of an entry are not cached.
+func (c *MongoDB) add(ctx context.Context, key string, generation uint64, value interface{}, ttl time.Duration) {
+	encoded, err := bson.Marshal(value)
+	if err != nil {
+		log.Event(ctx, "failed to encode document to cache", log.ERROR, log.Error(err), log.Data{"key": key})
+		return
+	}
+	if int64(len(encoded)) > c.cfg.MaxEntryBytes {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+	c.entries.add(key, encoded, expiresAt)
+	metrics.ResponseCacheBytes.Set(float64(c.entries.bytes))
+}
+
+// changed moves the cache on to its next generation, once documents have been invalidated. The caller must hold the
+// mutex.
+func (c *MongoDB) changed() {
+	c.generation++
+	metrics.ResponseCacheBytes.Set(float64(c.entries.bytes))
+}
+
+func versionKey(datasetID, edition, version string) string {
+	return versionsPrefix + datasetID + "/" + edition + "/" + version + "/"
+}
+
+func optionsKey(instanceID, dimension string) string {
+	return optionsPrefix + instanceID + "/" + dimension + "/"
+}
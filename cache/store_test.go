@@ -0,0 +1,205 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/events"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testConfig = config.ResponseCacheConfig{
+	Enabled:       true,
+	MaxBytes:      1024 * 1024,
+	MaxEntryBytes: 1024,
+	DatasetTTL:    time.Minute,
+	EditionTTL:    time.Minute,
+	VersionTTL:    10 * time.Minute,
+}
+
+func TestGetDataset(t *testing.T) {
+	Convey("Given a cache of a mongo store", t, func() {
+		mongoMock := &storetest.MongoDBMock{
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				if ID == "unknown" {
+					return nil, errs.ErrDatasetNotFound
+				}
+				return &models.DatasetUpdate{ID: ID, Current: &models.Dataset{Title: "CPIH"}}, nil
+			},
+		}
+		c := New(mongoMock, testConfig)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+
+		Convey("When a dataset is read twice", func() {
+			first, err := c.GetDataset(context.Background(), "cpih01")
+			So(err, ShouldBeNil)
+			first.Current.Title = "changed by the caller"
+			second, err := c.GetDataset(context.Background(), "cpih01")
+
+			Convey("Then the second read is served from the cache, as a copy of the dataset read from the store", func() {
+				So(err, ShouldBeNil)
+				So(mongoMock.GetDatasetCalls(), ShouldHaveLength, 1)
+				So(second.ID, ShouldEqual, "cpih01")
+				So(second.Current.Title, ShouldEqual, "CPIH")
+			})
+
+			Convey("And the dataset is read from the store again once it expires", func() {
+				now = now.Add(time.Minute)
+				_, err := c.GetDataset(context.Background(), "cpih01")
+				So(err, ShouldBeNil)
+				So(mongoMock.GetDatasetCalls(), ShouldHaveLength, 2)
+			})
+
+			Convey("And the dataset is read from the store again once an event about it is received", func() {
+				c.Invalidate(events.Event{Type: events.VersionPublished, DatasetID: "cpih01", Edition: "time-series", Version: "2", InstanceID: "inst2"})
+				_, err := c.GetDataset(context.Background(), "cpih01")
+				So(err, ShouldBeNil)
+				So(mongoMock.GetDatasetCalls(), ShouldHaveLength, 2)
+			})
+
+			Convey("And the dataset stays cached when an event about a dataset with a longer ID is received", func() {
+				c.Invalidate(events.Event{Type: events.VersionPublished, DatasetID: "cpih01a"})
+				_, err := c.GetDataset(context.Background(), "cpih01")
+				So(err, ShouldBeNil)
+				So(mongoMock.GetDatasetCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a dataset is not found, then the error is not cached", func() {
+			_, err := c.GetDataset(context.Background(), "unknown")
+			So(err, ShouldEqual, errs.ErrDatasetNotFound)
+			_, err = c.GetDataset(context.Background(), "unknown")
+			So(err, ShouldEqual, errs.ErrDatasetNotFound)
+			So(mongoMock.GetDatasetCalls(), ShouldHaveLength, 2)
+		})
+
+		Convey("When documents are invalidated while a dataset is being read, then the dataset is not cached", func() {
+			mongoMock.GetDatasetFunc = func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				c.Flush()
+				return &models.DatasetUpdate{ID: ID}, nil
+			}
+			_, err := c.GetDataset(context.Background(), "cpih01")
+			So(err, ShouldBeNil)
+			_, err = c.GetDataset(context.Background(), "cpih01")
+			So(err, ShouldBeNil)
+			So(mongoMock.GetDatasetCalls(), ShouldHaveLength, 2)
+		})
+	})
+}
+
+func TestGetVersion(t *testing.T) {
+	Convey("Given a cache of a mongo store holding published and unpublished versions", t, func() {
+		mongoMock := &storetest.MongoDBMock{
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
+				if version == 1 {
+					return &models.Version{ID: "inst1", Version: version, State: models.PublishedState}, nil
+				}
+				return &models.Version{ID: "inst2", Version: version, State: models.AssociatedState}, nil
+			},
+		}
+		c := New(mongoMock, testConfig)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+
+		Convey("When a published version is read twice within its TTL, then it is cached", func() {
+			_, err := c.GetVersion(context.Background(), "cpih01", "time-series", 1, models.PublishedState)
+			So(err, ShouldBeNil)
+			now = now.Add(5 * time.Minute)
+			version, err := c.GetVersion(context.Background(), "cpih01", "time-series", 1, models.PublishedState)
+			So(err, ShouldBeNil)
+			So(version.ID, ShouldEqual, "inst1")
+			So(mongoMock.GetVersionCalls(), ShouldHaveLength, 1)
+
+			Convey("And it is read from the store again once it expires, in case a withdrawal was missed", func() {
+				now = now.Add(6 * time.Minute)
+				_, err := c.GetVersion(context.Background(), "cpih01", "time-series", 1, models.PublishedState)
+				So(err, ShouldBeNil)
+				So(mongoMock.GetVersionCalls(), ShouldHaveLength, 2)
+			})
+
+			Convey("And it is read from the store again once it is withdrawn", func() {
+				c.Invalidate(events.Event{Type: events.VersionWithdrawn, DatasetID: "cpih01", Edition: "time-series", Version: "1", InstanceID: "inst1"})
+				_, err := c.GetVersion(context.Background(), "cpih01", "time-series", 1, models.PublishedState)
+				So(err, ShouldBeNil)
+				So(mongoMock.GetVersionCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When an unpublished version is read twice, then it is not cached", func() {
+			_, err := c.GetVersion(context.Background(), "cpih01", "time-series", 2, "")
+			So(err, ShouldBeNil)
+			_, err = c.GetVersion(context.Background(), "cpih01", "time-series", 2, "")
+			So(err, ShouldBeNil)
+			So(mongoMock.GetVersionCalls(), ShouldHaveLength, 2)
+		})
+	})
+}
+
+func TestGetDimensionOptions(t *testing.T) {
+	Convey("Given a cache of a mongo store", t, func() {
+		mongoMock := &storetest.MongoDBMock{
+			GetDimensionOptionsFunc: func(ctx context.Context, version *models.Version, dimension string, offset, limit int) ([]*models.PublicDimensionOption, int, error) {
+				return []*models.PublicDimensionOption{{Name: dimension, Option: "K02000001"}}, 5, nil
+			},
+		}
+		c := New(mongoMock, testConfig)
+		published := &models.Version{ID: "inst1", State: models.PublishedState}
+
+		Convey("When a page of the options of a published version is read twice, then it is cached", func() {
+			c.GetDimensionOptions(context.Background(), published, "geography", 0, 1)
+			options, totalCount, err := c.GetDimensionOptions(context.Background(), published, "geography", 0, 1)
+			So(err, ShouldBeNil)
+			So(options, ShouldResemble, []*models.PublicDimensionOption{{Name: "geography", Option: "K02000001"}})
+			So(totalCount, ShouldEqual, 5)
+			So(mongoMock.GetDimensionOptionsCalls(), ShouldHaveLength, 1)
+
+			Convey("And another page is read from the store", func() {
+				c.GetDimensionOptions(context.Background(), published, "geography", 1, 1)
+				So(mongoMock.GetDimensionOptionsCalls(), ShouldHaveLength, 2)
+			})
+
+			Convey("And the page is read from the store again once the cache is flushed", func() {
+				c.Flush()
+				c.GetDimensionOptions(context.Background(), published, "geography", 0, 1)
+				So(mongoMock.GetDimensionOptionsCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When a page is larger than the maximum size of an entry, then it is not cached", func() {
+			c.cfg.MaxEntryBytes = 10
+			c.GetDimensionOptions(context.Background(), published, "geography", 0, 1)
+			c.GetDimensionOptions(context.Background(), published, "geography", 0, 1)
+			So(mongoMock.GetDimensionOptionsCalls(), ShouldHaveLength, 2)
+		})
+	})
+}
+
+func TestListen(t *testing.T) {
+	Convey("Given a cache holding a dataset, and a local channel of dataset events", t, func() {
+		mongoMock := &storetest.MongoDBMock{
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				return &models.DatasetUpdate{ID: ID}, nil
+			},
+		}
+		c := New(mongoMock, testConfig)
+		c.GetDataset(context.Background(), "cpih01")
+		datasetEvents := make(chan events.Event, 1)
+
+		Convey("When an event about the dataset is received", func() {
+			datasetEvents <- events.Event{Type: events.VersionDetached, DatasetID: "cpih01"}
+			close(datasetEvents)
+			c.Listen(context.Background(), datasetEvents)
+
+			Convey("Then the dataset is invalidated", func() {
+				c.GetDataset(context.Background(), "cpih01")
+				So(mongoMock.GetDatasetCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+}
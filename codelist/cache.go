@@ -0,0 +1,145 @@
+package codelist
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+)
+
+// CodeLists provides the code lists and codes that dimensions and their options are checked against
+type CodeLists interface {
+	GetCodeList(ctx context.Context, codeListID string) (*CodeList, error)
+	GetCode(ctx context.Context, codeListID, codeID string) (*Code, error)
+}
+
+type cacheKey struct {
+	codeListID string
+	codeID     string
+}
+
+type cacheEntry struct {
+	codeList  *CodeList
+	code      *Code
+	err       error
+	expiresAt time.Time
+}
+
+// CachedCodeLists caches the results of other CodeLists, as the options of an instance are added one request at a
+// time and mostly share a handful of code lists. Code lists and codes that are not found are cached as well, whereas
+// other errors are never cached. Expired entries are only removed while the cache is started.
+type CachedCodeLists struct {
+	codeLists CodeLists
+	ttl       time.Duration
+	now       func() time.Time
+
+	mutex   sync.Mutex
+	entries map[cacheKey]cacheEntry
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewCachedCodeLists returns a CachedCodeLists that holds the results of the provided code lists for the ttl
+func NewCachedCodeLists(codeLists CodeLists, ttl time.Duration) *CachedCodeLists {
+	return &CachedCodeLists{
+		codeLists: codeLists,
+		ttl:       ttl,
+		now:       time.Now,
+		entries:   make(map[cacheKey]cacheEntry),
+	}
+}
+
+// Start removes the expired entries every ttl, until the cache is stopped, so that the cache does not grow with every
+// code seen
+func (c *CachedCodeLists) Start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.done = make(chan struct{})
+
+	go func() {
+		defer close(c.done)
+
+		ticker := time.NewTicker(c.ttl)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.removeExpired()
+			}
+		}
+	}()
+}
+
+// Stop stops removing the expired entries
+func (c *CachedCodeLists) Stop() {
+	if c.cancel == nil {
+		return
+	}
+
+	c.cancel()
+	<-c.done
+}
+
+// GetCodeList returns the cached code list, asking the underlying code lists when it is not cached or has expired
+func (c *CachedCodeLists) GetCodeList(ctx context.Context, codeListID string) (*CodeList, error) {
+	key := cacheKey{codeListID: codeListID}
+	if entry, ok := c.get(key); ok {
+		return entry.codeList, entry.err
+	}
+
+	codeList, err := c.codeLists.GetCodeList(ctx, codeListID)
+	if err != nil && err != errs.ErrCodeListNotFound {
+		return nil, err
+	}
+
+	c.set(key, cacheEntry{codeList: codeList, err: err})
+	return codeList, err
+}
+
+// GetCode returns the cached code, asking the underlying code lists when it is not cached or has expired
+func (c *CachedCodeLists) GetCode(ctx context.Context, codeListID, codeID string) (*Code, error) {
+	key := cacheKey{codeListID: codeListID, codeID: codeID}
+	if entry, ok := c.get(key); ok {
+		return entry.code, entry.err
+	}
+
+	code, err := c.codeLists.GetCode(ctx, codeListID, codeID)
+	if err != nil && err != errs.ErrCodeNotFound {
+		return nil, err
+	}
+
+	c.set(key, cacheEntry{code: code, err: err})
+	return code, err
+}
+
+func (c *CachedCodeLists) get(key cacheKey) (cacheEntry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	return entry, ok && c.now().Before(entry.expiresAt)
+}
+
+func (c *CachedCodeLists) set(key cacheKey, entry cacheEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry.expiresAt = c.now().Add(c.ttl)
+	c.entries[key] = entry
+}
+
+func (c *CachedCodeLists) removeExpired() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
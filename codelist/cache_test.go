@@ -0,0 +1,133 @@
+package codelist
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type countingCodeLists struct {
+	codeListCalls int
+	codeCalls     int
+	err           error
+}
+
+func (c *countingCodeLists) GetCodeList(ctx context.Context, codeListID string) (*CodeList, error) {
+	c.codeListCalls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &CodeList{ID: codeListID}, nil
+}
+
+func (c *countingCodeLists) GetCode(ctx context.Context, codeListID, codeID string) (*Code, error) {
+	c.codeCalls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &Code{ID: codeID}, nil
+}
+
+func TestCachedCodeLists(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given cached code lists", t, func() {
+		codeLists := &countingCodeLists{}
+		now := time.Date(2020, time.March, 10, 9, 30, 0, 0, time.UTC)
+		cache := NewCachedCodeLists(codeLists, time.Minute)
+		cache.now = func() time.Time { return now }
+
+		Convey("When a code list is requested twice within the ttl", func() {
+			first, err := cache.GetCodeList(ctx, "age-list")
+			So(err, ShouldBeNil)
+			second, err := cache.GetCodeList(ctx, "age-list")
+			So(err, ShouldBeNil)
+
+			Convey("Then the underlying code lists are only asked once", func() {
+				So(first, ShouldResemble, &CodeList{ID: "age-list"})
+				So(second, ShouldResemble, first)
+				So(codeLists.codeListCalls, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When codes of the same code list are requested", func() {
+			cache.GetCode(ctx, "age-list", "24")
+			cache.GetCode(ctx, "age-list", "25")
+			cache.GetCode(ctx, "age-list", "24")
+
+			Convey("Then the underlying code lists are asked once for each code", func() {
+				So(codeLists.codeCalls, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When a code list is requested again after the ttl", func() {
+			cache.GetCodeList(ctx, "age-list")
+			now = now.Add(time.Minute)
+			cache.GetCodeList(ctx, "age-list")
+
+			Convey("Then the underlying code lists are asked again", func() {
+				So(codeLists.codeListCalls, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When a code list that does not exist is requested twice", func() {
+			codeLists.err = errs.ErrCodeListNotFound
+			_, first := cache.GetCodeList(ctx, "unknown-list")
+			_, second := cache.GetCodeList(ctx, "unknown-list")
+
+			Convey("Then the not found error is cached", func() {
+				So(first, ShouldEqual, errs.ErrCodeListNotFound)
+				So(second, ShouldEqual, errs.ErrCodeListNotFound)
+				So(codeLists.codeListCalls, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When expired entries are removed", func() {
+			cache.GetCodeList(ctx, "age-list")
+			now = now.Add(30 * time.Second)
+			cache.GetCode(ctx, "age-list", "24")
+			now = now.Add(30 * time.Second)
+			cache.removeExpired()
+
+			Convey("Then only the entries within the ttl are kept", func() {
+				So(cache.entries, ShouldHaveLength, 1)
+				So(cache.entries, ShouldContainKey, cacheKey{codeListID: "age-list", codeID: "24"})
+			})
+		})
+
+		Convey("When the underlying code lists fail", func() {
+			codeLists.err = errors.New("code list api is unavailable")
+			_, first := cache.GetCode(ctx, "age-list", "24")
+			_, second := cache.GetCode(ctx, "age-list", "24")
+
+			Convey("Then the error is not cached", func() {
+				So(first, ShouldEqual, codeLists.err)
+				So(second, ShouldEqual, codeLists.err)
+				So(codeLists.codeCalls, ShouldEqual, 2)
+			})
+		})
+	})
+}
+
+func TestCachedCodeListsStart(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given started cached code lists holding an entry that has expired", t, func() {
+		cache := NewCachedCodeLists(&countingCodeLists{}, time.Millisecond)
+		cache.GetCodeList(ctx, "age-list")
+		cache.Start(ctx)
+
+		Convey("When the ttl passes", func() {
+			time.Sleep(10 * time.Millisecond)
+			cache.Stop()
+
+			Convey("Then the expired entry is removed", func() {
+				So(cache.entries, ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,103 @@
+package codelist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/pkg/errors"
+)
+
+// HTTPClient sends http requests
+type HTTPClient interface {
+	Do(ctx context.Context, req *http.Request) (*http.Response, error)
+}
+
+// CodeList holds the details of a code list that the dimensions of a dataset are checked against
+type CodeList struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+// Code holds the details of a code in a code list that the options of a dimension are checked against
+type Code struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+// Client gets code lists and their codes from the code list API
+type Client struct {
+	CodeListAPIURL string
+	Client         HTTPClient
+}
+
+// NewClient returns a Client for the provided code list API host
+func NewClient(codeListAPIURL string, client HTTPClient) *Client {
+	return &Client{
+		CodeListAPIURL: codeListAPIURL,
+		Client:         client,
+	}
+}
+
+// GetCodeList returns the details of the code list, or errs.ErrCodeListNotFound if the code list API does not hold
+// a code list with the provided ID
+func (c *Client) GetCodeList(ctx context.Context, codeListID string) (*CodeList, error) {
+	uri := fmt.Sprintf("%s/code-lists/%s", c.CodeListAPIURL, url.PathEscape(codeListID))
+
+	var codeList CodeList
+	if err := c.get(ctx, uri, errs.ErrCodeListNotFound, &codeList); err != nil {
+		return nil, err
+	}
+	return &codeList, nil
+}
+
+// GetCode returns the details of the code, or errs.ErrCodeNotFound if the code list does not hold a code with the
+// provided ID. A code list that does not exist holds no codes, so its codes are not found either.
+func (c *Client) GetCode(ctx context.Context, codeListID, codeID string) (*Code, error) {
+	uri := fmt.Sprintf("%s/code-lists/%s/codes/%s", c.CodeListAPIURL, url.PathEscape(codeListID), url.PathEscape(codeID))
+
+	var code Code
+	if err := c.get(ctx, uri, errs.ErrCodeNotFound, &code); err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (c *Client) get(ctx context.Context, uri string, errNotFound error, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create code list request")
+	}
+
+	resp, err := c.Client.Do(ctx, req)
+	if err != nil {
+		return errors.Wrap(err, "code list request failed")
+	}
+	defer closeResponseBody(ctx, resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return errNotFound
+	default:
+		return errors.Errorf("unexpected status returned from code list api: %d", resp.StatusCode)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return errors.Wrap(err, "failed to decode code list response")
+	}
+	return nil
+}
+
+func closeResponseBody(ctx context.Context, resp *http.Response) {
+	if resp.Body == nil {
+		return
+	}
+	if err := resp.Body.Close(); err != nil {
+		log.Event(ctx, "failed to close code list api response body", log.WARN, log.Error(err))
+	}
+}
@@ -0,0 +1,110 @@
+package codelist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	dphttp "github.com/ONSdigital/dp-net/http"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newCodeListAPI(status int, body string, requests *[]*http.Request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r)
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestClientGetCodeList(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the code list API holds the code list", t, func() {
+		var requests []*http.Request
+		codeListAPI := newCodeListAPI(http.StatusOK, `{"id":"age-list","label":"Age"}`, &requests)
+		defer codeListAPI.Close()
+		client := NewClient(codeListAPI.URL, dphttp.NewClient())
+
+		Convey("When GetCodeList is called", func() {
+			codeList, err := client.GetCodeList(ctx, "age-list")
+
+			Convey("Then the code list is returned", func() {
+				So(err, ShouldBeNil)
+				So(codeList, ShouldResemble, &CodeList{ID: "age-list", Label: "Age"})
+			})
+
+			Convey("And the code list is requested by its ID", func() {
+				So(requests, ShouldHaveLength, 1)
+				So(requests[0].URL.Path, ShouldEqual, "/code-lists/age-list")
+			})
+		})
+	})
+
+	Convey("Given the code list API does not hold the code list", t, func() {
+		var requests []*http.Request
+		codeListAPI := newCodeListAPI(http.StatusNotFound, "", &requests)
+		defer codeListAPI.Close()
+		client := NewClient(codeListAPI.URL, dphttp.NewClient())
+
+		Convey("Then GetCodeList returns a code list not found error", func() {
+			codeList, err := client.GetCodeList(ctx, "age-list")
+			So(err, ShouldEqual, errs.ErrCodeListNotFound)
+			So(codeList, ShouldBeNil)
+		})
+	})
+
+	Convey("Given the code list API fails", t, func() {
+		var requests []*http.Request
+		codeListAPI := newCodeListAPI(http.StatusInternalServerError, "", &requests)
+		defer codeListAPI.Close()
+		client := NewClient(codeListAPI.URL, dphttp.NewClient())
+
+		Convey("Then GetCodeList returns an error", func() {
+			codeList, err := client.GetCodeList(ctx, "age-list")
+			So(err, ShouldNotBeNil)
+			So(err, ShouldNotEqual, errs.ErrCodeListNotFound)
+			So(codeList, ShouldBeNil)
+		})
+	})
+}
+
+func TestClientGetCode(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the code list API holds the code", t, func() {
+		var requests []*http.Request
+		codeListAPI := newCodeListAPI(http.StatusOK, `{"id":"24","label":"24 years"}`, &requests)
+		defer codeListAPI.Close()
+		client := NewClient(codeListAPI.URL, dphttp.NewClient())
+
+		Convey("When GetCode is called", func() {
+			code, err := client.GetCode(ctx, "age-list", "24")
+
+			Convey("Then the code is returned", func() {
+				So(err, ShouldBeNil)
+				So(code, ShouldResemble, &Code{ID: "24", Label: "24 years"})
+			})
+
+			Convey("And the code is requested from its code list", func() {
+				So(requests, ShouldHaveLength, 1)
+				So(requests[0].URL.Path, ShouldEqual, "/code-lists/age-list/codes/24")
+			})
+		})
+	})
+
+	Convey("Given the code list API does not hold the code", t, func() {
+		var requests []*http.Request
+		codeListAPI := newCodeListAPI(http.StatusNotFound, "", &requests)
+		defer codeListAPI.Close()
+		client := NewClient(codeListAPI.URL, dphttp.NewClient())
+
+		Convey("Then GetCode returns a code not found error", func() {
+			code, err := client.GetCode(ctx, "age-list", "200")
+			So(err, ShouldEqual, errs.ErrCodeNotFound)
+			So(code, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,59 @@
+package codelist
+
+import (
+	"context"
+	"sync"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+)
+
+// LocalCodeLists is an in memory store of code lists, standing in for the code list API in tests and local environments
+type LocalCodeLists struct {
+	mutex     sync.RWMutex
+	codeLists map[string]CodeList
+	codes     map[string]map[string]Code
+}
+
+// NewLocalCodeLists returns a LocalCodeLists holding no code lists
+func NewLocalCodeLists() *LocalCodeLists {
+	return &LocalCodeLists{
+		codeLists: make(map[string]CodeList),
+		codes:     make(map[string]map[string]Code),
+	}
+}
+
+// Add stores the code list and its codes, replacing any code list with the same ID
+func (l *LocalCodeLists) Add(codeList CodeList, codes ...Code) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.codeLists[codeList.ID] = codeList
+	l.codes[codeList.ID] = make(map[string]Code)
+	for _, code := range codes {
+		l.codes[codeList.ID][code.ID] = code
+	}
+}
+
+// GetCodeList returns the stored code list, or errs.ErrCodeListNotFound if it is not held
+func (l *LocalCodeLists) GetCodeList(ctx context.Context, codeListID string) (*CodeList, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	codeList, ok := l.codeLists[codeListID]
+	if !ok {
+		return nil, errs.ErrCodeListNotFound
+	}
+	return &codeList, nil
+}
+
+// GetCode returns the stored code, or errs.ErrCodeNotFound if the code list does not hold it
+func (l *LocalCodeLists) GetCode(ctx context.Context, codeListID, codeID string) (*Code, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	code, ok := l.codes[codeListID][codeID]
+	if !ok {
+		return nil, errs.ErrCodeNotFound
+	}
+	return &code, nil
+}
@@ -0,0 +1,94 @@
+package codelist
+
+import (
+	"context"
+	"fmt"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+)
+
+// NotCheckedMessage is the message of the warning reported for a dimension or option that could not be checked, as
+// its code list could not be retrieved
+const NotCheckedMessage = "could not be checked as the code list api is unavailable"
+
+// Validator checks that the dimensions of an instance, and their options, are taken from code lists and codes that
+// exist. Labels that do not match those in the code list are only reported as warnings, as they are often reworded
+// for a dataset.
+type Validator struct {
+	codeLists CodeLists
+}
+
+// NewValidator returns a Validator that checks dimensions against the provided code lists
+func NewValidator(codeLists CodeLists) *Validator {
+	return &Validator{codeLists: codeLists}
+}
+
+// ValidateDimension returns errs.ErrCodeListNotFound if the code list of the dimension does not exist, and a warning
+// if the label of the dimension does not match the code list. Dimensions without a code list are not checked.
+func (v *Validator) ValidateDimension(ctx context.Context, dimension *models.Dimension) ([]models.DimensionValidationIssue, error) {
+	codeListID := dimension.Links.CodeList.ID
+	if codeListID == "" {
+		return nil, nil
+	}
+
+	codeList, err := v.codeLists.GetCodeList(ctx, codeListID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dimension.Label == "" || dimension.Label == codeList.Label {
+		return nil, nil
+	}
+	return []models.DimensionValidationIssue{{
+		Dimension: dimension.Name,
+		CodeList:  codeListID,
+		Message:   labelMismatch(dimension.Label, codeList.Label),
+	}}, nil
+}
+
+// ValidateOption returns errs.ErrCodeListNotFound or errs.ErrCodeNotFound if the code list or the code of the option
+// do not exist, and a warning if the label of the option does not match the code. Options are identified by their
+// option when they have no code, and options without a code list are not checked.
+func (v *Validator) ValidateOption(ctx context.Context, option *models.CachedDimensionOption) ([]models.DimensionValidationIssue, error) {
+	if option.CodeList == "" {
+		return nil, nil
+	}
+
+	if _, err := v.codeLists.GetCodeList(ctx, option.CodeList); err != nil {
+		return nil, err
+	}
+
+	codeID := optionCode(option)
+	code, err := v.codeLists.GetCode(ctx, option.CodeList, codeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if option.Label == "" || option.Label == code.Label {
+		return nil, nil
+	}
+	return []models.DimensionValidationIssue{{
+		Dimension: option.Name,
+		Option:    option.Option,
+		CodeList:  option.CodeList,
+		Code:      codeID,
+		Message:   labelMismatch(option.Label, code.Label),
+	}}, nil
+}
+
+func optionCode(option *models.CachedDimensionOption) string {
+	if option.Code != "" {
+		return option.Code
+	}
+	return option.Option
+}
+
+func labelMismatch(label, codeListLabel string) string {
+	return fmt.Sprintf("label %q does not match the label %q in the code list", label, codeListLabel)
+}
+
+// IsNotFound returns whether the error is a code list or code that does not exist, rather than a failure to check it
+func IsNotFound(err error) bool {
+	return err == errs.ErrCodeListNotFound || err == errs.ErrCodeNotFound
+}
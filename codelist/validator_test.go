@@ -0,0 +1,75 @@
+package codelist
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestValidator(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a validator of the age code list", t, func() {
+		codeLists := NewLocalCodeLists()
+		codeLists.Add(CodeList{ID: "age-list", Label: "Age"}, Code{ID: "24", Label: "24 years"})
+		validator := NewValidator(codeLists)
+
+		Convey("Then a dimension without a code list is not checked", func() {
+			warnings, err := validator.ValidateDimension(ctx, &models.Dimension{Name: "age", Label: "Age of person"})
+			So(err, ShouldBeNil)
+			So(warnings, ShouldBeEmpty)
+		})
+
+		Convey("Then a dimension with a code list that does not exist is rejected", func() {
+			dimension := &models.Dimension{Name: "sex", Links: models.DimensionLink{CodeList: models.LinkObject{ID: "sex-list"}}}
+			_, err := validator.ValidateDimension(ctx, dimension)
+			So(err, ShouldEqual, errs.ErrCodeListNotFound)
+		})
+
+		Convey("Then a dimension with a different label to its code list is warned about", func() {
+			dimension := &models.Dimension{Name: "age", Label: "Age of person", Links: models.DimensionLink{CodeList: models.LinkObject{ID: "age-list"}}}
+			warnings, err := validator.ValidateDimension(ctx, dimension)
+			So(err, ShouldBeNil)
+			So(warnings, ShouldResemble, []models.DimensionValidationIssue{{
+				Dimension: "age",
+				CodeList:  "age-list",
+				Message:   `label "Age of person" does not match the label "Age" in the code list`,
+			}})
+		})
+
+		Convey("Then an option without a code list is not checked", func() {
+			warnings, err := validator.ValidateOption(ctx, &models.CachedDimensionOption{Name: "age", Option: "200"})
+			So(err, ShouldBeNil)
+			So(warnings, ShouldBeEmpty)
+		})
+
+		Convey("Then an option is identified by its code, or by its option when it has no code", func() {
+			warnings, err := validator.ValidateOption(ctx, &models.CachedDimensionOption{Name: "age", Option: "twenty four", Code: "24", CodeList: "age-list"})
+			So(err, ShouldBeNil)
+			So(warnings, ShouldBeEmpty)
+
+			_, err = validator.ValidateOption(ctx, &models.CachedDimensionOption{Name: "age", Option: "200", CodeList: "age-list"})
+			So(err, ShouldEqual, errs.ErrCodeNotFound)
+		})
+
+		Convey("Then an option with a code list that does not exist is rejected", func() {
+			_, err := validator.ValidateOption(ctx, &models.CachedDimensionOption{Name: "sex", Option: "male", CodeList: "sex-list"})
+			So(err, ShouldEqual, errs.ErrCodeListNotFound)
+		})
+
+		Convey("Then an option with a different label to its code is warned about", func() {
+			warnings, err := validator.ValidateOption(ctx, &models.CachedDimensionOption{Name: "age", Option: "24", Label: "24", CodeList: "age-list"})
+			So(err, ShouldBeNil)
+			So(warnings, ShouldResemble, []models.DimensionValidationIssue{{
+				Dimension: "age",
+				Option:    "24",
+				CodeList:  "age-list",
+				Code:      "24",
+				Message:   `label "24" does not match the label "24 years" in the code list`,
+			}})
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package collection
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Checker checks whether a user can access the dataset resources associated with a collection
+type Checker interface {
+	CanAccess(ctx context.Context, userAccessToken, datasetID, collectionID string) (bool, error)
+}
+
+type cacheKey struct {
+	userAccessToken string
+	datasetID       string
+	collectionID    string
+}
+
+type cacheEntry struct {
+	canAccess bool
+	expiresAt time.Time
+}
+
+// CachedChecker caches the results of another Checker, so that a user browsing the resources of a collection does
+// not trigger a permissions request for every call. Errors are never cached.
+type CachedChecker struct {
+	checker Checker
+	ttl     time.Duration
+	now     func() time.Time
+
+	mutex   sync.Mutex
+	entries map[cacheKey]cacheEntry
+}
+
+// NewCachedChecker returns a CachedChecker that holds the results of the provided checker for the ttl
+func NewCachedChecker(checker Checker, ttl time.Duration) *CachedChecker {
+	return &CachedChecker{
+		checker: checker,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[cacheKey]cacheEntry),
+	}
+}
+
+// CanAccess returns the cached access of the user to the collection, asking the underlying checker when
+// the result is not cached or has expired
+func (c *CachedChecker) CanAccess(ctx context.Context, userAccessToken, datasetID, collectionID string) (bool, error) {
+	key := cacheKey{userAccessToken: userAccessToken, datasetID: datasetID, collectionID: collectionID}
+
+	c.mutex.Lock()
+	entry, ok := c.entries[key]
+	c.mutex.Unlock()
+	if ok && c.now().Before(entry.expiresAt) {
+		return entry.canAccess, nil
+	}
+
+	canAccess, err := c.checker.CanAccess(ctx, userAccessToken, datasetID, collectionID)
+	if err != nil {
+		return false, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.removeExpired()
+	c.entries[key] = cacheEntry{canAccess: canAccess, expiresAt: c.now().Add(c.ttl)}
+
+	return canAccess, nil
+}
+
+// removeExpired drops the expired entries, so that the cache does not grow with every session seen.
+// The caller must hold the mutex.
+func (c *CachedChecker) removeExpired() {
+	now := c.now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
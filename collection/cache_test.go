@@ -0,0 +1,80 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type countingChecker struct {
+	calls     int
+	canAccess bool
+	err       error
+}
+
+func (c *countingChecker) CanAccess(ctx context.Context, userAccessToken, datasetID, collectionID string) (bool, error) {
+	c.calls++
+	return c.canAccess, c.err
+}
+
+func TestCachedChecker(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a cached checker", t, func() {
+		checker := &countingChecker{canAccess: true}
+		now := time.Date(2020, time.March, 10, 9, 30, 0, 0, time.UTC)
+		cache := NewCachedChecker(checker, time.Minute)
+		cache.now = func() time.Time { return now }
+
+		Convey("When the access of a user is checked twice within the ttl", func() {
+			first, err := cache.CanAccess(ctx, "user-token", "123", "456")
+			So(err, ShouldBeNil)
+			second, err := cache.CanAccess(ctx, "user-token", "123", "456")
+			So(err, ShouldBeNil)
+
+			Convey("Then the underlying checker is only called once", func() {
+				So(first, ShouldBeTrue)
+				So(second, ShouldBeTrue)
+				So(checker.calls, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the access of a user is checked for a different collection", func() {
+			cache.CanAccess(ctx, "user-token", "123", "456")
+			cache.CanAccess(ctx, "user-token", "123", "789")
+
+			Convey("Then the underlying checker is called for each collection", func() {
+				So(checker.calls, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When the access of a user is checked again after the ttl", func() {
+			cache.CanAccess(ctx, "user-token", "123", "456")
+			now = now.Add(time.Minute)
+			cache.CanAccess(ctx, "user-token", "123", "456")
+
+			Convey("Then the underlying checker is called again", func() {
+				So(checker.calls, ShouldEqual, 2)
+				So(cache.entries, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the underlying checker returns an error", func() {
+			checker.err = errors.New("zebedee is unavailable")
+			_, err := cache.CanAccess(ctx, "user-token", "123", "456")
+			So(err, ShouldNotBeNil)
+
+			checker.err = nil
+			canAccess, err := cache.CanAccess(ctx, "user-token", "123", "456")
+
+			Convey("Then the error is not cached", func() {
+				So(err, ShouldBeNil)
+				So(canAccess, ShouldBeTrue)
+				So(checker.calls, ShouldEqual, 2)
+			})
+		})
+	})
+}
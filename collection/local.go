@@ -0,0 +1,86 @@
+package collection
+
+import (
+	"context"
+	"sync"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+)
+
+// LocalChecker is an in memory Checker, standing in for Zebedee in tests and local environments
+type LocalChecker struct {
+	mutex  sync.RWMutex
+	grants map[string]map[string]bool
+}
+
+// NewLocalChecker returns a LocalChecker where no user has access to any collection
+func NewLocalChecker() *LocalChecker {
+	return &LocalChecker{
+		grants: make(map[string]map[string]bool),
+	}
+}
+
+// Grant gives the user identified by the access token access to the collection. Granting access to an empty
+// collection ID gives the user the permissions on datasets that are not granted through a collection.
+func (l *LocalChecker) Grant(userAccessToken, collectionID string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.grants[userAccessToken] == nil {
+		l.grants[userAccessToken] = make(map[string]bool)
+	}
+	l.grants[userAccessToken][collectionID] = true
+}
+
+// CanAccess returns true if the user has been granted access to the collection
+func (l *LocalChecker) CanAccess(ctx context.Context, userAccessToken, datasetID, collectionID string) (bool, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.grants[userAccessToken][collectionID], nil
+}
+
+// LocalCollections is an in memory store of collection details, standing in for Zebedee in tests and local environments
+type LocalCollections struct {
+	mutex       sync.RWMutex
+	collections map[string]Collection
+}
+
+// NewLocalCollections returns a LocalCollections holding the provided collections
+func NewLocalCollections(collections ...Collection) *LocalCollections {
+	l := &LocalCollections{
+		collections: make(map[string]Collection),
+	}
+	for _, c := range collections {
+		l.Add(c)
+	}
+	return l
+}
+
+// Add stores the collection, replacing any collection with the same ID
+func (l *LocalCollections) Add(collection Collection) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.collections[collection.ID] = collection
+}
+
+// Remove deletes the collection, as Zebedee does once a collection has been published
+func (l *LocalCollections) Remove(collectionID string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.collections, collectionID)
+}
+
+// GetCollection returns the stored collection, or errs.ErrCollectionNotFound if it is not held
+func (l *LocalCollections) GetCollection(ctx context.Context, collectionID string) (*Collection, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	collection, ok := l.collections[collectionID]
+	if !ok {
+		return nil, errs.ErrCollectionNotFound
+	}
+	return &collection, nil
+}
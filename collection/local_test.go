@@ -0,0 +1,57 @@
+package collection
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLocalChecker(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a local checker where a user has been granted access to a collection", t, func() {
+		checker := NewLocalChecker()
+		checker.Grant("user-token", "456")
+
+		Convey("Then the user can access that collection only", func() {
+			canAccess, err := checker.CanAccess(ctx, "user-token", "123", "456")
+			So(err, ShouldBeNil)
+			So(canAccess, ShouldBeTrue)
+
+			canAccess, err = checker.CanAccess(ctx, "user-token", "123", "789")
+			So(err, ShouldBeNil)
+			So(canAccess, ShouldBeFalse)
+		})
+
+		Convey("Then other users cannot access the collection", func() {
+			canAccess, err := checker.CanAccess(ctx, "other-token", "123", "456")
+			So(err, ShouldBeNil)
+			So(canAccess, ShouldBeFalse)
+		})
+	})
+}
+
+func TestLocalCollections(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given local collections holding a collection", t, func() {
+		collections := NewLocalCollections(Collection{ID: "456", Name: "LMS"})
+
+		Convey("Then the collection is returned", func() {
+			collection, err := collections.GetCollection(ctx, "456")
+			So(err, ShouldBeNil)
+			So(collection.Name, ShouldEqual, "LMS")
+		})
+
+		Convey("When the collection is removed", func() {
+			collections.Remove("456")
+
+			Convey("Then a collection not found error is returned", func() {
+				_, err := collections.GetCollection(ctx, "456")
+				So(err, ShouldEqual, errs.ErrCollectionNotFound)
+			})
+		})
+	})
+}
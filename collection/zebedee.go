@@ -0,0 +1,144 @@
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	dprequest "github.com/ONSdigital/dp-net/request"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/pkg/errors"
+)
+
+const (
+	userDatasetPermissionsPath = "/userDatasetPermissions"
+	collectionDetailsPath      = "/collectionDetails"
+	readPermission             = "READ"
+	approvedStatus             = "COMPLETE"
+)
+
+// HTTPClient sends http requests
+type HTTPClient interface {
+	Do(ctx context.Context, req *http.Request) (*http.Response, error)
+}
+
+type userDatasetPermissions struct {
+	Permissions []string `json:"permissions"`
+}
+
+// Collection holds the details of a Zebedee collection that are relevant to the dataset resources associated with it
+type Collection struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	ApprovalStatus  string `json:"approvalStatus"`
+	PublishComplete bool   `json:"publishComplete"`
+}
+
+// IsEditable returns true if resources can still be added to the collection, i.e. it has not been approved or published
+func (c *Collection) IsEditable() bool {
+	return c.ApprovalStatus != approvedStatus && !c.PublishComplete
+}
+
+// ZebedeeClient checks the collections managed by Zebedee, and the access users have to them
+type ZebedeeClient struct {
+	ZebedeeURL       string
+	ServiceAuthToken string
+	Client           HTTPClient
+}
+
+// NewZebedeeClient returns a ZebedeeClient for the provided Zebedee host. The service auth token is used for the
+// requests that are not made on behalf of a user.
+func NewZebedeeClient(zebedeeURL, serviceAuthToken string, client HTTPClient) *ZebedeeClient {
+	return &ZebedeeClient{
+		ZebedeeURL:       zebedeeURL,
+		ServiceAuthToken: serviceAuthToken,
+		Client:           client,
+	}
+}
+
+// GetCollection returns the details of the collection, or errs.ErrCollectionNotFound if Zebedee does not hold
+// a collection with the provided ID. Published collections are removed by Zebedee, so are not found either.
+func (z *ZebedeeClient) GetCollection(ctx context.Context, collectionID string) (*Collection, error) {
+	uri := fmt.Sprintf("%s%s/%s", z.ZebedeeURL, collectionDetailsPath, url.PathEscape(collectionID))
+
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create collection details request")
+	}
+	dprequest.AddServiceTokenHeader(req, z.ServiceAuthToken)
+
+	resp, err := z.Client.Do(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "collection details request failed")
+	}
+	defer closeResponseBody(ctx, resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errs.ErrCollectionNotFound
+	default:
+		return nil, errors.Errorf("unexpected status returned from zebedee collection details: %d", resp.StatusCode)
+	}
+
+	var collection Collection
+	if err = json.NewDecoder(resp.Body).Decode(&collection); err != nil {
+		return nil, errors.Wrap(err, "failed to decode collection details")
+	}
+
+	return &collection, nil
+}
+
+// CanAccess returns true if the user identified by the provided access token has read access to the dataset
+// resources associated with the collection. Zebedee reports unknown users, sessions and collections with an error
+// status, which are treated as the user not having access.
+func (z *ZebedeeClient) CanAccess(ctx context.Context, userAccessToken, datasetID, collectionID string) (bool, error) {
+	query := url.Values{}
+	query.Set("dataset_id", datasetID)
+	query.Set("collection_id", collectionID)
+	uri := fmt.Sprintf("%s%s?%s", z.ZebedeeURL, userDatasetPermissionsPath, query.Encode())
+
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create user dataset permissions request")
+	}
+	req.Header.Set(dprequest.FlorenceHeaderKey, userAccessToken)
+
+	resp, err := z.Client.Do(ctx, req)
+	if err != nil {
+		return false, errors.Wrap(err, "user dataset permissions request failed")
+	}
+	defer closeResponseBody(ctx, resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return false, nil
+	default:
+		return false, errors.Errorf("unexpected status returned from zebedee user dataset permissions: %d", resp.StatusCode)
+	}
+
+	var permissions userDatasetPermissions
+	if err = json.NewDecoder(resp.Body).Decode(&permissions); err != nil {
+		return false, errors.Wrap(err, "failed to decode user dataset permissions")
+	}
+
+	for _, p := range permissions.Permissions {
+		if p == readPermission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func closeResponseBody(ctx context.Context, resp *http.Response) {
+	if resp.Body == nil {
+		return
+	}
+	if err := resp.Body.Close(); err != nil {
+		log.Event(ctx, "failed to close zebedee response body", log.WARN, log.Error(err))
+	}
+}
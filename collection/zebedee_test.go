@@ -0,0 +1,139 @@
+package collection
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	dphttp "github.com/ONSdigital/dp-net/http"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testServiceAuthToken = "service-token"
+
+func newZebedee(status int, body string, requests *[]*http.Request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r)
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestZebedeeClientCanAccess(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given zebedee grants read access to the collection", t, func() {
+		var requests []*http.Request
+		zebedee := newZebedee(http.StatusOK, `{"permissions":["READ"]}`, &requests)
+		defer zebedee.Close()
+		client := NewZebedeeClient(zebedee.URL, testServiceAuthToken, dphttp.NewClient())
+
+		Convey("When CanAccess is called", func() {
+			canAccess, err := client.CanAccess(ctx, "user-token", "123", "456")
+
+			Convey("Then the user can access the collection", func() {
+				So(err, ShouldBeNil)
+				So(canAccess, ShouldBeTrue)
+			})
+
+			Convey("And the user dataset permissions are requested with the user access token", func() {
+				So(requests, ShouldHaveLength, 1)
+				So(requests[0].URL.Path, ShouldEqual, "/userDatasetPermissions")
+				So(requests[0].URL.Query().Get("dataset_id"), ShouldEqual, "123")
+				So(requests[0].URL.Query().Get("collection_id"), ShouldEqual, "456")
+				So(requests[0].Header.Get("X-Florence-Token"), ShouldEqual, "user-token")
+			})
+		})
+	})
+
+	Convey("Given zebedee does not grant read access to the collection", t, func() {
+		var requests []*http.Request
+		zebedee := newZebedee(http.StatusOK, `{"permissions":[]}`, &requests)
+		defer zebedee.Close()
+		client := NewZebedeeClient(zebedee.URL, testServiceAuthToken, dphttp.NewClient())
+
+		Convey("Then CanAccess returns false", func() {
+			canAccess, err := client.CanAccess(ctx, "user-token", "123", "456")
+			So(err, ShouldBeNil)
+			So(canAccess, ShouldBeFalse)
+		})
+	})
+
+	Convey("Given zebedee does not recognise the user session", t, func() {
+		var requests []*http.Request
+		zebedee := newZebedee(http.StatusUnauthorized, "", &requests)
+		defer zebedee.Close()
+		client := NewZebedeeClient(zebedee.URL, testServiceAuthToken, dphttp.NewClient())
+
+		Convey("Then CanAccess returns false without an error", func() {
+			canAccess, err := client.CanAccess(ctx, "user-token", "123", "456")
+			So(err, ShouldBeNil)
+			So(canAccess, ShouldBeFalse)
+		})
+	})
+
+	Convey("Given zebedee returns an unexpected status", t, func() {
+		var requests []*http.Request
+		zebedee := newZebedee(http.StatusBadGateway, "", &requests)
+		defer zebedee.Close()
+		client := NewZebedeeClient(zebedee.URL, testServiceAuthToken, dphttp.NewClient())
+		client.Client.(dphttp.Clienter).SetMaxRetries(0)
+
+		Convey("Then CanAccess returns an error", func() {
+			canAccess, err := client.CanAccess(ctx, "user-token", "123", "456")
+			So(err, ShouldNotBeNil)
+			So(canAccess, ShouldBeFalse)
+		})
+	})
+}
+
+func TestZebedeeClientGetCollection(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given zebedee holds the collection", t, func() {
+		var requests []*http.Request
+		zebedee := newZebedee(http.StatusOK, `{"id":"456","name":"LMS","approvalStatus":"IN_PROGRESS"}`, &requests)
+		defer zebedee.Close()
+		client := NewZebedeeClient(zebedee.URL, testServiceAuthToken, dphttp.NewClient())
+
+		Convey("When GetCollection is called", func() {
+			collection, err := client.GetCollection(ctx, "456")
+
+			Convey("Then the collection details are returned", func() {
+				So(err, ShouldBeNil)
+				So(collection.ID, ShouldEqual, "456")
+				So(collection.Name, ShouldEqual, "LMS")
+				So(collection.IsEditable(), ShouldBeTrue)
+			})
+
+			Convey("And the collection details are requested with the service auth token", func() {
+				So(requests, ShouldHaveLength, 1)
+				So(requests[0].URL.Path, ShouldEqual, "/collectionDetails/456")
+				So(requests[0].Header.Get("Authorization"), ShouldEqual, "Bearer "+testServiceAuthToken)
+			})
+		})
+	})
+
+	Convey("Given zebedee does not hold the collection", t, func() {
+		var requests []*http.Request
+		zebedee := newZebedee(http.StatusNotFound, "", &requests)
+		defer zebedee.Close()
+		client := NewZebedeeClient(zebedee.URL, testServiceAuthToken, dphttp.NewClient())
+
+		Convey("Then GetCollection returns a collection not found error", func() {
+			collection, err := client.GetCollection(ctx, "456")
+			So(err, ShouldEqual, errs.ErrCollectionNotFound)
+			So(collection, ShouldBeNil)
+		})
+	})
+}
+
+func TestCollectionIsEditable(t *testing.T) {
+	Convey("Approved and published collections are not editable", t, func() {
+		So((&Collection{ApprovalStatus: "NOT_STARTED"}).IsEditable(), ShouldBeTrue)
+		So((&Collection{ApprovalStatus: "COMPLETE"}).IsEditable(), ShouldBeFalse)
+		So((&Collection{ApprovalStatus: "IN_PROGRESS", PublishComplete: true}).IsEditable(), ShouldBeFalse)
+	})
+}
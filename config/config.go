@@ -12,6 +12,8 @@ type Configuration struct {
 	BindAddr                   string        `envconfig:"BIND_ADDR"`
 	KafkaAddr                  []string      `envconfig:"KAFKA_ADDR"                       json:"-"`
 	GenerateDownloadsTopic     string        `envconfig:"GENERATE_DOWNLOADS_TOPIC"`
+	ImportRetryTopic           string        `envconfig:"IMPORT_RETRY_TOPIC"`
+	DatasetEventsTopic         string        `envconfig:"DATASET_EVENTS_TOPIC"`
 	CodeListAPIURL             string        `envconfig:"CODE_LIST_API_URL"`
 	DatasetAPIURL              string        `envconfig:"DATASET_API_URL"`
 	WebsiteURL                 string        `envconfig:"WEBSITE_URL"`
@@ -25,18 +27,80 @@ type Configuration struct {
 	EnableDetachDataset        bool          `envconfig:"ENABLE_DETACH_DATASET"`
 	EnablePermissionsAuth      bool          `envconfig:"ENABLE_PERMISSIONS_AUTH"`
 	EnableObservationEndpoint  bool          `envconfig:"ENABLE_OBSERVATION_ENDPOINT"`
+	EnableForwardedHostLinks   bool          `envconfig:"ENABLE_FORWARDED_HOST_LINKS"`
+	ForwardedHosts             []string      `envconfig:"FORWARDED_HOSTS"`
+	MigrateLinksOnStartup      bool          `envconfig:"MIGRATE_LINKS_ON_STARTUP"`
 	KafkaVersion               string        `envconfig:"KAFKA_VERSION"`
 	DefaultMaxLimit            int           `envconfig:"DEFAULT_MAXIMUM_LIMIT"`
 	DefaultLimit               int           `envconfig:"DEFAULT_LIMIT"`
 	DefaultOffset              int           `envconfig:"DEFAULT_OFFSET"`
+	CollectionPermissionsTTL   time.Duration `envconfig:"COLLECTION_PERMISSIONS_CACHE_TTL"`
+	DeleteJobThreshold         int64         `envconfig:"DELETE_JOB_THRESHOLD"`
+	LinkCheckInterval          time.Duration `envconfig:"LINK_CHECK_INTERVAL"`
+	LinkCheckTimeout           time.Duration `envconfig:"LINK_CHECK_TIMEOUT"`
+	LinkCheckConcurrency       int           `envconfig:"LINK_CHECK_CONCURRENCY"`
+	GraphQLMaxDepth            int           `envconfig:"GRAPHQL_MAX_DEPTH"`
+	GraphQLMaxCost             int           `envconfig:"GRAPHQL_MAX_COST"`
+	EnableCodeListValidation   bool          `envconfig:"ENABLE_CODE_LIST_VALIDATION"`
+	CodeListCacheTTL           time.Duration `envconfig:"CODE_LIST_CACHE_TTL"`
 	MongoConfig                MongoConfig
+	DeletionConfig             DeletionConfig
+	CacheControlConfig         CacheControlConfig
+	TracingConfig              TracingConfig
+	ResponseCacheConfig        ResponseCacheConfig
 }
 
-// MongoConfig contains the config required to connect to MongoDB.
+// MongoConfig contains the config required to connect to MongoDB. Writes, and reads made by the publishing instance,
+// are made with majority write concern and strong consistency. The read preference and maximum staleness only apply
+// to the public reads made by web instances. The indexes the queries of the store rely on are created on startup by the
+// publishing instance, unless EnsureIndexes is false, in which case missing indexes are only reported.
 type MongoConfig struct {
-	BindAddr   string `envconfig:"MONGODB_BIND_ADDR"   json:"-"`
-	Collection string `envconfig:"MONGODB_COLLECTION"`
-	Database   string `envconfig:"MONGODB_DATABASE"`
+	BindAddr       string        `envconfig:"MONGODB_BIND_ADDR"   json:"-"`
+	Collection     string        `envconfig:"MONGODB_COLLECTION"`
+	Database       string        `envconfig:"MONGODB_DATABASE"`
+	ReadPreference string        `envconfig:"MONGODB_READ_PREFERENCE"`
+	MaxStaleness   time.Duration `envconfig:"MONGODB_MAX_STALENESS"`
+	EnsureIndexes  bool          `envconfig:"MONGODB_ENSURE_INDEXES"`
+}
+
+// DeletionConfig contains the config of the soft deletion of datasets. A deleted dataset can be restored for the
+// Retention, after which it is purged by the publishing instance holding the purge lock, checked every PurgeInterval.
+type DeletionConfig struct {
+	Retention     time.Duration `envconfig:"DELETED_DATASET_RETENTION"`
+	PurgeInterval time.Duration `envconfig:"DELETED_DATASET_PURGE_INTERVAL"`
+}
+
+// ResponseCacheConfig contains the limits of the cache of public reads held by web instances. Datasets, editions and
+// published versions with their dimension options expire after their TTLs, as well as being invalidated by the
+// dataset events the web instances consume from DATASET_EVENTS_TOPIC. Each web instance consumes the events in a
+// consumer group of its own, named after its ReplicaID, which must stay the same as the instance is restarted.
+type ResponseCacheConfig struct {
+	Enabled       bool          `envconfig:"ENABLE_RESPONSE_CACHE"`
+	MaxBytes      int64         `envconfig:"RESPONSE_CACHE_MAX_BYTES"`
+	MaxEntryBytes int64         `envconfig:"RESPONSE_CACHE_MAX_ENTRY_BYTES"`
+	DatasetTTL    time.Duration `envconfig:"RESPONSE_CACHE_DATASET_TTL"`
+	EditionTTL    time.Duration `envconfig:"RESPONSE_CACHE_EDITION_TTL"`
+	VersionTTL    time.Duration `envconfig:"RESPONSE_CACHE_VERSION_TTL"`
+	ConsumerGroup string        `envconfig:"RESPONSE_CACHE_CONSUMER_GROUP"`
+	ReplicaID     string        `envconfig:"RESPONSE_CACHE_REPLICA_ID"`
+}
+
+// CacheControlConfig contains the Cache-Control max-age values, per route, sent with public responses.
+type CacheControlConfig struct {
+	DatasetMaxAge          time.Duration `envconfig:"CACHE_CONTROL_DATASET_MAX_AGE"`
+	EditionMaxAge          time.Duration `envconfig:"CACHE_CONTROL_EDITION_MAX_AGE"`
+	VersionMaxAge          time.Duration `envconfig:"CACHE_CONTROL_VERSION_MAX_AGE"`
+	MetadataMaxAge         time.Duration `envconfig:"CACHE_CONTROL_METADATA_MAX_AGE"`
+	DimensionsMaxAge       time.Duration `envconfig:"CACHE_CONTROL_DIMENSIONS_MAX_AGE"`
+	DimensionOptionsMaxAge time.Duration `envconfig:"CACHE_CONTROL_DIMENSION_OPTIONS_MAX_AGE"`
+	TopicsMaxAge           time.Duration `envconfig:"CACHE_CONTROL_TOPICS_MAX_AGE"`
+}
+
+// TracingConfig contains the config for exporting the spans of the requests handled.
+type TracingConfig struct {
+	Exporter     string  `envconfig:"TRACING_EXPORTER"`
+	OTLPEndpoint string  `envconfig:"TRACING_OTLP_ENDPOINT"`
+	SampleRatio  float64 `envconfig:"TRACING_SAMPLE_RATIO"`
 }
 
 var cfg *Configuration
@@ -51,6 +115,8 @@ func Get() (*Configuration, error) {
 		BindAddr:                   ":22000",
 		KafkaAddr:                  []string{"localhost:9092"},
 		GenerateDownloadsTopic:     "filter-job-submitted",
+		ImportRetryTopic:           "instance-import-retry",
+		DatasetEventsTopic:         "dataset-events",
 		CodeListAPIURL:             "http://localhost:22400",
 		DatasetAPIURL:              "http://localhost:22000",
 		WebsiteURL:                 "http://localhost:20000",
@@ -64,14 +130,56 @@ func Get() (*Configuration, error) {
 		EnableDetachDataset:        false,
 		EnablePermissionsAuth:      false,
 		EnableObservationEndpoint:  true,
+		EnableForwardedHostLinks:   false,
+		ForwardedHosts:             []string{},
+		MigrateLinksOnStartup:      false,
 		KafkaVersion:               "1.0.2",
 		DefaultMaxLimit:            1000,
 		DefaultLimit:               20,
 		DefaultOffset:              0,
+		CollectionPermissionsTTL:   30 * time.Second,
+		DeleteJobThreshold:         10000,
+		LinkCheckInterval:          24 * time.Hour,
+		LinkCheckTimeout:           10 * time.Second,
+		LinkCheckConcurrency:       10,
+		GraphQLMaxDepth:            12,
+		GraphQLMaxCost:             2000,
+		EnableCodeListValidation:   false,
+		CodeListCacheTTL:           10 * time.Minute,
 		MongoConfig: MongoConfig{
-			BindAddr:   "localhost:27017",
-			Collection: "datasets",
-			Database:   "datasets",
+			BindAddr:       "localhost:27017",
+			Collection:     "datasets",
+			Database:       "datasets",
+			ReadPreference: "secondaryPreferred",
+			MaxStaleness:   90 * time.Second,
+			EnsureIndexes:  true,
+		},
+		DeletionConfig: DeletionConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		ResponseCacheConfig: ResponseCacheConfig{
+			Enabled:       false,
+			MaxBytes:      64 * 1024 * 1024,
+			MaxEntryBytes: 1024 * 1024,
+			DatasetTTL:    time.Minute,
+			EditionTTL:    time.Minute,
+			VersionTTL:    10 * time.Minute,
+			ConsumerGroup: "dp-dataset-api-response-cache",
+		},
+		CacheControlConfig: CacheControlConfig{
+			DatasetMaxAge:          time.Minute,
+			EditionMaxAge:          time.Minute,
+			VersionMaxAge:          5 * time.Minute,
+			MetadataMaxAge:         5 * time.Minute,
+			DimensionsMaxAge:       5 * time.Minute,
+			DimensionOptionsMaxAge: 5 * time.Minute,
+			TopicsMaxAge:           time.Minute,
+		},
+		TracingConfig: TracingConfig{
+			Exporter:     "none",
+			OTLPEndpoint: "http://localhost:4318",
+			SampleRatio:  1,
 		},
 	}
 
@@ -23,6 +23,8 @@ func TestSpec(t *testing.T) {
 				So(cfg.BindAddr, ShouldEqual, ":22000")
 				So(cfg.KafkaAddr, ShouldResemble, []string{"localhost:9092"})
 				So(cfg.GenerateDownloadsTopic, ShouldEqual, "filter-job-submitted")
+				So(cfg.ImportRetryTopic, ShouldEqual, "instance-import-retry")
+				So(cfg.DatasetEventsTopic, ShouldEqual, "dataset-events")
 				So(cfg.DatasetAPIURL, ShouldEqual, "http://localhost:22000")
 				So(cfg.CodeListAPIURL, ShouldEqual, "http://localhost:22400")
 				So(cfg.DownloadServiceSecretKey, ShouldEqual, "QB0108EZ-825D-412C-9B1D-41EF7747F462")
@@ -33,11 +35,47 @@ func TestSpec(t *testing.T) {
 				So(cfg.MongoConfig.BindAddr, ShouldEqual, "localhost:27017")
 				So(cfg.MongoConfig.Collection, ShouldEqual, "datasets")
 				So(cfg.MongoConfig.Database, ShouldEqual, "datasets")
+				So(cfg.MongoConfig.ReadPreference, ShouldEqual, "secondaryPreferred")
+				So(cfg.MongoConfig.MaxStaleness, ShouldEqual, 90*time.Second)
+				So(cfg.MongoConfig.EnsureIndexes, ShouldBeTrue)
+				So(cfg.ResponseCacheConfig, ShouldResemble, ResponseCacheConfig{
+					Enabled:       false,
+					MaxBytes:      64 * 1024 * 1024,
+					MaxEntryBytes: 1024 * 1024,
+					DatasetTTL:    time.Minute,
+					EditionTTL:    time.Minute,
+					VersionTTL:    10 * time.Minute,
+					ConsumerGroup: "dp-dataset-api-response-cache",
+				})
 				So(cfg.DefaultLimit, ShouldEqual, 20)
 				So(cfg.DefaultOffset, ShouldEqual, 0)
+				So(cfg.CollectionPermissionsTTL, ShouldEqual, 30*time.Second)
+				So(cfg.DeleteJobThreshold, ShouldEqual, 10000)
+				So(cfg.DeletionConfig.Retention, ShouldEqual, 30*24*time.Hour)
+				So(cfg.DeletionConfig.PurgeInterval, ShouldEqual, time.Hour)
+				So(cfg.LinkCheckInterval, ShouldEqual, 24*time.Hour)
+				So(cfg.LinkCheckTimeout, ShouldEqual, 10*time.Second)
+				So(cfg.LinkCheckConcurrency, ShouldEqual, 10)
+				So(cfg.GraphQLMaxDepth, ShouldEqual, 12)
+				So(cfg.GraphQLMaxCost, ShouldEqual, 2000)
+				So(cfg.EnableCodeListValidation, ShouldBeFalse)
+				So(cfg.CodeListCacheTTL, ShouldEqual, 10*time.Minute)
 				So(cfg.EnablePermissionsAuth, ShouldBeFalse)
+				So(cfg.EnableForwardedHostLinks, ShouldBeFalse)
+				So(cfg.ForwardedHosts, ShouldBeEmpty)
+				So(cfg.MigrateLinksOnStartup, ShouldBeFalse)
 				So(cfg.HealthCheckCriticalTimeout, ShouldEqual, 90*time.Second)
 				So(cfg.HealthCheckInterval, ShouldEqual, 30*time.Second)
+				So(cfg.CacheControlConfig.DatasetMaxAge, ShouldEqual, time.Minute)
+				So(cfg.CacheControlConfig.EditionMaxAge, ShouldEqual, time.Minute)
+				So(cfg.CacheControlConfig.VersionMaxAge, ShouldEqual, 5*time.Minute)
+				So(cfg.CacheControlConfig.MetadataMaxAge, ShouldEqual, 5*time.Minute)
+				So(cfg.CacheControlConfig.DimensionsMaxAge, ShouldEqual, 5*time.Minute)
+				So(cfg.CacheControlConfig.DimensionOptionsMaxAge, ShouldEqual, 5*time.Minute)
+				So(cfg.CacheControlConfig.TopicsMaxAge, ShouldEqual, time.Minute)
+				So(cfg.TracingConfig.Exporter, ShouldEqual, "none")
+				So(cfg.TracingConfig.OTLPEndpoint, ShouldEqual, "http://localhost:4318")
+				So(cfg.TracingConfig.SampleRatio, ShouldEqual, 1)
 			})
 		})
 	})
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/codelist"
 	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-dataset-api/mongo"
 	"github.com/ONSdigital/dp-dataset-api/store"
@@ -22,6 +23,9 @@ import (
 // Store provides a backend for dimensions
 type Store struct {
 	store.Storer
+	// CodeListValidator checks dimension options against their code lists when they are added. Options are not
+	// checked if it is nil.
+	CodeListValidator *codelist.Validator
 }
 
 // List of actions for dimensions
@@ -47,10 +51,10 @@ func (s *Store) GetDimensionsHandler(w http.ResponseWriter, r *http.Request, lim
 	if err != nil {
 		return nil, 0, err
 	}
-	defer s.UnlockInstance(lockID)
+	defer s.UnlockInstance(ctx, lockID)
 
 	// Get instance from MongoDB
-	instance, err := s.GetInstance(instanceID, eTag)
+	instance, err := s.GetInstance(ctx, instanceID, eTag)
 	if err != nil {
 		log.Event(ctx, "failed to get instance", log.ERROR, log.Error(err), logData)
 		handleDimensionErr(ctx, w, err, logData)
@@ -94,10 +98,10 @@ func (s *Store) GetUniqueDimensionAndOptionsHandler(w http.ResponseWriter, r *ht
 	if err != nil {
 		return nil, 0, err
 	}
-	defer s.UnlockInstance(lockID)
+	defer s.UnlockInstance(ctx, lockID)
 
 	// Get instance from MongoDB
-	instance, err := s.GetInstance(instanceID, eTag)
+	instance, err := s.GetInstance(ctx, instanceID, eTag)
 	if err != nil {
 		log.Event(ctx, "failed to get instance", log.ERROR, log.Error(err), logData)
 		handleDimensionErr(ctx, w, err, logData)
@@ -151,6 +155,13 @@ func (s *Store) AddHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the code list is checked before the instance is locked, so that other options can be added while it is requested
+	warnings, err := s.validateOption(ctx, option, logData)
+	if err != nil {
+		handleDimensionErr(ctx, w, err, logData)
+		return
+	}
+
 	newETag, err := s.add(ctx, instanceID, option, logData, eTag)
 	if err != nil {
 		handleDimensionErr(ctx, w, err, logData)
@@ -158,9 +169,42 @@ func (s *Store) AddHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	log.Event(ctx, "added dimension to instance resource", log.INFO, logData)
 
+	utils.AddValidationWarnings(w, warnings)
 	setETag(w, newETag)
 }
 
+// validateOption checks the option against its code list, if a code list validator has been provided, returning the
+// labels that do not match as warnings. Options are still added when the code list API is unavailable, with a warning
+// that they were not checked, as the validation report of the instance checks them again.
+func (s *Store) validateOption(ctx context.Context, option *models.CachedDimensionOption, logData log.Data) ([]models.DimensionValidationIssue, error) {
+	if s.CodeListValidator == nil {
+		return nil, nil
+	}
+
+	warnings, err := s.CodeListValidator.ValidateOption(ctx, option)
+	if err != nil {
+		logData["code_list"] = option.CodeList
+		logData["code"] = option.Code
+		if !codelist.IsNotFound(err) {
+			log.Event(ctx, "failed to check dimension option against its code list", log.WARN, log.Error(err), logData)
+			return []models.DimensionValidationIssue{{
+				Dimension: option.Name,
+				Option:    option.Option,
+				CodeList:  option.CodeList,
+				Code:      option.Code,
+				Message:   codelist.NotCheckedMessage,
+			}}, nil
+		}
+		log.Event(ctx, "dimension option does not match its code list", log.ERROR, log.Error(err), logData)
+		return nil, err
+	}
+	if len(warnings) > 0 {
+		logData["warnings"] = warnings
+		log.Event(ctx, "dimension option label does not match its code list", log.WARN, logData)
+	}
+	return warnings, nil
+}
+
 func (s *Store) add(ctx context.Context, instanceID string, option *models.CachedDimensionOption, logData log.Data, eTagSelector string) (newETag string, err error) {
 
 	// acquire instance lock so that the instance update and the dimension.options update are atomic
@@ -168,10 +212,10 @@ func (s *Store) add(ctx context.Context, instanceID string, option *models.Cache
 	if err != nil {
 		return "", err
 	}
-	defer s.UnlockInstance(lockID)
+	defer s.UnlockInstance(ctx, lockID)
 
 	// Get instance
-	instance, err := s.GetInstance(instanceID, eTagSelector)
+	instance, err := s.GetInstance(ctx, instanceID, eTagSelector)
 	if err != nil {
 		log.Event(ctx, "failed to get instance", log.ERROR, log.Error(err), logData)
 		return "", err
@@ -184,14 +228,14 @@ func (s *Store) add(ctx context.Context, instanceID string, option *models.Cache
 		return "", err
 	}
 
-	newETag, err = s.UpdateETagForOptions(instance, option, eTagSelector)
+	newETag, err = s.UpdateETagForOptions(ctx, instance, option, eTagSelector)
 	if err != nil {
 		log.Event(ctx, "failed to update eTag for an instance", log.ERROR, log.Error(err), logData)
 		return "", err
 	}
 
 	option.InstanceID = instanceID
-	if err := s.AddDimensionToInstance(option); err != nil {
+	if err := s.AddDimensionToInstance(ctx, option); err != nil {
 		log.Event(ctx, "failed to upsert dimension for an instance", log.ERROR, log.Error(err), logData)
 		return "", err
 	}
@@ -235,7 +279,7 @@ func (s *Store) PatchOptionHandler(w http.ResponseWriter, r *http.Request) {
 	patches, err := createPatches(r.Body)
 	if err != nil {
 		log.Event(ctx, "error obtaining patch from request body", log.ERROR, log.Error(err), logData)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		utils.WriteError(ctx, w, err, http.StatusBadRequest)
 		return
 	}
 	logData["patch_list"] = patches
@@ -333,10 +377,10 @@ func (s *Store) updateOption(ctx context.Context, dimOption models.DimensionOpti
 	if err != nil {
 		return "", err
 	}
-	defer s.UnlockInstance(lockID)
+	defer s.UnlockInstance(ctx, lockID)
 
 	// Get instance
-	instance, err := s.GetInstance(dimOption.InstanceID, eTagSelector)
+	instance, err := s.GetInstance(ctx, dimOption.InstanceID, eTagSelector)
 	if err != nil {
 		log.Event(ctx, "failed to get instance", log.ERROR, log.Error(err), logData)
 		return "", err
@@ -350,14 +394,14 @@ func (s *Store) updateOption(ctx context.Context, dimOption models.DimensionOpti
 	}
 
 	// Update instance ETag
-	newETag, err = s.UpdateETagForNodeIDAndOrder(instance, dimOption.NodeID, dimOption.Order, eTagSelector)
+	newETag, err = s.UpdateETagForNodeIDAndOrder(ctx, instance, dimOption.NodeID, dimOption.Order, eTagSelector)
 	if err != nil {
 		log.Event(ctx, "failed to update ETag for instance", log.ERROR, log.Error(err), logData)
 		return "", err
 	}
 
 	// Update dimension ID and order in dimension.options collection
-	if err := s.UpdateDimensionNodeIDAndOrder(&dimOption); err != nil {
+	if err := s.UpdateDimensionNodeIDAndOrder(ctx, &dimOption); err != nil {
 		log.Event(ctx, "failed to update a dimension of that instance", log.ERROR, log.Error(err), logData)
 		return "", err
 	}
@@ -384,6 +428,6 @@ func setETag(w http.ResponseWriter, eTag string) {
 func writeBody(ctx context.Context, w http.ResponseWriter, b []byte, data log.Data) {
 	if _, err := w.Write(b); err != nil {
 		log.Event(ctx, "failed to write response body", log.ERROR, log.Error(err), data)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		utils.WriteError(ctx, w, apierrors.ErrInternalServer, http.StatusInternalServerError)
 	}
 }
@@ -12,6 +12,8 @@ import (
 
 	"github.com/ONSdigital/dp-dataset-api/api"
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/codelist"
+	"github.com/ONSdigital/dp-dataset-api/collection"
 	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/mocks"
 	"github.com/ONSdigital/dp-dataset-api/models"
@@ -74,11 +76,11 @@ func storeMockWithLock(expectFirstGetUnlocked bool) (*storetest.StorerMock, *boo
 			isLocked = true
 			return testLockID, nil
 		},
-		UnlockInstanceFunc: func(lockID string) error {
+		UnlockInstanceFunc: func(ctx context.Context, lockID string) error {
 			isLocked = false
 			return nil
 		},
-		GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+		GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 			if expectFirstGetUnlocked {
 				if numGetCall > 0 {
 					So(isLocked, ShouldBeTrue)
@@ -102,11 +104,11 @@ func TestAddNodeIDToDimensionReturnsOK(t *testing.T) {
 
 	Convey("Given a dataset API with a successful store mock and auth", t, func() {
 		mockedDataStore, isLocked := storeMockWithLock(true)
-		mockedDataStore.UpdateETagForNodeIDAndOrderFunc = func(currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
+		mockedDataStore.UpdateETagForNodeIDAndOrderFunc = func(ctx context.Context, currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
 			So(*isLocked, ShouldBeTrue)
 			return testETag, nil
 		}
-		mockedDataStore.UpdateDimensionNodeIDAndOrderFunc = func(dimension *models.DimensionOption) error {
+		mockedDataStore.UpdateDimensionNodeIDAndOrderFunc = func(ctx context.Context, dimension *models.DimensionOption) error {
 			So(*isLocked, ShouldBeTrue)
 			return nil
 		}
@@ -178,13 +180,13 @@ func TestPatchOptionReturnsOK(t *testing.T) {
 		numUpdateCall := 0
 
 		mockedDataStore, isLocked := storeMockWithLock(true)
-		mockedDataStore.UpdateETagForNodeIDAndOrderFunc = func(currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
+		mockedDataStore.UpdateETagForNodeIDAndOrderFunc = func(ctx context.Context, currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
 			So(*isLocked, ShouldBeTrue)
 			newETag := fmt.Sprintf("%s_%d", testETag, numUpdateCall)
 			numUpdateCall++
 			return newETag, nil
 		}
-		mockedDataStore.UpdateDimensionNodeIDAndOrderFunc = func(dimension *models.DimensionOption) error {
+		mockedDataStore.UpdateDimensionNodeIDAndOrderFunc = func(ctx context.Context, dimension *models.DimensionOption) error {
 			So(*isLocked, ShouldBeTrue)
 			return nil
 		}
@@ -328,11 +330,11 @@ func TestAddNodeIDToDimensionReturnsNotFound(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore, isLocked := storeMockWithLock(true)
-		mockedDataStore.UpdateETagForNodeIDAndOrderFunc = func(currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
+		mockedDataStore.UpdateETagForNodeIDAndOrderFunc = func(ctx context.Context, currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
 			So(*isLocked, ShouldBeTrue)
 			return testETag, nil
 		}
-		mockedDataStore.UpdateDimensionNodeIDAndOrderFunc = func(dimension *models.DimensionOption) error {
+		mockedDataStore.UpdateDimensionNodeIDAndOrderFunc = func(ctx context.Context, dimension *models.DimensionOption) error {
 			So(*isLocked, ShouldBeTrue)
 			return errs.ErrDimensionNodeNotFound
 		}
@@ -372,11 +374,11 @@ func TestPatchOptionReturnsNotFound(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore, isLocked := storeMockWithLock(true)
-		mockedDataStore.UpdateETagForNodeIDAndOrderFunc = func(currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
+		mockedDataStore.UpdateETagForNodeIDAndOrderFunc = func(ctx context.Context, currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
 			So(*isLocked, ShouldBeTrue)
 			return testETag, nil
 		}
-		mockedDataStore.UpdateDimensionNodeIDAndOrderFunc = func(dimension *models.DimensionOption) error {
+		mockedDataStore.UpdateDimensionNodeIDAndOrderFunc = func(ctx context.Context, dimension *models.DimensionOption) error {
 			So(*isLocked, ShouldBeTrue)
 			return errs.ErrDimensionNodeNotFound
 		}
@@ -419,17 +421,31 @@ func TestPatchOptionReturnsBadRequest(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+			GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 				return &models.Instance{State: models.CreatedState}, nil
 			},
 		}
 
 		datasetAPI := getAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{})
 
+		invalidBodies := map[string]io.Reader{
+			"Then patch dimension option with an invalid body returns bad request":                          strings.NewReader(`wrong`),
+			"Then patch dimension option with a patch containing an unsupported method returns bad request": strings.NewReader(`[{"op": "remove", "path": "/node_id"}]`),
+			"Then patch dimension option with an unexpected path returns bad request":                       strings.NewReader(`[{"op": "add", "path": "unexpected", "value": "11"}]`),
+		}
+
+		for msg, body := range invalidBodies {
+			Convey(msg, func() {
+				r, err := createRequestWithToken(http.MethodPatch, "http://localhost:21800/instances/123/dimensions/age/options/55", body)
+				So(err, ShouldBeNil)
+
+				datasetAPI.Router.ServeHTTP(w, r)
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(mockedDataStore.GetInstanceCalls(), ShouldHaveLength, 0)
+			})
+		}
+
 		bodies := map[string]io.Reader{
-			"Then patch dimension option with an invalid body returns bad request":                            strings.NewReader(`wrong`),
-			"Then patch dimension option with a patch containing an unsupported method returns bad request":   strings.NewReader(`[{"op": "remove", "path": "/node_id"}]`),
-			"Then patch dimension option with an unexpected path returns bad request":                         strings.NewReader(`[{"op": "add", "path": "unexpected", "value": "11"}]`),
 			"Then patch dimension option with an unexpected value type for /node_id path returns bad request": strings.NewReader(`[{"op": "add", "path": "/node_id", "value": 123.321}]`),
 			"Then patch dimension option with an unexpected value type for /order path returns bad request":   strings.NewReader(`[{"op": "add", "path": "/order", "value": "notAnOrder"}]`),
 		}
@@ -457,7 +473,7 @@ func TestAddNodeIDToDimensionReturnsInternalError(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+			GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 				return nil, errs.ErrInternalServer
 			},
 		}
@@ -476,7 +492,7 @@ func TestAddNodeIDToDimensionReturnsInternalError(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+			GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 				return &models.Instance{State: "gobbledygook"}, nil
 			},
 		}
@@ -494,18 +510,18 @@ func TestAddNodeIDToDimensionReturnsInternalError(t *testing.T) {
 func TestPatchOptionReturnsInternalError(t *testing.T) {
 	t.Parallel()
 
-	body := strings.NewReader(`[
+	body := `[
 		{"op": "add", "path": "/order", "value": 0},
 		{"op": "add", "path": "/node_id", "value": "11"}
-	]`)
+	]`
 
 	Convey("Given an internal error is returned from mongo, then response returns an internal error", t, func() {
 		mockedDataStore, isLocked := storeMockWithLock(false)
-		mockedDataStore.GetInstanceFunc = func(ID string, eTagSelector string) (*models.Instance, error) {
+		mockedDataStore.GetInstanceFunc = func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 			return nil, errs.ErrInternalServer
 		}
 
-		r, err := createRequestWithToken(http.MethodPatch, "http://localhost:21800/instances/123/dimensions/age/options/55", body)
+		r, err := createRequestWithToken(http.MethodPatch, "http://localhost:21800/instances/123/dimensions/age/options/55", strings.NewReader(body))
 		So(err, ShouldBeNil)
 
 		w := httptest.NewRecorder()
@@ -519,13 +535,13 @@ func TestPatchOptionReturnsInternalError(t *testing.T) {
 	})
 
 	Convey("Given instance state is invalid, then response returns an internal error", t, func() {
-		r, err := createRequestWithToken(http.MethodPatch, "http://localhost:21800/instances/123/dimensions/age/options/55", body)
+		r, err := createRequestWithToken(http.MethodPatch, "http://localhost:21800/instances/123/dimensions/age/options/55", strings.NewReader(body))
 		So(err, ShouldBeNil)
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore, isLocked := storeMockWithLock(false)
-		mockedDataStore.GetInstanceFunc = func(ID string, eTagSelector string) (*models.Instance, error) {
+		mockedDataStore.GetInstanceFunc = func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 			return &models.Instance{State: "gobbledygook"}, nil
 		}
 
@@ -541,14 +557,14 @@ func TestPatchOptionReturnsInternalError(t *testing.T) {
 
 	Convey("Given an internal error is returned from mongo GetInstance on the second call, then response returns an internal error", t, func() {
 		mockedDataStore, isLocked := storeMockWithLock(true)
-		mockedDataStore.GetInstanceFunc = func(ID string, eTagSelector string) (*models.Instance, error) {
+		mockedDataStore.GetInstanceFunc = func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 			if len(mockedDataStore.GetInstanceCalls()) == 1 {
 				return &models.Instance{State: models.CreatedState}, nil
 			}
 			return nil, errs.ErrInternalServer
 		}
 
-		r, err := createRequestWithToken(http.MethodPatch, "http://localhost:21800/instances/123/dimensions/age/options/55", body)
+		r, err := createRequestWithToken(http.MethodPatch, "http://localhost:21800/instances/123/dimensions/age/options/55", strings.NewReader(body))
 		So(err, ShouldBeNil)
 
 		w := httptest.NewRecorder()
@@ -572,7 +588,7 @@ func TestAddNodeIDToDimensionReturnsForbidden(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+			GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 				return &models.Instance{State: models.PublishedState}, nil
 			},
 		}
@@ -598,7 +614,7 @@ func TestPatchOptionReturnsForbidden(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+			GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 				return &models.Instance{State: models.PublishedState}, nil
 			},
 		}
@@ -653,22 +669,23 @@ func TestPatchOptionReturnsUnauthorized(t *testing.T) {
 func TestAddDimensionToInstanceReturnsOk(t *testing.T) {
 	t.Parallel()
 
-	bodyStr := `{"value":"24", "code_list":"123-456", "dimension": "test", "order": 1}`
+	bodyStr := `{"option":"24", "code_list":"123-456", "dimension": "test", "order": 1}`
 	expectedOrder := 1
 	expected := &models.CachedDimensionOption{
 		InstanceID: "123",
 		CodeList:   "123-456",
 		Name:       "test",
+		Option:     "24",
 		Order:      &expectedOrder,
 	}
 
 	Convey("Given a dataset API with a successful store mock and auth", t, func() {
 		mockedDataStore, isLocked := storeMockWithLock(true)
-		mockedDataStore.UpdateETagForOptionsFunc = func(currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error) {
+		mockedDataStore.UpdateETagForOptionsFunc = func(ctx context.Context, currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error) {
 			So(*isLocked, ShouldBeTrue)
 			return testETag, nil
 		}
-		mockedDataStore.AddDimensionToInstanceFunc = func(dimension *models.CachedDimensionOption) error {
+		mockedDataStore.AddDimensionToInstanceFunc = func(ctx context.Context, dimension *models.CachedDimensionOption) error {
 			So(*isLocked, ShouldBeTrue)
 			return nil
 		}
@@ -742,7 +759,7 @@ func TestAddDimensionToInstanceReturnsOk(t *testing.T) {
 func TestAddDimensionToInstanceReturnsNotFound(t *testing.T) {
 	t.Parallel()
 	Convey("Add a dimension to an instance returns not found", t, func() {
-		json := strings.NewReader(`{"value":"24", "code_list":"123-456", "dimension": "test"}`)
+		json := strings.NewReader(`{"option":"24", "code_list":"123-456", "dimension": "test"}`)
 		r, err := createRequestWithToken("POST", "http://localhost:21800/instances/123/dimensions", json)
 		So(err, ShouldBeNil)
 
@@ -750,16 +767,17 @@ func TestAddDimensionToInstanceReturnsNotFound(t *testing.T) {
 			InstanceID: "123",
 			CodeList:   "123-456",
 			Name:       "test",
+			Option:     "24",
 		}
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore, isLocked := storeMockWithLock(true)
-		mockedDataStore.UpdateETagForOptionsFunc = func(currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error) {
+		mockedDataStore.UpdateETagForOptionsFunc = func(ctx context.Context, currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error) {
 			So(*isLocked, ShouldBeTrue)
 			return testETag, nil
 		}
-		mockedDataStore.AddDimensionToInstanceFunc = func(dimension *models.CachedDimensionOption) error {
+		mockedDataStore.AddDimensionToInstanceFunc = func(ctx context.Context, dimension *models.CachedDimensionOption) error {
 			So(*isLocked, ShouldBeTrue)
 			return errs.ErrDimensionNodeNotFound
 		}
@@ -790,14 +808,14 @@ func TestAddDimensionToInstanceReturnsNotFound(t *testing.T) {
 func TestAddDimensionToInstanceReturnsForbidden(t *testing.T) {
 	t.Parallel()
 	Convey("Add a dimension to a published instance returns forbidden", t, func() {
-		json := strings.NewReader(`{"value":"24", "code_list":"123-456", "dimension": "test"}`)
+		json := strings.NewReader(`{"option":"24", "code_list":"123-456", "dimension": "test"}`)
 		r, err := createRequestWithToken("POST", "http://localhost:21800/instances/123/dimensions", json)
 		So(err, ShouldBeNil)
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+			GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 				return &models.Instance{State: models.PublishedState}, nil
 			},
 		}
@@ -814,7 +832,7 @@ func TestAddDimensionToInstanceReturnsForbidden(t *testing.T) {
 func TestAddDimensionToInstanceReturnsUnauthorized(t *testing.T) {
 	t.Parallel()
 	Convey("Add a dimension to a instance returns unauthorized", t, func() {
-		json := strings.NewReader(`{"value":"24", "code_list":"123-456", "dimension": "test"}`)
+		json := strings.NewReader(`{"option":"24", "code_list":"123-456", "dimension": "test"}`)
 		r, err := http.NewRequest("POST", "http://localhost:21800/instances/123/dimensions", json)
 		So(err, ShouldBeNil)
 
@@ -834,14 +852,14 @@ func TestAddDimensionToInstanceReturnsInternalError(t *testing.T) {
 	t.Parallel()
 
 	Convey("Given an internal error is returned from mongo GetInstance, then response returns an internal error", t, func() {
-		json := strings.NewReader(`{"value":"24", "code_list":"123-456", "dimension": "test"}`)
+		json := strings.NewReader(`{"option":"24", "code_list":"123-456", "dimension": "test"}`)
 		r, err := createRequestWithToken("POST", "http://localhost:21800/instances/123/dimensions", json)
 		So(err, ShouldBeNil)
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+			GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 				return nil, errs.ErrInternalServer
 			},
 		}
@@ -856,14 +874,14 @@ func TestAddDimensionToInstanceReturnsInternalError(t *testing.T) {
 	})
 
 	Convey("Given instance state is invalid, then response returns an internal error", t, func() {
-		json := strings.NewReader(`{"value":"24", "code_list":"123-456", "dimension": "test"}`)
+		json := strings.NewReader(`{"option":"24", "code_list":"123-456", "dimension": "test"}`)
 		r, err := createRequestWithToken("POST", "http://localhost:21800/instances/123/dimensions", json)
 		So(err, ShouldBeNil)
 
 		w := httptest.NewRecorder()
 
 		mockedDataStore := &storetest.StorerMock{
-			GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+			GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 				return &models.Instance{State: "gobbledygook"}, nil
 			},
 		}
@@ -983,7 +1001,7 @@ func TestGetDimensionsReturnsConflict(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore, isLocked := storeMockWithLock(false)
-		mockedDataStore.GetInstanceFunc = func(ID string, eTagSelector string) (*models.Instance, error) {
+		mockedDataStore.GetInstanceFunc = func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 			So(*isLocked, ShouldBeTrue)
 			return nil, errs.ErrInstanceConflict
 		}
@@ -1009,7 +1027,7 @@ func TestGetDimensionsAndOptionsReturnsInternalError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore, isLocked := storeMockWithLock(false)
-		mockedDataStore.GetInstanceFunc = func(ID string, eTagSelector string) (*models.Instance, error) {
+		mockedDataStore.GetInstanceFunc = func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 			So(*isLocked, ShouldBeTrue)
 			return nil, errs.ErrInternalServer
 		}
@@ -1032,7 +1050,7 @@ func TestGetDimensionsAndOptionsReturnsInternalError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore, isLocked := storeMockWithLock(true)
-		mockedDataStore.GetInstanceFunc = func(ID string, eTagSelector string) (*models.Instance, error) {
+		mockedDataStore.GetInstanceFunc = func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 			So(*isLocked, ShouldBeTrue)
 			return &models.Instance{State: "gobbly gook"}, nil
 		}
@@ -1149,7 +1167,7 @@ func TestGetUniqueDimensionAndOptionsReturnsConflict(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		mockedDataStore, isLocked := storeMockWithLock(false)
-		mockedDataStore.GetInstanceFunc = func(ID string, eTagSelector string) (*models.Instance, error) {
+		mockedDataStore.GetInstanceFunc = func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 			return nil, errs.ErrInstanceConflict
 		}
 
@@ -1174,7 +1192,7 @@ func TestGetUniqueDimensionAndOptionsReturnsInternalError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore, isLocked := storeMockWithLock(false)
-		mockedDataStore.GetInstanceFunc = func(ID string, eTagSelector string) (*models.Instance, error) {
+		mockedDataStore.GetInstanceFunc = func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 			So(*isLocked, ShouldBeTrue)
 			return nil, errs.ErrInternalServer
 		}
@@ -1195,7 +1213,7 @@ func TestGetUniqueDimensionAndOptionsReturnsInternalError(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		mockedDataStore, isLocked := storeMockWithLock(false)
-		mockedDataStore.GetInstanceFunc = func(ID string, eTagSelector string) (*models.Instance, error) {
+		mockedDataStore.GetInstanceFunc = func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 			So(*isLocked, ShouldBeTrue)
 			return &models.Instance{State: "gobbly gook"}, nil
 		}
@@ -1212,6 +1230,10 @@ func TestGetUniqueDimensionAndOptionsReturnsInternalError(t *testing.T) {
 }
 
 func getAPIWithMocks(ctx context.Context, mockedDataStore store.Storer, mockedGeneratedDownloads api.DownloadsGenerator) *api.DatasetAPI {
+	return getAPIWithCodeLists(ctx, mockedDataStore, mockedGeneratedDownloads, nil)
+}
+
+func getAPIWithCodeLists(ctx context.Context, mockedDataStore store.Storer, mockedGeneratedDownloads api.DownloadsGenerator, codeListValidator *codelist.Validator) *api.DatasetAPI {
 	mu.Lock()
 	defer mu.Unlock()
 
@@ -1224,7 +1246,7 @@ func getAPIWithMocks(ctx context.Context, mockedDataStore store.Storer, mockedGe
 	datasetPermissions := getAuthorisationHandlerMock()
 	permissions := getAuthorisationHandlerMock()
 
-	return api.Setup(ctx, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, mockedGeneratedDownloads, datasetPermissions, permissions)
+	return api.Setup(ctx, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, mockedGeneratedDownloads, &mocks.ImportRetrierMock{}, &mocks.DatasetEventEmitterMock{}, collection.NewLocalChecker(), collection.NewLocalCollections(), codeListValidator, datasetPermissions, permissions)
 }
 
 func getAuthorisationHandlerMock() *mocks.AuthHandlerMock {
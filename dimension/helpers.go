@@ -10,6 +10,7 @@ import (
 	"github.com/ONSdigital/dp-dataset-api/apierrors"
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/utils"
 	dprequest "github.com/ONSdigital/dp-net/request"
 	"github.com/ONSdigital/log.go/log"
 )
@@ -67,7 +68,7 @@ func handleDimensionErr(ctx context.Context, w http.ResponseWriter, err error, d
 
 	data["response_status"] = status
 	logError(ctx, err, data)
-	http.Error(w, err.Error(), status)
+	utils.WriteError(ctx, w, err, status)
 }
 
 func logError(ctx context.Context, err error, data log.Data) {
@@ -0,0 +1,117 @@
+package dimension
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+)
+
+// ValidateDimensionsAction is the action of getting the validation report of the dimensions of an instance
+const ValidateDimensionsAction = "validateInstanceDimensions"
+
+// validationPageSize is the number of dimension options read from the datastore at a time while they are validated
+const validationPageSize = 1000
+
+// GetValidationReportHandler checks every dimension and dimension option of an instance against their code lists,
+// returning the code lists and codes that do not exist as errors, and the labels that do not match as warnings
+func (s *Store) GetValidationReportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	instanceID := vars["instance_id"]
+	logData := log.Data{"instance_id": instanceID, "action": ValidateDimensionsAction}
+
+	instance, err := s.GetInstance(ctx, instanceID, getIfMatch(r))
+	if err != nil {
+		log.Event(ctx, "failed to get instance", log.ERROR, log.Error(err), logData)
+		handleDimensionErr(ctx, w, err, logData)
+		return
+	}
+
+	report := &models.DimensionValidationReport{
+		InstanceID: instanceID,
+		Errors:     []models.DimensionValidationIssue{},
+		Warnings:   []models.DimensionValidationIssue{},
+	}
+
+	for i := range instance.Dimensions {
+		dimension := &instance.Dimensions[i]
+		warnings, err := s.CodeListValidator.ValidateDimension(ctx, dimension)
+		if err = addToReport(report, warnings, err, models.DimensionValidationIssue{Dimension: dimension.Name, CodeList: dimension.Links.CodeList.ID}); err != nil {
+			log.Event(ctx, "failed to validate instance dimension", log.ERROR, log.Error(err), logData)
+			handleDimensionErr(ctx, w, err, logData)
+			return
+		}
+	}
+
+	if err = s.validateOptions(ctx, instanceID, report); err != nil {
+		log.Event(ctx, "failed to validate instance dimension options", log.ERROR, log.Error(err), logData)
+		handleDimensionErr(ctx, w, err, logData)
+		return
+	}
+	report.Valid = len(report.Errors) == 0
+
+	b, err := json.Marshal(report)
+	if err != nil {
+		log.Event(ctx, "failed to marshal dimension validation report", log.ERROR, log.Error(err), logData)
+		handleDimensionErr(ctx, w, err, logData)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	setETag(w, instance.ETag)
+	writeBody(ctx, w, b, logData)
+
+	logData["valid"] = report.Valid
+	log.Event(ctx, "validated dimensions of instance", log.INFO, logData)
+}
+
+// validateOptions adds the issues of every dimension option of the instance to the report, reading the options a page
+// at a time so that instances with large dimensions are not held in memory at once
+func (s *Store) validateOptions(ctx context.Context, instanceID string, report *models.DimensionValidationReport) error {
+	for offset := 0; ; offset += validationPageSize {
+		options, totalCount, err := s.GetDimensionsFromInstance(ctx, instanceID, offset, validationPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, opt := range options {
+			option := &models.CachedDimensionOption{
+				Name:     opt.Name,
+				Option:   opt.Option,
+				Label:    opt.Label,
+				CodeList: opt.Links.CodeList.ID,
+				Code:     opt.Links.Code.ID,
+			}
+			warnings, err := s.CodeListValidator.ValidateOption(ctx, option)
+			issue := models.DimensionValidationIssue{Dimension: option.Name, Option: option.Option, CodeList: option.CodeList, Code: option.Code}
+			if err = addToReport(report, warnings, err, issue); err != nil {
+				return err
+			}
+		}
+
+		if len(options) == 0 || offset+validationPageSize >= totalCount {
+			return nil
+		}
+	}
+}
+
+// addToReport records the outcome of validating a dimension or option in the report. Code lists and codes that do not
+// exist are recorded as errors against the provided issue, whereas any other error is returned.
+func addToReport(report *models.DimensionValidationReport, warnings []models.DimensionValidationIssue, err error, issue models.DimensionValidationIssue) error {
+	switch err {
+	case nil:
+		report.Warnings = append(report.Warnings, warnings...)
+		return nil
+	case errs.ErrCodeListNotFound, errs.ErrCodeNotFound:
+		issue.Message = err.Error()
+		report.Errors = append(report.Errors, issue)
+		return nil
+	default:
+		return err
+	}
+}
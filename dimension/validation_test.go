@@ -0,0 +1,203 @@
+package dimension_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/codelist"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func testCodeListValidator() *codelist.Validator {
+	codeLists := codelist.NewLocalCodeLists()
+	codeLists.Add(codelist.CodeList{ID: "age-list", Label: "Age"},
+		codelist.Code{ID: "24", Label: "24 years"},
+		codelist.Code{ID: "25", Label: "25 years"},
+	)
+	return codelist.NewValidator(codeLists)
+}
+
+func TestAddDimensionToInstanceValidatesCodeList(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a dataset API that validates dimension options against their code lists", t, func() {
+		mockedDataStore, _ := storeMockWithLock(false)
+		mockedDataStore.UpdateETagForOptionsFunc = func(ctx context.Context, currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error) {
+			return testETag, nil
+		}
+		mockedDataStore.AddDimensionToInstanceFunc = func(ctx context.Context, dimension *models.CachedDimensionOption) error {
+			return nil
+		}
+		datasetAPI := getAPIWithCodeLists(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, testCodeListValidator())
+
+		Convey("When an option with a code in its code list is added", func() {
+			body := strings.NewReader(`{"option":"24", "code":"24", "code_list":"age-list", "dimension": "age", "label": "24 years"}`)
+			r, err := createRequestWithToken("POST", "http://localhost:22000/instances/123/dimensions", body)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the option is added without any warning", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Warning"), ShouldBeEmpty)
+				So(mockedDataStore.AddDimensionToInstanceCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When an option with a label that does not match its code is added", func() {
+			body := strings.NewReader(`{"option":"24", "code_list":"age-list", "dimension": "age", "label": "24"}`)
+			r, err := createRequestWithToken("POST", "http://localhost:22000/instances/123/dimensions", body)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the option is added with a warning about the label", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Warning"), ShouldEqual, `299 - "label \"24\" does not match the label \"24 years\" in the code list"`)
+				So(mockedDataStore.AddDimensionToInstanceCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When an option with a code that is not in its code list is added", func() {
+			body := strings.NewReader(`{"option":"200", "code_list":"age-list", "dimension": "age"}`)
+			r, err := createRequestWithToken("POST", "http://localhost:22000/instances/123/dimensions", body)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then a bad request is returned without the option being added or the instance being locked", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, `"code":"code_not_found"`)
+				So(mockedDataStore.AcquireInstanceLockCalls(), ShouldBeEmpty)
+				So(mockedDataStore.AddDimensionToInstanceCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When an option from a code list that does not exist is added", func() {
+			body := strings.NewReader(`{"option":"24", "code_list":"unknown-list", "dimension": "age"}`)
+			r, err := createRequestWithToken("POST", "http://localhost:22000/instances/123/dimensions", body)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then a bad request is returned without the option being added", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, `"code":"code_list_not_found"`)
+				So(mockedDataStore.AddDimensionToInstanceCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+// unavailableCodeLists fails every request, as the code list API does when it is unavailable
+type unavailableCodeLists struct{}
+
+func (unavailableCodeLists) GetCodeList(ctx context.Context, codeListID string) (*codelist.CodeList, error) {
+	return nil, errors.New("code list api is unavailable")
+}
+
+func (unavailableCodeLists) GetCode(ctx context.Context, codeListID, codeID string) (*codelist.Code, error) {
+	return nil, errors.New("code list api is unavailable")
+}
+
+func TestAddDimensionToInstanceWithCodeListAPIUnavailable(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a dataset API that validates dimension options against code lists that are unavailable", t, func() {
+		mockedDataStore, _ := storeMockWithLock(false)
+		mockedDataStore.UpdateETagForOptionsFunc = func(ctx context.Context, currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error) {
+			return testETag, nil
+		}
+		mockedDataStore.AddDimensionToInstanceFunc = func(ctx context.Context, dimension *models.CachedDimensionOption) error {
+			return nil
+		}
+		datasetAPI := getAPIWithCodeLists(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, codelist.NewValidator(unavailableCodeLists{}))
+
+		Convey("When an option with a code list is added", func() {
+			body := strings.NewReader(`{"option":"24", "code":"24", "code_list":"age-list", "dimension": "age", "label": "24 years"}`)
+			r, err := createRequestWithToken("POST", "http://localhost:22000/instances/123/dimensions", body)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the option is added with a warning that it was not checked", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Warning"), ShouldEqual, `299 - "`+codelist.NotCheckedMessage+`"`)
+				So(mockedDataStore.AddDimensionToInstanceCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestGetValidationReport(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given an instance with dimensions that do and do not match their code lists", t, func() {
+		mockedDataStore, _ := storeMockWithLock(false)
+		mockedDataStore.GetInstanceFunc = func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
+			return &models.Instance{
+				InstanceID: ID,
+				State:      models.CompletedState,
+				ETag:       testETag,
+				Dimensions: []models.Dimension{
+					{Name: "age", Label: "Age", Links: models.DimensionLink{CodeList: models.LinkObject{ID: "age-list"}}},
+					{Name: "sex", Label: "Sex", Links: models.DimensionLink{CodeList: models.LinkObject{ID: "sex-list"}}},
+				},
+			}, nil
+		}
+		mockedDataStore.GetDimensionsFromInstanceFunc = func(ctx context.Context, id string, offset int, limit int) ([]*models.DimensionOption, int, error) {
+			return []*models.DimensionOption{
+				{Name: "age", Option: "24", Label: "24 years", Links: models.DimensionOptionLinks{Code: models.LinkObject{ID: "24"}, CodeList: models.LinkObject{ID: "age-list"}}},
+				{Name: "age", Option: "25", Label: "25", Links: models.DimensionOptionLinks{Code: models.LinkObject{ID: "25"}, CodeList: models.LinkObject{ID: "age-list"}}},
+				{Name: "age", Option: "200", Label: "200 years", Links: models.DimensionOptionLinks{Code: models.LinkObject{ID: "200"}, CodeList: models.LinkObject{ID: "age-list"}}},
+			}, 3, nil
+		}
+		datasetAPI := getAPIWithCodeLists(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, testCodeListValidator())
+
+		Convey("When the validation report of the instance is requested", func() {
+			r, err := createRequestWithToken("GET", "http://localhost:22000/instances/123/dimensions/validation", nil)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the missing code list and code are reported as errors, and the mismatched label as a warning", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var report models.DimensionValidationReport
+				So(json.Unmarshal(w.Body.Bytes(), &report), ShouldBeNil)
+				So(report.InstanceID, ShouldEqual, "123")
+				So(report.Valid, ShouldBeFalse)
+				So(report.Errors, ShouldResemble, []models.DimensionValidationIssue{
+					{Dimension: "sex", CodeList: "sex-list", Message: "code list not found"},
+					{Dimension: "age", Option: "200", CodeList: "age-list", Code: "200", Message: "code not found in code list"},
+				})
+				So(report.Warnings, ShouldResemble, []models.DimensionValidationIssue{
+					{Dimension: "age", Option: "25", CodeList: "age-list", Code: "25", Message: `label "25" does not match the label "25 years" in the code list`},
+				})
+
+				So(mockedDataStore.GetDimensionsFromInstanceCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a dataset API that does not validate dimensions against their code lists", t, func() {
+		datasetAPI := getAPIWithMocks(testContext, &storetest.StorerMock{}, &mocks.DownloadsGeneratorMock{})
+
+		Convey("Then the validation report of an instance can not be requested", func() {
+			r, err := createRequestWithToken("GET", "http://localhost:22000/instances/123/dimensions/validation", nil)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusMethodNotAllowed)
+		})
+	})
+}
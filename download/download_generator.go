@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ONSdigital/dp-dataset-api/tracing"
 	"github.com/ONSdigital/log.go/log"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 //go:generate moq -out ../mocks/generate_downloads_mocks.go -pkg mocks . KafkaProducer GenerateDownloadsEvent
@@ -30,11 +32,12 @@ type GenerateDownloadsEvent interface {
 }
 
 type generateDownloads struct {
-	FilterID   string `avro:"filter_output_id"`
-	InstanceID string `avro:"instance_id"`
-	DatasetID  string `avro:"dataset_id"`
-	Edition    string `avro:"edition"`
-	Version    string `avro:"version"`
+	FilterID    string `avro:"filter_output_id"`
+	InstanceID  string `avro:"instance_id"`
+	DatasetID   string `avro:"dataset_id"`
+	Edition     string `avro:"edition"`
+	Version     string `avro:"version"`
+	TraceParent string `avro:"traceparent"`
 }
 
 // Generator kicks off a full dataset version download task
@@ -44,7 +47,15 @@ type Generator struct {
 }
 
 // Generate the full file download files for the specified dataset/edition/version
-func (gen *Generator) Generate(ctx context.Context, datasetID string, instanceID string, edition string, version string) error {
+func (gen *Generator) Generate(ctx context.Context, datasetID string, instanceID string, edition string, version string) (err error) {
+	ctx, span := tracing.StartProducerSpan(ctx, "download.Generate",
+		attribute.String("dataset_id", datasetID),
+		attribute.String("instance_id", instanceID),
+		attribute.String("edition", edition),
+		attribute.String("version", version),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	if datasetID == "" {
 		return datasetIDEmptyErr
 	}
@@ -61,11 +72,12 @@ func (gen *Generator) Generate(ctx context.Context, datasetID string, instanceID
 	// FilterID is set to an empty string as the avro schema expects there to be
 	// a filter ID otherwise struct wont be marshalled into an acceptable message
 	downloads := generateDownloads{
-		FilterID:   "",
-		DatasetID:  datasetID,
-		InstanceID: instanceID,
-		Edition:    edition,
-		Version:    version,
+		FilterID:    "",
+		DatasetID:   datasetID,
+		InstanceID:  instanceID,
+		Edition:     edition,
+		Version:     version,
+		TraceParent: tracing.TraceParent(ctx),
 	}
 
 	log.Event(ctx, "send generate downloads event", log.INFO, log.Data{
@@ -7,6 +7,11 @@ import (
 	"github.com/ONSdigital/dp-dataset-api/mocks"
 	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var testContext = context.Background()
@@ -196,5 +201,23 @@ func TestGenerator_Generate(t *testing.T) {
 			})
 
 		})
+
+		Convey("when generate is called within a trace", func() {
+			exporter := tracetest.NewInMemoryExporter()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+			defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+			err := gen.Generate(testContext, datasetID, instanceID, edition, version)
+			So(err, ShouldBeNil)
+			<-output
+
+			Convey("then a producer span is recorded for the generate downloads event", func() {
+				spans := exporter.GetSpans()
+				So(spans, ShouldHaveLength, 1)
+				So(spans[0].Name, ShouldEqual, "download.Generate")
+				So(spans[0].SpanKind, ShouldEqual, trace.SpanKindProducer)
+				So(spans[0].Attributes, ShouldContain, attribute.String("dataset_id", datasetID))
+			})
+		})
 	})
 }
@@ -0,0 +1,44 @@
+package events
+
+import (
+	"context"
+
+	kafka "github.com/ONSdigital/dp-kafka/v2"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// DatasetEventUnmarshaller unmarshals the avro encoded dataset events
+type DatasetEventUnmarshaller interface {
+	Unmarshal(message []byte, s interface{}) error
+}
+
+// Consume decodes the dataset events of the kafka messages received, sending each event to the events channel, until
+// the messages channel is closed or the context is done. Every message is committed once its event has been handed
+// over, including messages which cannot be decoded, which are logged and skipped.
+func Consume(ctx context.Context, messages <-chan kafka.Message, unmarshaller DatasetEventUnmarshaller, events chan<- Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			var event Event
+			if err := unmarshaller.Unmarshal(message.GetData(), &event); err != nil {
+				log.Event(ctx, "failed to unmarshal dataset event", log.ERROR, log.Error(err), log.Data{"offset": message.Offset()})
+				message.CommitAndRelease()
+				continue
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				message.Release()
+				return
+			}
+			message.CommitAndRelease()
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/schema"
+	kafka "github.com/ONSdigital/dp-kafka/v2"
+	"github.com/ONSdigital/dp-kafka/v2/kafkatest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConsume(t *testing.T) {
+	Convey("Given a dataset event and a message which is not a dataset event", t, func() {
+		data, err := schema.DatasetEvent.Marshal(Event{Type: VersionPublished, DatasetID: "123", Edition: "2017", Version: "2", InstanceID: "789"})
+		So(err, ShouldBeNil)
+		valid := kafkatest.NewMessage(data, 1)
+		invalid := kafkatest.NewMessage([]byte("not avro"), 0)
+
+		messages := make(chan kafka.Message, 2)
+		messages <- invalid
+		messages <- valid
+		close(messages)
+
+		Convey("When the messages are consumed", func() {
+			events := make(chan Event, 2)
+			Consume(context.Background(), messages, schema.DatasetEvent, events)
+			close(events)
+
+			Convey("Then the dataset event is sent to the events channel", func() {
+				received := []Event{}
+				for event := range events {
+					received = append(received, event)
+				}
+				So(received, ShouldResemble, []Event{{Type: VersionPublished, DatasetID: "123", Edition: "2017", Version: "2", InstanceID: "789"}})
+			})
+
+			Convey("And both messages are committed", func() {
+				So(valid.IsCommitted(), ShouldBeTrue)
+				So(invalid.IsCommitted(), ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -0,0 +1,102 @@
+package events
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-dataset-api/tracing"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// The types of event sent when a dataset changes
+const (
+	// VersionWithdrawn is sent when a published version is withdrawn
+	VersionWithdrawn = "version-withdrawn"
+	// LatestVersionChanged is sent when the latest version of an edition changes to the version in the event
+	LatestVersionChanged = "latest-version-changed"
+	// VersionPublished is sent when a version is published
+	VersionPublished = "version-published"
+	// VersionDetached is sent when an unpublished version is detached from its edition
+	VersionDetached = "version-detached"
+	// CacheFlushed is sent to have the web instances flush their response caches. It is the only event without a
+	// dataset ID.
+	CacheFlushed = "cache-flushed"
+)
+
+var (
+	typeEmptyErr      = errors.New("failed to send dataset event as type was empty")
+	datasetIDEmptyErr = errors.New("failed to send dataset event as dataset ID was empty")
+)
+
+// KafkaProducer sends an outbound kafka message
+type KafkaProducer interface {
+	Output() chan []byte
+}
+
+// DatasetEventMarshaller marshal the event into avro format
+type DatasetEventMarshaller interface {
+	Marshal(s interface{}) ([]byte, error)
+}
+
+// Event is the message sent about a change to a dataset
+type Event struct {
+	Type        string `avro:"type"`
+	DatasetID   string `avro:"dataset_id"`
+	Edition     string `avro:"edition"`
+	Version     string `avro:"version"`
+	InstanceID  string `avro:"instance_id"`
+	TraceParent string `avro:"traceparent"`
+}
+
+// Emitter tells other services about changes to datasets
+type Emitter struct {
+	Producer   KafkaProducer
+	Marshaller DatasetEventMarshaller
+}
+
+// Emit sends an event of the provided type about a dataset, or one of its editions or versions. The edition, version
+// and instance ID are empty for events about the whole dataset.
+func (e *Emitter) Emit(ctx context.Context, eventType, datasetID, edition, version, instanceID string) (err error) {
+	ctx, span := tracing.StartProducerSpan(ctx, "events.Emit",
+		attribute.String("event_type", eventType),
+		attribute.String("dataset_id", datasetID),
+		attribute.String("edition", edition),
+		attribute.String("version", version),
+		attribute.String("instance_id", instanceID),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	if eventType == "" {
+		return typeEmptyErr
+	}
+	if datasetID == "" && eventType != CacheFlushed {
+		return datasetIDEmptyErr
+	}
+
+	event := Event{
+		Type:        eventType,
+		DatasetID:   datasetID,
+		Edition:     edition,
+		Version:     version,
+		InstanceID:  instanceID,
+		TraceParent: tracing.TraceParent(ctx),
+	}
+
+	log.Event(ctx, "send dataset event", log.INFO, log.Data{
+		"type":        eventType,
+		"dataset_id":  datasetID,
+		"edition":     edition,
+		"version":     version,
+		"instance_id": instanceID,
+	})
+
+	avroBytes, err := e.Marshaller.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "error while attempting to marshal dataset event to avro bytes")
+	}
+
+	e.Producer.Output() <- avroBytes
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/schema"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testContext = context.Background()
+
+func TestEmitter_EmitValidationErrors(t *testing.T) {
+	producerMock := &mocks.KafkaProducerMock{
+		OutputFunc: func() chan []byte {
+			return nil
+		},
+	}
+
+	marshallerMock := &mocks.GenerateDownloadsEventMock{
+		MarshalFunc: func(s interface{}) ([]byte, error) {
+			return nil, nil
+		},
+	}
+
+	emitter := Emitter{
+		Producer:   producerMock,
+		Marshaller: marshallerMock,
+	}
+
+	Convey("Given an event without a type", t, func() {
+		err := emitter.Emit(testContext, "", "123", "", "", "")
+
+		Convey("Then the expected error is returned and no message is sent", func() {
+			So(err, ShouldEqual, typeEmptyErr)
+			So(len(marshallerMock.MarshalCalls()), ShouldEqual, 0)
+			So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+		})
+	})
+
+	Convey("Given an event without a dataset ID", t, func() {
+		err := emitter.Emit(testContext, VersionWithdrawn, "", "", "", "")
+
+		Convey("Then the expected error is returned and no message is sent", func() {
+			So(err, ShouldEqual, datasetIDEmptyErr)
+			So(len(marshallerMock.MarshalCalls()), ShouldEqual, 0)
+			So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+		})
+	})
+}
+
+func TestEmitter_Emit(t *testing.T) {
+	Convey("Given an emitter with a working marshaller", t, func() {
+		output := make(chan []byte, 1)
+		producerMock := &mocks.KafkaProducerMock{
+			OutputFunc: func() chan []byte {
+				return output
+			},
+		}
+
+		emitter := Emitter{
+			Producer:   producerMock,
+			Marshaller: schema.DatasetEvent,
+		}
+
+		Convey("When a version withdrawn event is emitted", func() {
+			err := emitter.Emit(testContext, VersionWithdrawn, "123", "2017", "2", "789")
+
+			Convey("Then the expected event is sent to the producer", func() {
+				So(err, ShouldBeNil)
+				So(len(producerMock.OutputCalls()), ShouldEqual, 1)
+
+				var event Event
+				So(schema.DatasetEvent.Unmarshal(<-output, &event), ShouldBeNil)
+				So(event.Type, ShouldEqual, VersionWithdrawn)
+				So(event.DatasetID, ShouldEqual, "123")
+				So(event.Edition, ShouldEqual, "2017")
+				So(event.Version, ShouldEqual, "2")
+				So(event.InstanceID, ShouldEqual, "789")
+			})
+		})
+	})
+
+	Convey("Given a marshaller that fails", t, func() {
+		producerMock := &mocks.KafkaProducerMock{}
+		emitter := Emitter{
+			Producer: producerMock,
+			Marshaller: &mocks.GenerateDownloadsEventMock{
+				MarshalFunc: func(s interface{}) ([]byte, error) {
+					return nil, errors.New("marshal failed")
+				},
+			},
+		}
+
+		Convey("Then emit returns an error and no message is sent", func() {
+			err := emitter.Emit(testContext, VersionWithdrawn, "123", "", "", "")
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "marshal failed")
+			So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+		})
+	})
+}
@@ -119,19 +119,33 @@ Feature: Dataset API
         Given private endpoints are enabled
         And I am identified as "user@ons.gov.uk"
         And I am authorised
+        And I set the "X-Request-Id" header to "test-request-id"
         When I GET "/datasets/population-estimates/editions/hello/versions/2/dimensions"
         Then the HTTP status code should be "404"
-        And I should receive the following response:
+        And I should receive the following JSON response:
             """
-            dimensions not found
+            {
+                "title": "Not Found",
+                "status": 404,
+                "code": "dimensions_not_found",
+                "detail": "dimensions not found",
+                "request_id": "test-request-id"
+            }
             """
 
     Scenario: GET version with invalid state in public mode
+        Given I set the "X-Request-Id" header to "test-request-id"
         When I GET "/datasets/population-estimates/editions/hello/versions/2/dimensions"
         Then the HTTP status code should be "404"
-        And I should receive the following response:
+        And I should receive the following JSON response:
             """
-            version not found
+            {
+                "title": "Not Found",
+                "status": 404,
+                "code": "version_not_found",
+                "detail": "version not found",
+                "request_id": "test-request-id"
+            }
             """
 
     Scenario: GET /datasets/{id}/editions/{edition}/versions/{version}/dimensions/{dimension}/options in public mode
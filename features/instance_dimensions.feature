@@ -238,11 +238,18 @@ Feature: Dataset API
         Given private endpoints are enabled
         And I am identified as "user@ons.gov.uk"
         And I am authorised
+        And I set the "X-Request-Id" header to "test-request-id"
         When I GET "/instances/inexistent/dimensions"
         Then the HTTP status code should be "404"
-        And I should receive the following response:
+        And I should receive the following JSON response:
             """
-            instance not found
+            {
+                "title": "Not Found",
+                "status": 404,
+                "code": "instance_not_found",
+                "detail": "instance not found",
+                "request_id": "test-request-id"
+            }
             """
 
     Scenario: GET /instances/test-item-1/dimensions in private mode with the wrong If-Match header value returns conflict
@@ -295,22 +302,36 @@ Feature: Dataset API
         Given private endpoints are enabled
         And I am identified as "user@ons.gov.uk"
         And I am authorised
+        And I set the "X-Request-Id" header to "test-request-id"
         When I GET "/instances/inexistent/dimensions/time/options"
         Then the HTTP status code should be "404"
-        And I should receive the following response:
+        And I should receive the following JSON response:
             """
-            instance not found
+            {
+                "title": "Not Found",
+                "status": 404,
+                "code": "instance_not_found",
+                "detail": "instance not found",
+                "request_id": "test-request-id"
+            }
             """
 
     Scenario: GET /instances/test-item-1/dimensions/inexistent/options in private mode returns a notFound status code
         Given private endpoints are enabled
         And I am identified as "user@ons.gov.uk"
         And I am authorised
+        And I set the "X-Request-Id" header to "test-request-id"
         When I GET "/instances/test-item-1/dimensions/inexistent/options"
         Then the HTTP status code should be "404"
-        And I should receive the following response:
+        And I should receive the following JSON response:
             """
-            dimension node not found
+            {
+                "title": "Not Found",
+                "status": 404,
+                "code": "dimension_node_not_found",
+                "detail": "dimension node not found",
+                "request_id": "test-request-id"
+            }
             """
 
     Scenario: GET /instances/test-item-1/dimensions/time/options in private mode with the wrong If-Match header value returns conflict
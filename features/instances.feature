@@ -178,11 +178,18 @@ Feature: Dataset API
         Given private endpoints are enabled
         And I am identified as "user@ons.gov.uk"
         And I am authorised
+        And I set the "X-Request-Id" header to "test-request-id"
         When I GET "/instances?state=false"
         Then the HTTP status code should be "400"
-        And I should receive the following response:
+        And I should receive the following JSON response:
             """
-            bad request - invalid filter state values: [false]
+            {
+                "title": "Bad Request",
+                "status": 400,
+                "code": "bad_request",
+                "detail": "bad request - invalid filter state values: [false]",
+                "request_id": "test-request-id"
+            }
             """
 
     Scenario: GET /instances in private mode with dataset that doesnt match any instance returns empty list
@@ -252,8 +259,28 @@ Feature: Dataset API
         Given private endpoints are enabled
         And I am identified as "user@ons.gov.uk"
         And I am authorised
+        And I set the "X-Request-Id" header to "test-request-id"
         When I GET "/instances/inexistent"
         Then the HTTP status code should be "404"
+        And I should receive the following JSON response:
+            """
+            {
+                "title": "Not Found",
+                "status": 404,
+                "code": "instance_not_found",
+                "detail": "instance not found",
+                "request_id": "test-request-id"
+            }
+            """
+
+    Scenario: GET /instances/inexistent in private mode accepting text returns the deprecated error message
+        Given private endpoints are enabled
+        And I am identified as "user@ons.gov.uk"
+        And I am authorised
+        And I set the "Accept" header to "text/plain"
+        When I GET "/instances/inexistent"
+        Then the HTTP status code should be "404"
+        And the response header "Deprecation" should be "true"
         And I should receive the following response:
             """
             instance not found
@@ -264,9 +291,16 @@ Feature: Dataset API
         And I am identified as "user@ons.gov.uk"
         And I am authorised
         And I set the "If-Match" header to "wrongValue"
+        And I set the "X-Request-Id" header to "test-request-id"
         When I GET "/instances/test-item-1"
         Then the HTTP status code should be "409"
-                And I should receive the following response:
+                And I should receive the following JSON response:
             """
-            instance does not match the expected eTag
+            {
+                "title": "Conflict",
+                "status": 409,
+                "code": "instance_conflict",
+                "detail": "instance does not match the expected eTag",
+                "request_id": "test-request-id"
+            }
             """
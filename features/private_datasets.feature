@@ -32,6 +32,7 @@ Feature: Private Dataset API
                 }
             ]
             """
+        And I set the "X-Request-Id" header to "test-request-id"
         When I POST "/datasets/ageing-population-estimates"
             """
             {
@@ -39,9 +40,15 @@ Feature: Private Dataset API
             }
             """
         Then the HTTP status code should be "403"
-        And I should receive the following response:
+        And I should receive the following JSON response:
             """
-            forbidden - dataset already exists
+            {
+                "title": "Forbidden",
+                "status": 403,
+                "code": "dataset_already_exists",
+                "detail": "forbidden - dataset already exists",
+                "request_id": "test-request-id"
+            }
             """
 
     Scenario: GET /datasets
@@ -204,25 +204,46 @@ Feature: Dataset API
             """
 
     Scenario: GET versions for unknown dataset returns not found error
+        Given I set the "X-Request-Id" header to "test-request-id"
         When I GET "/datasets/unknown-dataset/editions/hello/versions"
         Then the HTTP status code should be "404"
-        And I should receive the following response:
+        And I should receive the following JSON response:
             """
-            dataset not found
+            {
+                "title": "Not Found",
+                "status": 404,
+                "code": "dataset_not_found",
+                "detail": "dataset not found",
+                "request_id": "test-request-id"
+            }
             """
 
     Scenario: GET versions for unknown edition returns not found error
+        Given I set the "X-Request-Id" header to "test-request-id"
         When I GET "/datasets/population-estimates/editions/unknown/versions"
         Then the HTTP status code should be "404"
-        And I should receive the following response:
+        And I should receive the following JSON response:
             """
-            edition not found
+            {
+                "title": "Not Found",
+                "status": 404,
+                "code": "edition_not_found",
+                "detail": "edition not found",
+                "request_id": "test-request-id"
+            }
             """
 
     Scenario: GET versions for edition with no versions returns not found error
+        Given I set the "X-Request-Id" header to "test-request-id"
         When I GET "/datasets/population-estimates/editions/edition-with-no-versions/versions"
         Then the HTTP status code should be "404"
-        And I should receive the following response:
+        And I should receive the following JSON response:
             """
-            version not found
+            {
+                "title": "Not Found",
+                "status": 404,
+                "code": "version_not_found",
+                "detail": "version not found",
+                "request_id": "test-request-id"
+            }
             """
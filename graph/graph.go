@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	dpgraph "github.com/ONSdigital/dp-graph/v2/graph"
+	"github.com/ONSdigital/dp-graph/v2/neo4j"
+	"github.com/ONSdigital/dp-graph/v2/neptune"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/pkg/errors"
+)
+
+// instanceNodes find each kind of node imported for an instance: its observations, its dimension options and the
+// instance itself. The nodes are found by their labels, or the id of the instance node, so that the queries use the
+// label index rather than scanning every node. The dimension options are found through the instance node, so they are
+// removed before it.
+var instanceNodes = []struct {
+	neo4j   string
+	neptune string
+}{
+	{neo4j: "MATCH (n:`_%s_observation`)", neptune: "g.V().hasLabel('_%s_observation')"},
+	{neo4j: "MATCH (:`_%s_Instance`)-[:HAS_DIMENSION]->(n)", neptune: "g.V('_%s_Instance').in('HAS_DIMENSION')"},
+	{neo4j: "MATCH (n:`_%s_Instance`)", neptune: "g.V('_%s_Instance')"},
+}
+
+const (
+	neo4jCount  = " RETURN COUNT(n)"
+	neo4jDelete = " WITH n LIMIT %d DETACH DELETE n RETURN COUNT(*)"
+
+	neptuneCount  = ".count()"
+	neptuneDelete = ".limit(%d).sideEffect(drop()).count()"
+
+	// deleteBatchSize limits the number of nodes removed by a single query, so large instances do not time out
+	deleteBatchSize = 10000
+)
+
+// ErrUnsupportedDriver is returned when the configured graph driver cannot remove instance nodes
+var ErrUnsupportedDriver = errors.New("configured graph driver does not support removing instances")
+
+// Store wraps a dp-graph DB, adding the removal of instance nodes which dp-graph does not provide
+type Store struct {
+	*dpgraph.DB
+}
+
+// CountInstanceNodes returns the number of graph nodes imported for the instance
+func (s *Store) CountInstanceNodes(ctx context.Context, instanceID string) (int64, error) {
+	if err := validateInstanceID(instanceID); err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, nodes := range instanceNodes {
+		n, err := s.countNodes(nodes.neo4j, nodes.neptune, instanceID)
+		if err != nil {
+			return count, err
+		}
+		count += n
+	}
+	return count, nil
+}
+
+// DeleteInstanceNodes removes every graph node imported for the instance, along with their relationships, and returns
+// the number of nodes removed. Nodes are removed in batches, until a batch removes none.
+func (s *Store) DeleteInstanceNodes(ctx context.Context, instanceID string) (int64, error) {
+	if err := validateInstanceID(instanceID); err != nil {
+		return 0, err
+	}
+	logData := log.Data{"instance_id": instanceID}
+
+	var deleted int64
+	for _, nodes := range instanceNodes {
+		for {
+			n, err := s.deleteBatch(nodes.neo4j, nodes.neptune, instanceID)
+			if err != nil {
+				log.Event(ctx, "failed to delete batch of instance nodes", log.ERROR, log.Error(err), logData)
+				return deleted, err
+			}
+			if n == 0 {
+				break
+			}
+			deleted += n
+		}
+	}
+	return deleted, nil
+}
+
+func (s *Store) countNodes(neo4jMatch, neptuneMatch, instanceID string) (int64, error) {
+	switch d := s.Driver.(type) {
+	case *neo4j.Neo4j:
+		return d.Count(fmt.Sprintf(neo4jMatch+neo4jCount, instanceID))
+	case *neptune.NeptuneDB:
+		return d.Pool.GetCount(fmt.Sprintf(neptuneMatch+neptuneCount, instanceID), nil, nil)
+	default:
+		return 0, ErrUnsupportedDriver
+	}
+}
+
+// deleteBatch removes a batch of the matched nodes, returning the number removed
+func (s *Store) deleteBatch(neo4jMatch, neptuneMatch, instanceID string) (int64, error) {
+	switch d := s.Driver.(type) {
+	case *neo4j.Neo4j:
+		return d.Count(fmt.Sprintf(neo4jMatch+neo4jDelete, instanceID, deleteBatchSize))
+	case *neptune.NeptuneDB:
+		return d.Pool.GetCount(fmt.Sprintf(neptuneMatch+neptuneDelete, instanceID, deleteBatchSize), nil, nil)
+	default:
+		return 0, ErrUnsupportedDriver
+	}
+}
+
+// validateInstanceID ensures the instance ID can be safely used as part of a node label in a query
+func validateInstanceID(instanceID string) error {
+	if instanceID == "" || strings.ContainsAny(instanceID, "'`\"\\") {
+		return errors.Errorf("invalid instance id: %q", instanceID)
+	}
+	return nil
+}
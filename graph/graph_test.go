@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	dpgraph "github.com/ONSdigital/dp-graph/v2/graph"
+	"github.com/ONSdigital/dp-graph/v2/mock"
+	"github.com/ONSdigital/dp-graph/v2/neo4j"
+	"github.com/ONSdigital/dp-graph/v2/neo4j/neo4jdriver"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a graph store with a driver that cannot remove instances", t, func() {
+		store := &Store{DB: &dpgraph.DB{Driver: &mock.Mock{}}}
+
+		Convey("Then counting instance nodes returns an unsupported driver error", func() {
+			_, err := store.CountInstanceNodes(ctx, "123")
+			So(err, ShouldEqual, ErrUnsupportedDriver)
+		})
+
+		Convey("Then deleting instance nodes returns an unsupported driver error", func() {
+			deleted, err := store.DeleteInstanceNodes(ctx, "123")
+			So(err, ShouldEqual, ErrUnsupportedDriver)
+			So(deleted, ShouldEqual, 0)
+		})
+
+		Convey("Then an instance ID that cannot be used in a node label is rejected", func() {
+			_, err := store.CountInstanceNodes(ctx, "123') DETACH DELETE n //")
+			So(err, ShouldNotBeNil)
+			So(err, ShouldNotEqual, ErrUnsupportedDriver)
+		})
+	})
+}
+
+// countingDriver is a neo4j driver that answers count queries with the provided function, recording the queries
+type countingDriver struct {
+	neo4jdriver.Neo4jDriver
+	count   func(query string) int64
+	queries []string
+}
+
+func (d *countingDriver) Count(query string) (int64, error) {
+	d.queries = append(d.queries, query)
+	return d.count(query), nil
+}
+
+func TestDeleteInstanceNodes(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a neo4j graph holding the observations, dimension options and node of an instance", t, func() {
+		remaining := map[string]int64{"observations": 25000, "dimension options": 30, "instance": 1}
+		driver := &countingDriver{count: func(query string) int64 {
+			nodes := "instance"
+			if strings.Contains(query, "_observation") {
+				nodes = "observations"
+			} else if strings.Contains(query, "HAS_DIMENSION") {
+				nodes = "dimension options"
+			}
+
+			n := remaining[nodes]
+			if !strings.Contains(query, "DELETE") {
+				return n
+			}
+			if n > deleteBatchSize {
+				n = deleteBatchSize
+			}
+			remaining[nodes] -= n
+			return n
+		}}
+		store := &Store{DB: &dpgraph.DB{Driver: &neo4j.Neo4j{Neo4jDriver: driver}}}
+
+		Convey("Then every node of the instance is counted", func() {
+			count, err := store.CountInstanceNodes(ctx, "123")
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 25031)
+		})
+
+		Convey("When the nodes of the instance are deleted", func() {
+			deleted, err := store.DeleteInstanceNodes(ctx, "123")
+
+			Convey("Then the nodes are deleted in batches until a batch deletes none, and the number deleted is returned", func() {
+				So(err, ShouldBeNil)
+				So(deleted, ShouldEqual, 25031)
+				So(driver.queries, ShouldHaveLength, 8)
+			})
+
+			Convey("And the nodes are matched by their labels, rather than by scanning the labels of every node", func() {
+				So(driver.queries[0], ShouldStartWith, "MATCH (n:`_123_observation`)")
+				So(driver.queries[4], ShouldStartWith, "MATCH (:`_123_Instance`)-[:HAS_DIMENSION]->(n)")
+				So(driver.queries[6], ShouldStartWith, "MATCH (n:`_123_Instance`)")
+			})
+		})
+	})
+
+	Convey("Given a neo4j graph where nodes of an instance remain that cannot be deleted", t, func() {
+		driver := &countingDriver{count: func(query string) int64 {
+			if strings.Contains(query, "DELETE") {
+				return 0
+			}
+			return 10
+		}}
+		store := &Store{DB: &dpgraph.DB{Driver: &neo4j.Neo4j{Neo4jDriver: driver}}}
+
+		Convey("When the nodes of the instance are deleted", func() {
+			deleted, err := store.DeleteInstanceNodes(ctx, "123")
+
+			Convey("Then deleting stops once a batch deletes no nodes", func() {
+				So(err, ShouldBeNil)
+				So(deleted, ShouldEqual, 0)
+				So(driver.queries, ShouldHaveLength, len(instanceNodes))
+			})
+		})
+	})
+}
@@ -0,0 +1,85 @@
+package importtask
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-dataset-api/tracing"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	instanceIDEmptyErr = errors.New("failed to retry import tasks as instance ID was empty")
+	datasetIDEmptyErr  = errors.New("failed to retry import tasks as dataset ID was empty")
+)
+
+// KafkaProducer sends an outbound kafka message
+type KafkaProducer interface {
+	Output() chan []byte
+}
+
+// ImportRetryEvent marshal the event into avro format
+type ImportRetryEvent interface {
+	Marshal(s interface{}) ([]byte, error)
+}
+
+type importRetry struct {
+	InstanceID         string   `avro:"instance_id"`
+	DatasetID          string   `avro:"dataset_id"`
+	Dimensions         []string `avro:"dimensions"`
+	ImportObservations bool     `avro:"import_observations"`
+	TraceParent        string   `avro:"traceparent"`
+}
+
+// Retrier asks the importers to pick up the failed tasks of an instance again
+type Retrier struct {
+	Producer   KafkaProducer
+	Marshaller ImportRetryEvent
+}
+
+// Retry sends an import retry event for the provided instance. An empty list of dimensions
+// means that the tasks for all dimensions of the instance should be retried.
+func (r *Retrier) Retry(ctx context.Context, instanceID, datasetID string, dimensions []string, importObservations bool) (err error) {
+	ctx, span := tracing.StartProducerSpan(ctx, "importtask.Retry",
+		attribute.String("instance_id", instanceID),
+		attribute.String("dataset_id", datasetID),
+		attribute.StringSlice("dimensions", dimensions),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	if instanceID == "" {
+		return instanceIDEmptyErr
+	}
+	if datasetID == "" {
+		return datasetIDEmptyErr
+	}
+
+	if dimensions == nil {
+		dimensions = []string{}
+	}
+
+	event := importRetry{
+		InstanceID:         instanceID,
+		DatasetID:          datasetID,
+		Dimensions:         dimensions,
+		ImportObservations: importObservations,
+		TraceParent:        tracing.TraceParent(ctx),
+	}
+
+	log.Event(ctx, "send import retry event", log.INFO, log.Data{
+		"instance_id":         instanceID,
+		"dataset_id":          datasetID,
+		"dimensions":          dimensions,
+		"import_observations": importObservations,
+	})
+
+	avroBytes, err := r.Marshaller.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "error while attempting to marshal importRetryEvent to avro bytes")
+	}
+
+	r.Producer.Output() <- avroBytes
+
+	return nil
+}
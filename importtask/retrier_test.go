@@ -0,0 +1,116 @@
+package importtask
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/schema"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testContext = context.Background()
+
+func TestRetrier_RetryValidationErrors(t *testing.T) {
+	producerMock := &mocks.KafkaProducerMock{
+		OutputFunc: func() chan []byte {
+			return nil
+		},
+	}
+
+	marshallerMock := &mocks.GenerateDownloadsEventMock{
+		MarshalFunc: func(s interface{}) ([]byte, error) {
+			return nil, nil
+		},
+	}
+
+	retrier := Retrier{
+		Producer:   producerMock,
+		Marshaller: marshallerMock,
+	}
+
+	Convey("Given an empty instanceID", t, func() {
+		err := retrier.Retry(testContext, "", "123", nil, true)
+
+		Convey("Then the expected error is returned and no message is sent", func() {
+			So(err, ShouldEqual, instanceIDEmptyErr)
+			So(len(marshallerMock.MarshalCalls()), ShouldEqual, 0)
+			So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+		})
+	})
+
+	Convey("Given an empty datasetID", t, func() {
+		err := retrier.Retry(testContext, "789", "", nil, true)
+
+		Convey("Then the expected error is returned and no message is sent", func() {
+			So(err, ShouldEqual, datasetIDEmptyErr)
+			So(len(marshallerMock.MarshalCalls()), ShouldEqual, 0)
+			So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+		})
+	})
+}
+
+func TestRetrier_Retry(t *testing.T) {
+	Convey("Given a retrier with a working marshaller", t, func() {
+		output := make(chan []byte, 1)
+		producerMock := &mocks.KafkaProducerMock{
+			OutputFunc: func() chan []byte {
+				return output
+			},
+		}
+
+		retrier := Retrier{
+			Producer:   producerMock,
+			Marshaller: schema.ImportRetryEvent,
+		}
+
+		Convey("When retry is called for a set of dimensions", func() {
+			err := retrier.Retry(testContext, "789", "123", []string{"geography"}, false)
+
+			Convey("Then the expected event is sent to the producer", func() {
+				So(err, ShouldBeNil)
+				So(len(producerMock.OutputCalls()), ShouldEqual, 1)
+
+				var event importRetry
+				So(schema.ImportRetryEvent.Unmarshal(<-output, &event), ShouldBeNil)
+				So(event.InstanceID, ShouldEqual, "789")
+				So(event.DatasetID, ShouldEqual, "123")
+				So(event.Dimensions, ShouldContain, "geography")
+				So(event.ImportObservations, ShouldBeFalse)
+			})
+		})
+
+		Convey("When retry is called for the whole instance", func() {
+			err := retrier.Retry(testContext, "789", "123", nil, true)
+
+			Convey("Then the event is sent with an empty list of dimensions", func() {
+				So(err, ShouldBeNil)
+
+				var event importRetry
+				So(schema.ImportRetryEvent.Unmarshal(<-output, &event), ShouldBeNil)
+				So(event.Dimensions, ShouldBeEmpty)
+				So(event.ImportObservations, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a marshaller that fails", t, func() {
+		producerMock := &mocks.KafkaProducerMock{}
+		retrier := Retrier{
+			Producer: producerMock,
+			Marshaller: &mocks.GenerateDownloadsEventMock{
+				MarshalFunc: func(s interface{}) ([]byte, error) {
+					return nil, errors.New("marshal failed")
+				},
+			},
+		}
+
+		Convey("Then retry returns an error and no message is sent", func() {
+			err := retrier.Retry(testContext, "789", "123", nil, true)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "marshal failed")
+			So(len(producerMock.OutputCalls()), ShouldEqual, 0)
+		})
+	})
+}
@@ -6,7 +6,9 @@ import (
 	"net/http"
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/codelist"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/utils"
 	dphttp "github.com/ONSdigital/dp-net/http"
 	"github.com/ONSdigital/log.go/log"
 	"github.com/gorilla/mux"
@@ -27,7 +29,7 @@ func (s *Store) UpdateDimension(w http.ResponseWriter, r *http.Request) {
 
 	log.Event(ctx, "update instance dimension: update instance dimension", log.INFO, logData)
 
-	instance, err := s.GetInstance(instanceID, eTag)
+	instance, err := s.GetInstance(ctx, instanceID, eTag)
 	if err != nil {
 		log.Event(ctx, "update instance dimension: Failed to GET instance", log.ERROR, log.Error(err), logData)
 		handleInstanceErr(ctx, err, w, logData)
@@ -60,12 +62,12 @@ func (s *Store) UpdateDimension(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Update instance-dimension
-	notFound := true
+	var updated *models.Dimension
 	for i := range instance.Dimensions {
 
 		// For the chosen dimension
 		if instance.Dimensions[i].Name == dimension {
-			notFound = false
+			updated = &instance.Dimensions[i]
 			// Assign update info, conditionals to allow updating
 			// of both or either without blanking other
 			if dim.Label != "" {
@@ -78,12 +80,39 @@ func (s *Store) UpdateDimension(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if notFound {
+	if updated == nil {
 		log.Event(ctx, "update instance dimension: dimension not found", log.ERROR, log.Error(errs.ErrDimensionNotFound), logData)
 		handleInstanceErr(ctx, errs.ErrDimensionNotFound, w, logData)
 		return
 	}
 
+	var warnings []models.DimensionValidationIssue
+	if s.CodeListValidator != nil {
+		warnings, err = s.CodeListValidator.ValidateDimension(ctx, updated)
+		switch {
+		case err == nil:
+		case codelist.IsNotFound(err):
+			logData["code_list"] = updated.Links.CodeList.ID
+			log.Event(ctx, "update instance dimension: dimension does not match its code list", log.ERROR, log.Error(err), logData)
+			handleInstanceErr(ctx, err, w, logData)
+			return
+		default:
+			// the dimension is still updated when the code list api is unavailable, as the validation report of the
+			// instance checks it again
+			logData["code_list"] = updated.Links.CodeList.ID
+			log.Event(ctx, "update instance dimension: failed to check dimension against its code list", log.WARN, log.Error(err), logData)
+			warnings = []models.DimensionValidationIssue{{
+				Dimension: updated.Name,
+				CodeList:  updated.Links.CodeList.ID,
+				Message:   codelist.NotCheckedMessage,
+			}}
+		}
+		if len(warnings) > 0 {
+			logData["warnings"] = warnings
+			log.Event(ctx, "update instance dimension: dimension label does not match its code list", log.WARN, logData)
+		}
+	}
+
 	// Only update dimensions of an instance
 	instanceUpdate := &models.Instance{
 		Dimensions:      instance.Dimensions,
@@ -100,5 +129,6 @@ func (s *Store) UpdateDimension(w http.ResponseWriter, r *http.Request) {
 
 	log.Event(ctx, "updated instance dimension: request successful", log.INFO, logData)
 
+	utils.AddValidationWarnings(w, warnings)
 	setETag(w, newETag)
 }
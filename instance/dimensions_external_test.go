@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/codelist"
 	"github.com/ONSdigital/dp-dataset-api/mocks"
 	"github.com/ONSdigital/dp-dataset-api/models"
 	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
@@ -37,7 +38,7 @@ func Test_UpdateDimensionReturnsOk(t *testing.T) {
 
 	Convey("Given a dataset API with a successful store mock and auth", t, func() {
 		mockedDataStore := &storetest.StorerMock{
-			GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+			GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 				return &models.Instance{State: models.EditionConfirmedState,
 					InstanceID: "123",
 					Dimensions: []models.Dimension{{Name: "age", ID: "age"}}}, nil
@@ -119,7 +120,7 @@ func Test_UpdateDimensionReturnsInternalError(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return nil, errs.ErrInternalServer
 					},
 					UpdateInstanceFunc: func(ctx context.Context, currentInstance *models.Instance, updatedInstance *models.Instance, eTagSelector string) (string, error) {
@@ -152,7 +153,7 @@ func Test_UpdateDimensionReturnsInternalError(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return &models.Instance{State: "gobbly gook"}, nil
 					},
 					UpdateInstanceFunc: func(ctx context.Context, currentInstance *models.Instance, updatedInstance *models.Instance, eTagSelector string) (string, error) {
@@ -177,12 +178,12 @@ func Test_UpdateDimensionReturnsInternalError(t *testing.T) {
 
 		Convey("When the resource has a state of published", func() {
 			Convey("Then return status forbidden (403)", func() {
-				r, err := createRequestWithToken("PUT", "http://localhost:22000/instances/123/dimensions/age", nil)
+				r, err := createRequestWithToken("PUT", "http://localhost:22000/instances/123/dimensions/age", strings.NewReader(`{"label":"Age"}`))
 				So(err, ShouldBeNil)
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return &models.Instance{State: models.PublishedState}, nil
 					},
 				}
@@ -204,12 +205,12 @@ func Test_UpdateDimensionReturnsInternalError(t *testing.T) {
 
 		Convey("When the instance does not exist", func() {
 			Convey("Then return status not found (404) with message 'instance not found'", func() {
-				r, err := createRequestWithToken("PUT", "http://localhost:22000/instances/123/dimensions/age", nil)
+				r, err := createRequestWithToken("PUT", "http://localhost:22000/instances/123/dimensions/age", strings.NewReader(`{"label":"Age"}`))
 				So(err, ShouldBeNil)
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return nil, errs.ErrInstanceNotFound
 					},
 				}
@@ -237,7 +238,7 @@ func Test_UpdateDimensionReturnsInternalError(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return &models.Instance{State: models.EditionConfirmedState,
 							InstanceID: "123",
 							Dimensions: []models.Dimension{{Name: "age", ID: "age"}}}, nil
@@ -271,7 +272,7 @@ func Test_UpdateDimensionReturnsInternalError(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return &models.Instance{State: models.CompletedState}, nil
 					},
 				}
@@ -287,7 +288,7 @@ func Test_UpdateDimensionReturnsInternalError(t *testing.T) {
 				So(datasetPermissions.Required.Calls, ShouldEqual, 0)
 				So(permissions.Required.Calls, ShouldEqual, 1)
 
-				So(len(mockedDataStore.GetInstanceCalls()), ShouldEqual, 2)
+				So(len(mockedDataStore.GetInstanceCalls()), ShouldEqual, 0)
 				So(len(mockedDataStore.UpdateInstanceCalls()), ShouldEqual, 0)
 			})
 		})
@@ -301,7 +302,7 @@ func Test_UpdateDimensionReturnsInternalError(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return nil, errs.ErrInstanceConflict
 					},
 				}
@@ -324,3 +325,53 @@ func Test_UpdateDimensionReturnsInternalError(t *testing.T) {
 		})
 	})
 }
+
+func Test_UpdateDimensionValidatesCodeList(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given an instance dimension taken from a code list, and code list validation", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
+				return &models.Instance{State: models.EditionConfirmedState,
+					InstanceID: "123",
+					Dimensions: []models.Dimension{
+						{Name: "age", ID: "age", Links: models.DimensionLink{CodeList: models.LinkObject{ID: "age-list"}}},
+						{Name: "sex", ID: "sex", Links: models.DimensionLink{CodeList: models.LinkObject{ID: "unknown-list"}}},
+					}}, nil
+			},
+			UpdateInstanceFunc: func(ctx context.Context, currentInstance *models.Instance, updatedInstance *models.Instance, eTagSelector string) (string, error) {
+				return testETag, nil
+			},
+		}
+
+		codeLists := codelist.NewLocalCodeLists()
+		codeLists.Add(codelist.CodeList{ID: "age-list", Label: "Age"})
+		datasetAPI := getAPIWithCodeLists(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, mocks.NewAuthHandlerMock(), mocks.NewAuthHandlerMock(), codelist.NewValidator(codeLists))
+
+		Convey("When the dimension is given a label that does not match its code list", func() {
+			r, err := createRequestWithToken("PUT", "http://localhost:22000/instances/123/dimensions/age", strings.NewReader(`{"label":"ages"}`))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the dimension is updated, with a warning about the label", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Warning"), ShouldEqual, `299 - "label \"ages\" does not match the label \"Age\" in the code list"`)
+				So(mockedDataStore.UpdateInstanceCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a dimension taken from a code list that does not exist is updated", func() {
+			r, err := createRequestWithToken("PUT", "http://localhost:22000/instances/123/dimensions/sex", strings.NewReader(`{"label":"Sex"}`))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then a bad request is returned without the dimension being updated", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, `"code":"code_list_not_found"`)
+				So(mockedDataStore.UpdateInstanceCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/mongo"
 	"github.com/ONSdigital/log.go/log"
 )
 
@@ -18,7 +19,7 @@ func (s *Store) confirmEdition(ctx context.Context, datasetID, edition, instance
 
 		log.Event(ctx, "confirm edition: getting edition", log.INFO, logData)
 
-		editionDoc, err := s.GetEdition(datasetID, edition, "")
+		editionDoc, err := s.GetEdition(ctx, datasetID, edition, "")
 		if err != nil {
 			if err != errs.ErrEditionNotFound {
 				log.Event(ctx, "confirm edition: failed to confirm edition", log.ERROR, log.Error(err), logData)
@@ -26,7 +27,7 @@ func (s *Store) confirmEdition(ctx context.Context, datasetID, edition, instance
 			}
 
 			log.Event(ctx, "confirm edition: edition not found, creating", log.INFO, logData)
-			editionDoc, err = models.CreateEdition(s.Host, datasetID, edition)
+			editionDoc, err = models.CreateEdition(datasetID, edition)
 			if err != nil {
 				return nil, action, err
 			}
@@ -45,7 +46,10 @@ func (s *Store) confirmEdition(ctx context.Context, datasetID, edition, instance
 
 			log.Event(ctx, "confirm edition: edition found, updating", log.INFO, logData)
 
-			if err = editionDoc.UpdateLinks(ctx, s.Host); err != nil {
+			if editionDoc.IsEmpty() {
+				// the edition was created through the API, and this is its first version
+				editionDoc.SetFirstVersionLink()
+			} else if err = editionDoc.UpdateLinks(ctx); err != nil {
 				log.Event(ctx, "confirm edition: unable to update edition links", log.ERROR, log.Error(err), logData)
 				return nil, action, err
 			}
@@ -53,7 +57,7 @@ func (s *Store) confirmEdition(ctx context.Context, datasetID, edition, instance
 
 		editionDoc.Next.State = models.EditionConfirmedState
 
-		if err = s.UpsertEdition(datasetID, edition, editionDoc); err != nil {
+		if err = s.UpsertEdition(ctx, datasetID, edition, editionDoc, mongo.AnyETag); err != nil {
 			log.Event(ctx, "confirm edition: store.UpsertEdition returned an error", log.ERROR, log.Error(err), logData)
 			return nil, action, err
 		}
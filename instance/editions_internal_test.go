@@ -18,10 +18,10 @@ func Test_ConfirmEditionReturnsOK(t *testing.T) {
 
 	Convey("given no edition exists", t, func() {
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(dataset, edition, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, dataset, edition, state string) (*models.EditionUpdate, error) {
 				return nil, errs.ErrEditionNotFound
 			},
-			UpsertEditionFunc: func(dataset, edition string, doc *models.EditionUpdate) error {
+			UpsertEditionFunc: func(ctx context.Context, dataset, edition string, doc *models.EditionUpdate, eTagSelector string) error {
 				return nil
 			},
 		}
@@ -53,17 +53,17 @@ func Test_ConfirmEditionReturnsOK(t *testing.T) {
 					Links: &models.EditionUpdateLinks{
 						Dataset: &models.LinkObject{
 							ID:   datasetID,
-							HRef: fmt.Sprintf("%s/datasets/%s", s.Host, datasetID),
+							HRef: fmt.Sprintf("/datasets/%s", datasetID),
 						},
 						Self: &models.LinkObject{
-							HRef: fmt.Sprintf("%s/datasets/%s/editions/%s", s.Host, datasetID, editionName),
+							HRef: fmt.Sprintf("/datasets/%s/editions/%s", datasetID, editionName),
 						},
 						Versions: &models.LinkObject{
-							HRef: fmt.Sprintf("%s/datasets/%s/editions/%s/versions", s.Host, datasetID, editionName),
+							HRef: fmt.Sprintf("/datasets/%s/editions/%s/versions", datasetID, editionName),
 						},
 						LatestVersion: &models.LinkObject{
 							ID:   "1",
-							HRef: fmt.Sprintf("%s/datasets/%s/editions/%s/versions/1", s.Host, datasetID, editionName),
+							HRef: fmt.Sprintf("/datasets/%s/editions/%s/versions/1", datasetID, editionName),
 						},
 					},
 				})
@@ -78,7 +78,7 @@ func Test_ConfirmEditionReturnsOK(t *testing.T) {
 	if featureOn {
 		Convey("given an edition exists with 1 unpublished version", t, func() {
 			mockedDataStore := &storetest.StorerMock{
-				GetEditionFunc: func(dataset, edition, state string) (*models.EditionUpdate, error) {
+				GetEditionFunc: func(ctx context.Context, dataset, edition, state string) (*models.EditionUpdate, error) {
 					return &models.EditionUpdate{
 						ID: "test",
 						Next: &models.Edition{
@@ -89,7 +89,7 @@ func Test_ConfirmEditionReturnsOK(t *testing.T) {
 					}, nil
 				},
 
-				UpsertEditionFunc: func(dataset, edition string, doc *models.EditionUpdate) error {
+				UpsertEditionFunc: func(ctx context.Context, dataset, edition string, doc *models.EditionUpdate, eTagSelector string) error {
 					return errs.ErrInternalServer
 				},
 			}
@@ -117,7 +117,7 @@ func Test_ConfirmEditionReturnsOK(t *testing.T) {
 
 	Convey("given an edition exists with a published version 10", t, func() {
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(dataset, edition, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, dataset, edition, state string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					ID: "test",
 					Next: &models.Edition{
@@ -156,7 +156,7 @@ func Test_ConfirmEditionReturnsOK(t *testing.T) {
 					},
 				}, nil
 			},
-			UpsertEditionFunc: func(dataset, edition string, doc *models.EditionUpdate) error {
+			UpsertEditionFunc: func(ctx context.Context, dataset, edition string, doc *models.EditionUpdate, eTagSelector string) error {
 				return nil
 			},
 		}
@@ -189,12 +189,46 @@ func Test_ConfirmEditionReturnsOK(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("given an edition created without any versions", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetEditionFunc: func(ctx context.Context, dataset, edition, state string) (*models.EditionUpdate, error) {
+				editionDoc := models.CreateEmptyEdition(dataset, edition)
+				editionDoc.Next.Title = "Time series"
+				return editionDoc, nil
+			},
+			UpsertEditionFunc: func(ctx context.Context, dataset, edition string, doc *models.EditionUpdate, eTagSelector string) error {
+				return nil
+			},
+		}
+
+		s := Store{
+			Storer:              mockedDataStore,
+			Host:                "example.com",
+			EnableDetachDataset: true,
+		}
+
+		Convey("when confirmEdition is called", func() {
+			edition, err := s.confirmEdition(ctx, "1234", "time-series", "new-instance-1234")
+
+			Convey("then the edition is confirmed, keeping its metadata, and the version ID is 1", func() {
+				So(err, ShouldBeNil)
+				So(edition.Next.State, ShouldEqual, models.EditionConfirmedState)
+				So(edition.Next.Title, ShouldEqual, "Time series")
+				So(edition.Next.Links.LatestVersion, ShouldResemble, &models.LinkObject{
+					ID:   "1",
+					HRef: "/datasets/1234/editions/time-series/versions/1",
+				})
+				So(len(mockedDataStore.UpsertEditionCalls()), ShouldEqual, 1)
+			})
+		})
+	})
 }
 
 func Test_ConfirmEditionReturnsError(t *testing.T) {
 	Convey("given the datastore is unavailable", t, func() {
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(dataset, edition, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, dataset, edition, state string) (*models.EditionUpdate, error) {
 				return nil, errs.ErrInternalServer
 			},
 		}
@@ -220,7 +254,7 @@ func Test_ConfirmEditionReturnsError(t *testing.T) {
 
 	Convey("given an invalid edition exists", t, func() {
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(dataset, edition, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, dataset, edition, state string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					ID: "test",
 					Current: &models.Edition{
@@ -261,7 +295,7 @@ func Test_ConfirmEditionReturnsError(t *testing.T) {
 
 	Convey("given an edition exists with nil current doc", t, func() {
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(dataset, edition, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, dataset, edition, state string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					ID: "test",
 					Next: &models.Edition{
@@ -297,7 +331,7 @@ func Test_ConfirmEditionReturnsError(t *testing.T) {
 
 	Convey("given an edition exists with nil next doc", t, func() {
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(dataset, edition, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, dataset, edition, state string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					ID: "test",
 					Current: &models.Edition{
@@ -333,7 +367,7 @@ func Test_ConfirmEditionReturnsError(t *testing.T) {
 
 	Convey("given intermittent datastore failures", t, func() {
 		mockedDataStore := &storetest.StorerMock{
-			GetEditionFunc: func(dataset, edition, state string) (*models.EditionUpdate, error) {
+			GetEditionFunc: func(ctx context.Context, dataset, edition, state string) (*models.EditionUpdate, error) {
 				return &models.EditionUpdate{
 					ID: "test",
 					Next: &models.Edition{
@@ -372,7 +406,7 @@ func Test_ConfirmEditionReturnsError(t *testing.T) {
 					},
 				}, nil
 			},
-			UpsertEditionFunc: func(dataset, edition string, doc *models.EditionUpdate) error {
+			UpsertEditionFunc: func(ctx context.Context, dataset, edition string, doc *models.EditionUpdate, eTagSelector string) error {
 				return errs.ErrInternalServer
 			},
 		}
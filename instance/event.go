@@ -51,14 +51,14 @@ func (s *Store) AddEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	instance, err := s.GetInstance(instanceID, eTag)
+	instance, err := s.GetInstance(ctx, instanceID, eTag)
 	if err != nil {
 		log.Event(ctx, "add instance event: failed to get instance from datastore", log.ERROR, log.Error(err), data)
 		handleInstanceErr(ctx, err, w, data)
 		return
 	}
 
-	newETag, err := s.AddEventToInstance(instance, event, eTag)
+	newETag, err := s.AddEventToInstance(ctx, instance, event, eTag)
 	if err != nil {
 		log.Event(ctx, "add instance event: failed to add event to instance in datastore", log.ERROR, log.Error(err), data)
 		handleInstanceErr(ctx, err, w, data)
@@ -10,6 +10,7 @@ import (
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/utils"
 	dphttp "github.com/ONSdigital/dp-net/http"
 	"github.com/ONSdigital/log.go/log"
 	"github.com/gorilla/mux"
@@ -33,14 +34,14 @@ func (s *Store) UpdateObservations(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	instance, err := s.GetInstance(instanceID, eTag)
+	instance, err := s.GetInstance(ctx, instanceID, eTag)
 	if err != nil {
 		log.Event(ctx, "failed to get instance from database", log.ERROR, log.Error(err), logData)
 		handleInstanceErr(ctx, err, w, logData)
 		return
 	}
 
-	newETag, err := s.UpdateObservationInserted(instance, observations, eTag)
+	newETag, err := s.UpdateObservationInserted(ctx, instance, observations, eTag)
 	if err != nil {
 		log.Event(ctx, "update imported observations: store.UpdateObservationInserted returned an error", log.ERROR, log.Error(err), logData)
 		handleInstanceErr(ctx, err, w, logData)
@@ -65,7 +66,7 @@ func (s *Store) UpdateImportTask(w http.ResponseWriter, r *http.Request) {
 
 	handleError := func(updateErr *taskError) {
 		log.Event(ctx, "updateImportTask endpoint: request unsuccessful", log.ERROR, log.Error(updateErr), logData)
-		http.Error(w, updateErr.Error(), updateErr.status)
+		utils.WriteError(ctx, w, updateErr, updateErr.status)
 	}
 
 	tasks, err := unmarshalImportTasks(r.Body)
@@ -75,7 +76,7 @@ func (s *Store) UpdateImportTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	instance, err := s.GetInstance(instanceID, eTag)
+	instance, err := s.GetInstance(ctx, instanceID, eTag)
 	if err != nil {
 		log.Event(ctx, "failed to get instance from database", log.ERROR, log.Error(err), logData)
 		if err == errs.ErrInstanceConflict {
@@ -92,10 +93,10 @@ func (s *Store) UpdateImportTask(w http.ResponseWriter, r *http.Request) {
 	if tasks.ImportObservations != nil {
 		hasImportTasks = true
 		if tasks.ImportObservations.State != "" {
-			if tasks.ImportObservations.State != models.CompletedState {
+			if !models.IsValidTaskState(tasks.ImportObservations.State) {
 				validationErrs = append(validationErrs, fmt.Errorf("bad request - invalid task state value for import observations: %v", tasks.ImportObservations.State))
 			} else {
-				eTag, err = s.UpdateImportObservationsTaskState(instance, tasks.ImportObservations.State, eTag)
+				eTag, err = s.UpdateImportObservationsTaskState(ctx, instance, tasks.ImportObservations.State, eTag)
 				if err != nil {
 					log.Event(ctx, "failed to update import observations task state", log.ERROR, log.Error(err), logData)
 					handleError(&taskError{err, http.StatusInternalServerError})
@@ -115,7 +116,7 @@ func (s *Store) UpdateImportTask(w http.ResponseWriter, r *http.Request) {
 			if err := models.ValidateImportTask(task.GenericTaskDetails); err != nil {
 				validationErrs = append(validationErrs, err)
 			} else {
-				eTag, err = s.UpdateBuildHierarchyTaskState(instance, task.DimensionName, task.State, eTag)
+				eTag, err = s.UpdateBuildHierarchyTaskState(ctx, instance, task.DimensionName, task.State, eTag)
 				if err != nil {
 					if err.Error() == errs.ErrNotFound.Error() {
 						notFoundErr := task.DimensionName + " hierarchy import task does not exist"
@@ -142,7 +143,7 @@ func (s *Store) UpdateImportTask(w http.ResponseWriter, r *http.Request) {
 			if err := models.ValidateImportTask(task.GenericTaskDetails); err != nil {
 				validationErrs = append(validationErrs, err)
 			} else {
-				eTag, err = s.UpdateBuildSearchTaskState(instance, task.DimensionName, task.State, eTag)
+				eTag, err = s.UpdateBuildSearchTaskState(ctx, instance, task.DimensionName, task.State, eTag)
 				if err != nil {
 					if err.Error() == "not found" {
 						notFoundErr := task.DimensionName + " search index import task does not exist"
@@ -11,9 +11,13 @@ import (
 	"strings"
 
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/codelist"
+	"github.com/ONSdigital/dp-dataset-api/metrics"
 	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-dataset-api/mongo"
 	"github.com/ONSdigital/dp-dataset-api/store"
+	"github.com/ONSdigital/dp-dataset-api/url"
+	"github.com/ONSdigital/dp-dataset-api/utils"
 	dphttp "github.com/ONSdigital/dp-net/http"
 	"github.com/ONSdigital/log.go/log"
 	"github.com/gorilla/mux"
@@ -26,6 +30,13 @@ type Store struct {
 	store.Storer
 	Host                string
 	EnableDetachDataset bool
+	ImportRetrier       ImportRetrier
+	// CodeListValidator checks instance dimensions against their code lists when they are updated. Dimensions are
+	// not checked if it is nil.
+	CodeListValidator *codelist.Validator
+	// BaseURL returns the url the links to the api are rendered against in the response to the request. Links are
+	// rendered against the host if it is nil.
+	BaseURL func(r *http.Request) string
 }
 
 type taskError struct {
@@ -40,6 +51,10 @@ func (e taskError) Error() string {
 	return ""
 }
 
+func (e taskError) Unwrap() error {
+	return e.error
+}
+
 //GetList returns a list of instances, the total count of instances that match the query parameters and an error
 func (s *Store) GetList(w http.ResponseWriter, r *http.Request, limit int, offset int) (interface{}, int, error) {
 	ctx := r.Context()
@@ -98,7 +113,7 @@ func (s *Store) Get(w http.ResponseWriter, r *http.Request) {
 
 	log.Event(ctx, "get instance", log.INFO, logData)
 
-	instance, err := s.GetInstance(instanceID, eTag)
+	instance, err := s.GetInstance(ctx, instanceID, eTag)
 	if err != nil {
 		log.Event(ctx, "get instance: failed to retrieve instance", log.ERROR, log.Error(err), logData)
 		handleInstanceErr(ctx, err, w, logData)
@@ -115,6 +130,7 @@ func (s *Store) Get(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Event(ctx, "get instance: marshalling instance json", log.INFO, logData)
+	s.renderLinks(r, instance)
 	b, err := json.Marshal(instance)
 	if err != nil {
 		log.Event(ctx, "get instance: failed to marshal instance to json", log.ERROR, log.Error(err), logData)
@@ -147,16 +163,24 @@ func (s *Store) Add(w http.ResponseWriter, r *http.Request) {
 	logData["instance_id"] = instance.InstanceID
 
 	instance.Links.Self = &models.LinkObject{
-		HRef: fmt.Sprintf("%s/instances/%s", s.Host, instance.InstanceID),
+		HRef: fmt.Sprintf("/instances/%s", instance.InstanceID),
+	}
+
+	// links to this api are stored as paths, which are rendered against the url the api is requested from
+	for _, link := range []*models.LinkObject{instance.Links.Dataset, instance.Links.Dimensions} {
+		if link != nil {
+			link.HRef = url.RelativeLink(link.HRef, s.Host)
+		}
 	}
 
-	instance, err = s.AddInstance(instance)
+	instance, err = s.AddInstance(ctx, instance)
 	if err != nil {
 		log.Event(ctx, "add instance: store.AddInstance returned an error", log.ERROR, log.Error(err), logData)
 		handleInstanceErr(ctx, err, w, logData)
 		return
 	}
 
+	s.renderLinks(r, instance)
 	b, err := json.Marshal(instance)
 	if err != nil {
 		log.Event(ctx, "add instance: failed to marshal instance to json", log.ERROR, log.Error(err), logData)
@@ -203,10 +227,10 @@ func (s *Store) Update(w http.ResponseWriter, r *http.Request) {
 		handleInstanceErr(ctx, taskError{error: err, status: http.StatusInternalServerError}, w, logData)
 		return
 	}
-	defer s.UnlockInstance(lockID)
+	defer s.UnlockInstance(ctx, lockID)
 
 	// Get the current document
-	currentInstance, err := s.GetInstance(instanceID, eTag)
+	currentInstance, err := s.GetInstance(ctx, instanceID, eTag)
 	if err != nil {
 		log.Event(ctx, "update instance: store.GetInstance returned error", log.ERROR, log.Error(err), logData)
 		handleInstanceErr(ctx, err, w, logData)
@@ -265,6 +289,16 @@ func (s *Store) Update(w http.ResponseWriter, r *http.Request) {
 		log.Event(ctx, "update instance: added version details to instance", log.INFO, editionLogData)
 	}
 
+	// the dimension options are complete once the edition is confirmed, so they are summarised on the version to save
+	// counting them whenever the dimensions of the version are listed
+	if instance.State != currentInstance.State && (instance.State == models.EditionConfirmedState || instance.State == models.AssociatedState) {
+		if err = s.summariseDimensions(ctx, instanceID, currentInstance, instance); err != nil {
+			log.Event(ctx, "update instance: failed to summarise the dimensions of the instance", log.ERROR, log.Error(err), logData)
+			handleInstanceErr(ctx, err, w, logData)
+			return
+		}
+	}
+
 	// Set the current mongo timestamp on instance document
 	instance.UniqueTimestamp = currentInstance.UniqueTimestamp
 	newETag, err := s.UpdateInstance(ctx, currentInstance, instance, eTag)
@@ -273,13 +307,15 @@ func (s *Store) Update(w http.ResponseWriter, r *http.Request) {
 		handleInstanceErr(ctx, err, w, logData)
 		return
 	}
+	metrics.StateChanged(currentInstance.State, instance.State)
 
-	if instance, err = s.GetInstance(instanceID, newETag); err != nil {
+	if instance, err = s.GetInstance(ctx, instanceID, newETag); err != nil {
 		log.Event(ctx, "update instance: store.GetInstance for response returned an error", log.ERROR, log.Error(err), logData)
 		handleInstanceErr(ctx, err, w, logData)
 		return
 	}
 
+	s.renderLinks(r, instance)
 	b, err := json.Marshal(instance)
 	if err != nil {
 		log.Event(ctx, "add instance: failed to marshal instance to json", log.ERROR, log.Error(err), logData)
@@ -295,6 +331,24 @@ func (s *Store) Update(w http.ResponseWriter, r *http.Request) {
 	log.Event(ctx, "update instance: request successful", log.INFO, logData)
 }
 
+// summariseDimensions stores the summaries of the dimension options of the instance on the dimensions being updated,
+// or on the current dimensions when the update does not replace them
+func (s *Store) summariseDimensions(ctx context.Context, instanceID string, currentInstance, instance *models.Instance) error {
+	summaries, err := s.GetDimensionSummaries(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+
+	dimensions := instance.Dimensions
+	if dimensions == nil {
+		dimensions = currentInstance.Dimensions
+	}
+
+	instance.Dimensions = models.SummariseDimensions(dimensions, summaries, currentInstance.ImportTasks)
+	log.Event(ctx, "update instance: summarised the dimensions of the instance", log.INFO, log.Data{"instance_id": instanceID, "dimensions": len(instance.Dimensions)})
+	return nil
+}
+
 func validateInstanceUpdate(instance *models.Instance) error {
 	var fieldsUnableToUpdate []string
 	if instance.Links != nil {
@@ -334,8 +388,7 @@ func validateInstanceUpdate(instance *models.Instance) error {
 	}
 
 	if len(fieldsUnableToUpdate) > 0 {
-		err := fmt.Errorf("unable to update instance contains invalid fields: %s", fieldsUnableToUpdate)
-		return err
+		return &models.FieldsError{Reason: "unable to update instance contains invalid fields", Fields: fieldsUnableToUpdate}
 	}
 
 	return nil
@@ -432,7 +485,7 @@ func unmarshalInstance(ctx context.Context, reader io.Reader, post bool) (*model
 
 func internalError(ctx context.Context, w http.ResponseWriter, err error, logData log.Data) {
 	log.Event(ctx, "internal server error", log.ERROR, log.Error(err), logData)
-	http.Error(w, err.Error(), http.StatusInternalServerError)
+	utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
 }
 
 func setJSONContentType(w http.ResponseWriter) {
@@ -451,10 +504,20 @@ func setETag(w http.ResponseWriter, eTag string) {
 	w.Header().Set("ETag", eTag)
 }
 
+// renderLinks renders the links to the api held by the instance, which are stored as paths, in the response to the
+// request
+func (s *Store) renderLinks(r *http.Request, instance *models.Instance) {
+	baseURL := s.Host
+	if s.BaseURL != nil {
+		baseURL = s.BaseURL(r)
+	}
+	models.RenderLinks(instance, baseURL)
+}
+
 func writeBody(ctx context.Context, w http.ResponseWriter, b []byte, logData log.Data) {
 	if _, err := w.Write(b); err != nil {
 		log.Event(ctx, "failed to write http response body", log.FATAL, log.Error(err), logData)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		utils.WriteError(ctx, w, errs.ErrInternalServer, http.StatusInternalServerError)
 	}
 }
 
@@ -472,7 +535,7 @@ func (d *PublishCheck) Check(handle func(http.ResponseWriter, *http.Request)) ht
 		eTag := getIfMatch(r)
 		logData := log.Data{"action": "CheckAction", "instance_id": instanceID}
 
-		if err := d.checkState(instanceID, eTag); err != nil {
+		if err := d.checkState(ctx, instanceID, eTag); err != nil {
 			log.Event(ctx, "errored whilst checking instance state", log.ERROR, log.Error(err), logData)
 			handleInstanceErr(ctx, err, w, logData)
 			return
@@ -482,13 +545,13 @@ func (d *PublishCheck) Check(handle func(http.ResponseWriter, *http.Request)) ht
 	})
 }
 
-func (d *PublishCheck) checkState(instanceID, eTagSelector string) error {
-	instance, err := d.Datastore.GetInstance(instanceID, eTagSelector)
+func (d *PublishCheck) checkState(ctx context.Context, instanceID, eTagSelector string) error {
+	instance, err := d.Datastore.GetInstance(ctx, instanceID, eTagSelector)
 	if err != nil {
 		return err
 	}
 
-	if instance.State == models.PublishedState {
+	if models.IsPublished(instance.State) {
 		return errs.ErrResourcePublished
 	}
 
@@ -527,5 +590,5 @@ func handleInstanceErr(ctx context.Context, err error, w http.ResponseWriter, lo
 
 	logData["responseStatus"] = status
 	log.Event(ctx, "request unsuccessful", log.ERROR, log.Error(err), logData)
-	http.Error(w, response.Error(), status)
+	utils.WriteError(ctx, w, response, status)
 }
@@ -42,23 +42,41 @@ func Test_UpdateInstanceToEditionConfirmedReturnsOk(t *testing.T) {
 						},
 					},
 					State: models.CompletedState,
+					Dimensions: []models.Dimension{
+						{Name: "age", Label: "Age"},
+						{Name: "sex", Label: "Sex"},
+					},
+					ImportTasks: &models.InstanceImportTasks{
+						BuildHierarchyTasks: []*models.BuildHierarchyTask{
+							{GenericTaskDetails: models.GenericTaskDetails{DimensionName: "age"}},
+						},
+					},
 				}
 
 				mockedDataStore, isLocked := storeMockWithLock(currentInstanceTest_Data, true)
-				mockedDataStore.GetEditionFunc = func(datasetID string, edition string, state string) (*models.EditionUpdate, error) {
+				mockedDataStore.GetEditionFunc = func(ctx context.Context, datasetID string, edition string, state string) (*models.EditionUpdate, error) {
 					So(*isLocked, ShouldBeTrue)
 					return nil, errs.ErrEditionNotFound
 				}
-				mockedDataStore.UpsertEditionFunc = func(datasetID, edition string, editionDoc *models.EditionUpdate) error {
+				mockedDataStore.UpsertEditionFunc = func(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error {
 					So(*isLocked, ShouldBeTrue)
 					return nil
 				}
-				mockedDataStore.GetNextVersionFunc = func(string, string) (int, error) {
+				mockedDataStore.GetNextVersionFunc = func(context.Context, string, string) (int, error) {
 					So(*isLocked, ShouldBeTrue)
 					return 1, nil
 				}
+				mockedDataStore.GetDimensionSummariesFunc = func(ctx context.Context, instanceID string) ([]*models.DimensionSummary, error) {
+					So(*isLocked, ShouldBeTrue)
+					return []*models.DimensionSummary{
+						{Name: "age", NumberOfOptions: 2, FirstOption: "24", CodeList: models.LinkObject{ID: "age-list"}},
+						{Name: "geography", NumberOfOptions: 1, FirstOption: "K02000001", CodeList: models.LinkObject{ID: "geography-list"}},
+					}, nil
+				}
+				var updated *models.Instance
 				mockedDataStore.UpdateInstanceFunc = func(ctx context.Context, currentInstance *models.Instance, updatedInstance *models.Instance, eTagSelector string) (string, error) {
 					So(*isLocked, ShouldBeTrue)
+					updated = updatedInstance
 					return testETag, nil
 				}
 				mockedDataStore.AddVersionDetailsToInstanceFunc = func(ctx context.Context, instanceID string, datasetID string, edition string, version int) error {
@@ -81,6 +99,15 @@ func Test_UpdateInstanceToEditionConfirmedReturnsOk(t *testing.T) {
 				So(len(mockedDataStore.UpdateInstanceCalls()), ShouldEqual, 1)
 				So(len(mockedDataStore.AddVersionDetailsToInstanceCalls()), ShouldEqual, 1)
 				So(*isLocked, ShouldBeFalse)
+
+				So(mockedDataStore.GetDimensionSummariesCalls(), ShouldHaveLength, 1)
+				So(mockedDataStore.GetDimensionSummariesCalls()[0].InstanceID, ShouldEqual, "123")
+				two, zero, one := 2, 0, 1
+				So(updated.Dimensions, ShouldResemble, []models.Dimension{
+					{Name: "age", Label: "Age", NumberOfOptions: &two, FirstOption: "24", IsHierarchy: true, Links: models.DimensionLink{CodeList: models.LinkObject{ID: "age-list"}}},
+					{Name: "sex", Label: "Sex", NumberOfOptions: &zero},
+					{Name: "geography", NumberOfOptions: &one, FirstOption: "K02000001", Links: models.DimensionLink{CodeList: models.LinkObject{ID: "geography-list"}}},
+				})
 			})
 		})
 	})
@@ -117,15 +144,15 @@ func Test_UpdateInstanceToEditionConfirmedReturnsError(t *testing.T) {
 				}
 
 				mockedDataStore, isLocked := storeMockWithLock(currentInstanceTest_Data, true)
-				mockedDataStore.GetEditionFunc = func(datasetID string, edition string, state string) (*models.EditionUpdate, error) {
+				mockedDataStore.GetEditionFunc = func(ctx context.Context, datasetID string, edition string, state string) (*models.EditionUpdate, error) {
 					So(*isLocked, ShouldBeTrue)
 					return nil, errs.ErrEditionNotFound
 				}
-				mockedDataStore.UpsertEditionFunc = func(datasetID, edition string, editionDoc *models.EditionUpdate) error {
+				mockedDataStore.UpsertEditionFunc = func(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error {
 					So(*isLocked, ShouldBeTrue)
 					return nil
 				}
-				mockedDataStore.GetNextVersionFunc = func(string, string) (int, error) {
+				mockedDataStore.GetNextVersionFunc = func(context.Context, string, string) (int, error) {
 					So(*isLocked, ShouldBeTrue)
 					return 1, nil
 				}
@@ -156,6 +183,42 @@ func Test_UpdateInstanceToEditionConfirmedReturnsError(t *testing.T) {
 			})
 		})
 
+		Convey(`When request updates state to 'associated'
+        but fails to summarise the dimensions of the instance`, func() {
+			Convey("Then return status internal server error (500)", func() {
+				body := strings.NewReader(`{"state":"associated"}`)
+				r, err := createRequestWithToken("PUT", "http://localhost:21800/instances/123", body)
+				So(err, ShouldBeNil)
+				w := httptest.NewRecorder()
+
+				currentInstanceTest_Data := &models.Instance{
+					Edition: "2017",
+					Links: &models.InstanceLinks{
+						Dataset: &models.LinkObject{
+							ID: "4567",
+						},
+					},
+					State: models.EditionConfirmedState,
+				}
+
+				mockedDataStore, isLocked := storeMockWithLock(currentInstanceTest_Data, true)
+				mockedDataStore.GetDimensionSummariesFunc = func(ctx context.Context, instanceID string) ([]*models.DimensionSummary, error) {
+					So(*isLocked, ShouldBeTrue)
+					return nil, errors.New("boom")
+				}
+				datasetPermissions := mocks.NewAuthHandlerMock()
+				permissions := mocks.NewAuthHandlerMock()
+
+				datasetAPI := getAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, datasetPermissions, permissions)
+				datasetAPI.Router.ServeHTTP(w, r)
+
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+				So(len(mockedDataStore.GetDimensionSummariesCalls()), ShouldEqual, 1)
+				So(len(mockedDataStore.UpdateInstanceCalls()), ShouldEqual, 0)
+				So(*isLocked, ShouldBeFalse)
+			})
+		})
+
 		Convey(`When request updates instance from a state 'edition-confirmed' to 'completed'`, func() {
 			Convey("Then return status forbidden (403)", func() {
 				body := strings.NewReader(`{"state":"completed"}`)
@@ -213,11 +276,11 @@ func storeMockWithLock(instance *models.Instance, expectFirstGetUnlocked bool) (
 			isLocked = true
 			return testLockID, nil
 		},
-		UnlockInstanceFunc: func(lockID string) error {
+		UnlockInstanceFunc: func(ctx context.Context, lockID string) error {
 			isLocked = false
 			return nil
 		},
-		GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+		GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 			if expectFirstGetUnlocked {
 				if numGetCall > 0 {
 					So(isLocked, ShouldBeTrue)
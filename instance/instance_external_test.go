@@ -11,6 +11,8 @@ import (
 
 	"github.com/ONSdigital/dp-dataset-api/api"
 	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/codelist"
+	"github.com/ONSdigital/dp-dataset-api/collection"
 	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/instance"
 	"github.com/ONSdigital/dp-dataset-api/mocks"
@@ -182,7 +184,7 @@ func Test_GetInstanceReturnsOK(t *testing.T) {
 
 	Convey("Given a dataset API with a successful store mock and auth", t, func() {
 		mockedDataStore := &storetest.StorerMock{
-			GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+			GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 				return &models.Instance{
 					State: models.CreatedState,
 					ETag:  testETag,
@@ -248,7 +250,7 @@ func Test_GetInstanceReturnsError(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return nil, errs.ErrInternalServer
 					},
 				}
@@ -274,7 +276,7 @@ func Test_GetInstanceReturnsError(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return &models.Instance{State: "gobbledygook"}, nil
 					},
 				}
@@ -299,7 +301,7 @@ func Test_GetInstanceReturnsError(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return nil, errs.ErrInstanceNotFound
 					},
 				}
@@ -326,7 +328,7 @@ func Test_GetInstanceReturnsError(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return nil, errs.ErrInstanceConflict
 					},
 				}
@@ -359,7 +361,7 @@ func Test_AddInstanceReturnsCreated(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					AddInstanceFunc: func(*models.Instance) (*models.Instance, error) {
+					AddInstanceFunc: func(context.Context, *models.Instance) (*models.Instance, error) {
 						return &models.Instance{
 							ETag: testETag,
 						}, nil
@@ -382,6 +384,39 @@ func Test_AddInstanceReturnsCreated(t *testing.T) {
 	})
 }
 
+func Test_AddInstanceStoresRelativeLinks(t *testing.T) {
+	t.Parallel()
+	Convey("Given a POST request to create an instance linked to a dataset of this api", t, func() {
+		body := strings.NewReader(`{"links": { "job": { "id":"123-456", "href":"http://localhost:2200/jobs/123-456" }, "dataset": { "id":"cpih01", "href":"http://localhost:22000/datasets/cpih01" } } }`)
+		r, err := createRequestWithToken("POST", "http://localhost:21800/instances", body)
+		So(err, ShouldBeNil)
+		w := httptest.NewRecorder()
+
+		// the links are recorded as they are stored, before they are rendered in the response
+		var storedLinks []string
+		mockedDataStore := &storetest.StorerMock{
+			AddInstanceFunc: func(ctx context.Context, instance *models.Instance) (*models.Instance, error) {
+				storedLinks = []string{instance.Links.Self.HRef, instance.Links.Dataset.HRef, instance.Links.Job.HRef}
+				return instance, nil
+			},
+		}
+
+		datasetAPI := getAPIWithMocks(testContext, mockedDataStore, &mocks.DownloadsGeneratorMock{}, mocks.NewAuthHandlerMock(), mocks.NewAuthHandlerMock())
+		datasetAPI.Router.ServeHTTP(w, r)
+
+		Convey("Then the links to this api are stored as paths and rendered in the response", func() {
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(mockedDataStore.AddInstanceCalls(), ShouldHaveLength, 1)
+
+			So(storedLinks, ShouldHaveLength, 3)
+			So(storedLinks[0], ShouldStartWith, "/instances/")
+			So(storedLinks[1], ShouldEqual, "/datasets/cpih01")
+			So(storedLinks[2], ShouldEqual, "http://localhost:2200/jobs/123-456")
+			So(w.Body.String(), ShouldContainSubstring, `"href":"http://localhost:22000/datasets/cpih01"`)
+		})
+	})
+}
+
 func Test_AddInstanceReturnsError(t *testing.T) {
 	t.Parallel()
 	Convey("Given a POST request to create an instance resources", t, func() {
@@ -392,7 +427,7 @@ func Test_AddInstanceReturnsError(t *testing.T) {
 				So(err, ShouldBeNil)
 				w := httptest.NewRecorder()
 				mockedDataStore := &storetest.StorerMock{
-					AddInstanceFunc: func(*models.Instance) (*models.Instance, error) {
+					AddInstanceFunc: func(context.Context, *models.Instance) (*models.Instance, error) {
 						return nil, errs.ErrInternalServer
 					},
 				}
@@ -418,7 +453,7 @@ func Test_AddInstanceReturnsError(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					AddInstanceFunc: func(*models.Instance) (*models.Instance, error) {
+					AddInstanceFunc: func(context.Context, *models.Instance) (*models.Instance, error) {
 						return &models.Instance{}, nil
 					},
 				}
@@ -444,7 +479,7 @@ func Test_AddInstanceReturnsError(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					AddInstanceFunc: func(*models.Instance) (*models.Instance, error) {
+					AddInstanceFunc: func(context.Context, *models.Instance) (*models.Instance, error) {
 						return &models.Instance{}, nil
 					},
 				}
@@ -575,7 +610,7 @@ func Test_UpdateInstanceReturnsError(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return nil, errs.ErrInternalServer
 					},
 				}
@@ -603,7 +638,7 @@ func Test_UpdateInstanceReturnsError(t *testing.T) {
 				So(err, ShouldBeNil)
 				w := httptest.NewRecorder()
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return &models.Instance{State: "gobbledygook"}, nil
 					},
 				}
@@ -631,7 +666,7 @@ func Test_UpdateInstanceReturnsError(t *testing.T) {
 				So(err, ShouldBeNil)
 				w := httptest.NewRecorder()
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return &models.Instance{State: "completed"}, nil
 					},
 				}
@@ -647,7 +682,7 @@ func Test_UpdateInstanceReturnsError(t *testing.T) {
 				So(datasetPermissions.Required.Calls, ShouldEqual, 0)
 				So(permissions.Required.Calls, ShouldEqual, 1)
 
-				So(len(mockedDataStore.GetInstanceCalls()), ShouldEqual, 1)
+				So(len(mockedDataStore.GetInstanceCalls()), ShouldEqual, 0)
 				So(len(mockedDataStore.AddVersionDetailsToInstanceCalls()), ShouldEqual, 0)
 			})
 		})
@@ -659,7 +694,7 @@ func Test_UpdateInstanceReturnsError(t *testing.T) {
 				So(err, ShouldBeNil)
 				w := httptest.NewRecorder()
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return &models.Instance{State: "completed"}, nil
 					},
 					UpdateInstanceFunc: func(ctx context.Context, currentInstance *models.Instance, updatedInstance *models.Instance, eTagSelector string) (string, error) {
@@ -692,7 +727,7 @@ func Test_UpdateInstanceReturnsError(t *testing.T) {
 				So(err, ShouldBeNil)
 				w := httptest.NewRecorder()
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return nil, errs.ErrInstanceNotFound
 					},
 				}
@@ -723,7 +758,7 @@ func Test_UpdateInstanceReturnsError(t *testing.T) {
 				So(err, ShouldBeNil)
 				w := httptest.NewRecorder()
 				mockedDataStore := &storetest.StorerMock{
-					GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+					GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 						return nil, errs.ErrInstanceConflict
 					},
 				}
@@ -751,6 +786,10 @@ func Test_UpdateInstanceReturnsError(t *testing.T) {
 var urlBuilder = url.NewBuilder("localhost:20000")
 
 func getAPIWithMocks(ctx context.Context, mockedDataStore store.Storer, mockedGeneratedDownloads api.DownloadsGenerator, datasetPermissions api.AuthHandler, permissions api.AuthHandler) *api.DatasetAPI {
+	return getAPIWithCodeLists(ctx, mockedDataStore, mockedGeneratedDownloads, datasetPermissions, permissions, nil)
+}
+
+func getAPIWithCodeLists(ctx context.Context, mockedDataStore store.Storer, mockedGeneratedDownloads api.DownloadsGenerator, datasetPermissions api.AuthHandler, permissions api.AuthHandler, codeListValidator *codelist.Validator) *api.DatasetAPI {
 	mu.Lock()
 	defer mu.Unlock()
 	cfg, err := config.Get()
@@ -759,5 +798,5 @@ func getAPIWithMocks(ctx context.Context, mockedDataStore store.Storer, mockedGe
 	cfg.DatasetAPIURL = "http://localhost:22000"
 	cfg.EnablePrivateEndpoints = true
 
-	return api.Setup(ctx, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, mockedGeneratedDownloads, datasetPermissions, permissions)
+	return api.Setup(ctx, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, mockedGeneratedDownloads, &mocks.ImportRetrierMock{}, &mocks.DatasetEventEmitterMock{}, collection.NewLocalChecker(), collection.NewLocalCollections(), codeListValidator, datasetPermissions, permissions)
 }
@@ -0,0 +1,227 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	dphttp "github.com/ONSdigital/dp-net/http"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/gorilla/mux"
+)
+
+//go:generate moq -out ../mocks/import_retrier_mocks.go -pkg mocks . ImportRetrier
+
+// RetryImportTasksAction represents the action to retry the import tasks of an instance
+const RetryImportTasksAction = "retryImportTasks"
+
+// The types of the events recorded against an instance when its import tasks are retried, and when the retry could not
+// be sent to the importers so the tasks were restored
+const (
+	retryEventType       = "retry"
+	retryFailedEventType = "retry_failed"
+)
+
+// ImportRetrier asks the importers to pick up the import tasks of an instance again
+type ImportRetrier interface {
+	Retry(ctx context.Context, instanceID, datasetID string, dimensions []string, importObservations bool) error
+}
+
+// retryableStates are the instance states from which the import tasks can be retried
+var retryableStates = map[string]bool{
+	models.SubmittedState: true,
+	models.CompletedState: true,
+	models.FailedState:    true,
+}
+
+// RetryImportTasks resets the import tasks of an instance, either for the whole instance or
+// for the dimensions in the request body, and asks the importers to process them again
+func (s *Store) RetryImportTasks(w http.ResponseWriter, r *http.Request) {
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	instanceID := vars["instance_id"]
+	eTag := getIfMatch(r)
+	logData := log.Data{"instance_id": instanceID, "action": RetryImportTasksAction}
+
+	retry, err := unmarshalImportRetry(r.Body)
+	if err != nil {
+		log.Event(ctx, "retry import tasks: failed to unmarshal request body", log.ERROR, log.Error(err), logData)
+		handleInstanceErr(ctx, err, w, logData)
+		return
+	}
+	logData["dimensions"] = retry.Dimensions
+	logData["reset_inserted_observations"] = retry.ResetInsertedObservations
+
+	instance, err := s.GetInstance(ctx, instanceID, eTag)
+	if err != nil {
+		log.Event(ctx, "retry import tasks: failed to get instance from datastore", log.ERROR, log.Error(err), logData)
+		handleInstanceErr(ctx, err, w, logData)
+		return
+	}
+
+	tasks, err := resetImportTasks(instance, retry)
+	if err != nil {
+		log.Event(ctx, "retry import tasks: unable to reset import tasks", log.ERROR, log.Error(err), logData)
+		handleInstanceErr(ctx, err, w, logData)
+		return
+	}
+
+	now := time.Now().UTC()
+	event := &models.Event{
+		Type:    retryEventType,
+		Message: retryEventMessage(retry),
+		Time:    &now,
+	}
+
+	newETag, err := s.Storer.RetryImportTasks(ctx, instance, models.SubmittedState, tasks, event, eTag)
+	if err != nil {
+		log.Event(ctx, "retry import tasks: store.RetryImportTasks returned an error", log.ERROR, log.Error(err), logData)
+		handleInstanceErr(ctx, err, w, logData)
+		return
+	}
+
+	var datasetID string
+	if instance.Links != nil && instance.Links.Dataset != nil {
+		datasetID = instance.Links.Dataset.ID
+	}
+
+	importObservations := len(retry.Dimensions) == 0
+	if err := s.ImportRetrier.Retry(ctx, instanceID, datasetID, retry.Dimensions, importObservations); err != nil {
+		log.Event(ctx, "retry import tasks: failed to send import retry event", log.ERROR, log.Error(err), logData)
+		s.restoreImportTasks(ctx, instance, newETag, logData)
+		handleInstanceErr(ctx, err, w, logData)
+		return
+	}
+
+	log.Event(ctx, "retry import tasks: request successful", log.INFO, logData)
+	setETag(w, newETag)
+}
+
+// restoreImportTasks moves an instance whose retry could not be sent back to its state and import tasks before the
+// retry, so that the retry can be requested again. The restore fails if the instance has changed since the retry.
+func (s *Store) restoreImportTasks(ctx context.Context, instance *models.Instance, eTag string, logData log.Data) {
+	now := time.Now().UTC()
+	event := &models.Event{
+		Type:    retryFailedEventType,
+		Message: "failed to send import retry, import tasks restored",
+		Time:    &now,
+	}
+
+	if _, err := s.Storer.RetryImportTasks(ctx, instance, instance.State, instance.ImportTasks, event, eTag); err != nil {
+		log.Event(ctx, "retry import tasks: failed to restore import tasks", log.ERROR, log.Error(err), logData)
+	}
+}
+
+// resetImportTasks returns a copy of the instance import tasks with the tasks in scope of the retry set back to
+// created. An error is returned if the instance cannot be retried, if a requested dimension has no import tasks
+// or if neither the instance nor any of the tasks in scope have failed.
+func resetImportTasks(instance *models.Instance, retry *models.ImportRetry) (*models.InstanceImportTasks, error) {
+	if !retryableStates[instance.State] {
+		return nil, errs.ErrImportTasksNotRetryable
+	}
+
+	tasks := &models.InstanceImportTasks{}
+	if instance.ImportTasks != nil {
+		tasks.BuildHierarchyTasks = make([]*models.BuildHierarchyTask, 0, len(instance.ImportTasks.BuildHierarchyTasks))
+		tasks.BuildSearchIndexTasks = make([]*models.BuildSearchIndexTask, 0, len(instance.ImportTasks.BuildSearchIndexTasks))
+		for _, task := range instance.ImportTasks.BuildHierarchyTasks {
+			t := *task
+			tasks.BuildHierarchyTasks = append(tasks.BuildHierarchyTasks, &t)
+		}
+		for _, task := range instance.ImportTasks.BuildSearchIndexTasks {
+			t := *task
+			tasks.BuildSearchIndexTasks = append(tasks.BuildSearchIndexTasks, &t)
+		}
+		if instance.ImportTasks.ImportObservations != nil {
+			t := *instance.ImportTasks.ImportObservations
+			tasks.ImportObservations = &t
+		}
+	}
+
+	wholeInstance := len(retry.Dimensions) == 0
+	inScope := make(map[string]bool)
+	for _, dimension := range retry.Dimensions {
+		inScope[dimension] = false
+	}
+
+	hasFailed := instance.State == models.FailedState
+	reset := func(task *models.GenericTaskDetails) {
+		if _, ok := inScope[task.DimensionName]; !ok && !wholeInstance {
+			return
+		}
+		inScope[task.DimensionName] = true
+		if task.State == models.FailedState {
+			hasFailed = true
+		}
+		task.State = models.CreatedState
+	}
+
+	for _, task := range tasks.BuildHierarchyTasks {
+		reset(&task.GenericTaskDetails)
+	}
+	for _, task := range tasks.BuildSearchIndexTasks {
+		reset(&task.GenericTaskDetails)
+	}
+
+	if !wholeInstance {
+		for _, found := range inScope {
+			if !found {
+				return nil, errs.ErrDimensionNotFound
+			}
+		}
+	}
+
+	if wholeInstance && tasks.ImportObservations != nil {
+		if tasks.ImportObservations.State == models.FailedState {
+			hasFailed = true
+		}
+		tasks.ImportObservations.State = models.CreatedState
+	}
+
+	// observations are only imported again when the whole instance is retried
+	if wholeInstance && retry.ResetInsertedObservations && tasks.ImportObservations != nil {
+		tasks.ImportObservations.InsertedObservations = 0
+	}
+
+	if !hasFailed {
+		return nil, errs.ErrNoFailedImportTasks
+	}
+
+	return tasks, nil
+}
+
+func retryEventMessage(retry *models.ImportRetry) string {
+	message := "retrying all import tasks"
+	if len(retry.Dimensions) > 0 {
+		message = fmt.Sprintf("retrying import tasks for dimensions: %v", retry.Dimensions)
+	} else if retry.ResetInsertedObservations {
+		message += ", inserted observations reset"
+	}
+	return message
+}
+
+func unmarshalImportRetry(reader io.Reader) (*models.ImportRetry, error) {
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, errs.ErrUnableToReadMessage
+	}
+
+	var retry models.ImportRetry
+	if len(b) == 0 {
+		return &retry, nil
+	}
+
+	if err := json.Unmarshal(b, &retry); err != nil {
+		return nil, errs.ErrUnableToParseJSON
+	}
+
+	return &retry, nil
+}
@@ -0,0 +1,329 @@
+package instance_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/api"
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/collection"
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/instance"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/store"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const retryURL = "http://localhost:21800/instances/123/import_tasks/retry"
+
+func failedImportInstance() *models.Instance {
+	return &models.Instance{
+		InstanceID: "123",
+		State:      models.SubmittedState,
+		Links: &models.InstanceLinks{
+			Dataset: &models.LinkObject{ID: "cpih01"},
+		},
+		ImportTasks: &models.InstanceImportTasks{
+			ImportObservations: &models.ImportObservationsTask{
+				InsertedObservations: 500,
+				State:                models.CompletedState,
+			},
+			BuildHierarchyTasks: []*models.BuildHierarchyTask{
+				{GenericTaskDetails: models.GenericTaskDetails{DimensionName: "geography", State: models.FailedState}},
+				{GenericTaskDetails: models.GenericTaskDetails{DimensionName: "aggregate", State: models.CompletedState}},
+			},
+			BuildSearchIndexTasks: []*models.BuildSearchIndexTask{
+				{GenericTaskDetails: models.GenericTaskDetails{DimensionName: "geography", State: models.CompletedState}},
+			},
+		},
+	}
+}
+
+func Test_RetryImportTasksReturnsOk(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a dataset API with a submitted instance that has a failed hierarchy task", t, func() {
+		mockedDataStore := &storetest.StorerMock{
+			GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
+				return failedImportInstance(), nil
+			},
+			RetryImportTasksFunc: func(ctx context.Context, currentInstance *models.Instance, state string, tasks *models.InstanceImportTasks, event *models.Event, eTagSelector string) (string, error) {
+				return testETag, nil
+			},
+		}
+		retrier := &mocks.ImportRetrierMock{
+			RetryFunc: func(ctx context.Context, instanceID string, datasetID string, dimensions []string, importObservations bool) error {
+				return nil
+			},
+		}
+		datasetPermissions := mocks.NewAuthHandlerMock()
+		permissions := mocks.NewAuthHandlerMock()
+		datasetAPI := getAPIWithRetrier(testContext, mockedDataStore, retrier, datasetPermissions, permissions)
+
+		Convey("When a retry request is made for the geography dimension, with a valid If-Match header", func() {
+			body := strings.NewReader(`{"dimensions":["geography"]}`)
+			r, err := createRequestWithToken(http.MethodPost, retryURL, body)
+			So(err, ShouldBeNil)
+			r.Header.Set("If-Match", testIfMatch)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response status is 200 OK, with the expected ETag header", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("ETag"), ShouldEqual, testETag)
+			})
+
+			Convey("Then only the tasks for the geography dimension are reset", func() {
+				So(permissions.Required.Calls, ShouldEqual, 1)
+				So(mockedDataStore.RetryImportTasksCalls(), ShouldHaveLength, 1)
+				call := mockedDataStore.RetryImportTasksCalls()[0]
+				So(call.ETagSelector, ShouldEqual, testIfMatch)
+				So(call.State, ShouldEqual, models.SubmittedState)
+				So(call.Tasks.BuildHierarchyTasks[0].State, ShouldEqual, models.CreatedState)
+				So(call.Tasks.BuildHierarchyTasks[1].State, ShouldEqual, models.CompletedState)
+				So(call.Tasks.BuildSearchIndexTasks[0].State, ShouldEqual, models.CreatedState)
+				So(call.Tasks.ImportObservations.State, ShouldEqual, models.CompletedState)
+				So(call.Tasks.ImportObservations.InsertedObservations, ShouldEqual, 500)
+				So(call.Event.Type, ShouldEqual, "retry")
+				So(call.Event.Message, ShouldEqual, "retrying import tasks for dimensions: [geography]")
+			})
+
+			Convey("Then the importers are asked to retry the geography dimension", func() {
+				So(retrier.RetryCalls(), ShouldHaveLength, 1)
+				So(retrier.RetryCalls()[0].InstanceID, ShouldEqual, "123")
+				So(retrier.RetryCalls()[0].DatasetID, ShouldEqual, "cpih01")
+				So(retrier.RetryCalls()[0].Dimensions, ShouldResemble, []string{"geography"})
+				So(retrier.RetryCalls()[0].ImportObservations, ShouldBeFalse)
+			})
+		})
+
+		Convey("When a retry request is made for the geography dimension, resetting the inserted observations", func() {
+			body := strings.NewReader(`{"dimensions":["geography"],"reset_inserted_observations":true}`)
+			r, err := createRequestWithToken(http.MethodPost, retryURL, body)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the inserted observations are kept, as observations are not imported again", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedDataStore.RetryImportTasksCalls(), ShouldHaveLength, 1)
+				call := mockedDataStore.RetryImportTasksCalls()[0]
+				So(call.Tasks.ImportObservations.InsertedObservations, ShouldEqual, 500)
+				So(call.Event.Message, ShouldEqual, "retrying import tasks for dimensions: [geography]")
+			})
+		})
+
+		Convey("When a retry request is made for the whole instance, resetting the inserted observations", func() {
+			body := strings.NewReader(`{"reset_inserted_observations":true}`)
+			r, err := createRequestWithToken(http.MethodPost, retryURL, body)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response status is 200 OK", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+
+			Convey("Then all tasks and the inserted observations are reset", func() {
+				So(mockedDataStore.RetryImportTasksCalls(), ShouldHaveLength, 1)
+				call := mockedDataStore.RetryImportTasksCalls()[0]
+				So(call.ETagSelector, ShouldEqual, AnyETag)
+				So(call.Tasks.BuildHierarchyTasks[0].State, ShouldEqual, models.CreatedState)
+				So(call.Tasks.BuildHierarchyTasks[1].State, ShouldEqual, models.CreatedState)
+				So(call.Tasks.BuildSearchIndexTasks[0].State, ShouldEqual, models.CreatedState)
+				So(call.Tasks.ImportObservations.State, ShouldEqual, models.CreatedState)
+				So(call.Tasks.ImportObservations.InsertedObservations, ShouldEqual, 0)
+				So(call.Event.Message, ShouldEqual, "retrying all import tasks, inserted observations reset")
+			})
+
+			Convey("Then the importers are asked to retry the whole instance", func() {
+				So(retrier.RetryCalls(), ShouldHaveLength, 1)
+				So(retrier.RetryCalls()[0].Dimensions, ShouldBeEmpty)
+				So(retrier.RetryCalls()[0].ImportObservations, ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func Test_RetryImportTasksReturnsError(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a retry request", t, func() {
+		datasetPermissions := mocks.NewAuthHandlerMock()
+		permissions := mocks.NewAuthHandlerMock()
+		retrier := &mocks.ImportRetrierMock{
+			RetryFunc: func(ctx context.Context, instanceID string, datasetID string, dimensions []string, importObservations bool) error {
+				return nil
+			},
+		}
+
+		Convey("When the request body is invalid", func() {
+			mockedDataStore := &storetest.StorerMock{
+				GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
+					return failedImportInstance(), nil
+				},
+			}
+			datasetAPI := getAPIWithRetrier(testContext, mockedDataStore, retrier, datasetPermissions, permissions)
+
+			r, err := createRequestWithToken(http.MethodPost, retryURL, strings.NewReader(`{"dimensions":`))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then return status bad request (400)", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrUnableToParseJSON.Error())
+				So(mockedDataStore.RetryImportTasksCalls(), ShouldHaveLength, 0)
+				So(retrier.RetryCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a requested dimension has no import tasks", func() {
+			mockedDataStore := &storetest.StorerMock{
+				GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
+					return failedImportInstance(), nil
+				},
+			}
+			datasetAPI := getAPIWithRetrier(testContext, mockedDataStore, retrier, datasetPermissions, permissions)
+
+			r, err := createRequestWithToken(http.MethodPost, retryURL, strings.NewReader(`{"dimensions":["time"]}`))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then return status not found (404)", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrDimensionNotFound.Error())
+				So(mockedDataStore.RetryImportTasksCalls(), ShouldHaveLength, 0)
+				So(retrier.RetryCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When none of the requested tasks have failed", func() {
+			mockedDataStore := &storetest.StorerMock{
+				GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
+					return failedImportInstance(), nil
+				},
+			}
+			datasetAPI := getAPIWithRetrier(testContext, mockedDataStore, retrier, datasetPermissions, permissions)
+
+			r, err := createRequestWithToken(http.MethodPost, retryURL, strings.NewReader(`{"dimensions":["aggregate"]}`))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then return status forbidden (403)", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrNoFailedImportTasks.Error())
+				So(mockedDataStore.RetryImportTasksCalls(), ShouldHaveLength, 0)
+				So(retrier.RetryCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the instance has moved beyond the import stage", func() {
+			mockedDataStore := &storetest.StorerMock{
+				GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
+					i := failedImportInstance()
+					i.State = models.EditionConfirmedState
+					return i, nil
+				},
+			}
+			datasetAPI := getAPIWithRetrier(testContext, mockedDataStore, retrier, datasetPermissions, permissions)
+
+			r, err := createRequestWithToken(http.MethodPost, retryURL, strings.NewReader(""))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then return status forbidden (403)", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrImportTasksNotRetryable.Error())
+				So(mockedDataStore.RetryImportTasksCalls(), ShouldHaveLength, 0)
+				So(retrier.RetryCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the instance eTag does not match the provided If-Match header value", func() {
+			mockedDataStore := &storetest.StorerMock{
+				GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
+					if eTagSelector != AnyETag {
+						return nil, errs.ErrInstanceConflict
+					}
+					return failedImportInstance(), nil
+				},
+			}
+			datasetAPI := getAPIWithRetrier(testContext, mockedDataStore, retrier, datasetPermissions, permissions)
+
+			r, err := createRequestWithToken(http.MethodPost, retryURL, strings.NewReader(""))
+			So(err, ShouldBeNil)
+			r.Header.Set("If-Match", testIfMatch)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then return status conflict (409)", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrInstanceConflict.Error())
+				So(mockedDataStore.RetryImportTasksCalls(), ShouldHaveLength, 0)
+				So(retrier.RetryCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the import retry event for a failed instance cannot be sent", func() {
+			mockedDataStore := &storetest.StorerMock{
+				GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
+					i := failedImportInstance()
+					i.State = models.FailedState
+					return i, nil
+				},
+				RetryImportTasksFunc: func(ctx context.Context, currentInstance *models.Instance, state string, tasks *models.InstanceImportTasks, event *models.Event, eTagSelector string) (string, error) {
+					return testETag, nil
+				},
+			}
+			failingRetrier := &mocks.ImportRetrierMock{
+				RetryFunc: func(ctx context.Context, instanceID string, datasetID string, dimensions []string, importObservations bool) error {
+					return errors.New("kafka is down")
+				},
+			}
+			datasetAPI := getAPIWithRetrier(testContext, mockedDataStore, failingRetrier, datasetPermissions, permissions)
+
+			r, err := createRequestWithToken(http.MethodPost, retryURL, strings.NewReader(""))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			datasetAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then return status internal server error (500)", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+				So(w.Body.String(), ShouldContainSubstring, errs.ErrInternalServer.Error())
+				So(failingRetrier.RetryCalls(), ShouldHaveLength, 1)
+			})
+
+			Convey("Then the instance is moved back to its state and import tasks before the retry", func() {
+				So(mockedDataStore.RetryImportTasksCalls(), ShouldHaveLength, 2)
+				restore := mockedDataStore.RetryImportTasksCalls()[1]
+				So(restore.ETagSelector, ShouldEqual, testETag)
+				So(restore.State, ShouldEqual, models.FailedState)
+				So(restore.Tasks, ShouldResemble, failedImportInstance().ImportTasks)
+				So(restore.Event.Type, ShouldEqual, "retry_failed")
+			})
+		})
+	})
+}
+
+func getAPIWithRetrier(ctx context.Context, mockedDataStore store.Storer, importRetrier instance.ImportRetrier, datasetPermissions api.AuthHandler, permissions api.AuthHandler) *api.DatasetAPI {
+	mu.Lock()
+	defer mu.Unlock()
+	cfg, err := config.Get()
+	So(err, ShouldBeNil)
+	cfg.ServiceAuthToken = "dataset"
+	cfg.DatasetAPIURL = "http://localhost:22000"
+	cfg.EnablePrivateEndpoints = true
+
+	return api.Setup(ctx, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, urlBuilder, &mocks.DownloadsGeneratorMock{}, importRetrier, &mocks.DatasetEventEmitterMock{}, collection.NewLocalChecker(), collection.NewLocalCollections(), nil, datasetPermissions, permissions)
+}
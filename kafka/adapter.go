@@ -1,18 +1,72 @@
 package kafka
 
-import kafka "github.com/ONSdigital/dp-kafka/v2"
+import (
+	"sync"
 
-// NewProducerAdapter creates a new kafka producer with access to Output function
-func NewProducerAdapter(producer kafka.IProducer) *Producer {
-	return &Producer{kafkaProducer: producer}
+	"github.com/ONSdigital/dp-dataset-api/metrics"
+	kafka "github.com/ONSdigital/dp-kafka/v2"
+)
+
+// NewProducerAdapter creates a new kafka producer with access to Output function. The messages sent to the output
+// channel are forwarded to the producer of the topic, and counted as they are handed over, until the adapter is
+// closed. Nothing is forwarded if there is no producer, as the producers are not created when the private endpoints
+// are disabled.
+func NewProducerAdapter(producer kafka.IProducer, topic string) *Producer {
+	p := &Producer{
+		kafkaProducer: producer,
+		topic:         topic,
+		output:        make(chan []byte),
+		closing:       make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	if producer == nil {
+		close(p.done)
+		return p
+	}
+	go p.forward()
+	return p
 }
 
 // Producer exposes an output function, to satisfy the interface used by go-ns libraries
 type Producer struct {
 	kafkaProducer kafka.IProducer
+	topic         string
+	output        chan []byte
+
+	closeOnce sync.Once
+	closing   chan struct{}
+	done      chan struct{}
 }
 
 // Output returns the output channel
-func (p Producer) Output() chan []byte {
-	return p.kafkaProducer.Channels().Output
+func (p *Producer) Output() chan []byte {
+	return p.output
+}
+
+// Close stops forwarding messages to the kafka producer, waiting for any message being handed over. It must be called
+// before the kafka producer is closed.
+func (p *Producer) Close() {
+	p.closeOnce.Do(func() {
+		close(p.closing)
+	})
+	<-p.done
+}
+
+// forward hands each message sent to the output channel to the kafka producer, until the adapter is closed
+func (p *Producer) forward() {
+	defer close(p.done)
+
+	for {
+		select {
+		case <-p.closing:
+			return
+		case message := <-p.output:
+			select {
+			case <-p.closing:
+				return
+			case p.kafkaProducer.Channels().Output <- message:
+				metrics.KafkaMessagesHandedOff.WithLabelValues(p.topic).Inc()
+			}
+		}
+	}
 }
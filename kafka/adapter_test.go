@@ -0,0 +1,68 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	kafka "github.com/ONSdigital/dp-kafka/v2"
+	"github.com/ONSdigital/dp-kafka/v2/kafkatest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProducerAdapter(t *testing.T) {
+	Convey("Given an adapter of a kafka producer", t, func() {
+		channels := kafka.CreateProducerChannels()
+		p := NewProducerAdapter(kafkatest.NewMessageProducerWithChannels(channels, true), "test-topic")
+
+		Convey("When a message is sent to the output channel", func() {
+			go func() { p.Output() <- []byte("message") }()
+
+			Convey("Then it is forwarded to the kafka producer", func() {
+				select {
+				case message := <-channels.Output:
+					So(string(message), ShouldEqual, "message")
+				case <-time.After(time.Second):
+					t.Fatal("the message was not forwarded")
+				}
+				p.Close()
+			})
+		})
+
+		Convey("When the adapter is closed while a message is waiting to be handed over", func() {
+			go func() { p.Output() <- []byte("message") }()
+			closed := make(chan struct{})
+			go func() {
+				p.Close()
+				close(closed)
+			}()
+
+			Convey("Then the adapter stops without the kafka producer receiving the message", func() {
+				select {
+				case <-closed:
+				case <-time.After(time.Second):
+					t.Fatal("the adapter did not stop")
+				}
+			})
+		})
+	})
+
+	Convey("Given an adapter without a kafka producer", t, func() {
+		p := NewProducerAdapter(nil, "test-topic")
+
+		Convey("When the adapter is closed", func() {
+			closed := make(chan struct{})
+			go func() {
+				p.Close()
+				close(closed)
+			}()
+
+			Convey("Then it stops at once, as nothing is forwarded", func() {
+				select {
+				case <-closed:
+				case <-time.After(time.Second):
+					t.Fatal("the adapter did not stop")
+				}
+			})
+		})
+	})
+}
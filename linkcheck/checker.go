@@ -0,0 +1,370 @@
+package linkcheck
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/store"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/pkg/errors"
+)
+
+//go:generate moq -out ../mocks/link_checker_mocks.go -pkg mocks . HTTPClient
+
+const (
+	// maxRedirects is the number of redirects followed before a link is reported as broken
+	maxRedirects = 10
+	// pageSize is the number of published datasets read from the datastore at a time
+	pageSize = 100
+	// lockTTL is how long the link check lock is held without being renewed, after which it is released, so that the
+	// links are still checked once an instance stops while checking them
+	lockTTL   = 5 * time.Minute
+	userAgent = "dp-dataset-api link checker"
+)
+
+var (
+	errTooManyRedirects  = errors.New("too many redirects")
+	errUnsupportedScheme = errors.New("only http and https links are checked")
+	errPrivateAddress    = errors.New("links to private addresses are not checked")
+)
+
+// privateNetworks are the networks outside the public internet not covered by the classifications of net.IP
+var privateNetworks = parseNetworks("0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "172.16.0.0/12", "192.168.0.0/16", "198.18.0.0/15", "fc00::/7")
+
+// HTTPClient sends the requests checking links. It must not follow redirects, so that the checker can record them.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// NewHTTPClient returns an HTTPClient that does not follow redirects, giving up on each request after the timeout.
+// Links are requested directly rather than through a proxy, and only when they resolve to a public address, so that
+// the metadata of a dataset cannot be used to make requests to the network the API runs in.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout: timeout,
+		Control: checkAddress,
+	}).DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkAddress rejects connections to addresses outside the public internet
+func checkAddress(network, address string, conn syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublic(ip) {
+		return errPrivateAddress
+	}
+	return nil
+}
+
+func isPublic(ip net.IP) bool {
+	if ip.IsUnspecified() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return false
+	}
+
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// Checker periodically requests the external links in the metadata of every published dataset, recording whether
+// each is broken in a link report per dataset. Broken links are only reported, and never prevent publishing.
+type Checker struct {
+	Client    HTTPClient
+	Datastore store.Storer
+	// Concurrency is the number of links requested at the same time, which defaults to one
+	Concurrency int
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// Start checks the links as soon as it is called and then periodically, until Stop is called
+func (c *Checker) Start(ctx context.Context, interval time.Duration) {
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.done = make(chan struct{})
+
+	go func() {
+		defer close(c.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := c.CheckLinks(ctx); err != nil && ctx.Err() == nil {
+				log.Event(ctx, "failed to check the links of published datasets", log.ERROR, log.Error(err))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops the checker, waiting for any link being checked
+func (c *Checker) Stop() {
+	if c.cancel == nil {
+		return
+	}
+
+	c.cancel()
+	<-c.done
+}
+
+// CheckLinks checks the external links of every published dataset, replacing their link reports, and removes the
+// reports of datasets that are no longer published. The links are only checked by the instance holding the link
+// check lock, so nothing is checked while another instance is checking them.
+func (c *Checker) CheckLinks(ctx context.Context) error {
+	lockID, err := c.Datastore.LockLinkCheck(ctx, lockTTL)
+	if err != nil {
+		if err == errs.ErrLinkCheckLocked {
+			log.Event(ctx, "the links of published datasets are being checked by another instance", log.INFO)
+			return nil
+		}
+		return errors.Wrap(err, "failed to lock the link check")
+	}
+	defer func() {
+		if err := c.Datastore.UnlockLinkCheck(ctx, lockID); err != nil {
+			log.Event(ctx, "failed to unlock the link check", log.ERROR, log.Error(err))
+		}
+	}()
+
+	stopRenewing := c.renewLock(ctx, lockID)
+	defer stopRenewing()
+
+	checked := []string{}
+	broken := 0
+
+	for offset := 0; ; offset += pageSize {
+		datasets, totalCount, err := c.Datastore.GetDatasets(ctx, offset, pageSize, false)
+		if err != nil {
+			return errors.Wrap(err, "failed to get published datasets")
+		}
+
+		// the links of the whole page are checked together, and the report of each dataset stored once they are checked
+		pending := []*models.LinkReport{}
+		links := []*models.LinkStatus{}
+		for _, dataset := range datasets {
+			if dataset.Current == nil {
+				continue
+			}
+
+			unchecked := &models.LinkReport{DatasetID: dataset.ID, Links: models.ExternalLinks(dataset.Current)}
+			pending = append(pending, unchecked)
+			links = append(links, unchecked.Links...)
+		}
+
+		c.checkConcurrently(ctx, links)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		for _, unchecked := range pending {
+			report := c.report(ctx, unchecked.DatasetID, unchecked.Links)
+			if err := c.Datastore.UpsertLinkReport(ctx, report); err != nil {
+				return errors.Wrapf(err, "failed to store the link report of dataset %s", report.DatasetID)
+			}
+			checked = append(checked, report.DatasetID)
+			broken += report.BrokenLinks
+		}
+
+		if len(datasets) == 0 || offset+pageSize >= totalCount {
+			break
+		}
+	}
+
+	if err := c.Datastore.DeleteLinkReportsExcept(ctx, checked); err != nil {
+		return errors.Wrap(err, "failed to remove the link reports of datasets that are no longer published")
+	}
+
+	log.Event(ctx, "checked the links of published datasets", log.INFO, log.Data{"datasets": len(checked), "broken_links": broken})
+	return nil
+}
+
+// renewLock renews the link check lock until the returned function is called
+func (c *Checker) renewLock(ctx context.Context, lockID string) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(lockTTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := c.Datastore.RenewLinkCheckLock(ctx, lockID, lockTTL); err != nil {
+					log.Event(ctx, "failed to renew the link check lock", log.WARN, log.Error(err))
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// checkConcurrently requests the links, with no more requests in flight at a time than the concurrency of the checker
+func (c *Checker) checkConcurrently(ctx context.Context, links []*models.LinkStatus) {
+	workers := c.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	queue := make(chan *models.LinkStatus)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for link := range queue {
+				c.checkLink(ctx, link)
+			}
+		}()
+	}
+
+	for _, link := range links {
+		queue <- link
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// report returns the link report of the checked links of a dataset, logging each broken link
+func (c *Checker) report(ctx context.Context, datasetID string, links []*models.LinkStatus) *models.LinkReport {
+	for _, link := range links {
+		if link.Broken {
+			log.Event(ctx, "broken link in dataset metadata", log.WARN, log.Data{"dataset_id": datasetID, "link": link})
+		}
+	}
+
+	return models.NewLinkReport(datasetID, links, time.Now().UTC())
+}
+
+// checkLink requests the link, following any redirects, and records the final status and where it was redirected to.
+// A link is broken if it cannot be requested or its final status is an error.
+func (c *Checker) checkLink(ctx context.Context, link *models.LinkStatus) {
+	target, err := url.Parse(link.HRef)
+	if err != nil {
+		link.Error = err.Error()
+		link.Broken = true
+		return
+	}
+
+	for redirects := 0; ; redirects++ {
+		if target.Scheme != "http" && target.Scheme != "https" {
+			link.Error = errUnsupportedScheme.Error()
+			link.Broken = true
+			return
+		}
+
+		statusCode, location, err := c.request(ctx, target)
+		if err != nil {
+			link.Error = err.Error()
+			link.Broken = true
+			return
+		}
+
+		if location == nil {
+			link.StatusCode = statusCode
+			link.Broken = statusCode >= http.StatusBadRequest
+			return
+		}
+
+		if redirects == maxRedirects {
+			link.StatusCode = statusCode
+			link.Error = errTooManyRedirects.Error()
+			link.Broken = true
+			return
+		}
+
+		target = target.ResolveReference(location)
+		link.RedirectedTo = target.String()
+	}
+}
+
+// request sends a HEAD request for the target, falling back to GET for servers that do not support HEAD, and returns
+// the status code, and the location of the redirect if the target is redirected
+func (c *Checker) request(ctx context.Context, target *url.URL) (int, *url.URL, error) {
+	resp, err := c.send(ctx, http.MethodHead, target)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+		resp, err = c.send(ctx, http.MethodGet, target)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if resp.StatusCode < http.StatusMultipleChoices || resp.StatusCode >= http.StatusBadRequest || resp.StatusCode == http.StatusNotModified {
+		return resp.StatusCode, nil, nil
+	}
+
+	location, err := resp.Location()
+	if err != nil {
+		return resp.StatusCode, nil, errors.Wrap(err, "redirect without a valid location")
+	}
+	return resp.StatusCode, location, nil
+}
+
+func (c *Checker) send(ctx context.Context, method string, target *url.URL) (*http.Response, error) {
+	req, err := http.NewRequest(method, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the status and headers are needed, so the body is discarded to allow the connection to be reused
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	return resp, nil
+}
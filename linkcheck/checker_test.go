@@ -0,0 +1,297 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/mocks"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var ctx = context.Background()
+
+func response(statusCode int, location string) *http.Response {
+	resp := &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}
+	if location != "" {
+		resp.Header.Set("Location", location)
+	}
+	return resp
+}
+
+// offlineClient returns a client responding to HEAD requests as the web would
+func offlineClient() *mocks.HTTPClientMock {
+	return &mocks.HTTPClientMock{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			switch req.URL.String() {
+			case "https://www.ons.gov.uk/qmi":
+				return response(http.StatusOK, ""), nil
+			case "https://www.ons.gov.uk/old-methodology":
+				return response(http.StatusMovedPermanently, "/methodology"), nil
+			case "https://www.ons.gov.uk/methodology":
+				return response(http.StatusOK, ""), nil
+			case "https://www.ons.gov.uk/gone":
+				return response(http.StatusNotFound, ""), nil
+			case "https://no-head.example.com/publication":
+				if req.Method == http.MethodHead {
+					return response(http.StatusMethodNotAllowed, ""), nil
+				}
+				return response(http.StatusOK, ""), nil
+			case "https://loop.example.com/":
+				return response(http.StatusFound, "https://loop.example.com/"), nil
+			case "https://ftp.example.com/":
+				return response(http.StatusFound, "ftp://ftp.example.com/"), nil
+			}
+			return nil, errors.New("no such host")
+		},
+	}
+}
+
+func publishedDatasets() []*models.DatasetUpdate {
+	return []*models.DatasetUpdate{
+		{
+			ID: "cpih01",
+			Current: &models.Dataset{
+				QMI: &models.GeneralDetails{HRef: "https://www.ons.gov.uk/qmi"},
+				Methodologies: []models.GeneralDetails{
+					{HRef: "https://www.ons.gov.uk/old-methodology"},
+					{HRef: "https://www.ons.gov.uk/gone"},
+				},
+				Publications: []models.GeneralDetails{
+					{HRef: "https://no-head.example.com/publication"},
+					{HRef: "https://unknown.example.com/"},
+				},
+				RelatedDatasets: []models.GeneralDetails{
+					{HRef: "https://loop.example.com/"},
+					{HRef: "https://ftp.example.com/"},
+					{HRef: "http://localhost:22000/datasets/mm23", ID: "mm23"},
+				},
+			},
+		},
+		{ID: "unpublished"},
+	}
+}
+
+func TestCheckLinks(t *testing.T) {
+	Convey("Given published datasets with external links", t, func() {
+		client := offlineClient()
+		datastore := &storetest.StorerMock{
+			LockLinkCheckFunc: func(ctx context.Context, ttl time.Duration) (string, error) {
+				return "lock", nil
+			},
+			UnlockLinkCheckFunc: func(ctx context.Context, lockID string) error {
+				return nil
+			},
+			GetDatasetsFunc: func(ctx context.Context, offset, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
+				return publishedDatasets(), 2, nil
+			},
+			UpsertLinkReportFunc: func(ctx context.Context, report *models.LinkReport) error {
+				return nil
+			},
+			DeleteLinkReportsExceptFunc: func(ctx context.Context, datasetIDs []string) error {
+				return nil
+			},
+		}
+		checker := &Checker{Client: client, Datastore: datastore}
+
+		Convey("When the links are checked", func() {
+			err := checker.CheckLinks(ctx)
+			So(err, ShouldBeNil)
+
+			Convey("Then only published datasets are read", func() {
+				So(datastore.GetDatasetsCalls(), ShouldHaveLength, 1)
+				So(datastore.GetDatasetsCalls()[0].Authorised, ShouldBeFalse)
+			})
+
+			Convey("Then the status of every external link is recorded", func() {
+				So(datastore.UpsertLinkReportCalls(), ShouldHaveLength, 1)
+
+				report := datastore.UpsertLinkReportCalls()[0].Report
+				So(report.DatasetID, ShouldEqual, "cpih01")
+				So(report.BrokenLinks, ShouldEqual, 4)
+				So(report.Links, ShouldResemble, []*models.LinkStatus{
+					{Field: "qmi", HRef: "https://www.ons.gov.uk/qmi", StatusCode: 200},
+					{Field: "methodologies[0]", HRef: "https://www.ons.gov.uk/old-methodology", StatusCode: 200, RedirectedTo: "https://www.ons.gov.uk/methodology"},
+					{Field: "methodologies[1]", HRef: "https://www.ons.gov.uk/gone", StatusCode: 404, Broken: true},
+					{Field: "publications[0]", HRef: "https://no-head.example.com/publication", StatusCode: 200},
+					{Field: "publications[1]", HRef: "https://unknown.example.com/", Error: "no such host", Broken: true},
+					{Field: "related_datasets[0]", HRef: "https://loop.example.com/", StatusCode: 302, RedirectedTo: "https://loop.example.com/", Error: errTooManyRedirects.Error(), Broken: true},
+					{Field: "related_datasets[1]", HRef: "https://ftp.example.com/", RedirectedTo: "ftp://ftp.example.com/", Error: errUnsupportedScheme.Error(), Broken: true},
+				})
+			})
+
+			Convey("Then the links are checked while holding the link check lock", func() {
+				So(datastore.LockLinkCheckCalls(), ShouldHaveLength, 1)
+				So(datastore.UnlockLinkCheckCalls(), ShouldHaveLength, 1)
+				So(datastore.UnlockLinkCheckCalls()[0].LockID, ShouldEqual, "lock")
+			})
+
+			Convey("Then the reports of datasets that are no longer published are removed", func() {
+				So(datastore.DeleteLinkReportsExceptCalls(), ShouldHaveLength, 1)
+				So(datastore.DeleteLinkReportsExceptCalls()[0].DatasetIDs, ShouldResemble, []string{"cpih01"})
+			})
+		})
+
+		Convey("When the links are being checked by another instance", func() {
+			datastore.LockLinkCheckFunc = func(ctx context.Context, ttl time.Duration) (string, error) {
+				return "", errs.ErrLinkCheckLocked
+			}
+			err := checker.CheckLinks(ctx)
+
+			Convey("Then nothing is checked", func() {
+				So(err, ShouldBeNil)
+				So(datastore.GetDatasetsCalls(), ShouldHaveLength, 0)
+				So(client.DoCalls(), ShouldHaveLength, 0)
+				So(datastore.UnlockLinkCheckCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the link reports cannot be stored", func() {
+			datastore.UpsertLinkReportFunc = func(ctx context.Context, report *models.LinkReport) error {
+				return errors.New("mongo is down")
+			}
+			err := checker.CheckLinks(ctx)
+
+			Convey("Then an error is returned and no reports are removed", func() {
+				So(err, ShouldNotBeNil)
+				So(datastore.DeleteLinkReportsExceptCalls(), ShouldHaveLength, 0)
+			})
+
+			Convey("Then the link check lock is released", func() {
+				So(datastore.UnlockLinkCheckCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a checker requesting two links at a time", t, func() {
+		inFlight, maxInFlight := int32(0), int32(0)
+		client := &mocks.HTTPClientMock{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					max := atomic.LoadInt32(&maxInFlight)
+					if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				return response(http.StatusOK, ""), nil
+			},
+		}
+		datastore := &storetest.StorerMock{
+			LockLinkCheckFunc: func(ctx context.Context, ttl time.Duration) (string, error) {
+				return "lock", nil
+			},
+			UnlockLinkCheckFunc: func(ctx context.Context, lockID string) error {
+				return nil
+			},
+			GetDatasetsFunc: func(ctx context.Context, offset, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
+				return publishedDatasets(), 2, nil
+			},
+			UpsertLinkReportFunc: func(ctx context.Context, report *models.LinkReport) error {
+				return nil
+			},
+			DeleteLinkReportsExceptFunc: func(ctx context.Context, datasetIDs []string) error {
+				return nil
+			},
+		}
+		checker := &Checker{Client: client, Datastore: datastore, Concurrency: 2}
+
+		Convey("When the links are checked", func() {
+			So(checker.CheckLinks(ctx), ShouldBeNil)
+
+			Convey("Then no more than two links are requested at a time", func() {
+				So(client.DoCalls(), ShouldHaveLength, 7)
+				So(atomic.LoadInt32(&maxInFlight), ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given more published datasets than fit in a page", t, func() {
+		datastore := &storetest.StorerMock{
+			LockLinkCheckFunc: func(ctx context.Context, ttl time.Duration) (string, error) {
+				return "lock", nil
+			},
+			UnlockLinkCheckFunc: func(ctx context.Context, lockID string) error {
+				return nil
+			},
+			GetDatasetsFunc: func(ctx context.Context, offset, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
+				return []*models.DatasetUpdate{{ID: "dataset", Current: &models.Dataset{}}}, pageSize + 1, nil
+			},
+			UpsertLinkReportFunc: func(ctx context.Context, report *models.LinkReport) error {
+				return nil
+			},
+			DeleteLinkReportsExceptFunc: func(ctx context.Context, datasetIDs []string) error {
+				return nil
+			},
+		}
+		checker := &Checker{Client: offlineClient(), Datastore: datastore}
+
+		Convey("When the links are checked", func() {
+			So(checker.CheckLinks(ctx), ShouldBeNil)
+
+			Convey("Then every page is read", func() {
+				So(datastore.GetDatasetsCalls(), ShouldHaveLength, 2)
+				So(datastore.GetDatasetsCalls()[1].Offset, ShouldEqual, pageSize)
+			})
+		})
+	})
+}
+
+func TestCheckAddress(t *testing.T) {
+	Convey("Public addresses can be connected to", t, func() {
+		So(checkAddress("tcp", "151.101.0.81:443", nil), ShouldBeNil)
+		So(checkAddress("tcp6", "[2a04:4e42::81]:443", nil), ShouldBeNil)
+	})
+
+	Convey("Private addresses cannot be connected to", t, func() {
+		for _, address := range []string{
+			"127.0.0.1:80",
+			"10.1.2.3:80",
+			"172.16.0.1:80",
+			"192.168.1.1:80",
+			"169.254.169.254:80",
+			"100.64.0.1:80",
+			"0.0.0.0:80",
+			"[::1]:80",
+			"[fd00::1]:80",
+			"[fe80::1]:80",
+			"[::ffff:10.0.0.1]:80",
+		} {
+			So(checkAddress("tcp", address, nil), ShouldEqual, errPrivateAddress)
+		}
+	})
+}
+
+func TestStart(t *testing.T) {
+	Convey("Given a checker", t, func() {
+		datastore := &storetest.StorerMock{
+			LockLinkCheckFunc: func(ctx context.Context, ttl time.Duration) (string, error) {
+				return "", errs.ErrLinkCheckLocked
+			},
+		}
+		checker := &Checker{Client: offlineClient(), Datastore: datastore}
+
+		Convey("When it is started and stopped before the first interval has passed", func() {
+			checker.Start(ctx, time.Hour)
+			checker.Stop()
+
+			Convey("Then the links have been checked once", func() {
+				So(datastore.LockLinkCheckCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
@@ -0,0 +1,176 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "dataset_api"
+
+var (
+	// Registry holds the metrics of the dataset API, along with the metrics of the go runtime and the process
+	Registry = prometheus.NewRegistry()
+
+	// Requests counts the requests handled, by method, route template and status code
+	Requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "The number of http requests handled, by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	// RequestDuration observes the latency of requests, by method and route template
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "The latency of http requests, by method and route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// MongoDuration observes the latency of the mongo store, by store method
+	MongoDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mongo_operation_duration_seconds",
+		Help:      "The latency of mongo db operations, by store method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	// MongoErrors counts the unexpected errors returned by the mongo store, by store method
+	MongoErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "mongo_operation_errors_total",
+		Help:      "The number of mongo db operations that failed, by store method.",
+	}, []string{"operation"})
+
+	// MongoSessions counts the sessions used by the mongo store, by store method and the read preference of the session.
+	// Public reads can be served from secondaries by web instances, while every other operation uses the primary.
+	MongoSessions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "mongo_sessions_total",
+		Help:      "The number of mongo db sessions used, by store method and read preference.",
+	}, []string{"operation", "read_preference"})
+
+	// MongoSecondariesStale is 1 while every secondary lags the primary by more than the maximum staleness allowed, so
+	// that reads which could be served from secondaries are served from the primary instead
+	MongoSecondariesStale = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "mongo_secondaries_stale",
+		Help:      "Whether every mongo db secondary lags the primary by more than the maximum staleness allowed.",
+	})
+
+	// GraphDuration observes the latency of the graph store, by store method
+	GraphDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "graph_operation_duration_seconds",
+		Help:      "The latency of graph db operations, by store method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	// GraphErrors counts the unexpected errors returned by the graph store, by store method
+	GraphErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "graph_operation_errors_total",
+		Help:      "The number of graph db operations that failed, by store method.",
+	}, []string{"operation"})
+
+	// ResponseCacheLookups counts the public reads looked up in the response cache, by store method and whether the
+	// result was cached
+	ResponseCacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "response_cache_lookups_total",
+		Help:      "The number of public reads looked up in the response cache, by store method and result.",
+	}, []string{"operation", "result"})
+
+	// ResponseCacheBytes is the size of the values held by the response cache
+	ResponseCacheBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "response_cache_bytes",
+		Help:      "The size in bytes of the values held by the response cache.",
+	})
+
+	// KafkaMessagesHandedOff counts the messages handed to the kafka producers to be sent, by topic. The producers send
+	// them asynchronously, so messages that then fail to be sent are counted as well, and logged as producer errors.
+	KafkaMessagesHandedOff = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "kafka_messages_handed_off_total",
+		Help:      "The number of messages handed to the kafka producers to be sent, by topic.",
+	}, []string{"topic"})
+
+	// VersionsPublished counts the versions published
+	VersionsPublished = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "versions_published_total",
+		Help:      "The number of versions published.",
+	})
+
+	// VersionsAssociated counts the versions associated with a collection
+	VersionsAssociated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "versions_associated_total",
+		Help:      "The number of versions associated with a collection.",
+	})
+
+	// VersionsDetached counts the versions detached from their edition
+	VersionsDetached = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "versions_detached_total",
+		Help:      "The number of versions detached.",
+	})
+
+	// StateTransitions counts the changes to the state of instances, and so of the versions they became, by the state
+	// moved from and the state moved to
+	StateTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "instance_state_transitions_total",
+		Help:      "The number of times instances moved from one state to another.",
+	}, []string{"from", "to"})
+)
+
+func init() {
+	Registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		Requests,
+		RequestDuration,
+		MongoDuration,
+		MongoErrors,
+		MongoSessions,
+		MongoSecondariesStale,
+		GraphDuration,
+		GraphErrors,
+		ResponseCacheLookups,
+		ResponseCacheBytes,
+		KafkaMessagesHandedOff,
+		VersionsPublished,
+		VersionsAssociated,
+		VersionsDetached,
+		StateTransitions,
+	)
+}
+
+// Handler returns the http handler which serves the metrics in the prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// StateChanged counts the transition of an instance between two states. Updates which leave the state unchanged are
+// not counted.
+func StateChanged(from, to string) {
+	if to == "" || to == from {
+		return
+	}
+	StateTransitions.WithLabelValues(from, to).Inc()
+}
+
+// observe records the latency of an operation against a store, and whether it failed. Errors for resources which
+// are not found, or which were changed by another caller, are the expected outcome of some requests and so are not
+// counted as failures.
+func observe(duration *prometheus.HistogramVec, errors *prometheus.CounterVec, operation string, start time.Time, err error) {
+	duration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil && !errs.NotFoundMap[err] && !errs.ConflictRequestMap[err] {
+		errors.WithLabelValues(operation).Inc()
+	}
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/utils"
+	"github.com/gorilla/mux"
+)
+
+// Middleware counts the requests handled by the router and observes their latency, labelled by the path template of
+// the route each request matches rather than the path requested
+func Middleware(router *mux.Router) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := utils.NewStatusRecorder(w)
+
+			h.ServeHTTP(recorder, r)
+
+			route := utils.RouteTemplate(router, r)
+			RequestDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+			Requests.WithLabelValues(r.Method, route, strconv.Itoa(recorder.Status)).Inc()
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/utils"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMiddleware(t *testing.T) {
+	Convey("Given a router wrapped by the metrics middleware", t, func() {
+		router := mux.NewRouter()
+		router.HandleFunc("/datasets/{dataset_id}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}).Methods(http.MethodGet)
+		handler := Middleware(router)(router)
+
+		Convey("When a request matching a route is handled", func() {
+			before := testutil.ToFloat64(Requests.WithLabelValues(http.MethodGet, "/datasets/{dataset_id}", "404"))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/datasets/cpih01", nil))
+
+			Convey("Then the request is counted against the template of the route, with its status code", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(testutil.ToFloat64(Requests.WithLabelValues(http.MethodGet, "/datasets/{dataset_id}", "404")), ShouldEqual, before+1)
+			})
+		})
+
+		Convey("When a request matching no route is handled", func() {
+			before := testutil.ToFloat64(Requests.WithLabelValues(http.MethodGet, utils.UnmatchedRoute, "404"))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/unknown/path", nil))
+
+			Convey("Then the request is counted against the unmatched route rather than its path", func() {
+				So(testutil.ToFloat64(Requests.WithLabelValues(http.MethodGet, utils.UnmatchedRoute, "404")), ShouldEqual, before+1)
+			})
+		})
+	})
+}
+
+func TestHandler(t *testing.T) {
+	Convey("When the metrics are requested", t, func() {
+		StateChanged("completed", "edition-confirmed")
+
+		w := httptest.NewRecorder()
+		Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		Convey("Then they are returned in the prometheus exposition format", func() {
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Body.String(), ShouldContainSubstring, `dataset_api_instance_state_transitions_total{from="completed",to="edition-confirmed"}`)
+			So(strings.Contains(w.Body.String(), "go_goroutines"), ShouldBeTrue)
+		})
+	})
+}
+
+func TestStateChanged(t *testing.T) {
+	Convey("Updates which leave the state of an instance unchanged are not counted", t, func() {
+		before := testutil.ToFloat64(StateTransitions.WithLabelValues("associated", "associated"))
+		StateChanged("associated", "associated")
+		StateChanged("associated", "")
+		So(testutil.ToFloat64(StateTransitions.WithLabelValues("associated", "associated")), ShouldEqual, before)
+		So(testutil.ToFloat64(StateTransitions.WithLabelValues("associated", "")), ShouldEqual, 0)
+	})
+}
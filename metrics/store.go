@@ -0,0 +1,447 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/store"
+	"github.com/globalsign/mgo/bson"
+)
+
+// InstrumentMongoDB wraps a mongo store, so that the latency and errors of each of the store methods used by the
+// handlers are recorded
+func InstrumentMongoDB(db store.MongoDB) store.MongoDB {
+	if db == nil {
+		return nil
+	}
+	return &mongoDB{MongoDB: db}
+}
+
+// InstrumentGraphDB wraps a graph store, so that the latency and errors of each of its methods are recorded. The
+// graph store is only used by the publishing instance, so is left nil when it has not been created.
+func InstrumentGraphDB(db store.GraphDB) store.GraphDB {
+	if db == nil {
+		return nil
+	}
+	return &graphDB{GraphDB: db}
+}
+
+func observeMongo(operation string, start time.Time, err *error) {
+	observe(MongoDuration, MongoErrors, operation, start, *err)
+}
+
+func observeGraph(operation string, start time.Time, err *error) {
+	observe(GraphDuration, GraphErrors, operation, start, *err)
+}
+
+// mongoDB records the latency and errors of the store methods of the embedded mongo store. The methods used to manage
+// the connection are not recorded.
+type mongoDB struct {
+	store.MongoDB
+}
+
+func (m *mongoDB) AddDimensionToInstance(ctx context.Context, dimension *models.CachedDimensionOption) (err error) {
+	defer observeMongo("AddDimensionToInstance", time.Now(), &err)
+	return m.MongoDB.AddDimensionToInstance(ctx, dimension)
+}
+
+func (m *mongoDB) AddEventToInstance(ctx context.Context, currentInstance *models.Instance, event *models.Event, eTagSelector string) (newETag string, err error) {
+	defer observeMongo("AddEventToInstance", time.Now(), &err)
+	return m.MongoDB.AddEventToInstance(ctx, currentInstance, event, eTagSelector)
+}
+
+func (m *mongoDB) AddInstance(ctx context.Context, instance *models.Instance) (added *models.Instance, err error) {
+	defer observeMongo("AddInstance", time.Now(), &err)
+	return m.MongoDB.AddInstance(ctx, instance)
+}
+
+func (m *mongoDB) CheckDatasetExists(ctx context.Context, ID, state string) (err error) {
+	defer observeMongo("CheckDatasetExists", time.Now(), &err)
+	return m.MongoDB.CheckDatasetExists(ctx, ID, state)
+}
+
+func (m *mongoDB) CheckEditionExists(ctx context.Context, ID, editionID, state string) (err error) {
+	defer observeMongo("CheckEditionExists", time.Now(), &err)
+	return m.MongoDB.CheckEditionExists(ctx, ID, editionID, state)
+}
+
+func (m *mongoDB) GetDataset(ctx context.Context, ID string) (dataset *models.DatasetUpdate, err error) {
+	defer observeMongo("GetDataset", time.Now(), &err)
+	return m.MongoDB.GetDataset(ctx, ID)
+}
+
+func (m *mongoDB) GetDatasets(ctx context.Context, offset, limit int, authorised bool) (datasets []*models.DatasetUpdate, totalCount int, err error) {
+	defer observeMongo("GetDatasets", time.Now(), &err)
+	return m.MongoDB.GetDatasets(ctx, offset, limit, authorised)
+}
+
+func (m *mongoDB) GetDatasetsByIDs(ctx context.Context, ids []string) (datasets []*models.DatasetUpdate, err error) {
+	defer observeMongo("GetDatasetsByIDs", time.Now(), &err)
+	return m.MongoDB.GetDatasetsByIDs(ctx, ids)
+}
+
+func (m *mongoDB) GetDimensionsFromInstance(ctx context.Context, ID string, offset, limit int) (options []*models.DimensionOption, totalCount int, err error) {
+	defer observeMongo("GetDimensionsFromInstance", time.Now(), &err)
+	return m.MongoDB.GetDimensionsFromInstance(ctx, ID, offset, limit)
+}
+
+func (m *mongoDB) GetDimensions(ctx context.Context, datasetID, versionID string) (dimensions []bson.M, err error) {
+	defer observeMongo("GetDimensions", time.Now(), &err)
+	return m.MongoDB.GetDimensions(ctx, datasetID, versionID)
+}
+
+func (m *mongoDB) GetDimensionSummaries(ctx context.Context, instanceID string) (summaries []*models.DimensionSummary, err error) {
+	defer observeMongo("GetDimensionSummaries", time.Now(), &err)
+	return m.MongoDB.GetDimensionSummaries(ctx, instanceID)
+}
+
+func (m *mongoDB) GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset, limit int) (options []*models.PublicDimensionOption, totalCount int, err error) {
+	defer observeMongo("GetDimensionOptions", time.Now(), &err)
+	return m.MongoDB.GetDimensionOptions(ctx, version, dimension, offset, limit)
+}
+
+func (m *mongoDB) GetDimensionOptionsFromIDs(ctx context.Context, version *models.Version, dimension string, ids []string) (options []*models.PublicDimensionOption, totalCount int, err error) {
+	defer observeMongo("GetDimensionOptionsFromIDs", time.Now(), &err)
+	return m.MongoDB.GetDimensionOptionsFromIDs(ctx, version, dimension, ids)
+}
+
+func (m *mongoDB) GetEdition(ctx context.Context, ID, editionID, state string) (edition *models.EditionUpdate, err error) {
+	defer observeMongo("GetEdition", time.Now(), &err)
+	return m.MongoDB.GetEdition(ctx, ID, editionID, state)
+}
+
+func (m *mongoDB) GetEditions(ctx context.Context, ID, state string, offset, limit int, authorised bool) (editions []*models.EditionUpdate, totalCount int, err error) {
+	defer observeMongo("GetEditions", time.Now(), &err)
+	return m.MongoDB.GetEditions(ctx, ID, state, offset, limit, authorised)
+}
+
+func (m *mongoDB) GetInstances(ctx context.Context, states []string, datasets []string, offset, limit int) (instances []*models.Instance, totalCount int, err error) {
+	defer observeMongo("GetInstances", time.Now(), &err)
+	return m.MongoDB.GetInstances(ctx, states, datasets, offset, limit)
+}
+
+func (m *mongoDB) GetInstance(ctx context.Context, ID, eTagSelector string) (instance *models.Instance, err error) {
+	defer observeMongo("GetInstance", time.Now(), &err)
+	return m.MongoDB.GetInstance(ctx, ID, eTagSelector)
+}
+
+func (m *mongoDB) GetNextVersion(ctx context.Context, datasetID, editionID string) (version int, err error) {
+	defer observeMongo("GetNextVersion", time.Now(), &err)
+	return m.MongoDB.GetNextVersion(ctx, datasetID, editionID)
+}
+
+func (m *mongoDB) GetPreviousPublishedVersion(ctx context.Context, datasetID, editionID string, version int) (versionDoc *models.Version, err error) {
+	defer observeMongo("GetPreviousPublishedVersion", time.Now(), &err)
+	return m.MongoDB.GetPreviousPublishedVersion(ctx, datasetID, editionID, version)
+}
+
+func (m *mongoDB) GetTopics(ctx context.Context) (topics []*models.Topic, err error) {
+	defer observeMongo("GetTopics", time.Now(), &err)
+	return m.MongoDB.GetTopics(ctx)
+}
+
+func (m *mongoDB) GetTopicsPage(ctx context.Context, offset, limit int) (topics []*models.Topic, totalCount int, err error) {
+	defer observeMongo("GetTopicsPage", time.Now(), &err)
+	return m.MongoDB.GetTopicsPage(ctx, offset, limit)
+}
+
+func (m *mongoDB) GetTopic(ctx context.Context, id string) (topic *models.Topic, err error) {
+	defer observeMongo("GetTopic", time.Now(), &err)
+	return m.MongoDB.GetTopic(ctx, id)
+}
+
+func (m *mongoDB) GetTopicDatasets(ctx context.Context, topicIDs []string, offset, limit int) (datasets []*models.DatasetUpdate, totalCount int, err error) {
+	defer observeMongo("GetTopicDatasets", time.Now(), &err)
+	return m.MongoDB.GetTopicDatasets(ctx, topicIDs, offset, limit)
+}
+
+func (m *mongoDB) CountTopicDatasets(ctx context.Context, id string) (count int, err error) {
+	defer observeMongo("CountTopicDatasets", time.Now(), &err)
+	return m.MongoDB.CountTopicDatasets(ctx, id)
+}
+
+func (m *mongoDB) GetDatasetsRelatedTo(ctx context.Context, datasetID string) (datasets []*models.DatasetUpdate, err error) {
+	defer observeMongo("GetDatasetsRelatedTo", time.Now(), &err)
+	return m.MongoDB.GetDatasetsRelatedTo(ctx, datasetID)
+}
+
+func (m *mongoDB) GetLinkReports(ctx context.Context, offset, limit int) (reports []*models.LinkReport, totalCount int, err error) {
+	defer observeMongo("GetLinkReports", time.Now(), &err)
+	return m.MongoDB.GetLinkReports(ctx, offset, limit)
+}
+
+func (m *mongoDB) GetLinkReport(ctx context.Context, datasetID string) (report *models.LinkReport, err error) {
+	defer observeMongo("GetLinkReport", time.Now(), &err)
+	return m.MongoDB.GetLinkReport(ctx, datasetID)
+}
+
+func (m *mongoDB) GetVersion(ctx context.Context, datasetID, editionID string, version int, state string) (versionDoc *models.Version, err error) {
+	defer observeMongo("GetVersion", time.Now(), &err)
+	return m.MongoDB.GetVersion(ctx, datasetID, editionID, version, state)
+}
+
+func (m *mongoDB) GetUniqueDimensionAndOptions(ctx context.Context, ID, dimension string, offset, limit int) (values []*string, totalCount int, err error) {
+	defer observeMongo("GetUniqueDimensionAndOptions", time.Now(), &err)
+	return m.MongoDB.GetUniqueDimensionAndOptions(ctx, ID, dimension, offset, limit)
+}
+
+func (m *mongoDB) GetVersions(ctx context.Context, datasetID, editionID, state string, offset, limit int) (versions []models.Version, totalCount int, err error) {
+	defer observeMongo("GetVersions", time.Now(), &err)
+	return m.MongoDB.GetVersions(ctx, datasetID, editionID, state, offset, limit)
+}
+
+func (m *mongoDB) GetVersionsInCollections(ctx context.Context) (versions []models.Version, err error) {
+	defer observeMongo("GetVersionsInCollections", time.Now(), &err)
+	return m.MongoDB.GetVersionsInCollections(ctx)
+}
+
+func (m *mongoDB) UpdateDataset(ctx context.Context, currentDataset *models.DatasetUpdate, dataset *models.Dataset, eTagSelector string) (newETag string, err error) {
+	defer observeMongo("UpdateDataset", time.Now(), &err)
+	return m.MongoDB.UpdateDataset(ctx, currentDataset, dataset, eTagSelector)
+}
+
+func (m *mongoDB) UpdateDatasetWithAssociation(ctx context.Context, currentDataset *models.DatasetUpdate, state string, version *models.Version) (err error) {
+	defer observeMongo("UpdateDatasetWithAssociation", time.Now(), &err)
+	return m.MongoDB.UpdateDatasetWithAssociation(ctx, currentDataset, state, version)
+}
+
+func (m *mongoDB) UpdateDimensionNodeIDAndOrder(ctx context.Context, dimension *models.DimensionOption) (err error) {
+	defer observeMongo("UpdateDimensionNodeIDAndOrder", time.Now(), &err)
+	return m.MongoDB.UpdateDimensionNodeIDAndOrder(ctx, dimension)
+}
+
+func (m *mongoDB) UpdateInstance(ctx context.Context, currentInstance, updatedInstance *models.Instance, eTagSelector string) (newETag string, err error) {
+	defer observeMongo("UpdateInstance", time.Now(), &err)
+	return m.MongoDB.UpdateInstance(ctx, currentInstance, updatedInstance, eTagSelector)
+}
+
+func (m *mongoDB) UpdateObservationInserted(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (newETag string, err error) {
+	defer observeMongo("UpdateObservationInserted", time.Now(), &err)
+	return m.MongoDB.UpdateObservationInserted(ctx, currentInstance, observationInserted, eTagSelector)
+}
+
+func (m *mongoDB) UpdateImportObservationsTaskState(ctx context.Context, currentInstance *models.Instance, state, eTagSelector string) (newETag string, err error) {
+	defer observeMongo("UpdateImportObservationsTaskState", time.Now(), &err)
+	return m.MongoDB.UpdateImportObservationsTaskState(ctx, currentInstance, state, eTagSelector)
+}
+
+func (m *mongoDB) UpdateBuildHierarchyTaskState(ctx context.Context, currentInstance *models.Instance, dimension, state, eTagSelector string) (newETag string, err error) {
+	defer observeMongo("UpdateBuildHierarchyTaskState", time.Now(), &err)
+	return m.MongoDB.UpdateBuildHierarchyTaskState(ctx, currentInstance, dimension, state, eTagSelector)
+}
+
+func (m *mongoDB) UpdateBuildSearchTaskState(ctx context.Context, currentInstance *models.Instance, dimension, state, eTagSelector string) (newETag string, err error) {
+	defer observeMongo("UpdateBuildSearchTaskState", time.Now(), &err)
+	return m.MongoDB.UpdateBuildSearchTaskState(ctx, currentInstance, dimension, state, eTagSelector)
+}
+
+func (m *mongoDB) RetryImportTasks(ctx context.Context, currentInstance *models.Instance, state string, tasks *models.InstanceImportTasks, event *models.Event, eTagSelector string) (newETag string, err error) {
+	defer observeMongo("RetryImportTasks", time.Now(), &err)
+	return m.MongoDB.RetryImportTasks(ctx, currentInstance, state, tasks, event, eTagSelector)
+}
+
+func (m *mongoDB) UpdateETagForNodeIDAndOrder(ctx context.Context, currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (newETag string, err error) {
+	defer observeMongo("UpdateETagForNodeIDAndOrder", time.Now(), &err)
+	return m.MongoDB.UpdateETagForNodeIDAndOrder(ctx, currentInstance, nodeID, order, eTagSelector)
+}
+
+func (m *mongoDB) UpdateETagForOptions(ctx context.Context, currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (newETag string, err error) {
+	defer observeMongo("UpdateETagForOptions", time.Now(), &err)
+	return m.MongoDB.UpdateETagForOptions(ctx, currentInstance, option, eTagSelector)
+}
+
+func (m *mongoDB) UpdateVersion(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (newETag string, err error) {
+	defer observeMongo("UpdateVersion", time.Now(), &err)
+	return m.MongoDB.UpdateVersion(ctx, currentVersion, version, eTagSelector)
+}
+
+func (m *mongoDB) UpsertContact(ctx context.Context, ID string, update interface{}) (err error) {
+	defer observeMongo("UpsertContact", time.Now(), &err)
+	return m.MongoDB.UpsertContact(ctx, ID, update)
+}
+
+func (m *mongoDB) UpsertDataset(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate, eTagSelector string) (err error) {
+	defer observeMongo("UpsertDataset", time.Now(), &err)
+	return m.MongoDB.UpsertDataset(ctx, ID, datasetDoc, eTagSelector)
+}
+
+func (m *mongoDB) UpsertEdition(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate, eTagSelector string) (err error) {
+	defer observeMongo("UpsertEdition", time.Now(), &err)
+	return m.MongoDB.UpsertEdition(ctx, datasetID, edition, editionDoc, eTagSelector)
+}
+
+func (m *mongoDB) UpsertVersion(ctx context.Context, ID string, versionDoc *models.Version) (err error) {
+	defer observeMongo("UpsertVersion", time.Now(), &err)
+	return m.MongoDB.UpsertVersion(ctx, ID, versionDoc)
+}
+
+func (m *mongoDB) UpsertTopic(ctx context.Context, topic *models.Topic) (err error) {
+	defer observeMongo("UpsertTopic", time.Now(), &err)
+	return m.MongoDB.UpsertTopic(ctx, topic)
+}
+
+func (m *mongoDB) UpsertLinkReport(ctx context.Context, report *models.LinkReport) (err error) {
+	defer observeMongo("UpsertLinkReport", time.Now(), &err)
+	return m.MongoDB.UpsertLinkReport(ctx, report)
+}
+
+func (m *mongoDB) DeleteDataset(ctx context.Context, ID string) (err error) {
+	defer observeMongo("DeleteDataset", time.Now(), &err)
+	return m.MongoDB.DeleteDataset(ctx, ID)
+}
+
+func (m *mongoDB) DeleteEdition(ctx context.Context, ID string) (err error) {
+	defer observeMongo("DeleteEdition", time.Now(), &err)
+	return m.MongoDB.DeleteEdition(ctx, ID)
+}
+
+func (m *mongoDB) DeleteTopic(ctx context.Context, id string) (err error) {
+	defer observeMongo("DeleteTopic", time.Now(), &err)
+	return m.MongoDB.DeleteTopic(ctx, id)
+}
+
+func (m *mongoDB) DeleteLinkReportsExcept(ctx context.Context, datasetIDs []string) (err error) {
+	defer observeMongo("DeleteLinkReportsExcept", time.Now(), &err)
+	return m.MongoDB.DeleteLinkReportsExcept(ctx, datasetIDs)
+}
+
+func (m *mongoDB) GetDatasetInstances(ctx context.Context, datasetID string) (instances []*models.Instance, err error) {
+	defer observeMongo("GetDatasetInstances", time.Now(), &err)
+	return m.MongoDB.GetDatasetInstances(ctx, datasetID)
+}
+
+func (m *mongoDB) DeleteInstance(ctx context.Context, instanceID string) (err error) {
+	defer observeMongo("DeleteInstance", time.Now(), &err)
+	return m.MongoDB.DeleteInstance(ctx, instanceID)
+}
+
+func (m *mongoDB) CountDimensionOptions(ctx context.Context, instanceID string) (count int, err error) {
+	defer observeMongo("CountDimensionOptions", time.Now(), &err)
+	return m.MongoDB.CountDimensionOptions(ctx, instanceID)
+}
+
+func (m *mongoDB) DeleteDimensionOptions(ctx context.Context, instanceID string) (deleted int, err error) {
+	defer observeMongo("DeleteDimensionOptions", time.Now(), &err)
+	return m.MongoDB.DeleteDimensionOptions(ctx, instanceID)
+}
+
+func (m *mongoDB) AddDeleteJob(ctx context.Context, job *models.DeleteJob) (err error) {
+	defer observeMongo("AddDeleteJob", time.Now(), &err)
+	return m.MongoDB.AddDeleteJob(ctx, job)
+}
+
+func (m *mongoDB) GetDeleteJob(ctx context.Context, jobID string) (job *models.DeleteJob, err error) {
+	defer observeMongo("GetDeleteJob", time.Now(), &err)
+	return m.MongoDB.GetDeleteJob(ctx, jobID)
+}
+
+func (m *mongoDB) UpdateDeleteJob(ctx context.Context, job *models.DeleteJob) (err error) {
+	defer observeMongo("UpdateDeleteJob", time.Now(), &err)
+	return m.MongoDB.UpdateDeleteJob(ctx, job)
+}
+
+func (m *mongoDB) GetDeletedDataset(ctx context.Context, id string) (dataset *models.DatasetUpdate, err error) {
+	defer observeMongo("GetDeletedDataset", time.Now(), &err)
+	return m.MongoDB.GetDeletedDataset(ctx, id)
+}
+
+func (m *mongoDB) GetDatasetsDeletedBefore(ctx context.Context, before time.Time) (ids []string, err error) {
+	defer observeMongo("GetDatasetsDeletedBefore", time.Now(), &err)
+	return m.MongoDB.GetDatasetsDeletedBefore(ctx, before)
+}
+
+func (m *mongoDB) GetDatasetEditions(ctx context.Context, datasetID string) (editions []*models.EditionUpdate, err error) {
+	defer observeMongo("GetDatasetEditions", time.Now(), &err)
+	return m.MongoDB.GetDatasetEditions(ctx, datasetID)
+}
+
+func (m *mongoDB) SoftDeleteDataset(ctx context.Context, datasetID, eTagSelector, deletedBy string, deletedAt time.Time) (err error) {
+	defer observeMongo("SoftDeleteDataset", time.Now(), &err)
+	return m.MongoDB.SoftDeleteDataset(ctx, datasetID, eTagSelector, deletedBy, deletedAt)
+}
+
+func (m *mongoDB) RestoreDataset(ctx context.Context, datasetID string) (err error) {
+	defer observeMongo("RestoreDataset", time.Now(), &err)
+	return m.MongoDB.RestoreDataset(ctx, datasetID)
+}
+
+func (m *mongoDB) SoftDeleteEdition(ctx context.Context, datasetID, edition, eTagSelector, deletedBy string, deletedAt time.Time) (err error) {
+	defer observeMongo("SoftDeleteEdition", time.Now(), &err)
+	return m.MongoDB.SoftDeleteEdition(ctx, datasetID, edition, eTagSelector, deletedBy, deletedAt)
+}
+
+func (m *mongoDB) GetEditionsDeletedBefore(ctx context.Context, before time.Time) (editions []*models.EditionUpdate, err error) {
+	defer observeMongo("GetEditionsDeletedBefore", time.Now(), &err)
+	return m.MongoDB.GetEditionsDeletedBefore(ctx, before)
+}
+
+func (m *mongoDB) LockPurge(ctx context.Context, ttl time.Duration) (lockID string, err error) {
+	defer observeMongo("LockPurge", time.Now(), &err)
+	return m.MongoDB.LockPurge(ctx, ttl)
+}
+
+func (m *mongoDB) RenewPurgeLock(ctx context.Context, lockID string, ttl time.Duration) (err error) {
+	defer observeMongo("RenewPurgeLock", time.Now(), &err)
+	return m.MongoDB.RenewPurgeLock(ctx, lockID, ttl)
+}
+
+func (m *mongoDB) UnlockPurge(ctx context.Context, lockID string) (err error) {
+	defer observeMongo("UnlockPurge", time.Now(), &err)
+	return m.MongoDB.UnlockPurge(ctx, lockID)
+}
+
+func (m *mongoDB) LockLinkCheck(ctx context.Context, ttl time.Duration) (lockID string, err error) {
+	defer observeMongo("LockLinkCheck", time.Now(), &err)
+	return m.MongoDB.LockLinkCheck(ctx, ttl)
+}
+
+func (m *mongoDB) RenewLinkCheckLock(ctx context.Context, lockID string, ttl time.Duration) (err error) {
+	defer observeMongo("RenewLinkCheckLock", time.Now(), &err)
+	return m.MongoDB.RenewLinkCheckLock(ctx, lockID, ttl)
+}
+
+func (m *mongoDB) UnlockLinkCheck(ctx context.Context, lockID string) (err error) {
+	defer observeMongo("UnlockLinkCheck", time.Now(), &err)
+	return m.MongoDB.UnlockLinkCheck(ctx, lockID)
+}
+
+func (m *mongoDB) ClearLatestEditions(ctx context.Context, datasetID, edition string) (err error) {
+	defer observeMongo("ClearLatestEditions", time.Now(), &err)
+	return m.MongoDB.ClearLatestEditions(ctx, datasetID, edition)
+}
+
+func (m *mongoDB) AcquireInstanceLock(ctx context.Context, instanceID string) (lockID string, err error) {
+	defer observeMongo("AcquireInstanceLock", time.Now(), &err)
+	return m.MongoDB.AcquireInstanceLock(ctx, instanceID)
+}
+
+func (m *mongoDB) UnlockInstance(ctx context.Context, lockID string) (err error) {
+	defer observeMongo("UnlockInstance", time.Now(), &err)
+	return m.MongoDB.UnlockInstance(ctx, lockID)
+}
+
+// graphDB records the latency and errors of the store methods of the embedded graph store
+type graphDB struct {
+	store.GraphDB
+}
+
+func (g *graphDB) AddVersionDetailsToInstance(ctx context.Context, instanceID string, datasetID string, edition string, version int) (err error) {
+	defer observeGraph("AddVersionDetailsToInstance", time.Now(), &err)
+	return g.GraphDB.AddVersionDetailsToInstance(ctx, instanceID, datasetID, edition, version)
+}
+
+func (g *graphDB) SetInstanceIsPublished(ctx context.Context, instanceID string) (err error) {
+	defer observeGraph("SetInstanceIsPublished", time.Now(), &err)
+	return g.GraphDB.SetInstanceIsPublished(ctx, instanceID)
+}
+
+func (g *graphDB) CountInstanceNodes(ctx context.Context, instanceID string) (count int64, err error) {
+	defer observeGraph("CountInstanceNodes", time.Now(), &err)
+	return g.GraphDB.CountInstanceNodes(ctx, instanceID)
+}
+
+func (g *graphDB) DeleteInstanceNodes(ctx context.Context, instanceID string) (deleted int64, err error) {
+	defer observeGraph("DeleteInstanceNodes", time.Now(), &err)
+	return g.GraphDB.DeleteInstanceNodes(ctx, instanceID)
+}
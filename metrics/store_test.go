@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestInstrumentMongoDB(t *testing.T) {
+	Convey("Given an instrumented mongo store", t, func() {
+		var getDatasetErr error
+		mongoMock := &storetest.MongoDBMock{
+			GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+				if getDatasetErr != nil {
+					return nil, getDatasetErr
+				}
+				return &models.DatasetUpdate{ID: ID}, nil
+			},
+		}
+		db := InstrumentMongoDB(mongoMock)
+		errorsBefore := testutil.ToFloat64(MongoErrors.WithLabelValues("GetDataset"))
+
+		Convey("When a store method succeeds", func() {
+			dataset, err := db.GetDataset(context.Background(), "cpih01")
+
+			Convey("Then the result of the embedded store is returned, without counting an error", func() {
+				So(err, ShouldBeNil)
+				So(dataset.ID, ShouldEqual, "cpih01")
+				So(mongoMock.GetDatasetCalls(), ShouldHaveLength, 1)
+				So(testutil.ToFloat64(MongoErrors.WithLabelValues("GetDataset")), ShouldEqual, errorsBefore)
+			})
+		})
+
+		Convey("When a store method fails", func() {
+			getDatasetErr = errors.New("connection reset")
+			_, err := db.GetDataset(context.Background(), "cpih01")
+
+			Convey("Then the error is returned and counted", func() {
+				So(err, ShouldEqual, getDatasetErr)
+				So(testutil.ToFloat64(MongoErrors.WithLabelValues("GetDataset")), ShouldEqual, errorsBefore+1)
+			})
+		})
+
+		Convey("When a store method does not find the resource", func() {
+			getDatasetErr = errs.ErrDatasetNotFound
+			_, err := db.GetDataset(context.Background(), "cpih01")
+
+			Convey("Then the error is returned without being counted", func() {
+				So(err, ShouldEqual, errs.ErrDatasetNotFound)
+				So(testutil.ToFloat64(MongoErrors.WithLabelValues("GetDataset")), ShouldEqual, errorsBefore)
+			})
+		})
+	})
+
+	Convey("A store which has not been created is not instrumented", t, func() {
+		So(InstrumentMongoDB(nil), ShouldBeNil)
+		So(InstrumentGraphDB(nil), ShouldBeNil)
+	})
+}
+
+func TestInstrumentGraphDB(t *testing.T) {
+	Convey("Given an instrumented graph store which fails", t, func() {
+		graphMock := &storetest.GraphDBMock{
+			SetInstanceIsPublishedFunc: func(ctx context.Context, instanceID string) error {
+				return errors.New("graph unavailable")
+			},
+		}
+		db := InstrumentGraphDB(graphMock)
+		errorsBefore := testutil.ToFloat64(GraphErrors.WithLabelValues("SetInstanceIsPublished"))
+
+		Convey("When an instance is set as published", func() {
+			err := db.SetInstanceIsPublished(context.Background(), "123")
+
+			Convey("Then the error is returned and counted", func() {
+				So(err, ShouldNotBeNil)
+				So(graphMock.SetInstanceIsPublishedCalls(), ShouldHaveLength, 1)
+				So(testutil.ToFloat64(GraphErrors.WithLabelValues("SetInstanceIsPublished")), ShouldEqual, errorsBefore+1)
+			})
+		})
+	})
+}
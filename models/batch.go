@@ -0,0 +1,39 @@
+package models
+
+// DatasetBatchItem represents one of the datasets requested by id in a batch. Resource is the dataset as the caller can
+// view it, or nil with NotFound set if there is no such dataset the caller can view, or with Error set if the dataset
+// could not be retrieved.
+type DatasetBatchItem struct {
+	ID       string      `json:"id"`
+	Resource interface{} `json:"resource,omitempty"`
+	NotFound bool        `json:"not_found,omitempty"`
+	Error    *Problem    `json:"error,omitempty"`
+}
+
+// VersionKey identifies a version of an edition of a dataset
+type VersionKey struct {
+	Dataset string `json:"dataset"`
+	Edition string `json:"edition"`
+	Version int    `json:"version"`
+}
+
+// VersionBatchRequest represents the versions requested in a batch
+type VersionBatchRequest struct {
+	Items []VersionKey `json:"items"`
+}
+
+// VersionBatchItem represents one of the versions requested in a batch. Resource is the version as the caller can view
+// it, or nil with NotFound set if there is no such version the caller can view, or with Error set if the version could
+// not be retrieved.
+type VersionBatchItem struct {
+	VersionKey
+	Resource *Version `json:"resource,omitempty"`
+	NotFound bool     `json:"not_found,omitempty"`
+	Error    *Problem `json:"error,omitempty"`
+}
+
+// VersionBatchResponse represents the versions requested in a batch, in the order they were requested in
+type VersionBatchResponse struct {
+	Items []VersionBatchItem `json:"items"`
+	Count int                `json:"count"`
+}
@@ -64,12 +64,24 @@ type DatasetUpdate struct {
 	ID      string   `bson:"_id,omitempty"         json:"id,omitempty"`
 	Current *Dataset `bson:"current,omitempty"     json:"current,omitempty"`
 	Next    *Dataset `bson:"next,omitempty"        json:"next,omitempty"`
+	ETag    string   `bson:"e_tag"                 json:"-"`
+}
+
+// Hash generates a SHA-1 hash of the dataset document, ignoring the ETag. extraBytes may be provided to
+// generate a different hash for an updated dataset document.
+func (d *DatasetUpdate) Hash(extraBytes []byte) (string, error) {
+	// copy by value to ignore ETag without affecting d
+	d2 := *d
+	d2.ETag = ""
+	return hash(d2, extraBytes)
 }
 
 // Dataset represents information related to a single dataset
 type Dataset struct {
 	CollectionID      string           `bson:"collection_id,omitempty"          json:"collection_id,omitempty"`
+	CollectionName    string           `bson:"collection_name,omitempty"        json:"collection_name,omitempty"`
 	Contacts          []ContactDetails `bson:"contacts,omitempty"               json:"contacts,omitempty"`
+	Deletion          *Deletion        `bson:"deletion,omitempty"               json:"-"`
 	Description       string           `bson:"description,omitempty"            json:"description,omitempty"`
 	Keywords          []string         `bson:"keywords,omitempty"               json:"keywords,omitempty"`
 	ID                string           `bson:"_id,omitempty"                    json:"id,omitempty"`
@@ -113,9 +125,55 @@ type LinkObject struct {
 type GeneralDetails struct {
 	Description string `bson:"description,omitempty"    json:"description,omitempty"`
 	HRef        string `bson:"href,omitempty"           json:"href,omitempty"`
+	ID          string `bson:"id,omitempty"             json:"id,omitempty"`
 	Title       string `bson:"title,omitempty"          json:"title,omitempty"`
 }
 
+// DatasetRelations represents the datasets a dataset is related to, and the datasets related to it. Related datasets
+// held by this API that no longer exist are listed as dangling.
+type DatasetRelations struct {
+	Related   []GeneralDetails `json:"related"`
+	RelatedBy []GeneralDetails `json:"related_by"`
+	Dangling  []GeneralDetails `json:"dangling,omitempty"`
+}
+
+// RelatedDatasetID returns the ID of the related dataset if it is a dataset held by the API running on host,
+// either because it has been typed with an ID or because its href points at the dataset, or an empty string if the
+// related dataset is an external link
+func RelatedDatasetID(host string, related GeneralDetails) string {
+	if related.ID != "" {
+		return related.ID
+	}
+
+	href, err := url.Parse(related.HRef)
+	if err != nil {
+		return ""
+	}
+
+	if href.Host != "" {
+		api, err := url.Parse(host)
+		if err != nil || href.Scheme != api.Scheme || href.Host != api.Host {
+			return ""
+		}
+	}
+
+	path := strings.Split(strings.Trim(href.Path, "/"), "/")
+	if len(path) != 2 || path[0] != "datasets" || path[1] == "" {
+		return ""
+	}
+	return path[1]
+}
+
+// IsRelatedTo checks whether the dataset has a typed related dataset reference to the dataset with the ID
+func (d *Dataset) IsRelatedTo(datasetID string) bool {
+	for _, related := range d.RelatedDatasets {
+		if related.ID == datasetID {
+			return true
+		}
+	}
+	return false
+}
+
 // Contact represents information of individual contact details
 type Contact struct {
 	Email       string    `bson:"email,omitempty"          json:"email,omitempty"`
@@ -137,6 +195,16 @@ type EditionUpdate struct {
 	ID      string   `bson:"id,omitempty"         json:"id,omitempty"`
 	Current *Edition `bson:"current,omitempty"     json:"current,omitempty"`
 	Next    *Edition `bson:"next,omitempty"        json:"next,omitempty"`
+	ETag    string   `bson:"e_tag"                json:"-"`
+}
+
+// Hash generates a SHA-1 hash of the edition document, ignoring the ETag. extraBytes may be provided to
+// generate a different hash for an updated edition document.
+func (ed *EditionUpdate) Hash(extraBytes []byte) (string, error) {
+	// copy by value to ignore ETag without affecting ed
+	ed2 := *ed
+	ed2.ETag = ""
+	return hash(ed2, extraBytes)
 }
 
 // EditionUpdateLinks represents those links common the both the current and next edition
@@ -149,15 +217,49 @@ type EditionUpdateLinks struct {
 
 // Edition represents information related to a single edition for a dataset
 type Edition struct {
+	Deletion    *Deletion           `bson:"deletion,omitempty"     json:"-"`
+	Description string              `bson:"description,omitempty"  json:"description,omitempty"`
 	Edition     string              `bson:"edition,omitempty"      json:"edition,omitempty"`
 	ID          string              `bson:"id,omitempty"           json:"id,omitempty"`
 	LastUpdated time.Time           `bson:"last_updated,omitempty" json:"-"`
+	Latest      bool                `bson:"latest,omitempty"       json:"latest,omitempty"`
 	Links       *EditionUpdateLinks `bson:"links,omitempty"        json:"links,omitempty"`
 	State       string              `bson:"state,omitempty"        json:"state,omitempty"`
 	IsBasedOn   *IsBasedOn          `bson:"is_based_on,omitempty"  json:"is_based_on,omitempty"`
+	Title       string              `bson:"title,omitempty"        json:"title,omitempty"`
 	Type        string              `bson:"type,omitempty"         json:"type,omitempty"`
 }
 
+// EditionMetadata represents the fields of an edition which can be set through the API. Latest marks the edition as
+// the latest edition of its dataset, so time-series editions can be told apart. The fields are pointers, so a field
+// provided as empty can be told apart from a field that was not provided.
+type EditionMetadata struct {
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Latest      *bool   `json:"latest,omitempty"`
+}
+
+// Apply updates the edition with the provided metadata, ignoring fields that were not provided. A title or
+// description provided as empty is cleared.
+func (m *EditionMetadata) Apply(edition *Edition) {
+	if m.Title != nil {
+		edition.Title = *m.Title
+	}
+	if m.Description != nil {
+		edition.Description = *m.Description
+	}
+	if m.Latest != nil {
+		edition.Latest = *m.Latest
+	}
+}
+
+// Deletion records who deleted a resource and when, and the state to restore the resource to
+type Deletion struct {
+	DeletedBy     string    `bson:"deleted_by"     json:"deleted_by"`
+	DeletedAt     time.Time `bson:"deleted_at"     json:"deleted_at"`
+	PreviousState string    `bson:"previous_state" json:"-"`
+}
+
 // Publisher represents an object containing information of the publisher
 type Publisher struct {
 	HRef string `bson:"href,omitempty" json:"href,omitempty"`
@@ -167,22 +269,34 @@ type Publisher struct {
 
 // Version represents information related to a single version for an edition of a dataset
 type Version struct {
-	Alerts        *[]Alert             `bson:"alerts,omitempty"         json:"alerts,omitempty"`
-	CollectionID  string               `bson:"collection_id,omitempty"  json:"collection_id,omitempty"`
-	DatasetID     string               `bson:"-"                        json:"dataset_id,omitempty"`
-	Dimensions    []Dimension          `bson:"dimensions,omitempty"     json:"dimensions,omitempty"`
-	Downloads     *DownloadList        `bson:"downloads,omitempty"      json:"downloads,omitempty"`
-	Edition       string               `bson:"edition,omitempty"        json:"edition,omitempty"`
-	Headers       []string             `bson:"headers,omitempty"        json:"-"`
-	ID            string               `bson:"id,omitempty"             json:"id,omitempty"`
-	LastUpdated   time.Time            `bson:"last_updated,omitempty"   json:"-"`
-	LatestChanges *[]LatestChange      `bson:"latest_changes,omitempty" json:"latest_changes,omitempty"`
-	Links         *VersionLinks        `bson:"links,omitempty"          json:"links,omitempty"`
-	ReleaseDate   string               `bson:"release_date,omitempty"   json:"release_date,omitempty"`
-	State         string               `bson:"state,omitempty"          json:"state,omitempty"`
-	Temporal      *[]TemporalFrequency `bson:"temporal,omitempty"       json:"temporal,omitempty"`
-	UsageNotes    *[]UsageNote         `bson:"usage_notes,omitempty"    json:"usage_notes,omitempty"`
-	Version       int                  `bson:"version,omitempty"        json:"version,omitempty"`
+	Alerts         *[]Alert             `bson:"alerts,omitempty"          json:"alerts,omitempty"`
+	CollectionID   string               `bson:"collection_id,omitempty"   json:"collection_id,omitempty"`
+	CollectionName string               `bson:"collection_name,omitempty" json:"collection_name,omitempty"`
+	DatasetID      string               `bson:"-"                         json:"dataset_id,omitempty"`
+	Deletion       *Deletion            `bson:"deletion,omitempty"        json:"-"`
+	Dimensions     []Dimension          `bson:"dimensions,omitempty"      json:"dimensions,omitempty"`
+	Downloads      *DownloadList        `bson:"downloads,omitempty"       json:"downloads,omitempty"`
+	Edition        string               `bson:"edition,omitempty"         json:"edition,omitempty"`
+	Headers        []string             `bson:"headers,omitempty"         json:"-"`
+	ID             string               `bson:"id,omitempty"              json:"id,omitempty"`
+	LastUpdated    time.Time            `bson:"last_updated,omitempty"    json:"-"`
+	LatestChanges  *[]LatestChange      `bson:"latest_changes,omitempty"  json:"latest_changes,omitempty"`
+	Links          *VersionLinks        `bson:"links,omitempty"           json:"links,omitempty"`
+	ReleaseDate    string               `bson:"release_date,omitempty"    json:"release_date,omitempty"`
+	State          string               `bson:"state,omitempty"           json:"state,omitempty"`
+	Temporal       *[]TemporalFrequency `bson:"temporal,omitempty"        json:"temporal,omitempty"`
+	UsageNotes     *[]UsageNote         `bson:"usage_notes,omitempty"     json:"usage_notes,omitempty"`
+	Version        int                  `bson:"version,omitempty"         json:"version,omitempty"`
+	ETag           string               `bson:"e_tag"                     json:"-"`
+}
+
+// Hash generates a SHA-1 hash of the version document, ignoring the ETag. extraBytes may be provided to
+// generate a different hash for an updated version document.
+func (v *Version) Hash(extraBytes []byte) (string, error) {
+	// copy by value to ignore ETag without affecting v
+	v2 := *v
+	v2.ETag = ""
+	return hash(v2, extraBytes)
 }
 
 // Alert represents an object containing information on an alert
@@ -192,6 +306,37 @@ type Alert struct {
 	Type        string `bson:"type,omitempty"        json:"type,omitempty"`
 }
 
+// CorrectionAlertType is the type of alert explaining why a published version was withdrawn
+const CorrectionAlertType = "correction"
+
+// VersionWithdrawal represents the request to withdraw a published version
+type VersionWithdrawal struct {
+	Alert *Alert `json:"alert"`
+}
+
+// CreateVersionWithdrawal manages the creation of a version withdrawal from a reader
+func CreateVersionWithdrawal(reader io.Reader) (*VersionWithdrawal, error) {
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, errs.ErrUnableToReadMessage
+	}
+
+	var withdrawal VersionWithdrawal
+	if err = json.Unmarshal(b, &withdrawal); err != nil {
+		return nil, errs.ErrUnableToParseJSON
+	}
+
+	return &withdrawal, nil
+}
+
+// Validate checks the withdrawal is explained by a correction alert
+func (w *VersionWithdrawal) Validate() error {
+	if w.Alert == nil || !strings.EqualFold(w.Alert.Type, CorrectionAlertType) || w.Alert.Description == "" {
+		return errs.ErrWithdrawalAlertInvalid
+	}
+	return nil
+}
+
 // DownloadList represents a list of objects of containing information on the downloadable files
 type DownloadList struct {
 	CSV  *DownloadObject `bson:"csv,omitempty" json:"csv,omitempty"`
@@ -316,37 +461,78 @@ func CreateContact(reader io.Reader) (*Contact, error) {
 	return &contact, nil
 }
 
-// CreateEdition manages the creation of a an edition object
-func CreateEdition(host, datasetID, edition string) (*EditionUpdate, error) {
+// CreateEdition manages the creation of a an edition object, for the first version of the edition
+func CreateEdition(datasetID, edition string) (*EditionUpdate, error) {
+	editionDoc := CreateEmptyEdition(datasetID, edition)
+	editionDoc.Next.State = EditionConfirmedState
+	editionDoc.SetFirstVersionLink()
+
+	return editionDoc, nil
+}
+
+// CreateEmptyEdition manages the creation of an edition object without any versions. Its links are paths, which are
+// rendered against the url the api is requested from.
+func CreateEmptyEdition(datasetID, edition string) *EditionUpdate {
 	id := uuid.NewV4()
 
 	return &EditionUpdate{
 		ID: id.String(),
 		Next: &Edition{
 			Edition: edition,
-			State:   EditionConfirmedState,
+			State:   CreatedState,
 			Links: &EditionUpdateLinks{
 				Dataset: &LinkObject{
 					ID:   datasetID,
-					HRef: fmt.Sprintf("%s/datasets/%s", host, datasetID),
+					HRef: fmt.Sprintf("/datasets/%s", datasetID),
 				},
 				Self: &LinkObject{
-					HRef: fmt.Sprintf("%s/datasets/%s/editions/%s", host, datasetID, edition),
+					HRef: fmt.Sprintf("/datasets/%s/editions/%s", datasetID, edition),
 				},
 				Versions: &LinkObject{
-					HRef: fmt.Sprintf("%s/datasets/%s/editions/%s/versions", host, datasetID, edition),
-				},
-				LatestVersion: &LinkObject{
-					ID:   "1",
-					HRef: fmt.Sprintf("%s/datasets/%s/editions/%s/versions/1", host, datasetID, edition),
+					HRef: fmt.Sprintf("/datasets/%s/editions/%s/versions", datasetID, edition),
 				},
 			},
 		},
-	}, nil
+	}
+}
+
+// CreateEditionMetadata manages the creation of edition metadata from a reader
+func CreateEditionMetadata(reader io.Reader) (*EditionMetadata, error) {
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, errs.ErrUnableToReadMessage
+	}
+
+	var metadata EditionMetadata
+
+	// an edition may be created without any metadata
+	if len(b) == 0 {
+		return &metadata, nil
+	}
+
+	err = json.Unmarshal(b, &metadata)
+	if err != nil {
+		return nil, errs.ErrUnableToParseJSON
+	}
+
+	return &metadata, nil
+}
+
+// IsEmpty reports whether the edition was created without versions, and no version has been added since
+func (ed *EditionUpdate) IsEmpty() bool {
+	return ed.Current == nil && ed.Next != nil && ed.Next.Links != nil && ed.Next.Links.LatestVersion == nil
+}
+
+// SetFirstVersionLink links the edition.next document to the first version of the edition
+func (ed *EditionUpdate) SetFirstVersionLink() {
+	ed.Next.Links.LatestVersion = &LinkObject{
+		ID:   "1",
+		HRef: fmt.Sprintf("/datasets/%s/editions/%s/versions/1", ed.Next.Links.Dataset.ID, ed.Next.Edition),
+	}
 }
 
 //UpdateLinks in the editions.next document, ensuring links can't regress once published to current
-func (ed *EditionUpdate) UpdateLinks(ctx context.Context, host string) error {
+func (ed *EditionUpdate) UpdateLinks(ctx context.Context) error {
 	if ed.Next == nil || ed.Next.Links == nil || ed.Next.Links.LatestVersion == nil || ed.Next.Links.LatestVersion.ID == "" {
 		return ErrEditionLinksInvalid
 	}
@@ -377,7 +563,7 @@ func (ed *EditionUpdate) UpdateLinks(ctx context.Context, host string) error {
 
 	ed.Next.Links.LatestVersion = &LinkObject{
 		ID:   versionID,
-		HRef: fmt.Sprintf("%s/datasets/%s/editions/%s/versions/%s", host, ed.Next.Links.Dataset.ID, ed.Next.Edition, versionID),
+		HRef: fmt.Sprintf("/datasets/%s/editions/%s/versions/%s", ed.Next.Links.Dataset.ID, ed.Next.Edition, versionID),
 	}
 
 	return nil
@@ -418,6 +604,33 @@ func (ed *EditionUpdate) PublishLinks(ctx context.Context, host string, versionL
 	return nil
 }
 
+// ReplaceLatestVersion re-points the latest version links of the edition from the withdrawn version to the provided
+// version link, returning true if a link was changed
+func (ed *EditionUpdate) ReplaceLatestVersion(withdrawnVersion string, versionLink *LinkObject) bool {
+	var replaced bool
+	for _, edition := range []*Edition{ed.Current, ed.Next} {
+		if edition != nil && edition.Links != nil && edition.Links.LatestVersion != nil && edition.Links.LatestVersion.ID == withdrawnVersion {
+			edition.Links.LatestVersion = versionLink
+			replaced = true
+		}
+	}
+	return replaced
+}
+
+// ReplaceLatestVersion re-points the latest version links of the dataset from the withdrawn version to the provided
+// version link, returning true if a link was changed. As the latest version of a dataset may belong to any of its
+// editions, the links are compared by href.
+func (d *DatasetUpdate) ReplaceLatestVersion(withdrawnVersion *LinkObject, versionLink *LinkObject) bool {
+	var replaced bool
+	for _, dataset := range []*Dataset{d.Current, d.Next} {
+		if dataset != nil && dataset.Links != nil && dataset.Links.LatestVersion != nil && dataset.Links.LatestVersion.HRef == withdrawnVersion.HRef {
+			dataset.Links.LatestVersion = versionLink
+			replaced = true
+		}
+	}
+	return replaced
+}
+
 // CleanDataset trims URI and any hrefs contained in the database
 func CleanDataset(dataset *Dataset) {
 	dataset.URI = strings.TrimSpace(dataset.URI)
@@ -465,7 +678,7 @@ func ValidateDataset(dataset *Dataset) error {
 	invalidFields = append(invalidFields, validateGeneralDetails(dataset.Methodologies, "Methodologies")...)
 
 	if len(invalidFields) > 0 {
-		return fmt.Errorf("invalid fields: %v", invalidFields)
+		return &FieldsError{Reason: "invalid fields", Fields: invalidFields}
 	}
 
 	return nil
@@ -571,11 +784,11 @@ func ValidateVersion(version *Version) error {
 	}
 
 	if missingFields != nil {
-		return fmt.Errorf("missing mandatory fields: %v", missingFields)
+		return &FieldsError{Reason: "missing mandatory fields", Fields: missingFields, Missing: true}
 	}
 
 	if invalidFields != nil {
-		return fmt.Errorf("invalid fields: %v", invalidFields)
+		return &FieldsError{Reason: "invalid fields", Fields: invalidFields}
 	}
 
 	return nil
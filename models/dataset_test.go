@@ -514,31 +514,31 @@ func TestValidateVersion(t *testing.T) {
 			v := &Version{ReleaseDate: "Today", State: EditionConfirmedState}
 
 			v.Downloads = &DownloadList{XLS: &DownloadObject{HRef: "", Size: "2"}}
-			assertVersionDownloadError(fmt.Errorf("missing mandatory fields: %v", []string{"Downloads.XLS.HRef"}), v)
+			assertVersionDownloadError(&FieldsError{Reason: "missing mandatory fields", Fields: []string{"Downloads.XLS.HRef"}, Missing: true}, v)
 
 			v.Downloads = &DownloadList{CSV: &DownloadObject{HRef: "", Size: "2"}}
-			assertVersionDownloadError(fmt.Errorf("missing mandatory fields: %v", []string{"Downloads.CSV.HRef"}), v)
+			assertVersionDownloadError(&FieldsError{Reason: "missing mandatory fields", Fields: []string{"Downloads.CSV.HRef"}, Missing: true}, v)
 
 			v.Downloads = &DownloadList{CSVW: &DownloadObject{HRef: "", Size: "2"}}
-			assertVersionDownloadError(fmt.Errorf("missing mandatory fields: %v", []string{"Downloads.CSVW.HRef"}), v)
+			assertVersionDownloadError(&FieldsError{Reason: "missing mandatory fields", Fields: []string{"Downloads.CSVW.HRef"}, Missing: true}, v)
 
 			v.Downloads = &DownloadList{XLS: &DownloadObject{HRef: "/", Size: ""}}
-			assertVersionDownloadError(fmt.Errorf("missing mandatory fields: %v", []string{"Downloads.XLS.Size"}), v)
+			assertVersionDownloadError(&FieldsError{Reason: "missing mandatory fields", Fields: []string{"Downloads.XLS.Size"}, Missing: true}, v)
 
 			v.Downloads = &DownloadList{CSV: &DownloadObject{HRef: "/", Size: ""}}
-			assertVersionDownloadError(fmt.Errorf("missing mandatory fields: %v", []string{"Downloads.CSV.Size"}), v)
+			assertVersionDownloadError(&FieldsError{Reason: "missing mandatory fields", Fields: []string{"Downloads.CSV.Size"}, Missing: true}, v)
 
 			v.Downloads = &DownloadList{CSVW: &DownloadObject{HRef: "/", Size: ""}}
-			assertVersionDownloadError(fmt.Errorf("missing mandatory fields: %v", []string{"Downloads.CSVW.Size"}), v)
+			assertVersionDownloadError(&FieldsError{Reason: "missing mandatory fields", Fields: []string{"Downloads.CSVW.Size"}, Missing: true}, v)
 
 			v.Downloads = &DownloadList{XLS: &DownloadObject{HRef: "/", Size: "bob"}}
-			assertVersionDownloadError(fmt.Errorf("invalid fields: %v", []string{"Downloads.XLS.Size not a number"}), v)
+			assertVersionDownloadError(&FieldsError{Reason: "invalid fields", Fields: []string{"Downloads.XLS.Size not a number"}}, v)
 
 			v.Downloads = &DownloadList{CSV: &DownloadObject{HRef: "/", Size: "bob"}}
-			assertVersionDownloadError(fmt.Errorf("invalid fields: %v", []string{"Downloads.CSV.Size not a number"}), v)
+			assertVersionDownloadError(&FieldsError{Reason: "invalid fields", Fields: []string{"Downloads.CSV.Size not a number"}}, v)
 
 			v.Downloads = &DownloadList{CSVW: &DownloadObject{HRef: "/", Size: "bob"}}
-			assertVersionDownloadError(fmt.Errorf("invalid fields: %v", []string{"Downloads.CSVW.Size not a number"}), v)
+			assertVersionDownloadError(&FieldsError{Reason: "invalid fields", Fields: []string{"Downloads.CSVW.Size not a number"}}, v)
 		})
 	})
 }
@@ -576,7 +576,6 @@ func TestCreateDownloadList(t *testing.T) {
 }
 
 func TestUpdateLinks(t *testing.T) {
-	host := "example.com"
 
 	Convey("Given a new edition with no links", t, func() {
 		edition := &EditionUpdate{
@@ -588,7 +587,7 @@ func TestUpdateLinks(t *testing.T) {
 		}
 
 		Convey("when UpdateLinks is called", func() {
-			err := edition.UpdateLinks(testContext, host)
+			err := edition.UpdateLinks(testContext)
 
 			Convey("then an error should be returned", func() {
 				So(err, ShouldNotBeNil)
@@ -620,7 +619,7 @@ func TestUpdateLinks(t *testing.T) {
 		}
 
 		Convey("when UpdateLinks is called", func() {
-			err := edition.UpdateLinks(testContext, host)
+			err := edition.UpdateLinks(testContext)
 
 			Convey("then links are correctly updated", func() {
 				So(err, ShouldBeNil)
@@ -670,7 +669,7 @@ func TestUpdateLinks(t *testing.T) {
 		}
 
 		Convey("when UpdateLinks is called", func() {
-			err := edition.UpdateLinks(testContext, host)
+			err := edition.UpdateLinks(testContext)
 			Convey("then links are correctly updated", func() {
 				So(err, ShouldBeNil)
 				So(edition.Next.Links.LatestVersion.ID, ShouldEqual, "2")
@@ -680,7 +679,7 @@ func TestUpdateLinks(t *testing.T) {
 
 		Convey("when UpdateLinks is called with a version ID which is lower than the latest published version", func() {
 			edition.Current.Links.LatestVersion.ID = "3"
-			err := edition.UpdateLinks(testContext, host)
+			err := edition.UpdateLinks(testContext)
 			Convey("then an error should be returned", func() {
 				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldResemble, "published edition links to a higher version than the requested change")
@@ -689,7 +688,7 @@ func TestUpdateLinks(t *testing.T) {
 
 		Convey("when UpdateLinks is called on an edition with an invalid current version ID", func() {
 			edition.Current.Links.LatestVersion.ID = "hi"
-			err := edition.UpdateLinks(testContext, host)
+			err := edition.UpdateLinks(testContext)
 			Convey("then an error should be returned", func() {
 				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldResemble, "failed to convert version id from edition.current document: strconv.Atoi: parsing \"hi\": invalid syntax")
@@ -698,7 +697,7 @@ func TestUpdateLinks(t *testing.T) {
 
 		Convey("when UpdateLinks is called on an edition with an invalid next version ID", func() {
 			edition.Next.Links.LatestVersion.ID = "there"
-			err := edition.UpdateLinks(testContext, host)
+			err := edition.UpdateLinks(testContext)
 			Convey("then an error should be returned", func() {
 				So(err, ShouldNotBeNil)
 				So(err.Error(), ShouldResemble, "failed to convert version id from edition.next document: strconv.Atoi: parsing \"there\": invalid syntax")
@@ -922,3 +921,259 @@ func TestValidateVersionNumberFailure(t *testing.T) {
 		})
 	})
 }
+
+func TestDatasetHash(t *testing.T) {
+
+	Convey("Given a dataset with some data", t, func() {
+		dataset := DatasetUpdate{
+			ID:   "123",
+			Next: &Dataset{Title: "CPI", State: CreatedState},
+		}
+
+		Convey("We can generate a valid hash", func() {
+			h, err := dataset.Hash(nil)
+			So(err, ShouldBeNil)
+			So(len(h), ShouldEqual, 40)
+
+			Convey("Then storing the hash as its ETag value and hashing it again, produces the same result and ETag field is preserved", func() {
+				dataset.ETag = h
+				hash, err := dataset.Hash(nil)
+				So(err, ShouldBeNil)
+				So(hash, ShouldEqual, h)
+				So(dataset.ETag, ShouldEqual, h)
+			})
+
+			Convey("Then if a dataset value is modified, its hash changes", func() {
+				dataset.Next.State = PublishedState
+				hash, err := dataset.Hash(nil)
+				So(err, ShouldBeNil)
+				So(hash, ShouldNotEqual, h)
+			})
+
+			Convey("Then providing extra bytes changes the hash", func() {
+				hash, err := dataset.Hash([]byte("update"))
+				So(err, ShouldBeNil)
+				So(hash, ShouldNotEqual, h)
+			})
+		})
+	})
+}
+
+func TestEditionHash(t *testing.T) {
+
+	Convey("Given an edition with some data", t, func() {
+		edition := EditionUpdate{
+			ID:   "123",
+			Next: &Edition{Edition: "2017", State: EditionConfirmedState},
+		}
+
+		Convey("We can generate a valid hash that ignores the ETag field", func() {
+			h, err := edition.Hash(nil)
+			So(err, ShouldBeNil)
+			So(len(h), ShouldEqual, 40)
+
+			edition.ETag = h
+			hash, err := edition.Hash(nil)
+			So(err, ShouldBeNil)
+			So(hash, ShouldEqual, h)
+
+			Convey("Then if an edition value is modified, its hash changes", func() {
+				edition.Next.State = PublishedState
+				hash, err := edition.Hash(nil)
+				So(err, ShouldBeNil)
+				So(hash, ShouldNotEqual, h)
+			})
+		})
+	})
+}
+
+func TestVersionHash(t *testing.T) {
+
+	Convey("Given a version with some data", t, func() {
+		version := Version{
+			ID:      "789",
+			Edition: "2017",
+			State:   EditionConfirmedState,
+			Version: 1,
+		}
+
+		Convey("We can generate a valid hash that ignores the ETag field", func() {
+			h, err := version.Hash(nil)
+			So(err, ShouldBeNil)
+			So(len(h), ShouldEqual, 40)
+
+			version.ETag = h
+			hash, err := version.Hash(nil)
+			So(err, ShouldBeNil)
+			So(hash, ShouldEqual, h)
+
+			Convey("Then if a version value is modified, its hash changes", func() {
+				version.State = AssociatedState
+				hash, err := version.Hash(nil)
+				So(err, ShouldBeNil)
+				So(hash, ShouldNotEqual, h)
+			})
+		})
+	})
+}
+
+func TestCreateEditionMetadata(t *testing.T) {
+	Convey("Successfully return edition metadata when the reader contains valid json", t, func() {
+		metadata, err := CreateEditionMetadata(bytes.NewBufferString(`{"title":"Time series","latest":true}`))
+		So(err, ShouldBeNil)
+		So(*metadata.Title, ShouldEqual, "Time series")
+		So(metadata.Description, ShouldBeNil)
+		So(*metadata.Latest, ShouldBeTrue)
+	})
+
+	Convey("Successfully return empty edition metadata when the reader is empty", t, func() {
+		metadata, err := CreateEditionMetadata(bytes.NewBufferString(""))
+		So(err, ShouldBeNil)
+		So(metadata, ShouldResemble, &EditionMetadata{})
+	})
+
+	Convey("Return an error when the reader contains invalid json", t, func() {
+		metadata, err := CreateEditionMetadata(bytes.NewBufferString("{"))
+		So(err, ShouldEqual, errs.ErrUnableToParseJSON)
+		So(metadata, ShouldBeNil)
+	})
+}
+
+func TestEditionMetadataApply(t *testing.T) {
+	Convey("Given an edition with a title, marked as the latest edition", t, func() {
+		edition := &Edition{Title: "Time series", Description: "Monthly figures", Latest: true}
+
+		Convey("When metadata with only a description is applied, only the description is changed", func() {
+			description := "Quarterly figures"
+			(&EditionMetadata{Description: &description}).Apply(edition)
+			So(edition, ShouldResemble, &Edition{Title: "Time series", Description: "Quarterly figures", Latest: true})
+		})
+
+		Convey("When metadata with an empty description is applied, the description is cleared", func() {
+			description := ""
+			(&EditionMetadata{Description: &description}).Apply(edition)
+			So(edition, ShouldResemble, &Edition{Title: "Time series", Latest: true})
+		})
+
+		Convey("When metadata unmarking it as the latest edition is applied, it is no longer the latest edition", func() {
+			latest := false
+			(&EditionMetadata{Latest: &latest}).Apply(edition)
+			So(edition.Latest, ShouldBeFalse)
+			So(edition.Title, ShouldEqual, "Time series")
+		})
+	})
+}
+
+func TestEditionIsEmpty(t *testing.T) {
+	Convey("An edition created without versions is empty", t, func() {
+		So(CreateEmptyEdition("123", "time-series").IsEmpty(), ShouldBeTrue)
+	})
+
+	Convey("An edition created for its first version is not empty", t, func() {
+		edition, err := CreateEdition("123", "time-series")
+		So(err, ShouldBeNil)
+		So(edition.IsEmpty(), ShouldBeFalse)
+	})
+
+	Convey("A published edition is not empty", t, func() {
+		edition := CreateEmptyEdition("123", "time-series")
+		edition.Current = &Edition{State: PublishedState}
+		So(edition.IsEmpty(), ShouldBeFalse)
+	})
+}
+
+func TestCreateVersionWithdrawal(t *testing.T) {
+	Convey("Successfully return a valid withdrawal when the reader contains a correction alert", t, func() {
+		withdrawal, err := CreateVersionWithdrawal(bytes.NewBufferString(`{"alert":{"description":"A correction","type":"Correction"}}`))
+		So(err, ShouldBeNil)
+		So(withdrawal.Alert, ShouldResemble, &Alert{Description: "A correction", Type: "Correction"})
+		So(withdrawal.Validate(), ShouldBeNil)
+	})
+
+	Convey("Return an error when the reader contains invalid json", t, func() {
+		withdrawal, err := CreateVersionWithdrawal(bytes.NewBufferString("{"))
+		So(err, ShouldEqual, errs.ErrUnableToParseJSON)
+		So(withdrawal, ShouldBeNil)
+	})
+
+	Convey("A withdrawal without a correction alert describing why is invalid", t, func() {
+		So((&VersionWithdrawal{}).Validate(), ShouldEqual, errs.ErrWithdrawalAlertInvalid)
+		So((&VersionWithdrawal{Alert: &Alert{Description: "A correction", Type: "alert"}}).Validate(), ShouldEqual, errs.ErrWithdrawalAlertInvalid)
+		So((&VersionWithdrawal{Alert: &Alert{Type: CorrectionAlertType}}).Validate(), ShouldEqual, errs.ErrWithdrawalAlertInvalid)
+	})
+}
+
+func TestReplaceLatestVersion(t *testing.T) {
+	withdrawn := &LinkObject{ID: "2", HRef: "http://localhost:22000/datasets/123/editions/2017/versions/2"}
+	previous := &LinkObject{ID: "1", HRef: "http://localhost:22000/datasets/123/editions/2017/versions/1"}
+
+	Convey("Given an edition whose published latest version is the withdrawn version, with a newer unpublished version", t, func() {
+		edition := &EditionUpdate{
+			Current: &Edition{Links: &EditionUpdateLinks{LatestVersion: withdrawn}},
+			Next:    &Edition{Links: &EditionUpdateLinks{LatestVersion: &LinkObject{ID: "3"}}},
+		}
+
+		Convey("Then only the published latest version is re-pointed to the previous version", func() {
+			So(edition.ReplaceLatestVersion("2", previous), ShouldBeTrue)
+			So(edition.Current.Links.LatestVersion, ShouldEqual, previous)
+			So(edition.Next.Links.LatestVersion.ID, ShouldEqual, "3")
+		})
+
+		Convey("Then nothing is changed when a different version was withdrawn", func() {
+			So(edition.ReplaceLatestVersion("1", previous), ShouldBeFalse)
+			So(edition.Current.Links.LatestVersion, ShouldEqual, withdrawn)
+		})
+	})
+
+	Convey("Given a dataset whose latest version is the withdrawn version", t, func() {
+		dataset := &DatasetUpdate{
+			Current: &Dataset{Links: &DatasetLinks{LatestVersion: &LinkObject{ID: "2", HRef: withdrawn.HRef}}},
+			Next:    &Dataset{Links: &DatasetLinks{LatestVersion: &LinkObject{ID: "2", HRef: withdrawn.HRef}}},
+		}
+
+		Convey("Then the latest version is re-pointed to the previous version", func() {
+			So(dataset.ReplaceLatestVersion(withdrawn, previous), ShouldBeTrue)
+			So(dataset.Current.Links.LatestVersion, ShouldEqual, previous)
+			So(dataset.Next.Links.LatestVersion, ShouldEqual, previous)
+		})
+
+		Convey("Then nothing is changed when a version of another edition with the same number was withdrawn", func() {
+			other := &LinkObject{ID: "2", HRef: "http://localhost:22000/datasets/123/editions/2018/versions/2"}
+			So(dataset.ReplaceLatestVersion(other, previous), ShouldBeFalse)
+		})
+	})
+}
+
+func TestRelatedDatasetID(t *testing.T) {
+	t.Parallel()
+	host := "http://localhost:22000"
+
+	Convey("A typed related dataset returns its ID", t, func() {
+		So(RelatedDatasetID(host, GeneralDetails{ID: "cpih01", HRef: "https://www.ons.gov.uk"}), ShouldEqual, "cpih01")
+	})
+
+	Convey("A related dataset pointing at a dataset of the api returns the ID of the dataset", t, func() {
+		So(RelatedDatasetID(host, GeneralDetails{HRef: "http://localhost:22000/datasets/cpih01"}), ShouldEqual, "cpih01")
+		So(RelatedDatasetID(host, GeneralDetails{HRef: "http://localhost:22000/datasets/cpih01/"}), ShouldEqual, "cpih01")
+		So(RelatedDatasetID(host, GeneralDetails{HRef: "/datasets/cpih01"}), ShouldEqual, "cpih01")
+	})
+
+	Convey("A related dataset pointing elsewhere returns an empty ID", t, func() {
+		So(RelatedDatasetID(host, GeneralDetails{HRef: "https://www.ons.gov.uk/datasets/cpih01"}), ShouldBeEmpty)
+		So(RelatedDatasetID(host, GeneralDetails{HRef: "http://localhost:22000/datasets/cpih01/editions"}), ShouldBeEmpty)
+		So(RelatedDatasetID(host, GeneralDetails{HRef: "http://localhost:22000/datasets"}), ShouldBeEmpty)
+		So(RelatedDatasetID(host, GeneralDetails{HRef: ":invalid"}), ShouldBeEmpty)
+	})
+}
+
+func TestDatasetIsRelatedTo(t *testing.T) {
+	t.Parallel()
+	Convey("A dataset is only related to the datasets it has typed references to", t, func() {
+		dataset := &Dataset{RelatedDatasets: []GeneralDetails{
+			{ID: "cpih01", HRef: "http://localhost:22000/datasets/cpih01"},
+			{HRef: "http://localhost:22000/datasets/mm23"},
+		}}
+		So(dataset.IsRelatedTo("cpih01"), ShouldBeTrue)
+		So(dataset.IsRelatedTo("mm23"), ShouldBeFalse)
+	})
+}
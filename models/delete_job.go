@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// DeleteCounts holds the number of resources, per collection, reachable from a dataset being deleted
+type DeleteCounts struct {
+	Datasets         int   `bson:"datasets"          json:"datasets"`
+	Editions         int   `bson:"editions"          json:"editions"`
+	Instances        int   `bson:"instances"         json:"instances"`
+	DimensionOptions int   `bson:"dimension_options" json:"dimension_options"`
+	GraphNodes       int64 `bson:"graph_nodes"       json:"graph_nodes"`
+}
+
+// Total returns the number of resources counted across all collections
+func (c DeleteCounts) Total() int64 {
+	return int64(c.Datasets+c.Editions+c.Instances+c.DimensionOptions) + c.GraphNodes
+}
+
+// DeleteDryRun describes what would be removed by deleting a dataset, without removing anything, and the datasets that
+// would be left with a dangling related dataset reference
+type DeleteDryRun struct {
+	DatasetID          string        `json:"dataset_id"`
+	Counts             DeleteCounts  `json:"counts"`
+	DanglingReferences []*LinkObject `json:"dangling_references,omitempty"`
+}
+
+// DeleteJob tracks the removal of a dataset, and everything reachable from it, running in the background
+type DeleteJob struct {
+	ID          string          `bson:"_id"                   json:"id"`
+	DatasetID   string          `bson:"dataset_id"            json:"dataset_id"`
+	State       string          `bson:"state"                 json:"state"`
+	Counts      DeleteCounts    `bson:"counts"                json:"counts"`
+	Deleted     DeleteCounts    `bson:"deleted"               json:"deleted"`
+	Error       string          `bson:"error,omitempty"       json:"error,omitempty"`
+	LastUpdated time.Time       `bson:"last_updated"          json:"last_updated"`
+	Links       *DeleteJobLinks `bson:"links,omitempty"       json:"links,omitempty"`
+}
+
+// DeleteJobLinks holds the links of a delete job
+type DeleteJobLinks struct {
+	Self    *LinkObject `bson:"self,omitempty"    json:"self,omitempty"`
+	Dataset *LinkObject `bson:"dataset,omitempty" json:"dataset,omitempty"`
+}
+
+// NewDeleteJob returns a submitted delete job for the dataset, expecting to remove the counted resources
+func NewDeleteJob(datasetID string, counts DeleteCounts) *DeleteJob {
+	id := uuid.NewV4().String()
+	return &DeleteJob{
+		ID:        id,
+		DatasetID: datasetID,
+		State:     SubmittedState,
+		Counts:    counts,
+		Links: &DeleteJobLinks{
+			Self: &LinkObject{
+				HRef: "/delete-jobs/" + id,
+				ID:   id,
+			},
+			Dataset: &LinkObject{
+				HRef: "/datasets/" + datasetID,
+				ID:   datasetID,
+			},
+		},
+	}
+}
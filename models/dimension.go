@@ -14,6 +14,75 @@ type Dimension struct {
 	Name            string        `bson:"name,omitempty"              json:"name,omitempty"`
 	Variable        string        `bson:"variable,omitempty"          json:"variable,omitempty"`
 	NumberOfOptions *int          `bson:"number_of_options,omitempty" json:"number_of_options,omitempty"`
+	FirstOption     string        `bson:"first_option,omitempty"      json:"first_option,omitempty"`
+	IsHierarchy     bool          `bson:"is_hierarchy,omitempty"      json:"is_hierarchy,omitempty"`
+}
+
+// DimensionSummary is the number of options of a dimension, the first of its options in the order they are listed in
+// and the code list they are taken from, as counted from the dimension options of an instance
+type DimensionSummary struct {
+	Name            string     `bson:"_id"`
+	NumberOfOptions int        `bson:"number_of_options"`
+	FirstOption     string     `bson:"first_option"`
+	CodeList        LinkObject `bson:"code_list"`
+}
+
+// SummariseDimensions returns a copy of the dimensions of a version with the summaries of their options, so that the
+// dimensions can be listed without counting the options again. Dimensions that only exist as options are added, and
+// dimensions without any options are given a count of zero. Dimensions with a hierarchy built by the import tasks are
+// flagged as hierarchies.
+func SummariseDimensions(dimensions []Dimension, summaries []*DimensionSummary, tasks *InstanceImportTasks) []Dimension {
+	hierarchies := make(map[string]bool)
+	if tasks != nil {
+		for _, task := range tasks.BuildHierarchyTasks {
+			if task != nil {
+				hierarchies[task.DimensionName] = true
+			}
+		}
+	}
+
+	summarised := make([]Dimension, len(dimensions), len(dimensions)+len(summaries))
+	copy(summarised, dimensions)
+
+	positions := make(map[string]int)
+	for i := range summarised {
+		zero := 0
+		summarised[i].NumberOfOptions = &zero
+		summarised[i].IsHierarchy = hierarchies[summarised[i].Name]
+		positions[summarised[i].Name] = i
+	}
+
+	for _, summary := range summaries {
+		i, ok := positions[summary.Name]
+		if !ok {
+			summarised = append(summarised, Dimension{Name: summary.Name, IsHierarchy: hierarchies[summary.Name]})
+			i = len(summarised) - 1
+			positions[summary.Name] = i
+		}
+
+		dimension := &summarised[i]
+		numberOfOptions := summary.NumberOfOptions
+		dimension.NumberOfOptions = &numberOfOptions
+		dimension.FirstOption = summary.FirstOption
+		if dimension.Links.CodeList.ID == "" {
+			dimension.Links.CodeList = summary.CodeList
+		}
+	}
+
+	return summarised
+}
+
+// DimensionsSummarised returns true if the summaries of the options of every dimension are stored on the dimensions
+func DimensionsSummarised(dimensions []Dimension) bool {
+	if len(dimensions) == 0 {
+		return false
+	}
+	for _, dimension := range dimensions {
+		if dimension.NumberOfOptions == nil {
+			return false
+		}
+	}
+	return true
 }
 
 // DimensionLink contains all links needed for a dimension
@@ -35,6 +104,24 @@ type CachedDimensionOption struct {
 	Order      *int   `bson:"order,omitempty"          json:"order"`
 }
 
+// DimensionValidationReport lists the dimensions and options of an instance that do not match the code lists they are
+// taken from. The instance is valid if all of its code lists and codes exist, whether or not their labels match.
+type DimensionValidationReport struct {
+	InstanceID string                     `json:"instance_id"`
+	Valid      bool                       `json:"valid"`
+	Errors     []DimensionValidationIssue `json:"errors"`
+	Warnings   []DimensionValidationIssue `json:"warnings"`
+}
+
+// DimensionValidationIssue describes a dimension or option that does not match its code list
+type DimensionValidationIssue struct {
+	Dimension string `json:"dimension"`
+	Option    string `json:"option,omitempty"`
+	CodeList  string `json:"code_list,omitempty"`
+	Code      string `json:"code,omitempty"`
+	Message   string `json:"message"`
+}
+
 // DimensionOption contains unique information and metadata used when processing the data
 type DimensionOption struct {
 	InstanceID  string               `bson:"instance_id,omitempty"    json:"instance_id,omitempty"`
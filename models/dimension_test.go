@@ -0,0 +1,46 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSummariseDimensions(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the dimensions of a version and the summaries of their options", t, func() {
+		dimensions := []Dimension{
+			{Name: "age", Label: "Age", Links: DimensionLink{CodeList: LinkObject{ID: "age-list"}}},
+			{Name: "sex", Label: "Sex"},
+		}
+		summaries := []*DimensionSummary{
+			{Name: "age", NumberOfOptions: 2, FirstOption: "24", CodeList: LinkObject{ID: "other-list"}},
+			{Name: "geography", NumberOfOptions: 1, FirstOption: "K02000001", CodeList: LinkObject{ID: "geography-list"}},
+		}
+		tasks := &InstanceImportTasks{BuildHierarchyTasks: []*BuildHierarchyTask{{GenericTaskDetails: GenericTaskDetails{DimensionName: "geography"}}}}
+
+		Convey("When the dimensions are summarised", func() {
+			summarised := SummariseDimensions(dimensions, summaries, tasks)
+
+			Convey("Then every dimension holds the summary of its options", func() {
+				ageOptions, sexOptions, geographyOptions := 2, 0, 1
+				So(summarised, ShouldResemble, []Dimension{
+					{Name: "age", Label: "Age", Links: DimensionLink{CodeList: LinkObject{ID: "age-list"}}, NumberOfOptions: &ageOptions, FirstOption: "24"},
+					{Name: "sex", Label: "Sex", NumberOfOptions: &sexOptions},
+					{Name: "geography", Links: DimensionLink{CodeList: LinkObject{ID: "geography-list"}}, NumberOfOptions: &geographyOptions, FirstOption: "K02000001", IsHierarchy: true},
+				})
+				So(DimensionsSummarised(summarised), ShouldBeTrue)
+			})
+
+			Convey("Then the original dimensions are left unchanged", func() {
+				So(dimensions[0].NumberOfOptions, ShouldBeNil)
+				So(DimensionsSummarised(dimensions), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("A version without any dimensions is not summarised", t, func() {
+		So(DimensionsSummarised(nil), ShouldBeFalse)
+	})
+}
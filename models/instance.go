@@ -13,6 +13,8 @@ import (
 type Instance struct {
 	Alerts            *[]Alert             `bson:"alerts,omitempty"                      json:"alerts,omitempty"`
 	CollectionID      string               `bson:"collection_id,omitempty"               json:"collection_id,omitempty"`
+	CollectionName    string               `bson:"collection_name,omitempty"             json:"collection_name,omitempty"`
+	Deletion          *Deletion            `bson:"deletion,omitempty"                    json:"-"`
 	Dimensions        []Dimension          `bson:"dimensions,omitempty"                  json:"dimensions,omitempty"`
 	Downloads         *DownloadList        `bson:"downloads,omitempty"                   json:"downloads,omitempty"`
 	Edition           string               `bson:"edition,omitempty"                     json:"edition,omitempty"`
@@ -40,18 +42,22 @@ type Instance struct {
 // An optional byte array can be provided to append to the hash.
 // This can be used, for example, to calculate a hash of this filter and an update applied to it.
 func (i *Instance) Hash(extraBytes []byte) (string, error) {
-	h := sha1.New()
-
-	// copy by value to ignore ETag without affecting f
+	// copy by value to ignore ETag without affecting i
 	i2 := *i
 	i2.ETag = ""
+	return hash(i2, extraBytes)
+}
+
+// hash generates a SHA-1 hash of the bson representation of the provided document, followed by extraBytes
+func hash(doc interface{}, extraBytes []byte) (string, error) {
+	h := sha1.New()
 
-	instanceBytes, err := bson.Marshal(i2)
+	docBytes, err := bson.Marshal(doc)
 	if err != nil {
 		return "", err
 	}
 
-	if _, err := h.Write(append(instanceBytes, extraBytes...)); err != nil {
+	if _, err := h.Write(append(docBytes, extraBytes...)); err != nil {
 		return "", err
 	}
 
@@ -87,6 +93,13 @@ type BuildSearchIndexTask struct {
 	GenericTaskDetails `bson:",inline"`
 }
 
+// ImportRetry describes which import tasks of an instance should be retried. When no
+// dimensions are provided all import tasks of the instance are retried.
+type ImportRetry struct {
+	Dimensions                []string `json:"dimensions,omitempty"`
+	ResetInsertedObservations bool     `json:"reset_inserted_observations,omitempty"`
+}
+
 // InstanceLinks holds all links for an instance
 type InstanceLinks struct {
 	Dataset    *LinkObject `bson:"dataset,omitempty"    json:"dataset,omitempty"`
@@ -114,6 +127,11 @@ func (e *Event) Validate() error {
 	return nil
 }
 
+// IsValidTaskState checks whether an import task can be updated to the provided state
+func IsValidTaskState(state string) bool {
+	return state == CompletedState || state == FailedState
+}
+
 // ValidateImportTask checks the task contains mandatory fields
 func ValidateImportTask(task GenericTaskDetails) error {
 	var missingFields []string
@@ -127,10 +145,10 @@ func ValidateImportTask(task GenericTaskDetails) error {
 	}
 
 	if len(missingFields) > 0 {
-		return fmt.Errorf("bad request - missing mandatory fields: %v", missingFields)
+		return &FieldsError{Reason: "bad request - missing mandatory fields", Fields: missingFields, Missing: true}
 	}
 
-	if task.State != CompletedState {
+	if !IsValidTaskState(task.State) {
 		return fmt.Errorf("bad request - invalid task state value: %v", task.State)
 	}
 
@@ -210,6 +210,17 @@ func TestValidateImportTask(t *testing.T) {
 		})
 	})
 
+	Convey("Given an import task contains all mandatory fields and state is set to 'failed'", t, func() {
+		Convey("Then successfully return without any errors", func() {
+			task := GenericTaskDetails{
+				DimensionName: "geography",
+				State:         FailedState,
+			}
+			err := ValidateImportTask(task)
+			So(err, ShouldBeNil)
+		})
+	})
+
 	Convey("Given an import task is missing mandatory field 'dimension_name'", t, func() {
 		Convey("Then import task fails validation and returns an error", func() {
 			task := GenericTaskDetails{
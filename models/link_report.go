@@ -0,0 +1,92 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// LinkStatus records the result of requesting an external link in the metadata of a published dataset
+type LinkStatus struct {
+	Field        string `bson:"field"                   json:"field"`
+	HRef         string `bson:"href"                    json:"href"`
+	StatusCode   int    `bson:"status_code,omitempty"   json:"status_code,omitempty"`
+	RedirectedTo string `bson:"redirected_to,omitempty" json:"redirected_to,omitempty"`
+	Error        string `bson:"error,omitempty"         json:"error,omitempty"`
+	Broken       bool   `bson:"broken"                  json:"broken"`
+}
+
+// LinkReport records the status of every external link in the metadata of a published dataset, as of the last check
+type LinkReport struct {
+	DatasetID   string        `bson:"_id"          json:"dataset_id"`
+	Links       []*LinkStatus `bson:"links"        json:"links"`
+	BrokenLinks int           `bson:"broken_links" json:"broken_links"`
+	LastChecked time.Time     `bson:"last_checked" json:"last_checked"`
+}
+
+// LinkReports represents a list of link reports
+type LinkReports struct {
+	Items []*LinkReport `json:"items"`
+}
+
+// NewLinkReport returns the report of the checked links of the dataset, counting those that are broken
+func NewLinkReport(datasetID string, links []*LinkStatus, checkedAt time.Time) *LinkReport {
+	report := &LinkReport{
+		DatasetID:   datasetID,
+		Links:       links,
+		LastChecked: checkedAt,
+	}
+
+	for _, link := range links {
+		if link.Broken {
+			report.BrokenLinks++
+		}
+	}
+	return report
+}
+
+// WithBrokenLinksOnly returns a copy of the report that only lists its broken links
+func (r *LinkReport) WithBrokenLinksOnly() *LinkReport {
+	broken := *r
+	broken.Links = []*LinkStatus{}
+	for _, link := range r.Links {
+		if link.Broken {
+			broken.Links = append(broken.Links, link)
+		}
+	}
+	return &broken
+}
+
+// ExternalLinks returns the absolute http and https links in the QMI, methodologies, publications and related
+// datasets of the dataset, named by the field they are in. Related datasets of this API are not included, as they
+// are validated when the dataset is stored.
+func ExternalLinks(dataset *Dataset) []*LinkStatus {
+	var links []*LinkStatus
+	add := func(field, href string) {
+		u, err := url.Parse(href)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return
+		}
+		links = append(links, &LinkStatus{Field: field, HRef: href})
+	}
+
+	if dataset.QMI != nil {
+		add("qmi", dataset.QMI.HRef)
+	}
+
+	for i, methodology := range dataset.Methodologies {
+		add(fmt.Sprintf("methodologies[%d]", i), methodology.HRef)
+	}
+
+	for i, publication := range dataset.Publications {
+		add(fmt.Sprintf("publications[%d]", i), publication.HRef)
+	}
+
+	for i, related := range dataset.RelatedDatasets {
+		if related.ID == "" {
+			add(fmt.Sprintf("related_datasets[%d]", i), related.HRef)
+		}
+	}
+
+	return links
+}
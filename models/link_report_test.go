@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewLinkReport(t *testing.T) {
+	t.Parallel()
+	Convey("The broken links of a report are counted and can be listed on their own", t, func() {
+		report := NewLinkReport("cpih01", []*LinkStatus{
+			{Field: "qmi", StatusCode: 200},
+			{Field: "methodologies[0]", StatusCode: 404, Broken: true},
+			{Field: "publications[0]", Error: "timeout", Broken: true},
+		}, time.Now())
+
+		So(report.BrokenLinks, ShouldEqual, 2)
+
+		broken := report.WithBrokenLinksOnly()
+		So(broken.Links, ShouldHaveLength, 2)
+		So(broken.Links[0].Field, ShouldEqual, "methodologies[0]")
+		So(report.Links, ShouldHaveLength, 3)
+	})
+}
+
+func TestExternalLinks(t *testing.T) {
+	t.Parallel()
+	Convey("Only the absolute links outside this api are checked", t, func() {
+		dataset := &Dataset{
+			QMI:           &GeneralDetails{HRef: "https://www.ons.gov.uk/qmi"},
+			Methodologies: []GeneralDetails{{HRef: "/relative"}, {HRef: "http://www.ons.gov.uk/methodology"}},
+			Publications:  []GeneralDetails{{HRef: "ftp://files.ons.gov.uk/publication"}, {HRef: ""}},
+			RelatedDatasets: []GeneralDetails{
+				{HRef: "http://localhost:22000/datasets/mm23", ID: "mm23"},
+				{HRef: "https://www.ons.gov.uk/related"},
+			},
+		}
+
+		So(ExternalLinks(dataset), ShouldResemble, []*LinkStatus{
+			{Field: "qmi", HRef: "https://www.ons.gov.uk/qmi"},
+			{Field: "methodologies[1]", HRef: "http://www.ons.gov.uk/methodology"},
+			{Field: "related_datasets[1]", HRef: "https://www.ons.gov.uk/related"},
+		})
+	})
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+)
+
+var (
+	linkObjectType     = reflect.TypeOf(LinkObject{})
+	generalDetailsType = reflect.TypeOf(GeneralDetails{})
+)
+
+// RenderLinks prefixes the hrefs of the links to the API held by the model, which are stored as paths, with the base
+// url. Only the links in the Links of the model and of the models it holds are rendered, along with its related
+// datasets held by the API, so hrefs provided elsewhere in the metadata are left as they were given. Hrefs that are
+// already absolute are left unchanged, so rendering a model more than once has no further effect.
+func RenderLinks(model interface{}, baseURL string) {
+	renderLinks(reflect.ValueOf(model), strings.TrimSuffix(baseURL, "/"), false)
+}
+
+// renderLinks walks the value, rendering the links it holds. inLinks is set while walking the Links of a model.
+func renderLinks(v reflect.Value, baseURL string, inLinks bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			renderLinks(v.Elem(), baseURL, inLinks)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			renderLinks(v.Index(i), baseURL, inLinks)
+		}
+	case reflect.Struct:
+		switch {
+		case v.Type() == linkObjectType:
+			if inLinks {
+				renderHRef(v.FieldByName("HRef"), baseURL)
+			}
+		case v.Type() == generalDetailsType:
+			if v.FieldByName("ID").String() != "" {
+				renderHRef(v.FieldByName("HRef"), baseURL)
+			}
+		default:
+			for i := 0; i < v.NumField(); i++ {
+				if field := v.Type().Field(i); field.PkgPath == "" {
+					renderLinks(v.Field(i), baseURL, inLinks || field.Name == "Links")
+				}
+			}
+		}
+	}
+}
+
+func renderHRef(href reflect.Value, baseURL string) {
+	path := href.String()
+	if !href.CanSet() || !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") {
+		return
+	}
+	href.SetString(baseURL + path)
+}
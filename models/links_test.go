@@ -0,0 +1,70 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRenderLinks(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given datasets holding links to the api stored as paths and hrefs provided by users", t, func() {
+		datasets := []*DatasetUpdate{
+			{
+				ID: "123",
+				Next: &Dataset{
+					Links: &DatasetLinks{
+						Self:         &LinkObject{HRef: "/datasets/123"},
+						Editions:     &LinkObject{HRef: "http://localhost:22000/datasets/123/editions"},
+						AccessRights: &LinkObject{HRef: "//cdn.example.com/access-rights"},
+					},
+					QMI:           &GeneralDetails{HRef: "/qmi"},
+					Methodologies: []GeneralDetails{{HRef: "/methodology"}},
+					RelatedDatasets: []GeneralDetails{
+						{HRef: "/datasets/456", ID: "456"},
+						{HRef: "/economy"},
+					},
+				},
+			},
+		}
+
+		Convey("When the links are rendered", func() {
+			RenderLinks(datasets, "https://api.example.com/")
+			dataset := datasets[0].Next
+
+			Convey("Then the links of the dataset held as paths are prefixed with the base url", func() {
+				So(dataset.Links.Self.HRef, ShouldEqual, "https://api.example.com/datasets/123")
+				So(dataset.RelatedDatasets[0].HRef, ShouldEqual, "https://api.example.com/datasets/456")
+			})
+
+			Convey("Then absolute links are left unchanged", func() {
+				So(dataset.Links.Editions.HRef, ShouldEqual, "http://localhost:22000/datasets/123/editions")
+				So(dataset.Links.AccessRights.HRef, ShouldEqual, "//cdn.example.com/access-rights")
+			})
+
+			Convey("Then the hrefs provided by users are left unchanged", func() {
+				So(dataset.QMI.HRef, ShouldEqual, "/qmi")
+				So(dataset.Methodologies[0].HRef, ShouldEqual, "/methodology")
+				So(dataset.RelatedDatasets[1].HRef, ShouldEqual, "/economy")
+			})
+
+			Convey("Then rendering the links again changes nothing", func() {
+				RenderLinks(datasets, "https://other.example.com")
+				So(dataset.Links.Self.HRef, ShouldEqual, "https://api.example.com/datasets/123")
+			})
+		})
+	})
+
+	Convey("Given dimension options holding links by value", t, func() {
+		options := []PublicDimensionOption{{Links: DimensionOptionLinks{Version: LinkObject{HRef: "/datasets/123/editions/2021/versions/1"}}}}
+
+		Convey("When the links are rendered", func() {
+			RenderLinks(options, "http://localhost:22000")
+
+			Convey("Then the links are prefixed with the base url", func() {
+				So(options[0].Links.Version.HRef, ShouldEqual, "http://localhost:22000/datasets/123/editions/2021/versions/1")
+			})
+		})
+	})
+}
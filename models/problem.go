@@ -0,0 +1,50 @@
+package models
+
+import "fmt"
+
+// Problem represents the application/problem+json body of an unsuccessful request. Code identifies the error and does
+// not change between releases, unlike Detail which is the message of the error.
+type Problem struct {
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Code      string       `json:"code"`
+	Detail    string       `json:"detail"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes why a field of a request does not match the specification of the API. Field is the path of
+// the field in the body, with the names of its parents separated by dots, or the name of a parameter.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FieldsError is returned when fields of a resource are missing or invalid, so that each field can be returned to the
+// caller rather than only the message of the error
+type FieldsError struct {
+	Reason  string
+	Fields  []string
+	Missing bool
+}
+
+func (e *FieldsError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Reason, e.Fields)
+}
+
+// Code identifies whether the fields in the error are missing or invalid
+func (e *FieldsError) Code() string {
+	if e.Missing {
+		return "missing_fields"
+	}
+	return "invalid_fields"
+}
+
+// FieldErrors returns the reason each of the fields is in the error
+func (e *FieldsError) FieldErrors() []FieldError {
+	fieldErrors := make([]FieldError, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		fieldErrors = append(fieldErrors, FieldError{Field: field, Message: e.Reason})
+	}
+	return fieldErrors
+}
@@ -16,12 +16,15 @@ const (
 	PublishedState        = "published"
 	DetachedState         = "detached"
 	FailedState           = "failed"
+	DeletedState          = "deleted"
+	WithdrawnState        = "withdrawn"
 )
 
 var validVersionStates = map[string]int{
 	EditionConfirmedState: 1,
 	AssociatedState:       1,
 	PublishedState:        1,
+	WithdrawnState:        1,
 }
 
 var validStates = map[string]int{
@@ -34,6 +37,11 @@ var validStates = map[string]int{
 	FailedState:           1,
 }
 
+// IsPublished returns true if the state is that of a published version, including one that has since been withdrawn
+func IsPublished(state string) bool {
+	return state == PublishedState || state == WithdrawnState
+}
+
 // ValidateStateFilter checks the list of filter states from a whitelist
 func ValidateStateFilter(filterList []string) error {
 	var invalidFilterStateValues []string
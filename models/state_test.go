@@ -27,6 +27,12 @@ func TestCheckState(t *testing.T) {
 			So(err, ShouldBeNil)
 		})
 
+		Convey("when the version has state of withdrawn", func() {
+
+			err := CheckState("version", WithdrawnState)
+			So(err, ShouldBeNil)
+		})
+
 		Convey("when a resource has state of created", func() {
 
 			err := CheckState("resource", CreatedState)
@@ -96,3 +102,15 @@ func TestCheckState(t *testing.T) {
 		})
 	})
 }
+
+func TestIsPublished(t *testing.T) {
+	Convey("Published and withdrawn versions have been published", t, func() {
+		So(IsPublished(PublishedState), ShouldBeTrue)
+		So(IsPublished(WithdrawnState), ShouldBeTrue)
+	})
+
+	Convey("Unpublished versions have not been published", t, func() {
+		So(IsPublished(AssociatedState), ShouldBeFalse)
+		So(IsPublished(EditionConfirmedState), ShouldBeFalse)
+	})
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+)
+
+// Topic represents a topic of the taxonomy datasets are browsed by. A topic without a parent is at the top of the
+// taxonomy.
+type Topic struct {
+	ID          string      `bson:"_id"                    json:"id"`
+	Label       string      `bson:"label"                  json:"label"`
+	ParentID    string      `bson:"parent_id,omitempty"    json:"parent_id,omitempty"`
+	Links       *TopicLinks `bson:"links,omitempty"        json:"links,omitempty"`
+	LastUpdated time.Time   `bson:"last_updated,omitempty" json:"-"`
+}
+
+// TopicLinks represents a list of specific links related to the topic resource
+type TopicLinks struct {
+	Datasets *LinkObject `bson:"datasets,omitempty" json:"datasets,omitempty"`
+	Parent   *LinkObject `bson:"parent,omitempty"   json:"parent,omitempty"`
+	Self     *LinkObject `bson:"self,omitempty"     json:"self,omitempty"`
+}
+
+// TopicResults represents a list of topics
+type TopicResults struct {
+	Items []*Topic `json:"items"`
+}
+
+// CreateTopic manages the creation of a topic from a reader
+func CreateTopic(reader io.Reader) (*Topic, error) {
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, errs.ErrUnableToReadMessage
+	}
+
+	var topic Topic
+	if err = json.Unmarshal(b, &topic); err != nil {
+		return nil, errs.ErrUnableToParseJSON
+	}
+
+	return &topic, nil
+}
+
+// ValidateTopic checks the topic has a label
+func ValidateTopic(topic *Topic) error {
+	if topic.Label == "" {
+		return &FieldsError{Reason: "missing mandatory fields", Fields: []string{"label"}, Missing: true}
+	}
+	return nil
+}
+
+// SetLinks sets the links of the topic to itself, its parent and its datasets
+func (t *Topic) SetLinks() {
+	t.Links = &TopicLinks{
+		Datasets: &LinkObject{HRef: fmt.Sprintf("/topics/%s/datasets", t.ID)},
+		Self:     &LinkObject{HRef: fmt.Sprintf("/topics/%s", t.ID), ID: t.ID},
+	}
+
+	if t.ParentID != "" {
+		t.Links.Parent = &LinkObject{HRef: fmt.Sprintf("/topics/%s", t.ParentID), ID: t.ParentID}
+	}
+}
+
+// TopicAndDescendants returns the ID of the topic followed by the IDs of every topic below it in the taxonomy
+func TopicAndDescendants(topics []*Topic, id string) []string {
+	children := make(map[string][]string)
+	for _, topic := range topics {
+		if topic.ParentID != "" {
+			children[topic.ParentID] = append(children[topic.ParentID], topic.ID)
+		}
+	}
+
+	ids := []string{id}
+	seen := map[string]bool{id: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			// a taxonomy containing a cycle must not be walked forever
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
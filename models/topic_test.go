@@ -0,0 +1,83 @@
+package models
+
+import (
+	"bytes"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateTopic(t *testing.T) {
+	t.Parallel()
+	Convey("Successfully return a topic when the request body is valid", t, func() {
+		topic, err := CreateTopic(bytes.NewBufferString(`{"label":"Inflation","parent_id":"economy"}`))
+		So(err, ShouldBeNil)
+		So(topic.Label, ShouldEqual, "Inflation")
+		So(topic.ParentID, ShouldEqual, "economy")
+	})
+
+	Convey("Return an error when the request body is not valid json", t, func() {
+		topic, err := CreateTopic(bytes.NewBufferString("{"))
+		So(err, ShouldEqual, errs.ErrUnableToParseJSON)
+		So(topic, ShouldBeNil)
+	})
+}
+
+func TestValidateTopic(t *testing.T) {
+	t.Parallel()
+	Convey("A topic with a label is valid", t, func() {
+		So(ValidateTopic(&Topic{ID: "economy", Label: "Economy"}), ShouldBeNil)
+	})
+
+	Convey("A topic without a label is not valid", t, func() {
+		err := ValidateTopic(&Topic{ID: "economy"})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "missing mandatory fields: [label]")
+	})
+}
+
+func TestTopicSetLinks(t *testing.T) {
+	t.Parallel()
+	Convey("A topic at the top of the taxonomy is not linked to a parent", t, func() {
+		topic := &Topic{ID: "economy"}
+		topic.SetLinks()
+		So(topic.Links.Self.HRef, ShouldEqual, "/topics/economy")
+		So(topic.Links.Datasets.HRef, ShouldEqual, "/topics/economy/datasets")
+		So(topic.Links.Parent, ShouldBeNil)
+	})
+
+	Convey("A topic below another topic is linked to its parent", t, func() {
+		topic := &Topic{ID: "inflation", ParentID: "economy"}
+		topic.SetLinks()
+		So(topic.Links.Parent.HRef, ShouldEqual, "/topics/economy")
+		So(topic.Links.Parent.ID, ShouldEqual, "economy")
+	})
+}
+
+func TestTopicAndDescendants(t *testing.T) {
+	t.Parallel()
+	topics := []*Topic{
+		{ID: "economy"},
+		{ID: "inflation", ParentID: "economy"},
+		{ID: "employment", ParentID: "economy"},
+		{ID: "prices", ParentID: "inflation"},
+		{ID: "population"},
+	}
+
+	Convey("Every topic below the topic is returned", t, func() {
+		So(TopicAndDescendants(topics, "economy"), ShouldResemble, []string{"economy", "inflation", "employment", "prices"})
+	})
+
+	Convey("A topic without sub topics is returned on its own", t, func() {
+		So(TopicAndDescendants(topics, "population"), ShouldResemble, []string{"population"})
+	})
+
+	Convey("A taxonomy containing a cycle is walked once", t, func() {
+		cyclic := []*Topic{
+			{ID: "a", ParentID: "b"},
+			{ID: "b", ParentID: "a"},
+		}
+		So(TopicAndDescendants(cyclic, "a"), ShouldResemble, []string{"a", "b"})
+	})
+}
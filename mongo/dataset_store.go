@@ -15,14 +15,14 @@ import (
 
 // GetDatasets retrieves all dataset documents
 func (m *Mongo) GetDatasets(ctx context.Context, offset, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
-	s := m.Session.Copy()
+	s := m.sessionFor("GetDatasets")
 	defer s.Close()
 
 	var q *mgo.Query
 	if authorised {
-		q = s.DB(m.Database).C("datasets").Find(nil).Sort()
+		q = s.DB(m.Database).C("datasets").Find(bson.M{"next.state": notDeleted}).Sort()
 	} else {
-		q = s.DB(m.Database).C("datasets").Find(bson.M{"current": bson.M{"$exists": true}}).Sort()
+		q = s.DB(m.Database).C("datasets").Find(bson.M{"current": bson.M{"$exists": true}, "next.state": notDeleted}).Sort()
 	}
 
 	// get total count and paginated values according to provided offset and limit
@@ -39,11 +39,11 @@ func (m *Mongo) GetDatasets(ctx context.Context, offset, limit int, authorised b
 }
 
 // GetDataset retrieves a dataset document
-func (m *Mongo) GetDataset(id string) (*models.DatasetUpdate, error) {
-	s := m.Session.Copy()
+func (m *Mongo) GetDataset(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+	s := m.sessionFor("GetDataset")
 	defer s.Close()
 	var dataset models.DatasetUpdate
-	err := s.DB(m.Database).C("datasets").Find(bson.M{"_id": id}).One(&dataset)
+	err := s.DB(m.Database).C("datasets").Find(bson.M{"_id": id, "next.state": notDeleted}).One(&dataset)
 	if err != nil {
 		if err == mgo.ErrNotFound {
 			return nil, errs.ErrDatasetNotFound
@@ -54,9 +54,40 @@ func (m *Mongo) GetDataset(id string) (*models.DatasetUpdate, error) {
 	return &dataset, nil
 }
 
+// GetDatasetsByIDs retrieves the dataset documents with the ids. Datasets that do not exist are left out, so fewer
+// documents than ids can be returned.
+func (m *Mongo) GetDatasetsByIDs(ctx context.Context, ids []string) ([]*models.DatasetUpdate, error) {
+	s := m.sessionFor("GetDatasetsByIDs")
+	defer s.Close()
+
+	datasets := []*models.DatasetUpdate{}
+	if err := s.DB(m.Database).C("datasets").Find(bson.M{"_id": bson.M{"$in": ids}, "next.state": notDeleted}).All(&datasets); err != nil {
+		return nil, err
+	}
+	return datasets, nil
+}
+
+// GetDatasetsRelatedTo retrieves the datasets with a published or unpublished typed related dataset reference to the
+// dataset
+func (m *Mongo) GetDatasetsRelatedTo(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+	s := m.sessionFor("GetDatasetsRelatedTo")
+	defer s.Close()
+
+	selector := bson.M{
+		"$or":        []bson.M{{"current.related_datasets.id": datasetID}, {"next.related_datasets.id": datasetID}},
+		"next.state": notDeleted,
+	}
+
+	datasets := []*models.DatasetUpdate{}
+	if err := s.DB(m.Database).C("datasets").Find(selector).Sort("_id").All(&datasets); err != nil {
+		return nil, err
+	}
+	return datasets, nil
+}
+
 // GetEditions retrieves all edition documents for a dataset
 func (m *Mongo) GetEditions(ctx context.Context, id, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
-	s := m.Session.Copy()
+	s := m.sessionFor("GetEditions")
 	defer s.Close()
 
 	selector := buildEditionsQuery(id, state, authorised)
@@ -78,9 +109,10 @@ func (m *Mongo) GetEditions(ctx context.Context, id, state string, offset, limit
 
 func buildEditionsQuery(id, state string, authorised bool) bson.M {
 
-	// all queries must get the dataset by id
+	// all queries must get the dataset by id, excluding deleted editions
 	selector := bson.M{
 		"next.links.dataset.id": id,
+		"next.state":            notDeleted,
 	}
 
 	// non-authorised queries require that the current edition must exist
@@ -97,8 +129,8 @@ func buildEditionsQuery(id, state string, authorised bool) bson.M {
 }
 
 // GetEdition retrieves an edition document for a dataset
-func (m *Mongo) GetEdition(id, editionID, state string) (*models.EditionUpdate, error) {
-	s := m.Session.Copy()
+func (m *Mongo) GetEdition(ctx context.Context, id, editionID, state string) (*models.EditionUpdate, error) {
+	s := m.sessionFor("GetEdition")
 	defer s.Close()
 
 	selector := buildEditionQuery(id, editionID, state)
@@ -121,11 +153,13 @@ func buildEditionQuery(id, editionID, state string) bson.M {
 			"current.links.dataset.id": id,
 			"current.edition":          editionID,
 			"current.state":            state,
+			"next.state":               notDeleted,
 		}
 	} else {
 		selector = bson.M{
 			"next.links.dataset.id": id,
 			"next.edition":          editionID,
+			"next.state":            notDeleted,
 		}
 	}
 
@@ -133,8 +167,8 @@ func buildEditionQuery(id, editionID, state string) bson.M {
 }
 
 // GetNextVersion retrieves the latest version for an edition of a dataset
-func (m *Mongo) GetNextVersion(datasetID, edition string) (int, error) {
-	s := m.Session.Copy()
+func (m *Mongo) GetNextVersion(ctx context.Context, datasetID, edition string) (int, error) {
+	s := m.sessionFor("GetNextVersion")
 	defer s.Close()
 	var version models.Version
 	var nextVersion int
@@ -160,7 +194,7 @@ func (m *Mongo) GetNextVersion(datasetID, edition string) (int, error) {
 
 // GetVersions retrieves all version documents for a dataset edition
 func (m *Mongo) GetVersions(ctx context.Context, datasetID, editionID, state string, offset, limit int) ([]models.Version, int, error) {
-	s := m.Session.Copy()
+	s := m.sessionFor("GetVersions")
 	defer s.Close()
 
 	var q *mgo.Query
@@ -188,6 +222,35 @@ func (m *Mongo) GetVersions(ctx context.Context, datasetID, editionID, state str
 	return results, totalCount, nil
 }
 
+// GetVersionsInCollections retrieves all unpublished versions that are associated with a collection
+func (m *Mongo) GetVersionsInCollections(ctx context.Context) ([]models.Version, error) {
+	s := m.sessionFor("GetVersionsInCollections")
+	defer s.Close()
+
+	selector := bson.M{
+		"collection_id": bson.M{"$exists": true, "$ne": ""},
+		"state":         bson.M{"$in": []string{models.EditionConfirmedState, models.AssociatedState}},
+	}
+
+	results := []models.Version{}
+	if err := s.DB(m.Database).C("instances").Find(selector).Sort("-last_updated").All(&results); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(results); i++ {
+		if results[i].Links != nil {
+			if results[i].Links.Version != nil && results[i].Links.Self != nil {
+				results[i].Links.Self.HRef = results[i].Links.Version.HRef
+			}
+			if results[i].Links.Dataset != nil {
+				results[i].DatasetID = results[i].Links.Dataset.ID
+			}
+		}
+	}
+
+	return results, nil
+}
+
 func buildVersionsQuery(datasetID, editionID, state string) bson.M {
 	var selector bson.M
 	if state == "" {
@@ -198,8 +261,15 @@ func buildVersionsQuery(datasetID, editionID, state string) bson.M {
 				bson.M{"state": models.EditionConfirmedState},
 				bson.M{"state": models.AssociatedState},
 				bson.M{"state": models.PublishedState},
+				bson.M{"state": models.WithdrawnState},
 			},
 		}
+	} else if state == models.PublishedState {
+		selector = bson.M{
+			"links.dataset.id": datasetID,
+			"edition":          editionID,
+			"state":            publishedVersion,
+		}
 	} else {
 		selector = bson.M{
 			"links.dataset.id": datasetID,
@@ -212,8 +282,8 @@ func buildVersionsQuery(datasetID, editionID, state string) bson.M {
 }
 
 // GetVersion retrieves a version document for a dataset edition
-func (m *Mongo) GetVersion(id, editionID string, versionID int, state string) (*models.Version, error) {
-	s := m.Session.Copy()
+func (m *Mongo) GetVersion(ctx context.Context, id, editionID string, versionID int, state string) (*models.Version, error) {
+	s := m.sessionFor("GetVersion")
 	defer s.Close()
 
 	selector := buildVersionQuery(id, editionID, state, versionID)
@@ -236,34 +306,73 @@ func buildVersionQuery(id, editionID, state string, versionID int) bson.M {
 			"links.dataset.id": id,
 			"version":          versionID,
 			"edition":          editionID,
+			"state":            notDeleted,
 		}
 	} else {
 		selector = bson.M{
 			"links.dataset.id": id,
 			"edition":          editionID,
 			"version":          versionID,
-			"state":            state,
+			"state":            publishedVersion,
 		}
 	}
 
 	return selector
 }
 
-// UpdateDataset updates an existing dataset document
-func (m *Mongo) UpdateDataset(ctx context.Context, id string, dataset *models.Dataset, currentState string) (err error) {
-	s := m.Session.Copy()
+// GetPreviousPublishedVersion retrieves the most recent version of a dataset edition published before the provided
+// version, which has not since been withdrawn
+func (m *Mongo) GetPreviousPublishedVersion(ctx context.Context, datasetID, editionID string, version int) (*models.Version, error) {
+	s := m.sessionFor("GetPreviousPublishedVersion")
+	defer s.Close()
+
+	selector := bson.M{
+		"links.dataset.id": datasetID,
+		"edition":          editionID,
+		"version":          bson.M{"$lt": version},
+		"state":            models.PublishedState,
+	}
+
+	var previous models.Version
+	if err := s.DB(m.Database).C("instances").Find(selector).Sort("-version").One(&previous); err != nil {
+		if err == mgo.ErrNotFound {
+			return nil, errs.ErrVersionNotFound
+		}
+		return nil, err
+	}
+	return &previous, nil
+}
+
+// UpdateDataset updates an existing dataset document, if it matches the provided eTag, and returns the new eTag
+func (m *Mongo) UpdateDataset(ctx context.Context, currentDataset *models.DatasetUpdate, dataset *models.Dataset, eTagSelector string) (newETag string, err error) {
+	s := m.sessionFor("UpdateDataset")
 	defer s.Close()
 
-	updates := createDatasetUpdateQuery(ctx, id, dataset, currentState)
+	var currentState string
+	if currentDataset.Next != nil {
+		currentState = currentDataset.Next.State
+	}
+
+	// calculate the new eTag hash for the dataset that would result from applying the update
+	newETag, err = newETagForDatasetUpdate(currentDataset, dataset)
+	if err != nil {
+		return "", err
+	}
+
+	updates := createDatasetUpdateQuery(ctx, currentDataset.ID, dataset, currentState)
+	updates["e_tag"] = newETag
 	update := bson.M{"$set": updates, "$setOnInsert": bson.M{"next.last_updated": time.Now()}}
-	if err = s.DB(m.Database).C("datasets").UpdateId(id, update); err != nil {
+	if err = s.DB(m.Database).C("datasets").Update(datasetSelector(currentDataset.ID, eTagSelector), update); err != nil {
 		if err == mgo.ErrNotFound {
-			return errs.ErrDatasetNotFound
+			if eTagSelector == AnyETag {
+				return "", errs.ErrDatasetNotFound
+			}
+			return "", errs.ErrDatasetConflict
 		}
-		return err
+		return "", err
 	}
 
-	return nil
+	return newETag, nil
 }
 
 func createDatasetUpdateQuery(ctx context.Context, id string, dataset *models.Dataset, currentState string) bson.M {
@@ -302,6 +411,9 @@ func createDatasetUpdateQuery(ctx context.Context, id string, dataset *models.Da
 			if dataset.Links.Taxonomy.HRef != "" {
 				updates["next.links.taxonomy.href"] = dataset.Links.Taxonomy.HRef
 			}
+			if dataset.Links.Taxonomy.ID != "" {
+				updates["next.links.taxonomy.id"] = dataset.Links.Taxonomy.ID
+			}
 		}
 	}
 
@@ -387,33 +499,57 @@ func createDatasetUpdateQuery(ctx context.Context, id string, dataset *models.Da
 }
 
 // UpdateDatasetWithAssociation updates an existing dataset document with collection data
-func (m *Mongo) UpdateDatasetWithAssociation(id, state string, version *models.Version) (err error) {
-	s := m.Session.Copy()
+func (m *Mongo) UpdateDatasetWithAssociation(ctx context.Context, currentDataset *models.DatasetUpdate, state string, version *models.Version) (err error) {
+	s := m.sessionFor("UpdateDatasetWithAssociation")
 	defer s.Close()
 
+	newETag, err := newETagForAssociation(currentDataset, state, version)
+	if err != nil {
+		return err
+	}
+
 	update := bson.M{
 		"$set": bson.M{
 			"next.state":                     state,
 			"next.collection_id":             version.CollectionID,
+			"next.collection_name":           version.CollectionName,
 			"next.links.latest_version.href": version.Links.Version.HRef,
 			"next.links.latest_version.id":   version.Links.Version.ID,
 			"next.last_updated":              time.Now(),
+			"e_tag":                          newETag,
 		},
 	}
 
-	err = s.DB(m.Database).C("datasets").UpdateId(id, update)
+	err = s.DB(m.Database).C("datasets").UpdateId(currentDataset.ID, update)
 	return
 }
 
-// UpdateVersion updates an existing version document
-func (m *Mongo) UpdateVersion(id string, version *models.Version) (err error) {
-	s := m.Session.Copy()
+// UpdateVersion updates an existing version document, if it matches the provided eTag, and returns the new eTag
+func (m *Mongo) UpdateVersion(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (newETag string, err error) {
+	s := m.sessionFor("UpdateVersion")
 	defer s.Close()
 
+	// calculate the new eTag hash for the version that would result from applying the update
+	newETag, err = newETagForVersionUpdate(currentVersion, version)
+	if err != nil {
+		return "", err
+	}
+
 	updates := createVersionUpdateQuery(version)
+	updates["e_tag"] = newETag
 
-	err = s.DB(m.Database).C("instances").Update(bson.M{"id": id}, bson.M{"$set": updates, "$setOnInsert": bson.M{"last_updated": time.Now()}})
-	return
+	sel := selector(currentVersion.ID, 0, eTagSelector)
+	if err = s.DB(m.Database).C("instances").Update(sel, bson.M{"$set": updates, "$setOnInsert": bson.M{"last_updated": time.Now()}}); err != nil {
+		if err == mgo.ErrNotFound {
+			if eTagSelector == AnyETag {
+				return "", errs.ErrVersionNotFound
+			}
+			return "", errs.ErrVersionConflict
+		}
+		return "", err
+	}
+
+	return newETag, nil
 }
 
 func createVersionUpdateQuery(version *models.Version) bson.M {
@@ -426,17 +562,25 @@ func createVersionUpdateQuery(version *models.Version) bson.M {
 	*/
 	if version.State == models.DetachedState {
 		setUpdates["collection_id"] = nil
+		setUpdates["collection_name"] = nil
 		setUpdates["version"] = nil
 	} else {
 		if version.CollectionID != "" {
 			setUpdates["collection_id"] = version.CollectionID
 		}
+		if version.CollectionName != "" {
+			setUpdates["collection_name"] = version.CollectionName
+		}
 	}
 
 	if version.Alerts != nil {
 		setUpdates["alerts"] = version.Alerts
 	}
 
+	if version.Dimensions != nil {
+		setUpdates["dimensions"] = version.Dimensions
+	}
+
 	if version.Downloads != nil {
 		setUpdates["downloads"] = version.Downloads
 	}
@@ -472,11 +616,16 @@ func createVersionUpdateQuery(version *models.Version) bson.M {
 	return setUpdates
 }
 
-// UpsertDataset adds or overides an existing dataset document
-func (m *Mongo) UpsertDataset(id string, datasetDoc *models.DatasetUpdate) (err error) {
-	s := m.Session.Copy()
+// UpsertDataset adds or overides an existing dataset document. A dataset is only overridden if it matches the eTag
+// selector, and is only added when any eTag is accepted.
+func (m *Mongo) UpsertDataset(ctx context.Context, id string, datasetDoc *models.DatasetUpdate, eTagSelector string) (err error) {
+	s := m.sessionFor("UpsertDataset")
 	defer s.Close()
 
+	if datasetDoc.ETag, err = datasetDoc.Hash(nil); err != nil {
+		return err
+	}
+
 	update := bson.M{
 		"$set": datasetDoc,
 		"$setOnInsert": bson.M{
@@ -484,35 +633,88 @@ func (m *Mongo) UpsertDataset(id string, datasetDoc *models.DatasetUpdate) (err
 		},
 	}
 
-	_, err = s.DB(m.Database).C("datasets").UpsertId(id, update)
+	if eTagSelector == AnyETag {
+		_, err = s.DB(m.Database).C("datasets").UpsertId(id, update)
+		return
+	}
+
+	if err = s.DB(m.Database).C("datasets").Update(datasetSelector(id, eTagSelector), update); err == mgo.ErrNotFound {
+		return errs.ErrDatasetConflict
+	}
 	return
 }
 
-// UpsertEdition adds or overides an existing edition document
-func (m *Mongo) UpsertEdition(datasetID, edition string, editionDoc *models.EditionUpdate) (err error) {
-	s := m.Session.Copy()
+// UpsertEdition adds or overides an existing edition document. An edition is only overridden if it matches the eTag
+// selector, and is only added when any eTag is accepted.
+func (m *Mongo) UpsertEdition(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate, eTagSelector string) (err error) {
+	s := m.sessionFor("UpsertEdition")
 	defer s.Close()
 
 	selector := bson.M{
 		"next.edition":          edition,
 		"next.links.dataset.id": datasetID,
 	}
+	if eTagSelector != AnyETag {
+		selector["e_tag"] = eTagSelector
+	}
 
 	editionDoc.Next.LastUpdated = time.Now()
 
+	if editionDoc.ETag, err = editionDoc.Hash(nil); err != nil {
+		return err
+	}
+
 	update := bson.M{
 		"$set": editionDoc,
 	}
 
-	_, err = s.DB(m.Database).C(editionsCollection).Upsert(selector, update)
+	if eTagSelector == AnyETag {
+		_, err = s.DB(m.Database).C(editionsCollection).Upsert(selector, update)
+		return
+	}
+
+	if err = s.DB(m.Database).C(editionsCollection).Update(selector, update); err == mgo.ErrNotFound {
+		return errs.ErrEditionConflict
+	}
 	return
 }
 
+// ClearLatestEditions unmarks every edition of the dataset, other than the provided edition, as the latest edition
+func (m *Mongo) ClearLatestEditions(ctx context.Context, datasetID, edition string) error {
+	s := m.sessionFor("ClearLatestEditions")
+	defer s.Close()
+
+	selector := bson.M{
+		"next.links.dataset.id": datasetID,
+		"next.edition":          bson.M{"$ne": edition},
+		"next.latest":           true,
+	}
+
+	var editions []*models.EditionUpdate
+	if err := s.DB(m.Database).C(editionsCollection).Find(selector).All(&editions); err != nil {
+		return err
+	}
+
+	// each edition is upserted, so its eTag reflects the change
+	for _, editionDoc := range editions {
+		editionDoc.Next.Latest = false
+		if err := m.UpsertEdition(ctx, datasetID, editionDoc.Next.Edition, editionDoc, AnyETag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // UpsertVersion adds or overrides an existing version document
-func (m *Mongo) UpsertVersion(id string, version *models.Version) (err error) {
-	s := m.Session.Copy()
+func (m *Mongo) UpsertVersion(ctx context.Context, id string, version *models.Version) (err error) {
+	s := m.sessionFor("UpsertVersion")
 	defer s.Close()
 
+	if version.ETag, err = version.Hash(nil); err != nil {
+		return err
+	}
+
 	update := bson.M{
 		"$set": version,
 		"$setOnInsert": bson.M{
@@ -525,8 +727,8 @@ func (m *Mongo) UpsertVersion(id string, version *models.Version) (err error) {
 }
 
 // UpsertContact adds or overides an existing contact document
-func (m *Mongo) UpsertContact(id string, update interface{}) (err error) {
-	s := m.Session.Copy()
+func (m *Mongo) UpsertContact(ctx context.Context, id string, update interface{}) (err error) {
+	s := m.sessionFor("UpsertContact")
 	defer s.Close()
 
 	_, err = s.DB(m.Database).C("contacts").UpsertId(id, update)
@@ -534,19 +736,21 @@ func (m *Mongo) UpsertContact(id string, update interface{}) (err error) {
 }
 
 // CheckDatasetExists checks that the dataset exists
-func (m *Mongo) CheckDatasetExists(id, state string) error {
-	s := m.Session.Copy()
+func (m *Mongo) CheckDatasetExists(ctx context.Context, id, state string) error {
+	s := m.sessionFor("CheckDatasetExists")
 	defer s.Close()
 
 	var query bson.M
 	if state == "" {
 		query = bson.M{
-			"_id": id,
+			"_id":        id,
+			"next.state": notDeleted,
 		}
 	} else {
 		query = bson.M{
 			"_id":           id,
 			"current.state": state,
+			"next.state":    notDeleted,
 		}
 	}
 
@@ -563,8 +767,8 @@ func (m *Mongo) CheckDatasetExists(id, state string) error {
 }
 
 // CheckEditionExists checks that the edition of a dataset exists
-func (m *Mongo) CheckEditionExists(id, editionID, state string) error {
-	s := m.Session.Copy()
+func (m *Mongo) CheckEditionExists(ctx context.Context, id, editionID, state string) error {
+	s := m.sessionFor("CheckEditionExists")
 	defer s.Close()
 
 	var query bson.M
@@ -572,12 +776,14 @@ func (m *Mongo) CheckEditionExists(id, editionID, state string) error {
 		query = bson.M{
 			"next.links.dataset.id": id,
 			"next.edition":          editionID,
+			"next.state":            notDeleted,
 		}
 	} else {
 		query = bson.M{
 			"current.links.dataset.id": id,
 			"current.edition":          editionID,
 			"current.state":            state,
+			"next.state":               notDeleted,
 		}
 	}
 
@@ -632,8 +838,8 @@ func (m *Mongo) Ping(ctx context.Context) (time.Time, error) {
 }
 
 // DeleteDataset deletes an existing dataset document
-func (m *Mongo) DeleteDataset(id string) (err error) {
-	s := m.Session.Copy()
+func (m *Mongo) DeleteDataset(ctx context.Context, id string) (err error) {
+	s := m.sessionFor("DeleteDataset")
 	defer s.Close()
 
 	if err = s.DB(m.Database).C("datasets").RemoveId(id); err != nil {
@@ -647,8 +853,8 @@ func (m *Mongo) DeleteDataset(id string) (err error) {
 }
 
 // DeleteEdition deletes an existing edition document
-func (m *Mongo) DeleteEdition(id string) (err error) {
-	s := m.Session.Copy()
+func (m *Mongo) DeleteEdition(ctx context.Context, id string) (err error) {
+	s := m.sessionFor("DeleteEdition")
 	defer s.Close()
 
 	if err = s.DB(m.Database).C("editions").Remove(bson.D{{Name: "id", Value: id}}); err != nil {
@@ -660,3 +866,11 @@ func (m *Mongo) DeleteEdition(id string) (err error) {
 
 	return nil
 }
+
+func datasetSelector(datasetID string, eTagSelector string) bson.M {
+	selector := bson.M{"_id": datasetID}
+	if eTagSelector != AnyETag {
+		selector["e_tag"] = eTagSelector
+	}
+	return selector
+}
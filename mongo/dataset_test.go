@@ -20,17 +20,18 @@ var (
 
 func TestBuildEditionsQuery(t *testing.T) {
 	t.Parallel()
-	Convey("When no state was set and the request is authorised then the selector only queries by id", t, func() {
+	Convey("When no state was set and the request is authorised then the selector only queries by id, excluding deleted editions", t, func() {
 		selector := buildEditionsQuery(id, "", true)
 		So(selector, ShouldNotBeNil)
-		So(selector, ShouldHaveLength, 1)
+		So(selector, ShouldHaveLength, 2)
 		So(selector["next.links.dataset.id"], ShouldEqual, id)
+		So(selector["next.state"], ShouldResemble, bson.M{"$ne": models.DeletedState})
 	})
 
 	Convey("When no state was set and the request is not authorised then the selector queries by id and current must exist", t, func() {
 		selector := buildEditionsQuery(id, "", false)
 		So(selector, ShouldNotBeNil)
-		So(selector, ShouldHaveLength, 2)
+		So(selector, ShouldHaveLength, 3)
 		So(selector["next.links.dataset.id"], ShouldEqual, id)
 		So(selector["current"], ShouldResemble, bson.M{"$exists": true})
 
@@ -39,7 +40,7 @@ func TestBuildEditionsQuery(t *testing.T) {
 	Convey("When state was set to published and request is authorised then the selector queries by id and state", t, func() {
 		selector := buildEditionsQuery(id, state, true)
 		So(selector, ShouldNotBeNil)
-		So(selector, ShouldHaveLength, 2)
+		So(selector, ShouldHaveLength, 3)
 		So(selector["next.links.dataset.id"], ShouldEqual, id)
 		So(selector["current.state"], ShouldEqual, state)
 	})
@@ -47,7 +48,7 @@ func TestBuildEditionsQuery(t *testing.T) {
 	Convey("When state was set to published and request is not authorised then the selector queries by id, state and current must exist", t, func() {
 		selector := buildEditionsQuery(id, state, false)
 		So(selector, ShouldNotBeNil)
-		So(selector, ShouldHaveLength, 3)
+		So(selector, ShouldHaveLength, 4)
 		So(selector["next.links.dataset.id"], ShouldEqual, id)
 		So(selector["current.state"], ShouldEqual, state)
 		So(selector["current"], ShouldResemble, bson.M{"$exists": true})
@@ -61,6 +62,7 @@ func TestBuildEditionQuery(t *testing.T) {
 		expectedSelector := bson.M{
 			"next.links.dataset.id": id,
 			"next.edition":          editionID,
+			"next.state":            bson.M{"$ne": models.DeletedState},
 		}
 
 		selector := buildEditionQuery(id, editionID, "")
@@ -74,6 +76,7 @@ func TestBuildEditionQuery(t *testing.T) {
 			"current.links.dataset.id": id,
 			"current.edition":          editionID,
 			"current.state":            state,
+			"next.state":               bson.M{"$ne": models.DeletedState},
 		}
 
 		selector := buildEditionQuery(id, editionID, state)
@@ -93,6 +96,7 @@ func TestBuildVersionsQuery(t *testing.T) {
 				bson.M{"state": "edition-confirmed"},
 				bson.M{"state": "associated"},
 				bson.M{"state": "published"},
+				bson.M{"state": "withdrawn"},
 			},
 		}
 
@@ -101,12 +105,12 @@ func TestBuildVersionsQuery(t *testing.T) {
 		So(selector, ShouldResemble, expectedSelector)
 	})
 
-	Convey("When state was set to published", t, func() {
+	Convey("When state was set to published, withdrawn versions are also selected", t, func() {
 
 		expectedSelector := bson.M{
 			"links.dataset.id": id,
 			"edition":          editionID,
-			"state":            state,
+			"state":            bson.M{"$in": []string{models.PublishedState, models.WithdrawnState}},
 		}
 
 		selector := buildVersionsQuery(id, editionID, state)
@@ -123,6 +127,7 @@ func TestBuildVersionQuery(t *testing.T) {
 			"links.dataset.id": id,
 			"edition":          editionID,
 			"version":          versionID,
+			"state":            bson.M{"$ne": models.DeletedState},
 		}
 
 		selector := buildVersionQuery(id, editionID, "", versionID)
@@ -130,13 +135,13 @@ func TestBuildVersionQuery(t *testing.T) {
 		So(selector, ShouldResemble, expectedSelector)
 	})
 
-	Convey("When state was set to published", t, func() {
+	Convey("When state was set to published, a withdrawn version is also selected", t, func() {
 
 		expectedSelector := bson.M{
 			"links.dataset.id": id,
 			"edition":          editionID,
 			"version":          versionID,
-			"state":            state,
+			"state":            bson.M{"$in": []string{models.PublishedState, models.WithdrawnState}},
 		}
 
 		selector := buildVersionQuery(id, editionID, state, versionID)
@@ -193,6 +198,8 @@ func TestDatasetUpdateQuery(t *testing.T) {
 			"next.keywords":                 []string{"statistics", "national"},
 			"next.license":                  "ONS License",
 			"next.links.access_rights.href": "http://ons.gov.uk/accessrights",
+			"next.links.taxonomy.href":      "http://localhost:22000/topics/construction",
+			"next.links.taxonomy.id":        "construction",
 			"next.methodologies":            methodologies,
 			"next.national_statistic":       &nationalStatistic,
 			"next.next_release":             "2018-05-05",
@@ -222,6 +229,10 @@ func TestDatasetUpdateQuery(t *testing.T) {
 				AccessRights: &models.LinkObject{
 					HRef: "http://ons.gov.uk/accessrights",
 				},
+				Taxonomy: &models.LinkObject{
+					HRef: "http://localhost:22000/topics/construction",
+					ID:   "construction",
+				},
 			},
 			Methodologies:     methodologies,
 			NationalStatistic: &nationalStatistic,
@@ -281,6 +292,7 @@ func TestVersionUpdateQuery(t *testing.T) {
 
 		expectedUpdate := bson.M{
 			"collection_id":      "12345678",
+			"collection_name":    "LMS",
 			"release_date":       "2017-09-09",
 			"links.spatial.href": "http://ons.gov.uk/geographylist",
 			"state":              models.PublishedState,
@@ -288,8 +300,9 @@ func TestVersionUpdateQuery(t *testing.T) {
 		}
 
 		version := &models.Version{
-			CollectionID: "12345678",
-			ReleaseDate:  "2017-09-09",
+			CollectionID:   "12345678",
+			CollectionName: "LMS",
+			ReleaseDate:    "2017-09-09",
 			Links: &models.VersionLinks{
 				Spatial: &models.LinkObject{
 					HRef: "http://ons.gov.uk/geographylist",
@@ -303,4 +316,20 @@ func TestVersionUpdateQuery(t *testing.T) {
 		So(selector, ShouldNotBeNil)
 		So(selector, ShouldResemble, expectedUpdate)
 	})
+
+	Convey("When the version is detached its collection is removed", t, func() {
+		version := &models.Version{
+			CollectionID:   "12345678",
+			CollectionName: "LMS",
+			State:          models.DetachedState,
+		}
+
+		selector := createVersionUpdateQuery(version)
+		So(selector, ShouldResemble, bson.M{
+			"collection_id":   nil,
+			"collection_name": nil,
+			"version":         nil,
+			"state":           models.DetachedState,
+		})
+	})
 }
@@ -0,0 +1,51 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/globalsign/mgo"
+)
+
+// AddDeleteJob stores a new delete job
+func (m *Mongo) AddDeleteJob(ctx context.Context, job *models.DeleteJob) error {
+	s := m.sessionFor("AddDeleteJob")
+	defer s.Close()
+
+	job.LastUpdated = time.Now().UTC()
+	return s.DB(m.Database).C(deleteJobsCollection).Insert(job)
+}
+
+// GetDeleteJob returns the delete job with the provided ID
+func (m *Mongo) GetDeleteJob(ctx context.Context, jobID string) (*models.DeleteJob, error) {
+	s := m.sessionFor("GetDeleteJob")
+	defer s.Close()
+
+	var job models.DeleteJob
+	if err := s.DB(m.Database).C(deleteJobsCollection).FindId(jobID).One(&job); err != nil {
+		if err == mgo.ErrNotFound {
+			return nil, errs.ErrDeleteJobNotFound
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// UpdateDeleteJob replaces a stored delete job with the provided one, recording the progress made
+func (m *Mongo) UpdateDeleteJob(ctx context.Context, job *models.DeleteJob) error {
+	s := m.sessionFor("UpdateDeleteJob")
+	defer s.Close()
+
+	job.LastUpdated = time.Now().UTC()
+	if err := s.DB(m.Database).C(deleteJobsCollection).UpdateId(job.ID, job); err != nil {
+		if err == mgo.ErrNotFound {
+			return errs.ErrDeleteJobNotFound
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,310 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+)
+
+// GetDeletedDataset retrieves a dataset document that has been deleted, but not yet purged
+func (m *Mongo) GetDeletedDataset(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+	s := m.sessionFor("GetDeletedDataset")
+	defer s.Close()
+
+	var dataset models.DatasetUpdate
+	if err := s.DB(m.Database).C("datasets").Find(bson.M{"_id": id, "next.state": models.DeletedState}).One(&dataset); err != nil {
+		if err == mgo.ErrNotFound {
+			return nil, errs.ErrDatasetNotFound
+		}
+		return nil, err
+	}
+
+	return &dataset, nil
+}
+
+// GetDatasetsDeletedBefore returns the IDs of the datasets that were deleted before the provided time
+func (m *Mongo) GetDatasetsDeletedBefore(ctx context.Context, before time.Time) ([]string, error) {
+	s := m.sessionFor("GetDatasetsDeletedBefore")
+	defer s.Close()
+
+	selector := bson.M{
+		"next.state":               models.DeletedState,
+		"next.deletion.deleted_at": bson.M{"$lt": before},
+	}
+
+	results := []models.DatasetUpdate{}
+	if err := s.DB(m.Database).C("datasets").Find(selector).Select(bson.M{"_id": 1}).All(&results); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(results))
+	for _, dataset := range results {
+		ids = append(ids, dataset.ID)
+	}
+	return ids, nil
+}
+
+// GetDatasetEditions returns the id of every edition of the dataset, including deleted editions
+func (m *Mongo) GetDatasetEditions(ctx context.Context, datasetID string) ([]*models.EditionUpdate, error) {
+	s := m.sessionFor("GetDatasetEditions")
+	defer s.Close()
+
+	results := []*models.EditionUpdate{}
+	err := s.DB(m.Database).C(editionsCollection).
+		Find(bson.M{"next.links.dataset.id": datasetID}).
+		Select(bson.M{"id": 1}).
+		All(&results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// SoftDeleteDataset moves the dataset, its editions and its instances (including versions) into the deleted state,
+// recording who deleted them, when, and the state each was in. The dataset must match the eTag, both before anything
+// is deleted and when the dataset itself is deleted. The instances and editions are deleted before the dataset, so a
+// deletion that fails part way through can be completed by deleting the dataset again.
+func (m *Mongo) SoftDeleteDataset(ctx context.Context, datasetID, eTagSelector, deletedBy string, deletedAt time.Time) error {
+	s := m.sessionFor("SoftDeleteDataset")
+	defer s.Close()
+	db := s.DB(m.Database)
+
+	var dataset models.DatasetUpdate
+	if err := db.C("datasets").Find(bson.M{"_id": datasetID, "next.state": notDeleted}).One(&dataset); err != nil {
+		if err == mgo.ErrNotFound {
+			return errs.ErrDatasetNotFound
+		}
+		return err
+	}
+	if eTagSelector != AnyETag && eTagSelector != dataset.ETag {
+		return errs.ErrDatasetConflict
+	}
+
+	deletion := func(previousState string) models.Deletion {
+		return models.Deletion{DeletedBy: deletedBy, DeletedAt: deletedAt, PreviousState: previousState}
+	}
+
+	if err := softDeleteInstances(db, bson.M{"links.dataset.id": datasetID}, deletion); err != nil {
+		return err
+	}
+
+	editions := []models.EditionUpdate{}
+	err := db.C(editionsCollection).
+		Find(bson.M{"next.links.dataset.id": datasetID, "next.state": notDeleted}).
+		Select(bson.M{"id": 1, "next.state": 1}).
+		All(&editions)
+	if err != nil {
+		return err
+	}
+	for _, edition := range editions {
+		update := softDeleteUpdate("next.", deletion(editionState(edition.Next)))
+		if err := db.C(editionsCollection).Update(bson.M{"id": edition.ID}, update); err != nil && err != mgo.ErrNotFound {
+			return err
+		}
+	}
+
+	selector := datasetSelector(datasetID, eTagSelector)
+	selector["next.state"] = notDeleted
+	if err := db.C("datasets").Update(selector, softDeleteUpdate("next.", deletion(datasetState(dataset.Next)))); err != nil {
+		if err == mgo.ErrNotFound {
+			return errs.ErrDatasetConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// SoftDeleteEdition moves the edition of the dataset, and its instances (including versions), into the deleted state,
+// recording who deleted them, when, and the state each was in. The edition must match the eTag, both before anything
+// is deleted and when the edition itself is deleted. The instances are deleted before the edition, so a deletion that
+// fails part way through can be completed by deleting the edition again.
+func (m *Mongo) SoftDeleteEdition(ctx context.Context, datasetID, edition, eTagSelector, deletedBy string, deletedAt time.Time) error {
+	s := m.sessionFor("SoftDeleteEdition")
+	defer s.Close()
+	db := s.DB(m.Database)
+
+	selector := bson.M{
+		"next.edition":          edition,
+		"next.links.dataset.id": datasetID,
+		"next.state":            notDeleted,
+	}
+
+	var editionDoc models.EditionUpdate
+	if err := db.C(editionsCollection).Find(selector).One(&editionDoc); err != nil {
+		if err == mgo.ErrNotFound {
+			return errs.ErrEditionNotFound
+		}
+		return err
+	}
+	if eTagSelector != AnyETag && eTagSelector != editionDoc.ETag {
+		return errs.ErrEditionConflict
+	}
+
+	deletion := func(previousState string) models.Deletion {
+		return models.Deletion{DeletedBy: deletedBy, DeletedAt: deletedAt, PreviousState: previousState}
+	}
+
+	if err := softDeleteInstances(db, bson.M{"links.dataset.id": datasetID, "edition": edition}, deletion); err != nil {
+		return err
+	}
+
+	if eTagSelector != AnyETag {
+		selector["e_tag"] = eTagSelector
+	}
+	if err := db.C(editionsCollection).Update(selector, softDeleteUpdate("next.", deletion(editionState(editionDoc.Next)))); err != nil {
+		if err == mgo.ErrNotFound {
+			return errs.ErrEditionConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// GetEditionsDeletedBefore returns the editions that were deleted before the provided time, with the dataset each
+// belongs to
+func (m *Mongo) GetEditionsDeletedBefore(ctx context.Context, before time.Time) ([]*models.EditionUpdate, error) {
+	s := m.sessionFor("GetEditionsDeletedBefore")
+	defer s.Close()
+
+	selector := bson.M{
+		"next.state":               models.DeletedState,
+		"next.deletion.deleted_at": bson.M{"$lt": before},
+	}
+
+	results := []*models.EditionUpdate{}
+	err := s.DB(m.Database).C(editionsCollection).
+		Find(selector).
+		Select(bson.M{"id": 1, "next.edition": 1, "next.links.dataset.id": 1}).
+		All(&results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// RestoreDataset moves a deleted dataset, and the editions and instances deleted with it, back into the states they
+// were deleted from. Editions and instances deleted on their own before the dataset was deleted stay deleted. The
+// dataset is restored last, so a restore that fails part way through can be completed by restoring the dataset again.
+func (m *Mongo) RestoreDataset(ctx context.Context, datasetID string) error {
+	s := m.sessionFor("RestoreDataset")
+	defer s.Close()
+	db := s.DB(m.Database)
+
+	var dataset models.DatasetUpdate
+	if err := db.C("datasets").Find(bson.M{"_id": datasetID, "next.state": models.DeletedState}).One(&dataset); err != nil {
+		if err == mgo.ErrNotFound {
+			return errs.ErrDatasetNotFound
+		}
+		return err
+	}
+	var deletion *models.Deletion
+	if dataset.Next != nil {
+		deletion = dataset.Next.Deletion
+	}
+
+	// the editions and instances deleted with the dataset were deleted at the same time as it
+	instanceSelector := bson.M{"links.dataset.id": datasetID, "state": models.DeletedState}
+	editionSelector := bson.M{"next.links.dataset.id": datasetID, "next.state": models.DeletedState}
+	if deletion != nil {
+		instanceSelector["deletion.deleted_at"] = deletion.DeletedAt
+		editionSelector["next.deletion.deleted_at"] = deletion.DeletedAt
+	}
+
+	instances := []models.Instance{}
+	err := db.C(instanceCollection).
+		Find(instanceSelector).
+		Select(bson.M{"id": 1, "deletion": 1}).
+		All(&instances)
+	if err != nil {
+		return err
+	}
+	for _, instance := range instances {
+		update := restoreUpdate("", instance.Deletion)
+		if err := db.C(instanceCollection).Update(bson.M{"id": instance.InstanceID}, update); err != nil && err != mgo.ErrNotFound {
+			return err
+		}
+	}
+
+	editions := []models.EditionUpdate{}
+	err = db.C(editionsCollection).
+		Find(editionSelector).
+		Select(bson.M{"id": 1, "next.deletion": 1}).
+		All(&editions)
+	if err != nil {
+		return err
+	}
+	for _, edition := range editions {
+		var editionDeletion *models.Deletion
+		if edition.Next != nil {
+			editionDeletion = edition.Next.Deletion
+		}
+		update := restoreUpdate("next.", editionDeletion)
+		if err := db.C(editionsCollection).Update(bson.M{"id": edition.ID}, update); err != nil && err != mgo.ErrNotFound {
+			return err
+		}
+	}
+
+	return db.C("datasets").UpdateId(datasetID, restoreUpdate("next.", deletion))
+}
+
+// softDeleteInstances moves the instances matching the selector, which have not already been deleted, into the
+// deleted state
+func softDeleteInstances(db *mgo.Database, selector bson.M, deletion func(previousState string) models.Deletion) error {
+	selector["state"] = notDeleted
+
+	instances := []models.Instance{}
+	if err := db.C(instanceCollection).Find(selector).Select(bson.M{"id": 1, "state": 1}).All(&instances); err != nil {
+		return err
+	}
+	for _, instance := range instances {
+		update := softDeleteUpdate("", deletion(instance.State))
+		if err := db.C(instanceCollection).Update(bson.M{"id": instance.InstanceID}, update); err != nil && err != mgo.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// softDeleteUpdate returns the update moving a document, or its sub document at prefix, into the deleted state
+func softDeleteUpdate(prefix string, deletion models.Deletion) bson.M {
+	return bson.M{
+		"$set": bson.M{
+			prefix + "state":    models.DeletedState,
+			prefix + "deletion": deletion,
+		},
+	}
+}
+
+// restoreUpdate returns the update moving a deleted document, or its sub document at prefix, back into the state it
+// was deleted from
+func restoreUpdate(prefix string, deletion *models.Deletion) bson.M {
+	var previousState string
+	if deletion != nil {
+		previousState = deletion.PreviousState
+	}
+
+	return bson.M{
+		"$set":   bson.M{prefix + "state": previousState},
+		"$unset": bson.M{prefix + "deletion": ""},
+	}
+}
+
+func datasetState(dataset *models.Dataset) string {
+	if dataset == nil {
+		return ""
+	}
+	return dataset.State
+}
+
+func editionState(edition *models.Edition) string {
+	if edition == nil {
+		return ""
+	}
+	return edition.State
+}
@@ -0,0 +1,47 @@
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/globalsign/mgo/bson"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSoftDeleteUpdate(t *testing.T) {
+	t.Parallel()
+	Convey("When a sub document is soft deleted, its state and deletion details are set", t, func() {
+		deletion := models.Deletion{
+			DeletedBy:     "someone@ons.gov.uk",
+			DeletedAt:     time.Date(2020, time.March, 10, 9, 30, 0, 0, time.UTC),
+			PreviousState: models.CreatedState,
+		}
+
+		So(softDeleteUpdate("next.", deletion), ShouldResemble, bson.M{
+			"$set": bson.M{
+				"next.state":    models.DeletedState,
+				"next.deletion": deletion,
+			},
+		})
+	})
+}
+
+func TestRestoreUpdate(t *testing.T) {
+	t.Parallel()
+	Convey("When a document is restored, its previous state is set and its deletion details are removed", t, func() {
+		deletion := &models.Deletion{PreviousState: models.AssociatedState}
+
+		So(restoreUpdate("", deletion), ShouldResemble, bson.M{
+			"$set":   bson.M{"state": models.AssociatedState},
+			"$unset": bson.M{"deletion": ""},
+		})
+	})
+
+	Convey("When a document without deletion details is restored, its state is cleared", t, func() {
+		So(restoreUpdate("next.", nil), ShouldResemble, bson.M{
+			"$set":   bson.M{"next.state": ""},
+			"$unset": bson.M{"next.deletion": ""},
+		})
+	})
+}
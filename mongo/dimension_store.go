@@ -18,12 +18,14 @@ const maxIDs = 1000
 // Note that all dimension options for all dimensions are returned as high level items, hence there can be duplicate dimension names,
 // which correspond to different options.
 func (m *Mongo) GetDimensionsFromInstance(ctx context.Context, id string, offset, limit int) ([]*models.DimensionOption, int, error) {
-	s := m.Session.Copy()
+	s := m.sessionFor("GetDimensionsFromInstance")
 	defer s.Close()
 
+	// options are sorted by _id, so that reading every option a page at a time neither skips nor repeats any of them
 	q := s.DB(m.Database).C(dimensionOptions).
 		Find(bson.M{"instance_id": id}).
-		Select(bson.M{"id": 0, "last_updated": 0, "instance_id": 0})
+		Select(bson.M{"id": 0, "last_updated": 0, "instance_id": 0}).
+		Sort("_id")
 
 	// get total count and paginated values according to provided offset and limit
 	dimensions := []*models.DimensionOption{}
@@ -37,7 +39,7 @@ func (m *Mongo) GetDimensionsFromInstance(ctx context.Context, id string, offset
 
 // GetUniqueDimensionAndOptions returns a list of dimension options for an instance resource
 func (m *Mongo) GetUniqueDimensionAndOptions(ctx context.Context, id, dimension string, offset, limit int) ([]*string, int, error) {
-	s := m.Session.Copy()
+	s := m.sessionFor("GetUniqueDimensionAndOptions")
 	defer s.Close()
 
 	q, err := m.sortedQuery(s, bson.M{"instance_id": id, "name": dimension})
@@ -62,8 +64,8 @@ func (m *Mongo) GetUniqueDimensionAndOptions(ctx context.Context, id, dimension
 }
 
 // AddDimensionToInstance to the dimension collection
-func (m *Mongo) AddDimensionToInstance(opt *models.CachedDimensionOption) error {
-	s := m.Session.Copy()
+func (m *Mongo) AddDimensionToInstance(ctx context.Context, opt *models.CachedDimensionOption) error {
+	s := m.sessionFor("AddDimensionToInstance")
 	defer s.Close()
 
 	option := models.DimensionOption{InstanceID: opt.InstanceID, Option: opt.Option, Name: opt.Name, Label: opt.Label}
@@ -79,8 +81,8 @@ func (m *Mongo) AddDimensionToInstance(opt *models.CachedDimensionOption) error
 }
 
 // GetDimensions returns a list of all dimensions from a dataset
-func (m *Mongo) GetDimensions(datasetID, versionID string) ([]bson.M, error) {
-	s := m.Session.Copy()
+func (m *Mongo) GetDimensions(ctx context.Context, datasetID, versionID string) ([]bson.M, error) {
+	s := m.sessionFor("GetDimensions")
 	defer s.Close()
 
 	// To get all unique values an aggregation is needed, as using distinct() will only return the distinct values and
@@ -102,11 +104,35 @@ func (m *Mongo) GetDimensions(datasetID, versionID string) ([]bson.M, error) {
 	return results, nil
 }
 
+// GetDimensionSummaries counts the options of each dimension of an instance, along with the first option in the order
+// the options are listed in and the code list the options are taken from
+func (m *Mongo) GetDimensionSummaries(ctx context.Context, instanceID string) ([]*models.DimensionSummary, error) {
+	s := m.sessionFor("GetDimensionSummaries")
+	defer s.Close()
+
+	match := bson.M{"$match": bson.M{"instance_id": instanceID}}
+	// Sort the options as they are listed, so that the first option of each group is the first one listed.
+	sort := bson.M{"$sort": bson.D{{Name: "name", Value: 1}, {Name: "order", Value: 1}, {Name: "option", Value: 1}}}
+	group := bson.M{"$group": bson.M{
+		"_id":               "$name",
+		"number_of_options": bson.M{"$sum": 1},
+		"first_option":      bson.M{"$first": "$option"},
+		"code_list":         bson.M{"$first": "$links.code_list"},
+	}}
+
+	summaries := []*models.DimensionSummary{}
+	if err := s.DB(m.Database).C(dimensionOptions).Pipe([]bson.M{match, sort, group}).All(&summaries); err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
 // GetDimensionOptions returns dimension options for a dimensions within a dataset, according to the provided limit and offest.
 // Offset and limit need to be positive or zero
 func (m *Mongo) GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset, limit int) ([]*models.PublicDimensionOption, int, error) {
 
-	s := m.Session.Copy()
+	s := m.sessionFor("GetDimensionOptions")
 	defer s.Close()
 
 	// define selector to obtain all the dimension options for an instance
@@ -134,12 +160,12 @@ func (m *Mongo) GetDimensionOptions(ctx context.Context, version *models.Version
 }
 
 // GetDimensionOptionsFromIDs returns dimension options for a dimension within a dataset, whose IDs match the provided list of IDs
-func (m *Mongo) GetDimensionOptionsFromIDs(version *models.Version, dimension string, IDs []string) ([]*models.PublicDimensionOption, int, error) {
+func (m *Mongo) GetDimensionOptionsFromIDs(ctx context.Context, version *models.Version, dimension string, IDs []string) ([]*models.PublicDimensionOption, int, error) {
 	if len(IDs) > maxIDs {
 		return nil, 0, errors.New("too many IDs provided")
 	}
 
-	s := m.Session.Copy()
+	s := m.sessionFor("GetDimensionOptionsFromIDs")
 	defer s.Close()
 
 	selectorAll := bson.M{"instance_id": version.ID, "name": dimension}
@@ -177,14 +203,14 @@ func (m *Mongo) GetDimensionOptionsFromIDs(version *models.Version, dimension st
 }
 
 // UpdateDimensionNodeIDAndOrder to cache the id and order (optional) for other import processes
-func (m *Mongo) UpdateDimensionNodeIDAndOrder(dimension *models.DimensionOption) error {
+func (m *Mongo) UpdateDimensionNodeIDAndOrder(ctx context.Context, dimension *models.DimensionOption) error {
 
 	// validate that there is something to update
 	if dimension.Order == nil && dimension.NodeID == "" {
 		return nil
 	}
 
-	s := m.Session.Copy()
+	s := m.sessionFor("UpdateDimensionNodeIDAndOrder")
 	defer s.Close()
 
 	selector := bson.M{"instance_id": dimension.InstanceID, "name": dimension.Name, "option": dimension.Option}
@@ -227,3 +253,24 @@ func (m *Mongo) sortedQuery(s *mgo.Session, selector bson.M) (*mgo.Query, error)
 	}
 	return q.Sort("option"), nil
 }
+
+// CountDimensionOptions returns the number of dimension options stored for an instance
+func (m *Mongo) CountDimensionOptions(ctx context.Context, instanceID string) (int, error) {
+	s := m.sessionFor("CountDimensionOptions")
+	defer s.Close()
+
+	return s.DB(m.Database).C(dimensionOptions).Find(bson.M{"instance_id": instanceID}).Count()
+}
+
+// DeleteDimensionOptions removes all of the dimension options stored for an instance, returning how many were removed
+func (m *Mongo) DeleteDimensionOptions(ctx context.Context, instanceID string) (int, error) {
+	s := m.sessionFor("DeleteDimensionOptions")
+	defer s.Close()
+
+	info, err := s.DB(m.Database).C(dimensionOptions).RemoveAll(bson.M{"instance_id": instanceID})
+	if err != nil {
+		return 0, err
+	}
+
+	return info.Removed, nil
+}
@@ -1,10 +1,14 @@
 package mongo
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/globalsign/mgo"
 	"github.com/globalsign/mgo/bson"
+	"github.com/pkg/errors"
 )
 
 // AnyETag represents the wildchar that corresponds to not check the ETag value for update requests
@@ -61,3 +65,94 @@ func newETagForAddDimensionOption(currentInstance *models.Instance, option *mode
 	}
 	return currentInstance.Hash(optionBytes)
 }
+
+func newETagForImportRetry(currentInstance *models.Instance, tasks *models.InstanceImportTasks, event *models.Event) (eTag string, err error) {
+	tasksBytes, err := bson.Marshal(tasks)
+	if err != nil {
+		return "", err
+	}
+	eventBytes, err := bson.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+	return currentInstance.Hash(append(tasksBytes, eventBytes...))
+}
+
+func newETagForDatasetUpdate(currentDataset *models.DatasetUpdate, update *models.Dataset) (eTag string, err error) {
+	b, err := bson.Marshal(update)
+	if err != nil {
+		return "", err
+	}
+	return currentDataset.Hash(b)
+}
+
+func newETagForAssociation(currentDataset *models.DatasetUpdate, state string, version *models.Version) (eTag string, err error) {
+	b, err := bson.Marshal(version)
+	if err != nil {
+		return "", err
+	}
+	return currentDataset.Hash(append([]byte(fmt.Sprintf("state%s", state)), b...))
+}
+
+func newETagForVersionUpdate(currentVersion *models.Version, update *models.Version) (eTag string, err error) {
+	b, err := bson.Marshal(update)
+	if err != nil {
+		return "", err
+	}
+	return currentVersion.Hash(b)
+}
+
+// withoutETag selects the documents written before eTags were stored, or without one
+var withoutETag = bson.M{"e_tag": bson.M{"$in": []interface{}{nil, ""}}}
+
+// BackfillETags stores the eTag of every dataset and edition without one, so that their responses carry an ETag header
+// that can be sent back in an If-Match header. Only documents without an eTag are updated, so the backfill can be run
+// more than once. It returns the number of updated documents.
+func (m *Mongo) BackfillETags(ctx context.Context) (int, error) {
+	s := m.Session.Copy()
+	defer s.Close()
+
+	datasets := s.DB(m.Database).C("datasets")
+	updated := 0
+	var dataset models.DatasetUpdate
+	iter := datasets.Find(withoutETag).Iter()
+	for iter.Next(&dataset) {
+		eTag, err := dataset.Hash(nil)
+		if err != nil {
+			iter.Close()
+			return updated, err
+		}
+		if err := datasets.Update(bson.M{"_id": dataset.ID, "e_tag": withoutETag["e_tag"]}, bson.M{"$set": bson.M{"e_tag": eTag}}); err != nil && err != mgo.ErrNotFound {
+			iter.Close()
+			return updated, errors.Wrap(err, "failed to backfill the eTag of a dataset")
+		}
+		updated++
+		dataset = models.DatasetUpdate{}
+	}
+	if err := iter.Close(); err != nil {
+		return updated, errors.Wrap(err, "failed to read the datasets without an eTag")
+	}
+
+	editions := s.DB(m.Database).C(editionsCollection)
+	var edition models.EditionUpdate
+	iter = editions.Find(withoutETag).Iter()
+	for iter.Next(&edition) {
+		eTag, err := edition.Hash(nil)
+		if err != nil {
+			iter.Close()
+			return updated, err
+		}
+		if err := editions.Update(bson.M{"id": edition.ID, "e_tag": withoutETag["e_tag"]}, bson.M{"$set": bson.M{"e_tag": eTag}}); err != nil && err != mgo.ErrNotFound {
+			iter.Close()
+			return updated, errors.Wrap(err, "failed to backfill the eTag of an edition")
+		}
+		updated++
+		edition = models.EditionUpdate{}
+	}
+	if err := iter.Close(); err != nil {
+		return updated, errors.Wrap(err, "failed to read the editions without an eTag")
+	}
+
+	log.Event(ctx, "backfilled the eTags of datasets and editions", log.INFO, log.Data{"updated": updated})
+	return updated, nil
+}
@@ -273,3 +273,65 @@ func TestNewETagForAddDimensionOption(t *testing.T) {
 		})
 	})
 }
+
+func TestNewETagForDatasetUpdate(t *testing.T) {
+
+	Convey("Given a dataset", t, func() {
+
+		currentDataset := &models.DatasetUpdate{
+			ID:   "123",
+			Next: &models.Dataset{Title: "CPI", State: models.CreatedState},
+		}
+		eTag0, err := currentDataset.Hash(nil)
+		So(err, ShouldBeNil)
+		currentDataset.ETag = eTag0
+
+		update := &models.Dataset{
+			Title: "CPIH",
+		}
+
+		Convey("newETagForDatasetUpdate returns an eTag that is different from the original dataset ETag", func() {
+			eTag1, err := newETagForDatasetUpdate(currentDataset, update)
+			So(err, ShouldBeNil)
+			So(eTag1, ShouldNotEqual, currentDataset.ETag)
+
+			Convey("Applying a different update to the same dataset results in a different ETag", func() {
+				eTag2, err := newETagForDatasetUpdate(currentDataset, &models.Dataset{Title: "RPI"})
+				So(err, ShouldBeNil)
+				So(eTag2, ShouldNotEqual, eTag1)
+			})
+		})
+	})
+}
+
+func TestNewETagForVersionUpdate(t *testing.T) {
+
+	Convey("Given a version", t, func() {
+
+		currentVersion := &models.Version{
+			ID:      "789",
+			Edition: "2017",
+			State:   models.EditionConfirmedState,
+			Version: 1,
+		}
+		eTag0, err := currentVersion.Hash(nil)
+		So(err, ShouldBeNil)
+		currentVersion.ETag = eTag0
+
+		update := &models.Version{
+			State: models.AssociatedState,
+		}
+
+		Convey("newETagForVersionUpdate returns an eTag that is different from the original version ETag", func() {
+			eTag1, err := newETagForVersionUpdate(currentVersion, update)
+			So(err, ShouldBeNil)
+			So(eTag1, ShouldNotEqual, currentVersion.ETag)
+
+			Convey("Applying a different update to the same version results in a different ETag", func() {
+				eTag2, err := newETagForVersionUpdate(currentVersion, &models.Version{State: models.PublishedState})
+				So(err, ShouldBeNil)
+				So(eTag2, ShouldNotEqual, eTag1)
+			})
+		})
+	})
+}
@@ -0,0 +1,188 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ONSdigital/log.go/log"
+	"github.com/globalsign/mgo"
+)
+
+// namespaceNotFound is the code of the error returned when the indexes of a collection that does not exist are listed
+const namespaceNotFound = 26
+
+// collectionIndexes are the indexes of each collection the queries of the store rely on. Delete jobs, topics and link
+// reports are only selected by their _id, which mongo always indexes, so none are declared for them.
+var collectionIndexes = []struct {
+	collection string
+	indexes    []mgo.Index
+}{
+	{
+		collection: datasetsCollection,
+		indexes: []mgo.Index{
+			// the datasets of a topic are those whose current or next theme is the topic
+			{Key: []string{"current.theme"}},
+			{Key: []string{"next.theme"}},
+			// related datasets are those which list the dataset in their current or next related datasets
+			{Key: []string{"current.related_datasets.id"}},
+			{Key: []string{"next.related_datasets.id"}},
+			// deleted datasets are purged once they have been deleted for longer than the retention period
+			{Key: []string{"next.state", "next.deletion.deleted_at"}},
+		},
+	},
+	{
+		collection: instanceCollection,
+		indexes: []mgo.Index{
+			// instances are updated by their id, along with the e_tag and unique_timestamp they were read with
+			{Key: []string{"id"}},
+			// versions are selected by their dataset, edition and version, and listed in version order
+			{Key: []string{"links.dataset.id", "edition", "version"}},
+			{Key: []string{"state", "-last_updated"}},
+		},
+	},
+	{
+		collection: editionsCollection,
+		indexes: []mgo.Index{
+			{Key: []string{"id"}},
+			{Key: []string{"next.links.dataset.id", "next.edition"}},
+			{Key: []string{"current.links.dataset.id", "current.edition"}},
+		},
+	},
+	{
+		collection: dimensionOptions,
+		indexes: []mgo.Index{
+			{Key: []string{"instance_id", "name", "option"}},
+			{Key: []string{"instance_id", "name", "order"}},
+		},
+	},
+}
+
+// ensureIndexes creates the indexes missing from each collection where create is set, in the background so that the
+// collections can still be read and written while they are built. Indexes that are missing once any have been created
+// are logged, and reported by the health check until they are found. It is run in a goroutine of its own, as building
+// the indexes of large collections can take minutes, with a copy of the session which it closes once it is done.
+func (m *Mongo) ensureIndexes(ctx context.Context, s *mgo.Session, create bool) {
+	defer s.Close()
+
+	missing, err := findMissingIndexes(s.DB(m.Database))
+	if err != nil {
+		log.Event(ctx, "failed to list the indexes of the mongo db collections", log.ERROR, log.Error(err))
+		return
+	}
+
+	if create {
+		for collection, indexes := range missing {
+			for _, index := range indexes {
+				index.Background = true
+				if err := s.DB(m.Database).C(collection).EnsureIndex(index); err != nil {
+					log.Event(ctx, "failed to create mongo db index", log.ERROR, log.Error(err), log.Data{
+						"collection": collection,
+						"index":      indexName(index),
+					})
+					continue
+				}
+				log.Event(ctx, "created mongo db index", log.INFO, log.Data{"collection": collection, "index": indexName(index)})
+			}
+		}
+
+		if missing, err = findMissingIndexes(s.DB(m.Database)); err != nil {
+			log.Event(ctx, "failed to list the indexes of the mongo db collections", log.ERROR, log.Error(err))
+			return
+		}
+	}
+
+	descriptions := describeIndexes(missing)
+	m.setMissingIndexes(descriptions)
+	if len(descriptions) > 0 {
+		log.Event(ctx, "mongo db indexes are missing", log.WARN, log.Data{"missing_indexes": descriptions})
+	}
+}
+
+// checkIndexes returns the indexes still missing from the collections. The collections are only listed while indexes
+// are missing, so that indexes added by hand since startup are found, without listing them on every health check.
+func (m *Mongo) checkIndexes(ctx context.Context) []string {
+	m.indexesMutex.Lock()
+	defer m.indexesMutex.Unlock()
+
+	if len(m.missingIndexes) == 0 {
+		return nil
+	}
+
+	s := m.Session.Copy()
+	defer s.Close()
+
+	missing, err := findMissingIndexes(s.DB(m.Database))
+	if err != nil {
+		log.Event(ctx, "failed to list the indexes of the mongo db collections", log.ERROR, log.Error(err))
+		return m.missingIndexes
+	}
+	m.missingIndexes = describeIndexes(missing)
+	return m.missingIndexes
+}
+
+func (m *Mongo) setMissingIndexes(missing []string) {
+	m.indexesMutex.Lock()
+	defer m.indexesMutex.Unlock()
+	m.missingIndexes = missing
+}
+
+// findMissingIndexes returns the declared indexes missing from each collection of the database
+func findMissingIndexes(db *mgo.Database) (map[string][]mgo.Index, error) {
+	missing := make(map[string][]mgo.Index)
+	for _, c := range collectionIndexes {
+		existing, err := db.C(c.collection).Indexes()
+		if err != nil {
+			if queryErr, ok := err.(*mgo.QueryError); !ok || queryErr.Code != namespaceNotFound {
+				return nil, err
+			}
+		}
+
+		if indexes := missingIndexes(existing, c.indexes); len(indexes) > 0 {
+			missing[c.collection] = indexes
+		}
+	}
+	return missing, nil
+}
+
+// missingIndexes returns the declared indexes whose keys do not match the keys of any existing index
+func missingIndexes(existing, declared []mgo.Index) []mgo.Index {
+	var missing []mgo.Index
+	for _, index := range declared {
+		found := false
+		for _, e := range existing {
+			if indexName(e) == indexName(index) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, index)
+		}
+	}
+	return missing
+}
+
+// describeIndexes lists the missing indexes in the order they are declared, named after their collection and keys
+func describeIndexes(missing map[string][]mgo.Index) []string {
+	var descriptions []string
+	for _, c := range collectionIndexes {
+		for _, index := range missing[c.collection] {
+			descriptions = append(descriptions, fmt.Sprintf("%s.%s", c.collection, indexName(index)))
+		}
+	}
+	return descriptions
+}
+
+// indexName names the index after its keys, in the form mongo names indexes by default (e.g. state_1_last_updated_-1)
+func indexName(index mgo.Index) string {
+	parts := make([]string, 0, len(index.Key))
+	for _, key := range index.Key {
+		if strings.HasPrefix(key, "-") {
+			parts = append(parts, key[1:]+"_-1")
+		} else {
+			parts = append(parts, key+"_1")
+		}
+	}
+	return strings.Join(parts, "_")
+}
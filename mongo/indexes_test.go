@@ -0,0 +1,86 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/globalsign/mgo"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMissingIndexes(t *testing.T) {
+	declared := []mgo.Index{
+		{Key: []string{"id"}},
+		{Key: []string{"links.dataset.id", "edition", "version"}},
+		{Key: []string{"state", "-last_updated"}},
+	}
+
+	Convey("Given a collection with only its _id index", t, func() {
+		existing := []mgo.Index{{Name: "_id_", Key: []string{"_id"}}}
+
+		Convey("Then every declared index is missing", func() {
+			So(missingIndexes(existing, declared), ShouldResemble, declared)
+		})
+	})
+
+	Convey("Given a collection with some of the declared indexes, added by hand under other names", t, func() {
+		existing := []mgo.Index{
+			{Name: "_id_", Key: []string{"_id"}},
+			{Name: "by_id", Key: []string{"id"}},
+			{Name: "by_state", Key: []string{"state", "-last_updated"}},
+		}
+
+		Convey("Then only the indexes whose keys do not match are missing", func() {
+			So(missingIndexes(existing, declared), ShouldResemble, []mgo.Index{declared[1]})
+		})
+	})
+
+	Convey("Given a collection with an index on the same fields in another order or direction", t, func() {
+		existing := []mgo.Index{
+			{Key: []string{"edition", "links.dataset.id", "version"}},
+			{Key: []string{"state", "last_updated"}},
+		}
+
+		Convey("Then the declared indexes are missing", func() {
+			So(missingIndexes(existing, declared), ShouldHaveLength, 3)
+		})
+	})
+
+	Convey("Given a collection with every declared index", t, func() {
+		Convey("Then no indexes are missing", func() {
+			So(missingIndexes(declared, declared), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestDescribeIndexes(t *testing.T) {
+	Convey("Given indexes missing from several collections", t, func() {
+		missing := map[string][]mgo.Index{
+			dimensionOptions:   {{Key: []string{"instance_id", "name", "option"}}},
+			instanceCollection: {{Key: []string{"state", "-last_updated"}}},
+			datasetsCollection: {{Key: []string{"next.state", "next.deletion.deleted_at"}}},
+		}
+
+		Convey("Then they are described by collection and keys, in the order they are declared", func() {
+			So(describeIndexes(missing), ShouldResemble, []string{
+				"datasets.next.state_1_next.deletion.deleted_at_1",
+				"instances.state_1_last_updated_-1",
+				"dimension.options.instance_id_1_name_1_option_1",
+			})
+		})
+	})
+
+	Convey("Given no missing indexes, then none are described", t, func() {
+		So(describeIndexes(map[string][]mgo.Index{}), ShouldBeEmpty)
+	})
+}
+
+func TestCheckIndexes(t *testing.T) {
+	Convey("Given that no indexes were missing on startup", t, func() {
+		m := &Mongo{}
+
+		Convey("Then the collections are not listed again, and no indexes are reported missing", func() {
+			So(m.checkIndexes(context.Background()), ShouldBeEmpty)
+		})
+	})
+}
@@ -20,25 +20,16 @@ func (m *Mongo) AcquireInstanceLock(ctx context.Context, instanceID string) (loc
 }
 
 // UnlockInstance releases an exclusive mongoDB lock for the provided lockId (if it exists)
-func (m *Mongo) UnlockInstance(lockID string) error {
+func (m *Mongo) UnlockInstance(ctx context.Context, lockID string) error {
 	return m.lockClient.Unlock(lockID)
 }
 
 // GetInstances from a mongo collection
 func (m *Mongo) GetInstances(ctx context.Context, states []string, datasets []string, offset, limit int) ([]*models.Instance, int, error) {
-	s := m.Session.Copy()
+	s := m.sessionFor("GetInstances")
 	defer s.Close()
 
-	selector := bson.M{}
-	if len(states) > 0 {
-		selector["state"] = bson.M{"$in": states}
-	}
-
-	if len(datasets) > 0 {
-		selector["links.dataset.id"] = bson.M{"$in": datasets}
-	}
-
-	q := s.DB(m.Database).C(instanceCollection).Find(selector).Sort("-last_updated")
+	q := s.DB(m.Database).C(instanceCollection).Find(instancesSelector(states, datasets)).Sort("-last_updated")
 
 	// get total count and paginated values according to provided offset and limit
 	results := []*models.Instance{}
@@ -53,14 +44,31 @@ func (m *Mongo) GetInstances(ctx context.Context, states []string, datasets []st
 	return results, totalCount, nil
 }
 
+// instancesSelector selects the instances in any of the states, of any of the datasets, where provided. Deleted
+// instances are never selected, even when the deleted state is provided.
+func instancesSelector(states, datasets []string) bson.M {
+	selector := bson.M{"state": notDeleted}
+	if len(states) > 0 {
+		selector = bson.M{"$and": []bson.M{
+			{"state": notDeleted},
+			{"state": bson.M{"$in": states}},
+		}}
+	}
+
+	if len(datasets) > 0 {
+		selector["links.dataset.id"] = bson.M{"$in": datasets}
+	}
+	return selector
+}
+
 // GetInstance returns a single instance from an ID
-func (m *Mongo) GetInstance(ID, eTagSelector string) (*models.Instance, error) {
-	s := m.Session.Copy()
+func (m *Mongo) GetInstance(ctx context.Context, ID, eTagSelector string) (*models.Instance, error) {
+	s := m.sessionFor("GetInstance")
 	defer s.Close()
 
 	// get instance from DB
 	var instance models.Instance
-	if err := s.DB(m.Database).C(instanceCollection).Find(bson.M{"id": ID}).One(&instance); err != nil {
+	if err := s.DB(m.Database).C(instanceCollection).Find(bson.M{"id": ID, "state": notDeleted}).One(&instance); err != nil {
 		if err == mgo.ErrNotFound {
 			return nil, errs.ErrInstanceNotFound
 		}
@@ -76,8 +84,8 @@ func (m *Mongo) GetInstance(ID, eTagSelector string) (*models.Instance, error) {
 }
 
 // AddInstance to the instance collection
-func (m *Mongo) AddInstance(instance *models.Instance) (*models.Instance, error) {
-	s := m.Session.Copy()
+func (m *Mongo) AddInstance(ctx context.Context, instance *models.Instance) (*models.Instance, error) {
+	s := m.sessionFor("AddInstance")
 	defer s.Close()
 
 	// Initialise with timestamp
@@ -103,7 +111,7 @@ func (m *Mongo) AddInstance(instance *models.Instance) (*models.Instance, error)
 
 // UpdateInstance with new properties
 func (m *Mongo) UpdateInstance(ctx context.Context, currentInstance, updatedInstance *models.Instance, eTagSelector string) (newETag string, err error) {
-	s := m.Session.Copy()
+	s := m.sessionFor("UpdateInstance")
 	defer s.Close()
 
 	// set lastUpdate value to now
@@ -273,8 +281,8 @@ func createInstanceUpdateQuery(ctx context.Context, instanceID string, instance
 }
 
 // AddEventToInstance to the instance collection
-func (m *Mongo) AddEventToInstance(currentInstance *models.Instance, event *models.Event, eTagSelector string) (newETag string, err error) {
-	s := m.Session.Copy()
+func (m *Mongo) AddEventToInstance(ctx context.Context, currentInstance *models.Instance, event *models.Event, eTagSelector string) (newETag string, err error) {
+	s := m.sessionFor("AddEventToInstance")
 	defer s.Close()
 
 	// calculate the new eTag hash for the instance that would result from adding the event
@@ -307,8 +315,8 @@ func (m *Mongo) AddEventToInstance(currentInstance *models.Instance, event *mode
 }
 
 // UpdateObservationInserted by incrementing the stored value
-func (m *Mongo) UpdateObservationInserted(currentInstance *models.Instance, observationInserted int64, eTagSelector string) (newETag string, err error) {
-	s := m.Session.Copy()
+func (m *Mongo) UpdateObservationInserted(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (newETag string, err error) {
+	s := m.sessionFor("UpdateObservationInserted")
 	defer s.Close()
 
 	// calculate the new eTag hash for the instance that would result from inceasing the observations
@@ -340,8 +348,8 @@ func (m *Mongo) UpdateObservationInserted(currentInstance *models.Instance, obse
 }
 
 // UpdateImportObservationsTaskState to the given state.
-func (m *Mongo) UpdateImportObservationsTaskState(currentInstance *models.Instance, state, eTagSelector string) (newETag string, err error) {
-	s := m.Session.Copy()
+func (m *Mongo) UpdateImportObservationsTaskState(ctx context.Context, currentInstance *models.Instance, state, eTagSelector string) (newETag string, err error) {
+	s := m.sessionFor("UpdateImportObservationsTaskState")
 	defer s.Close()
 
 	// calculate the new eTag hash for the instance that would result from inceasing the observations
@@ -374,8 +382,8 @@ func (m *Mongo) UpdateImportObservationsTaskState(currentInstance *models.Instan
 }
 
 // UpdateBuildHierarchyTaskState updates the state of a build hierarchy task.
-func (m *Mongo) UpdateBuildHierarchyTaskState(currentInstance *models.Instance, dimension, state, eTagSelector string) (newETag string, err error) {
-	s := m.Session.Copy()
+func (m *Mongo) UpdateBuildHierarchyTaskState(ctx context.Context, currentInstance *models.Instance, dimension, state, eTagSelector string) (newETag string, err error) {
+	s := m.sessionFor("UpdateBuildHierarchyTaskState")
 	defer s.Close()
 
 	// calculate the new eTag hash for the instance that would result from inceasing the observations
@@ -403,8 +411,8 @@ func (m *Mongo) UpdateBuildHierarchyTaskState(currentInstance *models.Instance,
 }
 
 // UpdateBuildSearchTaskState updates the state of a build search task.
-func (m *Mongo) UpdateBuildSearchTaskState(currentInstance *models.Instance, dimension, state, eTagSelector string) (newETag string, err error) {
-	s := m.Session.Copy()
+func (m *Mongo) UpdateBuildSearchTaskState(ctx context.Context, currentInstance *models.Instance, dimension, state, eTagSelector string) (newETag string, err error) {
+	s := m.sessionFor("UpdateBuildSearchTaskState")
 	defer s.Close()
 
 	// calculate the new eTag hash for the instance that would result from inceasing the observations
@@ -431,8 +439,45 @@ func (m *Mongo) UpdateBuildSearchTaskState(currentInstance *models.Instance, dim
 	return newETag, nil
 }
 
-func (m *Mongo) UpdateETagForNodeIDAndOrder(currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (newETag string, err error) {
-	s := m.Session.Copy()
+// RetryImportTasks replaces the import tasks of an instance with the provided tasks, moves the instance to the provided
+// state and records the provided event against it. Retries move the instance back to the submitted state with its tasks
+// reset, and retries that could not be sent move it back to its previous state and tasks.
+func (m *Mongo) RetryImportTasks(ctx context.Context, currentInstance *models.Instance, state string, tasks *models.InstanceImportTasks, event *models.Event, eTagSelector string) (newETag string, err error) {
+	s := m.sessionFor("RetryImportTasks")
+	defer s.Close()
+
+	// calculate the new eTag hash for the instance that would result from resetting the tasks and adding the event
+	newETag, err = newETagForImportRetry(currentInstance, tasks, event)
+	if err != nil {
+		return "", err
+	}
+
+	sel := selector(currentInstance.InstanceID, 0, eTagSelector)
+
+	update := bson.M{
+		"$push": bson.M{"events": event},
+		"$set": bson.M{
+			"import_tasks": tasks,
+			"state":        state,
+			"e_tag":        newETag,
+		},
+		"$currentDate": bson.M{"last_updated": true},
+	}
+
+	err = s.DB(m.Database).C(instanceCollection).Update(sel, update)
+	if err == mgo.ErrNotFound {
+		return "", errs.ErrInstanceNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return newETag, nil
+}
+
+func (m *Mongo) UpdateETagForNodeIDAndOrder(ctx context.Context, currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (newETag string, err error) {
+	s := m.sessionFor("UpdateETagForNodeIDAndOrder")
 	defer s.Close()
 
 	// calculate the new eTag hash by calculating the hash of the current instance plus the provided nodeID and order
@@ -458,8 +503,8 @@ func (m *Mongo) UpdateETagForNodeIDAndOrder(currentInstance *models.Instance, no
 }
 
 // UpdateETagForOptions updates the eTag value for an instance according to the provided dimension options
-func (m *Mongo) UpdateETagForOptions(currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (newETag string, err error) {
-	s := m.Session.Copy()
+func (m *Mongo) UpdateETagForOptions(ctx context.Context, currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (newETag string, err error) {
+	s := m.sessionFor("UpdateETagForOptions")
 	defer s.Close()
 
 	// calculate the new eTag hash by calculating the hash of the current instance plus the provided option
@@ -498,3 +543,32 @@ func selector(instanceID string, timestamp bson.MongoTimestamp, eTagSelector str
 	}
 	return selector
 }
+
+// GetDatasetInstances returns the id, state and edition of every instance, including those that are versions, linked to the dataset
+func (m *Mongo) GetDatasetInstances(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+	s := m.sessionFor("GetDatasetInstances")
+	defer s.Close()
+
+	results := []*models.Instance{}
+	err := s.DB(m.Database).C(instanceCollection).
+		Find(bson.M{"links.dataset.id": datasetID}).
+		Select(bson.M{"id": 1, "state": 1, "edition": 1}).
+		All(&results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// DeleteInstance removes an instance document. Instances that have already been removed are ignored.
+func (m *Mongo) DeleteInstance(ctx context.Context, instanceID string) error {
+	s := m.sessionFor("DeleteInstance")
+	defer s.Close()
+
+	if err := s.DB(m.Database).C(instanceCollection).Remove(bson.M{"id": instanceID}); err != nil && err != mgo.ErrNotFound {
+		return err
+	}
+
+	return nil
+}
@@ -29,3 +29,20 @@ func TestSelector(t *testing.T) {
 		})
 	})
 }
+
+func TestInstancesSelector(t *testing.T) {
+
+	Convey("Given no states or datasets, then every instance that has not been deleted is selected", t, func() {
+		So(instancesSelector(nil, nil), ShouldResemble, bson.M{"state": notDeleted})
+	})
+
+	Convey("Given states and datasets, then the instances in the states, of the datasets, that have not been deleted are selected", t, func() {
+		So(instancesSelector([]string{"completed", "deleted"}, []string{"123"}), ShouldResemble, bson.M{
+			"$and": []bson.M{
+				{"state": notDeleted},
+				{"state": bson.M{"$in": []string{"completed", "deleted"}}},
+			},
+			"links.dataset.id": bson.M{"$in": []string{"123"}},
+		})
+	})
+}
@@ -0,0 +1,83 @@
+package mongo
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ONSdigital/dp-dataset-api/url"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/globalsign/mgo/bson"
+	"github.com/pkg/errors"
+)
+
+// MigrateLinks strips the url of the api from the links to the api stored in the datasets, editions, versions,
+// instances, delete jobs and topics, so that they are rendered against the url the api is requested from. Only the
+// changed links are updated, so the migration can be run more than once. It returns the number of updated documents.
+func (m *Mongo) MigrateLinks(ctx context.Context, apiURL string) (int, error) {
+	s := m.Session.Copy()
+	defer s.Close()
+
+	migrated := 0
+	for _, collection := range []string{m.Collection, editionsCollection, instanceCollection, deleteJobsCollection, topicsCollection} {
+		c := s.DB(m.Database).C(collection)
+		iter := c.Find(nil).Iter()
+		updated := 0
+
+		doc := bson.M{}
+		for iter.Next(&doc) {
+			updates := bson.M{}
+			relativeLinkUpdates("", doc, apiURL, updates)
+
+			if len(updates) > 0 {
+				if err := c.UpdateId(doc["_id"], bson.M{"$set": updates}); err != nil {
+					iter.Close()
+					return migrated + updated, errors.Wrapf(err, "failed to migrate the links of a document in %s", collection)
+				}
+				updated++
+			}
+			doc = bson.M{}
+		}
+
+		if err := iter.Close(); err != nil {
+			return migrated + updated, errors.Wrapf(err, "failed to read the documents in %s", collection)
+		}
+		log.Event(ctx, "migrated links to paths", log.INFO, log.Data{"collection": collection, "updated": updated})
+		migrated += updated
+	}
+
+	return migrated, nil
+}
+
+// relativeLinkUpdates adds the dotted path of every href in the value that links to the api at its url to the
+// updates, mapped to the path of the link
+func relativeLinkUpdates(path string, value interface{}, apiURL string, updates bson.M) {
+	switch v := value.(type) {
+	case bson.M:
+		relativeMapLinkUpdates(path, v, apiURL, updates)
+	case map[string]interface{}:
+		relativeMapLinkUpdates(path, v, apiURL, updates)
+	case []interface{}:
+		for i, item := range v {
+			relativeLinkUpdates(joinPath(path, strconv.Itoa(i)), item, apiURL, updates)
+		}
+	}
+}
+
+func relativeMapLinkUpdates(path string, doc map[string]interface{}, apiURL string, updates bson.M) {
+	for key, value := range doc {
+		if href, ok := value.(string); ok && key == "href" {
+			if relative := url.RelativeLink(href, apiURL); relative != href {
+				updates[joinPath(path, key)] = relative
+			}
+			continue
+		}
+		relativeLinkUpdates(joinPath(path, key), value, apiURL, updates)
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,40 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/globalsign/mgo/bson"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRelativeLinkUpdates(t *testing.T) {
+	t.Parallel()
+	Convey("Given a stored document with links to the api and to other services", t, func() {
+		doc := bson.M{
+			"_id": "123",
+			"next": bson.M{
+				"links": bson.M{
+					"self":      bson.M{"href": "http://localhost:22000/datasets/123"},
+					"editions":  bson.M{"href": "/datasets/123/editions"},
+					"code_list": map[string]interface{}{"href": "http://localhost:22400/code-lists/123"},
+				},
+				"related_datasets": []interface{}{
+					bson.M{"href": "https://www.ons.gov.uk/economy"},
+					bson.M{"href": "http://localhost:22000/datasets/mm23", "id": "mm23"},
+				},
+			},
+		}
+
+		Convey("When the updates making its links relative are built", func() {
+			updates := bson.M{}
+			relativeLinkUpdates("", doc, "http://localhost:22000", updates)
+
+			Convey("Then only the absolute links to the api are set to their paths", func() {
+				So(updates, ShouldResemble, bson.M{
+					"next.links.self.href":         "/datasets/123",
+					"next.related_datasets.1.href": "/datasets/mm23",
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,61 @@
+package mongo
+
+import (
+	"context"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+)
+
+// GetLinkReports retrieves the link reports of the datasets with broken links, ordered by dataset ID
+func (m *Mongo) GetLinkReports(ctx context.Context, offset, limit int) ([]*models.LinkReport, int, error) {
+	s := m.sessionFor("GetLinkReports")
+	defer s.Close()
+
+	q := s.DB(m.Database).C(linkReportsCollection).Find(bson.M{"broken_links": bson.M{"$gt": 0}}).Sort("_id")
+
+	values := []*models.LinkReport{}
+	totalCount, err := QueryPage(ctx, q, offset, limit, &values)
+	if err != nil {
+		return values, 0, err
+	}
+
+	return values, totalCount, nil
+}
+
+// GetLinkReport retrieves the link report of a dataset
+func (m *Mongo) GetLinkReport(ctx context.Context, datasetID string) (*models.LinkReport, error) {
+	s := m.sessionFor("GetLinkReport")
+	defer s.Close()
+
+	var report models.LinkReport
+	if err := s.DB(m.Database).C(linkReportsCollection).FindId(datasetID).One(&report); err != nil {
+		if err == mgo.ErrNotFound {
+			return nil, errs.ErrLinkReportNotFound
+		}
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// UpsertLinkReport adds or overrides the link report of a dataset
+func (m *Mongo) UpsertLinkReport(ctx context.Context, report *models.LinkReport) error {
+	s := m.sessionFor("UpsertLinkReport")
+	defer s.Close()
+
+	_, err := s.DB(m.Database).C(linkReportsCollection).UpsertId(report.DatasetID, report)
+	return err
+}
+
+// DeleteLinkReportsExcept removes the link reports of every dataset other than those provided, such as datasets that
+// are no longer published
+func (m *Mongo) DeleteLinkReportsExcept(ctx context.Context, datasetIDs []string) error {
+	s := m.sessionFor("DeleteLinkReportsExcept")
+	defer s.Close()
+
+	_, err := s.DB(m.Database).C(linkReportsCollection).RemoveAll(bson.M{"_id": bson.M{"$nin": datasetIDs}})
+	return err
+}
@@ -0,0 +1,89 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	uuid "github.com/satori/go.uuid"
+	lock "github.com/square/mongo-lock"
+)
+
+const (
+	purgeLockCollection     = "purge_locks"
+	purgeLockResource       = "deleted-datasets"
+	linkCheckLockCollection = "link_check_locks"
+	linkCheckLockResource   = "published-dataset-links"
+)
+
+// LockPurge takes the lock held while deleted datasets are purged, so that they are only purged by one instance at a
+// time, returning errs.ErrPurgeLocked if another instance holds it. The lock expires after the TTL unless renewed, so
+// that it is released by an instance that stops while purging.
+func (m *Mongo) LockPurge(ctx context.Context, ttl time.Duration) (lockID string, err error) {
+	return m.lock(purgeLockCollection, purgeLockResource, ttl, errs.ErrPurgeLocked)
+}
+
+// RenewPurgeLock extends the purge lock by the TTL. An error is returned if the lock has expired and been released.
+func (m *Mongo) RenewPurgeLock(ctx context.Context, lockID string, ttl time.Duration) error {
+	return m.renewLock(purgeLockCollection, lockID, ttl)
+}
+
+// UnlockPurge releases the purge lock
+func (m *Mongo) UnlockPurge(ctx context.Context, lockID string) error {
+	return m.unlock(purgeLockCollection, lockID)
+}
+
+// LockLinkCheck takes the lock held while the links of published datasets are checked, so that they are only checked
+// by one instance at a time, returning errs.ErrLinkCheckLocked if another instance holds it. The lock expires after the
+// TTL unless renewed, so that it is released by an instance that stops while checking.
+func (m *Mongo) LockLinkCheck(ctx context.Context, ttl time.Duration) (lockID string, err error) {
+	return m.lock(linkCheckLockCollection, linkCheckLockResource, ttl, errs.ErrLinkCheckLocked)
+}
+
+// RenewLinkCheckLock extends the link check lock by the TTL. An error is returned if the lock has expired and been
+// released.
+func (m *Mongo) RenewLinkCheckLock(ctx context.Context, lockID string, ttl time.Duration) error {
+	return m.renewLock(linkCheckLockCollection, lockID, ttl)
+}
+
+// UnlockLinkCheck releases the link check lock
+func (m *Mongo) UnlockLinkCheck(ctx context.Context, lockID string) error {
+	return m.unlock(linkCheckLockCollection, lockID)
+}
+
+// lock takes an exclusive lock on the resource, returning lockedErr if another instance holds it
+func (m *Mongo) lock(collection, resource string, ttl time.Duration, lockedErr error) (string, error) {
+	locks := lock.NewClient(m.Session, m.Database, collection)
+
+	// expired locks are only released by purging them
+	if _, err := lock.NewPurger(locks).Purge(); err != nil {
+		return "", err
+	}
+
+	lockID := uuid.NewV4().String()
+	if err := locks.XLock(resource, lockID, lock.LockDetails{TTL: lockTTL(ttl)}); err != nil {
+		if err == lock.ErrAlreadyLocked {
+			return "", lockedErr
+		}
+		return "", err
+	}
+	return lockID, nil
+}
+
+func (m *Mongo) renewLock(collection, lockID string, ttl time.Duration) error {
+	_, err := lock.NewClient(m.Session, m.Database, collection).Renew(lockID, lockTTL(ttl))
+	return err
+}
+
+func (m *Mongo) unlock(collection, lockID string) error {
+	_, err := lock.NewClient(m.Session, m.Database, collection).Unlock(lockID)
+	return err
+}
+
+// lockTTL returns the TTL of a lock in whole seconds, which must be at least 1 for the lock to be renewed
+func lockTTL(ttl time.Duration) uint {
+	if ttl < time.Second {
+		return 1
+	}
+	return uint(ttl / time.Second)
+}
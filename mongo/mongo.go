@@ -3,37 +3,66 @@ package mongo
 import (
 	"context"
 	"errors"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dpmongo "github.com/ONSdigital/dp-mongodb"
 	dpMongoLock "github.com/ONSdigital/dp-mongodb/dplock"
 	dpMongoHealth "github.com/ONSdigital/dp-mongodb/health"
 	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
 )
 
-// Mongo represents a simplistic MongoDB configuration.
+// Mongo represents a simplistic MongoDB configuration. The public reads of the store are made with the ReadPreference,
+// where one other than the primary is set, while the secondaries lag the primary by no more than the MaxStaleness.
+// The indexes the store relies on are created once Init returns where EnsureIndexes is set, and otherwise only reported.
 type Mongo struct {
-	CodeListURL    string
-	Collection     string
-	Database       string
-	DatasetURL     string
-	Session        *mgo.Session
-	URI            string
-	lastPingTime   time.Time
-	lastPingResult error
-	healthClient   *dpMongoHealth.CheckMongoClient
-	lockClient     *dpMongoLock.Lock
+	CodeListURL        string
+	Collection         string
+	Database           string
+	DatasetURL         string
+	Session            *mgo.Session
+	URI                string
+	ReadPreference     string
+	MaxStaleness       time.Duration
+	EnsureIndexes      bool
+	lastPingTime       time.Time
+	lastPingResult     error
+	healthClient       *dpMongoHealth.CheckMongoClient
+	lockClient         *dpMongoLock.Lock
+	readSession        *mgo.Session
+	secondariesStale   int32
+	lagUnknown         bool
+	stopStalenessCheck context.CancelFunc
+	indexesMutex       sync.Mutex
+	missingIndexes     []string
 }
 
 const (
+	datasetsCollection     = "datasets"
 	editionsCollection     = "editions"
 	instanceCollection     = "instances"
 	instanceLockCollection = "instances_locks"
 	dimensionOptions       = "dimension.options"
+	deleteJobsCollection   = "delete_jobs"
+	topicsCollection       = "topics"
+	linkReportsCollection  = "link_reports"
 )
 
-// Init creates a new mgo.Session with a strong consistency and a write mode of "majortiy"; and initialises the mongo health client.
+// notDeleted selects the documents whose state is not deleted. Deleted documents are excluded from every query
+// other than those used to restore and purge them.
+var notDeleted = bson.M{"$ne": models.DeletedState}
+
+// publishedVersion selects the versions that have been published, including those since withdrawn, which remain
+// readable and are flagged by their state
+var publishedVersion = bson.M{"$in": []string{models.PublishedState, models.WithdrawnState}}
+
+// Init creates a new mgo.Session with a strong consistency and a write mode of "majortiy", and a session for public
+// reads where a read preference is set; starts ensuring the indexes of the collections, without waiting for them to be
+// built; and initialises the mongo health client.
 func (m *Mongo) Init(ctx context.Context) (err error) {
 	if m.Session != nil {
 		return errors.New("session already exists")
@@ -46,6 +75,12 @@ func (m *Mongo) Init(ctx context.Context) (err error) {
 	m.Session.EnsureSafe(&mgo.Safe{WMode: "majority"})
 	m.Session.SetMode(mgo.Strong, true)
 
+	if err = m.initReadSession(ctx); err != nil {
+		return err
+	}
+
+	go m.ensureIndexes(ctx, m.Session.Copy(), m.EnsureIndexes)
+
 	databaseCollectionBuilder := make(map[dpMongoHealth.Database][]dpMongoHealth.Collection)
 	databaseCollectionBuilder[(dpMongoHealth.Database)(m.Database)] = []dpMongoHealth.Collection{(dpMongoHealth.Collection)(m.Collection), (dpMongoHealth.Collection)(editionsCollection), (dpMongoHealth.Collection)(instanceCollection), (dpMongoHealth.Collection)(instanceLockCollection), (dpMongoHealth.Collection)(dimensionOptions)}
 
@@ -66,10 +101,25 @@ func (m *Mongo) Close(ctx context.Context) error {
 	if m.Session == nil {
 		return errors.New("cannot close a mongoDB connection without a valid session")
 	}
+	if m.readSession != nil {
+		m.stopStalenessCheck()
+		m.readSession.Close()
+	}
 	return dpmongo.Close(ctx, m.Session)
 }
 
-// Checker is called by the healthcheck library to check the health state of this mongoDB instance
+// Checker is called by the healthcheck library to check the health state of this mongoDB instance. A healthy instance
+// is reported as a warning while indexes the store relies on are missing, as its queries are slow without them.
 func (m *Mongo) Checker(ctx context.Context, state *healthcheck.CheckState) error {
-	return m.healthClient.Checker(ctx, state)
+	if err := m.healthClient.Checker(ctx, state); err != nil {
+		return err
+	}
+	if state.Status() != healthcheck.StatusOK {
+		return nil
+	}
+
+	if missing := m.checkIndexes(ctx); len(missing) > 0 {
+		return state.Update(healthcheck.StatusWarning, "missing indexes: "+strings.Join(missing, ", "), 0)
+	}
+	return nil
 }
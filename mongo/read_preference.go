@@ -0,0 +1,197 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/metrics"
+	"github.com/ONSdigital/log.go/log"
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+)
+
+// The read preferences a session can be configured with, named as they are in mongo db connection strings
+const (
+	ReadPrimary            = "primary"
+	ReadPrimaryPreferred   = "primaryPreferred"
+	ReadSecondary          = "secondary"
+	ReadSecondaryPreferred = "secondaryPreferred"
+	ReadNearest            = "nearest"
+)
+
+var readModes = map[string]mgo.Mode{
+	ReadPrimary:            mgo.Primary,
+	ReadPrimaryPreferred:   mgo.PrimaryPreferred,
+	ReadSecondary:          mgo.Secondary,
+	ReadSecondaryPreferred: mgo.SecondaryPreferred,
+	ReadNearest:            mgo.Nearest,
+}
+
+// publicReads are the store methods which serve the public GET requests of web instances. They read using the read
+// preference of the store, where one is configured, while every other store method reads from the primary, so that
+// the documents it updates are never stale.
+var publicReads = map[string]bool{
+	"CheckDatasetExists":          true,
+	"CheckEditionExists":          true,
+	"CountTopicDatasets":          true,
+	"GetDataset":                  true,
+	"GetDatasets":                 true,
+	"GetDatasetsByIDs":            true,
+	"GetDatasetsRelatedTo":        true,
+	"GetDimensionOptions":         true,
+	"GetDimensionOptionsFromIDs":  true,
+	"GetDimensions":               true,
+	"GetEdition":                  true,
+	"GetEditions":                 true,
+	"GetPreviousPublishedVersion": true,
+	"GetTopic":                    true,
+	"GetTopicDatasets":            true,
+	"GetTopics":                   true,
+	"GetVersion":                  true,
+	"GetVersions":                 true,
+}
+
+// stalenessCheckInterval is how often the replication lag of the secondaries is checked
+const stalenessCheckInterval = 10 * time.Second
+
+// initReadSession creates the session public reads are made with, when a read preference other than the primary is
+// configured, and starts checking that the secondaries are within the maximum staleness allowed
+func (m *Mongo) initReadSession(ctx context.Context) error {
+	if m.ReadPreference == "" || m.ReadPreference == ReadPrimary {
+		return nil
+	}
+
+	mode, ok := readModes[m.ReadPreference]
+	if !ok {
+		return fmt.Errorf("unknown mongo db read preference %q", m.ReadPreference)
+	}
+
+	m.readSession = m.Session.Copy()
+	m.readSession.SetMode(mode, true)
+	// public reads are made from the primary until the lag of the secondaries is known
+	m.setSecondariesStale(true)
+
+	ctx, m.stopStalenessCheck = context.WithCancel(ctx)
+	go m.checkStaleness(ctx)
+	return nil
+}
+
+// sessionFor returns a copy of the session used by the store method, which the caller must close
+func (m *Mongo) sessionFor(operation string) *mgo.Session {
+	if m.readSession != nil && publicReads[operation] && atomic.LoadInt32(&m.secondariesStale) == 0 {
+		metrics.MongoSessions.WithLabelValues(operation, m.ReadPreference).Inc()
+		return m.readSession.Copy()
+	}
+	metrics.MongoSessions.WithLabelValues(operation, ReadPrimary).Inc()
+	return m.Session.Copy()
+}
+
+// checkStaleness checks the replication lag of the secondaries until the context is cancelled. The driver cannot
+// bound the staleness of the secondaries it reads from, so public reads are made from the primary while any secondary
+// lags it by more than the maximum staleness, or while the lag is unknown.
+func (m *Mongo) checkStaleness(ctx context.Context) {
+	ticker := time.NewTicker(stalenessCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		m.updateStaleness(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Mongo) updateStaleness(ctx context.Context) {
+	s := m.Session.Copy()
+	defer s.Close()
+
+	var status replSetStatus
+	if err := s.Run(bson.D{{Name: "replSetGetStatus", Value: 1}}, &status); err != nil {
+		if !m.lagUnknown {
+			log.Event(ctx, "failed to get the replication lag of the mongo db secondaries", log.WARN, log.Error(err), log.Data{
+				"standalone": isStandalone(err),
+			})
+		}
+		m.lagUnknown = true
+		// a standalone server has no secondaries, so every read is served by the primary anyway
+		m.setSecondariesStale(!isStandalone(err))
+		return
+	}
+	m.lagUnknown = false
+
+	stale := !status.secondaryWithin(m.MaxStaleness)
+	if stale != (atomic.LoadInt32(&m.secondariesStale) == 1) {
+		log.Event(ctx, "mongo db secondaries changed staleness", log.INFO, log.Data{
+			"stale":         stale,
+			"max_staleness": m.MaxStaleness.String(),
+		})
+	}
+	m.setSecondariesStale(stale)
+}
+
+func (m *Mongo) setSecondariesStale(stale bool) {
+	var value int32
+	if stale {
+		value = 1
+	}
+	atomic.StoreInt32(&m.secondariesStale, value)
+	metrics.MongoSecondariesStale.Set(float64(value))
+}
+
+// errNoReplicationEnabled is the code of the error returned by replSetGetStatus on a server which is not a member of a
+// replica set
+const errNoReplicationEnabled = 76
+
+// isStandalone reports whether the error of replSetGetStatus confirms the server is not a member of a replica set
+func isStandalone(err error) bool {
+	queryErr, ok := err.(*mgo.QueryError)
+	return ok && queryErr.Code == errNoReplicationEnabled
+}
+
+// The member states of a replica set reported by replSetGetStatus
+const (
+	memberPrimary   = 1
+	memberSecondary = 2
+)
+
+type replSetStatus struct {
+	Members []replSetMember `bson:"members"`
+}
+
+type replSetMember struct {
+	State      int       `bson:"state"`
+	Health     float64   `bson:"health"`
+	OptimeDate time.Time `bson:"optimeDate"`
+}
+
+// secondaryWithin reports whether every healthy secondary lags the primary by no more than the maximum staleness, as
+// the driver may read from any of them. A replica set whose primary is unknown, or which has no healthy secondaries,
+// has no bound on the staleness of its secondaries.
+func (status replSetStatus) secondaryWithin(maxStaleness time.Duration) bool {
+	var primary *replSetMember
+	for i, member := range status.Members {
+		if member.State == memberPrimary {
+			primary = &status.Members[i]
+		}
+	}
+	if primary == nil {
+		return false
+	}
+
+	secondaries := 0
+	for _, member := range status.Members {
+		if member.State != memberSecondary || member.Health != 1 {
+			continue
+		}
+		if primary.OptimeDate.Sub(member.OptimeDate) > maxStaleness {
+			return false
+		}
+		secondaries++
+	}
+	return secondaries > 0
+}
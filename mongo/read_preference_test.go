@@ -0,0 +1,92 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/globalsign/mgo"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSecondaryWithin(t *testing.T) {
+	now := time.Now()
+	maxStaleness := 90 * time.Second
+
+	Convey("Given a replica set with a secondary lagging the primary by less than the maximum staleness", t, func() {
+		status := replSetStatus{Members: []replSetMember{
+			{State: memberPrimary, Health: 1, OptimeDate: now},
+			{State: memberSecondary, Health: 1, OptimeDate: now.Add(-time.Second)},
+		}}
+
+		Convey("Then the secondaries are within the maximum staleness", func() {
+			So(status.secondaryWithin(maxStaleness), ShouldBeTrue)
+		})
+
+		Convey("When the secondary is unhealthy, then the secondaries are stale", func() {
+			status.Members[1].Health = 0
+			So(status.secondaryWithin(maxStaleness), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a replica set with one secondary within the maximum staleness and one lagging by more", t, func() {
+		status := replSetStatus{Members: []replSetMember{
+			{State: memberPrimary, Health: 1, OptimeDate: now},
+			{State: memberSecondary, Health: 1, OptimeDate: now.Add(-5 * time.Minute)},
+			{State: memberSecondary, Health: 1, OptimeDate: now.Add(-time.Second)},
+		}}
+
+		Convey("Then the secondaries are stale, as either could be read from", func() {
+			So(status.secondaryWithin(maxStaleness), ShouldBeFalse)
+		})
+
+		Convey("When the lagging secondary is unhealthy, then the secondaries are within the maximum staleness", func() {
+			status.Members[1].Health = 0
+			So(status.secondaryWithin(maxStaleness), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given a replica set whose secondaries all lag by more than the maximum staleness", t, func() {
+		status := replSetStatus{Members: []replSetMember{
+			{State: memberSecondary, Health: 1, OptimeDate: now.Add(-2 * time.Minute)},
+			{State: memberPrimary, Health: 1, OptimeDate: now},
+		}}
+
+		Convey("Then the secondaries are stale", func() {
+			So(status.secondaryWithin(maxStaleness), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a replica set without a primary, then the secondaries are stale", t, func() {
+		status := replSetStatus{Members: []replSetMember{
+			{State: memberSecondary, Health: 1, OptimeDate: now},
+		}}
+		So(status.secondaryWithin(maxStaleness), ShouldBeFalse)
+	})
+}
+
+func TestIsStandalone(t *testing.T) {
+	Convey("When replSetGetStatus fails as replication is not enabled, then the server is standalone", t, func() {
+		So(isStandalone(&mgo.QueryError{Code: errNoReplicationEnabled, Message: "not running with --replSet"}), ShouldBeTrue)
+	})
+
+	Convey("When replSetGetStatus fails for any other reason, then the server is not known to be standalone", t, func() {
+		So(isStandalone(&mgo.QueryError{Code: 13, Message: "not authorized"}), ShouldBeFalse)
+		So(isStandalone(errors.New("no reachable servers")), ShouldBeFalse)
+	})
+}
+
+func TestInitReadSession(t *testing.T) {
+	Convey("When reads are made from the primary, then no read session is created", t, func() {
+		m := &Mongo{ReadPreference: ReadPrimary}
+		So(m.initReadSession(context.Background()), ShouldBeNil)
+		So(m.readSession, ShouldBeNil)
+	})
+
+	Convey("When the read preference is unknown, then an error is returned", t, func() {
+		m := &Mongo{ReadPreference: "tertiary"}
+		So(m.initReadSession(context.Background()), ShouldNotBeNil)
+		So(m.readSession, ShouldBeNil)
+	})
+}
@@ -0,0 +1,114 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/globalsign/mgo"
+	"github.com/globalsign/mgo/bson"
+)
+
+// GetTopics retrieves every topic of the taxonomy, ordered by ID
+func (m *Mongo) GetTopics(ctx context.Context) ([]*models.Topic, error) {
+	s := m.sessionFor("GetTopics")
+	defer s.Close()
+
+	topics := []*models.Topic{}
+	if err := s.DB(m.Database).C(topicsCollection).Find(nil).Sort("_id").All(&topics); err != nil {
+		return nil, err
+	}
+
+	return topics, nil
+}
+
+// GetTopicsPage retrieves the page of topics defined by the offset and limit, ordered by ID, along with the total
+// count of topics
+func (m *Mongo) GetTopicsPage(ctx context.Context, offset, limit int) ([]*models.Topic, int, error) {
+	s := m.sessionFor("GetTopicsPage")
+	defer s.Close()
+
+	q := s.DB(m.Database).C(topicsCollection).Find(nil).Sort("_id")
+
+	topics := []*models.Topic{}
+	totalCount, err := QueryPage(ctx, q, offset, limit, &topics)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return topics, totalCount, nil
+}
+
+// GetTopic retrieves a topic document
+func (m *Mongo) GetTopic(ctx context.Context, id string) (*models.Topic, error) {
+	s := m.sessionFor("GetTopic")
+	defer s.Close()
+
+	var topic models.Topic
+	if err := s.DB(m.Database).C(topicsCollection).FindId(id).One(&topic); err != nil {
+		if err == mgo.ErrNotFound {
+			return nil, errs.ErrTopicNotFound
+		}
+		return nil, err
+	}
+
+	return &topic, nil
+}
+
+// UpsertTopic adds or overrides an existing topic document
+func (m *Mongo) UpsertTopic(ctx context.Context, topic *models.Topic) error {
+	s := m.sessionFor("UpsertTopic")
+	defer s.Close()
+
+	topic.LastUpdated = time.Now()
+	_, err := s.DB(m.Database).C(topicsCollection).UpsertId(topic.ID, topic)
+	return err
+}
+
+// DeleteTopic removes a topic document
+func (m *Mongo) DeleteTopic(ctx context.Context, id string) error {
+	s := m.sessionFor("DeleteTopic")
+	defer s.Close()
+
+	if err := s.DB(m.Database).C(topicsCollection).RemoveId(id); err != nil {
+		if err == mgo.ErrNotFound {
+			return errs.ErrTopicNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// CountTopicDatasets returns the number of datasets whose published or unpublished theme is the topic
+func (m *Mongo) CountTopicDatasets(ctx context.Context, id string) (int, error) {
+	s := m.sessionFor("CountTopicDatasets")
+	defer s.Close()
+
+	selector := bson.M{
+		"$or":        []bson.M{{"current.theme": id}, {"next.theme": id}},
+		"next.state": notDeleted,
+	}
+	return s.DB(m.Database).C("datasets").Find(selector).Count()
+}
+
+// GetTopicDatasets retrieves the published datasets whose theme is one of the provided topics
+func (m *Mongo) GetTopicDatasets(ctx context.Context, topicIDs []string, offset, limit int) ([]*models.DatasetUpdate, int, error) {
+	s := m.sessionFor("GetTopicDatasets")
+	defer s.Close()
+
+	selector := bson.M{
+		"current.theme": bson.M{"$in": topicIDs},
+		"next.state":    notDeleted,
+	}
+	q := s.DB(m.Database).C("datasets").Find(selector).Sort("_id")
+
+	values := []*models.DatasetUpdate{}
+	totalCount, err := QueryPage(ctx, q, offset, limit, &values)
+	if err != nil {
+		return values, 0, err
+	}
+
+	return values, totalCount, nil
+}
@@ -2,11 +2,12 @@ package pagination
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
 	"reflect"
 	"strconv"
 
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/utils"
 	"github.com/ONSdigital/log.go/log"
 )
 
@@ -25,6 +26,8 @@ type Paginator struct {
 	DefaultLimit    int
 	DefaultOffset   int
 	DefaultMaxLimit int
+	// RenderLinks renders the links held by each page of items in the response to the request, if it is set
+	RenderLinks func(r *http.Request, items interface{})
 }
 
 func NewPaginator(defaultLimit, defaultOffset, defaultMaxLimit int) *Paginator {
@@ -49,7 +52,7 @@ func (p *Paginator) getPaginationParameters(w http.ResponseWriter, r *http.Reque
 		logData["offset"] = offsetParameter
 		offset, err = strconv.Atoi(offsetParameter)
 		if err != nil || offset < 0 {
-			err = errors.New("invalid query parameter")
+			err = errs.ErrInvalidQueryParameter
 			log.Event(r.Context(), "invalid query parameter: offset", log.ERROR, log.Error(err), logData)
 			return 0, 0, err
 		}
@@ -59,7 +62,7 @@ func (p *Paginator) getPaginationParameters(w http.ResponseWriter, r *http.Reque
 		logData["limit"] = limitParameter
 		limit, err = strconv.Atoi(limitParameter)
 		if err != nil || limit < 0 {
-			err = errors.New("invalid query parameter")
+			err = errs.ErrInvalidQueryParameter
 			log.Event(r.Context(), "invalid query parameter: limit", log.ERROR, log.Error(err), logData)
 			return 0, 0, err
 		}
@@ -67,7 +70,7 @@ func (p *Paginator) getPaginationParameters(w http.ResponseWriter, r *http.Reque
 
 	if limit > p.DefaultMaxLimit {
 		logData["max_limit"] = p.DefaultMaxLimit
-		err = errors.New("invalid query parameter")
+		err = errs.ErrInvalidQueryParameter
 		log.Event(r.Context(), "limit is greater than the maximum allowed", log.ERROR, logData)
 		return 0, 0, err
 	}
@@ -96,7 +99,7 @@ func (p *Paginator) Paginate(paginatedHandler PaginatedHandler) func(w http.Resp
 	return func(w http.ResponseWriter, r *http.Request) {
 		offset, limit, err := p.getPaginationParameters(w, r)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			utils.WriteError(r.Context(), w, err, http.StatusBadRequest)
 			return
 		}
 		list, totalCount, err := paginatedHandler(w, r, limit, offset)
@@ -104,6 +107,10 @@ func (p *Paginator) Paginate(paginatedHandler PaginatedHandler) func(w http.Resp
 			return
 		}
 
+		if p.RenderLinks != nil {
+			p.RenderLinks(r, list)
+		}
+
 		page := renderPage(list, offset, limit, totalCount)
 
 		returnPaginatedResults(w, r, page)
@@ -118,7 +125,7 @@ func returnPaginatedResults(w http.ResponseWriter, r *http.Request, list page) {
 
 	if err != nil {
 		log.Event(r.Context(), "api endpoint failed to marshal resource into bytes", log.ERROR, log.Error(err), logData)
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		utils.WriteError(r.Context(), w, errs.ErrInternalServer, http.StatusInternalServerError)
 		return
 	}
 
@@ -126,7 +133,7 @@ func returnPaginatedResults(w http.ResponseWriter, r *http.Request, list page) {
 
 	if _, err = w.Write(b); err != nil {
 		log.Event(r.Context(), "api endpoint error writing response body", log.ERROR, log.Error(err), logData)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		utils.WriteError(r.Context(), w, errs.ErrInternalServer, http.StatusInternalServerError)
 		return
 	}
 	log.Event(r.Context(), "api endpoint request successful", log.INFO, logData)
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/ONSdigital/dp-dataset-api/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -164,7 +165,8 @@ func TestReturnPaginatedResultsReturnsErrorIfCanNotMarshalJSON(t *testing.T) {
 	returnPaginatedResults(w, r, inputPage)
 	content, _ := ioutil.ReadAll(w.Body)
 
-	assert.Equal(t, "internal error\n", string(content))
+	assert.Contains(t, string(content), `"code":"internal_error"`)
+	assert.Equal(t, utils.ProblemContentType, w.Header().Get("Content-Type"))
 	assert.Equal(t, 500, w.Code)
 }
 
@@ -214,7 +216,7 @@ func TestPaginateFunctionReturnsBadRequestWhenInvalidQueryParametersAreGiven(t *
 	paginatedHandler(w, r)
 	content, _ := ioutil.ReadAll(w.Body)
 	assert.Equal(t, 400, w.Code)
-	assert.Equal(t, "invalid query parameter\n", string(content))
+	assert.Contains(t, string(content), `"code":"invalid_query_parameter"`)
 }
 
 func TestPaginateFunctionReturnsListFuncImplementedHttpErrorIfListFuncReturnsAnError(t *testing.T) {
@@ -10,7 +10,8 @@ var generateDownloads = `{
     {"name": "instance_id", "type": "string", "default": ""},
     {"name": "dataset_id", "type": "string", "default": ""},
     {"name": "edition", "type": "string", "default": ""},
-    {"name": "version", "type": "string", "default": ""}
+    {"name": "version", "type": "string", "default": ""},
+    {"name": "traceparent", "type": "string", "default": ""}
   ]
 }`
 
@@ -18,3 +19,38 @@ var generateDownloads = `{
 var GenerateDownloadsEvent = &avro.Schema{
 	Definition: generateDownloads,
 }
+
+var importRetry = `{
+  "type": "record",
+  "name": "instance-import-retry",
+  "fields": [
+    {"name": "instance_id", "type": "string", "default": ""},
+    {"name": "dataset_id", "type": "string", "default": ""},
+    {"name": "dimensions", "type": {"type": "array", "items": "string"}},
+    {"name": "import_observations", "type": "boolean", "default": false},
+    {"name": "traceparent", "type": "string", "default": ""}
+  ]
+}`
+
+// ImportRetryEvent the Avro schema for InstanceImportRetry messages.
+var ImportRetryEvent = &avro.Schema{
+	Definition: importRetry,
+}
+
+var datasetEvent = `{
+  "type": "record",
+  "name": "dataset-event",
+  "fields": [
+    {"name": "type", "type": "string", "default": ""},
+    {"name": "dataset_id", "type": "string", "default": ""},
+    {"name": "edition", "type": "string", "default": ""},
+    {"name": "version", "type": "string", "default": ""},
+    {"name": "instance_id", "type": "string", "default": ""},
+    {"name": "traceparent", "type": "string", "default": ""}
+  ]
+}`
+
+// DatasetEvent the Avro schema for DatasetEvent messages.
+var DatasetEvent = &avro.Schema{
+	Definition: datasetEvent,
+}
@@ -5,18 +5,25 @@ import (
 	"net/http"
 
 	"github.com/ONSdigital/dp-dataset-api/config"
+	"github.com/ONSdigital/dp-dataset-api/graph"
 	"github.com/ONSdigital/dp-dataset-api/mongo"
 	"github.com/ONSdigital/dp-dataset-api/store"
-	"github.com/ONSdigital/dp-graph/v2/graph"
+	dpgraph "github.com/ONSdigital/dp-graph/v2/graph"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	kafka "github.com/ONSdigital/dp-kafka/v2"
 	dphttp "github.com/ONSdigital/dp-net/http"
 	"github.com/ONSdigital/log.go/log"
+	"github.com/pkg/errors"
 )
 
+var errReplicaIDEmpty = errors.New("RESPONSE_CACHE_REPLICA_ID must be set to consume dataset events in a consumer group of its own")
+
 // ExternalServiceList holds the initialiser and initialisation state of external services.
 type ExternalServiceList struct {
 	GenerateDownloadsProducer bool
+	ImportRetryProducer       bool
+	DatasetEventsProducer     bool
+	DatasetEventsConsumer     bool
 	Graph                     bool
 	HealthCheck               bool
 	MongoDB                   bool
@@ -51,7 +58,7 @@ func (e *ExternalServiceList) GetHealthCheck(cfg *config.Configuration, buildTim
 
 // GetProducer returns a kafka producer, which might not be initialised yet.
 func (e *ExternalServiceList) GetProducer(ctx context.Context, cfg *config.Configuration) (kafkaProducer kafka.IProducer, err error) {
-	kafkaProducer, err = e.Init.DoGetKafkaProducer(ctx, cfg)
+	kafkaProducer, err = e.Init.DoGetKafkaProducer(ctx, cfg, cfg.GenerateDownloadsTopic)
 	if err != nil {
 		return
 	}
@@ -59,6 +66,43 @@ func (e *ExternalServiceList) GetProducer(ctx context.Context, cfg *config.Confi
 	return
 }
 
+// GetImportRetryProducer returns a kafka producer for import retry events, which might not be initialised yet.
+func (e *ExternalServiceList) GetImportRetryProducer(ctx context.Context, cfg *config.Configuration) (kafkaProducer kafka.IProducer, err error) {
+	kafkaProducer, err = e.Init.DoGetKafkaProducer(ctx, cfg, cfg.ImportRetryTopic)
+	if err != nil {
+		return
+	}
+	e.ImportRetryProducer = true
+	return
+}
+
+// GetDatasetEventsProducer returns a kafka producer for dataset events, which might not be initialised yet.
+func (e *ExternalServiceList) GetDatasetEventsProducer(ctx context.Context, cfg *config.Configuration) (kafkaProducer kafka.IProducer, err error) {
+	kafkaProducer, err = e.Init.DoGetKafkaProducer(ctx, cfg, cfg.DatasetEventsTopic)
+	if err != nil {
+		return
+	}
+	e.DatasetEventsProducer = true
+	return
+}
+
+// GetDatasetEventsConsumer returns a kafka consumer of dataset events, which might not be initialised yet. Each
+// instance consumes every event, so joins a consumer group of its own, named after its replica ID. The replica ID stays
+// the same as the instance is restarted, so that the number of consumer groups is bounded by the number of replicas.
+func (e *ExternalServiceList) GetDatasetEventsConsumer(ctx context.Context, cfg *config.Configuration) (kafkaConsumer kafka.IConsumerGroup, err error) {
+	if cfg.ResponseCacheConfig.ReplicaID == "" {
+		return nil, errReplicaIDEmpty
+	}
+	group := cfg.ResponseCacheConfig.ConsumerGroup + "-" + cfg.ResponseCacheConfig.ReplicaID
+
+	kafkaConsumer, err = e.Init.DoGetKafkaConsumer(ctx, cfg, cfg.DatasetEventsTopic, group)
+	if err != nil {
+		return
+	}
+	e.DatasetEventsConsumer = true
+	return
+}
+
 // GetGraphDB returns a graphDB (only if observation and private endpoint are enabled)
 func (e *ExternalServiceList) GetGraphDB(ctx context.Context) (store.GraphDB, Closer, error) {
 	graphDB, graphDBErrorConsumer, err := e.Init.DoGetGraphDB(ctx)
@@ -97,27 +141,40 @@ func (e *Init) DoGetHealthCheck(cfg *config.Configuration, buildTime, gitCommit,
 	return &hc, nil
 }
 
-// DoGetKafkaProducer creates a new Kafka Producer
-func (e *Init) DoGetKafkaProducer(ctx context.Context, cfg *config.Configuration) (kafka.IProducer, error) {
+// DoGetKafkaProducer creates a new Kafka Producer for the provided topic
+func (e *Init) DoGetKafkaProducer(ctx context.Context, cfg *config.Configuration, topic string) (kafka.IProducer, error) {
 
 	pConfig := &kafka.ProducerConfig{
 		KafkaVersion: &cfg.KafkaVersion,
 	}
 
 	pChannels := kafka.CreateProducerChannels()
-	return kafka.NewProducer(ctx, cfg.KafkaAddr, cfg.GenerateDownloadsTopic, pChannels, pConfig)
+	return kafka.NewProducer(ctx, cfg.KafkaAddr, topic, pChannels, pConfig)
+}
+
+// DoGetKafkaConsumer creates a new Kafka Consumer Group for the provided topic, which consumes the messages sent once
+// the group first joins
+func (e *Init) DoGetKafkaConsumer(ctx context.Context, cfg *config.Configuration, topic, group string) (kafka.IConsumerGroup, error) {
+	offset := kafka.OffsetNewest
+	cgConfig := &kafka.ConsumerGroupConfig{
+		KafkaVersion: &cfg.KafkaVersion,
+		Offset:       &offset,
+	}
+
+	cgChannels := kafka.CreateConsumerGroupChannels(1)
+	return kafka.NewConsumerGroup(ctx, cfg.KafkaAddr, topic, group, cgChannels, cgConfig)
 }
 
 // DoGetGraphDB creates a new GraphDB
 func (e *Init) DoGetGraphDB(ctx context.Context) (store.GraphDB, Closer, error) {
-	graphDB, err := graph.New(ctx, graph.Subsets{Observation: true, Instance: true})
+	graphDB, err := dpgraph.New(ctx, dpgraph.Subsets{Observation: true, Instance: true})
 	if err != nil {
 		return nil, nil, err
 	}
 
-	graphDBErrorConsumer := graph.NewLoggingErrorConsumer(ctx, graphDB.ErrorChan())
+	graphDBErrorConsumer := dpgraph.NewLoggingErrorConsumer(ctx, graphDB.ErrorChan())
 
-	return graphDB, graphDBErrorConsumer, nil
+	return &graph.Store{DB: graphDB}, graphDBErrorConsumer, nil
 }
 
 // DoGetMongoDB returns a MongoDB
@@ -129,9 +186,20 @@ func (e *Init) DoGetMongoDB(ctx context.Context, cfg *config.Configuration) (sto
 		DatasetURL:  cfg.DatasetAPIURL,
 		URI:         cfg.MongoConfig.BindAddr,
 	}
+
+	// web instances only serve public reads, which can be served from secondaries, whereas the reads of the
+	// publishing instance precede its writes so are made from the primary. Indexes are created by the publishing
+	// instance, so web instances only report those that are missing.
+	if !cfg.EnablePrivateEndpoints {
+		mongodb.ReadPreference = cfg.MongoConfig.ReadPreference
+		mongodb.MaxStaleness = cfg.MongoConfig.MaxStaleness
+	} else {
+		mongodb.EnsureIndexes = cfg.MongoConfig.EnsureIndexes
+	}
+
 	if err := mongodb.Init(ctx); err != nil {
 		return nil, err
 	}
-	log.Event(ctx, "listening to mongo db session", log.INFO, log.Data{"URI": mongodb.URI})
+	log.Event(ctx, "listening to mongo db session", log.INFO, log.Data{"URI": mongodb.URI, "read_preference": mongodb.ReadPreference})
 	return mongodb, nil
 }
@@ -19,7 +19,8 @@ import (
 type Initialiser interface {
 	DoGetHTTPServer(bindAddr string, router http.Handler) HTTPServer
 	DoGetHealthCheck(cfg *config.Configuration, buildTime, gitCommit, version string) (HealthChecker, error)
-	DoGetKafkaProducer(ctx context.Context, cfg *config.Configuration) (kafka.IProducer, error)
+	DoGetKafkaProducer(ctx context.Context, cfg *config.Configuration, topic string) (kafka.IProducer, error)
+	DoGetKafkaConsumer(ctx context.Context, cfg *config.Configuration, topic, group string) (kafka.IConsumerGroup, error)
 	DoGetGraphDB(ctx context.Context) (store.GraphDB, Closer, error)
 	DoGetMongoDB(ctx context.Context, cfg *config.Configuration) (store.MongoDB, error)
 }
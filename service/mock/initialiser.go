@@ -17,6 +17,7 @@ var (
 	lockInitialiserMockDoGetGraphDB       sync.RWMutex
 	lockInitialiserMockDoGetHTTPServer    sync.RWMutex
 	lockInitialiserMockDoGetHealthCheck   sync.RWMutex
+	lockInitialiserMockDoGetKafkaConsumer sync.RWMutex
 	lockInitialiserMockDoGetKafkaProducer sync.RWMutex
 	lockInitialiserMockDoGetMongoDB       sync.RWMutex
 )
@@ -40,7 +41,10 @@ var _ service.Initialiser = &InitialiserMock{}
 //             DoGetHealthCheckFunc: func(cfg *config.Configuration, buildTime string, gitCommit string, version string) (service.HealthChecker, error) {
 // 	               panic("mock out the DoGetHealthCheck method")
 //             },
-//             DoGetKafkaProducerFunc: func(ctx context.Context, cfg *config.Configuration) (kafka.IProducer, error) {
+//             DoGetKafkaConsumerFunc: func(ctx context.Context, cfg *config.Configuration, topic string, group string) (kafka.IConsumerGroup, error) {
+// 	               panic("mock out the DoGetKafkaConsumer method")
+//             },
+//             DoGetKafkaProducerFunc: func(ctx context.Context, cfg *config.Configuration, topic string) (kafka.IProducer, error) {
 // 	               panic("mock out the DoGetKafkaProducer method")
 //             },
 //             DoGetMongoDBFunc: func(ctx context.Context, cfg *config.Configuration) (store.MongoDB, error) {
@@ -62,8 +66,11 @@ type InitialiserMock struct {
 	// DoGetHealthCheckFunc mocks the DoGetHealthCheck method.
 	DoGetHealthCheckFunc func(cfg *config.Configuration, buildTime string, gitCommit string, version string) (service.HealthChecker, error)
 
+	// DoGetKafkaConsumerFunc mocks the DoGetKafkaConsumer method.
+	DoGetKafkaConsumerFunc func(ctx context.Context, cfg *config.Configuration, topic string, group string) (kafka.IConsumerGroup, error)
+
 	// DoGetKafkaProducerFunc mocks the DoGetKafkaProducer method.
-	DoGetKafkaProducerFunc func(ctx context.Context, cfg *config.Configuration) (kafka.IProducer, error)
+	DoGetKafkaProducerFunc func(ctx context.Context, cfg *config.Configuration, topic string) (kafka.IProducer, error)
 
 	// DoGetMongoDBFunc mocks the DoGetMongoDB method.
 	DoGetMongoDBFunc func(ctx context.Context, cfg *config.Configuration) (store.MongoDB, error)
@@ -93,12 +100,25 @@ type InitialiserMock struct {
 			// Version is the version argument value.
 			Version string
 		}
+		// DoGetKafkaConsumer holds details about calls to the DoGetKafkaConsumer method.
+		DoGetKafkaConsumer []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cfg is the cfg argument value.
+			Cfg *config.Configuration
+			// Topic is the topic argument value.
+			Topic string
+			// Group is the group argument value.
+			Group string
+		}
 		// DoGetKafkaProducer holds details about calls to the DoGetKafkaProducer method.
 		DoGetKafkaProducer []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Cfg is the cfg argument value.
 			Cfg *config.Configuration
+			// Topic is the topic argument value.
+			Topic string
 		}
 		// DoGetMongoDB holds details about calls to the DoGetMongoDB method.
 		DoGetMongoDB []struct {
@@ -219,34 +239,81 @@ func (mock *InitialiserMock) DoGetHealthCheckCalls() []struct {
 	return calls
 }
 
+// DoGetKafkaConsumer calls DoGetKafkaConsumerFunc.
+func (mock *InitialiserMock) DoGetKafkaConsumer(ctx context.Context, cfg *config.Configuration, topic string, group string) (kafka.IConsumerGroup, error) {
+	if mock.DoGetKafkaConsumerFunc == nil {
+		panic("InitialiserMock.DoGetKafkaConsumerFunc: method is nil but Initialiser.DoGetKafkaConsumer was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Cfg   *config.Configuration
+		Topic string
+		Group string
+	}{
+		Ctx:   ctx,
+		Cfg:   cfg,
+		Topic: topic,
+		Group: group,
+	}
+	lockInitialiserMockDoGetKafkaConsumer.Lock()
+	mock.calls.DoGetKafkaConsumer = append(mock.calls.DoGetKafkaConsumer, callInfo)
+	lockInitialiserMockDoGetKafkaConsumer.Unlock()
+	return mock.DoGetKafkaConsumerFunc(ctx, cfg, topic, group)
+}
+
+// DoGetKafkaConsumerCalls gets all the calls that were made to DoGetKafkaConsumer.
+// Check the length with:
+//     len(mockedInitialiser.DoGetKafkaConsumerCalls())
+func (mock *InitialiserMock) DoGetKafkaConsumerCalls() []struct {
+	Ctx   context.Context
+	Cfg   *config.Configuration
+	Topic string
+	Group string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Cfg   *config.Configuration
+		Topic string
+		Group string
+	}
+	lockInitialiserMockDoGetKafkaConsumer.RLock()
+	calls = mock.calls.DoGetKafkaConsumer
+	lockInitialiserMockDoGetKafkaConsumer.RUnlock()
+	return calls
+}
+
 // DoGetKafkaProducer calls DoGetKafkaProducerFunc.
-func (mock *InitialiserMock) DoGetKafkaProducer(ctx context.Context, cfg *config.Configuration) (kafka.IProducer, error) {
+func (mock *InitialiserMock) DoGetKafkaProducer(ctx context.Context, cfg *config.Configuration, topic string) (kafka.IProducer, error) {
 	if mock.DoGetKafkaProducerFunc == nil {
 		panic("InitialiserMock.DoGetKafkaProducerFunc: method is nil but Initialiser.DoGetKafkaProducer was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		Cfg *config.Configuration
+		Ctx   context.Context
+		Cfg   *config.Configuration
+		Topic string
 	}{
-		Ctx: ctx,
-		Cfg: cfg,
+		Ctx:   ctx,
+		Cfg:   cfg,
+		Topic: topic,
 	}
 	lockInitialiserMockDoGetKafkaProducer.Lock()
 	mock.calls.DoGetKafkaProducer = append(mock.calls.DoGetKafkaProducer, callInfo)
 	lockInitialiserMockDoGetKafkaProducer.Unlock()
-	return mock.DoGetKafkaProducerFunc(ctx, cfg)
+	return mock.DoGetKafkaProducerFunc(ctx, cfg, topic)
 }
 
 // DoGetKafkaProducerCalls gets all the calls that were made to DoGetKafkaProducer.
 // Check the length with:
 //     len(mockedInitialiser.DoGetKafkaProducerCalls())
 func (mock *InitialiserMock) DoGetKafkaProducerCalls() []struct {
-	Ctx context.Context
-	Cfg *config.Configuration
+	Ctx   context.Context
+	Cfg   *config.Configuration
+	Topic string
 } {
 	var calls []struct {
-		Ctx context.Context
-		Cfg *config.Configuration
+		Ctx   context.Context
+		Cfg   *config.Configuration
+		Topic string
 	}
 	lockInitialiserMockDoGetKafkaProducer.RLock()
 	calls = mock.calls.DoGetKafkaProducer
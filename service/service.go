@@ -7,19 +7,30 @@ import (
 	clientsidentity "github.com/ONSdigital/dp-api-clients-go/identity"
 	"github.com/ONSdigital/dp-authorisation/auth"
 	"github.com/ONSdigital/dp-dataset-api/api"
+	"github.com/ONSdigital/dp-dataset-api/cache"
+	"github.com/ONSdigital/dp-dataset-api/codelist"
+	"github.com/ONSdigital/dp-dataset-api/collection"
 	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/download"
+	"github.com/ONSdigital/dp-dataset-api/events"
+	"github.com/ONSdigital/dp-dataset-api/importtask"
 	adapter "github.com/ONSdigital/dp-dataset-api/kafka"
+	"github.com/ONSdigital/dp-dataset-api/linkcheck"
+	"github.com/ONSdigital/dp-dataset-api/metrics"
 	"github.com/ONSdigital/dp-dataset-api/schema"
 	"github.com/ONSdigital/dp-dataset-api/store"
+	"github.com/ONSdigital/dp-dataset-api/tracing"
 	"github.com/ONSdigital/dp-dataset-api/url"
+	"github.com/ONSdigital/dp-dataset-api/utils"
 	kafka "github.com/ONSdigital/dp-kafka/v2"
 	dphandlers "github.com/ONSdigital/dp-net/handlers"
 	dphttp "github.com/ONSdigital/dp-net/http"
+	dprequest "github.com/ONSdigital/dp-net/request"
 	"github.com/ONSdigital/log.go/log"
 	"github.com/gorilla/mux"
 	"github.com/justinas/alice"
 	"github.com/pkg/errors"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 // check that DatsetAPIStore satifies the the store.Storer interface
@@ -39,10 +50,19 @@ type Service struct {
 	graphDBErrorConsumer      Closer
 	mongoDB                   store.MongoDB
 	generateDownloadsProducer kafka.IProducer
+	importRetryProducer       kafka.IProducer
+	datasetEventsProducer     kafka.IProducer
+	producerAdapters          []*adapter.Producer
+	datasetEventsConsumer     kafka.IConsumerGroup
+	responseCache             *cache.MongoDB
+	stopResponseCache         context.CancelFunc
 	identityClient            *clientsidentity.Client
 	server                    HTTPServer
 	healthCheck               HealthChecker
 	api                       *api.DatasetAPI
+	linkChecker               *linkcheck.Checker
+	codeLists                 *codelist.CachedCodeLists
+	tracerProvider            *sdktrace.TracerProvider
 }
 
 // New creates a new service
@@ -69,6 +89,21 @@ func (svc *Service) SetDownloadsProducer(producer kafka.IProducer) {
 	svc.generateDownloadsProducer = producer
 }
 
+// SetImportRetryProducer sets the import retry kafka producer for a service
+func (svc *Service) SetImportRetryProducer(producer kafka.IProducer) {
+	svc.importRetryProducer = producer
+}
+
+// SetDatasetEventsProducer sets the dataset events kafka producer for a service
+func (svc *Service) SetDatasetEventsProducer(producer kafka.IProducer) {
+	svc.datasetEventsProducer = producer
+}
+
+// SetDatasetEventsConsumer sets the dataset events kafka consumer for a service
+func (svc *Service) SetDatasetEventsConsumer(consumer kafka.IConsumerGroup) {
+	svc.datasetEventsConsumer = consumer
+}
+
 // SetMongoDB sets the mongoDB connection for a service
 func (svc *Service) SetMongoDB(mongoDB store.MongoDB) {
 	svc.mongoDB = mongoDB
@@ -87,6 +122,13 @@ func (svc *Service) SetGraphDBErrorConsumer(graphDBErrorConsumer Closer) {
 // Run the service
 func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version string, svcErrors chan error) (err error) {
 
+	// Trace requests, and the store calls and kafka messages made while handling them
+	svc.tracerProvider, err = tracing.Init(svc.config.TracingConfig)
+	if err != nil {
+		log.Event(ctx, "failed to initialise tracing", log.FATAL, log.Error(err))
+		return err
+	}
+
 	// Get MongoDB connection
 	svc.mongoDB, err = svc.serviceList.GetMongoDB(ctx, svc.config)
 	if err != nil {
@@ -106,7 +148,44 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 			return err
 		}
 	}
-	store := store.DataStore{Backend: DatsetAPIStore{svc.mongoDB, svc.graphDB}}
+	mongoDB := tracing.InstrumentMongoDB(metrics.InstrumentMongoDB(svc.mongoDB))
+
+	// Cache the public reads of web instances, invalidated by the dataset events of the publishing instance
+	if !svc.config.EnablePrivateEndpoints && svc.config.ResponseCacheConfig.Enabled {
+		svc.datasetEventsConsumer, err = svc.serviceList.GetDatasetEventsConsumer(ctx, svc.config)
+		if err != nil {
+			log.Event(ctx, "could not obtain dataset events consumer", log.FATAL, log.Error(err))
+			return err
+		}
+
+		svc.responseCache = cache.New(mongoDB, svc.config.ResponseCacheConfig)
+		mongoDB = svc.responseCache
+		svc.startResponseCache(ctx)
+	}
+
+	store := store.DataStore{Backend: DatsetAPIStore{
+		mongoDB,
+		tracing.InstrumentGraphDB(metrics.InstrumentGraphDB(svc.graphDB)),
+	}}
+
+	// datasets and editions stored before eTags were are given one by the publishing instance, so that they can be updated
+	// with an If-Match header
+	if svc.config.EnablePrivateEndpoints {
+		backfilled, err := svc.mongoDB.BackfillETags(ctx)
+		if err != nil {
+			log.Event(ctx, "failed to backfill the eTags of datasets and editions", log.ERROR, log.Error(err), log.Data{"backfilled": backfilled})
+		}
+	}
+
+	// links are migrated by the publishing instance, before it starts serving them
+	if svc.config.EnablePrivateEndpoints && svc.config.MigrateLinksOnStartup {
+		migrated, err := svc.mongoDB.MigrateLinks(ctx, svc.config.DatasetAPIURL)
+		if err != nil {
+			log.Event(ctx, "failed to migrate the links stored in mongo db to paths", log.FATAL, log.Error(err), log.Data{"migrated": migrated})
+			return err
+		}
+		log.Event(ctx, "migrated the links stored in mongo db to paths", log.INFO, log.Data{"migrated": migrated})
+	}
 
 	// Get GenerateDownloads Kafka Producer
 	if !svc.config.EnablePrivateEndpoints {
@@ -119,16 +198,57 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 			log.Event(ctx, "could not obtain generate downloads producer", log.FATAL, log.Error(err))
 			return err
 		}
+
+		svc.importRetryProducer, err = svc.serviceList.GetImportRetryProducer(ctx, svc.config)
+		if err != nil {
+			log.Event(ctx, "could not obtain import retry producer", log.FATAL, log.Error(err))
+			return err
+		}
+
+		svc.datasetEventsProducer, err = svc.serviceList.GetDatasetEventsProducer(ctx, svc.config)
+		if err != nil {
+			log.Event(ctx, "could not obtain dataset events producer", log.FATAL, log.Error(err))
+			return err
+		}
 	}
 
+	generateDownloadsAdapter := adapter.NewProducerAdapter(svc.generateDownloadsProducer, svc.config.GenerateDownloadsTopic)
+	importRetryAdapter := adapter.NewProducerAdapter(svc.importRetryProducer, svc.config.ImportRetryTopic)
+	datasetEventsAdapter := adapter.NewProducerAdapter(svc.datasetEventsProducer, svc.config.DatasetEventsTopic)
+	svc.producerAdapters = []*adapter.Producer{generateDownloadsAdapter, importRetryAdapter, datasetEventsAdapter}
+
 	downloadGenerator := &download.Generator{
-		Producer:   adapter.NewProducerAdapter(svc.generateDownloadsProducer),
+		Producer:   generateDownloadsAdapter,
 		Marshaller: schema.GenerateDownloadsEvent,
 	}
 
-	// Get Identity Client (only if private endpoints are enabled)
+	importRetrier := &importtask.Retrier{
+		Producer:   importRetryAdapter,
+		Marshaller: schema.ImportRetryEvent,
+	}
+
+	eventEmitter := &events.Emitter{
+		Producer:   datasetEventsAdapter,
+		Marshaller: schema.DatasetEvent,
+	}
+
+	// Get Identity Client and Zebedee collections clients (only if private endpoints are enabled)
+	var collectionPermissions api.CollectionPermissions
+	var collections api.Collections
 	if svc.config.EnablePrivateEndpoints {
 		svc.identityClient = clientsidentity.New(svc.config.ZebedeeURL)
+		zebedeeClient := collection.NewZebedeeClient(svc.config.ZebedeeURL, svc.config.ServiceAuthToken, dphttp.NewClient())
+		collectionPermissions = collection.NewCachedChecker(zebedeeClient, svc.config.CollectionPermissionsTTL)
+		collections = zebedeeClient
+	}
+
+	// Get the code lists that instance dimensions are checked against (only if code list validation is enabled)
+	var codeListValidator *codelist.Validator
+	if svc.config.EnablePrivateEndpoints && svc.config.EnableCodeListValidation {
+		codeListClient := codelist.NewClient(svc.config.CodeListAPIURL, dphttp.NewClient())
+		svc.codeLists = codelist.NewCachedCodeLists(codeListClient, svc.config.CodeListCacheTTL)
+		svc.codeLists.Start(ctx)
+		codeListValidator = codelist.NewValidator(svc.codeLists)
 	}
 
 	// Get HealthCheck
@@ -143,19 +263,36 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 
 	// Get HTTP router and server with middleware
 	r := mux.NewRouter()
-	m := svc.createMiddleware(svc.config)
+	m := svc.createMiddleware(svc.config, r)
 	svc.server = svc.serviceList.GetHTTPServer(svc.config.BindAddr, m.Then(r))
+	r.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet)
 
 	// Create Dataset API
 	urlBuilder := url.NewBuilder(svc.config.WebsiteURL)
 	datasetPermissions, permissions := getAuthorisationHandlers(ctx, svc.config)
-	svc.api = api.Setup(ctx, svc.config, r, store, urlBuilder, downloadGenerator, datasetPermissions, permissions)
+	svc.api = api.Setup(ctx, svc.config, r, store, urlBuilder, downloadGenerator, importRetrier, eventEmitter, collectionPermissions, collections, codeListValidator, datasetPermissions, permissions)
+
+	// deleted datasets are purged by the publishing instance, which has access to the graph database
+	if svc.config.EnablePrivateEndpoints {
+		svc.api.StartPurger(ctx, svc.config.DeletionConfig.PurgeInterval)
+
+		// only publishing instances check the links of published datasets, one instance at a time, with each instance
+		// checking them once per interval
+		svc.linkChecker = &linkcheck.Checker{
+			Client:      linkcheck.NewHTTPClient(svc.config.LinkCheckTimeout),
+			Datastore:   store.Backend,
+			Concurrency: svc.config.LinkCheckConcurrency,
+		}
+		svc.linkChecker.Start(ctx, svc.config.LinkCheckInterval)
+	}
 
 	svc.healthCheck.Start(ctx)
 
 	// Log kafka producer errors in parallel go-routine
 	if svc.config.EnablePrivateEndpoints {
 		svc.generateDownloadsProducer.Channels().LogErrors(ctx, "generate downloads producer error")
+		svc.importRetryProducer.Channels().LogErrors(ctx, "import retry producer error")
+		svc.datasetEventsProducer.Channels().LogErrors(ctx, "dataset events producer error")
 	}
 
 	// Run the http server in a new go-routine
@@ -198,12 +335,18 @@ func getAuthorisationHandlers(ctx context.Context, cfg *config.Configuration) (a
 
 // CreateMiddleware creates an Alice middleware chain of handlers
 // to forward collectionID from cookie from header
-func (svc *Service) createMiddleware(cfg *config.Configuration) alice.Chain {
+func (svc *Service) createMiddleware(cfg *config.Configuration, router *mux.Router) alice.Chain {
 
 	// healthcheck
 	healthcheckHandler := newMiddleware(svc.healthCheck.Handler, "/health")
 	middleware := alice.New(healthcheckHandler)
 
+	// a span for each request, continuing the trace of the caller
+	middleware = middleware.Append(tracing.Middleware(router))
+
+	// request counts and latency, by the route of the router matching each request
+	middleware = middleware.Append(metrics.Middleware(router))
+
 	// Only add the identity middleware when running in publishing.
 	if cfg.EnablePrivateEndpoints {
 		middleware = middleware.Append(dphandlers.IdentityWithHTTPClient(svc.identityClient))
@@ -212,6 +355,9 @@ func (svc *Service) createMiddleware(cfg *config.Configuration) alice.Chain {
 	// collection ID
 	middleware = middleware.Append(dphandlers.CheckHeader(dphandlers.CollectionID))
 
+	// request ID, returned in the body of unsuccessful requests in the format negotiated with the caller
+	middleware = middleware.Append(dprequest.HandlerRequestID(16), utils.HandlerErrorFormat)
+
 	return middleware
 }
 
@@ -255,6 +401,34 @@ func (svc *Service) Close(ctx context.Context) error {
 			hasShutdownError = true
 		}
 
+		// stop purging deleted datasets, as it depends on MongoDB and the graph database
+		if svc.api != nil {
+			svc.api.StopPurger()
+		}
+
+		// stop checking links, as it depends on MongoDB
+		if svc.linkChecker != nil {
+			svc.linkChecker.Stop()
+		}
+
+		// stop removing expired code lists
+		if svc.codeLists != nil {
+			svc.codeLists.Stop()
+		}
+
+		// Close DatasetEventsConsumer (if it exists), before the response cache it invalidates stops listening
+		if svc.serviceList.DatasetEventsConsumer {
+			log.Event(shutdownContext, "closing dataset events kafka consumer", log.INFO, log.Data{"consumer": "DatasetEvents"})
+			if err := svc.datasetEventsConsumer.Close(shutdownContext); err != nil {
+				log.Event(shutdownContext, "failed to close dataset events kafka consumer", log.ERROR, log.Error(err))
+				hasShutdownError = true
+			}
+			log.Event(shutdownContext, "closed dataset events kafka consumer", log.INFO, log.Data{"consumer": "DatasetEvents"})
+		}
+		if svc.stopResponseCache != nil {
+			svc.stopResponseCache()
+		}
+
 		// Close MongoDB (if it exists)
 		if svc.serviceList.MongoDB {
 			if err := svc.mongoDB.Close(shutdownContext); err != nil {
@@ -263,6 +437,11 @@ func (svc *Service) Close(ctx context.Context) error {
 			}
 		}
 
+		// stop forwarding messages to the kafka producers, before they are closed
+		for _, producerAdapter := range svc.producerAdapters {
+			producerAdapter.Close()
+		}
+
 		// Close GenerateDownloadsProducer (if it exists)
 		if svc.serviceList.GenerateDownloadsProducer {
 			log.Event(shutdownContext, "closing generated downloads kafka producer", log.INFO, log.Data{"producer": "DimensionExtracted"})
@@ -270,6 +449,20 @@ func (svc *Service) Close(ctx context.Context) error {
 			log.Event(shutdownContext, "closed generated downloads kafka producer", log.INFO, log.Data{"producer": "DimensionExtracted"})
 		}
 
+		// Close ImportRetryProducer (if it exists)
+		if svc.serviceList.ImportRetryProducer {
+			log.Event(shutdownContext, "closing import retry kafka producer", log.INFO, log.Data{"producer": "ImportRetry"})
+			svc.importRetryProducer.Close(shutdownContext)
+			log.Event(shutdownContext, "closed import retry kafka producer", log.INFO, log.Data{"producer": "ImportRetry"})
+		}
+
+		// Close DatasetEventsProducer (if it exists)
+		if svc.serviceList.DatasetEventsProducer {
+			log.Event(shutdownContext, "closing dataset events kafka producer", log.INFO, log.Data{"producer": "DatasetEvents"})
+			svc.datasetEventsProducer.Close(shutdownContext)
+			log.Event(shutdownContext, "closed dataset events kafka producer", log.INFO, log.Data{"producer": "DatasetEvents"})
+		}
+
 		// Close GraphDB (if it exists)
 		if svc.serviceList.Graph {
 			if err := svc.graphDB.Close(shutdownContext); err != nil {
@@ -282,6 +475,14 @@ func (svc *Service) Close(ctx context.Context) error {
 				hasShutdownError = true
 			}
 		}
+
+		// Flush the spans of the requests handled before shutdown (if they are exported)
+		if svc.tracerProvider != nil {
+			if err := svc.tracerProvider.Shutdown(shutdownContext); err != nil {
+				log.Event(shutdownContext, "failed to flush spans to the trace exporter", log.ERROR, log.Error(err))
+				hasShutdownError = true
+			}
+		}
 	}()
 
 	// wait for shutdown success (via cancel) or failure (timeout)
@@ -304,6 +505,15 @@ func (svc *Service) Close(ctx context.Context) error {
 	return nil
 }
 
+// startResponseCache invalidates the response cache with the dataset events consumed, until it is stopped
+func (svc *Service) startResponseCache(ctx context.Context) {
+	ctx, svc.stopResponseCache = context.WithCancel(ctx)
+	datasetEvents := make(chan events.Event)
+
+	go events.Consume(ctx, svc.datasetEventsConsumer.Channels().Upstream, schema.DatasetEvent, datasetEvents)
+	go svc.responseCache.Listen(ctx, datasetEvents)
+}
+
 // registerCheckers adds the checkers for the provided clients to the health check object
 func (svc *Service) registerCheckers(ctx context.Context) (err error) {
 	hasErrors := false
@@ -320,12 +530,29 @@ func (svc *Service) registerCheckers(ctx context.Context) (err error) {
 			log.Event(ctx, "error adding check for kafka downloads producer", log.ERROR, log.Error(err))
 		}
 
+		if err = svc.healthCheck.AddCheck("Kafka Import Retry Producer", svc.importRetryProducer.Checker); err != nil {
+			hasErrors = true
+			log.Event(ctx, "error adding check for kafka import retry producer", log.ERROR, log.Error(err))
+		}
+
+		if err = svc.healthCheck.AddCheck("Kafka Dataset Events Producer", svc.datasetEventsProducer.Checker); err != nil {
+			hasErrors = true
+			log.Event(ctx, "error adding check for kafka dataset events producer", log.ERROR, log.Error(err))
+		}
+
 		if err = svc.healthCheck.AddCheck("Graph DB", svc.graphDB.Checker); err != nil {
 			hasErrors = true
 			log.Event(ctx, "error adding check for graph db", log.ERROR, log.Error(err))
 		}
 	}
 
+	if svc.datasetEventsConsumer != nil {
+		if err = svc.healthCheck.AddCheck("Kafka Dataset Events Consumer", svc.datasetEventsConsumer.Checker); err != nil {
+			hasErrors = true
+			log.Event(ctx, "error adding check for kafka dataset events consumer", log.ERROR, log.Error(err))
+		}
+	}
+
 	if err = svc.healthCheck.AddCheck("Mongo DB", svc.mongoDB.Checker); err != nil {
 		hasErrors = true
 		log.Event(ctx, "error adding check for mongo db", log.ERROR, log.Error(err))
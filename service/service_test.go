@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/ONSdigital/dp-dataset-api/apierrors"
 	"github.com/ONSdigital/dp-dataset-api/config"
 	"github.com/ONSdigital/dp-dataset-api/service"
 	"github.com/ONSdigital/dp-dataset-api/service/mock"
@@ -51,7 +53,7 @@ var funcDoGetGraphDBErr = func(ctx context.Context) (store.GraphDB, service.Clos
 	return nil, nil, errGraph
 }
 
-var funcDoGetKafkaProducerErr = func(ctx context.Context, cfg *config.Configuration) (kafka.IProducer, error) {
+var funcDoGetKafkaProducerErr = func(ctx context.Context, cfg *config.Configuration, topic string) (kafka.IProducer, error) {
 	return nil, errKafka
 }
 
@@ -96,7 +98,14 @@ func TestRun(t *testing.T) {
 		}
 
 		funcDoGetMongoDBOk := func(ctx context.Context, cfg *config.Configuration) (store.MongoDB, error) {
-			return &storeMock.MongoDBMock{}, nil
+			return &storeMock.MongoDBMock{
+				BackfillETagsFunc: func(ctx context.Context) (int, error) {
+					return 0, nil
+				},
+				LockLinkCheckFunc: func(ctx context.Context, ttl time.Duration) (string, error) {
+					return "", apierrors.ErrLinkCheckLocked
+				},
+			}, nil
 		}
 
 		funcDoGetGraphDBOk := func(ctx context.Context) (store.GraphDB, service.Closer, error) {
@@ -106,7 +115,7 @@ func TestRun(t *testing.T) {
 			return &storeMock.GraphDBMock{}, &serviceMock.CloserMock{CloseFunc: funcClose}, nil
 		}
 
-		funcDoGetKafkaProducerOk := func(ctx context.Context, cfg *config.Configuration) (kafka.IProducer, error) {
+		funcDoGetKafkaProducerOk := func(ctx context.Context, cfg *config.Configuration, topic string) (kafka.IProducer, error) {
 			return &kafkatest.IProducerMock{
 				ChannelsFunc: func() *kafka.ProducerChannels {
 					return &kafka.ProducerChannels{}
@@ -128,6 +137,8 @@ func TestRun(t *testing.T) {
 				So(svcList.MongoDB, ShouldBeFalse)
 				So(svcList.Graph, ShouldBeFalse)
 				So(svcList.GenerateDownloadsProducer, ShouldBeFalse)
+				So(svcList.ImportRetryProducer, ShouldBeFalse)
+				So(svcList.DatasetEventsProducer, ShouldBeFalse)
 				So(svcList.HealthCheck, ShouldBeFalse)
 			})
 		})
@@ -147,6 +158,51 @@ func TestRun(t *testing.T) {
 				So(svcList.MongoDB, ShouldBeTrue)
 				So(svcList.Graph, ShouldBeFalse)
 				So(svcList.GenerateDownloadsProducer, ShouldBeFalse)
+				So(svcList.ImportRetryProducer, ShouldBeFalse)
+				So(svcList.DatasetEventsProducer, ShouldBeFalse)
+				So(svcList.HealthCheck, ShouldBeFalse)
+			})
+		})
+
+		Convey("Given that the links stored in MongoDB are migrated on startup", func() {
+			cfg.MigrateLinksOnStartup = true
+			Reset(func() {
+				cfg.MigrateLinksOnStartup = false
+			})
+
+			errMigration := errors.New("migration failed")
+			mongoMock := &storeMock.MongoDBMock{
+				BackfillETagsFunc: func(ctx context.Context) (int, error) {
+					return 1, errors.New("backfill failed")
+				},
+				MigrateLinksFunc: func(ctx context.Context, apiURL string) (int, error) {
+					return 3, errMigration
+				},
+			}
+			initMock := &serviceMock.InitialiserMock{
+				DoGetMongoDBFunc: func(ctx context.Context, cfg *config.Configuration) (store.MongoDB, error) {
+					return mongoMock, nil
+				},
+				DoGetGraphDBFunc: funcDoGetGraphDBOk,
+			}
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			svc := service.New(cfg, svcList)
+			err := svc.Run(ctx, testBuildTime, testGitCommit, testVersion, svcErrors)
+
+			Convey("Then the eTags of datasets and editions are backfilled, and startup continues when the backfill fails", func() {
+				So(mongoMock.BackfillETagsCalls(), ShouldHaveLength, 1)
+				So(mongoMock.MigrateLinksCalls(), ShouldHaveLength, 1)
+			})
+
+			Convey("Then the links are migrated against the url of the api", func() {
+				So(mongoMock.MigrateLinksCalls(), ShouldHaveLength, 1)
+				So(mongoMock.MigrateLinksCalls()[0].ApiURL, ShouldEqual, cfg.DatasetAPIURL)
+			})
+
+			Convey("Then service Run fails if the migration fails. No further initialisations are attempted", func() {
+				So(err, ShouldResemble, errMigration)
+				So(svcList.GenerateDownloadsProducer, ShouldBeFalse)
 				So(svcList.HealthCheck, ShouldBeFalse)
 			})
 		})
@@ -167,6 +223,62 @@ func TestRun(t *testing.T) {
 				So(svcList.MongoDB, ShouldBeTrue)
 				So(svcList.Graph, ShouldBeTrue)
 				So(svcList.GenerateDownloadsProducer, ShouldBeFalse)
+				So(svcList.ImportRetryProducer, ShouldBeFalse)
+				So(svcList.DatasetEventsProducer, ShouldBeFalse)
+				So(svcList.HealthCheck, ShouldBeFalse)
+			})
+		})
+
+		Convey("Given that initialising the import retry Kafka producer returns an error", func() {
+			initMock := &mock.InitialiserMock{
+				DoGetMongoDBFunc: funcDoGetMongoDBOk,
+				DoGetGraphDBFunc: funcDoGetGraphDBOk,
+				DoGetKafkaProducerFunc: func(ctx context.Context, cfg *config.Configuration, topic string) (kafka.IProducer, error) {
+					if topic == cfg.ImportRetryTopic {
+						return nil, errKafka
+					}
+					return funcDoGetKafkaProducerOk(ctx, cfg, topic)
+				},
+			}
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			svc := service.New(cfg, svcList)
+			err := svc.Run(ctx, testBuildTime, testGitCommit, testVersion, svcErrors)
+
+			Convey("Then service Run fails with the same error and the flag is not set. No further initialisations are attempted", func() {
+				So(err, ShouldResemble, errKafka)
+				So(svcList.MongoDB, ShouldBeTrue)
+				So(svcList.Graph, ShouldBeTrue)
+				So(svcList.GenerateDownloadsProducer, ShouldBeTrue)
+				So(svcList.ImportRetryProducer, ShouldBeFalse)
+				So(svcList.DatasetEventsProducer, ShouldBeFalse)
+				So(svcList.HealthCheck, ShouldBeFalse)
+			})
+		})
+
+		Convey("Given that initialising the dataset events Kafka producer returns an error", func() {
+			initMock := &mock.InitialiserMock{
+				DoGetMongoDBFunc: funcDoGetMongoDBOk,
+				DoGetGraphDBFunc: funcDoGetGraphDBOk,
+				DoGetKafkaProducerFunc: func(ctx context.Context, cfg *config.Configuration, topic string) (kafka.IProducer, error) {
+					if topic == cfg.DatasetEventsTopic {
+						return nil, errKafka
+					}
+					return funcDoGetKafkaProducerOk(ctx, cfg, topic)
+				},
+			}
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			svc := service.New(cfg, svcList)
+			err := svc.Run(ctx, testBuildTime, testGitCommit, testVersion, svcErrors)
+
+			Convey("Then service Run fails with the same error and the flag is not set. No further initialisations are attempted", func() {
+				So(err, ShouldResemble, errKafka)
+				So(svcList.MongoDB, ShouldBeTrue)
+				So(svcList.Graph, ShouldBeTrue)
+				So(svcList.GenerateDownloadsProducer, ShouldBeTrue)
+				So(svcList.ImportRetryProducer, ShouldBeTrue)
+				So(svcList.DatasetEventsProducer, ShouldBeFalse)
 				So(svcList.HealthCheck, ShouldBeFalse)
 			})
 		})
@@ -188,6 +300,8 @@ func TestRun(t *testing.T) {
 				So(svcList.MongoDB, ShouldBeTrue)
 				So(svcList.Graph, ShouldBeTrue)
 				So(svcList.GenerateDownloadsProducer, ShouldBeTrue)
+				So(svcList.ImportRetryProducer, ShouldBeTrue)
+				So(svcList.DatasetEventsProducer, ShouldBeTrue)
 				So(svcList.HealthCheck, ShouldBeFalse)
 			})
 		})
@@ -219,12 +333,16 @@ func TestRun(t *testing.T) {
 				So(svcList.MongoDB, ShouldBeTrue)
 				So(svcList.Graph, ShouldBeTrue)
 				So(svcList.GenerateDownloadsProducer, ShouldBeTrue)
+				So(svcList.ImportRetryProducer, ShouldBeTrue)
+				So(svcList.DatasetEventsProducer, ShouldBeTrue)
 				So(svcList.HealthCheck, ShouldBeTrue)
-				So(len(hcMockAddFail.AddCheckCalls()), ShouldEqual, 4)
+				So(len(hcMockAddFail.AddCheckCalls()), ShouldEqual, 6)
 				So(hcMockAddFail.AddCheckCalls()[0].Name, ShouldResemble, "Zebedee")
 				So(hcMockAddFail.AddCheckCalls()[1].Name, ShouldResemble, "Kafka Generate Downloads Producer")
-				So(hcMockAddFail.AddCheckCalls()[2].Name, ShouldResemble, "Graph DB")
-				So(hcMockAddFail.AddCheckCalls()[3].Name, ShouldResemble, "Mongo DB")
+				So(hcMockAddFail.AddCheckCalls()[2].Name, ShouldResemble, "Kafka Import Retry Producer")
+				So(hcMockAddFail.AddCheckCalls()[3].Name, ShouldResemble, "Kafka Dataset Events Producer")
+				So(hcMockAddFail.AddCheckCalls()[4].Name, ShouldResemble, "Graph DB")
+				So(hcMockAddFail.AddCheckCalls()[5].Name, ShouldResemble, "Mongo DB")
 			})
 		})
 
@@ -247,15 +365,19 @@ func TestRun(t *testing.T) {
 				So(svcList.MongoDB, ShouldBeTrue)
 				So(svcList.Graph, ShouldBeTrue)
 				So(svcList.GenerateDownloadsProducer, ShouldBeTrue)
+				So(svcList.ImportRetryProducer, ShouldBeTrue)
+				So(svcList.DatasetEventsProducer, ShouldBeTrue)
 				So(svcList.HealthCheck, ShouldBeTrue)
 			})
 
 			Convey("The checkers are registered and the healthcheck and http server started", func() {
-				So(len(hcMock.AddCheckCalls()), ShouldEqual, 4)
+				So(len(hcMock.AddCheckCalls()), ShouldEqual, 6)
 				So(hcMock.AddCheckCalls()[0].Name, ShouldResemble, "Zebedee")
 				So(hcMock.AddCheckCalls()[1].Name, ShouldResemble, "Kafka Generate Downloads Producer")
-				So(hcMock.AddCheckCalls()[2].Name, ShouldResemble, "Graph DB")
-				So(hcMock.AddCheckCalls()[3].Name, ShouldResemble, "Mongo DB")
+				So(hcMock.AddCheckCalls()[2].Name, ShouldResemble, "Kafka Import Retry Producer")
+				So(hcMock.AddCheckCalls()[3].Name, ShouldResemble, "Kafka Dataset Events Producer")
+				So(hcMock.AddCheckCalls()[4].Name, ShouldResemble, "Graph DB")
+				So(hcMock.AddCheckCalls()[5].Name, ShouldResemble, "Mongo DB")
 				So(len(initMock.DoGetHTTPServerCalls()), ShouldEqual, 1)
 				So(initMock.DoGetHTTPServerCalls()[0].BindAddr, ShouldEqual, ":22000")
 				So(len(hcMock.StartCalls()), ShouldEqual, 1)
@@ -283,6 +405,8 @@ func TestRun(t *testing.T) {
 				So(svcList.MongoDB, ShouldBeTrue)
 				So(svcList.Graph, ShouldBeFalse)
 				So(svcList.GenerateDownloadsProducer, ShouldBeFalse)
+				So(svcList.ImportRetryProducer, ShouldBeFalse)
+				So(svcList.DatasetEventsProducer, ShouldBeFalse)
 				So(svcList.HealthCheck, ShouldBeTrue)
 			})
 
@@ -297,6 +421,89 @@ func TestRun(t *testing.T) {
 			})
 		})
 
+		Convey("Given that the response cache is enabled, private endpoints are disabled", func() {
+			cfg.EnablePrivateEndpoints = false
+			cfg.ResponseCacheConfig.Enabled = true
+			cfg.ResponseCacheConfig.ReplicaID = "1"
+			consumerMock := &kafkatest.IConsumerGroupMock{
+				ChannelsFunc: func() *kafka.ConsumerGroupChannels {
+					return &kafka.ConsumerGroupChannels{Upstream: make(chan kafka.Message)}
+				},
+			}
+			initMock := &serviceMock.InitialiserMock{
+				DoGetMongoDBFunc: funcDoGetMongoDBOk,
+				DoGetKafkaConsumerFunc: func(ctx context.Context, cfg *config.Configuration, topic, group string) (kafka.IConsumerGroup, error) {
+					return consumerMock, nil
+				},
+				DoGetHealthCheckFunc: funcDoGetHealthcheckOk,
+				DoGetHTTPServerFunc:  funcDoGetHTTPServer,
+			}
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			svc := service.New(cfg, svcList)
+			serverWg.Add(1)
+			err := svc.Run(ctx, testBuildTime, testGitCommit, testVersion, svcErrors)
+			serverWg.Wait() // Wait for HTTP server go-routine to finish
+
+			Convey("Then service Run succeeds, consuming the dataset events of its own consumer group", func() {
+				So(err, ShouldBeNil)
+				So(svcList.DatasetEventsConsumer, ShouldBeTrue)
+				So(svcList.DatasetEventsProducer, ShouldBeFalse)
+				So(initMock.DoGetKafkaConsumerCalls(), ShouldHaveLength, 1)
+				So(initMock.DoGetKafkaConsumerCalls()[0].Topic, ShouldEqual, cfg.DatasetEventsTopic)
+				So(initMock.DoGetKafkaConsumerCalls()[0].Group, ShouldEqual, cfg.ResponseCacheConfig.ConsumerGroup+"-1")
+			})
+
+			Convey("The checkers for the dataset events consumer and MongoDB are registered", func() {
+				So(len(hcMock.AddCheckCalls()), ShouldEqual, 2)
+				So(hcMock.AddCheckCalls()[0].Name, ShouldResemble, "Kafka Dataset Events Consumer")
+				So(hcMock.AddCheckCalls()[1].Name, ShouldResemble, "Mongo DB")
+			})
+		})
+
+		Convey("Given that the response cache is enabled without a replica ID", func() {
+			cfg.EnablePrivateEndpoints = false
+			cfg.ResponseCacheConfig.Enabled = true
+			cfg.ResponseCacheConfig.ReplicaID = ""
+			initMock := &serviceMock.InitialiserMock{
+				DoGetMongoDBFunc: funcDoGetMongoDBOk,
+			}
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			svc := service.New(cfg, svcList)
+			err := svc.Run(ctx, testBuildTime, testGitCommit, testVersion, svcErrors)
+
+			Convey("Then service Run fails without creating a consumer, as it would join a consumer group which is never reused", func() {
+				So(err, ShouldNotBeNil)
+				So(initMock.DoGetKafkaConsumerCalls(), ShouldHaveLength, 0)
+				So(svcList.DatasetEventsConsumer, ShouldBeFalse)
+				So(svcList.HealthCheck, ShouldBeFalse)
+			})
+		})
+
+		Convey("Given that the response cache is enabled, but initialising the dataset events Kafka consumer returns an error", func() {
+			cfg.EnablePrivateEndpoints = false
+			cfg.ResponseCacheConfig.Enabled = true
+			cfg.ResponseCacheConfig.ReplicaID = "1"
+			initMock := &serviceMock.InitialiserMock{
+				DoGetMongoDBFunc: funcDoGetMongoDBOk,
+				DoGetKafkaConsumerFunc: func(ctx context.Context, cfg *config.Configuration, topic, group string) (kafka.IConsumerGroup, error) {
+					return nil, errKafka
+				},
+			}
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			svc := service.New(cfg, svcList)
+			err := svc.Run(ctx, testBuildTime, testGitCommit, testVersion, svcErrors)
+
+			Convey("Then service Run fails with the same error and the flag is not set. No further initialisations are attempted", func() {
+				So(err, ShouldResemble, errKafka)
+				So(svcList.MongoDB, ShouldBeTrue)
+				So(svcList.DatasetEventsConsumer, ShouldBeFalse)
+				So(svcList.HealthCheck, ShouldBeFalse)
+			})
+		})
+
 		Convey("Given that all dependencies are successfully initialised but the http server fails", func() {
 
 			initMock := &serviceMock.InitialiserMock{
@@ -383,6 +590,24 @@ func TestClose(t *testing.T) {
 			CloseFunc: funcClose,
 		}
 
+		importRetryProducerMock := &kafkatest.IProducerMock{
+			ChannelsFunc: func() *kafka.ProducerChannels {
+				return &kafka.ProducerChannels{}
+			},
+			CloseFunc: funcClose,
+		}
+
+		datasetEventsProducerMock := &kafkatest.IProducerMock{
+			ChannelsFunc: func() *kafka.ProducerChannels {
+				return &kafka.ProducerChannels{}
+			},
+			CloseFunc: funcClose,
+		}
+
+		datasetEventsConsumerMock := &kafkatest.IConsumerGroupMock{
+			CloseFunc: funcClose,
+		}
+
 		Convey("Closing a service does not close uninitialised dependencies", func() {
 			svcList := service.NewServiceList(nil)
 			svcList.HealthCheck = true
@@ -397,6 +622,8 @@ func TestClose(t *testing.T) {
 
 		fullSvcList := &service.ExternalServiceList{
 			GenerateDownloadsProducer: true,
+			ImportRetryProducer:       true,
+			DatasetEventsProducer:     true,
 			Graph:                     true,
 			HealthCheck:               true,
 			MongoDB:                   true,
@@ -408,6 +635,8 @@ func TestClose(t *testing.T) {
 			svc.SetServer(serverMock)
 			svc.SetHealthCheck(hcMock)
 			svc.SetDownloadsProducer(kafkaProducerMock)
+			svc.SetImportRetryProducer(importRetryProducerMock)
+			svc.SetDatasetEventsProducer(datasetEventsProducerMock)
 			svc.SetMongoDB(mongoMock)
 			svc.SetGraphDB(graphMock)
 			svc.SetGraphDBErrorConsumer(graphErrorConsumerMock)
@@ -419,6 +648,25 @@ func TestClose(t *testing.T) {
 			So(len(graphMock.CloseCalls()), ShouldEqual, 1)
 			So(len(graphErrorConsumerMock.CloseCalls()), ShouldEqual, 1)
 			So(len(kafkaProducerMock.CloseCalls()), ShouldEqual, 1)
+			So(len(importRetryProducerMock.CloseCalls()), ShouldEqual, 1)
+			So(len(datasetEventsProducerMock.CloseCalls()), ShouldEqual, 1)
+		})
+
+		Convey("Closing a web service with a response cache closes the dataset events consumer", func() {
+			svcList := &service.ExternalServiceList{
+				DatasetEventsConsumer: true,
+				HealthCheck:           true,
+				MongoDB:               true,
+			}
+			svc := service.New(cfg, svcList)
+			svc.SetServer(serverMock)
+			svc.SetHealthCheck(hcMock)
+			svc.SetDatasetEventsConsumer(datasetEventsConsumerMock)
+			svc.SetMongoDB(mongoMock)
+			err = svc.Close(context.Background())
+			So(err, ShouldBeNil)
+			So(len(datasetEventsConsumerMock.CloseCalls()), ShouldEqual, 1)
+			So(len(mongoMock.CloseCalls()), ShouldEqual, 1)
 		})
 
 		Convey("If services fail to stop, the Close operation tries to close all dependencies and returns an error", func() {
@@ -433,6 +681,8 @@ func TestClose(t *testing.T) {
 			svc.SetServer(failingserverMock)
 			svc.SetHealthCheck(hcMock)
 			svc.SetDownloadsProducer(kafkaProducerMock)
+			svc.SetImportRetryProducer(importRetryProducerMock)
+			svc.SetDatasetEventsProducer(datasetEventsProducerMock)
 			svc.SetMongoDB(mongoMock)
 			svc.SetGraphDB(graphMock)
 			svc.SetGraphDBErrorConsumer(graphErrorConsumerMock)
@@ -445,6 +695,8 @@ func TestClose(t *testing.T) {
 			So(len(graphMock.CloseCalls()), ShouldEqual, 1)
 			So(len(graphErrorConsumerMock.CloseCalls()), ShouldEqual, 1)
 			So(len(kafkaProducerMock.CloseCalls()), ShouldEqual, 1)
+			So(len(importRetryProducerMock.CloseCalls()), ShouldEqual, 1)
+			So(len(datasetEventsProducerMock.CloseCalls()), ShouldEqual, 1)
 		})
 	})
 }
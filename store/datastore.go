@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/ONSdigital/dp-dataset-api/models"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
@@ -19,44 +20,82 @@ type DataStore struct {
 
 // dataMongoDB represents the required methos to access data from mongoDB
 type dataMongoDB interface {
-	AddDimensionToInstance(dimension *models.CachedDimensionOption) error
-	AddEventToInstance(currentInstance *models.Instance, event *models.Event, eTagSelector string) (newETag string, err error)
-	AddInstance(instance *models.Instance) (*models.Instance, error)
-	CheckDatasetExists(ID, state string) error
-	CheckEditionExists(ID, editionID, state string) error
-	GetDataset(ID string) (*models.DatasetUpdate, error)
+	AddDimensionToInstance(ctx context.Context, dimension *models.CachedDimensionOption) error
+	AddEventToInstance(ctx context.Context, currentInstance *models.Instance, event *models.Event, eTagSelector string) (newETag string, err error)
+	AddInstance(ctx context.Context, instance *models.Instance) (*models.Instance, error)
+	CheckDatasetExists(ctx context.Context, ID, state string) error
+	CheckEditionExists(ctx context.Context, ID, editionID, state string) error
+	GetDataset(ctx context.Context, ID string) (*models.DatasetUpdate, error)
 	GetDatasets(ctx context.Context, offset, limit int, authorised bool) ([]*models.DatasetUpdate, int, error)
+	GetDatasetsByIDs(ctx context.Context, ids []string) ([]*models.DatasetUpdate, error)
 	GetDimensionsFromInstance(ctx context.Context, ID string, offset, limit int) ([]*models.DimensionOption, int, error)
-	GetDimensions(datasetID, versionID string) ([]bson.M, error)
+	GetDimensions(ctx context.Context, datasetID, versionID string) ([]bson.M, error)
+	GetDimensionSummaries(ctx context.Context, instanceID string) ([]*models.DimensionSummary, error)
 	GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset, limit int) ([]*models.PublicDimensionOption, int, error)
-	GetDimensionOptionsFromIDs(version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error)
-	GetEdition(ID, editionID, state string) (*models.EditionUpdate, error)
+	GetDimensionOptionsFromIDs(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error)
+	GetEdition(ctx context.Context, ID, editionID, state string) (*models.EditionUpdate, error)
 	GetEditions(ctx context.Context, ID, state string, offset, limit int, authorised bool) ([]*models.EditionUpdate, int, error)
 	GetInstances(ctx context.Context, states []string, datasets []string, offset, limit int) ([]*models.Instance, int, error)
-	GetInstance(ID, eTagSelector string) (*models.Instance, error)
-	GetNextVersion(datasetID, editionID string) (int, error)
-	GetVersion(datasetID, editionID string, version int, state string) (*models.Version, error)
+	GetInstance(ctx context.Context, ID, eTagSelector string) (*models.Instance, error)
+	GetNextVersion(ctx context.Context, datasetID, editionID string) (int, error)
+	GetPreviousPublishedVersion(ctx context.Context, datasetID, editionID string, version int) (*models.Version, error)
+	GetTopics(ctx context.Context) ([]*models.Topic, error)
+	GetTopicsPage(ctx context.Context, offset, limit int) ([]*models.Topic, int, error)
+	GetTopic(ctx context.Context, id string) (*models.Topic, error)
+	GetTopicDatasets(ctx context.Context, topicIDs []string, offset, limit int) ([]*models.DatasetUpdate, int, error)
+	CountTopicDatasets(ctx context.Context, id string) (int, error)
+	GetDatasetsRelatedTo(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error)
+	GetLinkReports(ctx context.Context, offset, limit int) ([]*models.LinkReport, int, error)
+	GetLinkReport(ctx context.Context, datasetID string) (*models.LinkReport, error)
+	GetVersion(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error)
 	GetUniqueDimensionAndOptions(ctx context.Context, ID, dimension string, offset, limit int) ([]*string, int, error)
 	GetVersions(ctx context.Context, datasetID, editionID, state string, offset, limit int) ([]models.Version, int, error)
-	UpdateDataset(ctx context.Context, ID string, dataset *models.Dataset, currentState string) error
-	UpdateDatasetWithAssociation(ID, state string, version *models.Version) error
-	UpdateDimensionNodeIDAndOrder(dimension *models.DimensionOption) error
+	GetVersionsInCollections(ctx context.Context) ([]models.Version, error)
+	UpdateDataset(ctx context.Context, currentDataset *models.DatasetUpdate, dataset *models.Dataset, eTagSelector string) (newETag string, err error)
+	UpdateDatasetWithAssociation(ctx context.Context, currentDataset *models.DatasetUpdate, state string, version *models.Version) error
+	UpdateDimensionNodeIDAndOrder(ctx context.Context, dimension *models.DimensionOption) error
 	UpdateInstance(ctx context.Context, currentInstance, updatedInstance *models.Instance, eTagSelector string) (newETag string, err error)
-	UpdateObservationInserted(currentInstance *models.Instance, observationInserted int64, eTagSelector string) (newETag string, err error)
-	UpdateImportObservationsTaskState(currentInstance *models.Instance, state, eTagSelector string) (newETag string, err error)
-	UpdateBuildHierarchyTaskState(currentInstance *models.Instance, dimension, state, eTagSelector string) (newETag string, err error)
-	UpdateBuildSearchTaskState(currentInstance *models.Instance, dimension, state, eTagSelector string) (newETag string, err error)
-	UpdateETagForNodeIDAndOrder(currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (newETag string, err error)
-	UpdateETagForOptions(currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (newETag string, err error)
-	UpdateVersion(ID string, version *models.Version) error
-	UpsertContact(ID string, update interface{}) error
-	UpsertDataset(ID string, datasetDoc *models.DatasetUpdate) error
-	UpsertEdition(datasetID, edition string, editionDoc *models.EditionUpdate) error
-	UpsertVersion(ID string, versionDoc *models.Version) error
-	DeleteDataset(ID string) error
-	DeleteEdition(ID string) error
+	UpdateObservationInserted(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (newETag string, err error)
+	UpdateImportObservationsTaskState(ctx context.Context, currentInstance *models.Instance, state, eTagSelector string) (newETag string, err error)
+	UpdateBuildHierarchyTaskState(ctx context.Context, currentInstance *models.Instance, dimension, state, eTagSelector string) (newETag string, err error)
+	UpdateBuildSearchTaskState(ctx context.Context, currentInstance *models.Instance, dimension, state, eTagSelector string) (newETag string, err error)
+	RetryImportTasks(ctx context.Context, currentInstance *models.Instance, state string, tasks *models.InstanceImportTasks, event *models.Event, eTagSelector string) (newETag string, err error)
+	UpdateETagForNodeIDAndOrder(ctx context.Context, currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (newETag string, err error)
+	UpdateETagForOptions(ctx context.Context, currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (newETag string, err error)
+	UpdateVersion(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (newETag string, err error)
+	UpsertContact(ctx context.Context, ID string, update interface{}) error
+	UpsertDataset(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate, eTagSelector string) error
+	UpsertEdition(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error
+	UpsertVersion(ctx context.Context, ID string, versionDoc *models.Version) error
+	UpsertTopic(ctx context.Context, topic *models.Topic) error
+	UpsertLinkReport(ctx context.Context, report *models.LinkReport) error
+	DeleteDataset(ctx context.Context, ID string) error
+	DeleteEdition(ctx context.Context, ID string) error
+	DeleteTopic(ctx context.Context, id string) error
+	DeleteLinkReportsExcept(ctx context.Context, datasetIDs []string) error
+	GetDatasetInstances(ctx context.Context, datasetID string) ([]*models.Instance, error)
+	DeleteInstance(ctx context.Context, instanceID string) error
+	CountDimensionOptions(ctx context.Context, instanceID string) (int, error)
+	DeleteDimensionOptions(ctx context.Context, instanceID string) (int, error)
+	AddDeleteJob(ctx context.Context, job *models.DeleteJob) error
+	GetDeleteJob(ctx context.Context, jobID string) (*models.DeleteJob, error)
+	UpdateDeleteJob(ctx context.Context, job *models.DeleteJob) error
+	GetDeletedDataset(ctx context.Context, id string) (*models.DatasetUpdate, error)
+	GetDatasetsDeletedBefore(ctx context.Context, before time.Time) ([]string, error)
+	GetDatasetEditions(ctx context.Context, datasetID string) ([]*models.EditionUpdate, error)
+	SoftDeleteDataset(ctx context.Context, datasetID, eTagSelector, deletedBy string, deletedAt time.Time) error
+	RestoreDataset(ctx context.Context, datasetID string) error
+	SoftDeleteEdition(ctx context.Context, datasetID, edition, eTagSelector, deletedBy string, deletedAt time.Time) error
+	GetEditionsDeletedBefore(ctx context.Context, before time.Time) ([]*models.EditionUpdate, error)
+	LockPurge(ctx context.Context, ttl time.Duration) (lockID string, err error)
+	RenewPurgeLock(ctx context.Context, lockID string, ttl time.Duration) error
+	UnlockPurge(ctx context.Context, lockID string) error
+	LockLinkCheck(ctx context.Context, ttl time.Duration) (lockID string, err error)
+	RenewLinkCheckLock(ctx context.Context, lockID string, ttl time.Duration) error
+	UnlockLinkCheck(ctx context.Context, lockID string) error
+	ClearLatestEditions(ctx context.Context, datasetID, edition string) error
 	AcquireInstanceLock(ctx context.Context, instanceID string) (lockID string, err error)
-	UnlockInstance(lockID string) error
+	UnlockInstance(ctx context.Context, lockID string) error
 }
 
 // MongoDB represents all the required methods from mongo DB
@@ -64,12 +103,16 @@ type MongoDB interface {
 	dataMongoDB
 	Close(context.Context) error
 	Checker(context.Context, *healthcheck.CheckState) error
+	MigrateLinks(ctx context.Context, apiURL string) (int, error)
+	BackfillETags(ctx context.Context) (int, error)
 }
 
 // dataGraphDB represents the required methods to access data from GraphDB
 type dataGraphDB interface {
 	AddVersionDetailsToInstance(ctx context.Context, instanceID string, datasetID string, edition string, version int) error
 	SetInstanceIsPublished(ctx context.Context, instanceID string) error
+	CountInstanceNodes(ctx context.Context, instanceID string) (int64, error)
+	DeleteInstanceNodes(ctx context.Context, instanceID string) (int64, error)
 }
 
 // GraphDB represents all the required methods from graph DB
@@ -9,38 +9,77 @@ import (
 	"github.com/ONSdigital/dp-dataset-api/store"
 	"github.com/globalsign/mgo/bson"
 	"sync"
+	"time"
 )
 
 var (
 	lockStorerMockAcquireInstanceLock               sync.RWMutex
+	lockStorerMockAddDeleteJob                      sync.RWMutex
 	lockStorerMockAddDimensionToInstance            sync.RWMutex
 	lockStorerMockAddEventToInstance                sync.RWMutex
 	lockStorerMockAddInstance                       sync.RWMutex
 	lockStorerMockAddVersionDetailsToInstance       sync.RWMutex
 	lockStorerMockCheckDatasetExists                sync.RWMutex
 	lockStorerMockCheckEditionExists                sync.RWMutex
+	lockStorerMockClearLatestEditions               sync.RWMutex
+	lockStorerMockCountDimensionOptions             sync.RWMutex
+	lockStorerMockCountInstanceNodes                sync.RWMutex
+	lockStorerMockCountTopicDatasets                sync.RWMutex
 	lockStorerMockDeleteDataset                     sync.RWMutex
+	lockStorerMockDeleteDimensionOptions            sync.RWMutex
 	lockStorerMockDeleteEdition                     sync.RWMutex
+	lockStorerMockDeleteInstance                    sync.RWMutex
+	lockStorerMockDeleteInstanceNodes               sync.RWMutex
+	lockStorerMockDeleteLinkReportsExcept           sync.RWMutex
+	lockStorerMockDeleteTopic                       sync.RWMutex
 	lockStorerMockGetDataset                        sync.RWMutex
+	lockStorerMockGetDatasetEditions                sync.RWMutex
+	lockStorerMockGetDatasetInstances               sync.RWMutex
 	lockStorerMockGetDatasets                       sync.RWMutex
+	lockStorerMockGetDatasetsByIDs                  sync.RWMutex
+	lockStorerMockGetDatasetsDeletedBefore          sync.RWMutex
+	lockStorerMockGetDatasetsRelatedTo              sync.RWMutex
+	lockStorerMockGetDeleteJob                      sync.RWMutex
+	lockStorerMockGetDeletedDataset                 sync.RWMutex
 	lockStorerMockGetDimensionOptions               sync.RWMutex
 	lockStorerMockGetDimensionOptionsFromIDs        sync.RWMutex
+	lockStorerMockGetDimensionSummaries             sync.RWMutex
 	lockStorerMockGetDimensions                     sync.RWMutex
 	lockStorerMockGetDimensionsFromInstance         sync.RWMutex
 	lockStorerMockGetEdition                        sync.RWMutex
 	lockStorerMockGetEditions                       sync.RWMutex
+	lockStorerMockGetEditionsDeletedBefore          sync.RWMutex
 	lockStorerMockGetInstance                       sync.RWMutex
 	lockStorerMockGetInstances                      sync.RWMutex
+	lockStorerMockGetLinkReport                     sync.RWMutex
+	lockStorerMockGetLinkReports                    sync.RWMutex
 	lockStorerMockGetNextVersion                    sync.RWMutex
+	lockStorerMockGetPreviousPublishedVersion       sync.RWMutex
+	lockStorerMockGetTopic                          sync.RWMutex
+	lockStorerMockGetTopicDatasets                  sync.RWMutex
+	lockStorerMockGetTopics                         sync.RWMutex
+	lockStorerMockGetTopicsPage                     sync.RWMutex
 	lockStorerMockGetUniqueDimensionAndOptions      sync.RWMutex
 	lockStorerMockGetVersion                        sync.RWMutex
 	lockStorerMockGetVersions                       sync.RWMutex
+	lockStorerMockGetVersionsInCollections          sync.RWMutex
+	lockStorerMockLockLinkCheck                     sync.RWMutex
+	lockStorerMockLockPurge                         sync.RWMutex
+	lockStorerMockRenewLinkCheckLock                sync.RWMutex
+	lockStorerMockRenewPurgeLock                    sync.RWMutex
+	lockStorerMockRestoreDataset                    sync.RWMutex
+	lockStorerMockRetryImportTasks                  sync.RWMutex
 	lockStorerMockSetInstanceIsPublished            sync.RWMutex
+	lockStorerMockSoftDeleteDataset                 sync.RWMutex
+	lockStorerMockSoftDeleteEdition                 sync.RWMutex
 	lockStorerMockUnlockInstance                    sync.RWMutex
+	lockStorerMockUnlockLinkCheck                   sync.RWMutex
+	lockStorerMockUnlockPurge                       sync.RWMutex
 	lockStorerMockUpdateBuildHierarchyTaskState     sync.RWMutex
 	lockStorerMockUpdateBuildSearchTaskState        sync.RWMutex
 	lockStorerMockUpdateDataset                     sync.RWMutex
 	lockStorerMockUpdateDatasetWithAssociation      sync.RWMutex
+	lockStorerMockUpdateDeleteJob                   sync.RWMutex
 	lockStorerMockUpdateDimensionNodeIDAndOrder     sync.RWMutex
 	lockStorerMockUpdateETagForNodeIDAndOrder       sync.RWMutex
 	lockStorerMockUpdateETagForOptions              sync.RWMutex
@@ -51,6 +90,8 @@ var (
 	lockStorerMockUpsertContact                     sync.RWMutex
 	lockStorerMockUpsertDataset                     sync.RWMutex
 	lockStorerMockUpsertEdition                     sync.RWMutex
+	lockStorerMockUpsertLinkReport                  sync.RWMutex
+	lockStorerMockUpsertTopic                       sync.RWMutex
 	lockStorerMockUpsertVersion                     sync.RWMutex
 )
 
@@ -67,121 +108,241 @@ var _ store.Storer = &StorerMock{}
 //             AcquireInstanceLockFunc: func(ctx context.Context, instanceID string) (string, error) {
 // 	               panic("mock out the AcquireInstanceLock method")
 //             },
-//             AddDimensionToInstanceFunc: func(dimension *models.CachedDimensionOption) error {
+//             AddDeleteJobFunc: func(ctx context.Context, job *models.DeleteJob) error {
+// 	               panic("mock out the AddDeleteJob method")
+//             },
+//             AddDimensionToInstanceFunc: func(ctx context.Context, dimension *models.CachedDimensionOption) error {
 // 	               panic("mock out the AddDimensionToInstance method")
 //             },
-//             AddEventToInstanceFunc: func(currentInstance *models.Instance, event *models.Event, eTagSelector string) (string, error) {
+//             AddEventToInstanceFunc: func(ctx context.Context, currentInstance *models.Instance, event *models.Event, eTagSelector string) (string, error) {
 // 	               panic("mock out the AddEventToInstance method")
 //             },
-//             AddInstanceFunc: func(instance *models.Instance) (*models.Instance, error) {
+//             AddInstanceFunc: func(ctx context.Context, instance *models.Instance) (*models.Instance, error) {
 // 	               panic("mock out the AddInstance method")
 //             },
 //             AddVersionDetailsToInstanceFunc: func(ctx context.Context, instanceID string, datasetID string, edition string, version int) error {
 // 	               panic("mock out the AddVersionDetailsToInstance method")
 //             },
-//             CheckDatasetExistsFunc: func(ID string, state string) error {
+//             CheckDatasetExistsFunc: func(ctx context.Context, ID string, state string) error {
 // 	               panic("mock out the CheckDatasetExists method")
 //             },
-//             CheckEditionExistsFunc: func(ID string, editionID string, state string) error {
+//             CheckEditionExistsFunc: func(ctx context.Context, ID string, editionID string, state string) error {
 // 	               panic("mock out the CheckEditionExists method")
 //             },
-//             DeleteDatasetFunc: func(ID string) error {
+//             ClearLatestEditionsFunc: func(ctx context.Context, datasetID string, edition string) error {
+// 	               panic("mock out the ClearLatestEditions method")
+//             },
+//             CountDimensionOptionsFunc: func(ctx context.Context, instanceID string) (int, error) {
+// 	               panic("mock out the CountDimensionOptions method")
+//             },
+//             CountInstanceNodesFunc: func(ctx context.Context, instanceID string) (int64, error) {
+// 	               panic("mock out the CountInstanceNodes method")
+//             },
+//             CountTopicDatasetsFunc: func(ctx context.Context, id string) (int, error) {
+// 	               panic("mock out the CountTopicDatasets method")
+//             },
+//             DeleteDatasetFunc: func(ctx context.Context, ID string) error {
 // 	               panic("mock out the DeleteDataset method")
 //             },
-//             DeleteEditionFunc: func(ID string) error {
+//             DeleteDimensionOptionsFunc: func(ctx context.Context, instanceID string) (int, error) {
+// 	               panic("mock out the DeleteDimensionOptions method")
+//             },
+//             DeleteEditionFunc: func(ctx context.Context, ID string) error {
 // 	               panic("mock out the DeleteEdition method")
 //             },
-//             GetDatasetFunc: func(ID string) (*models.DatasetUpdate, error) {
+//             DeleteInstanceFunc: func(ctx context.Context, instanceID string) error {
+// 	               panic("mock out the DeleteInstance method")
+//             },
+//             DeleteInstanceNodesFunc: func(ctx context.Context, instanceID string) (int64, error) {
+// 	               panic("mock out the DeleteInstanceNodes method")
+//             },
+//             DeleteLinkReportsExceptFunc: func(ctx context.Context, datasetIDs []string) error {
+// 	               panic("mock out the DeleteLinkReportsExcept method")
+//             },
+//             DeleteTopicFunc: func(ctx context.Context, id string) error {
+// 	               panic("mock out the DeleteTopic method")
+//             },
+//             GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
 // 	               panic("mock out the GetDataset method")
 //             },
+//             GetDatasetEditionsFunc: func(ctx context.Context, datasetID string) ([]*models.EditionUpdate, error) {
+// 	               panic("mock out the GetDatasetEditions method")
+//             },
+//             GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+// 	               panic("mock out the GetDatasetInstances method")
+//             },
 //             GetDatasetsFunc: func(ctx context.Context, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
 // 	               panic("mock out the GetDatasets method")
 //             },
+//             GetDatasetsByIDsFunc: func(ctx context.Context, ids []string) ([]*models.DatasetUpdate, error) {
+// 	               panic("mock out the GetDatasetsByIDs method")
+//             },
+//             GetDatasetsDeletedBeforeFunc: func(ctx context.Context, before time.Time) ([]string, error) {
+// 	               panic("mock out the GetDatasetsDeletedBefore method")
+//             },
+//             GetDatasetsRelatedToFunc: func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+// 	               panic("mock out the GetDatasetsRelatedTo method")
+//             },
+//             GetDeleteJobFunc: func(ctx context.Context, jobID string) (*models.DeleteJob, error) {
+// 	               panic("mock out the GetDeleteJob method")
+//             },
+//             GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+// 	               panic("mock out the GetDeletedDataset method")
+//             },
 //             GetDimensionOptionsFunc: func(ctx context.Context, version *models.Version, dimension string, offset int, limit int) ([]*models.PublicDimensionOption, int, error) {
 // 	               panic("mock out the GetDimensionOptions method")
 //             },
-//             GetDimensionOptionsFromIDsFunc: func(version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
+//             GetDimensionOptionsFromIDsFunc: func(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
 // 	               panic("mock out the GetDimensionOptionsFromIDs method")
 //             },
-//             GetDimensionsFunc: func(datasetID string, versionID string) ([]bson.M, error) {
+//             GetDimensionSummariesFunc: func(ctx context.Context, instanceID string) ([]*models.DimensionSummary, error) {
+// 	               panic("mock out the GetDimensionSummaries method")
+//             },
+//             GetDimensionsFunc: func(ctx context.Context, datasetID string, versionID string) ([]bson.M, error) {
 // 	               panic("mock out the GetDimensions method")
 //             },
 //             GetDimensionsFromInstanceFunc: func(ctx context.Context, ID string, offset int, limit int) ([]*models.DimensionOption, int, error) {
 // 	               panic("mock out the GetDimensionsFromInstance method")
 //             },
-//             GetEditionFunc: func(ID string, editionID string, state string) (*models.EditionUpdate, error) {
+//             GetEditionFunc: func(ctx context.Context, ID string, editionID string, state string) (*models.EditionUpdate, error) {
 // 	               panic("mock out the GetEdition method")
 //             },
 //             GetEditionsFunc: func(ctx context.Context, ID string, state string, offset int, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
 // 	               panic("mock out the GetEditions method")
 //             },
-//             GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+//             GetEditionsDeletedBeforeFunc: func(ctx context.Context, before time.Time) ([]*models.EditionUpdate, error) {
+// 	               panic("mock out the GetEditionsDeletedBefore method")
+//             },
+//             GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 // 	               panic("mock out the GetInstance method")
 //             },
 //             GetInstancesFunc: func(ctx context.Context, states []string, datasets []string, offset int, limit int) ([]*models.Instance, int, error) {
 // 	               panic("mock out the GetInstances method")
 //             },
-//             GetNextVersionFunc: func(datasetID string, editionID string) (int, error) {
+//             GetLinkReportFunc: func(ctx context.Context, datasetID string) (*models.LinkReport, error) {
+// 	               panic("mock out the GetLinkReport method")
+//             },
+//             GetLinkReportsFunc: func(ctx context.Context, offset int, limit int) ([]*models.LinkReport, int, error) {
+// 	               panic("mock out the GetLinkReports method")
+//             },
+//             GetNextVersionFunc: func(ctx context.Context, datasetID string, editionID string) (int, error) {
 // 	               panic("mock out the GetNextVersion method")
 //             },
+//             GetPreviousPublishedVersionFunc: func(ctx context.Context, datasetID string, editionID string, version int) (*models.Version, error) {
+// 	               panic("mock out the GetPreviousPublishedVersion method")
+//             },
+//             GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+// 	               panic("mock out the GetTopic method")
+//             },
+//             GetTopicDatasetsFunc: func(ctx context.Context, topicIDs []string, offset int, limit int) ([]*models.DatasetUpdate, int, error) {
+// 	               panic("mock out the GetTopicDatasets method")
+//             },
+//             GetTopicsFunc: func(ctx context.Context) ([]*models.Topic, error) {
+// 	               panic("mock out the GetTopics method")
+//             },
+//             GetTopicsPageFunc: func(ctx context.Context, offset int, limit int) ([]*models.Topic, int, error) {
+// 	               panic("mock out the GetTopicsPage method")
+//             },
 //             GetUniqueDimensionAndOptionsFunc: func(ctx context.Context, ID string, dimension string, offset int, limit int) ([]*string, int, error) {
 // 	               panic("mock out the GetUniqueDimensionAndOptions method")
 //             },
-//             GetVersionFunc: func(datasetID string, editionID string, version int, state string) (*models.Version, error) {
+//             GetVersionFunc: func(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error) {
 // 	               panic("mock out the GetVersion method")
 //             },
 //             GetVersionsFunc: func(ctx context.Context, datasetID string, editionID string, state string, offset int, limit int) ([]models.Version, int, error) {
 // 	               panic("mock out the GetVersions method")
 //             },
+//             GetVersionsInCollectionsFunc: func(ctx context.Context) ([]models.Version, error) {
+// 	               panic("mock out the GetVersionsInCollections method")
+//             },
+//             LockLinkCheckFunc: func(ctx context.Context, ttl time.Duration) (string, error) {
+// 	               panic("mock out the LockLinkCheck method")
+//             },
+//             LockPurgeFunc: func(ctx context.Context, ttl time.Duration) (string, error) {
+// 	               panic("mock out the LockPurge method")
+//             },
+//             RenewLinkCheckLockFunc: func(ctx context.Context, lockID string, ttl time.Duration) error {
+// 	               panic("mock out the RenewLinkCheckLock method")
+//             },
+//             RenewPurgeLockFunc: func(ctx context.Context, lockID string, ttl time.Duration) error {
+// 	               panic("mock out the RenewPurgeLock method")
+//             },
+//             RestoreDatasetFunc: func(ctx context.Context, datasetID string) error {
+// 	               panic("mock out the RestoreDataset method")
+//             },
+//             RetryImportTasksFunc: func(ctx context.Context, currentInstance *models.Instance, state string, tasks *models.InstanceImportTasks, event *models.Event, eTagSelector string) (string, error) {
+// 	               panic("mock out the RetryImportTasks method")
+//             },
 //             SetInstanceIsPublishedFunc: func(ctx context.Context, instanceID string) error {
 // 	               panic("mock out the SetInstanceIsPublished method")
 //             },
-//             UnlockInstanceFunc: func(lockID string) error {
+//             SoftDeleteDatasetFunc: func(ctx context.Context, datasetID string, eTagSelector string, deletedBy string, deletedAt time.Time) error {
+// 	               panic("mock out the SoftDeleteDataset method")
+//             },
+//             SoftDeleteEditionFunc: func(ctx context.Context, datasetID string, edition string, eTagSelector string, deletedBy string, deletedAt time.Time) error {
+// 	               panic("mock out the SoftDeleteEdition method")
+//             },
+//             UnlockInstanceFunc: func(ctx context.Context, lockID string) error {
 // 	               panic("mock out the UnlockInstance method")
 //             },
-//             UpdateBuildHierarchyTaskStateFunc: func(currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
+//             UnlockLinkCheckFunc: func(ctx context.Context, lockID string) error {
+// 	               panic("mock out the UnlockLinkCheck method")
+//             },
+//             UnlockPurgeFunc: func(ctx context.Context, lockID string) error {
+// 	               panic("mock out the UnlockPurge method")
+//             },
+//             UpdateBuildHierarchyTaskStateFunc: func(ctx context.Context, currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateBuildHierarchyTaskState method")
 //             },
-//             UpdateBuildSearchTaskStateFunc: func(currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
+//             UpdateBuildSearchTaskStateFunc: func(ctx context.Context, currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateBuildSearchTaskState method")
 //             },
-//             UpdateDatasetFunc: func(ctx context.Context, ID string, dataset *models.Dataset, currentState string) error {
+//             UpdateDatasetFunc: func(ctx context.Context, currentDataset *models.DatasetUpdate, dataset *models.Dataset, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateDataset method")
 //             },
-//             UpdateDatasetWithAssociationFunc: func(ID string, state string, version *models.Version) error {
+//             UpdateDatasetWithAssociationFunc: func(ctx context.Context, currentDataset *models.DatasetUpdate, state string, version *models.Version) error {
 // 	               panic("mock out the UpdateDatasetWithAssociation method")
 //             },
-//             UpdateDimensionNodeIDAndOrderFunc: func(dimension *models.DimensionOption) error {
+//             UpdateDeleteJobFunc: func(ctx context.Context, job *models.DeleteJob) error {
+// 	               panic("mock out the UpdateDeleteJob method")
+//             },
+//             UpdateDimensionNodeIDAndOrderFunc: func(ctx context.Context, dimension *models.DimensionOption) error {
 // 	               panic("mock out the UpdateDimensionNodeIDAndOrder method")
 //             },
-//             UpdateETagForNodeIDAndOrderFunc: func(currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
+//             UpdateETagForNodeIDAndOrderFunc: func(ctx context.Context, currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateETagForNodeIDAndOrder method")
 //             },
-//             UpdateETagForOptionsFunc: func(currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error) {
+//             UpdateETagForOptionsFunc: func(ctx context.Context, currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateETagForOptions method")
 //             },
-//             UpdateImportObservationsTaskStateFunc: func(currentInstance *models.Instance, state string, eTagSelector string) (string, error) {
+//             UpdateImportObservationsTaskStateFunc: func(ctx context.Context, currentInstance *models.Instance, state string, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateImportObservationsTaskState method")
 //             },
 //             UpdateInstanceFunc: func(ctx context.Context, currentInstance *models.Instance, updatedInstance *models.Instance, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateInstance method")
 //             },
-//             UpdateObservationInsertedFunc: func(currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error) {
+//             UpdateObservationInsertedFunc: func(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateObservationInserted method")
 //             },
-//             UpdateVersionFunc: func(ID string, version *models.Version) error {
+//             UpdateVersionFunc: func(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateVersion method")
 //             },
-//             UpsertContactFunc: func(ID string, update interface{}) error {
+//             UpsertContactFunc: func(ctx context.Context, ID string, update interface{}) error {
 // 	               panic("mock out the UpsertContact method")
 //             },
-//             UpsertDatasetFunc: func(ID string, datasetDoc *models.DatasetUpdate) error {
+//             UpsertDatasetFunc: func(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
 // 	               panic("mock out the UpsertDataset method")
 //             },
-//             UpsertEditionFunc: func(datasetID string, edition string, editionDoc *models.EditionUpdate) error {
+//             UpsertEditionFunc: func(ctx context.Context, datasetID string, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error {
 // 	               panic("mock out the UpsertEdition method")
 //             },
-//             UpsertVersionFunc: func(ID string, versionDoc *models.Version) error {
+//             UpsertLinkReportFunc: func(ctx context.Context, report *models.LinkReport) error {
+// 	               panic("mock out the UpsertLinkReport method")
+//             },
+//             UpsertTopicFunc: func(ctx context.Context, topic *models.Topic) error {
+// 	               panic("mock out the UpsertTopic method")
+//             },
+//             UpsertVersionFunc: func(ctx context.Context, ID string, versionDoc *models.Version) error {
 // 	               panic("mock out the UpsertVersion method")
 //             },
 //         }
@@ -194,122 +355,242 @@ type StorerMock struct {
 	// AcquireInstanceLockFunc mocks the AcquireInstanceLock method.
 	AcquireInstanceLockFunc func(ctx context.Context, instanceID string) (string, error)
 
+	// AddDeleteJobFunc mocks the AddDeleteJob method.
+	AddDeleteJobFunc func(ctx context.Context, job *models.DeleteJob) error
+
 	// AddDimensionToInstanceFunc mocks the AddDimensionToInstance method.
-	AddDimensionToInstanceFunc func(dimension *models.CachedDimensionOption) error
+	AddDimensionToInstanceFunc func(ctx context.Context, dimension *models.CachedDimensionOption) error
 
 	// AddEventToInstanceFunc mocks the AddEventToInstance method.
-	AddEventToInstanceFunc func(currentInstance *models.Instance, event *models.Event, eTagSelector string) (string, error)
+	AddEventToInstanceFunc func(ctx context.Context, currentInstance *models.Instance, event *models.Event, eTagSelector string) (string, error)
 
 	// AddInstanceFunc mocks the AddInstance method.
-	AddInstanceFunc func(instance *models.Instance) (*models.Instance, error)
+	AddInstanceFunc func(ctx context.Context, instance *models.Instance) (*models.Instance, error)
 
 	// AddVersionDetailsToInstanceFunc mocks the AddVersionDetailsToInstance method.
 	AddVersionDetailsToInstanceFunc func(ctx context.Context, instanceID string, datasetID string, edition string, version int) error
 
 	// CheckDatasetExistsFunc mocks the CheckDatasetExists method.
-	CheckDatasetExistsFunc func(ID string, state string) error
+	CheckDatasetExistsFunc func(ctx context.Context, ID string, state string) error
 
 	// CheckEditionExistsFunc mocks the CheckEditionExists method.
-	CheckEditionExistsFunc func(ID string, editionID string, state string) error
+	CheckEditionExistsFunc func(ctx context.Context, ID string, editionID string, state string) error
+
+	// ClearLatestEditionsFunc mocks the ClearLatestEditions method.
+	ClearLatestEditionsFunc func(ctx context.Context, datasetID string, edition string) error
+
+	// CountDimensionOptionsFunc mocks the CountDimensionOptions method.
+	CountDimensionOptionsFunc func(ctx context.Context, instanceID string) (int, error)
+
+	// CountInstanceNodesFunc mocks the CountInstanceNodes method.
+	CountInstanceNodesFunc func(ctx context.Context, instanceID string) (int64, error)
+
+	// CountTopicDatasetsFunc mocks the CountTopicDatasets method.
+	CountTopicDatasetsFunc func(ctx context.Context, id string) (int, error)
 
 	// DeleteDatasetFunc mocks the DeleteDataset method.
-	DeleteDatasetFunc func(ID string) error
+	DeleteDatasetFunc func(ctx context.Context, ID string) error
+
+	// DeleteDimensionOptionsFunc mocks the DeleteDimensionOptions method.
+	DeleteDimensionOptionsFunc func(ctx context.Context, instanceID string) (int, error)
 
 	// DeleteEditionFunc mocks the DeleteEdition method.
-	DeleteEditionFunc func(ID string) error
+	DeleteEditionFunc func(ctx context.Context, ID string) error
+
+	// DeleteInstanceFunc mocks the DeleteInstance method.
+	DeleteInstanceFunc func(ctx context.Context, instanceID string) error
+
+	// DeleteInstanceNodesFunc mocks the DeleteInstanceNodes method.
+	DeleteInstanceNodesFunc func(ctx context.Context, instanceID string) (int64, error)
+
+	// DeleteLinkReportsExceptFunc mocks the DeleteLinkReportsExcept method.
+	DeleteLinkReportsExceptFunc func(ctx context.Context, datasetIDs []string) error
+
+	// DeleteTopicFunc mocks the DeleteTopic method.
+	DeleteTopicFunc func(ctx context.Context, id string) error
 
 	// GetDatasetFunc mocks the GetDataset method.
-	GetDatasetFunc func(ID string) (*models.DatasetUpdate, error)
+	GetDatasetFunc func(ctx context.Context, ID string) (*models.DatasetUpdate, error)
+
+	// GetDatasetEditionsFunc mocks the GetDatasetEditions method.
+	GetDatasetEditionsFunc func(ctx context.Context, datasetID string) ([]*models.EditionUpdate, error)
+
+	// GetDatasetInstancesFunc mocks the GetDatasetInstances method.
+	GetDatasetInstancesFunc func(ctx context.Context, datasetID string) ([]*models.Instance, error)
 
 	// GetDatasetsFunc mocks the GetDatasets method.
 	GetDatasetsFunc func(ctx context.Context, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error)
 
+	// GetDatasetsByIDsFunc mocks the GetDatasetsByIDs method.
+	GetDatasetsByIDsFunc func(ctx context.Context, ids []string) ([]*models.DatasetUpdate, error)
+
+	// GetDatasetsDeletedBeforeFunc mocks the GetDatasetsDeletedBefore method.
+	GetDatasetsDeletedBeforeFunc func(ctx context.Context, before time.Time) ([]string, error)
+
+	// GetDatasetsRelatedToFunc mocks the GetDatasetsRelatedTo method.
+	GetDatasetsRelatedToFunc func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error)
+
+	// GetDeleteJobFunc mocks the GetDeleteJob method.
+	GetDeleteJobFunc func(ctx context.Context, jobID string) (*models.DeleteJob, error)
+
+	// GetDeletedDatasetFunc mocks the GetDeletedDataset method.
+	GetDeletedDatasetFunc func(ctx context.Context, id string) (*models.DatasetUpdate, error)
+
 	// GetDimensionOptionsFunc mocks the GetDimensionOptions method.
 	GetDimensionOptionsFunc func(ctx context.Context, version *models.Version, dimension string, offset int, limit int) ([]*models.PublicDimensionOption, int, error)
 
 	// GetDimensionOptionsFromIDsFunc mocks the GetDimensionOptionsFromIDs method.
-	GetDimensionOptionsFromIDsFunc func(version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error)
+	GetDimensionOptionsFromIDsFunc func(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error)
+
+	// GetDimensionSummariesFunc mocks the GetDimensionSummaries method.
+	GetDimensionSummariesFunc func(ctx context.Context, instanceID string) ([]*models.DimensionSummary, error)
 
 	// GetDimensionsFunc mocks the GetDimensions method.
-	GetDimensionsFunc func(datasetID string, versionID string) ([]bson.M, error)
+	GetDimensionsFunc func(ctx context.Context, datasetID string, versionID string) ([]bson.M, error)
 
 	// GetDimensionsFromInstanceFunc mocks the GetDimensionsFromInstance method.
 	GetDimensionsFromInstanceFunc func(ctx context.Context, ID string, offset int, limit int) ([]*models.DimensionOption, int, error)
 
 	// GetEditionFunc mocks the GetEdition method.
-	GetEditionFunc func(ID string, editionID string, state string) (*models.EditionUpdate, error)
+	GetEditionFunc func(ctx context.Context, ID string, editionID string, state string) (*models.EditionUpdate, error)
 
 	// GetEditionsFunc mocks the GetEditions method.
 	GetEditionsFunc func(ctx context.Context, ID string, state string, offset int, limit int, authorised bool) ([]*models.EditionUpdate, int, error)
 
+	// GetEditionsDeletedBeforeFunc mocks the GetEditionsDeletedBefore method.
+	GetEditionsDeletedBeforeFunc func(ctx context.Context, before time.Time) ([]*models.EditionUpdate, error)
+
 	// GetInstanceFunc mocks the GetInstance method.
-	GetInstanceFunc func(ID string, eTagSelector string) (*models.Instance, error)
+	GetInstanceFunc func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error)
 
 	// GetInstancesFunc mocks the GetInstances method.
 	GetInstancesFunc func(ctx context.Context, states []string, datasets []string, offset int, limit int) ([]*models.Instance, int, error)
 
+	// GetLinkReportFunc mocks the GetLinkReport method.
+	GetLinkReportFunc func(ctx context.Context, datasetID string) (*models.LinkReport, error)
+
+	// GetLinkReportsFunc mocks the GetLinkReports method.
+	GetLinkReportsFunc func(ctx context.Context, offset int, limit int) ([]*models.LinkReport, int, error)
+
 	// GetNextVersionFunc mocks the GetNextVersion method.
-	GetNextVersionFunc func(datasetID string, editionID string) (int, error)
+	GetNextVersionFunc func(ctx context.Context, datasetID string, editionID string) (int, error)
+
+	// GetPreviousPublishedVersionFunc mocks the GetPreviousPublishedVersion method.
+	GetPreviousPublishedVersionFunc func(ctx context.Context, datasetID string, editionID string, version int) (*models.Version, error)
+
+	// GetTopicFunc mocks the GetTopic method.
+	GetTopicFunc func(ctx context.Context, id string) (*models.Topic, error)
+
+	// GetTopicDatasetsFunc mocks the GetTopicDatasets method.
+	GetTopicDatasetsFunc func(ctx context.Context, topicIDs []string, offset int, limit int) ([]*models.DatasetUpdate, int, error)
+
+	// GetTopicsFunc mocks the GetTopics method.
+	GetTopicsFunc func(ctx context.Context) ([]*models.Topic, error)
+
+	// GetTopicsPageFunc mocks the GetTopicsPage method.
+	GetTopicsPageFunc func(ctx context.Context, offset int, limit int) ([]*models.Topic, int, error)
 
 	// GetUniqueDimensionAndOptionsFunc mocks the GetUniqueDimensionAndOptions method.
 	GetUniqueDimensionAndOptionsFunc func(ctx context.Context, ID string, dimension string, offset int, limit int) ([]*string, int, error)
 
 	// GetVersionFunc mocks the GetVersion method.
-	GetVersionFunc func(datasetID string, editionID string, version int, state string) (*models.Version, error)
+	GetVersionFunc func(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error)
 
 	// GetVersionsFunc mocks the GetVersions method.
 	GetVersionsFunc func(ctx context.Context, datasetID string, editionID string, state string, offset int, limit int) ([]models.Version, int, error)
 
+	// GetVersionsInCollectionsFunc mocks the GetVersionsInCollections method.
+	GetVersionsInCollectionsFunc func(ctx context.Context) ([]models.Version, error)
+
+	// LockLinkCheckFunc mocks the LockLinkCheck method.
+	LockLinkCheckFunc func(ctx context.Context, ttl time.Duration) (string, error)
+
+	// LockPurgeFunc mocks the LockPurge method.
+	LockPurgeFunc func(ctx context.Context, ttl time.Duration) (string, error)
+
+	// RenewLinkCheckLockFunc mocks the RenewLinkCheckLock method.
+	RenewLinkCheckLockFunc func(ctx context.Context, lockID string, ttl time.Duration) error
+
+	// RenewPurgeLockFunc mocks the RenewPurgeLock method.
+	RenewPurgeLockFunc func(ctx context.Context, lockID string, ttl time.Duration) error
+
+	// RestoreDatasetFunc mocks the RestoreDataset method.
+	RestoreDatasetFunc func(ctx context.Context, datasetID string) error
+
+	// RetryImportTasksFunc mocks the RetryImportTasks method.
+	RetryImportTasksFunc func(ctx context.Context, currentInstance *models.Instance, state string, tasks *models.InstanceImportTasks, event *models.Event, eTagSelector string) (string, error)
+
 	// SetInstanceIsPublishedFunc mocks the SetInstanceIsPublished method.
 	SetInstanceIsPublishedFunc func(ctx context.Context, instanceID string) error
 
+	// SoftDeleteDatasetFunc mocks the SoftDeleteDataset method.
+	SoftDeleteDatasetFunc func(ctx context.Context, datasetID string, eTagSelector string, deletedBy string, deletedAt time.Time) error
+
+	// SoftDeleteEditionFunc mocks the SoftDeleteEdition method.
+	SoftDeleteEditionFunc func(ctx context.Context, datasetID string, edition string, eTagSelector string, deletedBy string, deletedAt time.Time) error
+
 	// UnlockInstanceFunc mocks the UnlockInstance method.
-	UnlockInstanceFunc func(lockID string) error
+	UnlockInstanceFunc func(ctx context.Context, lockID string) error
+
+	// UnlockLinkCheckFunc mocks the UnlockLinkCheck method.
+	UnlockLinkCheckFunc func(ctx context.Context, lockID string) error
+
+	// UnlockPurgeFunc mocks the UnlockPurge method.
+	UnlockPurgeFunc func(ctx context.Context, lockID string) error
 
 	// UpdateBuildHierarchyTaskStateFunc mocks the UpdateBuildHierarchyTaskState method.
-	UpdateBuildHierarchyTaskStateFunc func(currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error)
+	UpdateBuildHierarchyTaskStateFunc func(ctx context.Context, currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error)
 
 	// UpdateBuildSearchTaskStateFunc mocks the UpdateBuildSearchTaskState method.
-	UpdateBuildSearchTaskStateFunc func(currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error)
+	UpdateBuildSearchTaskStateFunc func(ctx context.Context, currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error)
 
 	// UpdateDatasetFunc mocks the UpdateDataset method.
-	UpdateDatasetFunc func(ctx context.Context, ID string, dataset *models.Dataset, currentState string) error
+	UpdateDatasetFunc func(ctx context.Context, currentDataset *models.DatasetUpdate, dataset *models.Dataset, eTagSelector string) (string, error)
 
 	// UpdateDatasetWithAssociationFunc mocks the UpdateDatasetWithAssociation method.
-	UpdateDatasetWithAssociationFunc func(ID string, state string, version *models.Version) error
+	UpdateDatasetWithAssociationFunc func(ctx context.Context, currentDataset *models.DatasetUpdate, state string, version *models.Version) error
+
+	// UpdateDeleteJobFunc mocks the UpdateDeleteJob method.
+	UpdateDeleteJobFunc func(ctx context.Context, job *models.DeleteJob) error
 
 	// UpdateDimensionNodeIDAndOrderFunc mocks the UpdateDimensionNodeIDAndOrder method.
-	UpdateDimensionNodeIDAndOrderFunc func(dimension *models.DimensionOption) error
+	UpdateDimensionNodeIDAndOrderFunc func(ctx context.Context, dimension *models.DimensionOption) error
 
 	// UpdateETagForNodeIDAndOrderFunc mocks the UpdateETagForNodeIDAndOrder method.
-	UpdateETagForNodeIDAndOrderFunc func(currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error)
+	UpdateETagForNodeIDAndOrderFunc func(ctx context.Context, currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error)
 
 	// UpdateETagForOptionsFunc mocks the UpdateETagForOptions method.
-	UpdateETagForOptionsFunc func(currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error)
+	UpdateETagForOptionsFunc func(ctx context.Context, currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error)
 
 	// UpdateImportObservationsTaskStateFunc mocks the UpdateImportObservationsTaskState method.
-	UpdateImportObservationsTaskStateFunc func(currentInstance *models.Instance, state string, eTagSelector string) (string, error)
+	UpdateImportObservationsTaskStateFunc func(ctx context.Context, currentInstance *models.Instance, state string, eTagSelector string) (string, error)
 
 	// UpdateInstanceFunc mocks the UpdateInstance method.
 	UpdateInstanceFunc func(ctx context.Context, currentInstance *models.Instance, updatedInstance *models.Instance, eTagSelector string) (string, error)
 
 	// UpdateObservationInsertedFunc mocks the UpdateObservationInserted method.
-	UpdateObservationInsertedFunc func(currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error)
+	UpdateObservationInsertedFunc func(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error)
 
 	// UpdateVersionFunc mocks the UpdateVersion method.
-	UpdateVersionFunc func(ID string, version *models.Version) error
+	UpdateVersionFunc func(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (string, error)
 
 	// UpsertContactFunc mocks the UpsertContact method.
-	UpsertContactFunc func(ID string, update interface{}) error
+	UpsertContactFunc func(ctx context.Context, ID string, update interface{}) error
 
 	// UpsertDatasetFunc mocks the UpsertDataset method.
-	UpsertDatasetFunc func(ID string, datasetDoc *models.DatasetUpdate) error
+	UpsertDatasetFunc func(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate, eTagSelector string) error
 
 	// UpsertEditionFunc mocks the UpsertEdition method.
-	UpsertEditionFunc func(datasetID string, edition string, editionDoc *models.EditionUpdate) error
+	UpsertEditionFunc func(ctx context.Context, datasetID string, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error
+
+	// UpsertLinkReportFunc mocks the UpsertLinkReport method.
+	UpsertLinkReportFunc func(ctx context.Context, report *models.LinkReport) error
+
+	// UpsertTopicFunc mocks the UpsertTopic method.
+	UpsertTopicFunc func(ctx context.Context, topic *models.Topic) error
 
 	// UpsertVersionFunc mocks the UpsertVersion method.
-	UpsertVersionFunc func(ID string, versionDoc *models.Version) error
+	UpsertVersionFunc func(ctx context.Context, ID string, versionDoc *models.Version) error
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -320,13 +601,24 @@ type StorerMock struct {
 			// InstanceID is the instanceID argument value.
 			InstanceID string
 		}
+		// AddDeleteJob holds details about calls to the AddDeleteJob method.
+		AddDeleteJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Job is the job argument value.
+			Job *models.DeleteJob
+		}
 		// AddDimensionToInstance holds details about calls to the AddDimensionToInstance method.
 		AddDimensionToInstance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// Dimension is the dimension argument value.
 			Dimension *models.CachedDimensionOption
 		}
 		// AddEventToInstance holds details about calls to the AddEventToInstance method.
 		AddEventToInstance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// CurrentInstance is the currentInstance argument value.
 			CurrentInstance *models.Instance
 			// Event is the event argument value.
@@ -336,6 +628,8 @@ type StorerMock struct {
 		}
 		// AddInstance holds details about calls to the AddInstance method.
 		AddInstance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// Instance is the instance argument value.
 			Instance *models.Instance
 		}
@@ -354,6 +648,8 @@ type StorerMock struct {
 		}
 		// CheckDatasetExists holds details about calls to the CheckDatasetExists method.
 		CheckDatasetExists []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 			// State is the state argument value.
@@ -361,6 +657,8 @@ type StorerMock struct {
 		}
 		// CheckEditionExists holds details about calls to the CheckEditionExists method.
 		CheckEditionExists []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 			// EditionID is the editionID argument value.
@@ -368,21 +666,106 @@ type StorerMock struct {
 			// State is the state argument value.
 			State string
 		}
+		// ClearLatestEditions holds details about calls to the ClearLatestEditions method.
+		ClearLatestEditions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// Edition is the edition argument value.
+			Edition string
+		}
+		// CountDimensionOptions holds details about calls to the CountDimensionOptions method.
+		CountDimensionOptions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InstanceID is the instanceID argument value.
+			InstanceID string
+		}
+		// CountInstanceNodes holds details about calls to the CountInstanceNodes method.
+		CountInstanceNodes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InstanceID is the instanceID argument value.
+			InstanceID string
+		}
+		// CountTopicDatasets holds details about calls to the CountTopicDatasets method.
+		CountTopicDatasets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
 		// DeleteDataset holds details about calls to the DeleteDataset method.
 		DeleteDataset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 		}
+		// DeleteDimensionOptions holds details about calls to the DeleteDimensionOptions method.
+		DeleteDimensionOptions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InstanceID is the instanceID argument value.
+			InstanceID string
+		}
 		// DeleteEdition holds details about calls to the DeleteEdition method.
 		DeleteEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 		}
+		// DeleteInstance holds details about calls to the DeleteInstance method.
+		DeleteInstance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InstanceID is the instanceID argument value.
+			InstanceID string
+		}
+		// DeleteInstanceNodes holds details about calls to the DeleteInstanceNodes method.
+		DeleteInstanceNodes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InstanceID is the instanceID argument value.
+			InstanceID string
+		}
+		// DeleteLinkReportsExcept holds details about calls to the DeleteLinkReportsExcept method.
+		DeleteLinkReportsExcept []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetIDs is the datasetIDs argument value.
+			DatasetIDs []string
+		}
+		// DeleteTopic holds details about calls to the DeleteTopic method.
+		DeleteTopic []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
 		// GetDataset holds details about calls to the GetDataset method.
 		GetDataset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 		}
+		// GetDatasetEditions holds details about calls to the GetDatasetEditions method.
+		GetDatasetEditions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+		}
+		// GetDatasetInstances holds details about calls to the GetDatasetInstances method.
+		GetDatasetInstances []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+		}
 		// GetDatasets holds details about calls to the GetDatasets method.
 		GetDatasets []struct {
 			// Ctx is the ctx argument value.
@@ -394,6 +777,41 @@ type StorerMock struct {
 			// Authorised is the authorised argument value.
 			Authorised bool
 		}
+		// GetDatasetsByIDs holds details about calls to the GetDatasetsByIDs method.
+		GetDatasetsByIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Ids is the ids argument value.
+			Ids []string
+		}
+		// GetDatasetsDeletedBefore holds details about calls to the GetDatasetsDeletedBefore method.
+		GetDatasetsDeletedBefore []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Before is the before argument value.
+			Before time.Time
+		}
+		// GetDatasetsRelatedTo holds details about calls to the GetDatasetsRelatedTo method.
+		GetDatasetsRelatedTo []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+		}
+		// GetDeleteJob holds details about calls to the GetDeleteJob method.
+		GetDeleteJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobID is the jobID argument value.
+			JobID string
+		}
+		// GetDeletedDataset holds details about calls to the GetDeletedDataset method.
+		GetDeletedDataset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
 		// GetDimensionOptions holds details about calls to the GetDimensionOptions method.
 		GetDimensionOptions []struct {
 			// Ctx is the ctx argument value.
@@ -409,6 +827,8 @@ type StorerMock struct {
 		}
 		// GetDimensionOptionsFromIDs holds details about calls to the GetDimensionOptionsFromIDs method.
 		GetDimensionOptionsFromIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// Version is the version argument value.
 			Version *models.Version
 			// Dimension is the dimension argument value.
@@ -416,8 +836,17 @@ type StorerMock struct {
 			// Ids is the ids argument value.
 			Ids []string
 		}
+		// GetDimensionSummaries holds details about calls to the GetDimensionSummaries method.
+		GetDimensionSummaries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InstanceID is the instanceID argument value.
+			InstanceID string
+		}
 		// GetDimensions holds details about calls to the GetDimensions method.
 		GetDimensions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// DatasetID is the datasetID argument value.
 			DatasetID string
 			// VersionID is the versionID argument value.
@@ -436,6 +865,8 @@ type StorerMock struct {
 		}
 		// GetEdition holds details about calls to the GetEdition method.
 		GetEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 			// EditionID is the editionID argument value.
@@ -458,8 +889,17 @@ type StorerMock struct {
 			// Authorised is the authorised argument value.
 			Authorised bool
 		}
+		// GetEditionsDeletedBefore holds details about calls to the GetEditionsDeletedBefore method.
+		GetEditionsDeletedBefore []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Before is the before argument value.
+			Before time.Time
+		}
 		// GetInstance holds details about calls to the GetInstance method.
 		GetInstance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 			// ETagSelector is the eTagSelector argument value.
@@ -478,12 +918,73 @@ type StorerMock struct {
 			// Limit is the limit argument value.
 			Limit int
 		}
+		// GetLinkReport holds details about calls to the GetLinkReport method.
+		GetLinkReport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+		}
+		// GetLinkReports holds details about calls to the GetLinkReports method.
+		GetLinkReports []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetNextVersion holds details about calls to the GetNextVersion method.
 		GetNextVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// EditionID is the editionID argument value.
+			EditionID string
+		}
+		// GetPreviousPublishedVersion holds details about calls to the GetPreviousPublishedVersion method.
+		GetPreviousPublishedVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// DatasetID is the datasetID argument value.
 			DatasetID string
 			// EditionID is the editionID argument value.
 			EditionID string
+			// Version is the version argument value.
+			Version int
+		}
+		// GetTopic holds details about calls to the GetTopic method.
+		GetTopic []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// GetTopicDatasets holds details about calls to the GetTopicDatasets method.
+		GetTopicDatasets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TopicIDs is the topicIDs argument value.
+			TopicIDs []string
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetTopics holds details about calls to the GetTopics method.
+		GetTopics []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetTopicsPage holds details about calls to the GetTopicsPage method.
+		GetTopicsPage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
 		}
 		// GetUniqueDimensionAndOptions holds details about calls to the GetUniqueDimensionAndOptions method.
 		GetUniqueDimensionAndOptions []struct {
@@ -500,6 +1001,8 @@ type StorerMock struct {
 		}
 		// GetVersion holds details about calls to the GetVersion method.
 		GetVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// DatasetID is the datasetID argument value.
 			DatasetID string
 			// EditionID is the editionID argument value.
@@ -524,31 +1027,138 @@ type StorerMock struct {
 			// Limit is the limit argument value.
 			Limit int
 		}
-		// SetInstanceIsPublished holds details about calls to the SetInstanceIsPublished method.
-		SetInstanceIsPublished []struct {
+		// GetVersionsInCollections holds details about calls to the GetVersionsInCollections method.
+		GetVersionsInCollections []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// InstanceID is the instanceID argument value.
-			InstanceID string
 		}
-		// UnlockInstance holds details about calls to the UnlockInstance method.
-		UnlockInstance []struct {
+		// LockLinkCheck holds details about calls to the LockLinkCheck method.
+		LockLinkCheck []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// LockPurge holds details about calls to the LockPurge method.
+		LockPurge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// RenewLinkCheckLock holds details about calls to the RenewLinkCheckLock method.
+		RenewLinkCheckLock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// LockID is the lockID argument value.
 			LockID string
+			// TTL is the ttl argument value.
+			TTL time.Duration
 		}
-		// UpdateBuildHierarchyTaskState holds details about calls to the UpdateBuildHierarchyTaskState method.
-		UpdateBuildHierarchyTaskState []struct {
-			// CurrentInstance is the currentInstance argument value.
-			CurrentInstance *models.Instance
-			// Dimension is the dimension argument value.
-			Dimension string
-			// State is the state argument value.
-			State string
+		// RenewPurgeLock holds details about calls to the RenewPurgeLock method.
+		RenewPurgeLock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// LockID is the lockID argument value.
+			LockID string
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// RestoreDataset holds details about calls to the RestoreDataset method.
+		RestoreDataset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+		}
+		// RetryImportTasks holds details about calls to the RetryImportTasks method.
+		RetryImportTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CurrentInstance is the currentInstance argument value.
+			CurrentInstance *models.Instance
+			// State is the state argument value.
+			State string
+			// Tasks is the tasks argument value.
+			Tasks *models.InstanceImportTasks
+			// Event is the event argument value.
+			Event *models.Event
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
+		}
+		// SetInstanceIsPublished holds details about calls to the SetInstanceIsPublished method.
+		SetInstanceIsPublished []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InstanceID is the instanceID argument value.
+			InstanceID string
+		}
+		// SoftDeleteDataset holds details about calls to the SoftDeleteDataset method.
+		SoftDeleteDataset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
+			// DeletedBy is the deletedBy argument value.
+			DeletedBy string
+			// DeletedAt is the deletedAt argument value.
+			DeletedAt time.Time
+		}
+		// SoftDeleteEdition holds details about calls to the SoftDeleteEdition method.
+		SoftDeleteEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// Edition is the edition argument value.
+			Edition string
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
+			// DeletedBy is the deletedBy argument value.
+			DeletedBy string
+			// DeletedAt is the deletedAt argument value.
+			DeletedAt time.Time
+		}
+		// UnlockInstance holds details about calls to the UnlockInstance method.
+		UnlockInstance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// LockID is the lockID argument value.
+			LockID string
+		}
+		// UnlockLinkCheck holds details about calls to the UnlockLinkCheck method.
+		UnlockLinkCheck []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// LockID is the lockID argument value.
+			LockID string
+		}
+		// UnlockPurge holds details about calls to the UnlockPurge method.
+		UnlockPurge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// LockID is the lockID argument value.
+			LockID string
+		}
+		// UpdateBuildHierarchyTaskState holds details about calls to the UpdateBuildHierarchyTaskState method.
+		UpdateBuildHierarchyTaskState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CurrentInstance is the currentInstance argument value.
+			CurrentInstance *models.Instance
+			// Dimension is the dimension argument value.
+			Dimension string
+			// State is the state argument value.
+			State string
 			// ETagSelector is the eTagSelector argument value.
 			ETagSelector string
 		}
 		// UpdateBuildSearchTaskState holds details about calls to the UpdateBuildSearchTaskState method.
 		UpdateBuildSearchTaskState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// CurrentInstance is the currentInstance argument value.
 			CurrentInstance *models.Instance
 			// Dimension is the dimension argument value.
@@ -562,29 +1172,42 @@ type StorerMock struct {
 		UpdateDataset []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ID is the ID argument value.
-			ID string
+			// CurrentDataset is the currentDataset argument value.
+			CurrentDataset *models.DatasetUpdate
 			// Dataset is the dataset argument value.
 			Dataset *models.Dataset
-			// CurrentState is the currentState argument value.
-			CurrentState string
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
 		}
 		// UpdateDatasetWithAssociation holds details about calls to the UpdateDatasetWithAssociation method.
 		UpdateDatasetWithAssociation []struct {
-			// ID is the ID argument value.
-			ID string
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CurrentDataset is the currentDataset argument value.
+			CurrentDataset *models.DatasetUpdate
 			// State is the state argument value.
 			State string
 			// Version is the version argument value.
 			Version *models.Version
 		}
+		// UpdateDeleteJob holds details about calls to the UpdateDeleteJob method.
+		UpdateDeleteJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Job is the job argument value.
+			Job *models.DeleteJob
+		}
 		// UpdateDimensionNodeIDAndOrder holds details about calls to the UpdateDimensionNodeIDAndOrder method.
 		UpdateDimensionNodeIDAndOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// Dimension is the dimension argument value.
 			Dimension *models.DimensionOption
 		}
 		// UpdateETagForNodeIDAndOrder holds details about calls to the UpdateETagForNodeIDAndOrder method.
 		UpdateETagForNodeIDAndOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// CurrentInstance is the currentInstance argument value.
 			CurrentInstance *models.Instance
 			// NodeID is the nodeID argument value.
@@ -596,6 +1219,8 @@ type StorerMock struct {
 		}
 		// UpdateETagForOptions holds details about calls to the UpdateETagForOptions method.
 		UpdateETagForOptions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// CurrentInstance is the currentInstance argument value.
 			CurrentInstance *models.Instance
 			// Option is the option argument value.
@@ -605,6 +1230,8 @@ type StorerMock struct {
 		}
 		// UpdateImportObservationsTaskState holds details about calls to the UpdateImportObservationsTaskState method.
 		UpdateImportObservationsTaskState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// CurrentInstance is the currentInstance argument value.
 			CurrentInstance *models.Instance
 			// State is the state argument value.
@@ -625,6 +1252,8 @@ type StorerMock struct {
 		}
 		// UpdateObservationInserted holds details about calls to the UpdateObservationInserted method.
 		UpdateObservationInserted []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// CurrentInstance is the currentInstance argument value.
 			CurrentInstance *models.Instance
 			// ObservationInserted is the observationInserted argument value.
@@ -634,13 +1263,19 @@ type StorerMock struct {
 		}
 		// UpdateVersion holds details about calls to the UpdateVersion method.
 		UpdateVersion []struct {
-			// ID is the ID argument value.
-			ID string
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CurrentVersion is the currentVersion argument value.
+			CurrentVersion *models.Version
 			// Version is the version argument value.
 			Version *models.Version
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
 		}
 		// UpsertContact holds details about calls to the UpsertContact method.
 		UpsertContact []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 			// Update is the update argument value.
@@ -648,22 +1283,46 @@ type StorerMock struct {
 		}
 		// UpsertDataset holds details about calls to the UpsertDataset method.
 		UpsertDataset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 			// DatasetDoc is the datasetDoc argument value.
 			DatasetDoc *models.DatasetUpdate
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
 		}
 		// UpsertEdition holds details about calls to the UpsertEdition method.
 		UpsertEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// DatasetID is the datasetID argument value.
 			DatasetID string
 			// Edition is the edition argument value.
 			Edition string
 			// EditionDoc is the editionDoc argument value.
 			EditionDoc *models.EditionUpdate
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
+		}
+		// UpsertLinkReport holds details about calls to the UpsertLinkReport method.
+		UpsertLinkReport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Report is the report argument value.
+			Report *models.LinkReport
+		}
+		// UpsertTopic holds details about calls to the UpsertTopic method.
+		UpsertTopic []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Topic is the topic argument value.
+			Topic *models.Topic
 		}
 		// UpsertVersion holds details about calls to the UpsertVersion method.
 		UpsertVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 			// VersionDoc is the versionDoc argument value.
@@ -707,29 +1366,68 @@ func (mock *StorerMock) AcquireInstanceLockCalls() []struct {
 	return calls
 }
 
+// AddDeleteJob calls AddDeleteJobFunc.
+func (mock *StorerMock) AddDeleteJob(ctx context.Context, job *models.DeleteJob) error {
+	if mock.AddDeleteJobFunc == nil {
+		panic("StorerMock.AddDeleteJobFunc: method is nil but Storer.AddDeleteJob was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Job *models.DeleteJob
+	}{
+		Ctx: ctx,
+		Job: job,
+	}
+	lockStorerMockAddDeleteJob.Lock()
+	mock.calls.AddDeleteJob = append(mock.calls.AddDeleteJob, callInfo)
+	lockStorerMockAddDeleteJob.Unlock()
+	return mock.AddDeleteJobFunc(ctx, job)
+}
+
+// AddDeleteJobCalls gets all the calls that were made to AddDeleteJob.
+// Check the length with:
+//     len(mockedStorer.AddDeleteJobCalls())
+func (mock *StorerMock) AddDeleteJobCalls() []struct {
+	Ctx context.Context
+	Job *models.DeleteJob
+} {
+	var calls []struct {
+		Ctx context.Context
+		Job *models.DeleteJob
+	}
+	lockStorerMockAddDeleteJob.RLock()
+	calls = mock.calls.AddDeleteJob
+	lockStorerMockAddDeleteJob.RUnlock()
+	return calls
+}
+
 // AddDimensionToInstance calls AddDimensionToInstanceFunc.
-func (mock *StorerMock) AddDimensionToInstance(dimension *models.CachedDimensionOption) error {
+func (mock *StorerMock) AddDimensionToInstance(ctx context.Context, dimension *models.CachedDimensionOption) error {
 	if mock.AddDimensionToInstanceFunc == nil {
 		panic("StorerMock.AddDimensionToInstanceFunc: method is nil but Storer.AddDimensionToInstance was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		Dimension *models.CachedDimensionOption
 	}{
+		Ctx:       ctx,
 		Dimension: dimension,
 	}
 	lockStorerMockAddDimensionToInstance.Lock()
 	mock.calls.AddDimensionToInstance = append(mock.calls.AddDimensionToInstance, callInfo)
 	lockStorerMockAddDimensionToInstance.Unlock()
-	return mock.AddDimensionToInstanceFunc(dimension)
+	return mock.AddDimensionToInstanceFunc(ctx, dimension)
 }
 
 // AddDimensionToInstanceCalls gets all the calls that were made to AddDimensionToInstance.
 // Check the length with:
 //     len(mockedStorer.AddDimensionToInstanceCalls())
 func (mock *StorerMock) AddDimensionToInstanceCalls() []struct {
+	Ctx       context.Context
 	Dimension *models.CachedDimensionOption
 } {
 	var calls []struct {
+		Ctx       context.Context
 		Dimension *models.CachedDimensionOption
 	}
 	lockStorerMockAddDimensionToInstance.RLock()
@@ -739,15 +1437,17 @@ func (mock *StorerMock) AddDimensionToInstanceCalls() []struct {
 }
 
 // AddEventToInstance calls AddEventToInstanceFunc.
-func (mock *StorerMock) AddEventToInstance(currentInstance *models.Instance, event *models.Event, eTagSelector string) (string, error) {
+func (mock *StorerMock) AddEventToInstance(ctx context.Context, currentInstance *models.Instance, event *models.Event, eTagSelector string) (string, error) {
 	if mock.AddEventToInstanceFunc == nil {
 		panic("StorerMock.AddEventToInstanceFunc: method is nil but Storer.AddEventToInstance was just called")
 	}
 	callInfo := struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Event           *models.Event
 		ETagSelector    string
 	}{
+		Ctx:             ctx,
 		CurrentInstance: currentInstance,
 		Event:           event,
 		ETagSelector:    eTagSelector,
@@ -755,18 +1455,20 @@ func (mock *StorerMock) AddEventToInstance(currentInstance *models.Instance, eve
 	lockStorerMockAddEventToInstance.Lock()
 	mock.calls.AddEventToInstance = append(mock.calls.AddEventToInstance, callInfo)
 	lockStorerMockAddEventToInstance.Unlock()
-	return mock.AddEventToInstanceFunc(currentInstance, event, eTagSelector)
+	return mock.AddEventToInstanceFunc(ctx, currentInstance, event, eTagSelector)
 }
 
 // AddEventToInstanceCalls gets all the calls that were made to AddEventToInstance.
 // Check the length with:
 //     len(mockedStorer.AddEventToInstanceCalls())
 func (mock *StorerMock) AddEventToInstanceCalls() []struct {
+	Ctx             context.Context
 	CurrentInstance *models.Instance
 	Event           *models.Event
 	ETagSelector    string
 } {
 	var calls []struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Event           *models.Event
 		ETagSelector    string
@@ -778,28 +1480,32 @@ func (mock *StorerMock) AddEventToInstanceCalls() []struct {
 }
 
 // AddInstance calls AddInstanceFunc.
-func (mock *StorerMock) AddInstance(instance *models.Instance) (*models.Instance, error) {
+func (mock *StorerMock) AddInstance(ctx context.Context, instance *models.Instance) (*models.Instance, error) {
 	if mock.AddInstanceFunc == nil {
 		panic("StorerMock.AddInstanceFunc: method is nil but Storer.AddInstance was just called")
 	}
 	callInfo := struct {
+		Ctx      context.Context
 		Instance *models.Instance
 	}{
+		Ctx:      ctx,
 		Instance: instance,
 	}
 	lockStorerMockAddInstance.Lock()
 	mock.calls.AddInstance = append(mock.calls.AddInstance, callInfo)
 	lockStorerMockAddInstance.Unlock()
-	return mock.AddInstanceFunc(instance)
+	return mock.AddInstanceFunc(ctx, instance)
 }
 
 // AddInstanceCalls gets all the calls that were made to AddInstance.
 // Check the length with:
 //     len(mockedStorer.AddInstanceCalls())
 func (mock *StorerMock) AddInstanceCalls() []struct {
+	Ctx      context.Context
 	Instance *models.Instance
 } {
 	var calls []struct {
+		Ctx      context.Context
 		Instance *models.Instance
 	}
 	lockStorerMockAddInstance.RLock()
@@ -856,31 +1562,35 @@ func (mock *StorerMock) AddVersionDetailsToInstanceCalls() []struct {
 }
 
 // CheckDatasetExists calls CheckDatasetExistsFunc.
-func (mock *StorerMock) CheckDatasetExists(ID string, state string) error {
+func (mock *StorerMock) CheckDatasetExists(ctx context.Context, ID string, state string) error {
 	if mock.CheckDatasetExistsFunc == nil {
 		panic("StorerMock.CheckDatasetExistsFunc: method is nil but Storer.CheckDatasetExists was just called")
 	}
 	callInfo := struct {
+		Ctx   context.Context
 		ID    string
 		State string
 	}{
+		Ctx:   ctx,
 		ID:    ID,
 		State: state,
 	}
 	lockStorerMockCheckDatasetExists.Lock()
 	mock.calls.CheckDatasetExists = append(mock.calls.CheckDatasetExists, callInfo)
 	lockStorerMockCheckDatasetExists.Unlock()
-	return mock.CheckDatasetExistsFunc(ID, state)
+	return mock.CheckDatasetExistsFunc(ctx, ID, state)
 }
 
 // CheckDatasetExistsCalls gets all the calls that were made to CheckDatasetExists.
 // Check the length with:
 //     len(mockedStorer.CheckDatasetExistsCalls())
 func (mock *StorerMock) CheckDatasetExistsCalls() []struct {
+	Ctx   context.Context
 	ID    string
 	State string
 } {
 	var calls []struct {
+		Ctx   context.Context
 		ID    string
 		State string
 	}
@@ -891,15 +1601,17 @@ func (mock *StorerMock) CheckDatasetExistsCalls() []struct {
 }
 
 // CheckEditionExists calls CheckEditionExistsFunc.
-func (mock *StorerMock) CheckEditionExists(ID string, editionID string, state string) error {
+func (mock *StorerMock) CheckEditionExists(ctx context.Context, ID string, editionID string, state string) error {
 	if mock.CheckEditionExistsFunc == nil {
 		panic("StorerMock.CheckEditionExistsFunc: method is nil but Storer.CheckEditionExists was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		ID        string
 		EditionID string
 		State     string
 	}{
+		Ctx:       ctx,
 		ID:        ID,
 		EditionID: editionID,
 		State:     state,
@@ -907,18 +1619,20 @@ func (mock *StorerMock) CheckEditionExists(ID string, editionID string, state st
 	lockStorerMockCheckEditionExists.Lock()
 	mock.calls.CheckEditionExists = append(mock.calls.CheckEditionExists, callInfo)
 	lockStorerMockCheckEditionExists.Unlock()
-	return mock.CheckEditionExistsFunc(ID, editionID, state)
+	return mock.CheckEditionExistsFunc(ctx, ID, editionID, state)
 }
 
 // CheckEditionExistsCalls gets all the calls that were made to CheckEditionExists.
 // Check the length with:
 //     len(mockedStorer.CheckEditionExistsCalls())
 func (mock *StorerMock) CheckEditionExistsCalls() []struct {
+	Ctx       context.Context
 	ID        string
 	EditionID string
 	State     string
 } {
 	var calls []struct {
+		Ctx       context.Context
 		ID        string
 		EditionID string
 		State     string
@@ -929,30 +1643,178 @@ func (mock *StorerMock) CheckEditionExistsCalls() []struct {
 	return calls
 }
 
+// ClearLatestEditions calls ClearLatestEditionsFunc.
+func (mock *StorerMock) ClearLatestEditions(ctx context.Context, datasetID string, edition string) error {
+	if mock.ClearLatestEditionsFunc == nil {
+		panic("StorerMock.ClearLatestEditionsFunc: method is nil but Storer.ClearLatestEditions was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		Edition   string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		Edition:   edition,
+	}
+	lockStorerMockClearLatestEditions.Lock()
+	mock.calls.ClearLatestEditions = append(mock.calls.ClearLatestEditions, callInfo)
+	lockStorerMockClearLatestEditions.Unlock()
+	return mock.ClearLatestEditionsFunc(ctx, datasetID, edition)
+}
+
+// ClearLatestEditionsCalls gets all the calls that were made to ClearLatestEditions.
+// Check the length with:
+//     len(mockedStorer.ClearLatestEditionsCalls())
+func (mock *StorerMock) ClearLatestEditionsCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	Edition   string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		Edition   string
+	}
+	lockStorerMockClearLatestEditions.RLock()
+	calls = mock.calls.ClearLatestEditions
+	lockStorerMockClearLatestEditions.RUnlock()
+	return calls
+}
+
+// CountDimensionOptions calls CountDimensionOptionsFunc.
+func (mock *StorerMock) CountDimensionOptions(ctx context.Context, instanceID string) (int, error) {
+	if mock.CountDimensionOptionsFunc == nil {
+		panic("StorerMock.CountDimensionOptionsFunc: method is nil but Storer.CountDimensionOptions was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		InstanceID string
+	}{
+		Ctx:        ctx,
+		InstanceID: instanceID,
+	}
+	lockStorerMockCountDimensionOptions.Lock()
+	mock.calls.CountDimensionOptions = append(mock.calls.CountDimensionOptions, callInfo)
+	lockStorerMockCountDimensionOptions.Unlock()
+	return mock.CountDimensionOptionsFunc(ctx, instanceID)
+}
+
+// CountDimensionOptionsCalls gets all the calls that were made to CountDimensionOptions.
+// Check the length with:
+//     len(mockedStorer.CountDimensionOptionsCalls())
+func (mock *StorerMock) CountDimensionOptionsCalls() []struct {
+	Ctx        context.Context
+	InstanceID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		InstanceID string
+	}
+	lockStorerMockCountDimensionOptions.RLock()
+	calls = mock.calls.CountDimensionOptions
+	lockStorerMockCountDimensionOptions.RUnlock()
+	return calls
+}
+
+// CountInstanceNodes calls CountInstanceNodesFunc.
+func (mock *StorerMock) CountInstanceNodes(ctx context.Context, instanceID string) (int64, error) {
+	if mock.CountInstanceNodesFunc == nil {
+		panic("StorerMock.CountInstanceNodesFunc: method is nil but Storer.CountInstanceNodes was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		InstanceID string
+	}{
+		Ctx:        ctx,
+		InstanceID: instanceID,
+	}
+	lockStorerMockCountInstanceNodes.Lock()
+	mock.calls.CountInstanceNodes = append(mock.calls.CountInstanceNodes, callInfo)
+	lockStorerMockCountInstanceNodes.Unlock()
+	return mock.CountInstanceNodesFunc(ctx, instanceID)
+}
+
+// CountInstanceNodesCalls gets all the calls that were made to CountInstanceNodes.
+// Check the length with:
+//     len(mockedStorer.CountInstanceNodesCalls())
+func (mock *StorerMock) CountInstanceNodesCalls() []struct {
+	Ctx        context.Context
+	InstanceID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		InstanceID string
+	}
+	lockStorerMockCountInstanceNodes.RLock()
+	calls = mock.calls.CountInstanceNodes
+	lockStorerMockCountInstanceNodes.RUnlock()
+	return calls
+}
+
+// CountTopicDatasets calls CountTopicDatasetsFunc.
+func (mock *StorerMock) CountTopicDatasets(ctx context.Context, id string) (int, error) {
+	if mock.CountTopicDatasetsFunc == nil {
+		panic("StorerMock.CountTopicDatasetsFunc: method is nil but Storer.CountTopicDatasets was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockStorerMockCountTopicDatasets.Lock()
+	mock.calls.CountTopicDatasets = append(mock.calls.CountTopicDatasets, callInfo)
+	lockStorerMockCountTopicDatasets.Unlock()
+	return mock.CountTopicDatasetsFunc(ctx, id)
+}
+
+// CountTopicDatasetsCalls gets all the calls that were made to CountTopicDatasets.
+// Check the length with:
+//     len(mockedStorer.CountTopicDatasetsCalls())
+func (mock *StorerMock) CountTopicDatasetsCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	lockStorerMockCountTopicDatasets.RLock()
+	calls = mock.calls.CountTopicDatasets
+	lockStorerMockCountTopicDatasets.RUnlock()
+	return calls
+}
+
 // DeleteDataset calls DeleteDatasetFunc.
-func (mock *StorerMock) DeleteDataset(ID string) error {
+func (mock *StorerMock) DeleteDataset(ctx context.Context, ID string) error {
 	if mock.DeleteDatasetFunc == nil {
 		panic("StorerMock.DeleteDatasetFunc: method is nil but Storer.DeleteDataset was just called")
 	}
 	callInfo := struct {
-		ID string
+		Ctx context.Context
+		ID  string
 	}{
-		ID: ID,
+		Ctx: ctx,
+		ID:  ID,
 	}
 	lockStorerMockDeleteDataset.Lock()
 	mock.calls.DeleteDataset = append(mock.calls.DeleteDataset, callInfo)
 	lockStorerMockDeleteDataset.Unlock()
-	return mock.DeleteDatasetFunc(ID)
+	return mock.DeleteDatasetFunc(ctx, ID)
 }
 
 // DeleteDatasetCalls gets all the calls that were made to DeleteDataset.
 // Check the length with:
 //     len(mockedStorer.DeleteDatasetCalls())
 func (mock *StorerMock) DeleteDatasetCalls() []struct {
-	ID string
+	Ctx context.Context
+	ID  string
 } {
 	var calls []struct {
-		ID string
+		Ctx context.Context
+		ID  string
 	}
 	lockStorerMockDeleteDataset.RLock()
 	calls = mock.calls.DeleteDataset
@@ -960,30 +1822,69 @@ func (mock *StorerMock) DeleteDatasetCalls() []struct {
 	return calls
 }
 
+// DeleteDimensionOptions calls DeleteDimensionOptionsFunc.
+func (mock *StorerMock) DeleteDimensionOptions(ctx context.Context, instanceID string) (int, error) {
+	if mock.DeleteDimensionOptionsFunc == nil {
+		panic("StorerMock.DeleteDimensionOptionsFunc: method is nil but Storer.DeleteDimensionOptions was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		InstanceID string
+	}{
+		Ctx:        ctx,
+		InstanceID: instanceID,
+	}
+	lockStorerMockDeleteDimensionOptions.Lock()
+	mock.calls.DeleteDimensionOptions = append(mock.calls.DeleteDimensionOptions, callInfo)
+	lockStorerMockDeleteDimensionOptions.Unlock()
+	return mock.DeleteDimensionOptionsFunc(ctx, instanceID)
+}
+
+// DeleteDimensionOptionsCalls gets all the calls that were made to DeleteDimensionOptions.
+// Check the length with:
+//     len(mockedStorer.DeleteDimensionOptionsCalls())
+func (mock *StorerMock) DeleteDimensionOptionsCalls() []struct {
+	Ctx        context.Context
+	InstanceID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		InstanceID string
+	}
+	lockStorerMockDeleteDimensionOptions.RLock()
+	calls = mock.calls.DeleteDimensionOptions
+	lockStorerMockDeleteDimensionOptions.RUnlock()
+	return calls
+}
+
 // DeleteEdition calls DeleteEditionFunc.
-func (mock *StorerMock) DeleteEdition(ID string) error {
+func (mock *StorerMock) DeleteEdition(ctx context.Context, ID string) error {
 	if mock.DeleteEditionFunc == nil {
 		panic("StorerMock.DeleteEditionFunc: method is nil but Storer.DeleteEdition was just called")
 	}
 	callInfo := struct {
-		ID string
+		Ctx context.Context
+		ID  string
 	}{
-		ID: ID,
+		Ctx: ctx,
+		ID:  ID,
 	}
 	lockStorerMockDeleteEdition.Lock()
 	mock.calls.DeleteEdition = append(mock.calls.DeleteEdition, callInfo)
 	lockStorerMockDeleteEdition.Unlock()
-	return mock.DeleteEditionFunc(ID)
+	return mock.DeleteEditionFunc(ctx, ID)
 }
 
 // DeleteEditionCalls gets all the calls that were made to DeleteEdition.
 // Check the length with:
 //     len(mockedStorer.DeleteEditionCalls())
 func (mock *StorerMock) DeleteEditionCalls() []struct {
-	ID string
+	Ctx context.Context
+	ID  string
 } {
 	var calls []struct {
-		ID string
+		Ctx context.Context
+		ID  string
 	}
 	lockStorerMockDeleteEdition.RLock()
 	calls = mock.calls.DeleteEdition
@@ -991,30 +1892,174 @@ func (mock *StorerMock) DeleteEditionCalls() []struct {
 	return calls
 }
 
-// GetDataset calls GetDatasetFunc.
-func (mock *StorerMock) GetDataset(ID string) (*models.DatasetUpdate, error) {
-	if mock.GetDatasetFunc == nil {
-		panic("StorerMock.GetDatasetFunc: method is nil but Storer.GetDataset was just called")
+// DeleteInstance calls DeleteInstanceFunc.
+func (mock *StorerMock) DeleteInstance(ctx context.Context, instanceID string) error {
+	if mock.DeleteInstanceFunc == nil {
+		panic("StorerMock.DeleteInstanceFunc: method is nil but Storer.DeleteInstance was just called")
 	}
 	callInfo := struct {
-		ID string
+		Ctx        context.Context
+		InstanceID string
 	}{
-		ID: ID,
+		Ctx:        ctx,
+		InstanceID: instanceID,
 	}
-	lockStorerMockGetDataset.Lock()
-	mock.calls.GetDataset = append(mock.calls.GetDataset, callInfo)
-	lockStorerMockGetDataset.Unlock()
-	return mock.GetDatasetFunc(ID)
+	lockStorerMockDeleteInstance.Lock()
+	mock.calls.DeleteInstance = append(mock.calls.DeleteInstance, callInfo)
+	lockStorerMockDeleteInstance.Unlock()
+	return mock.DeleteInstanceFunc(ctx, instanceID)
 }
 
-// GetDatasetCalls gets all the calls that were made to GetDataset.
+// DeleteInstanceCalls gets all the calls that were made to DeleteInstance.
 // Check the length with:
-//     len(mockedStorer.GetDatasetCalls())
+//     len(mockedStorer.DeleteInstanceCalls())
+func (mock *StorerMock) DeleteInstanceCalls() []struct {
+	Ctx        context.Context
+	InstanceID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		InstanceID string
+	}
+	lockStorerMockDeleteInstance.RLock()
+	calls = mock.calls.DeleteInstance
+	lockStorerMockDeleteInstance.RUnlock()
+	return calls
+}
+
+// DeleteInstanceNodes calls DeleteInstanceNodesFunc.
+func (mock *StorerMock) DeleteInstanceNodes(ctx context.Context, instanceID string) (int64, error) {
+	if mock.DeleteInstanceNodesFunc == nil {
+		panic("StorerMock.DeleteInstanceNodesFunc: method is nil but Storer.DeleteInstanceNodes was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		InstanceID string
+	}{
+		Ctx:        ctx,
+		InstanceID: instanceID,
+	}
+	lockStorerMockDeleteInstanceNodes.Lock()
+	mock.calls.DeleteInstanceNodes = append(mock.calls.DeleteInstanceNodes, callInfo)
+	lockStorerMockDeleteInstanceNodes.Unlock()
+	return mock.DeleteInstanceNodesFunc(ctx, instanceID)
+}
+
+// DeleteInstanceNodesCalls gets all the calls that were made to DeleteInstanceNodes.
+// Check the length with:
+//     len(mockedStorer.DeleteInstanceNodesCalls())
+func (mock *StorerMock) DeleteInstanceNodesCalls() []struct {
+	Ctx        context.Context
+	InstanceID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		InstanceID string
+	}
+	lockStorerMockDeleteInstanceNodes.RLock()
+	calls = mock.calls.DeleteInstanceNodes
+	lockStorerMockDeleteInstanceNodes.RUnlock()
+	return calls
+}
+
+// DeleteLinkReportsExcept calls DeleteLinkReportsExceptFunc.
+func (mock *StorerMock) DeleteLinkReportsExcept(ctx context.Context, datasetIDs []string) error {
+	if mock.DeleteLinkReportsExceptFunc == nil {
+		panic("StorerMock.DeleteLinkReportsExceptFunc: method is nil but Storer.DeleteLinkReportsExcept was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		DatasetIDs []string
+	}{
+		Ctx:        ctx,
+		DatasetIDs: datasetIDs,
+	}
+	lockStorerMockDeleteLinkReportsExcept.Lock()
+	mock.calls.DeleteLinkReportsExcept = append(mock.calls.DeleteLinkReportsExcept, callInfo)
+	lockStorerMockDeleteLinkReportsExcept.Unlock()
+	return mock.DeleteLinkReportsExceptFunc(ctx, datasetIDs)
+}
+
+// DeleteLinkReportsExceptCalls gets all the calls that were made to DeleteLinkReportsExcept.
+// Check the length with:
+//     len(mockedStorer.DeleteLinkReportsExceptCalls())
+func (mock *StorerMock) DeleteLinkReportsExceptCalls() []struct {
+	Ctx        context.Context
+	DatasetIDs []string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		DatasetIDs []string
+	}
+	lockStorerMockDeleteLinkReportsExcept.RLock()
+	calls = mock.calls.DeleteLinkReportsExcept
+	lockStorerMockDeleteLinkReportsExcept.RUnlock()
+	return calls
+}
+
+// DeleteTopic calls DeleteTopicFunc.
+func (mock *StorerMock) DeleteTopic(ctx context.Context, id string) error {
+	if mock.DeleteTopicFunc == nil {
+		panic("StorerMock.DeleteTopicFunc: method is nil but Storer.DeleteTopic was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockStorerMockDeleteTopic.Lock()
+	mock.calls.DeleteTopic = append(mock.calls.DeleteTopic, callInfo)
+	lockStorerMockDeleteTopic.Unlock()
+	return mock.DeleteTopicFunc(ctx, id)
+}
+
+// DeleteTopicCalls gets all the calls that were made to DeleteTopic.
+// Check the length with:
+//     len(mockedStorer.DeleteTopicCalls())
+func (mock *StorerMock) DeleteTopicCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	lockStorerMockDeleteTopic.RLock()
+	calls = mock.calls.DeleteTopic
+	lockStorerMockDeleteTopic.RUnlock()
+	return calls
+}
+
+// GetDataset calls GetDatasetFunc.
+func (mock *StorerMock) GetDataset(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+	if mock.GetDatasetFunc == nil {
+		panic("StorerMock.GetDatasetFunc: method is nil but Storer.GetDataset was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  ID,
+	}
+	lockStorerMockGetDataset.Lock()
+	mock.calls.GetDataset = append(mock.calls.GetDataset, callInfo)
+	lockStorerMockGetDataset.Unlock()
+	return mock.GetDatasetFunc(ctx, ID)
+}
+
+// GetDatasetCalls gets all the calls that were made to GetDataset.
+// Check the length with:
+//     len(mockedStorer.GetDatasetCalls())
 func (mock *StorerMock) GetDatasetCalls() []struct {
-	ID string
+	Ctx context.Context
+	ID  string
 } {
 	var calls []struct {
-		ID string
+		Ctx context.Context
+		ID  string
 	}
 	lockStorerMockGetDataset.RLock()
 	calls = mock.calls.GetDataset
@@ -1022,6 +2067,76 @@ func (mock *StorerMock) GetDatasetCalls() []struct {
 	return calls
 }
 
+// GetDatasetEditions calls GetDatasetEditionsFunc.
+func (mock *StorerMock) GetDatasetEditions(ctx context.Context, datasetID string) ([]*models.EditionUpdate, error) {
+	if mock.GetDatasetEditionsFunc == nil {
+		panic("StorerMock.GetDatasetEditionsFunc: method is nil but Storer.GetDatasetEditions was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+	}
+	lockStorerMockGetDatasetEditions.Lock()
+	mock.calls.GetDatasetEditions = append(mock.calls.GetDatasetEditions, callInfo)
+	lockStorerMockGetDatasetEditions.Unlock()
+	return mock.GetDatasetEditionsFunc(ctx, datasetID)
+}
+
+// GetDatasetEditionsCalls gets all the calls that were made to GetDatasetEditions.
+// Check the length with:
+//     len(mockedStorer.GetDatasetEditionsCalls())
+func (mock *StorerMock) GetDatasetEditionsCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+	}
+	lockStorerMockGetDatasetEditions.RLock()
+	calls = mock.calls.GetDatasetEditions
+	lockStorerMockGetDatasetEditions.RUnlock()
+	return calls
+}
+
+// GetDatasetInstances calls GetDatasetInstancesFunc.
+func (mock *StorerMock) GetDatasetInstances(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+	if mock.GetDatasetInstancesFunc == nil {
+		panic("StorerMock.GetDatasetInstancesFunc: method is nil but Storer.GetDatasetInstances was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+	}
+	lockStorerMockGetDatasetInstances.Lock()
+	mock.calls.GetDatasetInstances = append(mock.calls.GetDatasetInstances, callInfo)
+	lockStorerMockGetDatasetInstances.Unlock()
+	return mock.GetDatasetInstancesFunc(ctx, datasetID)
+}
+
+// GetDatasetInstancesCalls gets all the calls that were made to GetDatasetInstances.
+// Check the length with:
+//     len(mockedStorer.GetDatasetInstancesCalls())
+func (mock *StorerMock) GetDatasetInstancesCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+	}
+	lockStorerMockGetDatasetInstances.RLock()
+	calls = mock.calls.GetDatasetInstances
+	lockStorerMockGetDatasetInstances.RUnlock()
+	return calls
+}
+
 // GetDatasets calls GetDatasetsFunc.
 func (mock *StorerMock) GetDatasets(ctx context.Context, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
 	if mock.GetDatasetsFunc == nil {
@@ -1065,6 +2180,181 @@ func (mock *StorerMock) GetDatasetsCalls() []struct {
 	return calls
 }
 
+// GetDatasetsByIDs calls GetDatasetsByIDsFunc.
+func (mock *StorerMock) GetDatasetsByIDs(ctx context.Context, ids []string) ([]*models.DatasetUpdate, error) {
+	if mock.GetDatasetsByIDsFunc == nil {
+		panic("StorerMock.GetDatasetsByIDsFunc: method is nil but Storer.GetDatasetsByIDs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Ids []string
+	}{
+		Ctx: ctx,
+		Ids: ids,
+	}
+	lockStorerMockGetDatasetsByIDs.Lock()
+	mock.calls.GetDatasetsByIDs = append(mock.calls.GetDatasetsByIDs, callInfo)
+	lockStorerMockGetDatasetsByIDs.Unlock()
+	return mock.GetDatasetsByIDsFunc(ctx, ids)
+}
+
+// GetDatasetsByIDsCalls gets all the calls that were made to GetDatasetsByIDs.
+// Check the length with:
+//     len(mockedStorer.GetDatasetsByIDsCalls())
+func (mock *StorerMock) GetDatasetsByIDsCalls() []struct {
+	Ctx context.Context
+	Ids []string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Ids []string
+	}
+	lockStorerMockGetDatasetsByIDs.RLock()
+	calls = mock.calls.GetDatasetsByIDs
+	lockStorerMockGetDatasetsByIDs.RUnlock()
+	return calls
+}
+
+// GetDatasetsDeletedBefore calls GetDatasetsDeletedBeforeFunc.
+func (mock *StorerMock) GetDatasetsDeletedBefore(ctx context.Context, before time.Time) ([]string, error) {
+	if mock.GetDatasetsDeletedBeforeFunc == nil {
+		panic("StorerMock.GetDatasetsDeletedBeforeFunc: method is nil but Storer.GetDatasetsDeletedBefore was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Before time.Time
+	}{
+		Ctx:    ctx,
+		Before: before,
+	}
+	lockStorerMockGetDatasetsDeletedBefore.Lock()
+	mock.calls.GetDatasetsDeletedBefore = append(mock.calls.GetDatasetsDeletedBefore, callInfo)
+	lockStorerMockGetDatasetsDeletedBefore.Unlock()
+	return mock.GetDatasetsDeletedBeforeFunc(ctx, before)
+}
+
+// GetDatasetsDeletedBeforeCalls gets all the calls that were made to GetDatasetsDeletedBefore.
+// Check the length with:
+//     len(mockedStorer.GetDatasetsDeletedBeforeCalls())
+func (mock *StorerMock) GetDatasetsDeletedBeforeCalls() []struct {
+	Ctx    context.Context
+	Before time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Before time.Time
+	}
+	lockStorerMockGetDatasetsDeletedBefore.RLock()
+	calls = mock.calls.GetDatasetsDeletedBefore
+	lockStorerMockGetDatasetsDeletedBefore.RUnlock()
+	return calls
+}
+
+// GetDatasetsRelatedTo calls GetDatasetsRelatedToFunc.
+func (mock *StorerMock) GetDatasetsRelatedTo(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+	if mock.GetDatasetsRelatedToFunc == nil {
+		panic("StorerMock.GetDatasetsRelatedToFunc: method is nil but Storer.GetDatasetsRelatedTo was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+	}
+	lockStorerMockGetDatasetsRelatedTo.Lock()
+	mock.calls.GetDatasetsRelatedTo = append(mock.calls.GetDatasetsRelatedTo, callInfo)
+	lockStorerMockGetDatasetsRelatedTo.Unlock()
+	return mock.GetDatasetsRelatedToFunc(ctx, datasetID)
+}
+
+// GetDatasetsRelatedToCalls gets all the calls that were made to GetDatasetsRelatedTo.
+// Check the length with:
+//     len(mockedStorer.GetDatasetsRelatedToCalls())
+func (mock *StorerMock) GetDatasetsRelatedToCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+	}
+	lockStorerMockGetDatasetsRelatedTo.RLock()
+	calls = mock.calls.GetDatasetsRelatedTo
+	lockStorerMockGetDatasetsRelatedTo.RUnlock()
+	return calls
+}
+
+// GetDeleteJob calls GetDeleteJobFunc.
+func (mock *StorerMock) GetDeleteJob(ctx context.Context, jobID string) (*models.DeleteJob, error) {
+	if mock.GetDeleteJobFunc == nil {
+		panic("StorerMock.GetDeleteJobFunc: method is nil but Storer.GetDeleteJob was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		JobID string
+	}{
+		Ctx:   ctx,
+		JobID: jobID,
+	}
+	lockStorerMockGetDeleteJob.Lock()
+	mock.calls.GetDeleteJob = append(mock.calls.GetDeleteJob, callInfo)
+	lockStorerMockGetDeleteJob.Unlock()
+	return mock.GetDeleteJobFunc(ctx, jobID)
+}
+
+// GetDeleteJobCalls gets all the calls that were made to GetDeleteJob.
+// Check the length with:
+//     len(mockedStorer.GetDeleteJobCalls())
+func (mock *StorerMock) GetDeleteJobCalls() []struct {
+	Ctx   context.Context
+	JobID string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		JobID string
+	}
+	lockStorerMockGetDeleteJob.RLock()
+	calls = mock.calls.GetDeleteJob
+	lockStorerMockGetDeleteJob.RUnlock()
+	return calls
+}
+
+// GetDeletedDataset calls GetDeletedDatasetFunc.
+func (mock *StorerMock) GetDeletedDataset(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+	if mock.GetDeletedDatasetFunc == nil {
+		panic("StorerMock.GetDeletedDatasetFunc: method is nil but Storer.GetDeletedDataset was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockStorerMockGetDeletedDataset.Lock()
+	mock.calls.GetDeletedDataset = append(mock.calls.GetDeletedDataset, callInfo)
+	lockStorerMockGetDeletedDataset.Unlock()
+	return mock.GetDeletedDatasetFunc(ctx, id)
+}
+
+// GetDeletedDatasetCalls gets all the calls that were made to GetDeletedDataset.
+// Check the length with:
+//     len(mockedStorer.GetDeletedDatasetCalls())
+func (mock *StorerMock) GetDeletedDatasetCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	lockStorerMockGetDeletedDataset.RLock()
+	calls = mock.calls.GetDeletedDataset
+	lockStorerMockGetDeletedDataset.RUnlock()
+	return calls
+}
+
 // GetDimensionOptions calls GetDimensionOptionsFunc.
 func (mock *StorerMock) GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset int, limit int) ([]*models.PublicDimensionOption, int, error) {
 	if mock.GetDimensionOptionsFunc == nil {
@@ -1113,15 +2403,17 @@ func (mock *StorerMock) GetDimensionOptionsCalls() []struct {
 }
 
 // GetDimensionOptionsFromIDs calls GetDimensionOptionsFromIDsFunc.
-func (mock *StorerMock) GetDimensionOptionsFromIDs(version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
+func (mock *StorerMock) GetDimensionOptionsFromIDs(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
 	if mock.GetDimensionOptionsFromIDsFunc == nil {
 		panic("StorerMock.GetDimensionOptionsFromIDsFunc: method is nil but Storer.GetDimensionOptionsFromIDs was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		Version   *models.Version
 		Dimension string
 		Ids       []string
 	}{
+		Ctx:       ctx,
 		Version:   version,
 		Dimension: dimension,
 		Ids:       ids,
@@ -1129,18 +2421,20 @@ func (mock *StorerMock) GetDimensionOptionsFromIDs(version *models.Version, dime
 	lockStorerMockGetDimensionOptionsFromIDs.Lock()
 	mock.calls.GetDimensionOptionsFromIDs = append(mock.calls.GetDimensionOptionsFromIDs, callInfo)
 	lockStorerMockGetDimensionOptionsFromIDs.Unlock()
-	return mock.GetDimensionOptionsFromIDsFunc(version, dimension, ids)
+	return mock.GetDimensionOptionsFromIDsFunc(ctx, version, dimension, ids)
 }
 
 // GetDimensionOptionsFromIDsCalls gets all the calls that were made to GetDimensionOptionsFromIDs.
 // Check the length with:
 //     len(mockedStorer.GetDimensionOptionsFromIDsCalls())
 func (mock *StorerMock) GetDimensionOptionsFromIDsCalls() []struct {
+	Ctx       context.Context
 	Version   *models.Version
 	Dimension string
 	Ids       []string
 } {
 	var calls []struct {
+		Ctx       context.Context
 		Version   *models.Version
 		Dimension string
 		Ids       []string
@@ -1151,32 +2445,71 @@ func (mock *StorerMock) GetDimensionOptionsFromIDsCalls() []struct {
 	return calls
 }
 
+// GetDimensionSummaries calls GetDimensionSummariesFunc.
+func (mock *StorerMock) GetDimensionSummaries(ctx context.Context, instanceID string) ([]*models.DimensionSummary, error) {
+	if mock.GetDimensionSummariesFunc == nil {
+		panic("StorerMock.GetDimensionSummariesFunc: method is nil but Storer.GetDimensionSummaries was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		InstanceID string
+	}{
+		Ctx:        ctx,
+		InstanceID: instanceID,
+	}
+	lockStorerMockGetDimensionSummaries.Lock()
+	mock.calls.GetDimensionSummaries = append(mock.calls.GetDimensionSummaries, callInfo)
+	lockStorerMockGetDimensionSummaries.Unlock()
+	return mock.GetDimensionSummariesFunc(ctx, instanceID)
+}
+
+// GetDimensionSummariesCalls gets all the calls that were made to GetDimensionSummaries.
+// Check the length with:
+//     len(mockedStorer.GetDimensionSummariesCalls())
+func (mock *StorerMock) GetDimensionSummariesCalls() []struct {
+	Ctx        context.Context
+	InstanceID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		InstanceID string
+	}
+	lockStorerMockGetDimensionSummaries.RLock()
+	calls = mock.calls.GetDimensionSummaries
+	lockStorerMockGetDimensionSummaries.RUnlock()
+	return calls
+}
+
 // GetDimensions calls GetDimensionsFunc.
-func (mock *StorerMock) GetDimensions(datasetID string, versionID string) ([]bson.M, error) {
+func (mock *StorerMock) GetDimensions(ctx context.Context, datasetID string, versionID string) ([]bson.M, error) {
 	if mock.GetDimensionsFunc == nil {
 		panic("StorerMock.GetDimensionsFunc: method is nil but Storer.GetDimensions was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		DatasetID string
 		VersionID string
 	}{
+		Ctx:       ctx,
 		DatasetID: datasetID,
 		VersionID: versionID,
 	}
 	lockStorerMockGetDimensions.Lock()
 	mock.calls.GetDimensions = append(mock.calls.GetDimensions, callInfo)
 	lockStorerMockGetDimensions.Unlock()
-	return mock.GetDimensionsFunc(datasetID, versionID)
+	return mock.GetDimensionsFunc(ctx, datasetID, versionID)
 }
 
 // GetDimensionsCalls gets all the calls that were made to GetDimensions.
 // Check the length with:
 //     len(mockedStorer.GetDimensionsCalls())
 func (mock *StorerMock) GetDimensionsCalls() []struct {
+	Ctx       context.Context
 	DatasetID string
 	VersionID string
 } {
 	var calls []struct {
+		Ctx       context.Context
 		DatasetID string
 		VersionID string
 	}
@@ -1230,15 +2563,17 @@ func (mock *StorerMock) GetDimensionsFromInstanceCalls() []struct {
 }
 
 // GetEdition calls GetEditionFunc.
-func (mock *StorerMock) GetEdition(ID string, editionID string, state string) (*models.EditionUpdate, error) {
+func (mock *StorerMock) GetEdition(ctx context.Context, ID string, editionID string, state string) (*models.EditionUpdate, error) {
 	if mock.GetEditionFunc == nil {
 		panic("StorerMock.GetEditionFunc: method is nil but Storer.GetEdition was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		ID        string
 		EditionID string
 		State     string
 	}{
+		Ctx:       ctx,
 		ID:        ID,
 		EditionID: editionID,
 		State:     state,
@@ -1246,18 +2581,20 @@ func (mock *StorerMock) GetEdition(ID string, editionID string, state string) (*
 	lockStorerMockGetEdition.Lock()
 	mock.calls.GetEdition = append(mock.calls.GetEdition, callInfo)
 	lockStorerMockGetEdition.Unlock()
-	return mock.GetEditionFunc(ID, editionID, state)
+	return mock.GetEditionFunc(ctx, ID, editionID, state)
 }
 
 // GetEditionCalls gets all the calls that were made to GetEdition.
 // Check the length with:
 //     len(mockedStorer.GetEditionCalls())
 func (mock *StorerMock) GetEditionCalls() []struct {
+	Ctx       context.Context
 	ID        string
 	EditionID string
 	State     string
 } {
 	var calls []struct {
+		Ctx       context.Context
 		ID        string
 		EditionID string
 		State     string
@@ -1319,32 +2656,71 @@ func (mock *StorerMock) GetEditionsCalls() []struct {
 	return calls
 }
 
+// GetEditionsDeletedBefore calls GetEditionsDeletedBeforeFunc.
+func (mock *StorerMock) GetEditionsDeletedBefore(ctx context.Context, before time.Time) ([]*models.EditionUpdate, error) {
+	if mock.GetEditionsDeletedBeforeFunc == nil {
+		panic("StorerMock.GetEditionsDeletedBeforeFunc: method is nil but Storer.GetEditionsDeletedBefore was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Before time.Time
+	}{
+		Ctx:    ctx,
+		Before: before,
+	}
+	lockStorerMockGetEditionsDeletedBefore.Lock()
+	mock.calls.GetEditionsDeletedBefore = append(mock.calls.GetEditionsDeletedBefore, callInfo)
+	lockStorerMockGetEditionsDeletedBefore.Unlock()
+	return mock.GetEditionsDeletedBeforeFunc(ctx, before)
+}
+
+// GetEditionsDeletedBeforeCalls gets all the calls that were made to GetEditionsDeletedBefore.
+// Check the length with:
+//     len(mockedStorer.GetEditionsDeletedBeforeCalls())
+func (mock *StorerMock) GetEditionsDeletedBeforeCalls() []struct {
+	Ctx    context.Context
+	Before time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Before time.Time
+	}
+	lockStorerMockGetEditionsDeletedBefore.RLock()
+	calls = mock.calls.GetEditionsDeletedBefore
+	lockStorerMockGetEditionsDeletedBefore.RUnlock()
+	return calls
+}
+
 // GetInstance calls GetInstanceFunc.
-func (mock *StorerMock) GetInstance(ID string, eTagSelector string) (*models.Instance, error) {
+func (mock *StorerMock) GetInstance(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 	if mock.GetInstanceFunc == nil {
 		panic("StorerMock.GetInstanceFunc: method is nil but Storer.GetInstance was just called")
 	}
 	callInfo := struct {
+		Ctx          context.Context
 		ID           string
 		ETagSelector string
 	}{
+		Ctx:          ctx,
 		ID:           ID,
 		ETagSelector: eTagSelector,
 	}
 	lockStorerMockGetInstance.Lock()
 	mock.calls.GetInstance = append(mock.calls.GetInstance, callInfo)
 	lockStorerMockGetInstance.Unlock()
-	return mock.GetInstanceFunc(ID, eTagSelector)
+	return mock.GetInstanceFunc(ctx, ID, eTagSelector)
 }
 
 // GetInstanceCalls gets all the calls that were made to GetInstance.
 // Check the length with:
 //     len(mockedStorer.GetInstanceCalls())
 func (mock *StorerMock) GetInstanceCalls() []struct {
+	Ctx          context.Context
 	ID           string
 	ETagSelector string
 } {
 	var calls []struct {
+		Ctx          context.Context
 		ID           string
 		ETagSelector string
 	}
@@ -1401,32 +2777,110 @@ func (mock *StorerMock) GetInstancesCalls() []struct {
 	return calls
 }
 
-// GetNextVersion calls GetNextVersionFunc.
-func (mock *StorerMock) GetNextVersion(datasetID string, editionID string) (int, error) {
-	if mock.GetNextVersionFunc == nil {
-		panic("StorerMock.GetNextVersionFunc: method is nil but Storer.GetNextVersion was just called")
+// GetLinkReport calls GetLinkReportFunc.
+func (mock *StorerMock) GetLinkReport(ctx context.Context, datasetID string) (*models.LinkReport, error) {
+	if mock.GetLinkReportFunc == nil {
+		panic("StorerMock.GetLinkReportFunc: method is nil but Storer.GetLinkReport was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		DatasetID string
-		EditionID string
 	}{
+		Ctx:       ctx,
 		DatasetID: datasetID,
-		EditionID: editionID,
+	}
+	lockStorerMockGetLinkReport.Lock()
+	mock.calls.GetLinkReport = append(mock.calls.GetLinkReport, callInfo)
+	lockStorerMockGetLinkReport.Unlock()
+	return mock.GetLinkReportFunc(ctx, datasetID)
+}
+
+// GetLinkReportCalls gets all the calls that were made to GetLinkReport.
+// Check the length with:
+//     len(mockedStorer.GetLinkReportCalls())
+func (mock *StorerMock) GetLinkReportCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+	}
+	lockStorerMockGetLinkReport.RLock()
+	calls = mock.calls.GetLinkReport
+	lockStorerMockGetLinkReport.RUnlock()
+	return calls
+}
+
+// GetLinkReports calls GetLinkReportsFunc.
+func (mock *StorerMock) GetLinkReports(ctx context.Context, offset int, limit int) ([]*models.LinkReport, int, error) {
+	if mock.GetLinkReportsFunc == nil {
+		panic("StorerMock.GetLinkReportsFunc: method is nil but Storer.GetLinkReports was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Offset: offset,
+		Limit:  limit,
+	}
+	lockStorerMockGetLinkReports.Lock()
+	mock.calls.GetLinkReports = append(mock.calls.GetLinkReports, callInfo)
+	lockStorerMockGetLinkReports.Unlock()
+	return mock.GetLinkReportsFunc(ctx, offset, limit)
+}
+
+// GetLinkReportsCalls gets all the calls that were made to GetLinkReports.
+// Check the length with:
+//     len(mockedStorer.GetLinkReportsCalls())
+func (mock *StorerMock) GetLinkReportsCalls() []struct {
+	Ctx    context.Context
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}
+	lockStorerMockGetLinkReports.RLock()
+	calls = mock.calls.GetLinkReports
+	lockStorerMockGetLinkReports.RUnlock()
+	return calls
+}
+
+// GetNextVersion calls GetNextVersionFunc.
+func (mock *StorerMock) GetNextVersion(ctx context.Context, datasetID string, editionID string) (int, error) {
+	if mock.GetNextVersionFunc == nil {
+		panic("StorerMock.GetNextVersionFunc: method is nil but Storer.GetNextVersion was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		EditionID: editionID,
 	}
 	lockStorerMockGetNextVersion.Lock()
 	mock.calls.GetNextVersion = append(mock.calls.GetNextVersion, callInfo)
 	lockStorerMockGetNextVersion.Unlock()
-	return mock.GetNextVersionFunc(datasetID, editionID)
+	return mock.GetNextVersionFunc(ctx, datasetID, editionID)
 }
 
 // GetNextVersionCalls gets all the calls that were made to GetNextVersion.
 // Check the length with:
 //     len(mockedStorer.GetNextVersionCalls())
 func (mock *StorerMock) GetNextVersionCalls() []struct {
+	Ctx       context.Context
 	DatasetID string
 	EditionID string
 } {
 	var calls []struct {
+		Ctx       context.Context
 		DatasetID string
 		EditionID string
 	}
@@ -1436,6 +2890,197 @@ func (mock *StorerMock) GetNextVersionCalls() []struct {
 	return calls
 }
 
+// GetPreviousPublishedVersion calls GetPreviousPublishedVersionFunc.
+func (mock *StorerMock) GetPreviousPublishedVersion(ctx context.Context, datasetID string, editionID string, version int) (*models.Version, error) {
+	if mock.GetPreviousPublishedVersionFunc == nil {
+		panic("StorerMock.GetPreviousPublishedVersionFunc: method is nil but Storer.GetPreviousPublishedVersion was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+		Version   int
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		EditionID: editionID,
+		Version:   version,
+	}
+	lockStorerMockGetPreviousPublishedVersion.Lock()
+	mock.calls.GetPreviousPublishedVersion = append(mock.calls.GetPreviousPublishedVersion, callInfo)
+	lockStorerMockGetPreviousPublishedVersion.Unlock()
+	return mock.GetPreviousPublishedVersionFunc(ctx, datasetID, editionID, version)
+}
+
+// GetPreviousPublishedVersionCalls gets all the calls that were made to GetPreviousPublishedVersion.
+// Check the length with:
+//     len(mockedStorer.GetPreviousPublishedVersionCalls())
+func (mock *StorerMock) GetPreviousPublishedVersionCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	EditionID string
+	Version   int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+		Version   int
+	}
+	lockStorerMockGetPreviousPublishedVersion.RLock()
+	calls = mock.calls.GetPreviousPublishedVersion
+	lockStorerMockGetPreviousPublishedVersion.RUnlock()
+	return calls
+}
+
+// GetTopic calls GetTopicFunc.
+func (mock *StorerMock) GetTopic(ctx context.Context, id string) (*models.Topic, error) {
+	if mock.GetTopicFunc == nil {
+		panic("StorerMock.GetTopicFunc: method is nil but Storer.GetTopic was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockStorerMockGetTopic.Lock()
+	mock.calls.GetTopic = append(mock.calls.GetTopic, callInfo)
+	lockStorerMockGetTopic.Unlock()
+	return mock.GetTopicFunc(ctx, id)
+}
+
+// GetTopicCalls gets all the calls that were made to GetTopic.
+// Check the length with:
+//     len(mockedStorer.GetTopicCalls())
+func (mock *StorerMock) GetTopicCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	lockStorerMockGetTopic.RLock()
+	calls = mock.calls.GetTopic
+	lockStorerMockGetTopic.RUnlock()
+	return calls
+}
+
+// GetTopicDatasets calls GetTopicDatasetsFunc.
+func (mock *StorerMock) GetTopicDatasets(ctx context.Context, topicIDs []string, offset int, limit int) ([]*models.DatasetUpdate, int, error) {
+	if mock.GetTopicDatasetsFunc == nil {
+		panic("StorerMock.GetTopicDatasetsFunc: method is nil but Storer.GetTopicDatasets was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		TopicIDs []string
+		Offset   int
+		Limit    int
+	}{
+		Ctx:      ctx,
+		TopicIDs: topicIDs,
+		Offset:   offset,
+		Limit:    limit,
+	}
+	lockStorerMockGetTopicDatasets.Lock()
+	mock.calls.GetTopicDatasets = append(mock.calls.GetTopicDatasets, callInfo)
+	lockStorerMockGetTopicDatasets.Unlock()
+	return mock.GetTopicDatasetsFunc(ctx, topicIDs, offset, limit)
+}
+
+// GetTopicDatasetsCalls gets all the calls that were made to GetTopicDatasets.
+// Check the length with:
+//     len(mockedStorer.GetTopicDatasetsCalls())
+func (mock *StorerMock) GetTopicDatasetsCalls() []struct {
+	Ctx      context.Context
+	TopicIDs []string
+	Offset   int
+	Limit    int
+} {
+	var calls []struct {
+		Ctx      context.Context
+		TopicIDs []string
+		Offset   int
+		Limit    int
+	}
+	lockStorerMockGetTopicDatasets.RLock()
+	calls = mock.calls.GetTopicDatasets
+	lockStorerMockGetTopicDatasets.RUnlock()
+	return calls
+}
+
+// GetTopics calls GetTopicsFunc.
+func (mock *StorerMock) GetTopics(ctx context.Context) ([]*models.Topic, error) {
+	if mock.GetTopicsFunc == nil {
+		panic("StorerMock.GetTopicsFunc: method is nil but Storer.GetTopics was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockStorerMockGetTopics.Lock()
+	mock.calls.GetTopics = append(mock.calls.GetTopics, callInfo)
+	lockStorerMockGetTopics.Unlock()
+	return mock.GetTopicsFunc(ctx)
+}
+
+// GetTopicsCalls gets all the calls that were made to GetTopics.
+// Check the length with:
+//     len(mockedStorer.GetTopicsCalls())
+func (mock *StorerMock) GetTopicsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockStorerMockGetTopics.RLock()
+	calls = mock.calls.GetTopics
+	lockStorerMockGetTopics.RUnlock()
+	return calls
+}
+
+// GetTopicsPage calls GetTopicsPageFunc.
+func (mock *StorerMock) GetTopicsPage(ctx context.Context, offset int, limit int) ([]*models.Topic, int, error) {
+	if mock.GetTopicsPageFunc == nil {
+		panic("StorerMock.GetTopicsPageFunc: method is nil but Storer.GetTopicsPage was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Offset: offset,
+		Limit:  limit,
+	}
+	lockStorerMockGetTopicsPage.Lock()
+	mock.calls.GetTopicsPage = append(mock.calls.GetTopicsPage, callInfo)
+	lockStorerMockGetTopicsPage.Unlock()
+	return mock.GetTopicsPageFunc(ctx, offset, limit)
+}
+
+// GetTopicsPageCalls gets all the calls that were made to GetTopicsPage.
+// Check the length with:
+//     len(mockedStorer.GetTopicsPageCalls())
+func (mock *StorerMock) GetTopicsPageCalls() []struct {
+	Ctx    context.Context
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}
+	lockStorerMockGetTopicsPage.RLock()
+	calls = mock.calls.GetTopicsPage
+	lockStorerMockGetTopicsPage.RUnlock()
+	return calls
+}
+
 // GetUniqueDimensionAndOptions calls GetUniqueDimensionAndOptionsFunc.
 func (mock *StorerMock) GetUniqueDimensionAndOptions(ctx context.Context, ID string, dimension string, offset int, limit int) ([]*string, int, error) {
 	if mock.GetUniqueDimensionAndOptionsFunc == nil {
@@ -1484,16 +3129,18 @@ func (mock *StorerMock) GetUniqueDimensionAndOptionsCalls() []struct {
 }
 
 // GetVersion calls GetVersionFunc.
-func (mock *StorerMock) GetVersion(datasetID string, editionID string, version int, state string) (*models.Version, error) {
+func (mock *StorerMock) GetVersion(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error) {
 	if mock.GetVersionFunc == nil {
 		panic("StorerMock.GetVersionFunc: method is nil but Storer.GetVersion was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		DatasetID string
 		EditionID string
 		Version   int
 		State     string
 	}{
+		Ctx:       ctx,
 		DatasetID: datasetID,
 		EditionID: editionID,
 		Version:   version,
@@ -1502,19 +3149,21 @@ func (mock *StorerMock) GetVersion(datasetID string, editionID string, version i
 	lockStorerMockGetVersion.Lock()
 	mock.calls.GetVersion = append(mock.calls.GetVersion, callInfo)
 	lockStorerMockGetVersion.Unlock()
-	return mock.GetVersionFunc(datasetID, editionID, version, state)
+	return mock.GetVersionFunc(ctx, datasetID, editionID, version, state)
 }
 
 // GetVersionCalls gets all the calls that were made to GetVersion.
 // Check the length with:
 //     len(mockedStorer.GetVersionCalls())
 func (mock *StorerMock) GetVersionCalls() []struct {
+	Ctx       context.Context
 	DatasetID string
 	EditionID string
 	Version   int
 	State     string
 } {
 	var calls []struct {
+		Ctx       context.Context
 		DatasetID string
 		EditionID string
 		Version   int
@@ -1546,34 +3195,299 @@ func (mock *StorerMock) GetVersions(ctx context.Context, datasetID string, editi
 		Offset:    offset,
 		Limit:     limit,
 	}
-	lockStorerMockGetVersions.Lock()
-	mock.calls.GetVersions = append(mock.calls.GetVersions, callInfo)
-	lockStorerMockGetVersions.Unlock()
-	return mock.GetVersionsFunc(ctx, datasetID, editionID, state, offset, limit)
+	lockStorerMockGetVersions.Lock()
+	mock.calls.GetVersions = append(mock.calls.GetVersions, callInfo)
+	lockStorerMockGetVersions.Unlock()
+	return mock.GetVersionsFunc(ctx, datasetID, editionID, state, offset, limit)
+}
+
+// GetVersionsCalls gets all the calls that were made to GetVersions.
+// Check the length with:
+//     len(mockedStorer.GetVersionsCalls())
+func (mock *StorerMock) GetVersionsCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	EditionID string
+	State     string
+	Offset    int
+	Limit     int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+		State     string
+		Offset    int
+		Limit     int
+	}
+	lockStorerMockGetVersions.RLock()
+	calls = mock.calls.GetVersions
+	lockStorerMockGetVersions.RUnlock()
+	return calls
+}
+
+// GetVersionsInCollections calls GetVersionsInCollectionsFunc.
+func (mock *StorerMock) GetVersionsInCollections(ctx context.Context) ([]models.Version, error) {
+	if mock.GetVersionsInCollectionsFunc == nil {
+		panic("StorerMock.GetVersionsInCollectionsFunc: method is nil but Storer.GetVersionsInCollections was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockStorerMockGetVersionsInCollections.Lock()
+	mock.calls.GetVersionsInCollections = append(mock.calls.GetVersionsInCollections, callInfo)
+	lockStorerMockGetVersionsInCollections.Unlock()
+	return mock.GetVersionsInCollectionsFunc(ctx)
+}
+
+// GetVersionsInCollectionsCalls gets all the calls that were made to GetVersionsInCollections.
+// Check the length with:
+//     len(mockedStorer.GetVersionsInCollectionsCalls())
+func (mock *StorerMock) GetVersionsInCollectionsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockStorerMockGetVersionsInCollections.RLock()
+	calls = mock.calls.GetVersionsInCollections
+	lockStorerMockGetVersionsInCollections.RUnlock()
+	return calls
+}
+
+// LockLinkCheck calls LockLinkCheckFunc.
+func (mock *StorerMock) LockLinkCheck(ctx context.Context, ttl time.Duration) (string, error) {
+	if mock.LockLinkCheckFunc == nil {
+		panic("StorerMock.LockLinkCheckFunc: method is nil but Storer.LockLinkCheck was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		TTL time.Duration
+	}{
+		Ctx: ctx,
+		TTL: ttl,
+	}
+	lockStorerMockLockLinkCheck.Lock()
+	mock.calls.LockLinkCheck = append(mock.calls.LockLinkCheck, callInfo)
+	lockStorerMockLockLinkCheck.Unlock()
+	return mock.LockLinkCheckFunc(ctx, ttl)
+}
+
+// LockLinkCheckCalls gets all the calls that were made to LockLinkCheck.
+// Check the length with:
+//     len(mockedStorer.LockLinkCheckCalls())
+func (mock *StorerMock) LockLinkCheckCalls() []struct {
+	Ctx context.Context
+	TTL time.Duration
+} {
+	var calls []struct {
+		Ctx context.Context
+		TTL time.Duration
+	}
+	lockStorerMockLockLinkCheck.RLock()
+	calls = mock.calls.LockLinkCheck
+	lockStorerMockLockLinkCheck.RUnlock()
+	return calls
+}
+
+// LockPurge calls LockPurgeFunc.
+func (mock *StorerMock) LockPurge(ctx context.Context, ttl time.Duration) (string, error) {
+	if mock.LockPurgeFunc == nil {
+		panic("StorerMock.LockPurgeFunc: method is nil but Storer.LockPurge was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		TTL time.Duration
+	}{
+		Ctx: ctx,
+		TTL: ttl,
+	}
+	lockStorerMockLockPurge.Lock()
+	mock.calls.LockPurge = append(mock.calls.LockPurge, callInfo)
+	lockStorerMockLockPurge.Unlock()
+	return mock.LockPurgeFunc(ctx, ttl)
+}
+
+// LockPurgeCalls gets all the calls that were made to LockPurge.
+// Check the length with:
+//     len(mockedStorer.LockPurgeCalls())
+func (mock *StorerMock) LockPurgeCalls() []struct {
+	Ctx context.Context
+	TTL time.Duration
+} {
+	var calls []struct {
+		Ctx context.Context
+		TTL time.Duration
+	}
+	lockStorerMockLockPurge.RLock()
+	calls = mock.calls.LockPurge
+	lockStorerMockLockPurge.RUnlock()
+	return calls
+}
+
+// RenewLinkCheckLock calls RenewLinkCheckLockFunc.
+func (mock *StorerMock) RenewLinkCheckLock(ctx context.Context, lockID string, ttl time.Duration) error {
+	if mock.RenewLinkCheckLockFunc == nil {
+		panic("StorerMock.RenewLinkCheckLockFunc: method is nil but Storer.RenewLinkCheckLock was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		LockID string
+		TTL    time.Duration
+	}{
+		Ctx:    ctx,
+		LockID: lockID,
+		TTL:    ttl,
+	}
+	lockStorerMockRenewLinkCheckLock.Lock()
+	mock.calls.RenewLinkCheckLock = append(mock.calls.RenewLinkCheckLock, callInfo)
+	lockStorerMockRenewLinkCheckLock.Unlock()
+	return mock.RenewLinkCheckLockFunc(ctx, lockID, ttl)
+}
+
+// RenewLinkCheckLockCalls gets all the calls that were made to RenewLinkCheckLock.
+// Check the length with:
+//     len(mockedStorer.RenewLinkCheckLockCalls())
+func (mock *StorerMock) RenewLinkCheckLockCalls() []struct {
+	Ctx    context.Context
+	LockID string
+	TTL    time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		LockID string
+		TTL    time.Duration
+	}
+	lockStorerMockRenewLinkCheckLock.RLock()
+	calls = mock.calls.RenewLinkCheckLock
+	lockStorerMockRenewLinkCheckLock.RUnlock()
+	return calls
+}
+
+// RenewPurgeLock calls RenewPurgeLockFunc.
+func (mock *StorerMock) RenewPurgeLock(ctx context.Context, lockID string, ttl time.Duration) error {
+	if mock.RenewPurgeLockFunc == nil {
+		panic("StorerMock.RenewPurgeLockFunc: method is nil but Storer.RenewPurgeLock was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		LockID string
+		TTL    time.Duration
+	}{
+		Ctx:    ctx,
+		LockID: lockID,
+		TTL:    ttl,
+	}
+	lockStorerMockRenewPurgeLock.Lock()
+	mock.calls.RenewPurgeLock = append(mock.calls.RenewPurgeLock, callInfo)
+	lockStorerMockRenewPurgeLock.Unlock()
+	return mock.RenewPurgeLockFunc(ctx, lockID, ttl)
+}
+
+// RenewPurgeLockCalls gets all the calls that were made to RenewPurgeLock.
+// Check the length with:
+//     len(mockedStorer.RenewPurgeLockCalls())
+func (mock *StorerMock) RenewPurgeLockCalls() []struct {
+	Ctx    context.Context
+	LockID string
+	TTL    time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		LockID string
+		TTL    time.Duration
+	}
+	lockStorerMockRenewPurgeLock.RLock()
+	calls = mock.calls.RenewPurgeLock
+	lockStorerMockRenewPurgeLock.RUnlock()
+	return calls
+}
+
+// RestoreDataset calls RestoreDatasetFunc.
+func (mock *StorerMock) RestoreDataset(ctx context.Context, datasetID string) error {
+	if mock.RestoreDatasetFunc == nil {
+		panic("StorerMock.RestoreDatasetFunc: method is nil but Storer.RestoreDataset was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+	}
+	lockStorerMockRestoreDataset.Lock()
+	mock.calls.RestoreDataset = append(mock.calls.RestoreDataset, callInfo)
+	lockStorerMockRestoreDataset.Unlock()
+	return mock.RestoreDatasetFunc(ctx, datasetID)
 }
 
-// GetVersionsCalls gets all the calls that were made to GetVersions.
+// RestoreDatasetCalls gets all the calls that were made to RestoreDataset.
 // Check the length with:
-//     len(mockedStorer.GetVersionsCalls())
-func (mock *StorerMock) GetVersionsCalls() []struct {
+//     len(mockedStorer.RestoreDatasetCalls())
+func (mock *StorerMock) RestoreDatasetCalls() []struct {
 	Ctx       context.Context
 	DatasetID string
-	EditionID string
-	State     string
-	Offset    int
-	Limit     int
 } {
 	var calls []struct {
 		Ctx       context.Context
 		DatasetID string
-		EditionID string
-		State     string
-		Offset    int
-		Limit     int
 	}
-	lockStorerMockGetVersions.RLock()
-	calls = mock.calls.GetVersions
-	lockStorerMockGetVersions.RUnlock()
+	lockStorerMockRestoreDataset.RLock()
+	calls = mock.calls.RestoreDataset
+	lockStorerMockRestoreDataset.RUnlock()
+	return calls
+}
+
+// RetryImportTasks calls RetryImportTasksFunc.
+func (mock *StorerMock) RetryImportTasks(ctx context.Context, currentInstance *models.Instance, state string, tasks *models.InstanceImportTasks, event *models.Event, eTagSelector string) (string, error) {
+	if mock.RetryImportTasksFunc == nil {
+		panic("StorerMock.RetryImportTasksFunc: method is nil but Storer.RetryImportTasks was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		CurrentInstance *models.Instance
+		State           string
+		Tasks           *models.InstanceImportTasks
+		Event           *models.Event
+		ETagSelector    string
+	}{
+		Ctx:             ctx,
+		CurrentInstance: currentInstance,
+		State:           state,
+		Tasks:           tasks,
+		Event:           event,
+		ETagSelector:    eTagSelector,
+	}
+	lockStorerMockRetryImportTasks.Lock()
+	mock.calls.RetryImportTasks = append(mock.calls.RetryImportTasks, callInfo)
+	lockStorerMockRetryImportTasks.Unlock()
+	return mock.RetryImportTasksFunc(ctx, currentInstance, state, tasks, event, eTagSelector)
+}
+
+// RetryImportTasksCalls gets all the calls that were made to RetryImportTasks.
+// Check the length with:
+//     len(mockedStorer.RetryImportTasksCalls())
+func (mock *StorerMock) RetryImportTasksCalls() []struct {
+	Ctx             context.Context
+	CurrentInstance *models.Instance
+	State           string
+	Tasks           *models.InstanceImportTasks
+	Event           *models.Event
+	ETagSelector    string
+} {
+	var calls []struct {
+		Ctx             context.Context
+		CurrentInstance *models.Instance
+		State           string
+		Tasks           *models.InstanceImportTasks
+		Event           *models.Event
+		ETagSelector    string
+	}
+	lockStorerMockRetryImportTasks.RLock()
+	calls = mock.calls.RetryImportTasks
+	lockStorerMockRetryImportTasks.RUnlock()
 	return calls
 }
 
@@ -1612,29 +3526,131 @@ func (mock *StorerMock) SetInstanceIsPublishedCalls() []struct {
 	return calls
 }
 
+// SoftDeleteDataset calls SoftDeleteDatasetFunc.
+func (mock *StorerMock) SoftDeleteDataset(ctx context.Context, datasetID string, eTagSelector string, deletedBy string, deletedAt time.Time) error {
+	if mock.SoftDeleteDatasetFunc == nil {
+		panic("StorerMock.SoftDeleteDatasetFunc: method is nil but Storer.SoftDeleteDataset was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		DatasetID    string
+		ETagSelector string
+		DeletedBy    string
+		DeletedAt    time.Time
+	}{
+		Ctx:          ctx,
+		DatasetID:    datasetID,
+		ETagSelector: eTagSelector,
+		DeletedBy:    deletedBy,
+		DeletedAt:    deletedAt,
+	}
+	lockStorerMockSoftDeleteDataset.Lock()
+	mock.calls.SoftDeleteDataset = append(mock.calls.SoftDeleteDataset, callInfo)
+	lockStorerMockSoftDeleteDataset.Unlock()
+	return mock.SoftDeleteDatasetFunc(ctx, datasetID, eTagSelector, deletedBy, deletedAt)
+}
+
+// SoftDeleteDatasetCalls gets all the calls that were made to SoftDeleteDataset.
+// Check the length with:
+//     len(mockedStorer.SoftDeleteDatasetCalls())
+func (mock *StorerMock) SoftDeleteDatasetCalls() []struct {
+	Ctx          context.Context
+	DatasetID    string
+	ETagSelector string
+	DeletedBy    string
+	DeletedAt    time.Time
+} {
+	var calls []struct {
+		Ctx          context.Context
+		DatasetID    string
+		ETagSelector string
+		DeletedBy    string
+		DeletedAt    time.Time
+	}
+	lockStorerMockSoftDeleteDataset.RLock()
+	calls = mock.calls.SoftDeleteDataset
+	lockStorerMockSoftDeleteDataset.RUnlock()
+	return calls
+}
+
+// SoftDeleteEdition calls SoftDeleteEditionFunc.
+func (mock *StorerMock) SoftDeleteEdition(ctx context.Context, datasetID string, edition string, eTagSelector string, deletedBy string, deletedAt time.Time) error {
+	if mock.SoftDeleteEditionFunc == nil {
+		panic("StorerMock.SoftDeleteEditionFunc: method is nil but Storer.SoftDeleteEdition was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		DatasetID    string
+		Edition      string
+		ETagSelector string
+		DeletedBy    string
+		DeletedAt    time.Time
+	}{
+		Ctx:          ctx,
+		DatasetID:    datasetID,
+		Edition:      edition,
+		ETagSelector: eTagSelector,
+		DeletedBy:    deletedBy,
+		DeletedAt:    deletedAt,
+	}
+	lockStorerMockSoftDeleteEdition.Lock()
+	mock.calls.SoftDeleteEdition = append(mock.calls.SoftDeleteEdition, callInfo)
+	lockStorerMockSoftDeleteEdition.Unlock()
+	return mock.SoftDeleteEditionFunc(ctx, datasetID, edition, eTagSelector, deletedBy, deletedAt)
+}
+
+// SoftDeleteEditionCalls gets all the calls that were made to SoftDeleteEdition.
+// Check the length with:
+//     len(mockedStorer.SoftDeleteEditionCalls())
+func (mock *StorerMock) SoftDeleteEditionCalls() []struct {
+	Ctx          context.Context
+	DatasetID    string
+	Edition      string
+	ETagSelector string
+	DeletedBy    string
+	DeletedAt    time.Time
+} {
+	var calls []struct {
+		Ctx          context.Context
+		DatasetID    string
+		Edition      string
+		ETagSelector string
+		DeletedBy    string
+		DeletedAt    time.Time
+	}
+	lockStorerMockSoftDeleteEdition.RLock()
+	calls = mock.calls.SoftDeleteEdition
+	lockStorerMockSoftDeleteEdition.RUnlock()
+	return calls
+}
+
 // UnlockInstance calls UnlockInstanceFunc.
-func (mock *StorerMock) UnlockInstance(lockID string) error {
+func (mock *StorerMock) UnlockInstance(ctx context.Context, lockID string) error {
 	if mock.UnlockInstanceFunc == nil {
 		panic("StorerMock.UnlockInstanceFunc: method is nil but Storer.UnlockInstance was just called")
 	}
 	callInfo := struct {
+		Ctx    context.Context
 		LockID string
 	}{
+		Ctx:    ctx,
 		LockID: lockID,
 	}
 	lockStorerMockUnlockInstance.Lock()
 	mock.calls.UnlockInstance = append(mock.calls.UnlockInstance, callInfo)
 	lockStorerMockUnlockInstance.Unlock()
-	return mock.UnlockInstanceFunc(lockID)
+	return mock.UnlockInstanceFunc(ctx, lockID)
 }
 
 // UnlockInstanceCalls gets all the calls that were made to UnlockInstance.
 // Check the length with:
 //     len(mockedStorer.UnlockInstanceCalls())
 func (mock *StorerMock) UnlockInstanceCalls() []struct {
+	Ctx    context.Context
 	LockID string
 } {
 	var calls []struct {
+		Ctx    context.Context
 		LockID string
 	}
 	lockStorerMockUnlockInstance.RLock()
@@ -1643,17 +3659,89 @@ func (mock *StorerMock) UnlockInstanceCalls() []struct {
 	return calls
 }
 
+// UnlockLinkCheck calls UnlockLinkCheckFunc.
+func (mock *StorerMock) UnlockLinkCheck(ctx context.Context, lockID string) error {
+	if mock.UnlockLinkCheckFunc == nil {
+		panic("StorerMock.UnlockLinkCheckFunc: method is nil but Storer.UnlockLinkCheck was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		LockID string
+	}{
+		Ctx:    ctx,
+		LockID: lockID,
+	}
+	lockStorerMockUnlockLinkCheck.Lock()
+	mock.calls.UnlockLinkCheck = append(mock.calls.UnlockLinkCheck, callInfo)
+	lockStorerMockUnlockLinkCheck.Unlock()
+	return mock.UnlockLinkCheckFunc(ctx, lockID)
+}
+
+// UnlockLinkCheckCalls gets all the calls that were made to UnlockLinkCheck.
+// Check the length with:
+//     len(mockedStorer.UnlockLinkCheckCalls())
+func (mock *StorerMock) UnlockLinkCheckCalls() []struct {
+	Ctx    context.Context
+	LockID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		LockID string
+	}
+	lockStorerMockUnlockLinkCheck.RLock()
+	calls = mock.calls.UnlockLinkCheck
+	lockStorerMockUnlockLinkCheck.RUnlock()
+	return calls
+}
+
+// UnlockPurge calls UnlockPurgeFunc.
+func (mock *StorerMock) UnlockPurge(ctx context.Context, lockID string) error {
+	if mock.UnlockPurgeFunc == nil {
+		panic("StorerMock.UnlockPurgeFunc: method is nil but Storer.UnlockPurge was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		LockID string
+	}{
+		Ctx:    ctx,
+		LockID: lockID,
+	}
+	lockStorerMockUnlockPurge.Lock()
+	mock.calls.UnlockPurge = append(mock.calls.UnlockPurge, callInfo)
+	lockStorerMockUnlockPurge.Unlock()
+	return mock.UnlockPurgeFunc(ctx, lockID)
+}
+
+// UnlockPurgeCalls gets all the calls that were made to UnlockPurge.
+// Check the length with:
+//     len(mockedStorer.UnlockPurgeCalls())
+func (mock *StorerMock) UnlockPurgeCalls() []struct {
+	Ctx    context.Context
+	LockID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		LockID string
+	}
+	lockStorerMockUnlockPurge.RLock()
+	calls = mock.calls.UnlockPurge
+	lockStorerMockUnlockPurge.RUnlock()
+	return calls
+}
+
 // UpdateBuildHierarchyTaskState calls UpdateBuildHierarchyTaskStateFunc.
-func (mock *StorerMock) UpdateBuildHierarchyTaskState(currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
+func (mock *StorerMock) UpdateBuildHierarchyTaskState(ctx context.Context, currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
 	if mock.UpdateBuildHierarchyTaskStateFunc == nil {
 		panic("StorerMock.UpdateBuildHierarchyTaskStateFunc: method is nil but Storer.UpdateBuildHierarchyTaskState was just called")
 	}
 	callInfo := struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Dimension       string
 		State           string
 		ETagSelector    string
 	}{
+		Ctx:             ctx,
 		CurrentInstance: currentInstance,
 		Dimension:       dimension,
 		State:           state,
@@ -1662,19 +3750,21 @@ func (mock *StorerMock) UpdateBuildHierarchyTaskState(currentInstance *models.In
 	lockStorerMockUpdateBuildHierarchyTaskState.Lock()
 	mock.calls.UpdateBuildHierarchyTaskState = append(mock.calls.UpdateBuildHierarchyTaskState, callInfo)
 	lockStorerMockUpdateBuildHierarchyTaskState.Unlock()
-	return mock.UpdateBuildHierarchyTaskStateFunc(currentInstance, dimension, state, eTagSelector)
+	return mock.UpdateBuildHierarchyTaskStateFunc(ctx, currentInstance, dimension, state, eTagSelector)
 }
 
 // UpdateBuildHierarchyTaskStateCalls gets all the calls that were made to UpdateBuildHierarchyTaskState.
 // Check the length with:
 //     len(mockedStorer.UpdateBuildHierarchyTaskStateCalls())
 func (mock *StorerMock) UpdateBuildHierarchyTaskStateCalls() []struct {
+	Ctx             context.Context
 	CurrentInstance *models.Instance
 	Dimension       string
 	State           string
 	ETagSelector    string
 } {
 	var calls []struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Dimension       string
 		State           string
@@ -1687,16 +3777,18 @@ func (mock *StorerMock) UpdateBuildHierarchyTaskStateCalls() []struct {
 }
 
 // UpdateBuildSearchTaskState calls UpdateBuildSearchTaskStateFunc.
-func (mock *StorerMock) UpdateBuildSearchTaskState(currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
+func (mock *StorerMock) UpdateBuildSearchTaskState(ctx context.Context, currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
 	if mock.UpdateBuildSearchTaskStateFunc == nil {
 		panic("StorerMock.UpdateBuildSearchTaskStateFunc: method is nil but Storer.UpdateBuildSearchTaskState was just called")
 	}
 	callInfo := struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Dimension       string
 		State           string
 		ETagSelector    string
 	}{
+		Ctx:             ctx,
 		CurrentInstance: currentInstance,
 		Dimension:       dimension,
 		State:           state,
@@ -1705,19 +3797,21 @@ func (mock *StorerMock) UpdateBuildSearchTaskState(currentInstance *models.Insta
 	lockStorerMockUpdateBuildSearchTaskState.Lock()
 	mock.calls.UpdateBuildSearchTaskState = append(mock.calls.UpdateBuildSearchTaskState, callInfo)
 	lockStorerMockUpdateBuildSearchTaskState.Unlock()
-	return mock.UpdateBuildSearchTaskStateFunc(currentInstance, dimension, state, eTagSelector)
+	return mock.UpdateBuildSearchTaskStateFunc(ctx, currentInstance, dimension, state, eTagSelector)
 }
 
 // UpdateBuildSearchTaskStateCalls gets all the calls that were made to UpdateBuildSearchTaskState.
 // Check the length with:
 //     len(mockedStorer.UpdateBuildSearchTaskStateCalls())
 func (mock *StorerMock) UpdateBuildSearchTaskStateCalls() []struct {
+	Ctx             context.Context
 	CurrentInstance *models.Instance
 	Dimension       string
 	State           string
 	ETagSelector    string
 } {
 	var calls []struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Dimension       string
 		State           string
@@ -1730,41 +3824,41 @@ func (mock *StorerMock) UpdateBuildSearchTaskStateCalls() []struct {
 }
 
 // UpdateDataset calls UpdateDatasetFunc.
-func (mock *StorerMock) UpdateDataset(ctx context.Context, ID string, dataset *models.Dataset, currentState string) error {
+func (mock *StorerMock) UpdateDataset(ctx context.Context, currentDataset *models.DatasetUpdate, dataset *models.Dataset, eTagSelector string) (string, error) {
 	if mock.UpdateDatasetFunc == nil {
 		panic("StorerMock.UpdateDatasetFunc: method is nil but Storer.UpdateDataset was just called")
 	}
 	callInfo := struct {
-		Ctx          context.Context
-		ID           string
-		Dataset      *models.Dataset
-		CurrentState string
+		Ctx            context.Context
+		CurrentDataset *models.DatasetUpdate
+		Dataset        *models.Dataset
+		ETagSelector   string
 	}{
-		Ctx:          ctx,
-		ID:           ID,
-		Dataset:      dataset,
-		CurrentState: currentState,
+		Ctx:            ctx,
+		CurrentDataset: currentDataset,
+		Dataset:        dataset,
+		ETagSelector:   eTagSelector,
 	}
 	lockStorerMockUpdateDataset.Lock()
 	mock.calls.UpdateDataset = append(mock.calls.UpdateDataset, callInfo)
 	lockStorerMockUpdateDataset.Unlock()
-	return mock.UpdateDatasetFunc(ctx, ID, dataset, currentState)
+	return mock.UpdateDatasetFunc(ctx, currentDataset, dataset, eTagSelector)
 }
 
 // UpdateDatasetCalls gets all the calls that were made to UpdateDataset.
 // Check the length with:
 //     len(mockedStorer.UpdateDatasetCalls())
 func (mock *StorerMock) UpdateDatasetCalls() []struct {
-	Ctx          context.Context
-	ID           string
-	Dataset      *models.Dataset
-	CurrentState string
+	Ctx            context.Context
+	CurrentDataset *models.DatasetUpdate
+	Dataset        *models.Dataset
+	ETagSelector   string
 } {
 	var calls []struct {
-		Ctx          context.Context
-		ID           string
-		Dataset      *models.Dataset
-		CurrentState string
+		Ctx            context.Context
+		CurrentDataset *models.DatasetUpdate
+		Dataset        *models.Dataset
+		ETagSelector   string
 	}
 	lockStorerMockUpdateDataset.RLock()
 	calls = mock.calls.UpdateDataset
@@ -1773,37 +3867,41 @@ func (mock *StorerMock) UpdateDatasetCalls() []struct {
 }
 
 // UpdateDatasetWithAssociation calls UpdateDatasetWithAssociationFunc.
-func (mock *StorerMock) UpdateDatasetWithAssociation(ID string, state string, version *models.Version) error {
+func (mock *StorerMock) UpdateDatasetWithAssociation(ctx context.Context, currentDataset *models.DatasetUpdate, state string, version *models.Version) error {
 	if mock.UpdateDatasetWithAssociationFunc == nil {
 		panic("StorerMock.UpdateDatasetWithAssociationFunc: method is nil but Storer.UpdateDatasetWithAssociation was just called")
 	}
 	callInfo := struct {
-		ID      string
-		State   string
-		Version *models.Version
+		Ctx            context.Context
+		CurrentDataset *models.DatasetUpdate
+		State          string
+		Version        *models.Version
 	}{
-		ID:      ID,
-		State:   state,
-		Version: version,
+		Ctx:            ctx,
+		CurrentDataset: currentDataset,
+		State:          state,
+		Version:        version,
 	}
 	lockStorerMockUpdateDatasetWithAssociation.Lock()
 	mock.calls.UpdateDatasetWithAssociation = append(mock.calls.UpdateDatasetWithAssociation, callInfo)
 	lockStorerMockUpdateDatasetWithAssociation.Unlock()
-	return mock.UpdateDatasetWithAssociationFunc(ID, state, version)
+	return mock.UpdateDatasetWithAssociationFunc(ctx, currentDataset, state, version)
 }
 
 // UpdateDatasetWithAssociationCalls gets all the calls that were made to UpdateDatasetWithAssociation.
 // Check the length with:
 //     len(mockedStorer.UpdateDatasetWithAssociationCalls())
 func (mock *StorerMock) UpdateDatasetWithAssociationCalls() []struct {
-	ID      string
-	State   string
-	Version *models.Version
+	Ctx            context.Context
+	CurrentDataset *models.DatasetUpdate
+	State          string
+	Version        *models.Version
 } {
 	var calls []struct {
-		ID      string
-		State   string
-		Version *models.Version
+		Ctx            context.Context
+		CurrentDataset *models.DatasetUpdate
+		State          string
+		Version        *models.Version
 	}
 	lockStorerMockUpdateDatasetWithAssociation.RLock()
 	calls = mock.calls.UpdateDatasetWithAssociation
@@ -1811,29 +3909,68 @@ func (mock *StorerMock) UpdateDatasetWithAssociationCalls() []struct {
 	return calls
 }
 
+// UpdateDeleteJob calls UpdateDeleteJobFunc.
+func (mock *StorerMock) UpdateDeleteJob(ctx context.Context, job *models.DeleteJob) error {
+	if mock.UpdateDeleteJobFunc == nil {
+		panic("StorerMock.UpdateDeleteJobFunc: method is nil but Storer.UpdateDeleteJob was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Job *models.DeleteJob
+	}{
+		Ctx: ctx,
+		Job: job,
+	}
+	lockStorerMockUpdateDeleteJob.Lock()
+	mock.calls.UpdateDeleteJob = append(mock.calls.UpdateDeleteJob, callInfo)
+	lockStorerMockUpdateDeleteJob.Unlock()
+	return mock.UpdateDeleteJobFunc(ctx, job)
+}
+
+// UpdateDeleteJobCalls gets all the calls that were made to UpdateDeleteJob.
+// Check the length with:
+//     len(mockedStorer.UpdateDeleteJobCalls())
+func (mock *StorerMock) UpdateDeleteJobCalls() []struct {
+	Ctx context.Context
+	Job *models.DeleteJob
+} {
+	var calls []struct {
+		Ctx context.Context
+		Job *models.DeleteJob
+	}
+	lockStorerMockUpdateDeleteJob.RLock()
+	calls = mock.calls.UpdateDeleteJob
+	lockStorerMockUpdateDeleteJob.RUnlock()
+	return calls
+}
+
 // UpdateDimensionNodeIDAndOrder calls UpdateDimensionNodeIDAndOrderFunc.
-func (mock *StorerMock) UpdateDimensionNodeIDAndOrder(dimension *models.DimensionOption) error {
+func (mock *StorerMock) UpdateDimensionNodeIDAndOrder(ctx context.Context, dimension *models.DimensionOption) error {
 	if mock.UpdateDimensionNodeIDAndOrderFunc == nil {
 		panic("StorerMock.UpdateDimensionNodeIDAndOrderFunc: method is nil but Storer.UpdateDimensionNodeIDAndOrder was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		Dimension *models.DimensionOption
 	}{
+		Ctx:       ctx,
 		Dimension: dimension,
 	}
 	lockStorerMockUpdateDimensionNodeIDAndOrder.Lock()
 	mock.calls.UpdateDimensionNodeIDAndOrder = append(mock.calls.UpdateDimensionNodeIDAndOrder, callInfo)
 	lockStorerMockUpdateDimensionNodeIDAndOrder.Unlock()
-	return mock.UpdateDimensionNodeIDAndOrderFunc(dimension)
+	return mock.UpdateDimensionNodeIDAndOrderFunc(ctx, dimension)
 }
 
 // UpdateDimensionNodeIDAndOrderCalls gets all the calls that were made to UpdateDimensionNodeIDAndOrder.
 // Check the length with:
 //     len(mockedStorer.UpdateDimensionNodeIDAndOrderCalls())
 func (mock *StorerMock) UpdateDimensionNodeIDAndOrderCalls() []struct {
+	Ctx       context.Context
 	Dimension *models.DimensionOption
 } {
 	var calls []struct {
+		Ctx       context.Context
 		Dimension *models.DimensionOption
 	}
 	lockStorerMockUpdateDimensionNodeIDAndOrder.RLock()
@@ -1843,16 +3980,18 @@ func (mock *StorerMock) UpdateDimensionNodeIDAndOrderCalls() []struct {
 }
 
 // UpdateETagForNodeIDAndOrder calls UpdateETagForNodeIDAndOrderFunc.
-func (mock *StorerMock) UpdateETagForNodeIDAndOrder(currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
+func (mock *StorerMock) UpdateETagForNodeIDAndOrder(ctx context.Context, currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
 	if mock.UpdateETagForNodeIDAndOrderFunc == nil {
 		panic("StorerMock.UpdateETagForNodeIDAndOrderFunc: method is nil but Storer.UpdateETagForNodeIDAndOrder was just called")
 	}
 	callInfo := struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		NodeID          string
 		Order           *int
 		ETagSelector    string
 	}{
+		Ctx:             ctx,
 		CurrentInstance: currentInstance,
 		NodeID:          nodeID,
 		Order:           order,
@@ -1861,19 +4000,21 @@ func (mock *StorerMock) UpdateETagForNodeIDAndOrder(currentInstance *models.Inst
 	lockStorerMockUpdateETagForNodeIDAndOrder.Lock()
 	mock.calls.UpdateETagForNodeIDAndOrder = append(mock.calls.UpdateETagForNodeIDAndOrder, callInfo)
 	lockStorerMockUpdateETagForNodeIDAndOrder.Unlock()
-	return mock.UpdateETagForNodeIDAndOrderFunc(currentInstance, nodeID, order, eTagSelector)
+	return mock.UpdateETagForNodeIDAndOrderFunc(ctx, currentInstance, nodeID, order, eTagSelector)
 }
 
 // UpdateETagForNodeIDAndOrderCalls gets all the calls that were made to UpdateETagForNodeIDAndOrder.
 // Check the length with:
 //     len(mockedStorer.UpdateETagForNodeIDAndOrderCalls())
 func (mock *StorerMock) UpdateETagForNodeIDAndOrderCalls() []struct {
+	Ctx             context.Context
 	CurrentInstance *models.Instance
 	NodeID          string
 	Order           *int
 	ETagSelector    string
 } {
 	var calls []struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		NodeID          string
 		Order           *int
@@ -1886,15 +4027,17 @@ func (mock *StorerMock) UpdateETagForNodeIDAndOrderCalls() []struct {
 }
 
 // UpdateETagForOptions calls UpdateETagForOptionsFunc.
-func (mock *StorerMock) UpdateETagForOptions(currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error) {
+func (mock *StorerMock) UpdateETagForOptions(ctx context.Context, currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error) {
 	if mock.UpdateETagForOptionsFunc == nil {
 		panic("StorerMock.UpdateETagForOptionsFunc: method is nil but Storer.UpdateETagForOptions was just called")
 	}
 	callInfo := struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Option          *models.CachedDimensionOption
 		ETagSelector    string
 	}{
+		Ctx:             ctx,
 		CurrentInstance: currentInstance,
 		Option:          option,
 		ETagSelector:    eTagSelector,
@@ -1902,18 +4045,20 @@ func (mock *StorerMock) UpdateETagForOptions(currentInstance *models.Instance, o
 	lockStorerMockUpdateETagForOptions.Lock()
 	mock.calls.UpdateETagForOptions = append(mock.calls.UpdateETagForOptions, callInfo)
 	lockStorerMockUpdateETagForOptions.Unlock()
-	return mock.UpdateETagForOptionsFunc(currentInstance, option, eTagSelector)
+	return mock.UpdateETagForOptionsFunc(ctx, currentInstance, option, eTagSelector)
 }
 
 // UpdateETagForOptionsCalls gets all the calls that were made to UpdateETagForOptions.
 // Check the length with:
 //     len(mockedStorer.UpdateETagForOptionsCalls())
 func (mock *StorerMock) UpdateETagForOptionsCalls() []struct {
+	Ctx             context.Context
 	CurrentInstance *models.Instance
 	Option          *models.CachedDimensionOption
 	ETagSelector    string
 } {
 	var calls []struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Option          *models.CachedDimensionOption
 		ETagSelector    string
@@ -1925,15 +4070,17 @@ func (mock *StorerMock) UpdateETagForOptionsCalls() []struct {
 }
 
 // UpdateImportObservationsTaskState calls UpdateImportObservationsTaskStateFunc.
-func (mock *StorerMock) UpdateImportObservationsTaskState(currentInstance *models.Instance, state string, eTagSelector string) (string, error) {
+func (mock *StorerMock) UpdateImportObservationsTaskState(ctx context.Context, currentInstance *models.Instance, state string, eTagSelector string) (string, error) {
 	if mock.UpdateImportObservationsTaskStateFunc == nil {
 		panic("StorerMock.UpdateImportObservationsTaskStateFunc: method is nil but Storer.UpdateImportObservationsTaskState was just called")
 	}
 	callInfo := struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		State           string
 		ETagSelector    string
 	}{
+		Ctx:             ctx,
 		CurrentInstance: currentInstance,
 		State:           state,
 		ETagSelector:    eTagSelector,
@@ -1941,18 +4088,20 @@ func (mock *StorerMock) UpdateImportObservationsTaskState(currentInstance *model
 	lockStorerMockUpdateImportObservationsTaskState.Lock()
 	mock.calls.UpdateImportObservationsTaskState = append(mock.calls.UpdateImportObservationsTaskState, callInfo)
 	lockStorerMockUpdateImportObservationsTaskState.Unlock()
-	return mock.UpdateImportObservationsTaskStateFunc(currentInstance, state, eTagSelector)
+	return mock.UpdateImportObservationsTaskStateFunc(ctx, currentInstance, state, eTagSelector)
 }
 
 // UpdateImportObservationsTaskStateCalls gets all the calls that were made to UpdateImportObservationsTaskState.
 // Check the length with:
 //     len(mockedStorer.UpdateImportObservationsTaskStateCalls())
 func (mock *StorerMock) UpdateImportObservationsTaskStateCalls() []struct {
+	Ctx             context.Context
 	CurrentInstance *models.Instance
 	State           string
 	ETagSelector    string
 } {
 	var calls []struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		State           string
 		ETagSelector    string
@@ -2007,15 +4156,17 @@ func (mock *StorerMock) UpdateInstanceCalls() []struct {
 }
 
 // UpdateObservationInserted calls UpdateObservationInsertedFunc.
-func (mock *StorerMock) UpdateObservationInserted(currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error) {
+func (mock *StorerMock) UpdateObservationInserted(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error) {
 	if mock.UpdateObservationInsertedFunc == nil {
 		panic("StorerMock.UpdateObservationInsertedFunc: method is nil but Storer.UpdateObservationInserted was just called")
 	}
 	callInfo := struct {
+		Ctx                 context.Context
 		CurrentInstance     *models.Instance
 		ObservationInserted int64
 		ETagSelector        string
 	}{
+		Ctx:                 ctx,
 		CurrentInstance:     currentInstance,
 		ObservationInserted: observationInserted,
 		ETagSelector:        eTagSelector,
@@ -2023,18 +4174,20 @@ func (mock *StorerMock) UpdateObservationInserted(currentInstance *models.Instan
 	lockStorerMockUpdateObservationInserted.Lock()
 	mock.calls.UpdateObservationInserted = append(mock.calls.UpdateObservationInserted, callInfo)
 	lockStorerMockUpdateObservationInserted.Unlock()
-	return mock.UpdateObservationInsertedFunc(currentInstance, observationInserted, eTagSelector)
+	return mock.UpdateObservationInsertedFunc(ctx, currentInstance, observationInserted, eTagSelector)
 }
 
 // UpdateObservationInsertedCalls gets all the calls that were made to UpdateObservationInserted.
 // Check the length with:
 //     len(mockedStorer.UpdateObservationInsertedCalls())
 func (mock *StorerMock) UpdateObservationInsertedCalls() []struct {
+	Ctx                 context.Context
 	CurrentInstance     *models.Instance
 	ObservationInserted int64
 	ETagSelector        string
 } {
 	var calls []struct {
+		Ctx                 context.Context
 		CurrentInstance     *models.Instance
 		ObservationInserted int64
 		ETagSelector        string
@@ -2046,33 +4199,41 @@ func (mock *StorerMock) UpdateObservationInsertedCalls() []struct {
 }
 
 // UpdateVersion calls UpdateVersionFunc.
-func (mock *StorerMock) UpdateVersion(ID string, version *models.Version) error {
+func (mock *StorerMock) UpdateVersion(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (string, error) {
 	if mock.UpdateVersionFunc == nil {
 		panic("StorerMock.UpdateVersionFunc: method is nil but Storer.UpdateVersion was just called")
 	}
 	callInfo := struct {
-		ID      string
-		Version *models.Version
+		Ctx            context.Context
+		CurrentVersion *models.Version
+		Version        *models.Version
+		ETagSelector   string
 	}{
-		ID:      ID,
-		Version: version,
+		Ctx:            ctx,
+		CurrentVersion: currentVersion,
+		Version:        version,
+		ETagSelector:   eTagSelector,
 	}
 	lockStorerMockUpdateVersion.Lock()
 	mock.calls.UpdateVersion = append(mock.calls.UpdateVersion, callInfo)
 	lockStorerMockUpdateVersion.Unlock()
-	return mock.UpdateVersionFunc(ID, version)
+	return mock.UpdateVersionFunc(ctx, currentVersion, version, eTagSelector)
 }
 
 // UpdateVersionCalls gets all the calls that were made to UpdateVersion.
 // Check the length with:
 //     len(mockedStorer.UpdateVersionCalls())
 func (mock *StorerMock) UpdateVersionCalls() []struct {
-	ID      string
-	Version *models.Version
+	Ctx            context.Context
+	CurrentVersion *models.Version
+	Version        *models.Version
+	ETagSelector   string
 } {
 	var calls []struct {
-		ID      string
-		Version *models.Version
+		Ctx            context.Context
+		CurrentVersion *models.Version
+		Version        *models.Version
+		ETagSelector   string
 	}
 	lockStorerMockUpdateVersion.RLock()
 	calls = mock.calls.UpdateVersion
@@ -2081,31 +4242,35 @@ func (mock *StorerMock) UpdateVersionCalls() []struct {
 }
 
 // UpsertContact calls UpsertContactFunc.
-func (mock *StorerMock) UpsertContact(ID string, update interface{}) error {
+func (mock *StorerMock) UpsertContact(ctx context.Context, ID string, update interface{}) error {
 	if mock.UpsertContactFunc == nil {
 		panic("StorerMock.UpsertContactFunc: method is nil but Storer.UpsertContact was just called")
 	}
 	callInfo := struct {
+		Ctx    context.Context
 		ID     string
 		Update interface{}
 	}{
+		Ctx:    ctx,
 		ID:     ID,
 		Update: update,
 	}
 	lockStorerMockUpsertContact.Lock()
 	mock.calls.UpsertContact = append(mock.calls.UpsertContact, callInfo)
 	lockStorerMockUpsertContact.Unlock()
-	return mock.UpsertContactFunc(ID, update)
+	return mock.UpsertContactFunc(ctx, ID, update)
 }
 
 // UpsertContactCalls gets all the calls that were made to UpsertContact.
 // Check the length with:
 //     len(mockedStorer.UpsertContactCalls())
 func (mock *StorerMock) UpsertContactCalls() []struct {
+	Ctx    context.Context
 	ID     string
 	Update interface{}
 } {
 	var calls []struct {
+		Ctx    context.Context
 		ID     string
 		Update interface{}
 	}
@@ -2116,33 +4281,41 @@ func (mock *StorerMock) UpsertContactCalls() []struct {
 }
 
 // UpsertDataset calls UpsertDatasetFunc.
-func (mock *StorerMock) UpsertDataset(ID string, datasetDoc *models.DatasetUpdate) error {
+func (mock *StorerMock) UpsertDataset(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
 	if mock.UpsertDatasetFunc == nil {
 		panic("StorerMock.UpsertDatasetFunc: method is nil but Storer.UpsertDataset was just called")
 	}
 	callInfo := struct {
-		ID         string
-		DatasetDoc *models.DatasetUpdate
+		Ctx          context.Context
+		ID           string
+		DatasetDoc   *models.DatasetUpdate
+		ETagSelector string
 	}{
-		ID:         ID,
-		DatasetDoc: datasetDoc,
+		Ctx:          ctx,
+		ID:           ID,
+		DatasetDoc:   datasetDoc,
+		ETagSelector: eTagSelector,
 	}
 	lockStorerMockUpsertDataset.Lock()
 	mock.calls.UpsertDataset = append(mock.calls.UpsertDataset, callInfo)
 	lockStorerMockUpsertDataset.Unlock()
-	return mock.UpsertDatasetFunc(ID, datasetDoc)
+	return mock.UpsertDatasetFunc(ctx, ID, datasetDoc, eTagSelector)
 }
 
 // UpsertDatasetCalls gets all the calls that were made to UpsertDataset.
 // Check the length with:
 //     len(mockedStorer.UpsertDatasetCalls())
 func (mock *StorerMock) UpsertDatasetCalls() []struct {
-	ID         string
-	DatasetDoc *models.DatasetUpdate
+	Ctx          context.Context
+	ID           string
+	DatasetDoc   *models.DatasetUpdate
+	ETagSelector string
 } {
 	var calls []struct {
-		ID         string
-		DatasetDoc *models.DatasetUpdate
+		Ctx          context.Context
+		ID           string
+		DatasetDoc   *models.DatasetUpdate
+		ETagSelector string
 	}
 	lockStorerMockUpsertDataset.RLock()
 	calls = mock.calls.UpsertDataset
@@ -2151,37 +4324,45 @@ func (mock *StorerMock) UpsertDatasetCalls() []struct {
 }
 
 // UpsertEdition calls UpsertEditionFunc.
-func (mock *StorerMock) UpsertEdition(datasetID string, edition string, editionDoc *models.EditionUpdate) error {
+func (mock *StorerMock) UpsertEdition(ctx context.Context, datasetID string, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error {
 	if mock.UpsertEditionFunc == nil {
 		panic("StorerMock.UpsertEditionFunc: method is nil but Storer.UpsertEdition was just called")
 	}
 	callInfo := struct {
-		DatasetID  string
-		Edition    string
-		EditionDoc *models.EditionUpdate
+		Ctx          context.Context
+		DatasetID    string
+		Edition      string
+		EditionDoc   *models.EditionUpdate
+		ETagSelector string
 	}{
-		DatasetID:  datasetID,
-		Edition:    edition,
-		EditionDoc: editionDoc,
+		Ctx:          ctx,
+		DatasetID:    datasetID,
+		Edition:      edition,
+		EditionDoc:   editionDoc,
+		ETagSelector: eTagSelector,
 	}
 	lockStorerMockUpsertEdition.Lock()
 	mock.calls.UpsertEdition = append(mock.calls.UpsertEdition, callInfo)
 	lockStorerMockUpsertEdition.Unlock()
-	return mock.UpsertEditionFunc(datasetID, edition, editionDoc)
+	return mock.UpsertEditionFunc(ctx, datasetID, edition, editionDoc, eTagSelector)
 }
 
 // UpsertEditionCalls gets all the calls that were made to UpsertEdition.
 // Check the length with:
 //     len(mockedStorer.UpsertEditionCalls())
 func (mock *StorerMock) UpsertEditionCalls() []struct {
-	DatasetID  string
-	Edition    string
-	EditionDoc *models.EditionUpdate
+	Ctx          context.Context
+	DatasetID    string
+	Edition      string
+	EditionDoc   *models.EditionUpdate
+	ETagSelector string
 } {
 	var calls []struct {
-		DatasetID  string
-		Edition    string
-		EditionDoc *models.EditionUpdate
+		Ctx          context.Context
+		DatasetID    string
+		Edition      string
+		EditionDoc   *models.EditionUpdate
+		ETagSelector string
 	}
 	lockStorerMockUpsertEdition.RLock()
 	calls = mock.calls.UpsertEdition
@@ -2189,32 +4370,106 @@ func (mock *StorerMock) UpsertEditionCalls() []struct {
 	return calls
 }
 
+// UpsertLinkReport calls UpsertLinkReportFunc.
+func (mock *StorerMock) UpsertLinkReport(ctx context.Context, report *models.LinkReport) error {
+	if mock.UpsertLinkReportFunc == nil {
+		panic("StorerMock.UpsertLinkReportFunc: method is nil but Storer.UpsertLinkReport was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Report *models.LinkReport
+	}{
+		Ctx:    ctx,
+		Report: report,
+	}
+	lockStorerMockUpsertLinkReport.Lock()
+	mock.calls.UpsertLinkReport = append(mock.calls.UpsertLinkReport, callInfo)
+	lockStorerMockUpsertLinkReport.Unlock()
+	return mock.UpsertLinkReportFunc(ctx, report)
+}
+
+// UpsertLinkReportCalls gets all the calls that were made to UpsertLinkReport.
+// Check the length with:
+//     len(mockedStorer.UpsertLinkReportCalls())
+func (mock *StorerMock) UpsertLinkReportCalls() []struct {
+	Ctx    context.Context
+	Report *models.LinkReport
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Report *models.LinkReport
+	}
+	lockStorerMockUpsertLinkReport.RLock()
+	calls = mock.calls.UpsertLinkReport
+	lockStorerMockUpsertLinkReport.RUnlock()
+	return calls
+}
+
+// UpsertTopic calls UpsertTopicFunc.
+func (mock *StorerMock) UpsertTopic(ctx context.Context, topic *models.Topic) error {
+	if mock.UpsertTopicFunc == nil {
+		panic("StorerMock.UpsertTopicFunc: method is nil but Storer.UpsertTopic was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Topic *models.Topic
+	}{
+		Ctx:   ctx,
+		Topic: topic,
+	}
+	lockStorerMockUpsertTopic.Lock()
+	mock.calls.UpsertTopic = append(mock.calls.UpsertTopic, callInfo)
+	lockStorerMockUpsertTopic.Unlock()
+	return mock.UpsertTopicFunc(ctx, topic)
+}
+
+// UpsertTopicCalls gets all the calls that were made to UpsertTopic.
+// Check the length with:
+//     len(mockedStorer.UpsertTopicCalls())
+func (mock *StorerMock) UpsertTopicCalls() []struct {
+	Ctx   context.Context
+	Topic *models.Topic
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Topic *models.Topic
+	}
+	lockStorerMockUpsertTopic.RLock()
+	calls = mock.calls.UpsertTopic
+	lockStorerMockUpsertTopic.RUnlock()
+	return calls
+}
+
 // UpsertVersion calls UpsertVersionFunc.
-func (mock *StorerMock) UpsertVersion(ID string, versionDoc *models.Version) error {
+func (mock *StorerMock) UpsertVersion(ctx context.Context, ID string, versionDoc *models.Version) error {
 	if mock.UpsertVersionFunc == nil {
 		panic("StorerMock.UpsertVersionFunc: method is nil but Storer.UpsertVersion was just called")
 	}
 	callInfo := struct {
+		Ctx        context.Context
 		ID         string
 		VersionDoc *models.Version
 	}{
+		Ctx:        ctx,
 		ID:         ID,
 		VersionDoc: versionDoc,
 	}
 	lockStorerMockUpsertVersion.Lock()
 	mock.calls.UpsertVersion = append(mock.calls.UpsertVersion, callInfo)
 	lockStorerMockUpsertVersion.Unlock()
-	return mock.UpsertVersionFunc(ID, versionDoc)
+	return mock.UpsertVersionFunc(ctx, ID, versionDoc)
 }
 
 // UpsertVersionCalls gets all the calls that were made to UpsertVersion.
 // Check the length with:
 //     len(mockedStorer.UpsertVersionCalls())
 func (mock *StorerMock) UpsertVersionCalls() []struct {
+	Ctx        context.Context
 	ID         string
 	VersionDoc *models.Version
 } {
 	var calls []struct {
+		Ctx        context.Context
 		ID         string
 		VersionDoc *models.Version
 	}
@@ -14,6 +14,8 @@ var (
 	lockGraphDBMockAddVersionDetailsToInstance sync.RWMutex
 	lockGraphDBMockChecker                     sync.RWMutex
 	lockGraphDBMockClose                       sync.RWMutex
+	lockGraphDBMockCountInstanceNodes          sync.RWMutex
+	lockGraphDBMockDeleteInstanceNodes         sync.RWMutex
 	lockGraphDBMockSetInstanceIsPublished      sync.RWMutex
 )
 
@@ -36,6 +38,12 @@ var _ store.GraphDB = &GraphDBMock{}
 //             CloseFunc: func(ctx context.Context) error {
 // 	               panic("mock out the Close method")
 //             },
+//             CountInstanceNodesFunc: func(ctx context.Context, instanceID string) (int64, error) {
+// 	               panic("mock out the CountInstanceNodes method")
+//             },
+//             DeleteInstanceNodesFunc: func(ctx context.Context, instanceID string) (int64, error) {
+// 	               panic("mock out the DeleteInstanceNodes method")
+//             },
 //             SetInstanceIsPublishedFunc: func(ctx context.Context, instanceID string) error {
 // 	               panic("mock out the SetInstanceIsPublished method")
 //             },
@@ -55,6 +63,12 @@ type GraphDBMock struct {
 	// CloseFunc mocks the Close method.
 	CloseFunc func(ctx context.Context) error
 
+	// CountInstanceNodesFunc mocks the CountInstanceNodes method.
+	CountInstanceNodesFunc func(ctx context.Context, instanceID string) (int64, error)
+
+	// DeleteInstanceNodesFunc mocks the DeleteInstanceNodes method.
+	DeleteInstanceNodesFunc func(ctx context.Context, instanceID string) (int64, error)
+
 	// SetInstanceIsPublishedFunc mocks the SetInstanceIsPublished method.
 	SetInstanceIsPublishedFunc func(ctx context.Context, instanceID string) error
 
@@ -85,6 +99,20 @@ type GraphDBMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// CountInstanceNodes holds details about calls to the CountInstanceNodes method.
+		CountInstanceNodes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InstanceID is the instanceID argument value.
+			InstanceID string
+		}
+		// DeleteInstanceNodes holds details about calls to the DeleteInstanceNodes method.
+		DeleteInstanceNodes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InstanceID is the instanceID argument value.
+			InstanceID string
+		}
 		// SetInstanceIsPublished holds details about calls to the SetInstanceIsPublished method.
 		SetInstanceIsPublished []struct {
 			// Ctx is the ctx argument value.
@@ -208,6 +236,76 @@ func (mock *GraphDBMock) CloseCalls() []struct {
 	return calls
 }
 
+// CountInstanceNodes calls CountInstanceNodesFunc.
+func (mock *GraphDBMock) CountInstanceNodes(ctx context.Context, instanceID string) (int64, error) {
+	if mock.CountInstanceNodesFunc == nil {
+		panic("GraphDBMock.CountInstanceNodesFunc: method is nil but GraphDB.CountInstanceNodes was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		InstanceID string
+	}{
+		Ctx:        ctx,
+		InstanceID: instanceID,
+	}
+	lockGraphDBMockCountInstanceNodes.Lock()
+	mock.calls.CountInstanceNodes = append(mock.calls.CountInstanceNodes, callInfo)
+	lockGraphDBMockCountInstanceNodes.Unlock()
+	return mock.CountInstanceNodesFunc(ctx, instanceID)
+}
+
+// CountInstanceNodesCalls gets all the calls that were made to CountInstanceNodes.
+// Check the length with:
+//     len(mockedGraphDB.CountInstanceNodesCalls())
+func (mock *GraphDBMock) CountInstanceNodesCalls() []struct {
+	Ctx        context.Context
+	InstanceID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		InstanceID string
+	}
+	lockGraphDBMockCountInstanceNodes.RLock()
+	calls = mock.calls.CountInstanceNodes
+	lockGraphDBMockCountInstanceNodes.RUnlock()
+	return calls
+}
+
+// DeleteInstanceNodes calls DeleteInstanceNodesFunc.
+func (mock *GraphDBMock) DeleteInstanceNodes(ctx context.Context, instanceID string) (int64, error) {
+	if mock.DeleteInstanceNodesFunc == nil {
+		panic("GraphDBMock.DeleteInstanceNodesFunc: method is nil but GraphDB.DeleteInstanceNodes was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		InstanceID string
+	}{
+		Ctx:        ctx,
+		InstanceID: instanceID,
+	}
+	lockGraphDBMockDeleteInstanceNodes.Lock()
+	mock.calls.DeleteInstanceNodes = append(mock.calls.DeleteInstanceNodes, callInfo)
+	lockGraphDBMockDeleteInstanceNodes.Unlock()
+	return mock.DeleteInstanceNodesFunc(ctx, instanceID)
+}
+
+// DeleteInstanceNodesCalls gets all the calls that were made to DeleteInstanceNodes.
+// Check the length with:
+//     len(mockedGraphDB.DeleteInstanceNodesCalls())
+func (mock *GraphDBMock) DeleteInstanceNodesCalls() []struct {
+	Ctx        context.Context
+	InstanceID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		InstanceID string
+	}
+	lockGraphDBMockDeleteInstanceNodes.RLock()
+	calls = mock.calls.DeleteInstanceNodes
+	lockGraphDBMockDeleteInstanceNodes.RUnlock()
+	return calls
+}
+
 // SetInstanceIsPublished calls SetInstanceIsPublishedFunc.
 func (mock *GraphDBMock) SetInstanceIsPublished(ctx context.Context, instanceID string) error {
 	if mock.SetInstanceIsPublishedFunc == nil {
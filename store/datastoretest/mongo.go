@@ -10,38 +10,77 @@ import (
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/globalsign/mgo/bson"
 	"sync"
+	"time"
 )
 
 var (
 	lockMongoDBMockAcquireInstanceLock               sync.RWMutex
+	lockMongoDBMockAddDeleteJob                      sync.RWMutex
 	lockMongoDBMockAddDimensionToInstance            sync.RWMutex
 	lockMongoDBMockAddEventToInstance                sync.RWMutex
 	lockMongoDBMockAddInstance                       sync.RWMutex
+	lockMongoDBMockBackfillETags                     sync.RWMutex
 	lockMongoDBMockCheckDatasetExists                sync.RWMutex
 	lockMongoDBMockCheckEditionExists                sync.RWMutex
 	lockMongoDBMockChecker                           sync.RWMutex
+	lockMongoDBMockClearLatestEditions               sync.RWMutex
 	lockMongoDBMockClose                             sync.RWMutex
+	lockMongoDBMockCountDimensionOptions             sync.RWMutex
+	lockMongoDBMockCountTopicDatasets                sync.RWMutex
 	lockMongoDBMockDeleteDataset                     sync.RWMutex
+	lockMongoDBMockDeleteDimensionOptions            sync.RWMutex
 	lockMongoDBMockDeleteEdition                     sync.RWMutex
+	lockMongoDBMockDeleteInstance                    sync.RWMutex
+	lockMongoDBMockDeleteLinkReportsExcept           sync.RWMutex
+	lockMongoDBMockDeleteTopic                       sync.RWMutex
 	lockMongoDBMockGetDataset                        sync.RWMutex
+	lockMongoDBMockGetDatasetEditions                sync.RWMutex
+	lockMongoDBMockGetDatasetInstances               sync.RWMutex
 	lockMongoDBMockGetDatasets                       sync.RWMutex
+	lockMongoDBMockGetDatasetsByIDs                  sync.RWMutex
+	lockMongoDBMockGetDatasetsDeletedBefore          sync.RWMutex
+	lockMongoDBMockGetDatasetsRelatedTo              sync.RWMutex
+	lockMongoDBMockGetDeleteJob                      sync.RWMutex
+	lockMongoDBMockGetDeletedDataset                 sync.RWMutex
 	lockMongoDBMockGetDimensionOptions               sync.RWMutex
 	lockMongoDBMockGetDimensionOptionsFromIDs        sync.RWMutex
+	lockMongoDBMockGetDimensionSummaries             sync.RWMutex
 	lockMongoDBMockGetDimensions                     sync.RWMutex
 	lockMongoDBMockGetDimensionsFromInstance         sync.RWMutex
 	lockMongoDBMockGetEdition                        sync.RWMutex
 	lockMongoDBMockGetEditions                       sync.RWMutex
+	lockMongoDBMockGetEditionsDeletedBefore          sync.RWMutex
 	lockMongoDBMockGetInstance                       sync.RWMutex
 	lockMongoDBMockGetInstances                      sync.RWMutex
+	lockMongoDBMockGetLinkReport                     sync.RWMutex
+	lockMongoDBMockGetLinkReports                    sync.RWMutex
 	lockMongoDBMockGetNextVersion                    sync.RWMutex
+	lockMongoDBMockGetPreviousPublishedVersion       sync.RWMutex
+	lockMongoDBMockGetTopic                          sync.RWMutex
+	lockMongoDBMockGetTopicDatasets                  sync.RWMutex
+	lockMongoDBMockGetTopics                         sync.RWMutex
+	lockMongoDBMockGetTopicsPage                     sync.RWMutex
 	lockMongoDBMockGetUniqueDimensionAndOptions      sync.RWMutex
 	lockMongoDBMockGetVersion                        sync.RWMutex
 	lockMongoDBMockGetVersions                       sync.RWMutex
+	lockMongoDBMockGetVersionsInCollections          sync.RWMutex
+	lockMongoDBMockLockLinkCheck                     sync.RWMutex
+	lockMongoDBMockLockPurge                         sync.RWMutex
+	lockMongoDBMockMigrateLinks                      sync.RWMutex
+	lockMongoDBMockRenewLinkCheckLock                sync.RWMutex
+	lockMongoDBMockRenewPurgeLock                    sync.RWMutex
+	lockMongoDBMockRestoreDataset                    sync.RWMutex
+	lockMongoDBMockRetryImportTasks                  sync.RWMutex
+	lockMongoDBMockSoftDeleteDataset                 sync.RWMutex
+	lockMongoDBMockSoftDeleteEdition                 sync.RWMutex
 	lockMongoDBMockUnlockInstance                    sync.RWMutex
+	lockMongoDBMockUnlockLinkCheck                   sync.RWMutex
+	lockMongoDBMockUnlockPurge                       sync.RWMutex
 	lockMongoDBMockUpdateBuildHierarchyTaskState     sync.RWMutex
 	lockMongoDBMockUpdateBuildSearchTaskState        sync.RWMutex
 	lockMongoDBMockUpdateDataset                     sync.RWMutex
 	lockMongoDBMockUpdateDatasetWithAssociation      sync.RWMutex
+	lockMongoDBMockUpdateDeleteJob                   sync.RWMutex
 	lockMongoDBMockUpdateDimensionNodeIDAndOrder     sync.RWMutex
 	lockMongoDBMockUpdateETagForNodeIDAndOrder       sync.RWMutex
 	lockMongoDBMockUpdateETagForOptions              sync.RWMutex
@@ -52,6 +91,8 @@ var (
 	lockMongoDBMockUpsertContact                     sync.RWMutex
 	lockMongoDBMockUpsertDataset                     sync.RWMutex
 	lockMongoDBMockUpsertEdition                     sync.RWMutex
+	lockMongoDBMockUpsertLinkReport                  sync.RWMutex
+	lockMongoDBMockUpsertTopic                       sync.RWMutex
 	lockMongoDBMockUpsertVersion                     sync.RWMutex
 )
 
@@ -68,121 +109,241 @@ var _ store.MongoDB = &MongoDBMock{}
 //             AcquireInstanceLockFunc: func(ctx context.Context, instanceID string) (string, error) {
 // 	               panic("mock out the AcquireInstanceLock method")
 //             },
-//             AddDimensionToInstanceFunc: func(dimension *models.CachedDimensionOption) error {
+//             AddDeleteJobFunc: func(ctx context.Context, job *models.DeleteJob) error {
+// 	               panic("mock out the AddDeleteJob method")
+//             },
+//             AddDimensionToInstanceFunc: func(ctx context.Context, dimension *models.CachedDimensionOption) error {
 // 	               panic("mock out the AddDimensionToInstance method")
 //             },
-//             AddEventToInstanceFunc: func(currentInstance *models.Instance, event *models.Event, eTagSelector string) (string, error) {
+//             AddEventToInstanceFunc: func(ctx context.Context, currentInstance *models.Instance, event *models.Event, eTagSelector string) (string, error) {
 // 	               panic("mock out the AddEventToInstance method")
 //             },
-//             AddInstanceFunc: func(instance *models.Instance) (*models.Instance, error) {
+//             AddInstanceFunc: func(ctx context.Context, instance *models.Instance) (*models.Instance, error) {
 // 	               panic("mock out the AddInstance method")
 //             },
-//             CheckDatasetExistsFunc: func(ID string, state string) error {
+//             BackfillETagsFunc: func(ctx context.Context) (int, error) {
+// 	               panic("mock out the BackfillETags method")
+//             },
+//             CheckDatasetExistsFunc: func(ctx context.Context, ID string, state string) error {
 // 	               panic("mock out the CheckDatasetExists method")
 //             },
-//             CheckEditionExistsFunc: func(ID string, editionID string, state string) error {
+//             CheckEditionExistsFunc: func(ctx context.Context, ID string, editionID string, state string) error {
 // 	               panic("mock out the CheckEditionExists method")
 //             },
 //             CheckerFunc: func(in1 context.Context, in2 *healthcheck.CheckState) error {
 // 	               panic("mock out the Checker method")
 //             },
+//             ClearLatestEditionsFunc: func(ctx context.Context, datasetID string, edition string) error {
+// 	               panic("mock out the ClearLatestEditions method")
+//             },
 //             CloseFunc: func(in1 context.Context) error {
 // 	               panic("mock out the Close method")
 //             },
-//             DeleteDatasetFunc: func(ID string) error {
+//             CountDimensionOptionsFunc: func(ctx context.Context, instanceID string) (int, error) {
+// 	               panic("mock out the CountDimensionOptions method")
+//             },
+//             CountTopicDatasetsFunc: func(ctx context.Context, id string) (int, error) {
+// 	               panic("mock out the CountTopicDatasets method")
+//             },
+//             DeleteDatasetFunc: func(ctx context.Context, ID string) error {
 // 	               panic("mock out the DeleteDataset method")
 //             },
-//             DeleteEditionFunc: func(ID string) error {
+//             DeleteDimensionOptionsFunc: func(ctx context.Context, instanceID string) (int, error) {
+// 	               panic("mock out the DeleteDimensionOptions method")
+//             },
+//             DeleteEditionFunc: func(ctx context.Context, ID string) error {
 // 	               panic("mock out the DeleteEdition method")
 //             },
-//             GetDatasetFunc: func(ID string) (*models.DatasetUpdate, error) {
+//             DeleteInstanceFunc: func(ctx context.Context, instanceID string) error {
+// 	               panic("mock out the DeleteInstance method")
+//             },
+//             DeleteLinkReportsExceptFunc: func(ctx context.Context, datasetIDs []string) error {
+// 	               panic("mock out the DeleteLinkReportsExcept method")
+//             },
+//             DeleteTopicFunc: func(ctx context.Context, id string) error {
+// 	               panic("mock out the DeleteTopic method")
+//             },
+//             GetDatasetFunc: func(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
 // 	               panic("mock out the GetDataset method")
 //             },
+//             GetDatasetEditionsFunc: func(ctx context.Context, datasetID string) ([]*models.EditionUpdate, error) {
+// 	               panic("mock out the GetDatasetEditions method")
+//             },
+//             GetDatasetInstancesFunc: func(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+// 	               panic("mock out the GetDatasetInstances method")
+//             },
 //             GetDatasetsFunc: func(ctx context.Context, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
 // 	               panic("mock out the GetDatasets method")
 //             },
+//             GetDatasetsByIDsFunc: func(ctx context.Context, ids []string) ([]*models.DatasetUpdate, error) {
+// 	               panic("mock out the GetDatasetsByIDs method")
+//             },
+//             GetDatasetsDeletedBeforeFunc: func(ctx context.Context, before time.Time) ([]string, error) {
+// 	               panic("mock out the GetDatasetsDeletedBefore method")
+//             },
+//             GetDatasetsRelatedToFunc: func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+// 	               panic("mock out the GetDatasetsRelatedTo method")
+//             },
+//             GetDeleteJobFunc: func(ctx context.Context, jobID string) (*models.DeleteJob, error) {
+// 	               panic("mock out the GetDeleteJob method")
+//             },
+//             GetDeletedDatasetFunc: func(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+// 	               panic("mock out the GetDeletedDataset method")
+//             },
 //             GetDimensionOptionsFunc: func(ctx context.Context, version *models.Version, dimension string, offset int, limit int) ([]*models.PublicDimensionOption, int, error) {
 // 	               panic("mock out the GetDimensionOptions method")
 //             },
-//             GetDimensionOptionsFromIDsFunc: func(version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
+//             GetDimensionOptionsFromIDsFunc: func(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
 // 	               panic("mock out the GetDimensionOptionsFromIDs method")
 //             },
-//             GetDimensionsFunc: func(datasetID string, versionID string) ([]bson.M, error) {
+//             GetDimensionSummariesFunc: func(ctx context.Context, instanceID string) ([]*models.DimensionSummary, error) {
+// 	               panic("mock out the GetDimensionSummaries method")
+//             },
+//             GetDimensionsFunc: func(ctx context.Context, datasetID string, versionID string) ([]bson.M, error) {
 // 	               panic("mock out the GetDimensions method")
 //             },
 //             GetDimensionsFromInstanceFunc: func(ctx context.Context, ID string, offset int, limit int) ([]*models.DimensionOption, int, error) {
 // 	               panic("mock out the GetDimensionsFromInstance method")
 //             },
-//             GetEditionFunc: func(ID string, editionID string, state string) (*models.EditionUpdate, error) {
+//             GetEditionFunc: func(ctx context.Context, ID string, editionID string, state string) (*models.EditionUpdate, error) {
 // 	               panic("mock out the GetEdition method")
 //             },
 //             GetEditionsFunc: func(ctx context.Context, ID string, state string, offset int, limit int, authorised bool) ([]*models.EditionUpdate, int, error) {
 // 	               panic("mock out the GetEditions method")
 //             },
-//             GetInstanceFunc: func(ID string, eTagSelector string) (*models.Instance, error) {
+//             GetEditionsDeletedBeforeFunc: func(ctx context.Context, before time.Time) ([]*models.EditionUpdate, error) {
+// 	               panic("mock out the GetEditionsDeletedBefore method")
+//             },
+//             GetInstanceFunc: func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 // 	               panic("mock out the GetInstance method")
 //             },
 //             GetInstancesFunc: func(ctx context.Context, states []string, datasets []string, offset int, limit int) ([]*models.Instance, int, error) {
 // 	               panic("mock out the GetInstances method")
 //             },
-//             GetNextVersionFunc: func(datasetID string, editionID string) (int, error) {
+//             GetLinkReportFunc: func(ctx context.Context, datasetID string) (*models.LinkReport, error) {
+// 	               panic("mock out the GetLinkReport method")
+//             },
+//             GetLinkReportsFunc: func(ctx context.Context, offset int, limit int) ([]*models.LinkReport, int, error) {
+// 	               panic("mock out the GetLinkReports method")
+//             },
+//             GetNextVersionFunc: func(ctx context.Context, datasetID string, editionID string) (int, error) {
 // 	               panic("mock out the GetNextVersion method")
 //             },
+//             GetPreviousPublishedVersionFunc: func(ctx context.Context, datasetID string, editionID string, version int) (*models.Version, error) {
+// 	               panic("mock out the GetPreviousPublishedVersion method")
+//             },
+//             GetTopicFunc: func(ctx context.Context, id string) (*models.Topic, error) {
+// 	               panic("mock out the GetTopic method")
+//             },
+//             GetTopicDatasetsFunc: func(ctx context.Context, topicIDs []string, offset int, limit int) ([]*models.DatasetUpdate, int, error) {
+// 	               panic("mock out the GetTopicDatasets method")
+//             },
+//             GetTopicsFunc: func(ctx context.Context) ([]*models.Topic, error) {
+// 	               panic("mock out the GetTopics method")
+//             },
+//             GetTopicsPageFunc: func(ctx context.Context, offset int, limit int) ([]*models.Topic, int, error) {
+// 	               panic("mock out the GetTopicsPage method")
+//             },
 //             GetUniqueDimensionAndOptionsFunc: func(ctx context.Context, ID string, dimension string, offset int, limit int) ([]*string, int, error) {
 // 	               panic("mock out the GetUniqueDimensionAndOptions method")
 //             },
-//             GetVersionFunc: func(datasetID string, editionID string, version int, state string) (*models.Version, error) {
+//             GetVersionFunc: func(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error) {
 // 	               panic("mock out the GetVersion method")
 //             },
 //             GetVersionsFunc: func(ctx context.Context, datasetID string, editionID string, state string, offset int, limit int) ([]models.Version, int, error) {
 // 	               panic("mock out the GetVersions method")
 //             },
-//             UnlockInstanceFunc: func(lockID string) error {
+//             GetVersionsInCollectionsFunc: func(ctx context.Context) ([]models.Version, error) {
+// 	               panic("mock out the GetVersionsInCollections method")
+//             },
+//             LockLinkCheckFunc: func(ctx context.Context, ttl time.Duration) (string, error) {
+// 	               panic("mock out the LockLinkCheck method")
+//             },
+//             LockPurgeFunc: func(ctx context.Context, ttl time.Duration) (string, error) {
+// 	               panic("mock out the LockPurge method")
+//             },
+//             MigrateLinksFunc: func(ctx context.Context, apiURL string) (int, error) {
+// 	               panic("mock out the MigrateLinks method")
+//             },
+//             RenewLinkCheckLockFunc: func(ctx context.Context, lockID string, ttl time.Duration) error {
+// 	               panic("mock out the RenewLinkCheckLock method")
+//             },
+//             RenewPurgeLockFunc: func(ctx context.Context, lockID string, ttl time.Duration) error {
+// 	               panic("mock out the RenewPurgeLock method")
+//             },
+//             RestoreDatasetFunc: func(ctx context.Context, datasetID string) error {
+// 	               panic("mock out the RestoreDataset method")
+//             },
+//             RetryImportTasksFunc: func(ctx context.Context, currentInstance *models.Instance, state string, tasks *models.InstanceImportTasks, event *models.Event, eTagSelector string) (string, error) {
+// 	               panic("mock out the RetryImportTasks method")
+//             },
+//             SoftDeleteDatasetFunc: func(ctx context.Context, datasetID string, eTagSelector string, deletedBy string, deletedAt time.Time) error {
+// 	               panic("mock out the SoftDeleteDataset method")
+//             },
+//             SoftDeleteEditionFunc: func(ctx context.Context, datasetID string, edition string, eTagSelector string, deletedBy string, deletedAt time.Time) error {
+// 	               panic("mock out the SoftDeleteEdition method")
+//             },
+//             UnlockInstanceFunc: func(ctx context.Context, lockID string) error {
 // 	               panic("mock out the UnlockInstance method")
 //             },
-//             UpdateBuildHierarchyTaskStateFunc: func(currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
+//             UnlockLinkCheckFunc: func(ctx context.Context, lockID string) error {
+// 	               panic("mock out the UnlockLinkCheck method")
+//             },
+//             UnlockPurgeFunc: func(ctx context.Context, lockID string) error {
+// 	               panic("mock out the UnlockPurge method")
+//             },
+//             UpdateBuildHierarchyTaskStateFunc: func(ctx context.Context, currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateBuildHierarchyTaskState method")
 //             },
-//             UpdateBuildSearchTaskStateFunc: func(currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
+//             UpdateBuildSearchTaskStateFunc: func(ctx context.Context, currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateBuildSearchTaskState method")
 //             },
-//             UpdateDatasetFunc: func(ctx context.Context, ID string, dataset *models.Dataset, currentState string) error {
+//             UpdateDatasetFunc: func(ctx context.Context, currentDataset *models.DatasetUpdate, dataset *models.Dataset, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateDataset method")
 //             },
-//             UpdateDatasetWithAssociationFunc: func(ID string, state string, version *models.Version) error {
+//             UpdateDatasetWithAssociationFunc: func(ctx context.Context, currentDataset *models.DatasetUpdate, state string, version *models.Version) error {
 // 	               panic("mock out the UpdateDatasetWithAssociation method")
 //             },
-//             UpdateDimensionNodeIDAndOrderFunc: func(dimension *models.DimensionOption) error {
+//             UpdateDeleteJobFunc: func(ctx context.Context, job *models.DeleteJob) error {
+// 	               panic("mock out the UpdateDeleteJob method")
+//             },
+//             UpdateDimensionNodeIDAndOrderFunc: func(ctx context.Context, dimension *models.DimensionOption) error {
 // 	               panic("mock out the UpdateDimensionNodeIDAndOrder method")
 //             },
-//             UpdateETagForNodeIDAndOrderFunc: func(currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
+//             UpdateETagForNodeIDAndOrderFunc: func(ctx context.Context, currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateETagForNodeIDAndOrder method")
 //             },
-//             UpdateETagForOptionsFunc: func(currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error) {
+//             UpdateETagForOptionsFunc: func(ctx context.Context, currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateETagForOptions method")
 //             },
-//             UpdateImportObservationsTaskStateFunc: func(currentInstance *models.Instance, state string, eTagSelector string) (string, error) {
+//             UpdateImportObservationsTaskStateFunc: func(ctx context.Context, currentInstance *models.Instance, state string, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateImportObservationsTaskState method")
 //             },
 //             UpdateInstanceFunc: func(ctx context.Context, currentInstance *models.Instance, updatedInstance *models.Instance, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateInstance method")
 //             },
-//             UpdateObservationInsertedFunc: func(currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error) {
+//             UpdateObservationInsertedFunc: func(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateObservationInserted method")
 //             },
-//             UpdateVersionFunc: func(ID string, version *models.Version) error {
+//             UpdateVersionFunc: func(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (string, error) {
 // 	               panic("mock out the UpdateVersion method")
 //             },
-//             UpsertContactFunc: func(ID string, update interface{}) error {
+//             UpsertContactFunc: func(ctx context.Context, ID string, update interface{}) error {
 // 	               panic("mock out the UpsertContact method")
 //             },
-//             UpsertDatasetFunc: func(ID string, datasetDoc *models.DatasetUpdate) error {
+//             UpsertDatasetFunc: func(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
 // 	               panic("mock out the UpsertDataset method")
 //             },
-//             UpsertEditionFunc: func(datasetID string, edition string, editionDoc *models.EditionUpdate) error {
+//             UpsertEditionFunc: func(ctx context.Context, datasetID string, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error {
 // 	               panic("mock out the UpsertEdition method")
 //             },
-//             UpsertVersionFunc: func(ID string, versionDoc *models.Version) error {
+//             UpsertLinkReportFunc: func(ctx context.Context, report *models.LinkReport) error {
+// 	               panic("mock out the UpsertLinkReport method")
+//             },
+//             UpsertTopicFunc: func(ctx context.Context, topic *models.Topic) error {
+// 	               panic("mock out the UpsertTopic method")
+//             },
+//             UpsertVersionFunc: func(ctx context.Context, ID string, versionDoc *models.Version) error {
 // 	               panic("mock out the UpsertVersion method")
 //             },
 //         }
@@ -195,122 +356,242 @@ type MongoDBMock struct {
 	// AcquireInstanceLockFunc mocks the AcquireInstanceLock method.
 	AcquireInstanceLockFunc func(ctx context.Context, instanceID string) (string, error)
 
+	// AddDeleteJobFunc mocks the AddDeleteJob method.
+	AddDeleteJobFunc func(ctx context.Context, job *models.DeleteJob) error
+
 	// AddDimensionToInstanceFunc mocks the AddDimensionToInstance method.
-	AddDimensionToInstanceFunc func(dimension *models.CachedDimensionOption) error
+	AddDimensionToInstanceFunc func(ctx context.Context, dimension *models.CachedDimensionOption) error
 
 	// AddEventToInstanceFunc mocks the AddEventToInstance method.
-	AddEventToInstanceFunc func(currentInstance *models.Instance, event *models.Event, eTagSelector string) (string, error)
+	AddEventToInstanceFunc func(ctx context.Context, currentInstance *models.Instance, event *models.Event, eTagSelector string) (string, error)
 
 	// AddInstanceFunc mocks the AddInstance method.
-	AddInstanceFunc func(instance *models.Instance) (*models.Instance, error)
+	AddInstanceFunc func(ctx context.Context, instance *models.Instance) (*models.Instance, error)
+
+	// BackfillETagsFunc mocks the BackfillETags method.
+	BackfillETagsFunc func(ctx context.Context) (int, error)
 
 	// CheckDatasetExistsFunc mocks the CheckDatasetExists method.
-	CheckDatasetExistsFunc func(ID string, state string) error
+	CheckDatasetExistsFunc func(ctx context.Context, ID string, state string) error
 
 	// CheckEditionExistsFunc mocks the CheckEditionExists method.
-	CheckEditionExistsFunc func(ID string, editionID string, state string) error
+	CheckEditionExistsFunc func(ctx context.Context, ID string, editionID string, state string) error
 
 	// CheckerFunc mocks the Checker method.
 	CheckerFunc func(in1 context.Context, in2 *healthcheck.CheckState) error
 
+	// ClearLatestEditionsFunc mocks the ClearLatestEditions method.
+	ClearLatestEditionsFunc func(ctx context.Context, datasetID string, edition string) error
+
 	// CloseFunc mocks the Close method.
 	CloseFunc func(in1 context.Context) error
 
+	// CountDimensionOptionsFunc mocks the CountDimensionOptions method.
+	CountDimensionOptionsFunc func(ctx context.Context, instanceID string) (int, error)
+
+	// CountTopicDatasetsFunc mocks the CountTopicDatasets method.
+	CountTopicDatasetsFunc func(ctx context.Context, id string) (int, error)
+
 	// DeleteDatasetFunc mocks the DeleteDataset method.
-	DeleteDatasetFunc func(ID string) error
+	DeleteDatasetFunc func(ctx context.Context, ID string) error
+
+	// DeleteDimensionOptionsFunc mocks the DeleteDimensionOptions method.
+	DeleteDimensionOptionsFunc func(ctx context.Context, instanceID string) (int, error)
 
 	// DeleteEditionFunc mocks the DeleteEdition method.
-	DeleteEditionFunc func(ID string) error
+	DeleteEditionFunc func(ctx context.Context, ID string) error
+
+	// DeleteInstanceFunc mocks the DeleteInstance method.
+	DeleteInstanceFunc func(ctx context.Context, instanceID string) error
+
+	// DeleteLinkReportsExceptFunc mocks the DeleteLinkReportsExcept method.
+	DeleteLinkReportsExceptFunc func(ctx context.Context, datasetIDs []string) error
+
+	// DeleteTopicFunc mocks the DeleteTopic method.
+	DeleteTopicFunc func(ctx context.Context, id string) error
 
 	// GetDatasetFunc mocks the GetDataset method.
-	GetDatasetFunc func(ID string) (*models.DatasetUpdate, error)
+	GetDatasetFunc func(ctx context.Context, ID string) (*models.DatasetUpdate, error)
+
+	// GetDatasetEditionsFunc mocks the GetDatasetEditions method.
+	GetDatasetEditionsFunc func(ctx context.Context, datasetID string) ([]*models.EditionUpdate, error)
+
+	// GetDatasetInstancesFunc mocks the GetDatasetInstances method.
+	GetDatasetInstancesFunc func(ctx context.Context, datasetID string) ([]*models.Instance, error)
 
 	// GetDatasetsFunc mocks the GetDatasets method.
 	GetDatasetsFunc func(ctx context.Context, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error)
 
+	// GetDatasetsByIDsFunc mocks the GetDatasetsByIDs method.
+	GetDatasetsByIDsFunc func(ctx context.Context, ids []string) ([]*models.DatasetUpdate, error)
+
+	// GetDatasetsDeletedBeforeFunc mocks the GetDatasetsDeletedBefore method.
+	GetDatasetsDeletedBeforeFunc func(ctx context.Context, before time.Time) ([]string, error)
+
+	// GetDatasetsRelatedToFunc mocks the GetDatasetsRelatedTo method.
+	GetDatasetsRelatedToFunc func(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error)
+
+	// GetDeleteJobFunc mocks the GetDeleteJob method.
+	GetDeleteJobFunc func(ctx context.Context, jobID string) (*models.DeleteJob, error)
+
+	// GetDeletedDatasetFunc mocks the GetDeletedDataset method.
+	GetDeletedDatasetFunc func(ctx context.Context, id string) (*models.DatasetUpdate, error)
+
 	// GetDimensionOptionsFunc mocks the GetDimensionOptions method.
 	GetDimensionOptionsFunc func(ctx context.Context, version *models.Version, dimension string, offset int, limit int) ([]*models.PublicDimensionOption, int, error)
 
 	// GetDimensionOptionsFromIDsFunc mocks the GetDimensionOptionsFromIDs method.
-	GetDimensionOptionsFromIDsFunc func(version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error)
+	GetDimensionOptionsFromIDsFunc func(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error)
+
+	// GetDimensionSummariesFunc mocks the GetDimensionSummaries method.
+	GetDimensionSummariesFunc func(ctx context.Context, instanceID string) ([]*models.DimensionSummary, error)
 
 	// GetDimensionsFunc mocks the GetDimensions method.
-	GetDimensionsFunc func(datasetID string, versionID string) ([]bson.M, error)
+	GetDimensionsFunc func(ctx context.Context, datasetID string, versionID string) ([]bson.M, error)
 
 	// GetDimensionsFromInstanceFunc mocks the GetDimensionsFromInstance method.
 	GetDimensionsFromInstanceFunc func(ctx context.Context, ID string, offset int, limit int) ([]*models.DimensionOption, int, error)
 
 	// GetEditionFunc mocks the GetEdition method.
-	GetEditionFunc func(ID string, editionID string, state string) (*models.EditionUpdate, error)
+	GetEditionFunc func(ctx context.Context, ID string, editionID string, state string) (*models.EditionUpdate, error)
 
 	// GetEditionsFunc mocks the GetEditions method.
 	GetEditionsFunc func(ctx context.Context, ID string, state string, offset int, limit int, authorised bool) ([]*models.EditionUpdate, int, error)
 
+	// GetEditionsDeletedBeforeFunc mocks the GetEditionsDeletedBefore method.
+	GetEditionsDeletedBeforeFunc func(ctx context.Context, before time.Time) ([]*models.EditionUpdate, error)
+
 	// GetInstanceFunc mocks the GetInstance method.
-	GetInstanceFunc func(ID string, eTagSelector string) (*models.Instance, error)
+	GetInstanceFunc func(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error)
 
 	// GetInstancesFunc mocks the GetInstances method.
 	GetInstancesFunc func(ctx context.Context, states []string, datasets []string, offset int, limit int) ([]*models.Instance, int, error)
 
+	// GetLinkReportFunc mocks the GetLinkReport method.
+	GetLinkReportFunc func(ctx context.Context, datasetID string) (*models.LinkReport, error)
+
+	// GetLinkReportsFunc mocks the GetLinkReports method.
+	GetLinkReportsFunc func(ctx context.Context, offset int, limit int) ([]*models.LinkReport, int, error)
+
 	// GetNextVersionFunc mocks the GetNextVersion method.
-	GetNextVersionFunc func(datasetID string, editionID string) (int, error)
+	GetNextVersionFunc func(ctx context.Context, datasetID string, editionID string) (int, error)
+
+	// GetPreviousPublishedVersionFunc mocks the GetPreviousPublishedVersion method.
+	GetPreviousPublishedVersionFunc func(ctx context.Context, datasetID string, editionID string, version int) (*models.Version, error)
+
+	// GetTopicFunc mocks the GetTopic method.
+	GetTopicFunc func(ctx context.Context, id string) (*models.Topic, error)
+
+	// GetTopicDatasetsFunc mocks the GetTopicDatasets method.
+	GetTopicDatasetsFunc func(ctx context.Context, topicIDs []string, offset int, limit int) ([]*models.DatasetUpdate, int, error)
+
+	// GetTopicsFunc mocks the GetTopics method.
+	GetTopicsFunc func(ctx context.Context) ([]*models.Topic, error)
+
+	// GetTopicsPageFunc mocks the GetTopicsPage method.
+	GetTopicsPageFunc func(ctx context.Context, offset int, limit int) ([]*models.Topic, int, error)
 
 	// GetUniqueDimensionAndOptionsFunc mocks the GetUniqueDimensionAndOptions method.
 	GetUniqueDimensionAndOptionsFunc func(ctx context.Context, ID string, dimension string, offset int, limit int) ([]*string, int, error)
 
 	// GetVersionFunc mocks the GetVersion method.
-	GetVersionFunc func(datasetID string, editionID string, version int, state string) (*models.Version, error)
+	GetVersionFunc func(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error)
 
 	// GetVersionsFunc mocks the GetVersions method.
 	GetVersionsFunc func(ctx context.Context, datasetID string, editionID string, state string, offset int, limit int) ([]models.Version, int, error)
 
+	// GetVersionsInCollectionsFunc mocks the GetVersionsInCollections method.
+	GetVersionsInCollectionsFunc func(ctx context.Context) ([]models.Version, error)
+
+	// LockLinkCheckFunc mocks the LockLinkCheck method.
+	LockLinkCheckFunc func(ctx context.Context, ttl time.Duration) (string, error)
+
+	// LockPurgeFunc mocks the LockPurge method.
+	LockPurgeFunc func(ctx context.Context, ttl time.Duration) (string, error)
+
+	// MigrateLinksFunc mocks the MigrateLinks method.
+	MigrateLinksFunc func(ctx context.Context, apiURL string) (int, error)
+
+	// RenewLinkCheckLockFunc mocks the RenewLinkCheckLock method.
+	RenewLinkCheckLockFunc func(ctx context.Context, lockID string, ttl time.Duration) error
+
+	// RenewPurgeLockFunc mocks the RenewPurgeLock method.
+	RenewPurgeLockFunc func(ctx context.Context, lockID string, ttl time.Duration) error
+
+	// RestoreDatasetFunc mocks the RestoreDataset method.
+	RestoreDatasetFunc func(ctx context.Context, datasetID string) error
+
+	// RetryImportTasksFunc mocks the RetryImportTasks method.
+	RetryImportTasksFunc func(ctx context.Context, currentInstance *models.Instance, state string, tasks *models.InstanceImportTasks, event *models.Event, eTagSelector string) (string, error)
+
+	// SoftDeleteDatasetFunc mocks the SoftDeleteDataset method.
+	SoftDeleteDatasetFunc func(ctx context.Context, datasetID string, eTagSelector string, deletedBy string, deletedAt time.Time) error
+
+	// SoftDeleteEditionFunc mocks the SoftDeleteEdition method.
+	SoftDeleteEditionFunc func(ctx context.Context, datasetID string, edition string, eTagSelector string, deletedBy string, deletedAt time.Time) error
+
 	// UnlockInstanceFunc mocks the UnlockInstance method.
-	UnlockInstanceFunc func(lockID string) error
+	UnlockInstanceFunc func(ctx context.Context, lockID string) error
+
+	// UnlockLinkCheckFunc mocks the UnlockLinkCheck method.
+	UnlockLinkCheckFunc func(ctx context.Context, lockID string) error
+
+	// UnlockPurgeFunc mocks the UnlockPurge method.
+	UnlockPurgeFunc func(ctx context.Context, lockID string) error
 
 	// UpdateBuildHierarchyTaskStateFunc mocks the UpdateBuildHierarchyTaskState method.
-	UpdateBuildHierarchyTaskStateFunc func(currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error)
+	UpdateBuildHierarchyTaskStateFunc func(ctx context.Context, currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error)
 
 	// UpdateBuildSearchTaskStateFunc mocks the UpdateBuildSearchTaskState method.
-	UpdateBuildSearchTaskStateFunc func(currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error)
+	UpdateBuildSearchTaskStateFunc func(ctx context.Context, currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error)
 
 	// UpdateDatasetFunc mocks the UpdateDataset method.
-	UpdateDatasetFunc func(ctx context.Context, ID string, dataset *models.Dataset, currentState string) error
+	UpdateDatasetFunc func(ctx context.Context, currentDataset *models.DatasetUpdate, dataset *models.Dataset, eTagSelector string) (string, error)
 
 	// UpdateDatasetWithAssociationFunc mocks the UpdateDatasetWithAssociation method.
-	UpdateDatasetWithAssociationFunc func(ID string, state string, version *models.Version) error
+	UpdateDatasetWithAssociationFunc func(ctx context.Context, currentDataset *models.DatasetUpdate, state string, version *models.Version) error
+
+	// UpdateDeleteJobFunc mocks the UpdateDeleteJob method.
+	UpdateDeleteJobFunc func(ctx context.Context, job *models.DeleteJob) error
 
 	// UpdateDimensionNodeIDAndOrderFunc mocks the UpdateDimensionNodeIDAndOrder method.
-	UpdateDimensionNodeIDAndOrderFunc func(dimension *models.DimensionOption) error
+	UpdateDimensionNodeIDAndOrderFunc func(ctx context.Context, dimension *models.DimensionOption) error
 
 	// UpdateETagForNodeIDAndOrderFunc mocks the UpdateETagForNodeIDAndOrder method.
-	UpdateETagForNodeIDAndOrderFunc func(currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error)
+	UpdateETagForNodeIDAndOrderFunc func(ctx context.Context, currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error)
 
 	// UpdateETagForOptionsFunc mocks the UpdateETagForOptions method.
-	UpdateETagForOptionsFunc func(currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error)
+	UpdateETagForOptionsFunc func(ctx context.Context, currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error)
 
 	// UpdateImportObservationsTaskStateFunc mocks the UpdateImportObservationsTaskState method.
-	UpdateImportObservationsTaskStateFunc func(currentInstance *models.Instance, state string, eTagSelector string) (string, error)
+	UpdateImportObservationsTaskStateFunc func(ctx context.Context, currentInstance *models.Instance, state string, eTagSelector string) (string, error)
 
 	// UpdateInstanceFunc mocks the UpdateInstance method.
 	UpdateInstanceFunc func(ctx context.Context, currentInstance *models.Instance, updatedInstance *models.Instance, eTagSelector string) (string, error)
 
 	// UpdateObservationInsertedFunc mocks the UpdateObservationInserted method.
-	UpdateObservationInsertedFunc func(currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error)
+	UpdateObservationInsertedFunc func(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error)
 
 	// UpdateVersionFunc mocks the UpdateVersion method.
-	UpdateVersionFunc func(ID string, version *models.Version) error
+	UpdateVersionFunc func(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (string, error)
 
 	// UpsertContactFunc mocks the UpsertContact method.
-	UpsertContactFunc func(ID string, update interface{}) error
+	UpsertContactFunc func(ctx context.Context, ID string, update interface{}) error
 
 	// UpsertDatasetFunc mocks the UpsertDataset method.
-	UpsertDatasetFunc func(ID string, datasetDoc *models.DatasetUpdate) error
+	UpsertDatasetFunc func(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate, eTagSelector string) error
 
 	// UpsertEditionFunc mocks the UpsertEdition method.
-	UpsertEditionFunc func(datasetID string, edition string, editionDoc *models.EditionUpdate) error
+	UpsertEditionFunc func(ctx context.Context, datasetID string, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error
+
+	// UpsertLinkReportFunc mocks the UpsertLinkReport method.
+	UpsertLinkReportFunc func(ctx context.Context, report *models.LinkReport) error
+
+	// UpsertTopicFunc mocks the UpsertTopic method.
+	UpsertTopicFunc func(ctx context.Context, topic *models.Topic) error
 
 	// UpsertVersionFunc mocks the UpsertVersion method.
-	UpsertVersionFunc func(ID string, versionDoc *models.Version) error
+	UpsertVersionFunc func(ctx context.Context, ID string, versionDoc *models.Version) error
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -321,13 +602,24 @@ type MongoDBMock struct {
 			// InstanceID is the instanceID argument value.
 			InstanceID string
 		}
+		// AddDeleteJob holds details about calls to the AddDeleteJob method.
+		AddDeleteJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Job is the job argument value.
+			Job *models.DeleteJob
+		}
 		// AddDimensionToInstance holds details about calls to the AddDimensionToInstance method.
 		AddDimensionToInstance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// Dimension is the dimension argument value.
 			Dimension *models.CachedDimensionOption
 		}
 		// AddEventToInstance holds details about calls to the AddEventToInstance method.
 		AddEventToInstance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// CurrentInstance is the currentInstance argument value.
 			CurrentInstance *models.Instance
 			// Event is the event argument value.
@@ -337,11 +629,20 @@ type MongoDBMock struct {
 		}
 		// AddInstance holds details about calls to the AddInstance method.
 		AddInstance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// Instance is the instance argument value.
 			Instance *models.Instance
 		}
+		// BackfillETags holds details about calls to the BackfillETags method.
+		BackfillETags []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// CheckDatasetExists holds details about calls to the CheckDatasetExists method.
 		CheckDatasetExists []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 			// State is the state argument value.
@@ -349,6 +650,8 @@ type MongoDBMock struct {
 		}
 		// CheckEditionExists holds details about calls to the CheckEditionExists method.
 		CheckEditionExists []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 			// EditionID is the editionID argument value.
@@ -363,26 +666,97 @@ type MongoDBMock struct {
 			// In2 is the in2 argument value.
 			In2 *healthcheck.CheckState
 		}
+		// ClearLatestEditions holds details about calls to the ClearLatestEditions method.
+		ClearLatestEditions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// Edition is the edition argument value.
+			Edition string
+		}
 		// Close holds details about calls to the Close method.
 		Close []struct {
 			// In1 is the in1 argument value.
 			In1 context.Context
 		}
+		// CountDimensionOptions holds details about calls to the CountDimensionOptions method.
+		CountDimensionOptions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InstanceID is the instanceID argument value.
+			InstanceID string
+		}
+		// CountTopicDatasets holds details about calls to the CountTopicDatasets method.
+		CountTopicDatasets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
 		// DeleteDataset holds details about calls to the DeleteDataset method.
 		DeleteDataset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 		}
+		// DeleteDimensionOptions holds details about calls to the DeleteDimensionOptions method.
+		DeleteDimensionOptions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InstanceID is the instanceID argument value.
+			InstanceID string
+		}
 		// DeleteEdition holds details about calls to the DeleteEdition method.
 		DeleteEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 		}
+		// DeleteInstance holds details about calls to the DeleteInstance method.
+		DeleteInstance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InstanceID is the instanceID argument value.
+			InstanceID string
+		}
+		// DeleteLinkReportsExcept holds details about calls to the DeleteLinkReportsExcept method.
+		DeleteLinkReportsExcept []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetIDs is the datasetIDs argument value.
+			DatasetIDs []string
+		}
+		// DeleteTopic holds details about calls to the DeleteTopic method.
+		DeleteTopic []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
 		// GetDataset holds details about calls to the GetDataset method.
 		GetDataset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 		}
+		// GetDatasetEditions holds details about calls to the GetDatasetEditions method.
+		GetDatasetEditions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+		}
+		// GetDatasetInstances holds details about calls to the GetDatasetInstances method.
+		GetDatasetInstances []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+		}
 		// GetDatasets holds details about calls to the GetDatasets method.
 		GetDatasets []struct {
 			// Ctx is the ctx argument value.
@@ -394,6 +768,41 @@ type MongoDBMock struct {
 			// Authorised is the authorised argument value.
 			Authorised bool
 		}
+		// GetDatasetsByIDs holds details about calls to the GetDatasetsByIDs method.
+		GetDatasetsByIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Ids is the ids argument value.
+			Ids []string
+		}
+		// GetDatasetsDeletedBefore holds details about calls to the GetDatasetsDeletedBefore method.
+		GetDatasetsDeletedBefore []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Before is the before argument value.
+			Before time.Time
+		}
+		// GetDatasetsRelatedTo holds details about calls to the GetDatasetsRelatedTo method.
+		GetDatasetsRelatedTo []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+		}
+		// GetDeleteJob holds details about calls to the GetDeleteJob method.
+		GetDeleteJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// JobID is the jobID argument value.
+			JobID string
+		}
+		// GetDeletedDataset holds details about calls to the GetDeletedDataset method.
+		GetDeletedDataset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
 		// GetDimensionOptions holds details about calls to the GetDimensionOptions method.
 		GetDimensionOptions []struct {
 			// Ctx is the ctx argument value.
@@ -409,6 +818,8 @@ type MongoDBMock struct {
 		}
 		// GetDimensionOptionsFromIDs holds details about calls to the GetDimensionOptionsFromIDs method.
 		GetDimensionOptionsFromIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// Version is the version argument value.
 			Version *models.Version
 			// Dimension is the dimension argument value.
@@ -416,8 +827,17 @@ type MongoDBMock struct {
 			// Ids is the ids argument value.
 			Ids []string
 		}
+		// GetDimensionSummaries holds details about calls to the GetDimensionSummaries method.
+		GetDimensionSummaries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InstanceID is the instanceID argument value.
+			InstanceID string
+		}
 		// GetDimensions holds details about calls to the GetDimensions method.
 		GetDimensions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// DatasetID is the datasetID argument value.
 			DatasetID string
 			// VersionID is the versionID argument value.
@@ -436,6 +856,8 @@ type MongoDBMock struct {
 		}
 		// GetEdition holds details about calls to the GetEdition method.
 		GetEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 			// EditionID is the editionID argument value.
@@ -458,8 +880,17 @@ type MongoDBMock struct {
 			// Authorised is the authorised argument value.
 			Authorised bool
 		}
+		// GetEditionsDeletedBefore holds details about calls to the GetEditionsDeletedBefore method.
+		GetEditionsDeletedBefore []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Before is the before argument value.
+			Before time.Time
+		}
 		// GetInstance holds details about calls to the GetInstance method.
 		GetInstance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 			// ETagSelector is the eTagSelector argument value.
@@ -478,12 +909,73 @@ type MongoDBMock struct {
 			// Limit is the limit argument value.
 			Limit int
 		}
+		// GetLinkReport holds details about calls to the GetLinkReport method.
+		GetLinkReport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+		}
+		// GetLinkReports holds details about calls to the GetLinkReports method.
+		GetLinkReports []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetNextVersion holds details about calls to the GetNextVersion method.
 		GetNextVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// EditionID is the editionID argument value.
+			EditionID string
+		}
+		// GetPreviousPublishedVersion holds details about calls to the GetPreviousPublishedVersion method.
+		GetPreviousPublishedVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// DatasetID is the datasetID argument value.
 			DatasetID string
 			// EditionID is the editionID argument value.
 			EditionID string
+			// Version is the version argument value.
+			Version int
+		}
+		// GetTopic holds details about calls to the GetTopic method.
+		GetTopic []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// GetTopicDatasets holds details about calls to the GetTopicDatasets method.
+		GetTopicDatasets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TopicIDs is the topicIDs argument value.
+			TopicIDs []string
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetTopics holds details about calls to the GetTopics method.
+		GetTopics []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetTopicsPage holds details about calls to the GetTopicsPage method.
+		GetTopicsPage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
 		}
 		// GetUniqueDimensionAndOptions holds details about calls to the GetUniqueDimensionAndOptions method.
 		GetUniqueDimensionAndOptions []struct {
@@ -500,6 +992,8 @@ type MongoDBMock struct {
 		}
 		// GetVersion holds details about calls to the GetVersion method.
 		GetVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// DatasetID is the datasetID argument value.
 			DatasetID string
 			// EditionID is the editionID argument value.
@@ -524,24 +1018,138 @@ type MongoDBMock struct {
 			// Limit is the limit argument value.
 			Limit int
 		}
-		// UnlockInstance holds details about calls to the UnlockInstance method.
-		UnlockInstance []struct {
+		// GetVersionsInCollections holds details about calls to the GetVersionsInCollections method.
+		GetVersionsInCollections []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// LockLinkCheck holds details about calls to the LockLinkCheck method.
+		LockLinkCheck []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// LockPurge holds details about calls to the LockPurge method.
+		LockPurge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// MigrateLinks holds details about calls to the MigrateLinks method.
+		MigrateLinks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ApiURL is the apiURL argument value.
+			ApiURL string
+		}
+		// RenewLinkCheckLock holds details about calls to the RenewLinkCheckLock method.
+		RenewLinkCheckLock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// LockID is the lockID argument value.
 			LockID string
+			// TTL is the ttl argument value.
+			TTL time.Duration
 		}
-		// UpdateBuildHierarchyTaskState holds details about calls to the UpdateBuildHierarchyTaskState method.
-		UpdateBuildHierarchyTaskState []struct {
-			// CurrentInstance is the currentInstance argument value.
-			CurrentInstance *models.Instance
-			// Dimension is the dimension argument value.
-			Dimension string
-			// State is the state argument value.
-			State string
-			// ETagSelector is the eTagSelector argument value.
-			ETagSelector string
-		}
-		// UpdateBuildSearchTaskState holds details about calls to the UpdateBuildSearchTaskState method.
-		UpdateBuildSearchTaskState []struct {
+		// RenewPurgeLock holds details about calls to the RenewPurgeLock method.
+		RenewPurgeLock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// LockID is the lockID argument value.
+			LockID string
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// RestoreDataset holds details about calls to the RestoreDataset method.
+		RestoreDataset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+		}
+		// RetryImportTasks holds details about calls to the RetryImportTasks method.
+		RetryImportTasks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CurrentInstance is the currentInstance argument value.
+			CurrentInstance *models.Instance
+			// State is the state argument value.
+			State string
+			// Tasks is the tasks argument value.
+			Tasks *models.InstanceImportTasks
+			// Event is the event argument value.
+			Event *models.Event
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
+		}
+		// SoftDeleteDataset holds details about calls to the SoftDeleteDataset method.
+		SoftDeleteDataset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
+			// DeletedBy is the deletedBy argument value.
+			DeletedBy string
+			// DeletedAt is the deletedAt argument value.
+			DeletedAt time.Time
+		}
+		// SoftDeleteEdition holds details about calls to the SoftDeleteEdition method.
+		SoftDeleteEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetID is the datasetID argument value.
+			DatasetID string
+			// Edition is the edition argument value.
+			Edition string
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
+			// DeletedBy is the deletedBy argument value.
+			DeletedBy string
+			// DeletedAt is the deletedAt argument value.
+			DeletedAt time.Time
+		}
+		// UnlockInstance holds details about calls to the UnlockInstance method.
+		UnlockInstance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// LockID is the lockID argument value.
+			LockID string
+		}
+		// UnlockLinkCheck holds details about calls to the UnlockLinkCheck method.
+		UnlockLinkCheck []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// LockID is the lockID argument value.
+			LockID string
+		}
+		// UnlockPurge holds details about calls to the UnlockPurge method.
+		UnlockPurge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// LockID is the lockID argument value.
+			LockID string
+		}
+		// UpdateBuildHierarchyTaskState holds details about calls to the UpdateBuildHierarchyTaskState method.
+		UpdateBuildHierarchyTaskState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CurrentInstance is the currentInstance argument value.
+			CurrentInstance *models.Instance
+			// Dimension is the dimension argument value.
+			Dimension string
+			// State is the state argument value.
+			State string
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
+		}
+		// UpdateBuildSearchTaskState holds details about calls to the UpdateBuildSearchTaskState method.
+		UpdateBuildSearchTaskState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// CurrentInstance is the currentInstance argument value.
 			CurrentInstance *models.Instance
 			// Dimension is the dimension argument value.
@@ -555,29 +1163,42 @@ type MongoDBMock struct {
 		UpdateDataset []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ID is the ID argument value.
-			ID string
+			// CurrentDataset is the currentDataset argument value.
+			CurrentDataset *models.DatasetUpdate
 			// Dataset is the dataset argument value.
 			Dataset *models.Dataset
-			// CurrentState is the currentState argument value.
-			CurrentState string
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
 		}
 		// UpdateDatasetWithAssociation holds details about calls to the UpdateDatasetWithAssociation method.
 		UpdateDatasetWithAssociation []struct {
-			// ID is the ID argument value.
-			ID string
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CurrentDataset is the currentDataset argument value.
+			CurrentDataset *models.DatasetUpdate
 			// State is the state argument value.
 			State string
 			// Version is the version argument value.
 			Version *models.Version
 		}
+		// UpdateDeleteJob holds details about calls to the UpdateDeleteJob method.
+		UpdateDeleteJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Job is the job argument value.
+			Job *models.DeleteJob
+		}
 		// UpdateDimensionNodeIDAndOrder holds details about calls to the UpdateDimensionNodeIDAndOrder method.
 		UpdateDimensionNodeIDAndOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// Dimension is the dimension argument value.
 			Dimension *models.DimensionOption
 		}
 		// UpdateETagForNodeIDAndOrder holds details about calls to the UpdateETagForNodeIDAndOrder method.
 		UpdateETagForNodeIDAndOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// CurrentInstance is the currentInstance argument value.
 			CurrentInstance *models.Instance
 			// NodeID is the nodeID argument value.
@@ -589,6 +1210,8 @@ type MongoDBMock struct {
 		}
 		// UpdateETagForOptions holds details about calls to the UpdateETagForOptions method.
 		UpdateETagForOptions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// CurrentInstance is the currentInstance argument value.
 			CurrentInstance *models.Instance
 			// Option is the option argument value.
@@ -598,6 +1221,8 @@ type MongoDBMock struct {
 		}
 		// UpdateImportObservationsTaskState holds details about calls to the UpdateImportObservationsTaskState method.
 		UpdateImportObservationsTaskState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// CurrentInstance is the currentInstance argument value.
 			CurrentInstance *models.Instance
 			// State is the state argument value.
@@ -618,6 +1243,8 @@ type MongoDBMock struct {
 		}
 		// UpdateObservationInserted holds details about calls to the UpdateObservationInserted method.
 		UpdateObservationInserted []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// CurrentInstance is the currentInstance argument value.
 			CurrentInstance *models.Instance
 			// ObservationInserted is the observationInserted argument value.
@@ -627,13 +1254,19 @@ type MongoDBMock struct {
 		}
 		// UpdateVersion holds details about calls to the UpdateVersion method.
 		UpdateVersion []struct {
-			// ID is the ID argument value.
-			ID string
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CurrentVersion is the currentVersion argument value.
+			CurrentVersion *models.Version
 			// Version is the version argument value.
 			Version *models.Version
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
 		}
 		// UpsertContact holds details about calls to the UpsertContact method.
 		UpsertContact []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 			// Update is the update argument value.
@@ -641,22 +1274,46 @@ type MongoDBMock struct {
 		}
 		// UpsertDataset holds details about calls to the UpsertDataset method.
 		UpsertDataset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 			// DatasetDoc is the datasetDoc argument value.
 			DatasetDoc *models.DatasetUpdate
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
 		}
 		// UpsertEdition holds details about calls to the UpsertEdition method.
 		UpsertEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// DatasetID is the datasetID argument value.
 			DatasetID string
 			// Edition is the edition argument value.
 			Edition string
 			// EditionDoc is the editionDoc argument value.
 			EditionDoc *models.EditionUpdate
+			// ETagSelector is the eTagSelector argument value.
+			ETagSelector string
+		}
+		// UpsertLinkReport holds details about calls to the UpsertLinkReport method.
+		UpsertLinkReport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Report is the report argument value.
+			Report *models.LinkReport
+		}
+		// UpsertTopic holds details about calls to the UpsertTopic method.
+		UpsertTopic []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Topic is the topic argument value.
+			Topic *models.Topic
 		}
 		// UpsertVersion holds details about calls to the UpsertVersion method.
 		UpsertVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 			// ID is the ID argument value.
 			ID string
 			// VersionDoc is the versionDoc argument value.
@@ -700,29 +1357,68 @@ func (mock *MongoDBMock) AcquireInstanceLockCalls() []struct {
 	return calls
 }
 
+// AddDeleteJob calls AddDeleteJobFunc.
+func (mock *MongoDBMock) AddDeleteJob(ctx context.Context, job *models.DeleteJob) error {
+	if mock.AddDeleteJobFunc == nil {
+		panic("MongoDBMock.AddDeleteJobFunc: method is nil but MongoDB.AddDeleteJob was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Job *models.DeleteJob
+	}{
+		Ctx: ctx,
+		Job: job,
+	}
+	lockMongoDBMockAddDeleteJob.Lock()
+	mock.calls.AddDeleteJob = append(mock.calls.AddDeleteJob, callInfo)
+	lockMongoDBMockAddDeleteJob.Unlock()
+	return mock.AddDeleteJobFunc(ctx, job)
+}
+
+// AddDeleteJobCalls gets all the calls that were made to AddDeleteJob.
+// Check the length with:
+//     len(mockedMongoDB.AddDeleteJobCalls())
+func (mock *MongoDBMock) AddDeleteJobCalls() []struct {
+	Ctx context.Context
+	Job *models.DeleteJob
+} {
+	var calls []struct {
+		Ctx context.Context
+		Job *models.DeleteJob
+	}
+	lockMongoDBMockAddDeleteJob.RLock()
+	calls = mock.calls.AddDeleteJob
+	lockMongoDBMockAddDeleteJob.RUnlock()
+	return calls
+}
+
 // AddDimensionToInstance calls AddDimensionToInstanceFunc.
-func (mock *MongoDBMock) AddDimensionToInstance(dimension *models.CachedDimensionOption) error {
+func (mock *MongoDBMock) AddDimensionToInstance(ctx context.Context, dimension *models.CachedDimensionOption) error {
 	if mock.AddDimensionToInstanceFunc == nil {
 		panic("MongoDBMock.AddDimensionToInstanceFunc: method is nil but MongoDB.AddDimensionToInstance was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		Dimension *models.CachedDimensionOption
 	}{
+		Ctx:       ctx,
 		Dimension: dimension,
 	}
 	lockMongoDBMockAddDimensionToInstance.Lock()
 	mock.calls.AddDimensionToInstance = append(mock.calls.AddDimensionToInstance, callInfo)
 	lockMongoDBMockAddDimensionToInstance.Unlock()
-	return mock.AddDimensionToInstanceFunc(dimension)
+	return mock.AddDimensionToInstanceFunc(ctx, dimension)
 }
 
 // AddDimensionToInstanceCalls gets all the calls that were made to AddDimensionToInstance.
 // Check the length with:
 //     len(mockedMongoDB.AddDimensionToInstanceCalls())
 func (mock *MongoDBMock) AddDimensionToInstanceCalls() []struct {
+	Ctx       context.Context
 	Dimension *models.CachedDimensionOption
 } {
 	var calls []struct {
+		Ctx       context.Context
 		Dimension *models.CachedDimensionOption
 	}
 	lockMongoDBMockAddDimensionToInstance.RLock()
@@ -732,15 +1428,17 @@ func (mock *MongoDBMock) AddDimensionToInstanceCalls() []struct {
 }
 
 // AddEventToInstance calls AddEventToInstanceFunc.
-func (mock *MongoDBMock) AddEventToInstance(currentInstance *models.Instance, event *models.Event, eTagSelector string) (string, error) {
+func (mock *MongoDBMock) AddEventToInstance(ctx context.Context, currentInstance *models.Instance, event *models.Event, eTagSelector string) (string, error) {
 	if mock.AddEventToInstanceFunc == nil {
 		panic("MongoDBMock.AddEventToInstanceFunc: method is nil but MongoDB.AddEventToInstance was just called")
 	}
 	callInfo := struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Event           *models.Event
 		ETagSelector    string
 	}{
+		Ctx:             ctx,
 		CurrentInstance: currentInstance,
 		Event:           event,
 		ETagSelector:    eTagSelector,
@@ -748,18 +1446,20 @@ func (mock *MongoDBMock) AddEventToInstance(currentInstance *models.Instance, ev
 	lockMongoDBMockAddEventToInstance.Lock()
 	mock.calls.AddEventToInstance = append(mock.calls.AddEventToInstance, callInfo)
 	lockMongoDBMockAddEventToInstance.Unlock()
-	return mock.AddEventToInstanceFunc(currentInstance, event, eTagSelector)
+	return mock.AddEventToInstanceFunc(ctx, currentInstance, event, eTagSelector)
 }
 
 // AddEventToInstanceCalls gets all the calls that were made to AddEventToInstance.
 // Check the length with:
 //     len(mockedMongoDB.AddEventToInstanceCalls())
 func (mock *MongoDBMock) AddEventToInstanceCalls() []struct {
+	Ctx             context.Context
 	CurrentInstance *models.Instance
 	Event           *models.Event
 	ETagSelector    string
 } {
 	var calls []struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Event           *models.Event
 		ETagSelector    string
@@ -771,28 +1471,32 @@ func (mock *MongoDBMock) AddEventToInstanceCalls() []struct {
 }
 
 // AddInstance calls AddInstanceFunc.
-func (mock *MongoDBMock) AddInstance(instance *models.Instance) (*models.Instance, error) {
+func (mock *MongoDBMock) AddInstance(ctx context.Context, instance *models.Instance) (*models.Instance, error) {
 	if mock.AddInstanceFunc == nil {
 		panic("MongoDBMock.AddInstanceFunc: method is nil but MongoDB.AddInstance was just called")
 	}
 	callInfo := struct {
+		Ctx      context.Context
 		Instance *models.Instance
 	}{
+		Ctx:      ctx,
 		Instance: instance,
 	}
 	lockMongoDBMockAddInstance.Lock()
 	mock.calls.AddInstance = append(mock.calls.AddInstance, callInfo)
 	lockMongoDBMockAddInstance.Unlock()
-	return mock.AddInstanceFunc(instance)
+	return mock.AddInstanceFunc(ctx, instance)
 }
 
 // AddInstanceCalls gets all the calls that were made to AddInstance.
 // Check the length with:
 //     len(mockedMongoDB.AddInstanceCalls())
 func (mock *MongoDBMock) AddInstanceCalls() []struct {
+	Ctx      context.Context
 	Instance *models.Instance
 } {
 	var calls []struct {
+		Ctx      context.Context
 		Instance *models.Instance
 	}
 	lockMongoDBMockAddInstance.RLock()
@@ -801,32 +1505,67 @@ func (mock *MongoDBMock) AddInstanceCalls() []struct {
 	return calls
 }
 
+// BackfillETags calls BackfillETagsFunc.
+func (mock *MongoDBMock) BackfillETags(ctx context.Context) (int, error) {
+	if mock.BackfillETagsFunc == nil {
+		panic("MongoDBMock.BackfillETagsFunc: method is nil but MongoDB.BackfillETags was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockMongoDBMockBackfillETags.Lock()
+	mock.calls.BackfillETags = append(mock.calls.BackfillETags, callInfo)
+	lockMongoDBMockBackfillETags.Unlock()
+	return mock.BackfillETagsFunc(ctx)
+}
+
+// BackfillETagsCalls gets all the calls that were made to BackfillETags.
+// Check the length with:
+//     len(mockedMongoDB.BackfillETagsCalls())
+func (mock *MongoDBMock) BackfillETagsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockMongoDBMockBackfillETags.RLock()
+	calls = mock.calls.BackfillETags
+	lockMongoDBMockBackfillETags.RUnlock()
+	return calls
+}
+
 // CheckDatasetExists calls CheckDatasetExistsFunc.
-func (mock *MongoDBMock) CheckDatasetExists(ID string, state string) error {
+func (mock *MongoDBMock) CheckDatasetExists(ctx context.Context, ID string, state string) error {
 	if mock.CheckDatasetExistsFunc == nil {
 		panic("MongoDBMock.CheckDatasetExistsFunc: method is nil but MongoDB.CheckDatasetExists was just called")
 	}
 	callInfo := struct {
+		Ctx   context.Context
 		ID    string
 		State string
 	}{
+		Ctx:   ctx,
 		ID:    ID,
 		State: state,
 	}
 	lockMongoDBMockCheckDatasetExists.Lock()
 	mock.calls.CheckDatasetExists = append(mock.calls.CheckDatasetExists, callInfo)
 	lockMongoDBMockCheckDatasetExists.Unlock()
-	return mock.CheckDatasetExistsFunc(ID, state)
+	return mock.CheckDatasetExistsFunc(ctx, ID, state)
 }
 
 // CheckDatasetExistsCalls gets all the calls that were made to CheckDatasetExists.
 // Check the length with:
 //     len(mockedMongoDB.CheckDatasetExistsCalls())
 func (mock *MongoDBMock) CheckDatasetExistsCalls() []struct {
+	Ctx   context.Context
 	ID    string
 	State string
 } {
 	var calls []struct {
+		Ctx   context.Context
 		ID    string
 		State string
 	}
@@ -837,15 +1576,17 @@ func (mock *MongoDBMock) CheckDatasetExistsCalls() []struct {
 }
 
 // CheckEditionExists calls CheckEditionExistsFunc.
-func (mock *MongoDBMock) CheckEditionExists(ID string, editionID string, state string) error {
+func (mock *MongoDBMock) CheckEditionExists(ctx context.Context, ID string, editionID string, state string) error {
 	if mock.CheckEditionExistsFunc == nil {
 		panic("MongoDBMock.CheckEditionExistsFunc: method is nil but MongoDB.CheckEditionExists was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		ID        string
 		EditionID string
 		State     string
 	}{
+		Ctx:       ctx,
 		ID:        ID,
 		EditionID: editionID,
 		State:     state,
@@ -853,18 +1594,20 @@ func (mock *MongoDBMock) CheckEditionExists(ID string, editionID string, state s
 	lockMongoDBMockCheckEditionExists.Lock()
 	mock.calls.CheckEditionExists = append(mock.calls.CheckEditionExists, callInfo)
 	lockMongoDBMockCheckEditionExists.Unlock()
-	return mock.CheckEditionExistsFunc(ID, editionID, state)
+	return mock.CheckEditionExistsFunc(ctx, ID, editionID, state)
 }
 
 // CheckEditionExistsCalls gets all the calls that were made to CheckEditionExists.
 // Check the length with:
 //     len(mockedMongoDB.CheckEditionExistsCalls())
 func (mock *MongoDBMock) CheckEditionExistsCalls() []struct {
+	Ctx       context.Context
 	ID        string
 	EditionID string
 	State     string
 } {
 	var calls []struct {
+		Ctx       context.Context
 		ID        string
 		EditionID string
 		State     string
@@ -910,6 +1653,45 @@ func (mock *MongoDBMock) CheckerCalls() []struct {
 	return calls
 }
 
+// ClearLatestEditions calls ClearLatestEditionsFunc.
+func (mock *MongoDBMock) ClearLatestEditions(ctx context.Context, datasetID string, edition string) error {
+	if mock.ClearLatestEditionsFunc == nil {
+		panic("MongoDBMock.ClearLatestEditionsFunc: method is nil but MongoDB.ClearLatestEditions was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		Edition   string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		Edition:   edition,
+	}
+	lockMongoDBMockClearLatestEditions.Lock()
+	mock.calls.ClearLatestEditions = append(mock.calls.ClearLatestEditions, callInfo)
+	lockMongoDBMockClearLatestEditions.Unlock()
+	return mock.ClearLatestEditionsFunc(ctx, datasetID, edition)
+}
+
+// ClearLatestEditionsCalls gets all the calls that were made to ClearLatestEditions.
+// Check the length with:
+//     len(mockedMongoDB.ClearLatestEditionsCalls())
+func (mock *MongoDBMock) ClearLatestEditionsCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	Edition   string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		Edition   string
+	}
+	lockMongoDBMockClearLatestEditions.RLock()
+	calls = mock.calls.ClearLatestEditions
+	lockMongoDBMockClearLatestEditions.RUnlock()
+	return calls
+}
+
 // Close calls CloseFunc.
 func (mock *MongoDBMock) Close(in1 context.Context) error {
 	if mock.CloseFunc == nil {
@@ -941,30 +1723,104 @@ func (mock *MongoDBMock) CloseCalls() []struct {
 	return calls
 }
 
+// CountDimensionOptions calls CountDimensionOptionsFunc.
+func (mock *MongoDBMock) CountDimensionOptions(ctx context.Context, instanceID string) (int, error) {
+	if mock.CountDimensionOptionsFunc == nil {
+		panic("MongoDBMock.CountDimensionOptionsFunc: method is nil but MongoDB.CountDimensionOptions was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		InstanceID string
+	}{
+		Ctx:        ctx,
+		InstanceID: instanceID,
+	}
+	lockMongoDBMockCountDimensionOptions.Lock()
+	mock.calls.CountDimensionOptions = append(mock.calls.CountDimensionOptions, callInfo)
+	lockMongoDBMockCountDimensionOptions.Unlock()
+	return mock.CountDimensionOptionsFunc(ctx, instanceID)
+}
+
+// CountDimensionOptionsCalls gets all the calls that were made to CountDimensionOptions.
+// Check the length with:
+//     len(mockedMongoDB.CountDimensionOptionsCalls())
+func (mock *MongoDBMock) CountDimensionOptionsCalls() []struct {
+	Ctx        context.Context
+	InstanceID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		InstanceID string
+	}
+	lockMongoDBMockCountDimensionOptions.RLock()
+	calls = mock.calls.CountDimensionOptions
+	lockMongoDBMockCountDimensionOptions.RUnlock()
+	return calls
+}
+
+// CountTopicDatasets calls CountTopicDatasetsFunc.
+func (mock *MongoDBMock) CountTopicDatasets(ctx context.Context, id string) (int, error) {
+	if mock.CountTopicDatasetsFunc == nil {
+		panic("MongoDBMock.CountTopicDatasetsFunc: method is nil but MongoDB.CountTopicDatasets was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockMongoDBMockCountTopicDatasets.Lock()
+	mock.calls.CountTopicDatasets = append(mock.calls.CountTopicDatasets, callInfo)
+	lockMongoDBMockCountTopicDatasets.Unlock()
+	return mock.CountTopicDatasetsFunc(ctx, id)
+}
+
+// CountTopicDatasetsCalls gets all the calls that were made to CountTopicDatasets.
+// Check the length with:
+//     len(mockedMongoDB.CountTopicDatasetsCalls())
+func (mock *MongoDBMock) CountTopicDatasetsCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	lockMongoDBMockCountTopicDatasets.RLock()
+	calls = mock.calls.CountTopicDatasets
+	lockMongoDBMockCountTopicDatasets.RUnlock()
+	return calls
+}
+
 // DeleteDataset calls DeleteDatasetFunc.
-func (mock *MongoDBMock) DeleteDataset(ID string) error {
+func (mock *MongoDBMock) DeleteDataset(ctx context.Context, ID string) error {
 	if mock.DeleteDatasetFunc == nil {
 		panic("MongoDBMock.DeleteDatasetFunc: method is nil but MongoDB.DeleteDataset was just called")
 	}
 	callInfo := struct {
-		ID string
+		Ctx context.Context
+		ID  string
 	}{
-		ID: ID,
+		Ctx: ctx,
+		ID:  ID,
 	}
 	lockMongoDBMockDeleteDataset.Lock()
 	mock.calls.DeleteDataset = append(mock.calls.DeleteDataset, callInfo)
 	lockMongoDBMockDeleteDataset.Unlock()
-	return mock.DeleteDatasetFunc(ID)
+	return mock.DeleteDatasetFunc(ctx, ID)
 }
 
 // DeleteDatasetCalls gets all the calls that were made to DeleteDataset.
 // Check the length with:
 //     len(mockedMongoDB.DeleteDatasetCalls())
 func (mock *MongoDBMock) DeleteDatasetCalls() []struct {
-	ID string
+	Ctx context.Context
+	ID  string
 } {
 	var calls []struct {
-		ID string
+		Ctx context.Context
+		ID  string
 	}
 	lockMongoDBMockDeleteDataset.RLock()
 	calls = mock.calls.DeleteDataset
@@ -972,30 +1828,69 @@ func (mock *MongoDBMock) DeleteDatasetCalls() []struct {
 	return calls
 }
 
+// DeleteDimensionOptions calls DeleteDimensionOptionsFunc.
+func (mock *MongoDBMock) DeleteDimensionOptions(ctx context.Context, instanceID string) (int, error) {
+	if mock.DeleteDimensionOptionsFunc == nil {
+		panic("MongoDBMock.DeleteDimensionOptionsFunc: method is nil but MongoDB.DeleteDimensionOptions was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		InstanceID string
+	}{
+		Ctx:        ctx,
+		InstanceID: instanceID,
+	}
+	lockMongoDBMockDeleteDimensionOptions.Lock()
+	mock.calls.DeleteDimensionOptions = append(mock.calls.DeleteDimensionOptions, callInfo)
+	lockMongoDBMockDeleteDimensionOptions.Unlock()
+	return mock.DeleteDimensionOptionsFunc(ctx, instanceID)
+}
+
+// DeleteDimensionOptionsCalls gets all the calls that were made to DeleteDimensionOptions.
+// Check the length with:
+//     len(mockedMongoDB.DeleteDimensionOptionsCalls())
+func (mock *MongoDBMock) DeleteDimensionOptionsCalls() []struct {
+	Ctx        context.Context
+	InstanceID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		InstanceID string
+	}
+	lockMongoDBMockDeleteDimensionOptions.RLock()
+	calls = mock.calls.DeleteDimensionOptions
+	lockMongoDBMockDeleteDimensionOptions.RUnlock()
+	return calls
+}
+
 // DeleteEdition calls DeleteEditionFunc.
-func (mock *MongoDBMock) DeleteEdition(ID string) error {
+func (mock *MongoDBMock) DeleteEdition(ctx context.Context, ID string) error {
 	if mock.DeleteEditionFunc == nil {
 		panic("MongoDBMock.DeleteEditionFunc: method is nil but MongoDB.DeleteEdition was just called")
 	}
 	callInfo := struct {
-		ID string
+		Ctx context.Context
+		ID  string
 	}{
-		ID: ID,
+		Ctx: ctx,
+		ID:  ID,
 	}
 	lockMongoDBMockDeleteEdition.Lock()
 	mock.calls.DeleteEdition = append(mock.calls.DeleteEdition, callInfo)
 	lockMongoDBMockDeleteEdition.Unlock()
-	return mock.DeleteEditionFunc(ID)
+	return mock.DeleteEditionFunc(ctx, ID)
 }
 
 // DeleteEditionCalls gets all the calls that were made to DeleteEdition.
 // Check the length with:
 //     len(mockedMongoDB.DeleteEditionCalls())
 func (mock *MongoDBMock) DeleteEditionCalls() []struct {
-	ID string
+	Ctx context.Context
+	ID  string
 } {
 	var calls []struct {
-		ID string
+		Ctx context.Context
+		ID  string
 	}
 	lockMongoDBMockDeleteEdition.RLock()
 	calls = mock.calls.DeleteEdition
@@ -1003,39 +1898,218 @@ func (mock *MongoDBMock) DeleteEditionCalls() []struct {
 	return calls
 }
 
-// GetDataset calls GetDatasetFunc.
-func (mock *MongoDBMock) GetDataset(ID string) (*models.DatasetUpdate, error) {
-	if mock.GetDatasetFunc == nil {
-		panic("MongoDBMock.GetDatasetFunc: method is nil but MongoDB.GetDataset was just called")
+// DeleteInstance calls DeleteInstanceFunc.
+func (mock *MongoDBMock) DeleteInstance(ctx context.Context, instanceID string) error {
+	if mock.DeleteInstanceFunc == nil {
+		panic("MongoDBMock.DeleteInstanceFunc: method is nil but MongoDB.DeleteInstance was just called")
 	}
 	callInfo := struct {
-		ID string
+		Ctx        context.Context
+		InstanceID string
 	}{
-		ID: ID,
+		Ctx:        ctx,
+		InstanceID: instanceID,
 	}
-	lockMongoDBMockGetDataset.Lock()
-	mock.calls.GetDataset = append(mock.calls.GetDataset, callInfo)
-	lockMongoDBMockGetDataset.Unlock()
-	return mock.GetDatasetFunc(ID)
+	lockMongoDBMockDeleteInstance.Lock()
+	mock.calls.DeleteInstance = append(mock.calls.DeleteInstance, callInfo)
+	lockMongoDBMockDeleteInstance.Unlock()
+	return mock.DeleteInstanceFunc(ctx, instanceID)
 }
 
-// GetDatasetCalls gets all the calls that were made to GetDataset.
+// DeleteInstanceCalls gets all the calls that were made to DeleteInstance.
 // Check the length with:
-//     len(mockedMongoDB.GetDatasetCalls())
-func (mock *MongoDBMock) GetDatasetCalls() []struct {
-	ID string
+//     len(mockedMongoDB.DeleteInstanceCalls())
+func (mock *MongoDBMock) DeleteInstanceCalls() []struct {
+	Ctx        context.Context
+	InstanceID string
 } {
 	var calls []struct {
-		ID string
+		Ctx        context.Context
+		InstanceID string
 	}
-	lockMongoDBMockGetDataset.RLock()
-	calls = mock.calls.GetDataset
-	lockMongoDBMockGetDataset.RUnlock()
+	lockMongoDBMockDeleteInstance.RLock()
+	calls = mock.calls.DeleteInstance
+	lockMongoDBMockDeleteInstance.RUnlock()
 	return calls
 }
 
-// GetDatasets calls GetDatasetsFunc.
-func (mock *MongoDBMock) GetDatasets(ctx context.Context, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
+// DeleteLinkReportsExcept calls DeleteLinkReportsExceptFunc.
+func (mock *MongoDBMock) DeleteLinkReportsExcept(ctx context.Context, datasetIDs []string) error {
+	if mock.DeleteLinkReportsExceptFunc == nil {
+		panic("MongoDBMock.DeleteLinkReportsExceptFunc: method is nil but MongoDB.DeleteLinkReportsExcept was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		DatasetIDs []string
+	}{
+		Ctx:        ctx,
+		DatasetIDs: datasetIDs,
+	}
+	lockMongoDBMockDeleteLinkReportsExcept.Lock()
+	mock.calls.DeleteLinkReportsExcept = append(mock.calls.DeleteLinkReportsExcept, callInfo)
+	lockMongoDBMockDeleteLinkReportsExcept.Unlock()
+	return mock.DeleteLinkReportsExceptFunc(ctx, datasetIDs)
+}
+
+// DeleteLinkReportsExceptCalls gets all the calls that were made to DeleteLinkReportsExcept.
+// Check the length with:
+//     len(mockedMongoDB.DeleteLinkReportsExceptCalls())
+func (mock *MongoDBMock) DeleteLinkReportsExceptCalls() []struct {
+	Ctx        context.Context
+	DatasetIDs []string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		DatasetIDs []string
+	}
+	lockMongoDBMockDeleteLinkReportsExcept.RLock()
+	calls = mock.calls.DeleteLinkReportsExcept
+	lockMongoDBMockDeleteLinkReportsExcept.RUnlock()
+	return calls
+}
+
+// DeleteTopic calls DeleteTopicFunc.
+func (mock *MongoDBMock) DeleteTopic(ctx context.Context, id string) error {
+	if mock.DeleteTopicFunc == nil {
+		panic("MongoDBMock.DeleteTopicFunc: method is nil but MongoDB.DeleteTopic was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockMongoDBMockDeleteTopic.Lock()
+	mock.calls.DeleteTopic = append(mock.calls.DeleteTopic, callInfo)
+	lockMongoDBMockDeleteTopic.Unlock()
+	return mock.DeleteTopicFunc(ctx, id)
+}
+
+// DeleteTopicCalls gets all the calls that were made to DeleteTopic.
+// Check the length with:
+//     len(mockedMongoDB.DeleteTopicCalls())
+func (mock *MongoDBMock) DeleteTopicCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	lockMongoDBMockDeleteTopic.RLock()
+	calls = mock.calls.DeleteTopic
+	lockMongoDBMockDeleteTopic.RUnlock()
+	return calls
+}
+
+// GetDataset calls GetDatasetFunc.
+func (mock *MongoDBMock) GetDataset(ctx context.Context, ID string) (*models.DatasetUpdate, error) {
+	if mock.GetDatasetFunc == nil {
+		panic("MongoDBMock.GetDatasetFunc: method is nil but MongoDB.GetDataset was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  ID,
+	}
+	lockMongoDBMockGetDataset.Lock()
+	mock.calls.GetDataset = append(mock.calls.GetDataset, callInfo)
+	lockMongoDBMockGetDataset.Unlock()
+	return mock.GetDatasetFunc(ctx, ID)
+}
+
+// GetDatasetCalls gets all the calls that were made to GetDataset.
+// Check the length with:
+//     len(mockedMongoDB.GetDatasetCalls())
+func (mock *MongoDBMock) GetDatasetCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	lockMongoDBMockGetDataset.RLock()
+	calls = mock.calls.GetDataset
+	lockMongoDBMockGetDataset.RUnlock()
+	return calls
+}
+
+// GetDatasetEditions calls GetDatasetEditionsFunc.
+func (mock *MongoDBMock) GetDatasetEditions(ctx context.Context, datasetID string) ([]*models.EditionUpdate, error) {
+	if mock.GetDatasetEditionsFunc == nil {
+		panic("MongoDBMock.GetDatasetEditionsFunc: method is nil but MongoDB.GetDatasetEditions was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+	}
+	lockMongoDBMockGetDatasetEditions.Lock()
+	mock.calls.GetDatasetEditions = append(mock.calls.GetDatasetEditions, callInfo)
+	lockMongoDBMockGetDatasetEditions.Unlock()
+	return mock.GetDatasetEditionsFunc(ctx, datasetID)
+}
+
+// GetDatasetEditionsCalls gets all the calls that were made to GetDatasetEditions.
+// Check the length with:
+//     len(mockedMongoDB.GetDatasetEditionsCalls())
+func (mock *MongoDBMock) GetDatasetEditionsCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+	}
+	lockMongoDBMockGetDatasetEditions.RLock()
+	calls = mock.calls.GetDatasetEditions
+	lockMongoDBMockGetDatasetEditions.RUnlock()
+	return calls
+}
+
+// GetDatasetInstances calls GetDatasetInstancesFunc.
+func (mock *MongoDBMock) GetDatasetInstances(ctx context.Context, datasetID string) ([]*models.Instance, error) {
+	if mock.GetDatasetInstancesFunc == nil {
+		panic("MongoDBMock.GetDatasetInstancesFunc: method is nil but MongoDB.GetDatasetInstances was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+	}
+	lockMongoDBMockGetDatasetInstances.Lock()
+	mock.calls.GetDatasetInstances = append(mock.calls.GetDatasetInstances, callInfo)
+	lockMongoDBMockGetDatasetInstances.Unlock()
+	return mock.GetDatasetInstancesFunc(ctx, datasetID)
+}
+
+// GetDatasetInstancesCalls gets all the calls that were made to GetDatasetInstances.
+// Check the length with:
+//     len(mockedMongoDB.GetDatasetInstancesCalls())
+func (mock *MongoDBMock) GetDatasetInstancesCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+	}
+	lockMongoDBMockGetDatasetInstances.RLock()
+	calls = mock.calls.GetDatasetInstances
+	lockMongoDBMockGetDatasetInstances.RUnlock()
+	return calls
+}
+
+// GetDatasets calls GetDatasetsFunc.
+func (mock *MongoDBMock) GetDatasets(ctx context.Context, offset int, limit int, authorised bool) ([]*models.DatasetUpdate, int, error) {
 	if mock.GetDatasetsFunc == nil {
 		panic("MongoDBMock.GetDatasetsFunc: method is nil but MongoDB.GetDatasets was just called")
 	}
@@ -1077,6 +2151,181 @@ func (mock *MongoDBMock) GetDatasetsCalls() []struct {
 	return calls
 }
 
+// GetDatasetsByIDs calls GetDatasetsByIDsFunc.
+func (mock *MongoDBMock) GetDatasetsByIDs(ctx context.Context, ids []string) ([]*models.DatasetUpdate, error) {
+	if mock.GetDatasetsByIDsFunc == nil {
+		panic("MongoDBMock.GetDatasetsByIDsFunc: method is nil but MongoDB.GetDatasetsByIDs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Ids []string
+	}{
+		Ctx: ctx,
+		Ids: ids,
+	}
+	lockMongoDBMockGetDatasetsByIDs.Lock()
+	mock.calls.GetDatasetsByIDs = append(mock.calls.GetDatasetsByIDs, callInfo)
+	lockMongoDBMockGetDatasetsByIDs.Unlock()
+	return mock.GetDatasetsByIDsFunc(ctx, ids)
+}
+
+// GetDatasetsByIDsCalls gets all the calls that were made to GetDatasetsByIDs.
+// Check the length with:
+//     len(mockedMongoDB.GetDatasetsByIDsCalls())
+func (mock *MongoDBMock) GetDatasetsByIDsCalls() []struct {
+	Ctx context.Context
+	Ids []string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Ids []string
+	}
+	lockMongoDBMockGetDatasetsByIDs.RLock()
+	calls = mock.calls.GetDatasetsByIDs
+	lockMongoDBMockGetDatasetsByIDs.RUnlock()
+	return calls
+}
+
+// GetDatasetsDeletedBefore calls GetDatasetsDeletedBeforeFunc.
+func (mock *MongoDBMock) GetDatasetsDeletedBefore(ctx context.Context, before time.Time) ([]string, error) {
+	if mock.GetDatasetsDeletedBeforeFunc == nil {
+		panic("MongoDBMock.GetDatasetsDeletedBeforeFunc: method is nil but MongoDB.GetDatasetsDeletedBefore was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Before time.Time
+	}{
+		Ctx:    ctx,
+		Before: before,
+	}
+	lockMongoDBMockGetDatasetsDeletedBefore.Lock()
+	mock.calls.GetDatasetsDeletedBefore = append(mock.calls.GetDatasetsDeletedBefore, callInfo)
+	lockMongoDBMockGetDatasetsDeletedBefore.Unlock()
+	return mock.GetDatasetsDeletedBeforeFunc(ctx, before)
+}
+
+// GetDatasetsDeletedBeforeCalls gets all the calls that were made to GetDatasetsDeletedBefore.
+// Check the length with:
+//     len(mockedMongoDB.GetDatasetsDeletedBeforeCalls())
+func (mock *MongoDBMock) GetDatasetsDeletedBeforeCalls() []struct {
+	Ctx    context.Context
+	Before time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Before time.Time
+	}
+	lockMongoDBMockGetDatasetsDeletedBefore.RLock()
+	calls = mock.calls.GetDatasetsDeletedBefore
+	lockMongoDBMockGetDatasetsDeletedBefore.RUnlock()
+	return calls
+}
+
+// GetDatasetsRelatedTo calls GetDatasetsRelatedToFunc.
+func (mock *MongoDBMock) GetDatasetsRelatedTo(ctx context.Context, datasetID string) ([]*models.DatasetUpdate, error) {
+	if mock.GetDatasetsRelatedToFunc == nil {
+		panic("MongoDBMock.GetDatasetsRelatedToFunc: method is nil but MongoDB.GetDatasetsRelatedTo was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+	}
+	lockMongoDBMockGetDatasetsRelatedTo.Lock()
+	mock.calls.GetDatasetsRelatedTo = append(mock.calls.GetDatasetsRelatedTo, callInfo)
+	lockMongoDBMockGetDatasetsRelatedTo.Unlock()
+	return mock.GetDatasetsRelatedToFunc(ctx, datasetID)
+}
+
+// GetDatasetsRelatedToCalls gets all the calls that were made to GetDatasetsRelatedTo.
+// Check the length with:
+//     len(mockedMongoDB.GetDatasetsRelatedToCalls())
+func (mock *MongoDBMock) GetDatasetsRelatedToCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+	}
+	lockMongoDBMockGetDatasetsRelatedTo.RLock()
+	calls = mock.calls.GetDatasetsRelatedTo
+	lockMongoDBMockGetDatasetsRelatedTo.RUnlock()
+	return calls
+}
+
+// GetDeleteJob calls GetDeleteJobFunc.
+func (mock *MongoDBMock) GetDeleteJob(ctx context.Context, jobID string) (*models.DeleteJob, error) {
+	if mock.GetDeleteJobFunc == nil {
+		panic("MongoDBMock.GetDeleteJobFunc: method is nil but MongoDB.GetDeleteJob was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		JobID string
+	}{
+		Ctx:   ctx,
+		JobID: jobID,
+	}
+	lockMongoDBMockGetDeleteJob.Lock()
+	mock.calls.GetDeleteJob = append(mock.calls.GetDeleteJob, callInfo)
+	lockMongoDBMockGetDeleteJob.Unlock()
+	return mock.GetDeleteJobFunc(ctx, jobID)
+}
+
+// GetDeleteJobCalls gets all the calls that were made to GetDeleteJob.
+// Check the length with:
+//     len(mockedMongoDB.GetDeleteJobCalls())
+func (mock *MongoDBMock) GetDeleteJobCalls() []struct {
+	Ctx   context.Context
+	JobID string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		JobID string
+	}
+	lockMongoDBMockGetDeleteJob.RLock()
+	calls = mock.calls.GetDeleteJob
+	lockMongoDBMockGetDeleteJob.RUnlock()
+	return calls
+}
+
+// GetDeletedDataset calls GetDeletedDatasetFunc.
+func (mock *MongoDBMock) GetDeletedDataset(ctx context.Context, id string) (*models.DatasetUpdate, error) {
+	if mock.GetDeletedDatasetFunc == nil {
+		panic("MongoDBMock.GetDeletedDatasetFunc: method is nil but MongoDB.GetDeletedDataset was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockMongoDBMockGetDeletedDataset.Lock()
+	mock.calls.GetDeletedDataset = append(mock.calls.GetDeletedDataset, callInfo)
+	lockMongoDBMockGetDeletedDataset.Unlock()
+	return mock.GetDeletedDatasetFunc(ctx, id)
+}
+
+// GetDeletedDatasetCalls gets all the calls that were made to GetDeletedDataset.
+// Check the length with:
+//     len(mockedMongoDB.GetDeletedDatasetCalls())
+func (mock *MongoDBMock) GetDeletedDatasetCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	lockMongoDBMockGetDeletedDataset.RLock()
+	calls = mock.calls.GetDeletedDataset
+	lockMongoDBMockGetDeletedDataset.RUnlock()
+	return calls
+}
+
 // GetDimensionOptions calls GetDimensionOptionsFunc.
 func (mock *MongoDBMock) GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset int, limit int) ([]*models.PublicDimensionOption, int, error) {
 	if mock.GetDimensionOptionsFunc == nil {
@@ -1125,15 +2374,17 @@ func (mock *MongoDBMock) GetDimensionOptionsCalls() []struct {
 }
 
 // GetDimensionOptionsFromIDs calls GetDimensionOptionsFromIDsFunc.
-func (mock *MongoDBMock) GetDimensionOptionsFromIDs(version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
+func (mock *MongoDBMock) GetDimensionOptionsFromIDs(ctx context.Context, version *models.Version, dimension string, ids []string) ([]*models.PublicDimensionOption, int, error) {
 	if mock.GetDimensionOptionsFromIDsFunc == nil {
 		panic("MongoDBMock.GetDimensionOptionsFromIDsFunc: method is nil but MongoDB.GetDimensionOptionsFromIDs was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		Version   *models.Version
 		Dimension string
 		Ids       []string
 	}{
+		Ctx:       ctx,
 		Version:   version,
 		Dimension: dimension,
 		Ids:       ids,
@@ -1141,18 +2392,20 @@ func (mock *MongoDBMock) GetDimensionOptionsFromIDs(version *models.Version, dim
 	lockMongoDBMockGetDimensionOptionsFromIDs.Lock()
 	mock.calls.GetDimensionOptionsFromIDs = append(mock.calls.GetDimensionOptionsFromIDs, callInfo)
 	lockMongoDBMockGetDimensionOptionsFromIDs.Unlock()
-	return mock.GetDimensionOptionsFromIDsFunc(version, dimension, ids)
+	return mock.GetDimensionOptionsFromIDsFunc(ctx, version, dimension, ids)
 }
 
 // GetDimensionOptionsFromIDsCalls gets all the calls that were made to GetDimensionOptionsFromIDs.
 // Check the length with:
 //     len(mockedMongoDB.GetDimensionOptionsFromIDsCalls())
 func (mock *MongoDBMock) GetDimensionOptionsFromIDsCalls() []struct {
+	Ctx       context.Context
 	Version   *models.Version
 	Dimension string
 	Ids       []string
 } {
 	var calls []struct {
+		Ctx       context.Context
 		Version   *models.Version
 		Dimension string
 		Ids       []string
@@ -1163,32 +2416,71 @@ func (mock *MongoDBMock) GetDimensionOptionsFromIDsCalls() []struct {
 	return calls
 }
 
+// GetDimensionSummaries calls GetDimensionSummariesFunc.
+func (mock *MongoDBMock) GetDimensionSummaries(ctx context.Context, instanceID string) ([]*models.DimensionSummary, error) {
+	if mock.GetDimensionSummariesFunc == nil {
+		panic("MongoDBMock.GetDimensionSummariesFunc: method is nil but MongoDB.GetDimensionSummaries was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		InstanceID string
+	}{
+		Ctx:        ctx,
+		InstanceID: instanceID,
+	}
+	lockMongoDBMockGetDimensionSummaries.Lock()
+	mock.calls.GetDimensionSummaries = append(mock.calls.GetDimensionSummaries, callInfo)
+	lockMongoDBMockGetDimensionSummaries.Unlock()
+	return mock.GetDimensionSummariesFunc(ctx, instanceID)
+}
+
+// GetDimensionSummariesCalls gets all the calls that were made to GetDimensionSummaries.
+// Check the length with:
+//     len(mockedMongoDB.GetDimensionSummariesCalls())
+func (mock *MongoDBMock) GetDimensionSummariesCalls() []struct {
+	Ctx        context.Context
+	InstanceID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		InstanceID string
+	}
+	lockMongoDBMockGetDimensionSummaries.RLock()
+	calls = mock.calls.GetDimensionSummaries
+	lockMongoDBMockGetDimensionSummaries.RUnlock()
+	return calls
+}
+
 // GetDimensions calls GetDimensionsFunc.
-func (mock *MongoDBMock) GetDimensions(datasetID string, versionID string) ([]bson.M, error) {
+func (mock *MongoDBMock) GetDimensions(ctx context.Context, datasetID string, versionID string) ([]bson.M, error) {
 	if mock.GetDimensionsFunc == nil {
 		panic("MongoDBMock.GetDimensionsFunc: method is nil but MongoDB.GetDimensions was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		DatasetID string
 		VersionID string
 	}{
+		Ctx:       ctx,
 		DatasetID: datasetID,
 		VersionID: versionID,
 	}
 	lockMongoDBMockGetDimensions.Lock()
 	mock.calls.GetDimensions = append(mock.calls.GetDimensions, callInfo)
 	lockMongoDBMockGetDimensions.Unlock()
-	return mock.GetDimensionsFunc(datasetID, versionID)
+	return mock.GetDimensionsFunc(ctx, datasetID, versionID)
 }
 
 // GetDimensionsCalls gets all the calls that were made to GetDimensions.
 // Check the length with:
 //     len(mockedMongoDB.GetDimensionsCalls())
 func (mock *MongoDBMock) GetDimensionsCalls() []struct {
+	Ctx       context.Context
 	DatasetID string
 	VersionID string
 } {
 	var calls []struct {
+		Ctx       context.Context
 		DatasetID string
 		VersionID string
 	}
@@ -1242,15 +2534,17 @@ func (mock *MongoDBMock) GetDimensionsFromInstanceCalls() []struct {
 }
 
 // GetEdition calls GetEditionFunc.
-func (mock *MongoDBMock) GetEdition(ID string, editionID string, state string) (*models.EditionUpdate, error) {
+func (mock *MongoDBMock) GetEdition(ctx context.Context, ID string, editionID string, state string) (*models.EditionUpdate, error) {
 	if mock.GetEditionFunc == nil {
 		panic("MongoDBMock.GetEditionFunc: method is nil but MongoDB.GetEdition was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		ID        string
 		EditionID string
 		State     string
 	}{
+		Ctx:       ctx,
 		ID:        ID,
 		EditionID: editionID,
 		State:     state,
@@ -1258,18 +2552,20 @@ func (mock *MongoDBMock) GetEdition(ID string, editionID string, state string) (
 	lockMongoDBMockGetEdition.Lock()
 	mock.calls.GetEdition = append(mock.calls.GetEdition, callInfo)
 	lockMongoDBMockGetEdition.Unlock()
-	return mock.GetEditionFunc(ID, editionID, state)
+	return mock.GetEditionFunc(ctx, ID, editionID, state)
 }
 
 // GetEditionCalls gets all the calls that were made to GetEdition.
 // Check the length with:
 //     len(mockedMongoDB.GetEditionCalls())
 func (mock *MongoDBMock) GetEditionCalls() []struct {
+	Ctx       context.Context
 	ID        string
 	EditionID string
 	State     string
 } {
 	var calls []struct {
+		Ctx       context.Context
 		ID        string
 		EditionID string
 		State     string
@@ -1331,32 +2627,71 @@ func (mock *MongoDBMock) GetEditionsCalls() []struct {
 	return calls
 }
 
+// GetEditionsDeletedBefore calls GetEditionsDeletedBeforeFunc.
+func (mock *MongoDBMock) GetEditionsDeletedBefore(ctx context.Context, before time.Time) ([]*models.EditionUpdate, error) {
+	if mock.GetEditionsDeletedBeforeFunc == nil {
+		panic("MongoDBMock.GetEditionsDeletedBeforeFunc: method is nil but MongoDB.GetEditionsDeletedBefore was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Before time.Time
+	}{
+		Ctx:    ctx,
+		Before: before,
+	}
+	lockMongoDBMockGetEditionsDeletedBefore.Lock()
+	mock.calls.GetEditionsDeletedBefore = append(mock.calls.GetEditionsDeletedBefore, callInfo)
+	lockMongoDBMockGetEditionsDeletedBefore.Unlock()
+	return mock.GetEditionsDeletedBeforeFunc(ctx, before)
+}
+
+// GetEditionsDeletedBeforeCalls gets all the calls that were made to GetEditionsDeletedBefore.
+// Check the length with:
+//     len(mockedMongoDB.GetEditionsDeletedBeforeCalls())
+func (mock *MongoDBMock) GetEditionsDeletedBeforeCalls() []struct {
+	Ctx    context.Context
+	Before time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Before time.Time
+	}
+	lockMongoDBMockGetEditionsDeletedBefore.RLock()
+	calls = mock.calls.GetEditionsDeletedBefore
+	lockMongoDBMockGetEditionsDeletedBefore.RUnlock()
+	return calls
+}
+
 // GetInstance calls GetInstanceFunc.
-func (mock *MongoDBMock) GetInstance(ID string, eTagSelector string) (*models.Instance, error) {
+func (mock *MongoDBMock) GetInstance(ctx context.Context, ID string, eTagSelector string) (*models.Instance, error) {
 	if mock.GetInstanceFunc == nil {
 		panic("MongoDBMock.GetInstanceFunc: method is nil but MongoDB.GetInstance was just called")
 	}
 	callInfo := struct {
+		Ctx          context.Context
 		ID           string
 		ETagSelector string
 	}{
+		Ctx:          ctx,
 		ID:           ID,
 		ETagSelector: eTagSelector,
 	}
 	lockMongoDBMockGetInstance.Lock()
 	mock.calls.GetInstance = append(mock.calls.GetInstance, callInfo)
 	lockMongoDBMockGetInstance.Unlock()
-	return mock.GetInstanceFunc(ID, eTagSelector)
+	return mock.GetInstanceFunc(ctx, ID, eTagSelector)
 }
 
 // GetInstanceCalls gets all the calls that were made to GetInstance.
 // Check the length with:
 //     len(mockedMongoDB.GetInstanceCalls())
 func (mock *MongoDBMock) GetInstanceCalls() []struct {
+	Ctx          context.Context
 	ID           string
 	ETagSelector string
 } {
 	var calls []struct {
+		Ctx          context.Context
 		ID           string
 		ETagSelector string
 	}
@@ -1413,49 +2748,318 @@ func (mock *MongoDBMock) GetInstancesCalls() []struct {
 	return calls
 }
 
-// GetNextVersion calls GetNextVersionFunc.
-func (mock *MongoDBMock) GetNextVersion(datasetID string, editionID string) (int, error) {
-	if mock.GetNextVersionFunc == nil {
-		panic("MongoDBMock.GetNextVersionFunc: method is nil but MongoDB.GetNextVersion was just called")
+// GetLinkReport calls GetLinkReportFunc.
+func (mock *MongoDBMock) GetLinkReport(ctx context.Context, datasetID string) (*models.LinkReport, error) {
+	if mock.GetLinkReportFunc == nil {
+		panic("MongoDBMock.GetLinkReportFunc: method is nil but MongoDB.GetLinkReport was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		DatasetID string
-		EditionID string
 	}{
+		Ctx:       ctx,
 		DatasetID: datasetID,
-		EditionID: editionID,
 	}
-	lockMongoDBMockGetNextVersion.Lock()
-	mock.calls.GetNextVersion = append(mock.calls.GetNextVersion, callInfo)
-	lockMongoDBMockGetNextVersion.Unlock()
-	return mock.GetNextVersionFunc(datasetID, editionID)
+	lockMongoDBMockGetLinkReport.Lock()
+	mock.calls.GetLinkReport = append(mock.calls.GetLinkReport, callInfo)
+	lockMongoDBMockGetLinkReport.Unlock()
+	return mock.GetLinkReportFunc(ctx, datasetID)
 }
 
-// GetNextVersionCalls gets all the calls that were made to GetNextVersion.
+// GetLinkReportCalls gets all the calls that were made to GetLinkReport.
 // Check the length with:
-//     len(mockedMongoDB.GetNextVersionCalls())
-func (mock *MongoDBMock) GetNextVersionCalls() []struct {
+//     len(mockedMongoDB.GetLinkReportCalls())
+func (mock *MongoDBMock) GetLinkReportCalls() []struct {
+	Ctx       context.Context
 	DatasetID string
-	EditionID string
 } {
 	var calls []struct {
+		Ctx       context.Context
 		DatasetID string
-		EditionID string
 	}
-	lockMongoDBMockGetNextVersion.RLock()
-	calls = mock.calls.GetNextVersion
-	lockMongoDBMockGetNextVersion.RUnlock()
+	lockMongoDBMockGetLinkReport.RLock()
+	calls = mock.calls.GetLinkReport
+	lockMongoDBMockGetLinkReport.RUnlock()
 	return calls
 }
 
-// GetUniqueDimensionAndOptions calls GetUniqueDimensionAndOptionsFunc.
-func (mock *MongoDBMock) GetUniqueDimensionAndOptions(ctx context.Context, ID string, dimension string, offset int, limit int) ([]*string, int, error) {
-	if mock.GetUniqueDimensionAndOptionsFunc == nil {
-		panic("MongoDBMock.GetUniqueDimensionAndOptionsFunc: method is nil but MongoDB.GetUniqueDimensionAndOptions was just called")
+// GetLinkReports calls GetLinkReportsFunc.
+func (mock *MongoDBMock) GetLinkReports(ctx context.Context, offset int, limit int) ([]*models.LinkReport, int, error) {
+	if mock.GetLinkReportsFunc == nil {
+		panic("MongoDBMock.GetLinkReportsFunc: method is nil but MongoDB.GetLinkReports was just called")
 	}
 	callInfo := struct {
-		Ctx       context.Context
-		ID        string
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Offset: offset,
+		Limit:  limit,
+	}
+	lockMongoDBMockGetLinkReports.Lock()
+	mock.calls.GetLinkReports = append(mock.calls.GetLinkReports, callInfo)
+	lockMongoDBMockGetLinkReports.Unlock()
+	return mock.GetLinkReportsFunc(ctx, offset, limit)
+}
+
+// GetLinkReportsCalls gets all the calls that were made to GetLinkReports.
+// Check the length with:
+//     len(mockedMongoDB.GetLinkReportsCalls())
+func (mock *MongoDBMock) GetLinkReportsCalls() []struct {
+	Ctx    context.Context
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}
+	lockMongoDBMockGetLinkReports.RLock()
+	calls = mock.calls.GetLinkReports
+	lockMongoDBMockGetLinkReports.RUnlock()
+	return calls
+}
+
+// GetNextVersion calls GetNextVersionFunc.
+func (mock *MongoDBMock) GetNextVersion(ctx context.Context, datasetID string, editionID string) (int, error) {
+	if mock.GetNextVersionFunc == nil {
+		panic("MongoDBMock.GetNextVersionFunc: method is nil but MongoDB.GetNextVersion was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		EditionID: editionID,
+	}
+	lockMongoDBMockGetNextVersion.Lock()
+	mock.calls.GetNextVersion = append(mock.calls.GetNextVersion, callInfo)
+	lockMongoDBMockGetNextVersion.Unlock()
+	return mock.GetNextVersionFunc(ctx, datasetID, editionID)
+}
+
+// GetNextVersionCalls gets all the calls that were made to GetNextVersion.
+// Check the length with:
+//     len(mockedMongoDB.GetNextVersionCalls())
+func (mock *MongoDBMock) GetNextVersionCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	EditionID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+	}
+	lockMongoDBMockGetNextVersion.RLock()
+	calls = mock.calls.GetNextVersion
+	lockMongoDBMockGetNextVersion.RUnlock()
+	return calls
+}
+
+// GetPreviousPublishedVersion calls GetPreviousPublishedVersionFunc.
+func (mock *MongoDBMock) GetPreviousPublishedVersion(ctx context.Context, datasetID string, editionID string, version int) (*models.Version, error) {
+	if mock.GetPreviousPublishedVersionFunc == nil {
+		panic("MongoDBMock.GetPreviousPublishedVersionFunc: method is nil but MongoDB.GetPreviousPublishedVersion was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+		Version   int
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		EditionID: editionID,
+		Version:   version,
+	}
+	lockMongoDBMockGetPreviousPublishedVersion.Lock()
+	mock.calls.GetPreviousPublishedVersion = append(mock.calls.GetPreviousPublishedVersion, callInfo)
+	lockMongoDBMockGetPreviousPublishedVersion.Unlock()
+	return mock.GetPreviousPublishedVersionFunc(ctx, datasetID, editionID, version)
+}
+
+// GetPreviousPublishedVersionCalls gets all the calls that were made to GetPreviousPublishedVersion.
+// Check the length with:
+//     len(mockedMongoDB.GetPreviousPublishedVersionCalls())
+func (mock *MongoDBMock) GetPreviousPublishedVersionCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	EditionID string
+	Version   int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+		Version   int
+	}
+	lockMongoDBMockGetPreviousPublishedVersion.RLock()
+	calls = mock.calls.GetPreviousPublishedVersion
+	lockMongoDBMockGetPreviousPublishedVersion.RUnlock()
+	return calls
+}
+
+// GetTopic calls GetTopicFunc.
+func (mock *MongoDBMock) GetTopic(ctx context.Context, id string) (*models.Topic, error) {
+	if mock.GetTopicFunc == nil {
+		panic("MongoDBMock.GetTopicFunc: method is nil but MongoDB.GetTopic was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockMongoDBMockGetTopic.Lock()
+	mock.calls.GetTopic = append(mock.calls.GetTopic, callInfo)
+	lockMongoDBMockGetTopic.Unlock()
+	return mock.GetTopicFunc(ctx, id)
+}
+
+// GetTopicCalls gets all the calls that were made to GetTopic.
+// Check the length with:
+//     len(mockedMongoDB.GetTopicCalls())
+func (mock *MongoDBMock) GetTopicCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	lockMongoDBMockGetTopic.RLock()
+	calls = mock.calls.GetTopic
+	lockMongoDBMockGetTopic.RUnlock()
+	return calls
+}
+
+// GetTopicDatasets calls GetTopicDatasetsFunc.
+func (mock *MongoDBMock) GetTopicDatasets(ctx context.Context, topicIDs []string, offset int, limit int) ([]*models.DatasetUpdate, int, error) {
+	if mock.GetTopicDatasetsFunc == nil {
+		panic("MongoDBMock.GetTopicDatasetsFunc: method is nil but MongoDB.GetTopicDatasets was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		TopicIDs []string
+		Offset   int
+		Limit    int
+	}{
+		Ctx:      ctx,
+		TopicIDs: topicIDs,
+		Offset:   offset,
+		Limit:    limit,
+	}
+	lockMongoDBMockGetTopicDatasets.Lock()
+	mock.calls.GetTopicDatasets = append(mock.calls.GetTopicDatasets, callInfo)
+	lockMongoDBMockGetTopicDatasets.Unlock()
+	return mock.GetTopicDatasetsFunc(ctx, topicIDs, offset, limit)
+}
+
+// GetTopicDatasetsCalls gets all the calls that were made to GetTopicDatasets.
+// Check the length with:
+//     len(mockedMongoDB.GetTopicDatasetsCalls())
+func (mock *MongoDBMock) GetTopicDatasetsCalls() []struct {
+	Ctx      context.Context
+	TopicIDs []string
+	Offset   int
+	Limit    int
+} {
+	var calls []struct {
+		Ctx      context.Context
+		TopicIDs []string
+		Offset   int
+		Limit    int
+	}
+	lockMongoDBMockGetTopicDatasets.RLock()
+	calls = mock.calls.GetTopicDatasets
+	lockMongoDBMockGetTopicDatasets.RUnlock()
+	return calls
+}
+
+// GetTopics calls GetTopicsFunc.
+func (mock *MongoDBMock) GetTopics(ctx context.Context) ([]*models.Topic, error) {
+	if mock.GetTopicsFunc == nil {
+		panic("MongoDBMock.GetTopicsFunc: method is nil but MongoDB.GetTopics was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockMongoDBMockGetTopics.Lock()
+	mock.calls.GetTopics = append(mock.calls.GetTopics, callInfo)
+	lockMongoDBMockGetTopics.Unlock()
+	return mock.GetTopicsFunc(ctx)
+}
+
+// GetTopicsCalls gets all the calls that were made to GetTopics.
+// Check the length with:
+//     len(mockedMongoDB.GetTopicsCalls())
+func (mock *MongoDBMock) GetTopicsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockMongoDBMockGetTopics.RLock()
+	calls = mock.calls.GetTopics
+	lockMongoDBMockGetTopics.RUnlock()
+	return calls
+}
+
+// GetTopicsPage calls GetTopicsPageFunc.
+func (mock *MongoDBMock) GetTopicsPage(ctx context.Context, offset int, limit int) ([]*models.Topic, int, error) {
+	if mock.GetTopicsPageFunc == nil {
+		panic("MongoDBMock.GetTopicsPageFunc: method is nil but MongoDB.GetTopicsPage was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Offset: offset,
+		Limit:  limit,
+	}
+	lockMongoDBMockGetTopicsPage.Lock()
+	mock.calls.GetTopicsPage = append(mock.calls.GetTopicsPage, callInfo)
+	lockMongoDBMockGetTopicsPage.Unlock()
+	return mock.GetTopicsPageFunc(ctx, offset, limit)
+}
+
+// GetTopicsPageCalls gets all the calls that were made to GetTopicsPage.
+// Check the length with:
+//     len(mockedMongoDB.GetTopicsPageCalls())
+func (mock *MongoDBMock) GetTopicsPageCalls() []struct {
+	Ctx    context.Context
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}
+	lockMongoDBMockGetTopicsPage.RLock()
+	calls = mock.calls.GetTopicsPage
+	lockMongoDBMockGetTopicsPage.RUnlock()
+	return calls
+}
+
+// GetUniqueDimensionAndOptions calls GetUniqueDimensionAndOptionsFunc.
+func (mock *MongoDBMock) GetUniqueDimensionAndOptions(ctx context.Context, ID string, dimension string, offset int, limit int) ([]*string, int, error) {
+	if mock.GetUniqueDimensionAndOptionsFunc == nil {
+		panic("MongoDBMock.GetUniqueDimensionAndOptionsFunc: method is nil but MongoDB.GetUniqueDimensionAndOptions was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ID        string
 		Dimension string
 		Offset    int
 		Limit     int
@@ -1483,135 +3087,541 @@ func (mock *MongoDBMock) GetUniqueDimensionAndOptionsCalls() []struct {
 	Limit     int
 } {
 	var calls []struct {
-		Ctx       context.Context
-		ID        string
-		Dimension string
-		Offset    int
-		Limit     int
+		Ctx       context.Context
+		ID        string
+		Dimension string
+		Offset    int
+		Limit     int
+	}
+	lockMongoDBMockGetUniqueDimensionAndOptions.RLock()
+	calls = mock.calls.GetUniqueDimensionAndOptions
+	lockMongoDBMockGetUniqueDimensionAndOptions.RUnlock()
+	return calls
+}
+
+// GetVersion calls GetVersionFunc.
+func (mock *MongoDBMock) GetVersion(ctx context.Context, datasetID string, editionID string, version int, state string) (*models.Version, error) {
+	if mock.GetVersionFunc == nil {
+		panic("MongoDBMock.GetVersionFunc: method is nil but MongoDB.GetVersion was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+		Version   int
+		State     string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		EditionID: editionID,
+		Version:   version,
+		State:     state,
+	}
+	lockMongoDBMockGetVersion.Lock()
+	mock.calls.GetVersion = append(mock.calls.GetVersion, callInfo)
+	lockMongoDBMockGetVersion.Unlock()
+	return mock.GetVersionFunc(ctx, datasetID, editionID, version, state)
+}
+
+// GetVersionCalls gets all the calls that were made to GetVersion.
+// Check the length with:
+//     len(mockedMongoDB.GetVersionCalls())
+func (mock *MongoDBMock) GetVersionCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	EditionID string
+	Version   int
+	State     string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+		Version   int
+		State     string
+	}
+	lockMongoDBMockGetVersion.RLock()
+	calls = mock.calls.GetVersion
+	lockMongoDBMockGetVersion.RUnlock()
+	return calls
+}
+
+// GetVersions calls GetVersionsFunc.
+func (mock *MongoDBMock) GetVersions(ctx context.Context, datasetID string, editionID string, state string, offset int, limit int) ([]models.Version, int, error) {
+	if mock.GetVersionsFunc == nil {
+		panic("MongoDBMock.GetVersionsFunc: method is nil but MongoDB.GetVersions was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+		State     string
+		Offset    int
+		Limit     int
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+		EditionID: editionID,
+		State:     state,
+		Offset:    offset,
+		Limit:     limit,
+	}
+	lockMongoDBMockGetVersions.Lock()
+	mock.calls.GetVersions = append(mock.calls.GetVersions, callInfo)
+	lockMongoDBMockGetVersions.Unlock()
+	return mock.GetVersionsFunc(ctx, datasetID, editionID, state, offset, limit)
+}
+
+// GetVersionsCalls gets all the calls that were made to GetVersions.
+// Check the length with:
+//     len(mockedMongoDB.GetVersionsCalls())
+func (mock *MongoDBMock) GetVersionsCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+	EditionID string
+	State     string
+	Offset    int
+	Limit     int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+		EditionID string
+		State     string
+		Offset    int
+		Limit     int
+	}
+	lockMongoDBMockGetVersions.RLock()
+	calls = mock.calls.GetVersions
+	lockMongoDBMockGetVersions.RUnlock()
+	return calls
+}
+
+// GetVersionsInCollections calls GetVersionsInCollectionsFunc.
+func (mock *MongoDBMock) GetVersionsInCollections(ctx context.Context) ([]models.Version, error) {
+	if mock.GetVersionsInCollectionsFunc == nil {
+		panic("MongoDBMock.GetVersionsInCollectionsFunc: method is nil but MongoDB.GetVersionsInCollections was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockMongoDBMockGetVersionsInCollections.Lock()
+	mock.calls.GetVersionsInCollections = append(mock.calls.GetVersionsInCollections, callInfo)
+	lockMongoDBMockGetVersionsInCollections.Unlock()
+	return mock.GetVersionsInCollectionsFunc(ctx)
+}
+
+// GetVersionsInCollectionsCalls gets all the calls that were made to GetVersionsInCollections.
+// Check the length with:
+//     len(mockedMongoDB.GetVersionsInCollectionsCalls())
+func (mock *MongoDBMock) GetVersionsInCollectionsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockMongoDBMockGetVersionsInCollections.RLock()
+	calls = mock.calls.GetVersionsInCollections
+	lockMongoDBMockGetVersionsInCollections.RUnlock()
+	return calls
+}
+
+// LockLinkCheck calls LockLinkCheckFunc.
+func (mock *MongoDBMock) LockLinkCheck(ctx context.Context, ttl time.Duration) (string, error) {
+	if mock.LockLinkCheckFunc == nil {
+		panic("MongoDBMock.LockLinkCheckFunc: method is nil but MongoDB.LockLinkCheck was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		TTL time.Duration
+	}{
+		Ctx: ctx,
+		TTL: ttl,
+	}
+	lockMongoDBMockLockLinkCheck.Lock()
+	mock.calls.LockLinkCheck = append(mock.calls.LockLinkCheck, callInfo)
+	lockMongoDBMockLockLinkCheck.Unlock()
+	return mock.LockLinkCheckFunc(ctx, ttl)
+}
+
+// LockLinkCheckCalls gets all the calls that were made to LockLinkCheck.
+// Check the length with:
+//     len(mockedMongoDB.LockLinkCheckCalls())
+func (mock *MongoDBMock) LockLinkCheckCalls() []struct {
+	Ctx context.Context
+	TTL time.Duration
+} {
+	var calls []struct {
+		Ctx context.Context
+		TTL time.Duration
+	}
+	lockMongoDBMockLockLinkCheck.RLock()
+	calls = mock.calls.LockLinkCheck
+	lockMongoDBMockLockLinkCheck.RUnlock()
+	return calls
+}
+
+// LockPurge calls LockPurgeFunc.
+func (mock *MongoDBMock) LockPurge(ctx context.Context, ttl time.Duration) (string, error) {
+	if mock.LockPurgeFunc == nil {
+		panic("MongoDBMock.LockPurgeFunc: method is nil but MongoDB.LockPurge was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		TTL time.Duration
+	}{
+		Ctx: ctx,
+		TTL: ttl,
+	}
+	lockMongoDBMockLockPurge.Lock()
+	mock.calls.LockPurge = append(mock.calls.LockPurge, callInfo)
+	lockMongoDBMockLockPurge.Unlock()
+	return mock.LockPurgeFunc(ctx, ttl)
+}
+
+// LockPurgeCalls gets all the calls that were made to LockPurge.
+// Check the length with:
+//     len(mockedMongoDB.LockPurgeCalls())
+func (mock *MongoDBMock) LockPurgeCalls() []struct {
+	Ctx context.Context
+	TTL time.Duration
+} {
+	var calls []struct {
+		Ctx context.Context
+		TTL time.Duration
+	}
+	lockMongoDBMockLockPurge.RLock()
+	calls = mock.calls.LockPurge
+	lockMongoDBMockLockPurge.RUnlock()
+	return calls
+}
+
+// MigrateLinks calls MigrateLinksFunc.
+func (mock *MongoDBMock) MigrateLinks(ctx context.Context, apiURL string) (int, error) {
+	if mock.MigrateLinksFunc == nil {
+		panic("MongoDBMock.MigrateLinksFunc: method is nil but MongoDB.MigrateLinks was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ApiURL string
+	}{
+		Ctx:    ctx,
+		ApiURL: apiURL,
+	}
+	lockMongoDBMockMigrateLinks.Lock()
+	mock.calls.MigrateLinks = append(mock.calls.MigrateLinks, callInfo)
+	lockMongoDBMockMigrateLinks.Unlock()
+	return mock.MigrateLinksFunc(ctx, apiURL)
+}
+
+// MigrateLinksCalls gets all the calls that were made to MigrateLinks.
+// Check the length with:
+//     len(mockedMongoDB.MigrateLinksCalls())
+func (mock *MongoDBMock) MigrateLinksCalls() []struct {
+	Ctx    context.Context
+	ApiURL string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ApiURL string
+	}
+	lockMongoDBMockMigrateLinks.RLock()
+	calls = mock.calls.MigrateLinks
+	lockMongoDBMockMigrateLinks.RUnlock()
+	return calls
+}
+
+// RenewLinkCheckLock calls RenewLinkCheckLockFunc.
+func (mock *MongoDBMock) RenewLinkCheckLock(ctx context.Context, lockID string, ttl time.Duration) error {
+	if mock.RenewLinkCheckLockFunc == nil {
+		panic("MongoDBMock.RenewLinkCheckLockFunc: method is nil but MongoDB.RenewLinkCheckLock was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		LockID string
+		TTL    time.Duration
+	}{
+		Ctx:    ctx,
+		LockID: lockID,
+		TTL:    ttl,
+	}
+	lockMongoDBMockRenewLinkCheckLock.Lock()
+	mock.calls.RenewLinkCheckLock = append(mock.calls.RenewLinkCheckLock, callInfo)
+	lockMongoDBMockRenewLinkCheckLock.Unlock()
+	return mock.RenewLinkCheckLockFunc(ctx, lockID, ttl)
+}
+
+// RenewLinkCheckLockCalls gets all the calls that were made to RenewLinkCheckLock.
+// Check the length with:
+//     len(mockedMongoDB.RenewLinkCheckLockCalls())
+func (mock *MongoDBMock) RenewLinkCheckLockCalls() []struct {
+	Ctx    context.Context
+	LockID string
+	TTL    time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		LockID string
+		TTL    time.Duration
+	}
+	lockMongoDBMockRenewLinkCheckLock.RLock()
+	calls = mock.calls.RenewLinkCheckLock
+	lockMongoDBMockRenewLinkCheckLock.RUnlock()
+	return calls
+}
+
+// RenewPurgeLock calls RenewPurgeLockFunc.
+func (mock *MongoDBMock) RenewPurgeLock(ctx context.Context, lockID string, ttl time.Duration) error {
+	if mock.RenewPurgeLockFunc == nil {
+		panic("MongoDBMock.RenewPurgeLockFunc: method is nil but MongoDB.RenewPurgeLock was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		LockID string
+		TTL    time.Duration
+	}{
+		Ctx:    ctx,
+		LockID: lockID,
+		TTL:    ttl,
+	}
+	lockMongoDBMockRenewPurgeLock.Lock()
+	mock.calls.RenewPurgeLock = append(mock.calls.RenewPurgeLock, callInfo)
+	lockMongoDBMockRenewPurgeLock.Unlock()
+	return mock.RenewPurgeLockFunc(ctx, lockID, ttl)
+}
+
+// RenewPurgeLockCalls gets all the calls that were made to RenewPurgeLock.
+// Check the length with:
+//     len(mockedMongoDB.RenewPurgeLockCalls())
+func (mock *MongoDBMock) RenewPurgeLockCalls() []struct {
+	Ctx    context.Context
+	LockID string
+	TTL    time.Duration
+} {
+	var calls []struct {
+		Ctx    context.Context
+		LockID string
+		TTL    time.Duration
+	}
+	lockMongoDBMockRenewPurgeLock.RLock()
+	calls = mock.calls.RenewPurgeLock
+	lockMongoDBMockRenewPurgeLock.RUnlock()
+	return calls
+}
+
+// RestoreDataset calls RestoreDatasetFunc.
+func (mock *MongoDBMock) RestoreDataset(ctx context.Context, datasetID string) error {
+	if mock.RestoreDatasetFunc == nil {
+		panic("MongoDBMock.RestoreDatasetFunc: method is nil but MongoDB.RestoreDataset was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DatasetID string
+	}{
+		Ctx:       ctx,
+		DatasetID: datasetID,
+	}
+	lockMongoDBMockRestoreDataset.Lock()
+	mock.calls.RestoreDataset = append(mock.calls.RestoreDataset, callInfo)
+	lockMongoDBMockRestoreDataset.Unlock()
+	return mock.RestoreDatasetFunc(ctx, datasetID)
+}
+
+// RestoreDatasetCalls gets all the calls that were made to RestoreDataset.
+// Check the length with:
+//     len(mockedMongoDB.RestoreDatasetCalls())
+func (mock *MongoDBMock) RestoreDatasetCalls() []struct {
+	Ctx       context.Context
+	DatasetID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DatasetID string
+	}
+	lockMongoDBMockRestoreDataset.RLock()
+	calls = mock.calls.RestoreDataset
+	lockMongoDBMockRestoreDataset.RUnlock()
+	return calls
+}
+
+// RetryImportTasks calls RetryImportTasksFunc.
+func (mock *MongoDBMock) RetryImportTasks(ctx context.Context, currentInstance *models.Instance, state string, tasks *models.InstanceImportTasks, event *models.Event, eTagSelector string) (string, error) {
+	if mock.RetryImportTasksFunc == nil {
+		panic("MongoDBMock.RetryImportTasksFunc: method is nil but MongoDB.RetryImportTasks was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		CurrentInstance *models.Instance
+		State           string
+		Tasks           *models.InstanceImportTasks
+		Event           *models.Event
+		ETagSelector    string
+	}{
+		Ctx:             ctx,
+		CurrentInstance: currentInstance,
+		State:           state,
+		Tasks:           tasks,
+		Event:           event,
+		ETagSelector:    eTagSelector,
+	}
+	lockMongoDBMockRetryImportTasks.Lock()
+	mock.calls.RetryImportTasks = append(mock.calls.RetryImportTasks, callInfo)
+	lockMongoDBMockRetryImportTasks.Unlock()
+	return mock.RetryImportTasksFunc(ctx, currentInstance, state, tasks, event, eTagSelector)
+}
+
+// RetryImportTasksCalls gets all the calls that were made to RetryImportTasks.
+// Check the length with:
+//     len(mockedMongoDB.RetryImportTasksCalls())
+func (mock *MongoDBMock) RetryImportTasksCalls() []struct {
+	Ctx             context.Context
+	CurrentInstance *models.Instance
+	State           string
+	Tasks           *models.InstanceImportTasks
+	Event           *models.Event
+	ETagSelector    string
+} {
+	var calls []struct {
+		Ctx             context.Context
+		CurrentInstance *models.Instance
+		State           string
+		Tasks           *models.InstanceImportTasks
+		Event           *models.Event
+		ETagSelector    string
 	}
-	lockMongoDBMockGetUniqueDimensionAndOptions.RLock()
-	calls = mock.calls.GetUniqueDimensionAndOptions
-	lockMongoDBMockGetUniqueDimensionAndOptions.RUnlock()
+	lockMongoDBMockRetryImportTasks.RLock()
+	calls = mock.calls.RetryImportTasks
+	lockMongoDBMockRetryImportTasks.RUnlock()
 	return calls
 }
 
-// GetVersion calls GetVersionFunc.
-func (mock *MongoDBMock) GetVersion(datasetID string, editionID string, version int, state string) (*models.Version, error) {
-	if mock.GetVersionFunc == nil {
-		panic("MongoDBMock.GetVersionFunc: method is nil but MongoDB.GetVersion was just called")
+// SoftDeleteDataset calls SoftDeleteDatasetFunc.
+func (mock *MongoDBMock) SoftDeleteDataset(ctx context.Context, datasetID string, eTagSelector string, deletedBy string, deletedAt time.Time) error {
+	if mock.SoftDeleteDatasetFunc == nil {
+		panic("MongoDBMock.SoftDeleteDatasetFunc: method is nil but MongoDB.SoftDeleteDataset was just called")
 	}
 	callInfo := struct {
-		DatasetID string
-		EditionID string
-		Version   int
-		State     string
+		Ctx          context.Context
+		DatasetID    string
+		ETagSelector string
+		DeletedBy    string
+		DeletedAt    time.Time
 	}{
-		DatasetID: datasetID,
-		EditionID: editionID,
-		Version:   version,
-		State:     state,
+		Ctx:          ctx,
+		DatasetID:    datasetID,
+		ETagSelector: eTagSelector,
+		DeletedBy:    deletedBy,
+		DeletedAt:    deletedAt,
 	}
-	lockMongoDBMockGetVersion.Lock()
-	mock.calls.GetVersion = append(mock.calls.GetVersion, callInfo)
-	lockMongoDBMockGetVersion.Unlock()
-	return mock.GetVersionFunc(datasetID, editionID, version, state)
+	lockMongoDBMockSoftDeleteDataset.Lock()
+	mock.calls.SoftDeleteDataset = append(mock.calls.SoftDeleteDataset, callInfo)
+	lockMongoDBMockSoftDeleteDataset.Unlock()
+	return mock.SoftDeleteDatasetFunc(ctx, datasetID, eTagSelector, deletedBy, deletedAt)
 }
 
-// GetVersionCalls gets all the calls that were made to GetVersion.
+// SoftDeleteDatasetCalls gets all the calls that were made to SoftDeleteDataset.
 // Check the length with:
-//     len(mockedMongoDB.GetVersionCalls())
-func (mock *MongoDBMock) GetVersionCalls() []struct {
-	DatasetID string
-	EditionID string
-	Version   int
-	State     string
+//     len(mockedMongoDB.SoftDeleteDatasetCalls())
+func (mock *MongoDBMock) SoftDeleteDatasetCalls() []struct {
+	Ctx          context.Context
+	DatasetID    string
+	ETagSelector string
+	DeletedBy    string
+	DeletedAt    time.Time
 } {
 	var calls []struct {
-		DatasetID string
-		EditionID string
-		Version   int
-		State     string
+		Ctx          context.Context
+		DatasetID    string
+		ETagSelector string
+		DeletedBy    string
+		DeletedAt    time.Time
 	}
-	lockMongoDBMockGetVersion.RLock()
-	calls = mock.calls.GetVersion
-	lockMongoDBMockGetVersion.RUnlock()
+	lockMongoDBMockSoftDeleteDataset.RLock()
+	calls = mock.calls.SoftDeleteDataset
+	lockMongoDBMockSoftDeleteDataset.RUnlock()
 	return calls
 }
 
-// GetVersions calls GetVersionsFunc.
-func (mock *MongoDBMock) GetVersions(ctx context.Context, datasetID string, editionID string, state string, offset int, limit int) ([]models.Version, int, error) {
-	if mock.GetVersionsFunc == nil {
-		panic("MongoDBMock.GetVersionsFunc: method is nil but MongoDB.GetVersions was just called")
+// SoftDeleteEdition calls SoftDeleteEditionFunc.
+func (mock *MongoDBMock) SoftDeleteEdition(ctx context.Context, datasetID string, edition string, eTagSelector string, deletedBy string, deletedAt time.Time) error {
+	if mock.SoftDeleteEditionFunc == nil {
+		panic("MongoDBMock.SoftDeleteEditionFunc: method is nil but MongoDB.SoftDeleteEdition was just called")
 	}
 	callInfo := struct {
-		Ctx       context.Context
-		DatasetID string
-		EditionID string
-		State     string
-		Offset    int
-		Limit     int
+		Ctx          context.Context
+		DatasetID    string
+		Edition      string
+		ETagSelector string
+		DeletedBy    string
+		DeletedAt    time.Time
 	}{
-		Ctx:       ctx,
-		DatasetID: datasetID,
-		EditionID: editionID,
-		State:     state,
-		Offset:    offset,
-		Limit:     limit,
+		Ctx:          ctx,
+		DatasetID:    datasetID,
+		Edition:      edition,
+		ETagSelector: eTagSelector,
+		DeletedBy:    deletedBy,
+		DeletedAt:    deletedAt,
 	}
-	lockMongoDBMockGetVersions.Lock()
-	mock.calls.GetVersions = append(mock.calls.GetVersions, callInfo)
-	lockMongoDBMockGetVersions.Unlock()
-	return mock.GetVersionsFunc(ctx, datasetID, editionID, state, offset, limit)
+	lockMongoDBMockSoftDeleteEdition.Lock()
+	mock.calls.SoftDeleteEdition = append(mock.calls.SoftDeleteEdition, callInfo)
+	lockMongoDBMockSoftDeleteEdition.Unlock()
+	return mock.SoftDeleteEditionFunc(ctx, datasetID, edition, eTagSelector, deletedBy, deletedAt)
 }
 
-// GetVersionsCalls gets all the calls that were made to GetVersions.
+// SoftDeleteEditionCalls gets all the calls that were made to SoftDeleteEdition.
 // Check the length with:
-//     len(mockedMongoDB.GetVersionsCalls())
-func (mock *MongoDBMock) GetVersionsCalls() []struct {
-	Ctx       context.Context
-	DatasetID string
-	EditionID string
-	State     string
-	Offset    int
-	Limit     int
+//     len(mockedMongoDB.SoftDeleteEditionCalls())
+func (mock *MongoDBMock) SoftDeleteEditionCalls() []struct {
+	Ctx          context.Context
+	DatasetID    string
+	Edition      string
+	ETagSelector string
+	DeletedBy    string
+	DeletedAt    time.Time
 } {
 	var calls []struct {
-		Ctx       context.Context
-		DatasetID string
-		EditionID string
-		State     string
-		Offset    int
-		Limit     int
+		Ctx          context.Context
+		DatasetID    string
+		Edition      string
+		ETagSelector string
+		DeletedBy    string
+		DeletedAt    time.Time
 	}
-	lockMongoDBMockGetVersions.RLock()
-	calls = mock.calls.GetVersions
-	lockMongoDBMockGetVersions.RUnlock()
+	lockMongoDBMockSoftDeleteEdition.RLock()
+	calls = mock.calls.SoftDeleteEdition
+	lockMongoDBMockSoftDeleteEdition.RUnlock()
 	return calls
 }
 
 // UnlockInstance calls UnlockInstanceFunc.
-func (mock *MongoDBMock) UnlockInstance(lockID string) error {
+func (mock *MongoDBMock) UnlockInstance(ctx context.Context, lockID string) error {
 	if mock.UnlockInstanceFunc == nil {
 		panic("MongoDBMock.UnlockInstanceFunc: method is nil but MongoDB.UnlockInstance was just called")
 	}
 	callInfo := struct {
+		Ctx    context.Context
 		LockID string
 	}{
+		Ctx:    ctx,
 		LockID: lockID,
 	}
 	lockMongoDBMockUnlockInstance.Lock()
 	mock.calls.UnlockInstance = append(mock.calls.UnlockInstance, callInfo)
 	lockMongoDBMockUnlockInstance.Unlock()
-	return mock.UnlockInstanceFunc(lockID)
+	return mock.UnlockInstanceFunc(ctx, lockID)
 }
 
 // UnlockInstanceCalls gets all the calls that were made to UnlockInstance.
 // Check the length with:
 //     len(mockedMongoDB.UnlockInstanceCalls())
 func (mock *MongoDBMock) UnlockInstanceCalls() []struct {
+	Ctx    context.Context
 	LockID string
 } {
 	var calls []struct {
+		Ctx    context.Context
 		LockID string
 	}
 	lockMongoDBMockUnlockInstance.RLock()
@@ -1620,17 +3630,89 @@ func (mock *MongoDBMock) UnlockInstanceCalls() []struct {
 	return calls
 }
 
+// UnlockLinkCheck calls UnlockLinkCheckFunc.
+func (mock *MongoDBMock) UnlockLinkCheck(ctx context.Context, lockID string) error {
+	if mock.UnlockLinkCheckFunc == nil {
+		panic("MongoDBMock.UnlockLinkCheckFunc: method is nil but MongoDB.UnlockLinkCheck was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		LockID string
+	}{
+		Ctx:    ctx,
+		LockID: lockID,
+	}
+	lockMongoDBMockUnlockLinkCheck.Lock()
+	mock.calls.UnlockLinkCheck = append(mock.calls.UnlockLinkCheck, callInfo)
+	lockMongoDBMockUnlockLinkCheck.Unlock()
+	return mock.UnlockLinkCheckFunc(ctx, lockID)
+}
+
+// UnlockLinkCheckCalls gets all the calls that were made to UnlockLinkCheck.
+// Check the length with:
+//     len(mockedMongoDB.UnlockLinkCheckCalls())
+func (mock *MongoDBMock) UnlockLinkCheckCalls() []struct {
+	Ctx    context.Context
+	LockID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		LockID string
+	}
+	lockMongoDBMockUnlockLinkCheck.RLock()
+	calls = mock.calls.UnlockLinkCheck
+	lockMongoDBMockUnlockLinkCheck.RUnlock()
+	return calls
+}
+
+// UnlockPurge calls UnlockPurgeFunc.
+func (mock *MongoDBMock) UnlockPurge(ctx context.Context, lockID string) error {
+	if mock.UnlockPurgeFunc == nil {
+		panic("MongoDBMock.UnlockPurgeFunc: method is nil but MongoDB.UnlockPurge was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		LockID string
+	}{
+		Ctx:    ctx,
+		LockID: lockID,
+	}
+	lockMongoDBMockUnlockPurge.Lock()
+	mock.calls.UnlockPurge = append(mock.calls.UnlockPurge, callInfo)
+	lockMongoDBMockUnlockPurge.Unlock()
+	return mock.UnlockPurgeFunc(ctx, lockID)
+}
+
+// UnlockPurgeCalls gets all the calls that were made to UnlockPurge.
+// Check the length with:
+//     len(mockedMongoDB.UnlockPurgeCalls())
+func (mock *MongoDBMock) UnlockPurgeCalls() []struct {
+	Ctx    context.Context
+	LockID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		LockID string
+	}
+	lockMongoDBMockUnlockPurge.RLock()
+	calls = mock.calls.UnlockPurge
+	lockMongoDBMockUnlockPurge.RUnlock()
+	return calls
+}
+
 // UpdateBuildHierarchyTaskState calls UpdateBuildHierarchyTaskStateFunc.
-func (mock *MongoDBMock) UpdateBuildHierarchyTaskState(currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
+func (mock *MongoDBMock) UpdateBuildHierarchyTaskState(ctx context.Context, currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
 	if mock.UpdateBuildHierarchyTaskStateFunc == nil {
 		panic("MongoDBMock.UpdateBuildHierarchyTaskStateFunc: method is nil but MongoDB.UpdateBuildHierarchyTaskState was just called")
 	}
 	callInfo := struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Dimension       string
 		State           string
 		ETagSelector    string
 	}{
+		Ctx:             ctx,
 		CurrentInstance: currentInstance,
 		Dimension:       dimension,
 		State:           state,
@@ -1639,19 +3721,21 @@ func (mock *MongoDBMock) UpdateBuildHierarchyTaskState(currentInstance *models.I
 	lockMongoDBMockUpdateBuildHierarchyTaskState.Lock()
 	mock.calls.UpdateBuildHierarchyTaskState = append(mock.calls.UpdateBuildHierarchyTaskState, callInfo)
 	lockMongoDBMockUpdateBuildHierarchyTaskState.Unlock()
-	return mock.UpdateBuildHierarchyTaskStateFunc(currentInstance, dimension, state, eTagSelector)
+	return mock.UpdateBuildHierarchyTaskStateFunc(ctx, currentInstance, dimension, state, eTagSelector)
 }
 
 // UpdateBuildHierarchyTaskStateCalls gets all the calls that were made to UpdateBuildHierarchyTaskState.
 // Check the length with:
 //     len(mockedMongoDB.UpdateBuildHierarchyTaskStateCalls())
 func (mock *MongoDBMock) UpdateBuildHierarchyTaskStateCalls() []struct {
+	Ctx             context.Context
 	CurrentInstance *models.Instance
 	Dimension       string
 	State           string
 	ETagSelector    string
 } {
 	var calls []struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Dimension       string
 		State           string
@@ -1664,16 +3748,18 @@ func (mock *MongoDBMock) UpdateBuildHierarchyTaskStateCalls() []struct {
 }
 
 // UpdateBuildSearchTaskState calls UpdateBuildSearchTaskStateFunc.
-func (mock *MongoDBMock) UpdateBuildSearchTaskState(currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
+func (mock *MongoDBMock) UpdateBuildSearchTaskState(ctx context.Context, currentInstance *models.Instance, dimension string, state string, eTagSelector string) (string, error) {
 	if mock.UpdateBuildSearchTaskStateFunc == nil {
 		panic("MongoDBMock.UpdateBuildSearchTaskStateFunc: method is nil but MongoDB.UpdateBuildSearchTaskState was just called")
 	}
 	callInfo := struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Dimension       string
 		State           string
 		ETagSelector    string
 	}{
+		Ctx:             ctx,
 		CurrentInstance: currentInstance,
 		Dimension:       dimension,
 		State:           state,
@@ -1682,19 +3768,21 @@ func (mock *MongoDBMock) UpdateBuildSearchTaskState(currentInstance *models.Inst
 	lockMongoDBMockUpdateBuildSearchTaskState.Lock()
 	mock.calls.UpdateBuildSearchTaskState = append(mock.calls.UpdateBuildSearchTaskState, callInfo)
 	lockMongoDBMockUpdateBuildSearchTaskState.Unlock()
-	return mock.UpdateBuildSearchTaskStateFunc(currentInstance, dimension, state, eTagSelector)
+	return mock.UpdateBuildSearchTaskStateFunc(ctx, currentInstance, dimension, state, eTagSelector)
 }
 
 // UpdateBuildSearchTaskStateCalls gets all the calls that were made to UpdateBuildSearchTaskState.
 // Check the length with:
 //     len(mockedMongoDB.UpdateBuildSearchTaskStateCalls())
 func (mock *MongoDBMock) UpdateBuildSearchTaskStateCalls() []struct {
+	Ctx             context.Context
 	CurrentInstance *models.Instance
 	Dimension       string
 	State           string
 	ETagSelector    string
 } {
 	var calls []struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Dimension       string
 		State           string
@@ -1707,41 +3795,41 @@ func (mock *MongoDBMock) UpdateBuildSearchTaskStateCalls() []struct {
 }
 
 // UpdateDataset calls UpdateDatasetFunc.
-func (mock *MongoDBMock) UpdateDataset(ctx context.Context, ID string, dataset *models.Dataset, currentState string) error {
+func (mock *MongoDBMock) UpdateDataset(ctx context.Context, currentDataset *models.DatasetUpdate, dataset *models.Dataset, eTagSelector string) (string, error) {
 	if mock.UpdateDatasetFunc == nil {
 		panic("MongoDBMock.UpdateDatasetFunc: method is nil but MongoDB.UpdateDataset was just called")
 	}
 	callInfo := struct {
-		Ctx          context.Context
-		ID           string
-		Dataset      *models.Dataset
-		CurrentState string
+		Ctx            context.Context
+		CurrentDataset *models.DatasetUpdate
+		Dataset        *models.Dataset
+		ETagSelector   string
 	}{
-		Ctx:          ctx,
-		ID:           ID,
-		Dataset:      dataset,
-		CurrentState: currentState,
+		Ctx:            ctx,
+		CurrentDataset: currentDataset,
+		Dataset:        dataset,
+		ETagSelector:   eTagSelector,
 	}
 	lockMongoDBMockUpdateDataset.Lock()
 	mock.calls.UpdateDataset = append(mock.calls.UpdateDataset, callInfo)
 	lockMongoDBMockUpdateDataset.Unlock()
-	return mock.UpdateDatasetFunc(ctx, ID, dataset, currentState)
+	return mock.UpdateDatasetFunc(ctx, currentDataset, dataset, eTagSelector)
 }
 
 // UpdateDatasetCalls gets all the calls that were made to UpdateDataset.
 // Check the length with:
 //     len(mockedMongoDB.UpdateDatasetCalls())
 func (mock *MongoDBMock) UpdateDatasetCalls() []struct {
-	Ctx          context.Context
-	ID           string
-	Dataset      *models.Dataset
-	CurrentState string
+	Ctx            context.Context
+	CurrentDataset *models.DatasetUpdate
+	Dataset        *models.Dataset
+	ETagSelector   string
 } {
 	var calls []struct {
-		Ctx          context.Context
-		ID           string
-		Dataset      *models.Dataset
-		CurrentState string
+		Ctx            context.Context
+		CurrentDataset *models.DatasetUpdate
+		Dataset        *models.Dataset
+		ETagSelector   string
 	}
 	lockMongoDBMockUpdateDataset.RLock()
 	calls = mock.calls.UpdateDataset
@@ -1750,37 +3838,41 @@ func (mock *MongoDBMock) UpdateDatasetCalls() []struct {
 }
 
 // UpdateDatasetWithAssociation calls UpdateDatasetWithAssociationFunc.
-func (mock *MongoDBMock) UpdateDatasetWithAssociation(ID string, state string, version *models.Version) error {
+func (mock *MongoDBMock) UpdateDatasetWithAssociation(ctx context.Context, currentDataset *models.DatasetUpdate, state string, version *models.Version) error {
 	if mock.UpdateDatasetWithAssociationFunc == nil {
 		panic("MongoDBMock.UpdateDatasetWithAssociationFunc: method is nil but MongoDB.UpdateDatasetWithAssociation was just called")
 	}
 	callInfo := struct {
-		ID      string
-		State   string
-		Version *models.Version
+		Ctx            context.Context
+		CurrentDataset *models.DatasetUpdate
+		State          string
+		Version        *models.Version
 	}{
-		ID:      ID,
-		State:   state,
-		Version: version,
+		Ctx:            ctx,
+		CurrentDataset: currentDataset,
+		State:          state,
+		Version:        version,
 	}
 	lockMongoDBMockUpdateDatasetWithAssociation.Lock()
 	mock.calls.UpdateDatasetWithAssociation = append(mock.calls.UpdateDatasetWithAssociation, callInfo)
 	lockMongoDBMockUpdateDatasetWithAssociation.Unlock()
-	return mock.UpdateDatasetWithAssociationFunc(ID, state, version)
+	return mock.UpdateDatasetWithAssociationFunc(ctx, currentDataset, state, version)
 }
 
 // UpdateDatasetWithAssociationCalls gets all the calls that were made to UpdateDatasetWithAssociation.
 // Check the length with:
 //     len(mockedMongoDB.UpdateDatasetWithAssociationCalls())
 func (mock *MongoDBMock) UpdateDatasetWithAssociationCalls() []struct {
-	ID      string
-	State   string
-	Version *models.Version
+	Ctx            context.Context
+	CurrentDataset *models.DatasetUpdate
+	State          string
+	Version        *models.Version
 } {
 	var calls []struct {
-		ID      string
-		State   string
-		Version *models.Version
+		Ctx            context.Context
+		CurrentDataset *models.DatasetUpdate
+		State          string
+		Version        *models.Version
 	}
 	lockMongoDBMockUpdateDatasetWithAssociation.RLock()
 	calls = mock.calls.UpdateDatasetWithAssociation
@@ -1788,29 +3880,68 @@ func (mock *MongoDBMock) UpdateDatasetWithAssociationCalls() []struct {
 	return calls
 }
 
+// UpdateDeleteJob calls UpdateDeleteJobFunc.
+func (mock *MongoDBMock) UpdateDeleteJob(ctx context.Context, job *models.DeleteJob) error {
+	if mock.UpdateDeleteJobFunc == nil {
+		panic("MongoDBMock.UpdateDeleteJobFunc: method is nil but MongoDB.UpdateDeleteJob was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Job *models.DeleteJob
+	}{
+		Ctx: ctx,
+		Job: job,
+	}
+	lockMongoDBMockUpdateDeleteJob.Lock()
+	mock.calls.UpdateDeleteJob = append(mock.calls.UpdateDeleteJob, callInfo)
+	lockMongoDBMockUpdateDeleteJob.Unlock()
+	return mock.UpdateDeleteJobFunc(ctx, job)
+}
+
+// UpdateDeleteJobCalls gets all the calls that were made to UpdateDeleteJob.
+// Check the length with:
+//     len(mockedMongoDB.UpdateDeleteJobCalls())
+func (mock *MongoDBMock) UpdateDeleteJobCalls() []struct {
+	Ctx context.Context
+	Job *models.DeleteJob
+} {
+	var calls []struct {
+		Ctx context.Context
+		Job *models.DeleteJob
+	}
+	lockMongoDBMockUpdateDeleteJob.RLock()
+	calls = mock.calls.UpdateDeleteJob
+	lockMongoDBMockUpdateDeleteJob.RUnlock()
+	return calls
+}
+
 // UpdateDimensionNodeIDAndOrder calls UpdateDimensionNodeIDAndOrderFunc.
-func (mock *MongoDBMock) UpdateDimensionNodeIDAndOrder(dimension *models.DimensionOption) error {
+func (mock *MongoDBMock) UpdateDimensionNodeIDAndOrder(ctx context.Context, dimension *models.DimensionOption) error {
 	if mock.UpdateDimensionNodeIDAndOrderFunc == nil {
 		panic("MongoDBMock.UpdateDimensionNodeIDAndOrderFunc: method is nil but MongoDB.UpdateDimensionNodeIDAndOrder was just called")
 	}
 	callInfo := struct {
+		Ctx       context.Context
 		Dimension *models.DimensionOption
 	}{
+		Ctx:       ctx,
 		Dimension: dimension,
 	}
 	lockMongoDBMockUpdateDimensionNodeIDAndOrder.Lock()
 	mock.calls.UpdateDimensionNodeIDAndOrder = append(mock.calls.UpdateDimensionNodeIDAndOrder, callInfo)
 	lockMongoDBMockUpdateDimensionNodeIDAndOrder.Unlock()
-	return mock.UpdateDimensionNodeIDAndOrderFunc(dimension)
+	return mock.UpdateDimensionNodeIDAndOrderFunc(ctx, dimension)
 }
 
 // UpdateDimensionNodeIDAndOrderCalls gets all the calls that were made to UpdateDimensionNodeIDAndOrder.
 // Check the length with:
 //     len(mockedMongoDB.UpdateDimensionNodeIDAndOrderCalls())
 func (mock *MongoDBMock) UpdateDimensionNodeIDAndOrderCalls() []struct {
+	Ctx       context.Context
 	Dimension *models.DimensionOption
 } {
 	var calls []struct {
+		Ctx       context.Context
 		Dimension *models.DimensionOption
 	}
 	lockMongoDBMockUpdateDimensionNodeIDAndOrder.RLock()
@@ -1820,16 +3951,18 @@ func (mock *MongoDBMock) UpdateDimensionNodeIDAndOrderCalls() []struct {
 }
 
 // UpdateETagForNodeIDAndOrder calls UpdateETagForNodeIDAndOrderFunc.
-func (mock *MongoDBMock) UpdateETagForNodeIDAndOrder(currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
+func (mock *MongoDBMock) UpdateETagForNodeIDAndOrder(ctx context.Context, currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (string, error) {
 	if mock.UpdateETagForNodeIDAndOrderFunc == nil {
 		panic("MongoDBMock.UpdateETagForNodeIDAndOrderFunc: method is nil but MongoDB.UpdateETagForNodeIDAndOrder was just called")
 	}
 	callInfo := struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		NodeID          string
 		Order           *int
 		ETagSelector    string
 	}{
+		Ctx:             ctx,
 		CurrentInstance: currentInstance,
 		NodeID:          nodeID,
 		Order:           order,
@@ -1838,19 +3971,21 @@ func (mock *MongoDBMock) UpdateETagForNodeIDAndOrder(currentInstance *models.Ins
 	lockMongoDBMockUpdateETagForNodeIDAndOrder.Lock()
 	mock.calls.UpdateETagForNodeIDAndOrder = append(mock.calls.UpdateETagForNodeIDAndOrder, callInfo)
 	lockMongoDBMockUpdateETagForNodeIDAndOrder.Unlock()
-	return mock.UpdateETagForNodeIDAndOrderFunc(currentInstance, nodeID, order, eTagSelector)
+	return mock.UpdateETagForNodeIDAndOrderFunc(ctx, currentInstance, nodeID, order, eTagSelector)
 }
 
 // UpdateETagForNodeIDAndOrderCalls gets all the calls that were made to UpdateETagForNodeIDAndOrder.
 // Check the length with:
 //     len(mockedMongoDB.UpdateETagForNodeIDAndOrderCalls())
 func (mock *MongoDBMock) UpdateETagForNodeIDAndOrderCalls() []struct {
+	Ctx             context.Context
 	CurrentInstance *models.Instance
 	NodeID          string
 	Order           *int
 	ETagSelector    string
 } {
 	var calls []struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		NodeID          string
 		Order           *int
@@ -1863,15 +3998,17 @@ func (mock *MongoDBMock) UpdateETagForNodeIDAndOrderCalls() []struct {
 }
 
 // UpdateETagForOptions calls UpdateETagForOptionsFunc.
-func (mock *MongoDBMock) UpdateETagForOptions(currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error) {
+func (mock *MongoDBMock) UpdateETagForOptions(ctx context.Context, currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (string, error) {
 	if mock.UpdateETagForOptionsFunc == nil {
 		panic("MongoDBMock.UpdateETagForOptionsFunc: method is nil but MongoDB.UpdateETagForOptions was just called")
 	}
 	callInfo := struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Option          *models.CachedDimensionOption
 		ETagSelector    string
 	}{
+		Ctx:             ctx,
 		CurrentInstance: currentInstance,
 		Option:          option,
 		ETagSelector:    eTagSelector,
@@ -1879,18 +4016,20 @@ func (mock *MongoDBMock) UpdateETagForOptions(currentInstance *models.Instance,
 	lockMongoDBMockUpdateETagForOptions.Lock()
 	mock.calls.UpdateETagForOptions = append(mock.calls.UpdateETagForOptions, callInfo)
 	lockMongoDBMockUpdateETagForOptions.Unlock()
-	return mock.UpdateETagForOptionsFunc(currentInstance, option, eTagSelector)
+	return mock.UpdateETagForOptionsFunc(ctx, currentInstance, option, eTagSelector)
 }
 
 // UpdateETagForOptionsCalls gets all the calls that were made to UpdateETagForOptions.
 // Check the length with:
 //     len(mockedMongoDB.UpdateETagForOptionsCalls())
 func (mock *MongoDBMock) UpdateETagForOptionsCalls() []struct {
+	Ctx             context.Context
 	CurrentInstance *models.Instance
 	Option          *models.CachedDimensionOption
 	ETagSelector    string
 } {
 	var calls []struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		Option          *models.CachedDimensionOption
 		ETagSelector    string
@@ -1902,15 +4041,17 @@ func (mock *MongoDBMock) UpdateETagForOptionsCalls() []struct {
 }
 
 // UpdateImportObservationsTaskState calls UpdateImportObservationsTaskStateFunc.
-func (mock *MongoDBMock) UpdateImportObservationsTaskState(currentInstance *models.Instance, state string, eTagSelector string) (string, error) {
+func (mock *MongoDBMock) UpdateImportObservationsTaskState(ctx context.Context, currentInstance *models.Instance, state string, eTagSelector string) (string, error) {
 	if mock.UpdateImportObservationsTaskStateFunc == nil {
 		panic("MongoDBMock.UpdateImportObservationsTaskStateFunc: method is nil but MongoDB.UpdateImportObservationsTaskState was just called")
 	}
 	callInfo := struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		State           string
 		ETagSelector    string
 	}{
+		Ctx:             ctx,
 		CurrentInstance: currentInstance,
 		State:           state,
 		ETagSelector:    eTagSelector,
@@ -1918,18 +4059,20 @@ func (mock *MongoDBMock) UpdateImportObservationsTaskState(currentInstance *mode
 	lockMongoDBMockUpdateImportObservationsTaskState.Lock()
 	mock.calls.UpdateImportObservationsTaskState = append(mock.calls.UpdateImportObservationsTaskState, callInfo)
 	lockMongoDBMockUpdateImportObservationsTaskState.Unlock()
-	return mock.UpdateImportObservationsTaskStateFunc(currentInstance, state, eTagSelector)
+	return mock.UpdateImportObservationsTaskStateFunc(ctx, currentInstance, state, eTagSelector)
 }
 
 // UpdateImportObservationsTaskStateCalls gets all the calls that were made to UpdateImportObservationsTaskState.
 // Check the length with:
 //     len(mockedMongoDB.UpdateImportObservationsTaskStateCalls())
 func (mock *MongoDBMock) UpdateImportObservationsTaskStateCalls() []struct {
+	Ctx             context.Context
 	CurrentInstance *models.Instance
 	State           string
 	ETagSelector    string
 } {
 	var calls []struct {
+		Ctx             context.Context
 		CurrentInstance *models.Instance
 		State           string
 		ETagSelector    string
@@ -1984,15 +4127,17 @@ func (mock *MongoDBMock) UpdateInstanceCalls() []struct {
 }
 
 // UpdateObservationInserted calls UpdateObservationInsertedFunc.
-func (mock *MongoDBMock) UpdateObservationInserted(currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error) {
+func (mock *MongoDBMock) UpdateObservationInserted(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (string, error) {
 	if mock.UpdateObservationInsertedFunc == nil {
 		panic("MongoDBMock.UpdateObservationInsertedFunc: method is nil but MongoDB.UpdateObservationInserted was just called")
 	}
 	callInfo := struct {
+		Ctx                 context.Context
 		CurrentInstance     *models.Instance
 		ObservationInserted int64
 		ETagSelector        string
 	}{
+		Ctx:                 ctx,
 		CurrentInstance:     currentInstance,
 		ObservationInserted: observationInserted,
 		ETagSelector:        eTagSelector,
@@ -2000,18 +4145,20 @@ func (mock *MongoDBMock) UpdateObservationInserted(currentInstance *models.Insta
 	lockMongoDBMockUpdateObservationInserted.Lock()
 	mock.calls.UpdateObservationInserted = append(mock.calls.UpdateObservationInserted, callInfo)
 	lockMongoDBMockUpdateObservationInserted.Unlock()
-	return mock.UpdateObservationInsertedFunc(currentInstance, observationInserted, eTagSelector)
+	return mock.UpdateObservationInsertedFunc(ctx, currentInstance, observationInserted, eTagSelector)
 }
 
 // UpdateObservationInsertedCalls gets all the calls that were made to UpdateObservationInserted.
 // Check the length with:
 //     len(mockedMongoDB.UpdateObservationInsertedCalls())
 func (mock *MongoDBMock) UpdateObservationInsertedCalls() []struct {
+	Ctx                 context.Context
 	CurrentInstance     *models.Instance
 	ObservationInserted int64
 	ETagSelector        string
 } {
 	var calls []struct {
+		Ctx                 context.Context
 		CurrentInstance     *models.Instance
 		ObservationInserted int64
 		ETagSelector        string
@@ -2023,33 +4170,41 @@ func (mock *MongoDBMock) UpdateObservationInsertedCalls() []struct {
 }
 
 // UpdateVersion calls UpdateVersionFunc.
-func (mock *MongoDBMock) UpdateVersion(ID string, version *models.Version) error {
+func (mock *MongoDBMock) UpdateVersion(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (string, error) {
 	if mock.UpdateVersionFunc == nil {
 		panic("MongoDBMock.UpdateVersionFunc: method is nil but MongoDB.UpdateVersion was just called")
 	}
 	callInfo := struct {
-		ID      string
-		Version *models.Version
+		Ctx            context.Context
+		CurrentVersion *models.Version
+		Version        *models.Version
+		ETagSelector   string
 	}{
-		ID:      ID,
-		Version: version,
+		Ctx:            ctx,
+		CurrentVersion: currentVersion,
+		Version:        version,
+		ETagSelector:   eTagSelector,
 	}
 	lockMongoDBMockUpdateVersion.Lock()
 	mock.calls.UpdateVersion = append(mock.calls.UpdateVersion, callInfo)
 	lockMongoDBMockUpdateVersion.Unlock()
-	return mock.UpdateVersionFunc(ID, version)
+	return mock.UpdateVersionFunc(ctx, currentVersion, version, eTagSelector)
 }
 
 // UpdateVersionCalls gets all the calls that were made to UpdateVersion.
 // Check the length with:
 //     len(mockedMongoDB.UpdateVersionCalls())
 func (mock *MongoDBMock) UpdateVersionCalls() []struct {
-	ID      string
-	Version *models.Version
+	Ctx            context.Context
+	CurrentVersion *models.Version
+	Version        *models.Version
+	ETagSelector   string
 } {
 	var calls []struct {
-		ID      string
-		Version *models.Version
+		Ctx            context.Context
+		CurrentVersion *models.Version
+		Version        *models.Version
+		ETagSelector   string
 	}
 	lockMongoDBMockUpdateVersion.RLock()
 	calls = mock.calls.UpdateVersion
@@ -2058,31 +4213,35 @@ func (mock *MongoDBMock) UpdateVersionCalls() []struct {
 }
 
 // UpsertContact calls UpsertContactFunc.
-func (mock *MongoDBMock) UpsertContact(ID string, update interface{}) error {
+func (mock *MongoDBMock) UpsertContact(ctx context.Context, ID string, update interface{}) error {
 	if mock.UpsertContactFunc == nil {
 		panic("MongoDBMock.UpsertContactFunc: method is nil but MongoDB.UpsertContact was just called")
 	}
 	callInfo := struct {
+		Ctx    context.Context
 		ID     string
 		Update interface{}
 	}{
+		Ctx:    ctx,
 		ID:     ID,
 		Update: update,
 	}
 	lockMongoDBMockUpsertContact.Lock()
 	mock.calls.UpsertContact = append(mock.calls.UpsertContact, callInfo)
 	lockMongoDBMockUpsertContact.Unlock()
-	return mock.UpsertContactFunc(ID, update)
+	return mock.UpsertContactFunc(ctx, ID, update)
 }
 
 // UpsertContactCalls gets all the calls that were made to UpsertContact.
 // Check the length with:
 //     len(mockedMongoDB.UpsertContactCalls())
 func (mock *MongoDBMock) UpsertContactCalls() []struct {
+	Ctx    context.Context
 	ID     string
 	Update interface{}
 } {
 	var calls []struct {
+		Ctx    context.Context
 		ID     string
 		Update interface{}
 	}
@@ -2093,33 +4252,41 @@ func (mock *MongoDBMock) UpsertContactCalls() []struct {
 }
 
 // UpsertDataset calls UpsertDatasetFunc.
-func (mock *MongoDBMock) UpsertDataset(ID string, datasetDoc *models.DatasetUpdate) error {
+func (mock *MongoDBMock) UpsertDataset(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate, eTagSelector string) error {
 	if mock.UpsertDatasetFunc == nil {
 		panic("MongoDBMock.UpsertDatasetFunc: method is nil but MongoDB.UpsertDataset was just called")
 	}
 	callInfo := struct {
-		ID         string
-		DatasetDoc *models.DatasetUpdate
+		Ctx          context.Context
+		ID           string
+		DatasetDoc   *models.DatasetUpdate
+		ETagSelector string
 	}{
-		ID:         ID,
-		DatasetDoc: datasetDoc,
+		Ctx:          ctx,
+		ID:           ID,
+		DatasetDoc:   datasetDoc,
+		ETagSelector: eTagSelector,
 	}
 	lockMongoDBMockUpsertDataset.Lock()
 	mock.calls.UpsertDataset = append(mock.calls.UpsertDataset, callInfo)
 	lockMongoDBMockUpsertDataset.Unlock()
-	return mock.UpsertDatasetFunc(ID, datasetDoc)
+	return mock.UpsertDatasetFunc(ctx, ID, datasetDoc, eTagSelector)
 }
 
 // UpsertDatasetCalls gets all the calls that were made to UpsertDataset.
 // Check the length with:
 //     len(mockedMongoDB.UpsertDatasetCalls())
 func (mock *MongoDBMock) UpsertDatasetCalls() []struct {
-	ID         string
-	DatasetDoc *models.DatasetUpdate
+	Ctx          context.Context
+	ID           string
+	DatasetDoc   *models.DatasetUpdate
+	ETagSelector string
 } {
 	var calls []struct {
-		ID         string
-		DatasetDoc *models.DatasetUpdate
+		Ctx          context.Context
+		ID           string
+		DatasetDoc   *models.DatasetUpdate
+		ETagSelector string
 	}
 	lockMongoDBMockUpsertDataset.RLock()
 	calls = mock.calls.UpsertDataset
@@ -2128,37 +4295,45 @@ func (mock *MongoDBMock) UpsertDatasetCalls() []struct {
 }
 
 // UpsertEdition calls UpsertEditionFunc.
-func (mock *MongoDBMock) UpsertEdition(datasetID string, edition string, editionDoc *models.EditionUpdate) error {
+func (mock *MongoDBMock) UpsertEdition(ctx context.Context, datasetID string, edition string, editionDoc *models.EditionUpdate, eTagSelector string) error {
 	if mock.UpsertEditionFunc == nil {
 		panic("MongoDBMock.UpsertEditionFunc: method is nil but MongoDB.UpsertEdition was just called")
 	}
 	callInfo := struct {
-		DatasetID  string
-		Edition    string
-		EditionDoc *models.EditionUpdate
+		Ctx          context.Context
+		DatasetID    string
+		Edition      string
+		EditionDoc   *models.EditionUpdate
+		ETagSelector string
 	}{
-		DatasetID:  datasetID,
-		Edition:    edition,
-		EditionDoc: editionDoc,
+		Ctx:          ctx,
+		DatasetID:    datasetID,
+		Edition:      edition,
+		EditionDoc:   editionDoc,
+		ETagSelector: eTagSelector,
 	}
 	lockMongoDBMockUpsertEdition.Lock()
 	mock.calls.UpsertEdition = append(mock.calls.UpsertEdition, callInfo)
 	lockMongoDBMockUpsertEdition.Unlock()
-	return mock.UpsertEditionFunc(datasetID, edition, editionDoc)
+	return mock.UpsertEditionFunc(ctx, datasetID, edition, editionDoc, eTagSelector)
 }
 
 // UpsertEditionCalls gets all the calls that were made to UpsertEdition.
 // Check the length with:
 //     len(mockedMongoDB.UpsertEditionCalls())
 func (mock *MongoDBMock) UpsertEditionCalls() []struct {
-	DatasetID  string
-	Edition    string
-	EditionDoc *models.EditionUpdate
+	Ctx          context.Context
+	DatasetID    string
+	Edition      string
+	EditionDoc   *models.EditionUpdate
+	ETagSelector string
 } {
 	var calls []struct {
-		DatasetID  string
-		Edition    string
-		EditionDoc *models.EditionUpdate
+		Ctx          context.Context
+		DatasetID    string
+		Edition      string
+		EditionDoc   *models.EditionUpdate
+		ETagSelector string
 	}
 	lockMongoDBMockUpsertEdition.RLock()
 	calls = mock.calls.UpsertEdition
@@ -2166,32 +4341,106 @@ func (mock *MongoDBMock) UpsertEditionCalls() []struct {
 	return calls
 }
 
+// UpsertLinkReport calls UpsertLinkReportFunc.
+func (mock *MongoDBMock) UpsertLinkReport(ctx context.Context, report *models.LinkReport) error {
+	if mock.UpsertLinkReportFunc == nil {
+		panic("MongoDBMock.UpsertLinkReportFunc: method is nil but MongoDB.UpsertLinkReport was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Report *models.LinkReport
+	}{
+		Ctx:    ctx,
+		Report: report,
+	}
+	lockMongoDBMockUpsertLinkReport.Lock()
+	mock.calls.UpsertLinkReport = append(mock.calls.UpsertLinkReport, callInfo)
+	lockMongoDBMockUpsertLinkReport.Unlock()
+	return mock.UpsertLinkReportFunc(ctx, report)
+}
+
+// UpsertLinkReportCalls gets all the calls that were made to UpsertLinkReport.
+// Check the length with:
+//     len(mockedMongoDB.UpsertLinkReportCalls())
+func (mock *MongoDBMock) UpsertLinkReportCalls() []struct {
+	Ctx    context.Context
+	Report *models.LinkReport
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Report *models.LinkReport
+	}
+	lockMongoDBMockUpsertLinkReport.RLock()
+	calls = mock.calls.UpsertLinkReport
+	lockMongoDBMockUpsertLinkReport.RUnlock()
+	return calls
+}
+
+// UpsertTopic calls UpsertTopicFunc.
+func (mock *MongoDBMock) UpsertTopic(ctx context.Context, topic *models.Topic) error {
+	if mock.UpsertTopicFunc == nil {
+		panic("MongoDBMock.UpsertTopicFunc: method is nil but MongoDB.UpsertTopic was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Topic *models.Topic
+	}{
+		Ctx:   ctx,
+		Topic: topic,
+	}
+	lockMongoDBMockUpsertTopic.Lock()
+	mock.calls.UpsertTopic = append(mock.calls.UpsertTopic, callInfo)
+	lockMongoDBMockUpsertTopic.Unlock()
+	return mock.UpsertTopicFunc(ctx, topic)
+}
+
+// UpsertTopicCalls gets all the calls that were made to UpsertTopic.
+// Check the length with:
+//     len(mockedMongoDB.UpsertTopicCalls())
+func (mock *MongoDBMock) UpsertTopicCalls() []struct {
+	Ctx   context.Context
+	Topic *models.Topic
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Topic *models.Topic
+	}
+	lockMongoDBMockUpsertTopic.RLock()
+	calls = mock.calls.UpsertTopic
+	lockMongoDBMockUpsertTopic.RUnlock()
+	return calls
+}
+
 // UpsertVersion calls UpsertVersionFunc.
-func (mock *MongoDBMock) UpsertVersion(ID string, versionDoc *models.Version) error {
+func (mock *MongoDBMock) UpsertVersion(ctx context.Context, ID string, versionDoc *models.Version) error {
 	if mock.UpsertVersionFunc == nil {
 		panic("MongoDBMock.UpsertVersionFunc: method is nil but MongoDB.UpsertVersion was just called")
 	}
 	callInfo := struct {
+		Ctx        context.Context
 		ID         string
 		VersionDoc *models.Version
 	}{
+		Ctx:        ctx,
 		ID:         ID,
 		VersionDoc: versionDoc,
 	}
 	lockMongoDBMockUpsertVersion.Lock()
 	mock.calls.UpsertVersion = append(mock.calls.UpsertVersion, callInfo)
 	lockMongoDBMockUpsertVersion.Unlock()
-	return mock.UpsertVersionFunc(ID, versionDoc)
+	return mock.UpsertVersionFunc(ctx, ID, versionDoc)
 }
 
 // UpsertVersionCalls gets all the calls that were made to UpsertVersion.
 // Check the length with:
 //     len(mockedMongoDB.UpsertVersionCalls())
 func (mock *MongoDBMock) UpsertVersionCalls() []struct {
+	Ctx        context.Context
 	ID         string
 	VersionDoc *models.Version
 } {
 	var calls []struct {
+		Ctx        context.Context
 		ID         string
 		VersionDoc *models.Version
 	}
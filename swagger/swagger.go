@@ -0,0 +1,10 @@
+// Package swagger embeds the OpenAPI specification of the dataset API, so that it can be served and used to
+// validate requests by the binary without the specification being deployed alongside it.
+package swagger
+
+import _ "embed" // required to embed the specification
+
+// Spec is the swagger.yaml specification of the dataset API
+//
+//go:embed swagger.yaml
+var Spec []byte
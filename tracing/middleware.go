@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/dp-dataset-api/utils"
+	dprequest "github.com/ONSdigital/dp-net/request"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware starts a span for every request handled by the router, continuing any trace the caller sent in the
+// traceparent header, and named after the path template of the route the request matches. Every request is given the
+// trace ID as its request ID, so that the trace_id of the log events written while handling it is always the ID of its
+// trace. Where the caller sent a request ID of its own, it is recorded on the span, to find the trace it belongs to.
+func Middleware(router *mux.Router) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+			route := utils.RouteTemplate(router, r)
+			ctx, span := Tracer().Start(ctx, r.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(ServiceName, route, r)...),
+			)
+			defer span.End()
+
+			if requestID := r.Header.Get(dprequest.RequestHeaderKey); requestID != "" {
+				span.SetAttributes(attribute.String("request_id", requestID))
+			}
+			if traceID := TraceID(ctx); traceID != "" {
+				r.Header.Set(dprequest.RequestHeaderKey, traceID)
+			}
+
+			recorder := utils.NewStatusRecorder(w)
+			h.ServeHTTP(recorder, r.WithContext(ctx))
+
+			span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(recorder.Status)...)
+			span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(recorder.Status, trace.SpanKindServer))
+		})
+	}
+}
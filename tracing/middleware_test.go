@@ -0,0 +1,70 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dprequest "github.com/ONSdigital/dp-net/request"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMiddleware(t *testing.T) {
+	Convey("Given a router wrapped by the tracing middleware", t, func() {
+		exporter := useInMemoryExporter()
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+
+		var requestID, traceID string
+		router := mux.NewRouter()
+		router.HandleFunc("/datasets/{dataset_id}", func(w http.ResponseWriter, r *http.Request) {
+			requestID = r.Header.Get(dprequest.RequestHeaderKey)
+			traceID = TraceID(r.Context())
+			w.WriteHeader(http.StatusInternalServerError)
+		}).Methods(http.MethodGet)
+		handler := Middleware(router)(router)
+
+		Convey("When a request without a request ID is handled", func() {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/datasets/cpih01", nil))
+
+			Convey("Then a server span is recorded, named after the template of the route", func() {
+				spans := exporter.GetSpans()
+				So(spans, ShouldHaveLength, 1)
+				So(spans[0].Name, ShouldEqual, "GET /datasets/{dataset_id}")
+				So(spans[0].SpanKind, ShouldEqual, trace.SpanKindServer)
+				So(spans[0].Status.Code, ShouldEqual, codes.Error)
+			})
+
+			Convey("And the trace ID is used as the request ID, so that it is logged", func() {
+				So(traceID, ShouldNotBeEmpty)
+				So(requestID, ShouldEqual, traceID)
+			})
+		})
+
+		Convey("When a request is sent with a request ID and the trace context of the caller", func() {
+			r := httptest.NewRequest(http.MethodGet, "/datasets/cpih01", nil)
+			r.Header.Set(dprequest.RequestHeaderKey, "abc123")
+			r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			Convey("Then the span continues the trace of the caller, and records the request ID of the caller", func() {
+				spans := exporter.GetSpans()
+				So(spans, ShouldHaveLength, 1)
+				So(spans[0].SpanContext.TraceID().String(), ShouldEqual, "4bf92f3577b34da6a3ce929d0e0e4736")
+				So(spans[0].Parent.SpanID().String(), ShouldEqual, "00f067aa0ba902b7")
+				So(spans[0].Attributes, ShouldContain, attribute.String("request_id", "abc123"))
+			})
+
+			Convey("And the trace ID is used as the request ID, so that it is logged", func() {
+				So(traceID, ShouldEqual, "4bf92f3577b34da6a3ce929d0e0e4736")
+				So(requestID, ShouldEqual, traceID)
+			})
+		})
+	})
+}
@@ -0,0 +1,260 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// tracesPath is the path an OpenTelemetry collector receives spans on
+const tracesPath = "/v1/traces"
+
+// OTLPExporter sends spans to an OpenTelemetry collector as the JSON encoding of OTLP over http
+type OTLPExporter struct {
+	url    string
+	client *http.Client
+}
+
+// NewOTLPExporter creates an exporter which sends spans to the collector at the endpoint, e.g. http://localhost:4318
+func NewOTLPExporter(endpoint string, client *http.Client) *OTLPExporter {
+	return &OTLPExporter{
+		url:    strings.TrimSuffix(endpoint, "/") + tracesPath,
+		client: client,
+	}
+}
+
+// ExportSpans sends a batch of spans to the collector
+func (e *OTLPExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(newExportRequest(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d exporting %d spans to %s", resp.StatusCode, len(spans), e.url)
+	}
+	return nil
+}
+
+// Shutdown has nothing to release, as each batch is sent in its own request
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// The JSON encoding of the OTLP ExportTraceServiceRequest. Trace and span IDs are hex encoded, and 64 bit integers
+// are encoded as strings.
+type (
+	exportRequest struct {
+		ResourceSpans []resourceSpans `json:"resourceSpans"`
+	}
+
+	resourceSpans struct {
+		Resource   otlpResource `json:"resource"`
+		ScopeSpans []scopeSpans `json:"scopeSpans"`
+	}
+
+	otlpResource struct {
+		Attributes []keyValue `json:"attributes"`
+	}
+
+	scopeSpans struct {
+		Scope scope      `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+
+	scope struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+
+	otlpSpan struct {
+		TraceID           string      `json:"traceId"`
+		SpanID            string      `json:"spanId"`
+		ParentSpanID      string      `json:"parentSpanId,omitempty"`
+		Name              string      `json:"name"`
+		Kind              int         `json:"kind"`
+		StartTimeUnixNano string      `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string      `json:"endTimeUnixNano"`
+		Attributes        []keyValue  `json:"attributes,omitempty"`
+		Events            []spanEvent `json:"events,omitempty"`
+		Status            spanStatus  `json:"status"`
+	}
+
+	spanEvent struct {
+		TimeUnixNano string     `json:"timeUnixNano"`
+		Name         string     `json:"name"`
+		Attributes   []keyValue `json:"attributes,omitempty"`
+	}
+
+	spanStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+
+	keyValue struct {
+		Key   string   `json:"key"`
+		Value anyValue `json:"value"`
+	}
+
+	anyValue struct {
+		StringValue *string     `json:"stringValue,omitempty"`
+		BoolValue   *bool       `json:"boolValue,omitempty"`
+		IntValue    *string     `json:"intValue,omitempty"`
+		DoubleValue *float64    `json:"doubleValue,omitempty"`
+		ArrayValue  *arrayValue `json:"arrayValue,omitempty"`
+	}
+
+	arrayValue struct {
+		Values []anyValue `json:"values"`
+	}
+)
+
+// The OTLP status codes, which are numbered differently to the status codes of the API
+const (
+	otlpStatusUnset = 0
+	otlpStatusOK    = 1
+	otlpStatusError = 2
+)
+
+// newExportRequest groups the spans by their resource and the instrumentation which created them
+func newExportRequest(spans []sdktrace.ReadOnlySpan) exportRequest {
+	var request exportRequest
+	resources := make(map[attribute.Distinct]int)
+	scopes := make(map[attribute.Distinct]map[string]int)
+
+	for _, span := range spans {
+		key := span.Resource().Equivalent()
+		r, ok := resources[key]
+		if !ok {
+			r = len(request.ResourceSpans)
+			resources[key] = r
+			scopes[key] = make(map[string]int)
+			request.ResourceSpans = append(request.ResourceSpans, resourceSpans{
+				Resource: otlpResource{Attributes: keyValues(span.Resource().Attributes())},
+			})
+		}
+
+		library := span.InstrumentationLibrary()
+		s, ok := scopes[key][library.Name]
+		if !ok {
+			s = len(request.ResourceSpans[r].ScopeSpans)
+			scopes[key][library.Name] = s
+			request.ResourceSpans[r].ScopeSpans = append(request.ResourceSpans[r].ScopeSpans, scopeSpans{
+				Scope: scope{Name: library.Name, Version: library.Version},
+			})
+		}
+
+		request.ResourceSpans[r].ScopeSpans[s].Spans = append(request.ResourceSpans[r].ScopeSpans[s].Spans, newSpan(span))
+	}
+
+	return request
+}
+
+func newSpan(span sdktrace.ReadOnlySpan) otlpSpan {
+	s := otlpSpan{
+		TraceID:           span.SpanContext().TraceID().String(),
+		SpanID:            span.SpanContext().SpanID().String(),
+		Name:              span.Name(),
+		Kind:              int(span.SpanKind()),
+		StartTimeUnixNano: strconv.FormatInt(span.StartTime().UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.EndTime().UnixNano(), 10),
+		Attributes:        keyValues(span.Attributes()),
+		Status:            spanStatus{Code: otlpStatusUnset},
+	}
+	if span.Parent().HasSpanID() {
+		s.ParentSpanID = span.Parent().SpanID().String()
+	}
+
+	switch span.Status().Code {
+	case codes.Ok:
+		s.Status.Code = otlpStatusOK
+	case codes.Error:
+		s.Status = spanStatus{Code: otlpStatusError, Message: span.Status().Description}
+	}
+
+	for _, event := range span.Events() {
+		s.Events = append(s.Events, spanEvent{
+			TimeUnixNano: strconv.FormatInt(event.Time.UnixNano(), 10),
+			Name:         event.Name,
+			Attributes:   keyValues(event.Attributes),
+		})
+	}
+
+	return s
+}
+
+func keyValues(attributes []attribute.KeyValue) []keyValue {
+	values := make([]keyValue, 0, len(attributes))
+	for _, a := range attributes {
+		values = append(values, keyValue{Key: string(a.Key), Value: newAnyValue(a.Value)})
+	}
+	return values
+}
+
+func newAnyValue(v attribute.Value) anyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		b := v.AsBool()
+		return anyValue{BoolValue: &b}
+	case attribute.INT64:
+		i := strconv.FormatInt(v.AsInt64(), 10)
+		return anyValue{IntValue: &i}
+	case attribute.FLOAT64:
+		f := v.AsFloat64()
+		return anyValue{DoubleValue: &f}
+	case attribute.BOOLSLICE:
+		array := arrayValue{}
+		for _, b := range v.AsBoolSlice() {
+			array.Values = append(array.Values, newAnyValue(attribute.BoolValue(b)))
+		}
+		return anyValue{ArrayValue: &array}
+	case attribute.INT64SLICE:
+		array := arrayValue{}
+		for _, i := range v.AsInt64Slice() {
+			array.Values = append(array.Values, newAnyValue(attribute.Int64Value(i)))
+		}
+		return anyValue{ArrayValue: &array}
+	case attribute.FLOAT64SLICE:
+		array := arrayValue{}
+		for _, f := range v.AsFloat64Slice() {
+			array.Values = append(array.Values, newAnyValue(attribute.Float64Value(f)))
+		}
+		return anyValue{ArrayValue: &array}
+	case attribute.STRINGSLICE:
+		array := arrayValue{}
+		for _, s := range v.AsStringSlice() {
+			array.Values = append(array.Values, newAnyValue(attribute.StringValue(s)))
+		}
+		return anyValue{ArrayValue: &array}
+	default:
+		s := v.Emit()
+		return anyValue{StringValue: &s}
+	}
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	Convey("Given a collector receiving spans", t, func() {
+		var path string
+		var received exportRequest
+		status := http.StatusOK
+		collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			body, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(body, &received)
+			w.WriteHeader(status)
+		}))
+		defer collector.Close()
+
+		recorded := tracetest.NewInMemoryExporter()
+		provider := NewTracerProvider(recorded, sdktrace.AlwaysSample())
+		ctx, parent := provider.Tracer("test").Start(context.Background(), "GET /datasets/{dataset_id}")
+		_, child := provider.Tracer("test").Start(ctx, "mongo.GetDataset")
+		child.SetAttributes(attribute.String("dataset_id", "cpih01"), attribute.Int("version", 2))
+		End(child, errors.New("connection reset"))
+		parent.End()
+		provider.ForceFlush(context.Background())
+		spans := recorded.GetSpans().Snapshots()
+
+		exporter := NewOTLPExporter(collector.URL+"/", collector.Client())
+
+		Convey("When the spans are exported", func() {
+			err := exporter.ExportSpans(context.Background(), spans)
+
+			Convey("Then they are sent to the traces path of the collector, grouped by resource and scope", func() {
+				So(err, ShouldBeNil)
+				So(path, ShouldEqual, tracesPath)
+				So(received.ResourceSpans, ShouldHaveLength, 1)
+				So(received.ResourceSpans[0].Resource.Attributes, ShouldContain, keyValue{Key: "service.name", Value: stringValue(ServiceName)})
+				So(received.ResourceSpans[0].ScopeSpans, ShouldHaveLength, 1)
+				So(received.ResourceSpans[0].ScopeSpans[0].Scope.Name, ShouldEqual, "test")
+				So(received.ResourceSpans[0].ScopeSpans[0].Spans, ShouldHaveLength, 2)
+			})
+
+			Convey("And the IDs, attributes and status of each span are encoded as OTLP expects", func() {
+				sent := received.ResourceSpans[0].ScopeSpans[0].Spans[0]
+				So(sent.Name, ShouldEqual, "mongo.GetDataset")
+				So(sent.TraceID, ShouldEqual, spans[0].SpanContext().TraceID().String())
+				So(sent.ParentSpanID, ShouldEqual, spans[1].SpanContext().SpanID().String())
+				So(sent.Attributes, ShouldContain, keyValue{Key: "dataset_id", Value: stringValue("cpih01")})
+				So(*sent.Attributes[1].Value.IntValue, ShouldEqual, "2")
+				So(sent.Status, ShouldResemble, spanStatus{Code: otlpStatusError, Message: "connection reset"})
+				So(sent.Events, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the collector rejects the spans", func() {
+			status = http.StatusBadRequest
+			err := exporter.ExportSpans(context.Background(), spans)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func stringValue(s string) anyValue {
+	return anyValue{StringValue: &s}
+}
@@ -0,0 +1,584 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	"github.com/ONSdigital/dp-dataset-api/store"
+	"github.com/globalsign/mgo/bson"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentMongoDB wraps a mongo store, so that each of the store methods used by the handlers is recorded as a span
+// of the trace in the context it is called with
+func InstrumentMongoDB(db store.MongoDB) store.MongoDB {
+	if db == nil {
+		return nil
+	}
+	return &mongoDB{MongoDB: db}
+}
+
+// InstrumentGraphDB wraps a graph store, so that each of its methods is recorded as a span. The graph store is only
+// used by the publishing instance, so is left nil when it has not been created.
+func InstrumentGraphDB(db store.GraphDB) store.GraphDB {
+	if db == nil {
+		return nil
+	}
+	return &graphDB{GraphDB: db}
+}
+
+func startMongoSpan(ctx context.Context, operation string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	attributes = append(attributes, semconv.DBSystemMongoDB, semconv.DBOperationKey.String(operation))
+	return Tracer().Start(ctx, "mongo."+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+}
+
+func startGraphSpan(ctx context.Context, operation string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	attributes = append(attributes, semconv.DBOperationKey.String(operation))
+	return Tracer().Start(ctx, "graph."+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+}
+
+// end ends the span of a store method. Resources which are not found, and conflicting updates, are answered by the
+// handlers rather than being failures of the store, so are recorded as events rather than errors.
+func end(span trace.Span, err *error) {
+	if *err != nil && (errs.NotFoundMap[*err] || errs.ConflictRequestMap[*err]) {
+		span.AddEvent((*err).Error())
+		span.End()
+		return
+	}
+	End(span, *err)
+}
+
+// mongoDB records a span for each of the store methods of the embedded mongo store. The methods used to manage the
+// connection are not recorded.
+type mongoDB struct {
+	store.MongoDB
+}
+
+func (m *mongoDB) AddDimensionToInstance(ctx context.Context, dimension *models.CachedDimensionOption) (err error) {
+	ctx, span := startMongoSpan(ctx, "AddDimensionToInstance")
+	defer end(span, &err)
+	return m.MongoDB.AddDimensionToInstance(ctx, dimension)
+}
+
+func (m *mongoDB) AddEventToInstance(ctx context.Context, currentInstance *models.Instance, event *models.Event, eTagSelector string) (newETag string, err error) {
+	ctx, span := startMongoSpan(ctx, "AddEventToInstance")
+	defer end(span, &err)
+	return m.MongoDB.AddEventToInstance(ctx, currentInstance, event, eTagSelector)
+}
+
+func (m *mongoDB) AddInstance(ctx context.Context, instance *models.Instance) (added *models.Instance, err error) {
+	ctx, span := startMongoSpan(ctx, "AddInstance")
+	defer end(span, &err)
+	return m.MongoDB.AddInstance(ctx, instance)
+}
+
+func (m *mongoDB) CheckDatasetExists(ctx context.Context, ID, state string) (err error) {
+	ctx, span := startMongoSpan(ctx, "CheckDatasetExists", attribute.String("dataset_id", ID))
+	defer end(span, &err)
+	return m.MongoDB.CheckDatasetExists(ctx, ID, state)
+}
+
+func (m *mongoDB) CheckEditionExists(ctx context.Context, ID, editionID, state string) (err error) {
+	ctx, span := startMongoSpan(ctx, "CheckEditionExists",
+		attribute.String("dataset_id", ID),
+		attribute.String("edition", editionID),
+	)
+	defer end(span, &err)
+	return m.MongoDB.CheckEditionExists(ctx, ID, editionID, state)
+}
+
+func (m *mongoDB) GetDataset(ctx context.Context, ID string) (dataset *models.DatasetUpdate, err error) {
+	ctx, span := startMongoSpan(ctx, "GetDataset", attribute.String("dataset_id", ID))
+	defer end(span, &err)
+	return m.MongoDB.GetDataset(ctx, ID)
+}
+
+func (m *mongoDB) GetDatasets(ctx context.Context, offset, limit int, authorised bool) (datasets []*models.DatasetUpdate, totalCount int, err error) {
+	ctx, span := startMongoSpan(ctx, "GetDatasets")
+	defer end(span, &err)
+	return m.MongoDB.GetDatasets(ctx, offset, limit, authorised)
+}
+
+func (m *mongoDB) GetDatasetsByIDs(ctx context.Context, ids []string) (datasets []*models.DatasetUpdate, err error) {
+	ctx, span := startMongoSpan(ctx, "GetDatasetsByIDs")
+	defer end(span, &err)
+	return m.MongoDB.GetDatasetsByIDs(ctx, ids)
+}
+
+func (m *mongoDB) GetDimensionsFromInstance(ctx context.Context, ID string, offset, limit int) (options []*models.DimensionOption, totalCount int, err error) {
+	ctx, span := startMongoSpan(ctx, "GetDimensionsFromInstance", attribute.String("instance_id", ID))
+	defer end(span, &err)
+	return m.MongoDB.GetDimensionsFromInstance(ctx, ID, offset, limit)
+}
+
+func (m *mongoDB) GetDimensions(ctx context.Context, datasetID, versionID string) (dimensions []bson.M, err error) {
+	ctx, span := startMongoSpan(ctx, "GetDimensions", attribute.String("dataset_id", datasetID))
+	defer end(span, &err)
+	return m.MongoDB.GetDimensions(ctx, datasetID, versionID)
+}
+
+func (m *mongoDB) GetDimensionSummaries(ctx context.Context, instanceID string) (summaries []*models.DimensionSummary, err error) {
+	ctx, span := startMongoSpan(ctx, "GetDimensionSummaries", attribute.String("instance_id", instanceID))
+	defer end(span, &err)
+	return m.MongoDB.GetDimensionSummaries(ctx, instanceID)
+}
+
+func (m *mongoDB) GetDimensionOptions(ctx context.Context, version *models.Version, dimension string, offset, limit int) (options []*models.PublicDimensionOption, totalCount int, err error) {
+	ctx, span := startMongoSpan(ctx, "GetDimensionOptions", attribute.String("dimension", dimension))
+	defer end(span, &err)
+	return m.MongoDB.GetDimensionOptions(ctx, version, dimension, offset, limit)
+}
+
+func (m *mongoDB) GetDimensionOptionsFromIDs(ctx context.Context, version *models.Version, dimension string, ids []string) (options []*models.PublicDimensionOption, totalCount int, err error) {
+	ctx, span := startMongoSpan(ctx, "GetDimensionOptionsFromIDs", attribute.String("dimension", dimension))
+	defer end(span, &err)
+	return m.MongoDB.GetDimensionOptionsFromIDs(ctx, version, dimension, ids)
+}
+
+func (m *mongoDB) GetEdition(ctx context.Context, ID, editionID, state string) (edition *models.EditionUpdate, err error) {
+	ctx, span := startMongoSpan(ctx, "GetEdition",
+		attribute.String("dataset_id", ID),
+		attribute.String("edition", editionID),
+	)
+	defer end(span, &err)
+	return m.MongoDB.GetEdition(ctx, ID, editionID, state)
+}
+
+func (m *mongoDB) GetEditions(ctx context.Context, ID, state string, offset, limit int, authorised bool) (editions []*models.EditionUpdate, totalCount int, err error) {
+	ctx, span := startMongoSpan(ctx, "GetEditions", attribute.String("dataset_id", ID))
+	defer end(span, &err)
+	return m.MongoDB.GetEditions(ctx, ID, state, offset, limit, authorised)
+}
+
+func (m *mongoDB) GetInstances(ctx context.Context, states []string, datasets []string, offset, limit int) (instances []*models.Instance, totalCount int, err error) {
+	ctx, span := startMongoSpan(ctx, "GetInstances")
+	defer end(span, &err)
+	return m.MongoDB.GetInstances(ctx, states, datasets, offset, limit)
+}
+
+func (m *mongoDB) GetInstance(ctx context.Context, ID, eTagSelector string) (instance *models.Instance, err error) {
+	ctx, span := startMongoSpan(ctx, "GetInstance", attribute.String("instance_id", ID))
+	defer end(span, &err)
+	return m.MongoDB.GetInstance(ctx, ID, eTagSelector)
+}
+
+func (m *mongoDB) GetNextVersion(ctx context.Context, datasetID, editionID string) (version int, err error) {
+	ctx, span := startMongoSpan(ctx, "GetNextVersion",
+		attribute.String("dataset_id", datasetID),
+		attribute.String("edition", editionID),
+	)
+	defer end(span, &err)
+	return m.MongoDB.GetNextVersion(ctx, datasetID, editionID)
+}
+
+func (m *mongoDB) GetPreviousPublishedVersion(ctx context.Context, datasetID, editionID string, version int) (versionDoc *models.Version, err error) {
+	ctx, span := startMongoSpan(ctx, "GetPreviousPublishedVersion",
+		attribute.String("dataset_id", datasetID),
+		attribute.String("edition", editionID),
+		attribute.Int("version", version),
+	)
+	defer end(span, &err)
+	return m.MongoDB.GetPreviousPublishedVersion(ctx, datasetID, editionID, version)
+}
+
+func (m *mongoDB) GetTopics(ctx context.Context) (topics []*models.Topic, err error) {
+	ctx, span := startMongoSpan(ctx, "GetTopics")
+	defer end(span, &err)
+	return m.MongoDB.GetTopics(ctx)
+}
+
+func (m *mongoDB) GetTopicsPage(ctx context.Context, offset, limit int) (topics []*models.Topic, totalCount int, err error) {
+	ctx, span := startMongoSpan(ctx, "GetTopicsPage")
+	defer end(span, &err)
+	return m.MongoDB.GetTopicsPage(ctx, offset, limit)
+}
+
+func (m *mongoDB) GetTopic(ctx context.Context, id string) (topic *models.Topic, err error) {
+	ctx, span := startMongoSpan(ctx, "GetTopic")
+	defer end(span, &err)
+	return m.MongoDB.GetTopic(ctx, id)
+}
+
+func (m *mongoDB) GetTopicDatasets(ctx context.Context, topicIDs []string, offset, limit int) (datasets []*models.DatasetUpdate, totalCount int, err error) {
+	ctx, span := startMongoSpan(ctx, "GetTopicDatasets")
+	defer end(span, &err)
+	return m.MongoDB.GetTopicDatasets(ctx, topicIDs, offset, limit)
+}
+
+func (m *mongoDB) CountTopicDatasets(ctx context.Context, id string) (count int, err error) {
+	ctx, span := startMongoSpan(ctx, "CountTopicDatasets")
+	defer end(span, &err)
+	return m.MongoDB.CountTopicDatasets(ctx, id)
+}
+
+func (m *mongoDB) GetDatasetsRelatedTo(ctx context.Context, datasetID string) (datasets []*models.DatasetUpdate, err error) {
+	ctx, span := startMongoSpan(ctx, "GetDatasetsRelatedTo", attribute.String("dataset_id", datasetID))
+	defer end(span, &err)
+	return m.MongoDB.GetDatasetsRelatedTo(ctx, datasetID)
+}
+
+func (m *mongoDB) GetLinkReports(ctx context.Context, offset, limit int) (reports []*models.LinkReport, totalCount int, err error) {
+	ctx, span := startMongoSpan(ctx, "GetLinkReports")
+	defer end(span, &err)
+	return m.MongoDB.GetLinkReports(ctx, offset, limit)
+}
+
+func (m *mongoDB) GetLinkReport(ctx context.Context, datasetID string) (report *models.LinkReport, err error) {
+	ctx, span := startMongoSpan(ctx, "GetLinkReport", attribute.String("dataset_id", datasetID))
+	defer end(span, &err)
+	return m.MongoDB.GetLinkReport(ctx, datasetID)
+}
+
+func (m *mongoDB) GetVersion(ctx context.Context, datasetID, editionID string, version int, state string) (versionDoc *models.Version, err error) {
+	ctx, span := startMongoSpan(ctx, "GetVersion",
+		attribute.String("dataset_id", datasetID),
+		attribute.String("edition", editionID),
+		attribute.Int("version", version),
+	)
+	defer end(span, &err)
+	return m.MongoDB.GetVersion(ctx, datasetID, editionID, version, state)
+}
+
+func (m *mongoDB) GetUniqueDimensionAndOptions(ctx context.Context, ID, dimension string, offset, limit int) (values []*string, totalCount int, err error) {
+	ctx, span := startMongoSpan(ctx, "GetUniqueDimensionAndOptions",
+		attribute.String("instance_id", ID),
+		attribute.String("dimension", dimension),
+	)
+	defer end(span, &err)
+	return m.MongoDB.GetUniqueDimensionAndOptions(ctx, ID, dimension, offset, limit)
+}
+
+func (m *mongoDB) GetVersions(ctx context.Context, datasetID, editionID, state string, offset, limit int) (versions []models.Version, totalCount int, err error) {
+	ctx, span := startMongoSpan(ctx, "GetVersions",
+		attribute.String("dataset_id", datasetID),
+		attribute.String("edition", editionID),
+	)
+	defer end(span, &err)
+	return m.MongoDB.GetVersions(ctx, datasetID, editionID, state, offset, limit)
+}
+
+func (m *mongoDB) GetVersionsInCollections(ctx context.Context) (versions []models.Version, err error) {
+	ctx, span := startMongoSpan(ctx, "GetVersionsInCollections")
+	defer end(span, &err)
+	return m.MongoDB.GetVersionsInCollections(ctx)
+}
+
+func (m *mongoDB) UpdateDataset(ctx context.Context, currentDataset *models.DatasetUpdate, dataset *models.Dataset, eTagSelector string) (newETag string, err error) {
+	ctx, span := startMongoSpan(ctx, "UpdateDataset")
+	defer end(span, &err)
+	return m.MongoDB.UpdateDataset(ctx, currentDataset, dataset, eTagSelector)
+}
+
+func (m *mongoDB) UpdateDatasetWithAssociation(ctx context.Context, currentDataset *models.DatasetUpdate, state string, version *models.Version) (err error) {
+	ctx, span := startMongoSpan(ctx, "UpdateDatasetWithAssociation")
+	defer end(span, &err)
+	return m.MongoDB.UpdateDatasetWithAssociation(ctx, currentDataset, state, version)
+}
+
+func (m *mongoDB) UpdateDimensionNodeIDAndOrder(ctx context.Context, dimension *models.DimensionOption) (err error) {
+	ctx, span := startMongoSpan(ctx, "UpdateDimensionNodeIDAndOrder")
+	defer end(span, &err)
+	return m.MongoDB.UpdateDimensionNodeIDAndOrder(ctx, dimension)
+}
+
+func (m *mongoDB) UpdateInstance(ctx context.Context, currentInstance, updatedInstance *models.Instance, eTagSelector string) (newETag string, err error) {
+	ctx, span := startMongoSpan(ctx, "UpdateInstance")
+	defer end(span, &err)
+	return m.MongoDB.UpdateInstance(ctx, currentInstance, updatedInstance, eTagSelector)
+}
+
+func (m *mongoDB) UpdateObservationInserted(ctx context.Context, currentInstance *models.Instance, observationInserted int64, eTagSelector string) (newETag string, err error) {
+	ctx, span := startMongoSpan(ctx, "UpdateObservationInserted")
+	defer end(span, &err)
+	return m.MongoDB.UpdateObservationInserted(ctx, currentInstance, observationInserted, eTagSelector)
+}
+
+func (m *mongoDB) UpdateImportObservationsTaskState(ctx context.Context, currentInstance *models.Instance, state, eTagSelector string) (newETag string, err error) {
+	ctx, span := startMongoSpan(ctx, "UpdateImportObservationsTaskState")
+	defer end(span, &err)
+	return m.MongoDB.UpdateImportObservationsTaskState(ctx, currentInstance, state, eTagSelector)
+}
+
+func (m *mongoDB) UpdateBuildHierarchyTaskState(ctx context.Context, currentInstance *models.Instance, dimension, state, eTagSelector string) (newETag string, err error) {
+	ctx, span := startMongoSpan(ctx, "UpdateBuildHierarchyTaskState", attribute.String("dimension", dimension))
+	defer end(span, &err)
+	return m.MongoDB.UpdateBuildHierarchyTaskState(ctx, currentInstance, dimension, state, eTagSelector)
+}
+
+func (m *mongoDB) UpdateBuildSearchTaskState(ctx context.Context, currentInstance *models.Instance, dimension, state, eTagSelector string) (newETag string, err error) {
+	ctx, span := startMongoSpan(ctx, "UpdateBuildSearchTaskState", attribute.String("dimension", dimension))
+	defer end(span, &err)
+	return m.MongoDB.UpdateBuildSearchTaskState(ctx, currentInstance, dimension, state, eTagSelector)
+}
+
+func (m *mongoDB) RetryImportTasks(ctx context.Context, currentInstance *models.Instance, state string, tasks *models.InstanceImportTasks, event *models.Event, eTagSelector string) (newETag string, err error) {
+	ctx, span := startMongoSpan(ctx, "RetryImportTasks")
+	defer end(span, &err)
+	return m.MongoDB.RetryImportTasks(ctx, currentInstance, state, tasks, event, eTagSelector)
+}
+
+func (m *mongoDB) UpdateETagForNodeIDAndOrder(ctx context.Context, currentInstance *models.Instance, nodeID string, order *int, eTagSelector string) (newETag string, err error) {
+	ctx, span := startMongoSpan(ctx, "UpdateETagForNodeIDAndOrder")
+	defer end(span, &err)
+	return m.MongoDB.UpdateETagForNodeIDAndOrder(ctx, currentInstance, nodeID, order, eTagSelector)
+}
+
+func (m *mongoDB) UpdateETagForOptions(ctx context.Context, currentInstance *models.Instance, option *models.CachedDimensionOption, eTagSelector string) (newETag string, err error) {
+	ctx, span := startMongoSpan(ctx, "UpdateETagForOptions")
+	defer end(span, &err)
+	return m.MongoDB.UpdateETagForOptions(ctx, currentInstance, option, eTagSelector)
+}
+
+func (m *mongoDB) UpdateVersion(ctx context.Context, currentVersion *models.Version, version *models.Version, eTagSelector string) (newETag string, err error) {
+	ctx, span := startMongoSpan(ctx, "UpdateVersion")
+	defer end(span, &err)
+	return m.MongoDB.UpdateVersion(ctx, currentVersion, version, eTagSelector)
+}
+
+func (m *mongoDB) UpsertContact(ctx context.Context, ID string, update interface{}) (err error) {
+	ctx, span := startMongoSpan(ctx, "UpsertContact")
+	defer end(span, &err)
+	return m.MongoDB.UpsertContact(ctx, ID, update)
+}
+
+func (m *mongoDB) UpsertDataset(ctx context.Context, ID string, datasetDoc *models.DatasetUpdate, eTagSelector string) (err error) {
+	ctx, span := startMongoSpan(ctx, "UpsertDataset", attribute.String("dataset_id", ID))
+	defer end(span, &err)
+	return m.MongoDB.UpsertDataset(ctx, ID, datasetDoc, eTagSelector)
+}
+
+func (m *mongoDB) UpsertEdition(ctx context.Context, datasetID, edition string, editionDoc *models.EditionUpdate, eTagSelector string) (err error) {
+	ctx, span := startMongoSpan(ctx, "UpsertEdition",
+		attribute.String("dataset_id", datasetID),
+		attribute.String("edition", edition),
+	)
+	defer end(span, &err)
+	return m.MongoDB.UpsertEdition(ctx, datasetID, edition, editionDoc, eTagSelector)
+}
+
+func (m *mongoDB) UpsertVersion(ctx context.Context, ID string, versionDoc *models.Version) (err error) {
+	ctx, span := startMongoSpan(ctx, "UpsertVersion")
+	defer end(span, &err)
+	return m.MongoDB.UpsertVersion(ctx, ID, versionDoc)
+}
+
+func (m *mongoDB) UpsertTopic(ctx context.Context, topic *models.Topic) (err error) {
+	ctx, span := startMongoSpan(ctx, "UpsertTopic")
+	defer end(span, &err)
+	return m.MongoDB.UpsertTopic(ctx, topic)
+}
+
+func (m *mongoDB) UpsertLinkReport(ctx context.Context, report *models.LinkReport) (err error) {
+	ctx, span := startMongoSpan(ctx, "UpsertLinkReport")
+	defer end(span, &err)
+	return m.MongoDB.UpsertLinkReport(ctx, report)
+}
+
+func (m *mongoDB) DeleteDataset(ctx context.Context, ID string) (err error) {
+	ctx, span := startMongoSpan(ctx, "DeleteDataset", attribute.String("dataset_id", ID))
+	defer end(span, &err)
+	return m.MongoDB.DeleteDataset(ctx, ID)
+}
+
+func (m *mongoDB) DeleteEdition(ctx context.Context, ID string) (err error) {
+	ctx, span := startMongoSpan(ctx, "DeleteEdition")
+	defer end(span, &err)
+	return m.MongoDB.DeleteEdition(ctx, ID)
+}
+
+func (m *mongoDB) DeleteTopic(ctx context.Context, id string) (err error) {
+	ctx, span := startMongoSpan(ctx, "DeleteTopic")
+	defer end(span, &err)
+	return m.MongoDB.DeleteTopic(ctx, id)
+}
+
+func (m *mongoDB) DeleteLinkReportsExcept(ctx context.Context, datasetIDs []string) (err error) {
+	ctx, span := startMongoSpan(ctx, "DeleteLinkReportsExcept")
+	defer end(span, &err)
+	return m.MongoDB.DeleteLinkReportsExcept(ctx, datasetIDs)
+}
+
+func (m *mongoDB) GetDatasetInstances(ctx context.Context, datasetID string) (instances []*models.Instance, err error) {
+	ctx, span := startMongoSpan(ctx, "GetDatasetInstances", attribute.String("dataset_id", datasetID))
+	defer end(span, &err)
+	return m.MongoDB.GetDatasetInstances(ctx, datasetID)
+}
+
+func (m *mongoDB) DeleteInstance(ctx context.Context, instanceID string) (err error) {
+	ctx, span := startMongoSpan(ctx, "DeleteInstance", attribute.String("instance_id", instanceID))
+	defer end(span, &err)
+	return m.MongoDB.DeleteInstance(ctx, instanceID)
+}
+
+func (m *mongoDB) CountDimensionOptions(ctx context.Context, instanceID string) (count int, err error) {
+	ctx, span := startMongoSpan(ctx, "CountDimensionOptions", attribute.String("instance_id", instanceID))
+	defer end(span, &err)
+	return m.MongoDB.CountDimensionOptions(ctx, instanceID)
+}
+
+func (m *mongoDB) DeleteDimensionOptions(ctx context.Context, instanceID string) (deleted int, err error) {
+	ctx, span := startMongoSpan(ctx, "DeleteDimensionOptions", attribute.String("instance_id", instanceID))
+	defer end(span, &err)
+	return m.MongoDB.DeleteDimensionOptions(ctx, instanceID)
+}
+
+func (m *mongoDB) AddDeleteJob(ctx context.Context, job *models.DeleteJob) (err error) {
+	ctx, span := startMongoSpan(ctx, "AddDeleteJob")
+	defer end(span, &err)
+	return m.MongoDB.AddDeleteJob(ctx, job)
+}
+
+func (m *mongoDB) GetDeleteJob(ctx context.Context, jobID string) (job *models.DeleteJob, err error) {
+	ctx, span := startMongoSpan(ctx, "GetDeleteJob")
+	defer end(span, &err)
+	return m.MongoDB.GetDeleteJob(ctx, jobID)
+}
+
+func (m *mongoDB) UpdateDeleteJob(ctx context.Context, job *models.DeleteJob) (err error) {
+	ctx, span := startMongoSpan(ctx, "UpdateDeleteJob")
+	defer end(span, &err)
+	return m.MongoDB.UpdateDeleteJob(ctx, job)
+}
+
+func (m *mongoDB) GetDeletedDataset(ctx context.Context, id string) (dataset *models.DatasetUpdate, err error) {
+	ctx, span := startMongoSpan(ctx, "GetDeletedDataset")
+	defer end(span, &err)
+	return m.MongoDB.GetDeletedDataset(ctx, id)
+}
+
+func (m *mongoDB) GetDatasetsDeletedBefore(ctx context.Context, before time.Time) (ids []string, err error) {
+	ctx, span := startMongoSpan(ctx, "GetDatasetsDeletedBefore")
+	defer end(span, &err)
+	return m.MongoDB.GetDatasetsDeletedBefore(ctx, before)
+}
+
+func (m *mongoDB) GetDatasetEditions(ctx context.Context, datasetID string) (editions []*models.EditionUpdate, err error) {
+	ctx, span := startMongoSpan(ctx, "GetDatasetEditions", attribute.String("dataset_id", datasetID))
+	defer end(span, &err)
+	return m.MongoDB.GetDatasetEditions(ctx, datasetID)
+}
+
+func (m *mongoDB) SoftDeleteDataset(ctx context.Context, datasetID, eTagSelector, deletedBy string, deletedAt time.Time) (err error) {
+	ctx, span := startMongoSpan(ctx, "SoftDeleteDataset", attribute.String("dataset_id", datasetID))
+	defer end(span, &err)
+	return m.MongoDB.SoftDeleteDataset(ctx, datasetID, eTagSelector, deletedBy, deletedAt)
+}
+
+func (m *mongoDB) RestoreDataset(ctx context.Context, datasetID string) (err error) {
+	ctx, span := startMongoSpan(ctx, "RestoreDataset", attribute.String("dataset_id", datasetID))
+	defer end(span, &err)
+	return m.MongoDB.RestoreDataset(ctx, datasetID)
+}
+
+func (m *mongoDB) SoftDeleteEdition(ctx context.Context, datasetID, edition, eTagSelector, deletedBy string, deletedAt time.Time) (err error) {
+	ctx, span := startMongoSpan(ctx, "SoftDeleteEdition",
+		attribute.String("dataset_id", datasetID),
+		attribute.String("edition", edition),
+	)
+	defer end(span, &err)
+	return m.MongoDB.SoftDeleteEdition(ctx, datasetID, edition, eTagSelector, deletedBy, deletedAt)
+}
+
+func (m *mongoDB) GetEditionsDeletedBefore(ctx context.Context, before time.Time) (editions []*models.EditionUpdate, err error) {
+	ctx, span := startMongoSpan(ctx, "GetEditionsDeletedBefore")
+	defer end(span, &err)
+	return m.MongoDB.GetEditionsDeletedBefore(ctx, before)
+}
+
+func (m *mongoDB) LockPurge(ctx context.Context, ttl time.Duration) (lockID string, err error) {
+	ctx, span := startMongoSpan(ctx, "LockPurge")
+	defer end(span, &err)
+	return m.MongoDB.LockPurge(ctx, ttl)
+}
+
+func (m *mongoDB) RenewPurgeLock(ctx context.Context, lockID string, ttl time.Duration) (err error) {
+	ctx, span := startMongoSpan(ctx, "RenewPurgeLock")
+	defer end(span, &err)
+	return m.MongoDB.RenewPurgeLock(ctx, lockID, ttl)
+}
+
+func (m *mongoDB) UnlockPurge(ctx context.Context, lockID string) (err error) {
+	ctx, span := startMongoSpan(ctx, "UnlockPurge")
+	defer end(span, &err)
+	return m.MongoDB.UnlockPurge(ctx, lockID)
+}
+
+func (m *mongoDB) LockLinkCheck(ctx context.Context, ttl time.Duration) (lockID string, err error) {
+	ctx, span := startMongoSpan(ctx, "LockLinkCheck")
+	defer end(span, &err)
+	return m.MongoDB.LockLinkCheck(ctx, ttl)
+}
+
+func (m *mongoDB) RenewLinkCheckLock(ctx context.Context, lockID string, ttl time.Duration) (err error) {
+	ctx, span := startMongoSpan(ctx, "RenewLinkCheckLock")
+	defer end(span, &err)
+	return m.MongoDB.RenewLinkCheckLock(ctx, lockID, ttl)
+}
+
+func (m *mongoDB) UnlockLinkCheck(ctx context.Context, lockID string) (err error) {
+	ctx, span := startMongoSpan(ctx, "UnlockLinkCheck")
+	defer end(span, &err)
+	return m.MongoDB.UnlockLinkCheck(ctx, lockID)
+}
+
+func (m *mongoDB) ClearLatestEditions(ctx context.Context, datasetID, edition string) (err error) {
+	ctx, span := startMongoSpan(ctx, "ClearLatestEditions",
+		attribute.String("dataset_id", datasetID),
+		attribute.String("edition", edition),
+	)
+	defer end(span, &err)
+	return m.MongoDB.ClearLatestEditions(ctx, datasetID, edition)
+}
+
+func (m *mongoDB) AcquireInstanceLock(ctx context.Context, instanceID string) (lockID string, err error) {
+	ctx, span := startMongoSpan(ctx, "AcquireInstanceLock", attribute.String("instance_id", instanceID))
+	defer func(start time.Time) {
+		span.SetAttributes(attribute.Int64("lock_wait_ms", time.Since(start).Milliseconds()))
+		end(span, &err)
+	}(time.Now())
+	return m.MongoDB.AcquireInstanceLock(ctx, instanceID)
+}
+
+func (m *mongoDB) UnlockInstance(ctx context.Context, lockID string) (err error) {
+	ctx, span := startMongoSpan(ctx, "UnlockInstance")
+	defer end(span, &err)
+	return m.MongoDB.UnlockInstance(ctx, lockID)
+}
+
+// graphDB records a span for each of the store methods of the embedded graph store
+type graphDB struct {
+	store.GraphDB
+}
+
+func (g *graphDB) AddVersionDetailsToInstance(ctx context.Context, instanceID string, datasetID string, edition string, version int) (err error) {
+	ctx, span := startGraphSpan(ctx, "AddVersionDetailsToInstance",
+		attribute.String("instance_id", instanceID),
+		attribute.String("dataset_id", datasetID),
+		attribute.String("edition", edition),
+		attribute.Int("version", version),
+	)
+	defer end(span, &err)
+	return g.GraphDB.AddVersionDetailsToInstance(ctx, instanceID, datasetID, edition, version)
+}
+
+func (g *graphDB) SetInstanceIsPublished(ctx context.Context, instanceID string) (err error) {
+	ctx, span := startGraphSpan(ctx, "SetInstanceIsPublished", attribute.String("instance_id", instanceID))
+	defer end(span, &err)
+	return g.GraphDB.SetInstanceIsPublished(ctx, instanceID)
+}
+
+func (g *graphDB) CountInstanceNodes(ctx context.Context, instanceID string) (count int64, err error) {
+	ctx, span := startGraphSpan(ctx, "CountInstanceNodes", attribute.String("instance_id", instanceID))
+	defer end(span, &err)
+	return g.GraphDB.CountInstanceNodes(ctx, instanceID)
+}
+
+func (g *graphDB) DeleteInstanceNodes(ctx context.Context, instanceID string) (deleted int64, err error) {
+	ctx, span := startGraphSpan(ctx, "DeleteInstanceNodes", attribute.String("instance_id", instanceID))
+	defer end(span, &err)
+	return g.GraphDB.DeleteInstanceNodes(ctx, instanceID)
+}
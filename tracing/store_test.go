@@ -0,0 +1,125 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	storetest "github.com/ONSdigital/dp-dataset-api/store/datastoretest"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestInstrumentMongoDB(t *testing.T) {
+	Convey("Given an instrumented mongo store", t, func() {
+		exporter := useInMemoryExporter()
+
+		var getVersionErr error
+		mongoMock := &storetest.MongoDBMock{
+			GetVersionFunc: func(ctx context.Context, datasetID, editionID string, version int, state string) (*models.Version, error) {
+				if getVersionErr != nil {
+					return nil, getVersionErr
+				}
+				return &models.Version{Version: version}, nil
+			},
+			AcquireInstanceLockFunc: func(ctx context.Context, instanceID string) (string, error) {
+				return "lock", nil
+			},
+		}
+		db := InstrumentMongoDB(mongoMock)
+
+		Convey("When a store method succeeds", func() {
+			ctx, parent := StartSpan(context.Background(), "parent")
+			version, err := db.GetVersion(ctx, "cpih01", "time-series", 2, "")
+			parent.End()
+
+			Convey("Then the result of the embedded store is returned", func() {
+				So(err, ShouldBeNil)
+				So(version.Version, ShouldEqual, 2)
+			})
+
+			Convey("And a span is recorded as a child of the span in the context, with the IDs of the resource", func() {
+				spans := exporter.GetSpans()
+				So(spans, ShouldHaveLength, 2)
+				So(spans[0].Name, ShouldEqual, "mongo.GetVersion")
+				So(spans[0].Parent.SpanID(), ShouldEqual, spans[1].SpanContext.SpanID())
+				So(spans[0].Attributes, ShouldContain, attribute.String("dataset_id", "cpih01"))
+				So(spans[0].Attributes, ShouldContain, attribute.String("edition", "time-series"))
+				So(spans[0].Attributes, ShouldContain, attribute.Int("version", 2))
+				So(spans[0].Status.Code, ShouldEqual, codes.Unset)
+			})
+		})
+
+		Convey("When a store method fails", func() {
+			getVersionErr = errors.New("connection reset")
+			_, err := db.GetVersion(context.Background(), "cpih01", "time-series", 2, "")
+
+			Convey("Then the error is returned and recorded on the span", func() {
+				So(err, ShouldEqual, getVersionErr)
+				spans := exporter.GetSpans()
+				So(spans, ShouldHaveLength, 1)
+				So(spans[0].Status.Code, ShouldEqual, codes.Error)
+				So(spans[0].Status.Description, ShouldEqual, "connection reset")
+			})
+		})
+
+		Convey("When a store method does not find the resource", func() {
+			getVersionErr = errs.ErrVersionNotFound
+			_, err := db.GetVersion(context.Background(), "cpih01", "time-series", 2, "")
+
+			Convey("Then the error is returned without the span failing", func() {
+				So(err, ShouldEqual, errs.ErrVersionNotFound)
+				spans := exporter.GetSpans()
+				So(spans, ShouldHaveLength, 1)
+				So(spans[0].Status.Code, ShouldEqual, codes.Unset)
+				So(spans[0].Events, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the lock of an instance is acquired", func() {
+			lockID, err := db.AcquireInstanceLock(context.Background(), "inst1")
+
+			Convey("Then the time waited for the lock is recorded on the span", func() {
+				So(err, ShouldBeNil)
+				So(lockID, ShouldEqual, "lock")
+				spans := exporter.GetSpans()
+				So(spans, ShouldHaveLength, 1)
+				So(spans[0].Attributes, ShouldContain, attribute.String("instance_id", "inst1"))
+				keys := []attribute.Key{}
+				for _, a := range spans[0].Attributes {
+					keys = append(keys, a.Key)
+				}
+				So(keys, ShouldContain, attribute.Key("lock_wait_ms"))
+			})
+		})
+	})
+
+	Convey("A nil mongo store is left nil", t, func() {
+		So(InstrumentMongoDB(nil), ShouldBeNil)
+	})
+}
+
+func TestInstrumentGraphDB(t *testing.T) {
+	Convey("Given an instrumented graph store, when a store method is called", t, func() {
+		exporter := useInMemoryExporter()
+		graphMock := &storetest.GraphDBMock{
+			SetInstanceIsPublishedFunc: func(ctx context.Context, instanceID string) error {
+				return nil
+			},
+		}
+
+		err := InstrumentGraphDB(graphMock).SetInstanceIsPublished(context.Background(), "inst1")
+
+		Convey("Then a span is recorded for the call", func() {
+			So(err, ShouldBeNil)
+			So(graphMock.SetInstanceIsPublishedCalls(), ShouldHaveLength, 1)
+			spans := exporter.GetSpans()
+			So(spans, ShouldHaveLength, 1)
+			So(spans[0].Name, ShouldEqual, "graph.SetInstanceIsPublished")
+			So(spans[0].Attributes, ShouldContain, attribute.String("instance_id", "inst1"))
+		})
+	})
+}
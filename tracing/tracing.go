@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ONSdigital/dp-dataset-api/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName is the name of the service the spans of the dataset API are exported for
+const ServiceName = "dp-dataset-api"
+
+const instrumentationName = "github.com/ONSdigital/dp-dataset-api"
+
+// traceParentKey is the key of the trace context propagated in http headers and kafka messages
+const traceParentKey = "traceparent"
+
+// The exporters the spans can be sent to
+const (
+	// ExporterNone records no spans, although trace context is still propagated to the services called
+	ExporterNone = "none"
+	// ExporterOTLP sends the spans to an OpenTelemetry collector, using OTLP over http
+	ExporterOTLP = "otlp"
+)
+
+// exportTimeout is the longest a batch of spans can take to reach the collector
+const exportTimeout = 10 * time.Second
+
+// Tracer returns the tracer the spans of the dataset API are started with
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Init sets the global tracer provider to one sending spans to the configured exporter, and the global propagator to
+// W3C trace context. The provider is returned so that the spans it holds can be flushed when the service shuts down,
+// or nil where no spans are exported.
+func Init(cfg config.TracingConfig) (*sdktrace.TracerProvider, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	switch cfg.Exporter {
+	case ExporterNone, "":
+		return nil, nil
+	case ExporterOTLP:
+		exporter := NewOTLPExporter(cfg.OTLPEndpoint, &http.Client{Timeout: exportTimeout})
+		provider := NewTracerProvider(exporter, sdktrace.TraceIDRatioBased(cfg.SampleRatio))
+		otel.SetTracerProvider(provider)
+		return provider, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q, expected %q or %q", cfg.Exporter, ExporterNone, ExporterOTLP)
+	}
+}
+
+// NewTracerProvider creates a tracer provider which sends batches of the sampled spans to the exporter. Spans
+// continuing a trace started by the caller are sampled if the caller sampled its span.
+func NewTracerProvider(exporter sdktrace.SpanExporter, sampler sdktrace.Sampler) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(ServiceName))),
+	)
+}
+
+// StartSpan starts a span as a child of any span in the context
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End records the error on the span, if there is one, before ending the span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceID returns the ID of the trace of the span in the context, or an empty string where there is no span
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// StartProducerSpan starts a span for sending a kafka message. The kafka producer only sends the value of messages, so
+// the trace context is carried in the traceparent field of the message, as returned by TraceParent.
+func StartProducerSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	attributes = append(attributes, semconv.MessagingSystemKey.String("kafka"), semconv.MessagingDestinationKindTopic)
+	return Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindProducer), trace.WithAttributes(attributes...))
+}
+
+// StartConsumerSpan starts a span for handling a kafka message, continuing the trace of the traceparent field of the
+// message, where it has one
+func StartConsumerSpan(ctx context.Context, name, traceParent string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier{traceParentKey: traceParent})
+	attributes = append(attributes, semconv.MessagingSystemKey.String("kafka"), semconv.MessagingDestinationKindTopic)
+	return Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindConsumer), trace.WithAttributes(attributes...))
+}
+
+// TraceParent returns the W3C traceparent of the span in the context, for the messages sent to kafka, or an empty
+// string where there is no span
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier.Get(traceParentKey)
+}
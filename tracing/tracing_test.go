@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/config"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// useInMemoryExporter sets the global tracer provider to one which records every span, as it ends, in the exporter
+// returned
+func useInMemoryExporter() *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	return exporter
+}
+
+func TestInit(t *testing.T) {
+	Convey("When no spans are exported, then no tracer provider is returned", t, func() {
+		provider, err := Init(config.TracingConfig{Exporter: ExporterNone})
+		So(err, ShouldBeNil)
+		So(provider, ShouldBeNil)
+	})
+
+	Convey("When spans are exported to a collector, then the tracer provider is returned to be shut down", t, func() {
+		provider, err := Init(config.TracingConfig{Exporter: ExporterOTLP, OTLPEndpoint: "http://localhost:4318", SampleRatio: 1})
+		So(err, ShouldBeNil)
+		So(provider, ShouldNotBeNil)
+		So(provider.Shutdown(context.Background()), ShouldBeNil)
+	})
+
+	Convey("When the exporter is unknown, then an error is returned", t, func() {
+		provider, err := Init(config.TracingConfig{Exporter: "zipkin"})
+		So(err, ShouldNotBeNil)
+		So(provider, ShouldBeNil)
+	})
+}
+
+func TestTraceParent(t *testing.T) {
+	Convey("Given a producer span", t, func() {
+		exporter := useInMemoryExporter()
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		ctx, producer := StartProducerSpan(context.Background(), "events.Emit")
+
+		Convey("When a consumer span is started from the traceparent of the message sent", func() {
+			traceParent := TraceParent(ctx)
+			_, consumer := StartConsumerSpan(context.Background(), "cache.Invalidate", traceParent)
+			consumer.End()
+			producer.End()
+
+			Convey("Then the consumer span continues the trace of the producer span", func() {
+				So(traceParent, ShouldStartWith, "00-"+TraceID(ctx))
+				spans := exporter.GetSpans()
+				So(spans, ShouldHaveLength, 2)
+				So(spans[0].SpanKind, ShouldEqual, trace.SpanKindConsumer)
+				So(spans[0].Parent.SpanID(), ShouldEqual, spans[1].SpanContext.SpanID())
+				So(spans[0].SpanContext.TraceID(), ShouldEqual, spans[1].SpanContext.TraceID())
+			})
+		})
+	})
+
+	Convey("Given a context without a span, then the traceparent is empty and the consumer span starts a trace", t, func() {
+		useInMemoryExporter()
+		So(TraceParent(context.Background()), ShouldBeEmpty)
+		ctx, span := StartConsumerSpan(context.Background(), "cache.Invalidate", "")
+		defer span.End()
+		So(TraceID(ctx), ShouldNotBeEmpty)
+	})
+}
@@ -0,0 +1,71 @@
+package url
+
+import (
+	"net/http"
+	neturl "net/url"
+	"strings"
+)
+
+const (
+	forwardedHostHeader  = "X-Forwarded-Host"
+	forwardedProtoHeader = "X-Forwarded-Proto"
+)
+
+// BaseURL returns the url the links to the api are rendered against in the response to the request. This is the
+// configured url of the api, unless the request was forwarded by a proxy setting X-Forwarded-Host to one of the
+// forwarded hosts, in which case it is the scheme and host the request was originally made to. Hosts that are not
+// listed are ignored, so that a client cannot have links rendered against a host of its choosing.
+func BaseURL(r *http.Request, apiURL string, forwardedHosts []string) string {
+	apiURL = strings.TrimSuffix(apiURL, "/")
+
+	host := firstHeaderValue(r, forwardedHostHeader)
+	if host == "" || !contains(forwardedHosts, host) {
+		return apiURL
+	}
+	if u, err := neturl.Parse("//" + host); err != nil || u.Host != host {
+		return apiURL
+	}
+
+	scheme := firstHeaderValue(r, forwardedProtoHeader)
+	if scheme != "http" && scheme != "https" {
+		scheme = "http"
+		if u, err := neturl.Parse(apiURL); err == nil && u.Scheme != "" {
+			scheme = u.Scheme
+		}
+	}
+
+	return scheme + "://" + host
+}
+
+func contains(hosts []string, host string) bool {
+	for _, h := range hosts {
+		if strings.EqualFold(h, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstHeaderValue returns the first of the comma separated values of the header, which was set by the proxy
+// closest to the client
+func firstHeaderValue(r *http.Request, header string) string {
+	return strings.TrimSpace(strings.Split(r.Header.Get(header), ",")[0])
+}
+
+// RelativeLink returns the path of the href if it links to the api at one of the base urls, so that it can be stored
+// independently of the url the api is served from. Other hrefs are returned unchanged.
+func RelativeLink(href string, baseURLs ...string) string {
+	for _, baseURL := range baseURLs {
+		baseURL = strings.TrimSuffix(baseURL, "/")
+		if baseURL == "" {
+			continue
+		}
+		if href == baseURL {
+			return "/"
+		}
+		if strings.HasPrefix(href, baseURL+"/") {
+			return strings.TrimPrefix(href, baseURL)
+		}
+	}
+	return href
+}
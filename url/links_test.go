@@ -0,0 +1,68 @@
+package url_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-dataset-api/url"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const apiURL = "http://localhost:22000"
+
+var forwardedHosts = []string{"api.example.com"}
+
+func TestBaseURL(t *testing.T) {
+
+	Convey("Given a request made directly to the api", t, func() {
+		r := httptest.NewRequest("GET", "http://localhost:22000/datasets", nil)
+
+		Convey("Then the configured url is the base url", func() {
+			So(url.BaseURL(r, apiURL+"/", forwardedHosts), ShouldEqual, apiURL)
+		})
+	})
+
+	Convey("Given a request forwarded by proxies", t, func() {
+		r := httptest.NewRequest("GET", "http://localhost:22000/datasets", nil)
+		r.Header.Set("X-Forwarded-Host", "api.example.com, router.internal")
+		r.Header.Set("X-Forwarded-Proto", "https, http")
+
+		Convey("Then the scheme and host the request was originally made to is the base url", func() {
+			So(url.BaseURL(r, apiURL, forwardedHosts), ShouldEqual, "https://api.example.com")
+		})
+
+		Convey("Then the configured url is the base url if forwarded hosts are not used", func() {
+			So(url.BaseURL(r, apiURL, nil), ShouldEqual, apiURL)
+		})
+
+		Convey("Then the configured url is the base url if the forwarded host is not one of the forwarded hosts", func() {
+			r.Header.Set("X-Forwarded-Host", "attacker.example.com")
+			So(url.BaseURL(r, apiURL, forwardedHosts), ShouldEqual, apiURL)
+		})
+
+		Convey("Then the scheme of the configured url is used if the forwarded scheme is missing", func() {
+			r.Header.Del("X-Forwarded-Proto")
+			So(url.BaseURL(r, "https://localhost:22000", forwardedHosts), ShouldEqual, "https://api.example.com")
+		})
+
+		Convey("Then an invalid forwarded host is ignored", func() {
+			r.Header.Set("X-Forwarded-Host", `api.example.com/"`)
+			So(url.BaseURL(r, apiURL, []string{`api.example.com/"`}), ShouldEqual, apiURL)
+		})
+	})
+}
+
+func TestRelativeLink(t *testing.T) {
+
+	Convey("Links to the api at any of the base urls are made relative", t, func() {
+		So(url.RelativeLink("http://localhost:22000/datasets/123", "https://api.example.com", apiURL+"/"), ShouldEqual, "/datasets/123")
+		So(url.RelativeLink("http://localhost:22000", apiURL), ShouldEqual, "/")
+		So(url.RelativeLink("/datasets/123", apiURL), ShouldEqual, "/datasets/123")
+	})
+
+	Convey("Links outside the api are left unchanged", t, func() {
+		So(url.RelativeLink("http://localhost:22400/code-lists/123", apiURL), ShouldEqual, "http://localhost:22400/code-lists/123")
+		So(url.RelativeLink("http://localhost:220001/datasets", apiURL), ShouldEqual, "http://localhost:220001/datasets")
+		So(url.RelativeLink("http://localhost:22000/datasets", ""), ShouldEqual, "http://localhost:22000/datasets")
+	})
+}
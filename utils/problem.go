@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	dprequest "github.com/ONSdigital/dp-net/request"
+	"github.com/ONSdigital/log.go/log"
+)
+
+// ProblemContentType is the content type of the body of unsuccessful requests
+const ProblemContentType = "application/problem+json"
+
+type contextKey string
+
+const plainTextErrorsKey = contextKey("plain-text-errors")
+
+// HandlerErrorFormat is a middleware which negotiates the format errors are returned in from the Accept header of the
+// request. Errors are returned as application/problem+json, unless the caller prefers the deprecated text/plain
+// messages, which will be removed in the next release.
+func HandlerErrorFormat(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if prefersPlainText(r.Header.Get("Accept")) {
+			r = r.WithContext(context.WithValue(r.Context(), plainTextErrorsKey, true))
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// prefersPlainText returns whether the accepted media types of a request rank text/plain above json, the most
+// specific range matching each type deciding its quality
+func prefersPlainText(accept string) bool {
+	if accept == "" {
+		return false
+	}
+
+	var jsonQuality, jsonSpecificity, textQuality, textSpecificity float64
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+
+		var specificity float64
+		switch mediaType {
+		case "*/*":
+			specificity = 1
+		case "application/*", "text/*":
+			specificity = 2
+		case ProblemContentType, "application/json", "text/plain":
+			specificity = 3
+		default:
+			continue
+		}
+
+		if mediaType != "text/*" && mediaType != "text/plain" && specificity > jsonSpecificity {
+			jsonQuality, jsonSpecificity = quality, specificity
+		}
+		if !strings.HasPrefix(mediaType, "application/") && specificity > textSpecificity {
+			textQuality, textSpecificity = quality, specificity
+		}
+	}
+
+	return textQuality > jsonQuality
+}
+
+// NewProblem returns the problem describing the error, with field errors describing which fields of the request caused
+// it. The code of the problem is the code of the error, or the status if the error does not have one.
+func NewProblem(ctx context.Context, err error, status int, fieldErrors ...models.FieldError) *models.Problem {
+	code := errs.Code(err)
+	if code == "" {
+		code = strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+	}
+
+	var fieldsErr *models.FieldsError
+	if len(fieldErrors) == 0 && errors.As(err, &fieldsErr) {
+		fieldErrors = fieldsErr.FieldErrors()
+	}
+
+	return &models.Problem{
+		Title:     http.StatusText(status),
+		Status:    status,
+		Code:      code,
+		Detail:    err.Error(),
+		RequestID: dprequest.GetRequestId(ctx),
+		Errors:    fieldErrors,
+	}
+}
+
+// WriteError writes the error as the response to a request, with field errors describing which fields of the request
+// caused it. The error is written as application/problem+json with a code identifying it, unless the caller prefers
+// the deprecated text/plain message.
+func WriteError(ctx context.Context, w http.ResponseWriter, err error, status int, fieldErrors ...models.FieldError) {
+	if plainText, _ := ctx.Value(plainTextErrorsKey).(bool); plainText {
+		w.Header().Set("Deprecation", "true")
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	b, marshalErr := json.Marshal(NewProblem(ctx, err, status, fieldErrors...))
+	if marshalErr != nil {
+		log.Event(ctx, "failed to marshal problem into bytes", log.ERROR, log.Error(marshalErr))
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	if _, err = w.Write(b); err != nil {
+		log.Event(ctx, "failed to write problem to response", log.ERROR, log.Error(err))
+	}
+}
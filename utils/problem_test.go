@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/ONSdigital/dp-dataset-api/apierrors"
+	"github.com/ONSdigital/dp-dataset-api/models"
+	dprequest "github.com/ONSdigital/dp-net/request"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWriteError(t *testing.T) {
+	Convey("Given the context of a request with an id", t, func() {
+		ctx := dprequest.WithRequestId(context.Background(), "123")
+		w := httptest.NewRecorder()
+
+		Convey("When an error with a code is written", func() {
+			WriteError(ctx, w, errs.ErrDatasetNotFound, http.StatusNotFound)
+
+			Convey("Then a problem with the code of the error and the id of the request is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Header().Get("Content-Type"), ShouldEqual, ProblemContentType)
+				So(w.Body.String(), ShouldEqual, `{"title":"Not Found","status":404,"code":"dataset_not_found","detail":"dataset not found","request_id":"123"}`)
+			})
+		})
+
+		Convey("When an error without a code is written", func() {
+			WriteError(ctx, w, errors.New("unable to update version as it has been published"), http.StatusForbidden)
+
+			Convey("Then the code of the problem is the status of the response", func() {
+				So(w.Body.String(), ShouldEqual, `{"title":"Forbidden","status":403,"code":"forbidden","detail":"unable to update version as it has been published","request_id":"123"}`)
+			})
+		})
+
+		Convey("When an error with missing fields is written", func() {
+			err := &models.FieldsError{Reason: "missing mandatory fields", Fields: []string{"release_date", "state"}, Missing: true}
+			WriteError(ctx, w, err, http.StatusBadRequest)
+
+			Convey("Then each missing field is returned in the errors of the problem", func() {
+				So(w.Body.String(), ShouldEqual, `{"title":"Bad Request","status":400,"code":"missing_fields","detail":"missing mandatory fields: [release_date state]","request_id":"123",`+
+					`"errors":[{"field":"release_date","message":"missing mandatory fields"},{"field":"state","message":"missing mandatory fields"}]}`)
+			})
+		})
+
+		Convey("When the caller prefers text errors", func() {
+			r := httptest.NewRequest("GET", "/datasets/123", nil)
+			r.Header.Set("Accept", "text/plain")
+
+			HandlerErrorFormat(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				WriteError(r.Context(), w, errs.ErrDatasetNotFound, http.StatusNotFound)
+			})).ServeHTTP(w, r)
+
+			Convey("Then the deprecated message of the error is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "text/plain; charset=utf-8")
+				So(w.Header().Get("Deprecation"), ShouldEqual, "true")
+				So(w.Body.String(), ShouldEqual, "dataset not found\n")
+			})
+		})
+	})
+}
+
+func TestPrefersPlainText(t *testing.T) {
+	Convey("Text errors are only returned when the caller ranks them above json", t, func() {
+		So(prefersPlainText(""), ShouldBeFalse)
+		So(prefersPlainText("*/*"), ShouldBeFalse)
+		So(prefersPlainText("application/json"), ShouldBeFalse)
+		So(prefersPlainText("application/json, text/plain"), ShouldBeFalse)
+		So(prefersPlainText("text/html"), ShouldBeFalse)
+		So(prefersPlainText("text/plain"), ShouldBeTrue)
+		So(prefersPlainText("text/*"), ShouldBeTrue)
+		So(prefersPlainText("application/json;q=0.5, text/plain"), ShouldBeTrue)
+		So(prefersPlainText("text/plain, */*;q=0.1"), ShouldBeTrue)
+		So(prefersPlainText("text/plain;q=0.5, application/problem+json"), ShouldBeFalse)
+	})
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// UnmatchedRoute is the route template of requests which do not match any route of a router, so that the paths
+// requested can not create an unbounded number of metric series or span names
+const UnmatchedRoute = "unmatched"
+
+// RouteTemplate returns the path template of the route of the router which matches the request, e.g.
+// /datasets/{dataset_id}, for middleware which runs before the router has matched the request
+func RouteTemplate(router *mux.Router, r *http.Request) string {
+	var match mux.RouteMatch
+	if !router.Match(r, &match) || match.Route == nil {
+		return UnmatchedRoute
+	}
+
+	template, err := match.Route.GetPathTemplate()
+	if err != nil {
+		return UnmatchedRoute
+	}
+	return template
+}
+
+// StatusRecorder keeps the status code written by a handler, for middleware which reports on the responses
+type StatusRecorder struct {
+	http.ResponseWriter
+	Status int
+}
+
+// NewStatusRecorder wraps the response writer, with the status code defaulting to the 200 written by handlers which
+// never call WriteHeader
+func NewStatusRecorder(w http.ResponseWriter) *StatusRecorder {
+	return &StatusRecorder{ResponseWriter: w, Status: http.StatusOK}
+}
+
+// WriteHeader records the status code before writing it
+func (s *StatusRecorder) WriteHeader(status int) {
+	s.Status = status
+	s.ResponseWriter.WriteHeader(status)
+}
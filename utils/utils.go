@@ -67,3 +67,16 @@ func SliceStr(full []*string, offset, limit int) (sliced []*string) {
 	}
 	return full[offset:end]
 }
+
+// utility function to cut a slice of versions according to the provided offset and limit.
+func SliceVersions(full []models.Version, offset, limit int) (sliced []models.Version) {
+	end := offset + limit
+	if end > len(full) {
+		end = len(full)
+	}
+
+	if offset > len(full) {
+		return []models.Version{}
+	}
+	return full[offset:end]
+}
@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ONSdigital/dp-dataset-api/models"
+)
+
+// miscellaneousPersistentWarning is the warn-code of a Warning that is not about caching
+const miscellaneousPersistentWarning = 299
+
+// AddValidationWarnings adds a Warning header for each of the issues, so that callers are told of the problems with a
+// dimension that did not stop it from being stored. The header must be added before the response is written.
+func AddValidationWarnings(w http.ResponseWriter, issues []models.DimensionValidationIssue) {
+	for _, issue := range issues {
+		w.Header().Add("Warning", fmt.Sprintf("%d - %q", miscellaneousPersistentWarning, issue.Message))
+	}
+}